@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/ycho/redmine-mcp-server/internal/api"
+	"github.com/ycho/redmine-mcp-server/internal/config"
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
 	"github.com/ycho/redmine-mcp-server/internal/mcp"
 	"github.com/ycho/redmine-mcp-server/internal/redmine"
+	"github.com/ycho/redmine-mcp-server/internal/watch"
 )
 
 var (
@@ -21,6 +30,17 @@ var (
 	logLevel             string
 	customFieldRulesFile string
 	workflowRulesFile    string
+	workflowSource       string
+	rateLimit            string
+	shutdownTimeout      time.Duration
+	maxAttachmentMB      int
+	lang                 string
+	skipSelfTest         bool
+	configFile           string
+	redmineTimeout       time.Duration
+	caFile               string
+	insecureSkipVerify   bool
+	proxyURL             string
 )
 
 func main() {
@@ -28,17 +48,33 @@ func main() {
 		Use:     "redmine-mcp-server",
 		Short:   "Redmine MCP Server - AI assistant integration for Redmine",
 		Version: version,
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := loadConfigFile(cmd); err != nil {
+				return err
+			}
 			setupLogging()
+			return setupLanguage()
 		},
 	}
 
 	// Global flags
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", os.Getenv("REDMINE_MCP_CONFIG"), "Path to a YAML config file; flags and environment variables override its values")
 	rootCmd.PersistentFlags().StringVar(&redmineURL, "redmine-url", os.Getenv("REDMINE_URL"), "Redmine server URL")
 	rootCmd.PersistentFlags().IntVar(&port, "port", 8080, "Server port (for SSE, Streamable HTTP, and API modes)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log level (debug, info, warn, error)")
 	rootCmd.PersistentFlags().StringVar(&customFieldRulesFile, "custom-field-rules", os.Getenv("CUSTOM_FIELD_RULES_FILE"), "Path to custom field validation rules JSON file")
 	rootCmd.PersistentFlags().StringVar(&workflowRulesFile, "workflow-rules", os.Getenv("WORKFLOW_RULES_FILE"), "Path to workflow transition rules JSON file")
+	rootCmd.PersistentFlags().StringVar(&workflowSource, "workflow-source", os.Getenv("WORKFLOW_SOURCE"), "Workflow transition source: file (default), server (allowed_statuses from Redmine), hybrid (server, then file)")
+
+	rootCmd.PersistentFlags().StringVar(&rateLimit, "rate-limit", os.Getenv("REDMINE_RATE_LIMIT"), "Max requests per second to Redmine, optionally with burst as rps:burst (e.g. 5 or 5:10)")
+	rootCmd.PersistentFlags().DurationVar(&shutdownTimeout, "shutdown-timeout", envDuration("REDMINE_SHUTDOWN_TIMEOUT", 30*time.Second), "Grace period for in-flight requests after SIGTERM (HTTP and API modes)")
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", os.Getenv("REDMINE_MCP_LANG"), "Language of server-generated messages, e.g. tool errors and Markdown headings (en, zh-TW); Redmine data is not translated")
+	rootCmd.PersistentFlags().BoolVar(&skipSelfTest, "skip-selftest", os.Getenv("REDMINE_SKIP_SELFTEST") == "true", "Start without checking that Redmine answers and accepts the API key (for offline testing)")
+	rootCmd.PersistentFlags().DurationVar(&redmineTimeout, "redmine-timeout", envDuration("REDMINE_TIMEOUT", redmine.DefaultTimeout), "Timeout of each request to Redmine (each page of paginated fetches gets its own)")
+	rootCmd.PersistentFlags().StringVar(&caFile, "redmine-ca-file", os.Getenv("REDMINE_CA_FILE"), "PEM file of root CAs to trust for Redmine's TLS certificate, besides the system's")
+	rootCmd.PersistentFlags().BoolVar(&insecureSkipVerify, "redmine-insecure-skip-verify", os.Getenv("REDMINE_INSECURE_SKIP_VERIFY") == "true", "Skip verification of Redmine's TLS certificate (testing only; prefer --redmine-ca-file)")
+	rootCmd.PersistentFlags().StringVar(&proxyURL, "redmine-proxy", os.Getenv("REDMINE_PROXY"), "HTTP(S) proxy URL for requests to Redmine (default: HTTP_PROXY/HTTPS_PROXY)")
+	rootCmd.PersistentFlags().IntVar(&maxAttachmentMB, "max-attachment-mb", envInt("REDMINE_MCP_MAX_ATTACHMENT_MB", redmine.DefaultMaxAttachmentMB), "Max size of uploaded attachments in MB; match Redmine's attachment_max_size")
 
 	// MCP command
 	mcpCmd := &cobra.Command{
@@ -50,6 +86,9 @@ func main() {
 
 	var sseMode bool
 	mcpCmd.Flags().BoolVar(&sseMode, "sse", false, "Run in HTTP mode with SSE and Streamable HTTP transports")
+	mcpCmd.Flags().Int("custom-field-hint-length", envInt("REDMINE_MCP_CUSTOM_FIELD_HINT_LENGTH", mcp.DefaultCustomFieldHintLength), "Max length of the custom_fields parameter descriptions listing known fields and allowed values")
+	mcpCmd.Flags().String("templates", os.Getenv("REDMINE_MCP_TEMPLATES_FILE"), "Path to a YAML or JSON file of issue templates for issues_createFromTemplate")
+	mcpCmd.Flags().String("tool-defaults", os.Getenv("REDMINE_MCP_TOOL_DEFAULTS"), "Path to a YAML file of per-tool defaults (status, limit, sort) for omitted issues_search, issues_exportCSV and issues_stats arguments")
 
 	// API command
 	apiCmd := &cobra.Command{
@@ -58,6 +97,8 @@ func main() {
 		Long:  "Start the REST API server for ChatGPT GPT Actions",
 		RunE:  runAPI,
 	}
+	apiCmd.Flags().String("cors-origins", os.Getenv("REDMINE_API_CORS_ORIGINS"), "Comma-separated origins allowed to call the REST API from a browser (* for any)")
+	apiCmd.Flags().Bool("static-openapi", os.Getenv("REDMINE_API_STATIC_OPENAPI") == "true", "Serve /openapi.json without enum values fetched from Redmine (for offline use)")
 
 	// generate-rules command
 	var (
@@ -93,7 +134,24 @@ func main() {
 	genWorkflowCmd.Flags().BoolVar(&wfMergeMode, "merge", false, "Merge with existing file instead of overwriting")
 	genWorkflowCmd.Flags().IntVar(&wfPerTracker, "per-tracker", 50, "Max issues to inspect per tracker")
 
-	rootCmd.AddCommand(mcpCmd, apiCmd, genRulesCmd, genWorkflowCmd)
+	// watch command
+	var watchOpts watchOptions
+	watchCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Poll Redmine and emit issue change events",
+		Long: "Poll for issues updated since the last poll and emit JSON events for new issues, status and assignee changes and new comments, " +
+			"to stdout as JSON lines or to a webhook. The first run only records the current state.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(watchOpts)
+		},
+	}
+	watchCmd.Flags().DurationVar(&watchOpts.interval, "interval", time.Minute, "Polling interval")
+	watchCmd.Flags().StringSliceVar(&watchOpts.projects, "project", nil, "Project name or ID to watch (repeatable; default: all visible projects)")
+	watchCmd.Flags().StringVar(&watchOpts.stateFile, "state-file", "redmine-watch-state.json", "File storing the last seen state between runs")
+	watchCmd.Flags().StringVar(&watchOpts.webhookURL, "webhook-url", os.Getenv("REDMINE_WATCH_WEBHOOK_URL"), "POST events to this URL instead of writing them to stdout")
+	watchCmd.Flags().BoolVar(&watchOpts.once, "once", false, "Poll once and exit")
+
+	rootCmd.AddCommand(mcpCmd, apiCmd, genRulesCmd, genWorkflowCmd, watchCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -101,6 +159,19 @@ func main() {
 	}
 }
 
+// loadConfigFile applies the --config file to the options that no flag or
+// environment variable sets
+func loadConfigFile(cmd *cobra.Command) error {
+	if configFile == "" {
+		return nil
+	}
+	file, err := config.Load(configFile)
+	if err != nil {
+		return err
+	}
+	return file.Apply(cmd.Flags())
+}
+
 func setupLogging() {
 	var level slog.Level
 	switch logLevel {
@@ -120,40 +191,158 @@ func setupLogging() {
 	slog.SetDefault(slog.New(handler))
 }
 
+// setupLanguage selects the language of the messages the server generates
+func setupLanguage() error {
+	printer, err := i18n.NewPrinter(lang)
+	if err != nil {
+		return fmt.Errorf("invalid --lang: %w", err)
+	}
+	i18n.SetDefault(printer)
+	return nil
+}
+
 func runMCP(cmd *cobra.Command, args []string) error {
 	if redmineURL == "" {
 		return fmt.Errorf("REDMINE_URL is required (set via --redmine-url or REDMINE_URL env var)")
 	}
 
 	sseMode, _ := cmd.Flags().GetBool("sse")
+	hintLength, _ := cmd.Flags().GetInt("custom-field-hint-length")
+	toolDefaults, _ := cmd.Flags().GetString("tool-defaults")
+	templatesFile, _ := cmd.Flags().GetString("templates")
+
+	source, err := redmine.ParseWorkflowSource(workflowSource)
+	if err != nil {
+		return err
+	}
+
+	rps, burst, err := parseRateLimitFlag()
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := newRedmineHTTPClient()
+	if err != nil {
+		return err
+	}
 
 	config := mcp.Config{
 		RedmineURL:           redmineURL,
 		RedmineAPIKey:        os.Getenv("REDMINE_API_KEY"),
+		RedmineUsername:      os.Getenv("REDMINE_USERNAME"),
+		RedminePassword:      os.Getenv("REDMINE_PASSWORD"),
 		Port:                 port,
 		SSEMode:              sseMode,
 		CustomFieldRulesFile: customFieldRulesFile,
 		WorkflowRulesFile:    workflowRulesFile,
+		WorkflowSource:       source,
+		RateLimit:            rps,
+		RateBurst:            burst,
+		ShutdownTimeout:      shutdownTimeout,
+		MaxAttachmentMB:      maxAttachmentMB,
+
+		CustomFieldHintLength: hintLength,
+		ToolDefaultsFile:      toolDefaults,
+		TemplatesFile:         templatesFile,
+		SkipSelfTest:          skipSelfTest,
+		HTTPClient:            httpClient,
 	}
 
-	if !sseMode && config.RedmineAPIKey == "" {
-		return fmt.Errorf("REDMINE_API_KEY is required for stdio mode (set via REDMINE_API_KEY env var)")
+	if !sseMode && config.RedmineAPIKey == "" && config.RedmineUsername == "" {
+		return fmt.Errorf("REDMINE_API_KEY is required for stdio mode (set via REDMINE_API_KEY env var, or REDMINE_USERNAME and REDMINE_PASSWORD for basic auth)")
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	server := mcp.NewServer(config)
-	return server.Run()
+	return server.Run(ctx)
+}
+
+// parseRateLimitFlag parses --rate-limit; an empty value disables rate limiting
+func parseRateLimitFlag() (float64, int, error) {
+	if rateLimit == "" {
+		return 0, 0, nil
+	}
+	rps, burst, err := redmine.ParseRateLimit(rateLimit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid --rate-limit: %w", err)
+	}
+	return rps, burst, nil
+}
+
+// newRedmineHTTPClient builds the HTTP client shared by every Redmine client
+// of the command from the connection flags
+func newRedmineHTTPClient() (*http.Client, error) {
+	httpClient, err := redmine.NewHTTPClient(redmine.HTTPConfig{
+		Timeout:            redmineTimeout,
+		CAFile:             caFile,
+		InsecureSkipVerify: insecureSkipVerify,
+		ProxyURL:           proxyURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redmine connection settings: %w", err)
+	}
+	return httpClient, nil
+}
+
+// newServerClient creates a client for the command's own requests,
+// authenticated with REDMINE_API_KEY, or with basic auth when
+// REDMINE_USERNAME is set
+func newServerClient() (*redmine.Client, error) {
+	httpClient, err := newRedmineHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	opts := []redmine.ClientOption{redmine.WithHTTPClient(httpClient)}
+	if username := os.Getenv("REDMINE_USERNAME"); username != "" {
+		opts = append(opts, redmine.WithBasicAuth(username, os.Getenv("REDMINE_PASSWORD")))
+	}
+	return redmine.NewClient(redmineURL, os.Getenv("REDMINE_API_KEY"), opts...), nil
+}
+
+// envDuration reads a duration such as "30s" from an environment variable,
+// falling back to def when it is unset or invalid
+func envDuration(name string, def time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring invalid %s=%q: %v\n", name, value, err)
+		return def
+	}
+	return d
+}
+
+// envInt reads a positive integer from an environment variable, falling back
+// to def when it is unset or invalid
+func envInt(name string, def int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring invalid %s=%q: expected a positive integer\n", name, value)
+		return def
+	}
+	return n
 }
 
 func runGenerateRules(outputFile string, mergeMode bool) error {
 	if redmineURL == "" {
 		return fmt.Errorf("REDMINE_URL is required (set via --redmine-url or REDMINE_URL env var)")
 	}
-	apiKey := os.Getenv("REDMINE_API_KEY")
-	if apiKey == "" {
+	client, err := newServerClient()
+	if err != nil {
+		return err
+	}
+	if !client.HasCredentials() {
 		return fmt.Errorf("REDMINE_API_KEY is required (admin key needed for /custom_fields.json)")
 	}
 
-	client := redmine.NewClient(redmineURL, apiKey)
 	fields, err := client.ListAllCustomFields()
 	if err != nil {
 		return fmt.Errorf("failed to fetch custom fields: %w", err)
@@ -196,12 +385,14 @@ func runGenerateWorkflow(outputFile string, mergeMode bool, perTracker int) erro
 	if redmineURL == "" {
 		return fmt.Errorf("REDMINE_URL is required (set via --redmine-url or REDMINE_URL env var)")
 	}
-	apiKey := os.Getenv("REDMINE_API_KEY")
-	if apiKey == "" {
+	client, err := newServerClient()
+	if err != nil {
+		return err
+	}
+	if !client.HasCredentials() {
 		return fmt.Errorf("REDMINE_API_KEY is required")
 	}
 
-	client := redmine.NewClient(redmineURL, apiKey)
 	opts := redmine.GenerateWorkflowOptions{PerTracker: perTracker}
 	generated, err := redmine.GenerateWorkflowRules(client, opts, func(format string, args ...any) {
 		slog.Info(fmt.Sprintf(format, args...))
@@ -247,13 +438,112 @@ func runAPI(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("REDMINE_URL is required (set via --redmine-url or REDMINE_URL env var)")
 	}
 
+	source, err := redmine.ParseWorkflowSource(workflowSource)
+	if err != nil {
+		return err
+	}
+
+	rps, burst, err := parseRateLimitFlag()
+	if err != nil {
+		return err
+	}
+
+	httpClient, err := newRedmineHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	staticOpenAPI, _ := cmd.Flags().GetBool("static-openapi")
+	corsOrigins, _ := cmd.Flags().GetString("cors-origins")
+
 	config := api.Config{
 		RedmineURL:           redmineURL,
 		Port:                 port,
 		CustomFieldRulesFile: customFieldRulesFile,
 		WorkflowRulesFile:    workflowRulesFile,
+		WorkflowSource:       source,
+		RateLimit:            rps,
+		RateBurst:            burst,
+		ReadOnly:             os.Getenv("REDMINE_API_READ_ONLY") == "true" || os.Getenv("REDMINE_MCP_READ_ONLY") == "true",
+		ReadOnlyAllowUploads: os.Getenv("REDMINE_API_READ_ONLY_ALLOW_UPLOADS") == "true",
+		ShutdownTimeout:      shutdownTimeout,
+		MaxAttachmentMB:      maxAttachmentMB,
+		APIKey:               os.Getenv("REDMINE_API_KEY"),
+		Username:             os.Getenv("REDMINE_USERNAME"),
+		Password:             os.Getenv("REDMINE_PASSWORD"),
+		StaticOpenAPI:        staticOpenAPI,
+		CORSOrigins:          api.ParseCORSOrigins(corsOrigins),
+		SkipSelfTest:         skipSelfTest,
+		HTTPClient:           httpClient,
 	}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	server := api.NewServer(config)
-	return server.Run()
+	return server.Run(ctx)
+}
+
+type watchOptions struct {
+	interval   time.Duration
+	projects   []string
+	stateFile  string
+	webhookURL string
+	once       bool
+}
+
+func runWatch(opts watchOptions) error {
+	if redmineURL == "" {
+		return fmt.Errorf("REDMINE_URL is required (set via --redmine-url or REDMINE_URL env var)")
+	}
+	client, err := newServerClient()
+	if err != nil {
+		return err
+	}
+	if !client.HasCredentials() {
+		return fmt.Errorf("REDMINE_API_KEY is required")
+	}
+	if opts.interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	rps, burst, err := parseRateLimitFlag()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client = client.WithContext(ctx)
+	if rps > 0 {
+		client.SetRateLimiter(redmine.NewRateLimiter(rps, burst))
+	}
+
+	resolver := redmine.NewResolver(client)
+	projectIDs := make([]string, len(opts.projects))
+	for i, project := range opts.projects {
+		id, err := resolver.ResolveProject(project)
+		if err != nil {
+			return fmt.Errorf("failed to resolve project %q: %w", project, err)
+		}
+		projectIDs[i] = strconv.Itoa(id)
+	}
+
+	var sink watch.Sink = watch.JSONLinesSink{W: os.Stdout}
+	if opts.webhookURL != "" {
+		sink = watch.NewWebhookSink(opts.webhookURL)
+	}
+
+	watcher, err := watch.New(client, watch.Config{ProjectIDs: projectIDs, StateFile: opts.stateFile}, sink)
+	if err != nil {
+		return err
+	}
+
+	if opts.once {
+		_, err := watcher.Poll()
+		return err
+	}
+	slog.Info("watching Redmine for issue changes", "interval", opts.interval, "projects", len(projectIDs), "state_file", opts.stateFile)
+	return watcher.Run(ctx, opts.interval)
 }
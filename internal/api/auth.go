@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+const (
+	// keyCacheTTL is how long a validated API key is trusted before it is
+	// checked against Redmine again
+	keyCacheTTL = 15 * time.Minute
+
+	// keyCheckTimeout bounds the /users/current.json call validating a new key
+	keyCheckTimeout = 5 * time.Second
+)
+
+// keyEntry is a validated API key with the resolver shared by its requests
+type keyEntry struct {
+	user        *redmine.User
+	resolver    *redmine.Resolver
+	validatedAt time.Time
+}
+
+// keyCache remembers validated API keys, so each key costs one
+// /users/current.json call per TTL and its requests share one resolver cache
+type keyCache struct {
+	mu      sync.Mutex
+	entries map[string]*keyEntry
+	ttl     time.Duration
+}
+
+func newKeyCache(ttl time.Duration) *keyCache {
+	return &keyCache{
+		entries: make(map[string]*keyEntry),
+		ttl:     ttl,
+	}
+}
+
+// get returns the entry of a key validated within the TTL, or nil
+func (c *keyCache) get(apiKey string) *keyEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[apiKey]; ok && time.Since(e.validatedAt) < c.ttl {
+		return e
+	}
+	return nil
+}
+
+// put records a validated key, keeping the resolver of an expired entry so
+// revalidation doesn't throw away its reference data
+func (c *keyCache) put(apiKey string, user *redmine.User, newResolver func() *redmine.Resolver) *keyEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[apiKey]
+	if !ok {
+		e = &keyEntry{resolver: newResolver()}
+		c.entries[apiKey] = e
+	}
+	e.user, e.validatedAt = user, time.Now()
+	return e
+}
+
+// cleanup drops keys that haven't been revalidated for maxAge
+func (c *keyCache) cleanup(maxAge time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, e := range c.entries {
+		if time.Since(e.validatedAt) > maxAge {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// credentials authenticate a caller's requests to Redmine: an API key, or a
+// username and password for basic auth
+type credentials struct {
+	apiKey   string
+	username string
+	password string
+}
+
+// requestCredentials reads the X-Redmine-API-Key header, or else an
+// Authorization: Basic header
+func requestCredentials(r *http.Request) (credentials, bool) {
+	if apiKey := r.Header.Get("X-Redmine-API-Key"); apiKey != "" {
+		return credentials{apiKey: apiKey}, true
+	}
+	if username, password, ok := r.BasicAuth(); ok && username != "" {
+		return credentials{username: username, password: password}, true
+	}
+	return credentials{}, false
+}
+
+// cacheKey identifies the credentials in the key cache. Basic auth is
+// hashed, so the cache never holds a password.
+func (c credentials) cacheKey() string {
+	if c.username == "" {
+		return c.apiKey
+	}
+	sum := sha256.Sum256([]byte(c.username + "\x00" + c.password))
+	return "basic:" + hex.EncodeToString(sum[:])
+}
+
+// clientOptions returns the client options of basic auth credentials
+func (c credentials) clientOptions() []redmine.ClientOption {
+	if c.username == "" {
+		return nil
+	}
+	return []redmine.ClientOption{redmine.WithBasicAuth(c.username, c.password)}
+}
+
+// authMiddleware extracts the Redmine API key or basic auth credentials,
+// validates them against Redmine on first sight, and stores a client and the
+// credentials' resolver in the context. Credentials Redmine rejects get a 401
+// before any handler runs. When Redmine can't be reached they are not cached
+// and the request continues, so the handler reports the failure as before.
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		creds, ok := requestCredentials(r)
+		if !ok {
+			http.Error(w, `{"error": "Missing X-Redmine-API-Key or Authorization: Basic header"}`, http.StatusUnauthorized)
+			return
+		}
+
+		client := s.newClient(creds.apiKey, creds.clientOptions()...).WithContext(r.Context())
+
+		key := creds.cacheKey()
+		entry := s.keys.get(key)
+		if entry == nil {
+			// A single quick attempt; retrying would only delay the handler's own error
+			check := s.newClient(creds.apiKey, creds.clientOptions()...)
+			check.SetRetryPolicy(redmine.RetryPolicy{})
+			ctx, cancel := context.WithTimeout(r.Context(), keyCheckTimeout)
+			user, err := check.WithContext(ctx).GetCurrentUser()
+			cancel()
+			if apiErr, ok := redmine.AsAPIError(err); ok && apiErr.StatusCode == http.StatusUnauthorized {
+				if creds.username != "" {
+					writeError(w, http.StatusUnauthorized, "Invalid Redmine username or password")
+				} else {
+					writeError(w, http.StatusUnauthorized, "Invalid Redmine API key")
+				}
+				return
+			}
+			if err == nil {
+				entry = s.keys.put(key, user, func() *redmine.Resolver {
+					// The resolver outlives this request, so its client has no request context
+					return redmine.NewResolver(s.newClient(creds.apiKey, creds.clientOptions()...))
+				})
+			}
+		}
+
+		resolver := redmine.NewResolver(client)
+		if entry != nil {
+			resolver = entry.resolver
+			setLogUser(r.Context(), entry.user)
+		}
+		ctx := withResolver(withClient(r.Context(), client), resolver)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// newClient creates a Redmine client for apiKey that shares the outbound rate
+// limiter and HTTP client
+func (s *Server) newClient(apiKey string, opts ...redmine.ClientOption) *redmine.Client {
+	client := redmine.NewClient(s.config.RedmineURL, apiKey, append([]redmine.ClientOption{redmine.WithHTTPClient(s.config.HTTPClient)}, opts...)...)
+	if s.redmineLimiter != nil {
+		client.SetRateLimiter(s.redmineLimiter)
+	}
+	return client
+}
+
+// serverClient creates a client with the server's own credentials: APIKey,
+// or Username and Password for basic auth
+func (s *Server) serverClient() *redmine.Client {
+	return s.newClient(s.config.APIKey, credentials{username: s.config.Username, password: s.config.Password}.clientOptions()...)
+}
@@ -3,11 +3,14 @@ package api
 import (
 	"bytes"
 	"context"
-	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -24,10 +27,14 @@ import (
 // @securityDefinitions.apikey ApiKeyAuth
 // @in header
 // @name X-Redmine-API-Key
+// @securityDefinitions.basic BasicAuth
 
 type contextKey string
 
-const clientContextKey contextKey = "redmineClient"
+const (
+	clientContextKey   contextKey = "redmineClient"
+	resolverContextKey contextKey = "redmineResolver"
+)
 
 func withClient(ctx context.Context, client *redmine.Client) context.Context {
 	return context.WithValue(ctx, clientContextKey, client)
@@ -37,6 +44,15 @@ func getClient(ctx context.Context) *redmine.Client {
 	return ctx.Value(clientContextKey).(*redmine.Client)
 }
 
+// withResolver stores the resolver shared by all requests of an API key
+func withResolver(ctx context.Context, resolver *redmine.Resolver) context.Context {
+	return context.WithValue(ctx, resolverContextKey, resolver)
+}
+
+func getResolver(ctx context.Context) *redmine.Resolver {
+	return ctx.Value(resolverContextKey).(*redmine.Resolver)
+}
+
 func writeJSON(w http.ResponseWriter, status int, data any) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -47,6 +63,31 @@ func writeError(w http.ResponseWriter, status int, message string) {
 	writeJSON(w, status, map[string]string{"error": message})
 }
 
+// writeRedmineError writes an error returned by the Redmine client, passing through
+// Redmine's 401/403/404/422 statuses and validation messages. Other failures are 500s.
+func writeRedmineError(w http.ResponseWriter, err error) {
+	apiErr, ok := redmine.AsAPIError(err)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	status := http.StatusInternalServerError
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity:
+		status = apiErr.StatusCode
+	}
+
+	if len(apiErr.Errors) == 0 {
+		writeError(w, status, err.Error())
+		return
+	}
+	writeJSON(w, status, map[string]any{
+		"error":  err.Error(),
+		"errors": apiErr.Errors,
+	})
+}
+
 // @Summary Get current user
 // @Description Returns information about the current user
 // @Tags Account
@@ -60,7 +101,7 @@ func (s *Server) handleMe(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
 	user, err := client.GetCurrentUser()
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -94,9 +135,9 @@ func (s *Server) handleListProjects(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	projects, err := client.ListProjects(limit)
+	projects, total, err := client.ListProjects(limit)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -106,6 +147,7 @@ func (s *Server) handleListProjects(w http.ResponseWriter, r *http.Request) {
 			"id":          p.ID,
 			"name":        p.Name,
 			"identifier":  p.Identifier,
+			"url":         client.Links().Project(p.Identifier),
 			"description": p.Description,
 		}
 		if p.Parent != nil {
@@ -117,8 +159,9 @@ func (s *Server) handleListProjects(w http.ResponseWriter, r *http.Request) {
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
-		"projects": result,
-		"count":    len(projects),
+		"projects":    result,
+		"count":       len(projects),
+		"total_count": total,
 	})
 }
 
@@ -135,7 +178,7 @@ func (s *Server) handleListProjects(w http.ResponseWriter, r *http.Request) {
 // @Router /projects [post]
 func (s *Server) handleCreateProject(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
+	resolver := getResolver(r.Context())
 
 	var req struct {
 		Name        string `json:"name"`
@@ -166,7 +209,7 @@ func (s *Server) handleCreateProject(w http.ResponseWriter, r *http.Request) {
 
 	project, err := client.CreateProject(req.Name, req.Identifier, req.Description, parentID)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -194,7 +237,7 @@ func (s *Server) handleCreateProject(w http.ResponseWriter, r *http.Request) {
 // @Router /issues [get]
 func (s *Server) handleSearchIssues(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
+	resolver := getResolver(r.Context())
 
 	params := redmine.SearchIssuesParams{}
 	q := r.URL.Query()
@@ -289,13 +332,13 @@ func (s *Server) handleSearchIssues(w http.ResponseWriter, r *http.Request) {
 
 	issues, total, err := client.SearchIssues(params)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
 	result := make([]map[string]any, len(issues))
 	for i, issue := range issues {
-		result[i] = formatIssueAPI(issue)
+		result[i] = formatIssueAPI(issue, client.Links())
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
@@ -312,6 +355,8 @@ func (s *Server) handleSearchIssues(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path int true "Issue ID"
+// @Param include_spent_hours query bool false "Add total spent hours with a per-user breakdown"
+// @Param include_children query bool false "Add the direct subtasks"
 // @Success 200 {object} map[string]any
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
@@ -328,11 +373,29 @@ func (s *Server) handleGetIssue(w http.ResponseWriter, r *http.Request) {
 
 	issue, err := client.GetIssue(id)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
-	result := formatIssueDetailAPI(*issue)
+	result := formatIssueDetailAPI(*issue, client.Links())
+
+	q := r.URL.Query()
+	if q.Get("include_spent_hours") == "true" {
+		spent, err := client.SpentTimeOnIssue(id)
+		if err != nil {
+			writeRedmineError(w, err)
+			return
+		}
+		result["spent_time"] = spent
+	}
+	if q.Get("include_children") == "true" {
+		children, err := client.ChildIssues(id)
+		if err != nil {
+			writeRedmineError(w, err)
+			return
+		}
+		result["children"] = children
+	}
 
 	// Add allowed_statuses from workflow rules (Redmine pre-5.0 doesn't provide this)
 	if s.workflow != nil && len(issue.AllowedStatuses) == 0 {
@@ -361,47 +424,66 @@ func (s *Server) handleGetIssue(w http.ResponseWriter, r *http.Request) {
 // @Router /issues [post]
 func (s *Server) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
-
-	var req struct {
-		Project       string         `json:"project"`
-		Tracker       string         `json:"tracker"`
-		Subject       string         `json:"subject"`
-		Description   string         `json:"description"`
-		AssignedTo    string         `json:"assigned_to"`
-		ParentIssueID int            `json:"parent_issue_id"`
-		StartDate     string         `json:"start_date"`
-		DueDate       string         `json:"due_date"`
-		IsPrivate     *bool          `json:"is_private"`
-		CustomFields  map[string]any `json:"custom_fields"`
-		UploadTokens  []struct {
-			Token       string `json:"token"`
-			Filename    string `json:"filename"`
-			ContentType string `json:"content_type"`
-			Description string `json:"description"`
-		} `json:"upload_tokens"`
-	}
+	resolver := getResolver(r.Context())
 
+	var req createIssueRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	if req.Project == "" || req.Tracker == "" || req.Subject == "" {
-		writeError(w, http.StatusBadRequest, "project, tracker, and subject are required")
+	params, err := s.resolveCreateIssue(resolver, req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	projectID, err := resolver.ResolveProject(req.Project)
+	issue, err := client.CreateIssue(params)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
+	writeJSON(w, http.StatusCreated, createdIssueAPI(params, issue, client.Links()))
+}
+
+// createIssueRequest is the body of POST /issues and an item of POST /issues/bulk-create
+type createIssueRequest struct {
+	Project       string         `json:"project"`
+	Tracker       string         `json:"tracker"`
+	Subject       string         `json:"subject"`
+	Description   string         `json:"description"`
+	AssignedTo    string         `json:"assigned_to"`
+	Status        string         `json:"status"`
+	Priority      string         `json:"priority"`
+	DoneRatio     *int           `json:"done_ratio"`
+	ParentIssueID int            `json:"parent_issue_id"`
+	StartDate     string         `json:"start_date"`
+	DueDate       string         `json:"due_date"`
+	IsPrivate     *bool          `json:"is_private"`
+	CustomFields  map[string]any `json:"custom_fields"`
+	UploadTokens  []struct {
+		Token       string `json:"token"`
+		Filename    string `json:"filename"`
+		ContentType string `json:"content_type"`
+		Description string `json:"description"`
+	} `json:"upload_tokens"`
+}
+
+// resolveCreateIssue resolves names in a create request and validates its custom fields
+func (s *Server) resolveCreateIssue(resolver *redmine.Resolver, req createIssueRequest) (redmine.CreateIssueParams, error) {
+	if req.Project == "" || req.Tracker == "" || req.Subject == "" {
+		return redmine.CreateIssueParams{}, fmt.Errorf("project, tracker, and subject are required")
+	}
+
+	projectID, err := resolver.ResolveProject(req.Project)
+	if err != nil {
+		return redmine.CreateIssueParams{}, err
+	}
+
 	trackerID, err := resolver.ResolveTracker(req.Tracker)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, err.Error())
-		return
+		return redmine.CreateIssueParams{}, err
 	}
 
 	params := redmine.CreateIssueParams{
@@ -412,24 +494,28 @@ func (s *Server) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.AssignedTo != "" {
-		userID, err := resolver.ResolveUser(req.AssignedTo, projectID)
+		userID, err := resolver.ResolveAssignee(req.AssignedTo, projectID)
 		if err != nil {
-			writeError(w, http.StatusBadRequest, err.Error())
-			return
+			return params, err
 		}
 		params.AssignedToID = userID
 	}
 
+	if err := resolveInitialState(resolver, &params, req.Status, req.Priority, req.DoneRatio); err != nil {
+		return params, err
+	}
+
 	params.ParentIssueID = req.ParentIssueID
 	params.StartDate = req.StartDate
 	params.DueDate = req.DueDate
 	params.IsPrivate = req.IsPrivate
 
 	if req.CustomFields != nil {
-		resolved, err := resolveCustomFieldsAPI(req.CustomFields, s.rules)
+		// Definitions are best effort; without them values are only checked against the rules file
+		defs, _ := resolver.ProjectCustomFields(projectID, trackerID, s.rules)
+		resolved, err := resolveCustomFieldsAPI(req.CustomFields, s.rules, defs)
 		if err != nil {
-			writeError(w, http.StatusBadRequest, err.Error())
-			return
+			return params, err
 		}
 		params.CustomFields = resolved
 	}
@@ -443,13 +529,103 @@ func (s *Server) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	issue, err := client.CreateIssue(params)
-	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+	return params, nil
+}
+
+// resolveInitialState resolves the status, priority and done ratio a new
+// issue starts with; empty values keep Redmine's defaults
+func resolveInitialState(resolver *redmine.Resolver, params *redmine.CreateIssueParams, status, priority string, doneRatio *int) error {
+	if status != "" {
+		statusID, err := resolver.ResolveStatusID(status)
+		if err != nil {
+			return err
+		}
+		params.StatusID = statusID
+	}
+	if priority != "" {
+		priorityID, err := resolver.ResolvePriority(priority)
+		if err != nil {
+			return err
+		}
+		params.PriorityID = priorityID
+	}
+	if doneRatio != nil && (*doneRatio < 0 || *doneRatio > 100) {
+		return fmt.Errorf("done_ratio must be between 0 and 100")
+	}
+	params.DoneRatio = doneRatio
+	return nil
+}
+
+// createdIssueAPI formats a newly created issue, warning about requested
+// values Redmine ignored
+func createdIssueAPI(params redmine.CreateIssueParams, issue *redmine.Issue, links redmine.Links) map[string]any {
+	result := formatIssueAPI(*issue, links)
+	if warning := params.CreateWarning(issue); warning != "" {
+		result["warning"] = warning
+	}
+	return result
+}
+
+// @Summary Create issues in bulk
+// @Description Create up to 50 issues at once. Every item is validated first and nothing is created if any item is invalid; creation then continues on individual failures
+// @Tags Issues
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body object true "Issues with optional top-level project and tracker defaults"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]any
+// @Failure 401 {object} map[string]string
+// @Router /issues/bulk-create [post]
+func (s *Server) handleBulkCreateIssues(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+	resolver := getResolver(r.Context())
+
+	var req struct {
+		Project          string               `json:"project"`
+		Tracker          string               `json:"tracker"`
+		Issues           []createIssueRequest `json:"issues"`
+		LinkSequentially bool                 `json:"link_sequentially"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if len(req.Issues) == 0 {
+		writeError(w, http.StatusBadRequest, "issues is required and must not be empty")
+		return
+	}
+	if len(req.Issues) > redmine.MaxBulkCreateIssues {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("issues has %d items; at most %d can be created at once", len(req.Issues), redmine.MaxBulkCreateIssues))
+		return
+	}
+
+	items := make([]redmine.CreateIssueParams, len(req.Issues))
+	var problems []map[string]any
+	for i, item := range req.Issues {
+		if item.Project == "" {
+			item.Project = req.Project
+		}
+		if item.Tracker == "" {
+			item.Tracker = req.Tracker
+		}
+		params, err := s.resolveCreateIssue(resolver, item)
+		if err != nil {
+			problems = append(problems, map[string]any{"index": i, "subject": item.Subject, "error": err.Error()})
+			continue
+		}
+		items[i] = params
+	}
+	if len(problems) > 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]any{
+			"error":    "validation failed, no issues were created",
+			"problems": problems,
+		})
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, formatIssueAPI(*issue))
+	writeJSON(w, http.StatusOK, redmine.BulkCreateIssues(client, items, req.LinkSequentially))
 }
 
 // @Summary Update issue
@@ -466,7 +642,7 @@ func (s *Server) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
 // @Router /issues/{id} [patch]
 func (s *Server) handleUpdateIssue(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
+	resolver := getResolver(r.Context())
 
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.Atoi(idStr)
@@ -494,6 +670,9 @@ func (s *Server) handleUpdateIssue(w http.ResponseWriter, r *http.Request) {
 			ContentType string `json:"content_type"`
 			Description string `json:"description"`
 		} `json:"upload_tokens"`
+		ClearAssignedTo  bool `json:"clear_assigned_to"`
+		ClearDueDate     bool `json:"clear_due_date"`
+		ClearDescription bool `json:"clear_description"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -501,10 +680,15 @@ func (s *Server) handleUpdateIssue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if (req.ClearAssignedTo && req.AssignedTo != "") || (req.ClearDueDate && req.DueDate != "") || (req.ClearDescription && req.Description != "") {
+		writeError(w, http.StatusBadRequest, "A field cannot be both set and cleared")
+		return
+	}
+
 	// Get issue for project context
 	issue, err := client.GetIssue(id)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -517,6 +701,10 @@ func (s *Server) handleUpdateIssue(w http.ResponseWriter, r *http.Request) {
 		Notes:       req.Notes,
 		DoneRatio:   req.DoneRatio,
 		IsPrivate:   req.IsPrivate,
+
+		ClearAssignee:    req.ClearAssignedTo,
+		ClearDueDate:     req.ClearDueDate,
+		ClearDescription: req.ClearDescription,
 	}
 
 	if req.Priority != "" {
@@ -543,7 +731,12 @@ func (s *Server) handleUpdateIssue(w http.ResponseWriter, r *http.Request) {
 			writeError(w, http.StatusBadRequest, err.Error())
 			return
 		}
-		if err := s.workflow.ValidateTransition(issue.Tracker.ID, issue.Status.ID, statusID); err != nil {
+		if err := resolver.ValidateTransition(issue, statusID, s.workflow, s.config.WorkflowSource); err != nil {
+			var transitionErr *redmine.TransitionError
+			if errors.As(err, &transitionErr) {
+				writeJSON(w, http.StatusBadRequest, transitionErr.Details())
+				return
+			}
 			writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid status transition: %v", err))
 			return
 		}
@@ -551,7 +744,7 @@ func (s *Server) handleUpdateIssue(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.AssignedTo != "" {
-		userID, err := resolver.ResolveUser(req.AssignedTo, issue.Project.ID)
+		userID, err := resolver.ResolveAssignee(req.AssignedTo, issue.Project.ID)
 		if err != nil {
 			writeError(w, http.StatusBadRequest, err.Error())
 			return
@@ -560,7 +753,13 @@ func (s *Server) handleUpdateIssue(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.CustomFields != nil {
-		resolved, err := resolveCustomFieldsAPI(req.CustomFields, s.rules)
+		updateTrackerID := issue.Tracker.ID
+		if params.TrackerID > 0 {
+			updateTrackerID = params.TrackerID
+		}
+		// Definitions are best effort; without them values are only checked against the rules file
+		defs, _ := resolver.ProjectCustomFields(issue.Project.ID, updateTrackerID, s.rules)
+		resolved, err := resolveCustomFieldsAPI(req.CustomFields, s.rules, defs)
 		if err != nil {
 			writeError(w, http.StatusBadRequest, err.Error())
 			return
@@ -578,7 +777,7 @@ func (s *Server) handleUpdateIssue(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := client.UpdateIssue(params); err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -603,7 +802,7 @@ func (s *Server) handleUpdateIssue(w http.ResponseWriter, r *http.Request) {
 // @Router /issues/{id}/subtasks [post]
 func (s *Server) handleCreateSubtask(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
+	resolver := getResolver(r.Context())
 
 	idStr := chi.URLParam(r, "id")
 	parentID, err := strconv.Atoi(idStr)
@@ -617,6 +816,9 @@ func (s *Server) handleCreateSubtask(w http.ResponseWriter, r *http.Request) {
 		Tracker      string         `json:"tracker"`
 		Description  string         `json:"description"`
 		AssignedTo   string         `json:"assigned_to"`
+		Status       string         `json:"status"`
+		Priority     string         `json:"priority"`
+		DoneRatio    *int           `json:"done_ratio"`
 		CustomFields map[string]any `json:"custom_fields"`
 	}
 
@@ -633,7 +835,7 @@ func (s *Server) handleCreateSubtask(w http.ResponseWriter, r *http.Request) {
 	// Get parent issue
 	parent, err := client.GetIssue(parentID)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -655,7 +857,7 @@ func (s *Server) handleCreateSubtask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if req.AssignedTo != "" {
-		userID, err := resolver.ResolveUser(req.AssignedTo, parent.Project.ID)
+		userID, err := resolver.ResolveAssignee(req.AssignedTo, parent.Project.ID)
 		if err != nil {
 			writeError(w, http.StatusBadRequest, err.Error())
 			return
@@ -663,8 +865,15 @@ func (s *Server) handleCreateSubtask(w http.ResponseWriter, r *http.Request) {
 		params.AssignedToID = userID
 	}
 
+	if err := resolveInitialState(resolver, &params, req.Status, req.Priority, req.DoneRatio); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	if req.CustomFields != nil {
-		resolved, err := resolveCustomFieldsAPI(req.CustomFields, s.rules)
+		// Definitions are best effort; without them values are only checked against the rules file
+		defs, _ := resolver.ProjectCustomFields(parent.Project.ID, params.TrackerID, s.rules)
+		resolved, err := resolveCustomFieldsAPI(req.CustomFields, s.rules, defs)
 		if err != nil {
 			writeError(w, http.StatusBadRequest, err.Error())
 			return
@@ -674,11 +883,11 @@ func (s *Server) handleCreateSubtask(w http.ResponseWriter, r *http.Request) {
 
 	issue, err := client.CreateIssue(params)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, formatIssueAPI(*issue))
+	writeJSON(w, http.StatusCreated, createdIssueAPI(params, issue, client.Links()))
 }
 
 // @Summary Add watcher
@@ -695,7 +904,7 @@ func (s *Server) handleCreateSubtask(w http.ResponseWriter, r *http.Request) {
 // @Router /issues/{id}/watchers [post]
 func (s *Server) handleAddWatcher(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
+	resolver := getResolver(r.Context())
 
 	idStr := chi.URLParam(r, "id")
 	issueID, err := strconv.Atoi(idStr)
@@ -721,7 +930,7 @@ func (s *Server) handleAddWatcher(w http.ResponseWriter, r *http.Request) {
 	// Get issue for project context
 	issue, err := client.GetIssue(issueID)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -732,7 +941,7 @@ func (s *Server) handleAddWatcher(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := client.AddWatcher(issueID, userID); err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -744,14 +953,68 @@ func (s *Server) handleAddWatcher(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// @Summary Add comment
+// @Description Add a comment (journal note) to an issue without changing other fields
+// @Tags Issues
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Issue ID"
+// @Param request body object true "Comment data"
+// @Success 201 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /issues/{id}/comments [post]
+func (s *Server) handleAddComment(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	issueID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid issue ID")
+		return
+	}
+
+	var req struct {
+		Notes        string `json:"notes"`
+		PrivateNotes bool   `json:"private_notes"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if strings.TrimSpace(req.Notes) == "" {
+		writeError(w, http.StatusBadRequest, "notes is required")
+		return
+	}
+
+	params := redmine.UpdateIssueParams{IssueID: issueID, Notes: req.Notes}
+	if req.PrivateNotes {
+		params.PrivateNotes = &req.PrivateNotes
+	}
+
+	if err := client.UpdateIssue(params); err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"success":       true,
+		"issue_id":      issueID,
+		"private_notes": req.PrivateNotes,
+		"notes":         redmine.NotePreview(req.Notes),
+	})
+}
+
 // @Summary Add relation
-// @Description Create a relation between two issues
+// @Description Create a relation between two issues. Inverse types (blocked_by, follows, duplicated_by, copied_from) are stored as the forward type with the issues swapped, reported as swapped: true
 // @Tags Issues
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path int true "Source Issue ID"
-// @Param request body object true "Relation data"
+// @Param request body object true "Relation data: issue_to_id, relation_type and, for precedes/follows, delay in days"
 // @Success 201 {object} map[string]any
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
@@ -769,6 +1032,7 @@ func (s *Server) handleAddRelation(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		IssueToID    int    `json:"issue_to_id"`
 		RelationType string `json:"relation_type"`
+		Delay        *int   `json:"delay"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -780,23 +1044,152 @@ func (s *Server) handleAddRelation(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "issue_to_id and relation_type are required")
 		return
 	}
+	fromID, toID, relationType, err := redmine.NormalizeRelation(issueID, req.IssueToID, req.RelationType)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Delay != nil {
+		if err := redmine.ValidateRelationDelay(relationType); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 
-	relation, err := client.CreateRelation(issueID, req.IssueToID, req.RelationType)
+	relation, err := client.CreateRelation(fromID, toID, relationType, req.Delay)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, map[string]any{
+	result := map[string]any{
 		"id":            relation.ID,
 		"issue_id":      relation.IssueID,
 		"issue_to_id":   relation.IssueToID,
 		"relation_type": relation.RelationType,
+		"swapped":       fromID != issueID,
+	}
+	if relation.Delay != nil {
+		result["delay"] = *relation.Delay
+	}
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// @Summary List time entries
+// @Description List time entries with filters, like the timeEntries_list MCP tool
+// @Tags Time Entries
+// @Produce json
+// @Security ApiKeyAuth
+// @Param project query string false "Project name or ID"
+// @Param user query string false "User name, ID or 'me'"
+// @Param issue_id query int false "Issue ID"
+// @Param from query string false "Start date (YYYY-MM-DD or relative: today, '7 days ago', start_of_month, 2024-W12, ...)"
+// @Param to query string false "End date (YYYY-MM-DD or relative like from)"
+// @Param period query string false "Date shortcut such as this_week, last_month or last_30_days; from and to override its ends"
+// @Param timezone query string false "IANA timezone deciding what today is, e.g. Asia/Taipei"
+// @Param limit query int false "Number of entries to return" default(25)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /time_entries [get]
+func (s *Server) handleListTimeEntries(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+	q := r.URL.Query()
+
+	params, ok := s.timeEntryFilters(w, getResolver(r.Context()), q)
+	if !ok {
+		return
+	}
+
+	if issueID := q.Get("issue_id"); issueID != "" {
+		n, err := strconv.Atoi(issueID)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "Invalid issue_id")
+			return
+		}
+		params.IssueID = n
+	}
+
+	params.Limit = 25
+	if limit := q.Get("limit"); limit != "" {
+		if n, err := strconv.Atoi(limit); err == nil {
+			params.Limit = n
+		}
+	}
+	if offset := q.Get("offset"); offset != "" {
+		if n, err := strconv.Atoi(offset); err == nil {
+			params.Offset = n
+		}
+	}
+
+	entries, total, err := client.ListTimeEntries(params)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	results := make([]map[string]any, len(entries))
+	for i, entry := range entries {
+		result := map[string]any{
+			"id":       entry.ID,
+			"url":      client.Links().TimeEntry(entry.ID),
+			"project":  entry.Project.Name,
+			"user":     entry.User.Name,
+			"activity": entry.Activity.Name,
+			"hours":    entry.Hours,
+			"spent_on": entry.SpentOn,
+			"comments": entry.Comments,
+		}
+		if entry.Issue != nil {
+			result["issue_id"] = entry.Issue.ID
+		}
+		results[i] = result
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"total_count":  total,
+		"count":        len(entries),
+		"truncated":    params.Offset+len(entries) < total,
+		"time_entries": results,
 	})
 }
 
+// timeEntryFilters resolves the project, user and date query parameters
+// shared by the time entry list and report, writing a 400 when one is
+// invalid. It reports whether the caller should continue.
+func (s *Server) timeEntryFilters(w http.ResponseWriter, resolver *redmine.Resolver, q url.Values) (redmine.ListTimeEntriesParams, bool) {
+	params := redmine.ListTimeEntriesParams{}
+
+	var projectID int
+	if project := q.Get("project"); project != "" {
+		var err error
+		projectID, err = resolver.ResolveProject(project)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return params, false
+		}
+		params.ProjectID = strconv.Itoa(projectID)
+	}
+
+	if user := q.Get("user"); user == "me" {
+		params.UserID = "me"
+	} else if user != "" {
+		userID, err := resolver.ResolveUser(user, projectID)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return params, false
+		}
+		params.UserID = strconv.Itoa(userID)
+	}
+
+	var ok bool
+	params.From, params.To, ok = s.dateRange(w, q)
+	return params, ok
+}
+
 // @Summary Create time entry
-// @Description Log time on an issue
+// @Description Log time on an issue, or on a project without an issue; give exactly one of issue_id and project
 // @Tags Time Entries
 // @Accept json
 // @Produce json
@@ -808,10 +1201,11 @@ func (s *Server) handleAddRelation(w http.ResponseWriter, r *http.Request) {
 // @Router /time_entries [post]
 func (s *Server) handleCreateTimeEntry(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
+	resolver := getResolver(r.Context())
 
 	var req struct {
 		IssueID  int     `json:"issue_id"`
+		Project  string  `json:"project"`
 		Hours    float64 `json:"hours"`
 		Activity string  `json:"activity"`
 		Comments string  `json:"comments"`
@@ -822,8 +1216,12 @@ func (s *Server) handleCreateTimeEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.IssueID == 0 || req.Hours == 0 {
-		writeError(w, http.StatusBadRequest, "issue_id and hours are required")
+	if req.IssueID != 0 && req.Project != "" {
+		writeError(w, http.StatusBadRequest, "Give either issue_id or project, not both")
+		return
+	}
+	if (req.IssueID == 0 && req.Project == "") || req.Hours == 0 {
+		writeError(w, http.StatusBadRequest, "issue_id or project, and hours are required")
 		return
 	}
 
@@ -832,6 +1230,14 @@ func (s *Server) handleCreateTimeEntry(w http.ResponseWriter, r *http.Request) {
 		Hours:    req.Hours,
 		Comments: req.Comments,
 	}
+	if req.Project != "" {
+		projectID, err := resolver.ResolveProject(req.Project)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		params.ProjectID = projectID
+	}
 
 	if req.Activity != "" {
 		activityID, err := resolver.ResolveActivity(req.Activity)
@@ -844,21 +1250,115 @@ func (s *Server) handleCreateTimeEntry(w http.ResponseWriter, r *http.Request) {
 
 	entry, err := client.CreateTimeEntry(params)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, map[string]any{
+	result := map[string]any{
 		"id":       entry.ID,
-		"issue_id": req.IssueID,
+		"project":  entry.Project.Name,
 		"hours":    entry.Hours,
 		"activity": entry.Activity.Name,
 		"comments": entry.Comments,
 		"spent_on": entry.SpentOn,
-	})
+	}
+	if req.IssueID != 0 {
+		result["issue_id"] = req.IssueID
+	}
+	writeJSON(w, http.StatusCreated, result)
 }
 
-// @Summary List trackers
+// @Summary Create time entries in batch
+// @Description Log multiple time entries at once. Continues on individual failures (partial success).
+// @Tags Time Entries
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body object true "Batch of time entries"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /time_entries/batch [post]
+func (s *Server) handleCreateTimeEntriesBatch(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+	resolver := getResolver(r.Context())
+
+	var req struct {
+		Entries []struct {
+			IssueID  int     `json:"issue_id"`
+			Hours    float64 `json:"hours"`
+			Activity string  `json:"activity"`
+			Comments string  `json:"comments"`
+			SpentOn  string  `json:"spent_on"`
+		} `json:"entries"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if len(req.Entries) == 0 {
+		writeError(w, http.StatusBadRequest, "entries is required and must not be empty")
+		return
+	}
+
+	// Resolve each activity name once for the whole batch
+	activityCache := make(map[string]int)
+
+	var created []map[string]any
+	var failures []map[string]any
+
+	for i, e := range req.Entries {
+		if e.IssueID == 0 || e.Hours == 0 {
+			failures = append(failures, map[string]any{"index": i, "error": "issue_id and hours are required"})
+			continue
+		}
+
+		params := redmine.CreateTimeEntryParams{
+			IssueID:  e.IssueID,
+			Hours:    e.Hours,
+			Comments: e.Comments,
+			SpentOn:  e.SpentOn,
+		}
+
+		if e.Activity != "" {
+			activityID, ok := activityCache[e.Activity]
+			if !ok {
+				var err error
+				activityID, err = resolver.ResolveActivity(e.Activity)
+				if err != nil {
+					failures = append(failures, map[string]any{"index": i, "issue_id": e.IssueID, "error": err.Error()})
+					continue
+				}
+				activityCache[e.Activity] = activityID
+			}
+			params.ActivityID = activityID
+		}
+
+		entry, err := client.CreateTimeEntry(params)
+		if err != nil {
+			failures = append(failures, map[string]any{"index": i, "issue_id": e.IssueID, "error": err.Error()})
+			continue
+		}
+
+		created = append(created, map[string]any{
+			"index":    i,
+			"id":       entry.ID,
+			"issue_id": e.IssueID,
+			"hours":    entry.Hours,
+			"activity": entry.Activity.Name,
+			"spent_on": entry.SpentOn,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success": created,
+		"failed":  failures,
+	})
+}
+
+// @Summary List trackers
 // @Description Returns all available trackers
 // @Tags Reference
 // @Accept json
@@ -872,7 +1372,7 @@ func (s *Server) handleListTrackers(w http.ResponseWriter, r *http.Request) {
 
 	trackers, err := client.ListTrackers()
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -904,7 +1404,7 @@ func (s *Server) handleListStatuses(w http.ResponseWriter, r *http.Request) {
 
 	statuses, err := client.ListIssueStatuses()
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -937,7 +1437,7 @@ func (s *Server) handleListActivities(w http.ResponseWriter, r *http.Request) {
 
 	activities, err := client.ListTimeEntryActivities()
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -973,8 +1473,7 @@ func (s *Server) handleListAllCustomFields(w http.ResponseWriter, r *http.Reques
 
 	fields, err := client.ListAllCustomFields()
 	if err != nil {
-		writeError(w, http.StatusInternalServerError,
-			"Requires admin privileges. Use project-specific endpoints to see fields available in a specific project. Error: "+err.Error())
+		writeRedmineError(w, fmt.Errorf("requires admin privileges; use project-specific endpoints to see fields available in a specific project: %w", err))
 		return
 	}
 
@@ -1040,7 +1539,7 @@ func (s *Server) handleGetProject(w http.ResponseWriter, r *http.Request) {
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		// Try resolving by name/identifier
-		resolver := redmine.NewResolver(client)
+		resolver := getResolver(r.Context())
 		id, err = resolver.ResolveProject(idStr)
 		if err != nil {
 			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
@@ -1050,7 +1549,7 @@ func (s *Server) handleGetProject(w http.ResponseWriter, r *http.Request) {
 
 	project, err := client.GetProjectDetail(id, []string{"trackers", "issue_custom_fields"})
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -1058,6 +1557,7 @@ func (s *Server) handleGetProject(w http.ResponseWriter, r *http.Request) {
 		"id":          project.ID,
 		"name":        project.Name,
 		"identifier":  project.Identifier,
+		"url":         client.Links().Project(project.Identifier),
 		"description": project.Description,
 		"status":      project.Status,
 	}
@@ -1099,7 +1599,7 @@ func (s *Server) handleGetProject(w http.ResponseWriter, r *http.Request) {
 // @Router /projects/{id} [patch]
 func (s *Server) handleUpdateProject(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
+	resolver := getResolver(r.Context())
 
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.Atoi(idStr)
@@ -1158,7 +1658,7 @@ func (s *Server) handleUpdateProject(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := client.UpdateProject(params); err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -1195,11 +1695,77 @@ func (s *Server) handleUpdateProject(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, result)
 }
 
+// @Summary Change project status
+// @Description Archive, unarchive, close or reopen a project. Archiving needs an administrator key and Redmine 5.0+; closing needs Redmine 5.1+
+// @Tags Projects
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Project ID or identifier"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /projects/{id}/archive [post]
+// @Router /projects/{id}/unarchive [post]
+// @Router /projects/{id}/close [post]
+// @Router /projects/{id}/reopen [post]
+func (s *Server) handleSetProjectStatus(action string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.setProjectStatus(w, r, action)
+	}
+}
+
+// @Summary Delete project
+// @Description Permanently delete a project with all its issues, wiki pages and files (admin only). Requires confirm=true
+// @Tags Projects
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Project ID or identifier"
+// @Param confirm query bool true "Must be true"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /projects/{id} [delete]
+func (s *Server) handleDeleteProject(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("confirm") != "true" {
+		writeError(w, http.StatusBadRequest, "Deleting a project permanently removes all its issues, wiki pages and files. Pass confirm=true to proceed")
+		return
+	}
+	s.setProjectStatus(w, r, "delete")
+}
+
+// setProjectStatus resolves the project in the URL and applies a lifecycle action
+func (s *Server) setProjectStatus(w http.ResponseWriter, r *http.Request, action string) {
+	client := getClient(r.Context())
+	resolver := getResolver(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := resolver.ResolveProject(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+		return
+	}
+
+	if err := client.SetProjectStatus(id, action); err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success":    true,
+		"project_id": id,
+		"action":     action,
+	})
+}
+
 // Helper functions
 
-func formatIssueAPI(issue redmine.Issue) map[string]any {
+func formatIssueAPI(issue redmine.Issue, links redmine.Links) map[string]any {
 	result := map[string]any{
 		"id":      issue.ID,
+		"url":     links.Issue(issue.ID),
 		"subject": issue.Subject,
 		"project": map[string]any{
 			"id":   issue.Project.ID,
@@ -1235,8 +1801,8 @@ func formatIssueAPI(issue redmine.Issue) map[string]any {
 	return result
 }
 
-func formatIssueDetailAPI(issue redmine.Issue) map[string]any {
-	result := formatIssueAPI(issue)
+func formatIssueDetailAPI(issue redmine.Issue, links redmine.Links) map[string]any {
+	result := formatIssueAPI(issue, links)
 	result["description"] = issue.Description
 	result["done_ratio"] = issue.DoneRatio
 
@@ -1256,10 +1822,11 @@ func formatIssueDetailAPI(issue redmine.Issue) map[string]any {
 		journals := make([]map[string]any, len(issue.Journals))
 		for i, j := range issue.Journals {
 			journals[i] = map[string]any{
-				"id":         j.ID,
-				"user":       j.User.Name,
-				"notes":      j.Notes,
-				"created_on": j.CreatedOn,
+				"id":            j.ID,
+				"user":          j.User.Name,
+				"notes":         j.Notes,
+				"private_notes": j.PrivateNotes,
+				"created_on":    j.CreatedOn,
 			}
 		}
 		result["journals"] = journals
@@ -1277,29 +1844,41 @@ func formatIssueDetailAPI(issue redmine.Issue) map[string]any {
 	}
 
 	if len(issue.Attachments) > 0 {
-		attachments := make([]map[string]any, len(issue.Attachments))
-		for i, a := range issue.Attachments {
-			attachments[i] = map[string]any{
-				"id":           a.ID,
-				"filename":     a.Filename,
-				"filesize":     a.Filesize,
-				"content_type": a.ContentType,
-				"description":  a.Description,
-				"created_on":   a.CreatedOn,
-				"author":       map[string]any{"id": a.Author.ID, "name": a.Author.Name},
-			}
-		}
-		result["attachments"] = attachments
+		result["attachments"] = formatAttachmentsAPI(issue.Attachments, links)
 	}
 
 	return result
 }
 
-func resolveCustomFieldsAPI(fields map[string]any, rules *redmine.CustomFieldRules) (map[string]any, error) {
+func formatAttachmentsAPI(attachments []redmine.Attachment, links redmine.Links) []map[string]any {
+	result := make([]map[string]any, len(attachments))
+	for i, a := range attachments {
+		result[i] = map[string]any{
+			"id":           a.ID,
+			"url":          links.Attachment(a.ID, a.Filename),
+			"filename":     a.Filename,
+			"filesize":     a.Filesize,
+			"content_type": a.ContentType,
+			"description":  a.Description,
+			"created_on":   a.CreatedOn,
+			"author":       map[string]any{"id": a.Author.ID, "name": a.Author.Name},
+		}
+	}
+	return result
+}
+
+// resolveCustomFieldsAPI maps custom field names to IDs and validates values against
+// the rules file and the field definitions (format and possible values)
+func resolveCustomFieldsAPI(fields map[string]any, rules *redmine.CustomFieldRules, defs []redmine.CustomFieldDefinition) (map[string]any, error) {
 	result := make(map[string]any)
 
-	// Build name-to-ID mapping from rules
+	// Build name-to-ID mapping from field definitions and rules
 	nameToID := make(map[string]int)
+	defsByID := make(map[int]redmine.CustomFieldDefinition, len(defs))
+	for _, def := range defs {
+		nameToID[strings.ToLower(def.Name)] = def.ID
+		defsByID[def.ID] = def
+	}
 	if rules != nil {
 		for idStr, field := range rules.Fields {
 			if id, err := strconv.Atoi(idStr); err == nil {
@@ -1332,12 +1911,18 @@ func resolveCustomFieldsAPI(fields map[string]any, rules *redmine.CustomFieldRul
 				value = corrected
 			}
 		}
+		if def, ok := defsByID[fieldID]; ok {
+			normalized, verr := redmine.NormalizeCustomFieldValue(def, value)
+			if verr != nil {
+				return nil, verr
+			}
+			value = normalized
+		}
 		result[strconv.Itoa(fieldID)] = value
 	}
 	return result, nil
 }
 
-const maxUploadSize = 5 * 1024 * 1024   // 5MB per file
 const maxMultipartMem = 10 * 1024 * 1024 // 10MB for multipart form
 
 // @Summary Upload attachment
@@ -1347,6 +1932,7 @@ const maxMultipartMem = 10 * 1024 * 1024 // 10MB for multipart form
 // @Produce json
 // @Security ApiKeyAuth
 // @Param file formData file true "File to upload"
+// @Param content_type formData string false "MIME type; defaults to the part's Content-Type, then a guess from the filename and content"
 // @Success 200 {object} map[string]any
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
@@ -1366,24 +1952,41 @@ func (s *Server) handleUploadAttachment(w http.ResponseWriter, r *http.Request)
 	}
 	defer func() { _ = file.Close() }()
 
-	if header.Size > maxUploadSize {
-		writeError(w, http.StatusBadRequest, fmt.Sprintf("File too large: %d bytes (max %d bytes / 5MB)", header.Size, maxUploadSize))
+	if err := redmine.CheckAttachmentSize(header.Filename, header.Size, s.maxAttachmentSize); err != nil {
+		writeError(w, http.StatusBadRequest, "File too large: "+err.Error())
 		return
 	}
 
-	token, err := client.UploadFile(header.Filename, file)
+	contentType := r.FormValue("content_type")
+	if contentType == "" {
+		contentType = partContentType(header)
+	}
+
+	token, err := client.UploadFile(header.Filename, contentType, file)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to upload file: "+err.Error())
 		return
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
-		"token":    token.Token,
-		"filename": token.Filename,
-		"size":     header.Size,
+		"token":        token.Token,
+		"filename":     token.Filename,
+		"content_type": token.ContentType,
+		"size":         header.Size,
 	})
 }
 
+// partContentType returns the Content-Type of a multipart file, or "" when
+// the client sent the generic application/octet-stream so that UploadFile
+// guesses a better one
+func partContentType(header *multipart.FileHeader) string {
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "application/octet-stream" {
+		return ""
+	}
+	return contentType
+}
+
 // @Summary Download attachment
 // @Description Download an attachment by ID
 // @Tags Attachments
@@ -1404,11 +2007,13 @@ func (s *Server) handleDownloadAttachment(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	data, contentType, filename, err := client.DownloadAttachment(id)
+	// Streamed straight through so large files are never held in memory
+	body, contentType, filename, size, err := client.DownloadAttachmentStream(id)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "Failed to download attachment: "+err.Error())
+		writeRedmineError(w, err)
 		return
 	}
+	defer func() { _ = body.Close() }()
 
 	if contentType != "" {
 		w.Header().Set("Content-Type", contentType)
@@ -1416,8 +2021,117 @@ func (s *Server) handleDownloadAttachment(w http.ResponseWriter, r *http.Request
 		w.Header().Set("Content-Type", "application/octet-stream")
 	}
 	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
-	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
-	_, _ = w.Write(data)
+	if size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, body); err != nil {
+		// Headers are sent, so the client only sees a truncated body
+		slog.Warn("attachment download interrupted", "attachment_id", id, "error", err)
+	}
+}
+
+// @Summary Update attachment
+// @Description Rename an attachment or change its description, returning the updated attachment
+// @Tags Attachments
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Attachment ID"
+// @Param request body object true "filename and/or description"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /attachments/{id} [patch]
+func (s *Server) handleUpdateAttachment(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid attachment ID")
+		return
+	}
+
+	// Pointer fields distinguish omitted fields from explicit empty values
+	var req struct {
+		Filename    *string `json:"filename"`
+		Description *string `json:"description"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Filename == nil && req.Description == nil {
+		writeError(w, http.StatusBadRequest, "Nothing to update: give a filename or a description")
+		return
+	}
+	if req.Filename != nil && strings.TrimSpace(*req.Filename) == "" {
+		writeError(w, http.StatusBadRequest, "filename must not be empty")
+		return
+	}
+
+	if err := client.UpdateAttachment(id, req.Filename, req.Description); err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	attachment, err := client.GetAttachment(id)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success":    true,
+		"attachment": attachment,
+		"message":    "Attachment updated successfully",
+	})
+}
+
+// @Summary Delete attachment
+// @Description Permanently delete an attachment. Only its author or an administrator can delete it
+// @Tags Attachments
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Attachment ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /attachments/{id} [delete]
+func (s *Server) handleDeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid attachment ID")
+		return
+	}
+
+	// Fetched first so the response records what was deleted
+	attachment, err := client.GetAttachment(id)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	if err := client.DeleteAttachment(id); err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success":       true,
+		"attachment_id": id,
+		"filename":      attachment.Filename,
+		"filesize":      attachment.Filesize,
+		"author":        attachment.Author,
+		"message":       "Attachment deleted successfully",
+	})
 }
 
 // @Summary List issue attachments
@@ -1429,6 +2143,7 @@ func (s *Server) handleDownloadAttachment(w http.ResponseWriter, r *http.Request
 // @Success 200 {object} map[string]any
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Router /issues/{id}/attachments [get]
 func (s *Server) handleListAttachments(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
@@ -1442,22 +2157,11 @@ func (s *Server) handleListAttachments(w http.ResponseWriter, r *http.Request) {
 
 	issue, err := client.GetIssue(id)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "Failed to get issue: "+err.Error())
+		writeRedmineError(w, fmt.Errorf("failed to get issue: %w", err))
 		return
 	}
 
-	attachments := make([]map[string]any, len(issue.Attachments))
-	for i, a := range issue.Attachments {
-		attachments[i] = map[string]any{
-			"id":           a.ID,
-			"filename":     a.Filename,
-			"filesize":     a.Filesize,
-			"content_type": a.ContentType,
-			"description":  a.Description,
-			"created_on":   a.CreatedOn,
-			"author":       map[string]any{"id": a.Author.ID, "name": a.Author.Name},
-		}
-	}
+	attachments := formatAttachmentsAPI(issue.Attachments, client.Links())
 
 	writeJSON(w, http.StatusOK, map[string]any{
 		"issue_id":    id,
@@ -1504,14 +2208,25 @@ func (s *Server) handleAttachToIssue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Upload each file and collect tokens
-	var uploads []redmine.UploadToken
+	// Check sizes before uploading anything so a rejected request leaves no
+	// orphaned uploads behind
+	var total int64
 	for _, fileHeader := range files {
-		if fileHeader.Size > maxUploadSize {
-			writeError(w, http.StatusBadRequest, fmt.Sprintf("File '%s' too large: %d bytes (max %d bytes / 5MB)", fileHeader.Filename, fileHeader.Size, maxUploadSize))
+		if err := redmine.CheckAttachmentSize(fileHeader.Filename, fileHeader.Size, s.maxAttachmentSize); err != nil {
+			writeError(w, http.StatusBadRequest, "File too large: "+err.Error())
 			return
 		}
+		total += fileHeader.Size
+	}
+	if total > s.maxAttachmentSize {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Files too large: %d files total %.1f MB, over the %d MB attachment limit",
+			len(files), float64(total)/(1024*1024), s.maxAttachmentSize/(1024*1024)))
+		return
+	}
 
+	// Upload each file and collect tokens
+	var uploads []redmine.UploadToken
+	for _, fileHeader := range files {
 		file, err := fileHeader.Open()
 		if err != nil {
 			writeError(w, http.StatusInternalServerError, "Failed to open uploaded file: "+err.Error())
@@ -1525,12 +2240,11 @@ func (s *Server) handleAttachToIssue(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		token, err := client.UploadFile(fileHeader.Filename, io.NopCloser(io.Reader(bytes.NewReader(content))))
+		token, err := client.UploadFile(fileHeader.Filename, partContentType(fileHeader), bytes.NewReader(content))
 		if err != nil {
 			writeError(w, http.StatusInternalServerError, fmt.Sprintf("Failed to upload '%s': %v", fileHeader.Filename, err))
 			return
 		}
-		token.ContentType = fileHeader.Header.Get("Content-Type")
 		uploads = append(uploads, *token)
 	}
 
@@ -1580,7 +2294,7 @@ func (s *Server) handleAttachToIssue(w http.ResponseWriter, r *http.Request) {
 // @Router /time_entries/{id} [patch]
 func (s *Server) handleUpdateTimeEntry(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
+	resolver := getResolver(r.Context())
 
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.Atoi(idStr)
@@ -1589,11 +2303,12 @@ func (s *Server) handleUpdateTimeEntry(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Pointer fields distinguish omitted fields from explicit zero/empty values
 	var req struct {
-		Hours    float64 `json:"hours"`
-		Activity string  `json:"activity"`
-		Comments string  `json:"comments"`
-		SpentOn  string  `json:"spent_on"`
+		Hours    *float64 `json:"hours"`
+		Activity string   `json:"activity"`
+		Comments *string  `json:"comments"`
+		SpentOn  *string  `json:"spent_on"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -1614,11 +2329,11 @@ func (s *Server) handleUpdateTimeEntry(w http.ResponseWriter, r *http.Request) {
 			writeError(w, http.StatusBadRequest, err.Error())
 			return
 		}
-		params.ActivityID = activityID
+		params.ActivityID = &activityID
 	}
 
 	if err := client.UpdateTimeEntry(params); err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -1650,7 +2365,7 @@ func (s *Server) handleDeleteTimeEntry(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := client.DeleteTimeEntry(id); err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -1690,7 +2405,7 @@ func (s *Server) handleRemoveWatcher(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := client.RemoveWatcher(issueID, userID); err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -1723,7 +2438,7 @@ func (s *Server) handleDeleteRelation(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := client.DeleteRelation(id); err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -1736,6 +2451,46 @@ func (s *Server) handleDeleteRelation(w http.ResponseWriter, r *http.Request) {
 
 // --- Group E: User Search ---
 
+// @Summary Get user
+// @Description Get a user with group memberships and roles per project. Login, status and last login are only returned to admins
+// @Tags Users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID, name, login or 'me'"
+// @Param project query string false "Project name or ID to look a user name up in"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /users/{id} [get]
+func (s *Server) handleGetUser(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+	resolver := getResolver(r.Context())
+
+	var projectID int
+	if project := r.URL.Query().Get("project"); project != "" {
+		id, err := resolver.ResolveProject(project)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		projectID = id
+	}
+
+	userID, err := resolver.ResolveUser(chi.URLParam(r, "id"), projectID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := client.GetUser(userID, []string{"memberships", "groups"})
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, user.Profile())
+}
+
 // @Summary Search users
 // @Description Search for users by name, optionally within a project
 // @Tags Users
@@ -1751,7 +2506,7 @@ func (s *Server) handleDeleteRelation(w http.ResponseWriter, r *http.Request) {
 // @Router /users [get]
 func (s *Server) handleSearchUsers(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
+	resolver := getResolver(r.Context())
 
 	q := r.URL.Query()
 	params := redmine.SearchUsersParams{
@@ -1781,7 +2536,7 @@ func (s *Server) handleSearchUsers(w http.ResponseWriter, r *http.Request) {
 
 	users, total, err := client.SearchUsers(params)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -1806,23 +2561,69 @@ func (s *Server) handleSearchUsers(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// --- Global Search ---
-
-// @Summary Search across all Redmine resources
-// @Description Search issues, wiki pages, news, documents, changesets, messages, and projects
-// @Tags Search
-// @Accept json
+// @Summary List groups
+// @Description Returns all user groups (requires admin privileges)
+// @Tags Users
 // @Produce json
 // @Security ApiKeyAuth
-// @Param q query string true "Search query"
-// @Param scope query string false "Search scope: all, my_projects, subprojects"
-// @Param titles_only query bool false "Match only in titles"
-// @Param issues query bool false "Include issues"
-// @Param wiki_pages query bool false "Include wiki pages"
-// @Param news query bool false "Include news"
-// @Param documents query bool false "Include documents"
-// @Param changesets query bool false "Include changesets"
-// @Param messages query bool false "Include forum messages"
+// @Param include_users query bool false "Include the users in each group"
+// @Success 200 {object} map[string]any
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /groups [get]
+func (s *Server) handleListGroups(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	groups, err := client.ListGroups()
+	if err != nil {
+		writeRedmineError(w, fmt.Errorf("requires admin privileges; use project memberships to see groups in a specific project: %w", err))
+		return
+	}
+
+	includeUsers := r.URL.Query().Get("include_users") == "true"
+	result := make([]map[string]any, len(groups))
+	for i, g := range groups {
+		result[i] = map[string]any{
+			"id":   g.ID,
+			"name": g.Name,
+		}
+		if includeUsers {
+			detail, err := client.GetGroup(g.ID, true)
+			if err != nil {
+				writeRedmineError(w, err)
+				return
+			}
+			users := detail.Users
+			if users == nil {
+				users = []redmine.IDName{}
+			}
+			result[i]["users"] = users
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"groups": result,
+		"count":  len(groups),
+	})
+}
+
+// --- Global Search ---
+
+// @Summary Search across all Redmine resources
+// @Description Search issues, wiki pages, news, documents, changesets, messages, and projects
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string true "Search query"
+// @Param scope query string false "Search scope: all, my_projects, subprojects"
+// @Param titles_only query bool false "Match only in titles"
+// @Param issues query bool false "Include issues"
+// @Param wiki_pages query bool false "Include wiki pages"
+// @Param news query bool false "Include news"
+// @Param documents query bool false "Include documents"
+// @Param changesets query bool false "Include changesets"
+// @Param messages query bool false "Include forum messages"
 // @Param projects query bool false "Include projects"
 // @Param offset query int false "Offset for pagination"
 // @Param limit query int false "Max results" default(25)
@@ -1858,6 +2659,8 @@ func (s *Server) handleGlobalSearch(w http.ResponseWriter, r *http.Request) {
 			params.Offset = n
 		}
 	}
+	// Same default page size as the search_global MCP tool
+	params.Limit = 25
 	if limit := q.Get("limit"); limit != "" {
 		if n, err := strconv.Atoi(limit); err == nil {
 			params.Limit = n
@@ -1866,7 +2669,7 @@ func (s *Server) handleGlobalSearch(w http.ResponseWriter, r *http.Request) {
 
 	results, total, err := client.GlobalSearch(params)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -1897,7 +2700,7 @@ func (s *Server) handleGlobalSearch(w http.ResponseWriter, r *http.Request) {
 // --- Group B: Batch & Copy ---
 
 // @Summary Batch update issues
-// @Description Update multiple issues at once
+// @Description Update multiple issues at once. With dry_run the updates are resolved and validated but not applied, and the changes each issue would get are returned
 // @Tags Issues
 // @Accept json
 // @Produce json
@@ -1909,7 +2712,7 @@ func (s *Server) handleGlobalSearch(w http.ResponseWriter, r *http.Request) {
 // @Router /issues/batch-update [post]
 func (s *Server) handleBatchUpdateIssues(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
+	resolver := getResolver(r.Context())
 
 	var req struct {
 		IssueIDs   []int  `json:"issue_ids"`
@@ -1917,6 +2720,7 @@ func (s *Server) handleBatchUpdateIssues(w http.ResponseWriter, r *http.Request)
 		AssignedTo string `json:"assigned_to"`
 		Priority   string `json:"priority"`
 		Notes      string `json:"notes"`
+		DryRun     bool   `json:"dry_run"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -1958,47 +2762,89 @@ func (s *Server) handleBatchUpdateIssues(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	var successIDs []int
-	var failures []map[string]any
+	base := redmine.UpdateIssueParams{
+		StatusID:     statusID,
+		PriorityID:   priorityID,
+		AssignedToID: assignedToID,
+		Notes:        req.Notes,
+	}
+	names := redmine.BatchNames{Assignee: req.AssignedTo}
+	if statusID > 0 {
+		names.Status = resolver.StatusName(statusID)
+	}
+	if priorityID > 0 {
+		names.Priority = resolver.PriorityName(priorityID)
+	}
 
+	successIDs := []int{}
+	failures := []map[string]any{}
+	changes := []map[string]any{}
 	for _, issueID := range req.IssueIDs {
-		params := redmine.UpdateIssueParams{
-			IssueID:    issueID,
-			StatusID:   statusID,
-			PriorityID: priorityID,
-			Notes:      req.Notes,
-		}
-
-		if assignedToID > 0 {
-			params.AssignedToID = assignedToID
-		} else if req.AssignedTo != "" {
-			// Need to resolve user with project context
-			issue, err := client.GetIssue(issueID)
-			if err != nil {
-				failures = append(failures, map[string]any{"id": issueID, "error": err.Error()})
-				continue
-			}
-			uid, err := resolver.ResolveUser(req.AssignedTo, issue.Project.ID)
-			if err != nil {
-				failures = append(failures, map[string]any{"id": issueID, "error": err.Error()})
-				continue
-			}
-			params.AssignedToID = uid
+		issue, params, err := s.batchIssueUpdate(client, resolver, issueID, base, req.AssignedTo)
+		if err == nil && !req.DryRun {
+			err = client.UpdateIssue(params)
 		}
-
-		if err := client.UpdateIssue(params); err != nil {
+		if err != nil {
 			failures = append(failures, map[string]any{"id": issueID, "error": err.Error()})
 			continue
 		}
 		successIDs = append(successIDs, issueID)
+
+		if req.DryRun {
+			change := map[string]any{
+				"id":      issueID,
+				"subject": issue.Subject,
+				"changes": redmine.PreviewBatchUpdate(issue, params, names),
+			}
+			if req.Notes != "" {
+				change["notes"] = redmine.NotePreview(req.Notes)
+			}
+			changes = append(changes, change)
+		}
 	}
 
+	if req.DryRun {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"dry_run": true,
+			"success": successIDs,
+			"failed":  failures,
+			"changes": changes,
+		})
+		return
+	}
 	writeJSON(w, http.StatusOK, map[string]any{
 		"success": successIDs,
 		"failed":  failures,
 	})
 }
 
+// batchIssueUpdate fetches one issue of a batch update and builds its update
+// from base, checking the status transition and resolving the assignee in the
+// issue's project. A dry run and a real run go through it alike, so a preview
+// fails exactly where the update would.
+func (s *Server) batchIssueUpdate(client *redmine.Client, resolver *redmine.Resolver, issueID int, base redmine.UpdateIssueParams, assignee string) (*redmine.Issue, redmine.UpdateIssueParams, error) {
+	params := base
+	params.IssueID = issueID
+
+	issue, err := client.GetIssue(issueID)
+	if err != nil {
+		return nil, params, err
+	}
+	if params.StatusID > 0 {
+		if err := resolver.ValidateTransition(issue, params.StatusID, s.workflow, s.config.WorkflowSource); err != nil {
+			return nil, params, fmt.Errorf("Invalid status transition: %v", err)
+		}
+	}
+	if params.AssignedToID == 0 && assignee != "" {
+		uid, err := resolver.ResolveAssignee(assignee, issue.Project.ID)
+		if err != nil {
+			return nil, params, err
+		}
+		params.AssignedToID = uid
+	}
+	return issue, params, nil
+}
+
 // @Summary Copy issue
 // @Description Copy an issue, optionally to a different project with a new subject
 // @Tags Issues
@@ -2013,7 +2859,7 @@ func (s *Server) handleBatchUpdateIssues(w http.ResponseWriter, r *http.Request)
 // @Router /issues/{id}/copy [post]
 func (s *Server) handleCopyIssue(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
+	resolver := getResolver(r.Context())
 
 	idStr := chi.URLParam(r, "id")
 	sourceID, err := strconv.Atoi(idStr)
@@ -2035,7 +2881,7 @@ func (s *Server) handleCopyIssue(w http.ResponseWriter, r *http.Request) {
 	// Get source issue
 	source, err := client.GetIssue(sourceID)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -2077,11 +2923,11 @@ func (s *Server) handleCopyIssue(w http.ResponseWriter, r *http.Request) {
 
 	issue, err := client.CreateIssue(params)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, formatIssueAPI(*issue))
+	writeJSON(w, http.StatusCreated, formatIssueAPI(*issue, client.Links()))
 }
 
 // --- Group C: Versions ---
@@ -2103,7 +2949,7 @@ func (s *Server) handleListVersions(w http.ResponseWriter, r *http.Request) {
 	projectID, err := strconv.Atoi(idStr)
 	if err != nil {
 		// Try resolving by name
-		resolver := redmine.NewResolver(client)
+		resolver := getResolver(r.Context())
 		projectID, err = resolver.ResolveProject(idStr)
 		if err != nil {
 			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
@@ -2113,7 +2959,7 @@ func (s *Server) handleListVersions(w http.ResponseWriter, r *http.Request) {
 
 	versions, err := client.ListVersions(projectID)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -2121,6 +2967,7 @@ func (s *Server) handleListVersions(w http.ResponseWriter, r *http.Request) {
 	for i, v := range versions {
 		result[i] = map[string]any{
 			"id":          v.ID,
+			"url":         client.Links().Version(v.ID),
 			"name":        v.Name,
 			"description": v.Description,
 			"status":      v.Status,
@@ -2137,6 +2984,67 @@ func (s *Server) handleListVersions(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// @Summary Version progress
+// @Description Release readiness of a version: issue counts, completion, open issues by priority, overdue issues, estimated vs spent hours and at-risk flag
+// @Tags Versions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Project ID or name"
+// @Param vid path string true "Version ID or name"
+// @Param at_risk_days query int false "At risk when fewer days remain and high priority issues are open" default(7)
+// @Param timezone query string false "IANA timezone deciding what today is, e.g. Asia/Taipei"
+// @Success 200 {object} redmine.VersionProgress
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /projects/{id}/versions/{vid}/progress [get]
+func (s *Server) handleVersionProgress(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+	resolver := getResolver(r.Context())
+
+	projectID, err := resolver.ResolveProject(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+		return
+	}
+	versionID, err := resolver.ResolveVersion(chi.URLParam(r, "vid"), projectID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid version ID or name: "+err.Error())
+		return
+	}
+
+	atRiskDays := redmine.DefaultAtRiskDays
+	if v := r.URL.Query().Get("at_risk_days"); v != "" {
+		if atRiskDays, err = strconv.Atoi(v); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid at_risk_days, expected a number of days")
+			return
+		}
+	}
+
+	today, ok := s.now(w, r.URL.Query().Get("timezone"))
+	if !ok {
+		return
+	}
+
+	version, err := client.GetVersion(versionID)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+	priorities, err := resolver.GetPriorities()
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	progress, err := redmine.BuildVersionProgress(client, projectID, *version, today, atRiskDays, redmine.HighPriorityIDs(priorities))
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, progress)
+}
+
 // @Summary Create version
 // @Description Create a new version in a project
 // @Tags Versions
@@ -2155,7 +3063,7 @@ func (s *Server) handleCreateVersion(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	projectID, err := strconv.Atoi(idStr)
 	if err != nil {
-		resolver := redmine.NewResolver(client)
+		resolver := getResolver(r.Context())
 		projectID, err = resolver.ResolveProject(idStr)
 		if err != nil {
 			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
@@ -2192,7 +3100,7 @@ func (s *Server) handleCreateVersion(w http.ResponseWriter, r *http.Request) {
 
 	version, err := client.CreateVersion(params)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -2251,7 +3159,7 @@ func (s *Server) handleUpdateVersion(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := client.UpdateVersion(params); err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
@@ -2262,197 +3170,824 @@ func (s *Server) handleUpdateVersion(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// --- Group D: Wiki ---
+// --- Issue Categories ---
 
-// @Summary List wiki pages
-// @Description List all wiki pages in a project
-// @Tags Wiki
+// formatCategoryAPI formats an issue category for API responses
+func formatCategoryAPI(c redmine.IssueCategory) map[string]any {
+	result := map[string]any{
+		"id":   c.ID,
+		"name": c.Name,
+	}
+	if c.AssignedTo.ID > 0 {
+		result["assigned_to"] = map[string]any{"id": c.AssignedTo.ID, "name": c.AssignedTo.Name}
+	}
+	return result
+}
+
+// @Summary List issue categories
+// @Description List the issue categories of a project
+// @Tags Categories
 // @Produce json
 // @Security ApiKeyAuth
-// @Param id path int true "Project ID"
+// @Param id path string true "Project ID or name"
 // @Success 200 {object} map[string]any
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
-// @Router /projects/{id}/wiki [get]
-func (s *Server) handleListWikiPages(w http.ResponseWriter, r *http.Request) {
+// @Router /projects/{id}/categories [get]
+func (s *Server) handleListCategories(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
 
-	idStr := chi.URLParam(r, "id")
-	projectID, err := strconv.Atoi(idStr)
+	projectID, err := getResolver(r.Context()).ResolveProject(chi.URLParam(r, "id"))
 	if err != nil {
-		resolver := redmine.NewResolver(client)
-		projectID, err = resolver.ResolveProject(idStr)
-		if err != nil {
-			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
-			return
-		}
+		writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+		return
 	}
 
-	pages, err := client.ListWikiPages(projectID)
+	categories, err := client.ListIssueCategories(projectID)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
-	result := make([]map[string]any, len(pages))
-	for i, p := range pages {
-		result[i] = map[string]any{
-			"title":      p.Title,
-			"version":    p.Version,
-			"created_on": p.CreatedOn,
-			"updated_on": p.UpdatedOn,
-		}
+	result := make([]map[string]any, len(categories))
+	for i, c := range categories {
+		result[i] = formatCategoryAPI(c)
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
-		"wiki_pages": result,
-		"count":      len(pages),
+		"categories": result,
+		"count":      len(categories),
 	})
 }
 
-// @Summary Get wiki page
-// @Description Get a wiki page with content
-// @Tags Wiki
+// @Summary Create issue category
+// @Description Create an issue category in a project
+// @Tags Categories
+// @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param id path int true "Project ID"
-// @Param title path string true "Wiki page title"
-// @Success 200 {object} map[string]any
+// @Param id path string true "Project ID or name"
+// @Param request body object true "Category data: name, optional assigned_to (user name or ID)"
+// @Success 201 {object} map[string]any
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
-// @Router /projects/{id}/wiki/{title} [get]
-func (s *Server) handleGetWikiPage(w http.ResponseWriter, r *http.Request) {
+// @Router /projects/{id}/categories [post]
+func (s *Server) handleCreateCategory(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
+	resolver := getResolver(r.Context())
 
-	idStr := chi.URLParam(r, "id")
-	projectID, err := strconv.Atoi(idStr)
+	projectID, err := resolver.ResolveProject(chi.URLParam(r, "id"))
 	if err != nil {
-		resolver := redmine.NewResolver(client)
-		projectID, err = resolver.ResolveProject(idStr)
-		if err != nil {
-			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
-			return
-		}
+		writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+		return
 	}
 
-	title := chi.URLParam(r, "title")
-	if title == "" {
-		writeError(w, http.StatusBadRequest, "title is required")
+	var req struct {
+		Name       string `json:"name"`
+		AssignedTo string `json:"assigned_to"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
 		return
 	}
 
-	page, err := client.GetWikiPage(projectID, title)
+	params := redmine.CreateIssueCategoryParams{
+		ProjectID: projectID,
+		Name:      req.Name,
+	}
+	if req.AssignedTo != "" {
+		userID, err := resolver.ResolveUser(req.AssignedTo, projectID)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		params.AssignedToID = userID
+	}
+
+	category, err := client.CreateIssueCategory(params)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]any{
-		"title":      page.Title,
-		"text":       page.Text,
-		"version":    page.Version,
-		"author":     map[string]any{"id": page.Author.ID, "name": page.Author.Name},
-		"comments":   page.Comments,
-		"created_on": page.CreatedOn,
-		"updated_on": page.UpdatedOn,
-	})
+	writeJSON(w, http.StatusCreated, formatCategoryAPI(*category))
 }
 
-// @Summary Create or update wiki page
-// @Description Create a new wiki page or update an existing one
-// @Tags Wiki
+// @Summary Update issue category
+// @Description Rename an issue category or change its default assignee
+// @Tags Categories
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param id path int true "Project ID"
-// @Param title path string true "Wiki page title"
-// @Param request body object true "Wiki page data"
+// @Param id path int true "Category ID"
+// @Param request body object true "Category update: name and/or assigned_to (user name or ID)"
 // @Success 200 {object} map[string]any
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
-// @Router /projects/{id}/wiki/{title} [put]
-func (s *Server) handleCreateOrUpdateWikiPage(w http.ResponseWriter, r *http.Request) {
+// @Router /categories/{id} [patch]
+func (s *Server) handleUpdateCategory(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
 
-	idStr := chi.URLParam(r, "id")
-	projectID, err := strconv.Atoi(idStr)
+	categoryID, err := strconv.Atoi(chi.URLParam(r, "id"))
 	if err != nil {
-		resolver := redmine.NewResolver(client)
-		projectID, err = resolver.ResolveProject(idStr)
-		if err != nil {
-			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
-			return
-		}
-	}
-
-	title := chi.URLParam(r, "title")
-	if title == "" {
-		writeError(w, http.StatusBadRequest, "title is required")
+		writeError(w, http.StatusBadRequest, "Invalid category ID")
 		return
 	}
 
 	var req struct {
-		Text     string `json:"text"`
-		Comments string `json:"comments"`
+		Name       string `json:"name"`
+		AssignedTo string `json:"assigned_to"`
 	}
-
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
-
-	if req.Text == "" {
-		writeError(w, http.StatusBadRequest, "text is required")
+	if req.Name == "" && req.AssignedTo == "" {
+		writeError(w, http.StatusBadRequest, "name or assigned_to is required")
 		return
 	}
 
-	params := redmine.WikiPageParams{
-		ProjectID: projectID,
-		Title:     title,
-		Text:      req.Text,
-		Comments:  req.Comments,
+	params := redmine.UpdateIssueCategoryParams{
+		CategoryID: categoryID,
+		Name:       req.Name,
+	}
+	if req.AssignedTo != "" {
+		// The category's project isn't known here, so users are searched globally
+		userID, err := getResolver(r.Context()).ResolveUser(req.AssignedTo, 0)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		params.AssignedToID = userID
 	}
 
-	if err := client.CreateOrUpdateWikiPage(params); err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+	if err := client.UpdateIssueCategory(params); err != nil {
+		writeRedmineError(w, err)
 		return
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
-		"success":    true,
-		"project_id": projectID,
-		"title":      title,
-		"message":    "Wiki page saved successfully",
+		"success":     true,
+		"category_id": categoryID,
 	})
 }
 
-// --- Group F: Export ---
-
-// @Summary Export issues as CSV
-// @Description Export issues matching filters as a CSV file
-// @Tags Issues
-// @Produce text/csv
+// @Summary Delete issue category
+// @Description Delete an issue category
+// @Tags Categories
+// @Produce json
 // @Security ApiKeyAuth
-// @Param project query string false "Project name or ID"
-// @Param tracker query string false "Tracker name or ID"
-// @Param status query string false "Status: open, closed, all, or specific name"
-// @Param assigned_to query string false "Assignee name or 'me'"
-// @Param limit query int false "Number of issues to export" default(100)
-// @Success 200 {file} binary
+// @Param id path int true "Category ID"
+// @Success 200 {object} map[string]any
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
-// @Router /issues/export.csv [get]
-func (s *Server) handleExportIssuesCSV(w http.ResponseWriter, r *http.Request) {
+// @Router /categories/{id} [delete]
+func (s *Server) handleDeleteCategory(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
-	resolver := redmine.NewResolver(client)
 
-	params := redmine.SearchIssuesParams{}
-	q := r.URL.Query()
+	categoryID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
 
-	if project := q.Get("project"); project != "" {
-		projectID, err := resolver.ResolveProject(project)
-		if err != nil {
-			writeError(w, http.StatusBadRequest, err.Error())
+	if err := client.DeleteIssueCategory(categoryID); err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success":     true,
+		"category_id": categoryID,
+	})
+}
+
+// --- Memberships ---
+
+// @Summary List project memberships
+// @Description List the users and groups that are members of a project, with their roles
+// @Tags Memberships
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Project ID or name"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /projects/{id}/memberships [get]
+func (s *Server) handleListMemberships(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	projectID, err := getResolver(r.Context()).ResolveProject(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+		return
+	}
+
+	memberships, total, err := client.GetProjectMemberships(projectID, 0, redmine.MaxMembershipsLimit)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"memberships": memberships,
+		"count":       len(memberships),
+		"total_count": total,
+	})
+}
+
+// @Summary Add project member
+// @Description Add a user or a group to a project with roles
+// @Tags Memberships
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Project ID or name"
+// @Param request body object true "Membership: user or group (name or ID) and roles (names or IDs)"
+// @Success 201 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /projects/{id}/memberships [post]
+func (s *Server) handleAddMembership(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+	resolver := getResolver(r.Context())
+
+	projectID, err := resolver.ResolveProject(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+		return
+	}
+
+	var req struct {
+		User  string   `json:"user"`
+		Group string   `json:"group"`
+		Roles []string `json:"roles"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if (req.User == "") == (req.Group == "") {
+		writeError(w, http.StatusBadRequest, "specify either user or group")
+		return
+	}
+
+	roleIDs, err := resolver.ResolveRoles(req.Roles)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var userID, groupID *int
+	if req.User != "" {
+		id, err := resolver.ResolveUser(req.User, projectID)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		userID = &id
+	} else {
+		id, err := resolver.ResolveGroup(req.Group)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		groupID = &id
+	}
+
+	membership, err := client.CreateProjectMembership(projectID, userID, groupID, roleIDs)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, membership)
+}
+
+// @Summary Update membership roles
+// @Description Replace the roles of a project membership
+// @Tags Memberships
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Membership ID"
+// @Param request body object true "roles (names or IDs)"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /memberships/{id} [patch]
+func (s *Server) handleUpdateMembership(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	membershipID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid membership ID")
+		return
+	}
+
+	var req struct {
+		Roles []string `json:"roles"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	roleIDs, err := getResolver(r.Context()).ResolveRoles(req.Roles)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := client.UpdateProjectMembership(membershipID, roleIDs); err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success":       true,
+		"membership_id": membershipID,
+	})
+}
+
+// @Summary Remove project member
+// @Description Delete a project membership
+// @Tags Memberships
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Membership ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /memberships/{id} [delete]
+func (s *Server) handleDeleteMembership(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	membershipID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid membership ID")
+		return
+	}
+
+	if err := client.DeleteProjectMembership(membershipID); err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success":       true,
+		"membership_id": membershipID,
+	})
+}
+
+// --- Group D: Wiki ---
+
+// @Summary List wiki pages
+// @Description List all wiki pages in a project
+// @Tags Wiki
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Project ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /projects/{id}/wiki [get]
+func (s *Server) handleListWikiPages(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	projectID, err := strconv.Atoi(idStr)
+	if err != nil {
+		resolver := getResolver(r.Context())
+		projectID, err = resolver.ResolveProject(idStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+			return
+		}
+	}
+
+	pages, err := client.ListWikiPages(projectID)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	result := make([]map[string]any, len(pages))
+	for i, p := range pages {
+		result[i] = map[string]any{
+			"title":      p.Title,
+			"url":        client.Links().WikiPage(strconv.Itoa(projectID), p.Title),
+			"version":    p.Version,
+			"created_on": p.CreatedOn,
+			"updated_on": p.UpdatedOn,
+		}
+		if p.Parent != nil {
+			result[i]["parent_title"] = p.Parent.Title
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"wiki_pages": result,
+		"count":      len(pages),
+	})
+}
+
+// @Summary Get wiki page
+// @Description Get a wiki page with content
+// @Tags Wiki
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Project ID"
+// @Param title path string true "Wiki page title"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /projects/{id}/wiki/{title} [get]
+func (s *Server) handleGetWikiPage(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	projectID, err := strconv.Atoi(idStr)
+	if err != nil {
+		resolver := getResolver(r.Context())
+		projectID, err = resolver.ResolveProject(idStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+			return
+		}
+	}
+
+	title := chi.URLParam(r, "title")
+	if title == "" {
+		writeError(w, http.StatusBadRequest, "title is required")
+		return
+	}
+
+	page, err := client.GetWikiPage(projectID, title)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	result := map[string]any{
+		"title":      page.Title,
+		"url":        client.Links().WikiPage(strconv.Itoa(projectID), page.Title),
+		"text":       page.Text,
+		"version":    page.Version,
+		"author":     map[string]any{"id": page.Author.ID, "name": page.Author.Name},
+		"comments":   page.Comments,
+		"created_on": page.CreatedOn,
+		"updated_on": page.UpdatedOn,
+	}
+	if page.Parent != nil {
+		result["parent_title"] = page.Parent.Title
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// @Summary Create or update wiki page
+// @Description Create a new wiki page or update an existing one
+// @Tags Wiki
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Project ID"
+// @Param title path string true "Wiki page title"
+// @Param request body object true "Wiki page data"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /projects/{id}/wiki/{title} [put]
+func (s *Server) handleCreateOrUpdateWikiPage(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	projectID, err := strconv.Atoi(idStr)
+	if err != nil {
+		resolver := getResolver(r.Context())
+		projectID, err = resolver.ResolveProject(idStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+			return
+		}
+	}
+
+	title := chi.URLParam(r, "title")
+	if title == "" {
+		writeError(w, http.StatusBadRequest, "title is required")
+		return
+	}
+
+	var req struct {
+		Text        string `json:"text"`
+		Comments    string `json:"comments"`
+		ParentTitle string `json:"parent_title"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Text == "" {
+		writeError(w, http.StatusBadRequest, "text is required")
+		return
+	}
+
+	params := redmine.WikiPageParams{
+		ProjectID:   projectID,
+		Title:       title,
+		Text:        req.Text,
+		Comments:    req.Comments,
+		ParentTitle: req.ParentTitle,
+	}
+
+	if err := client.CreateOrUpdateWikiPage(params); err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success":    true,
+		"project_id": projectID,
+		"title":      title,
+		"message":    "Wiki page saved successfully",
+	})
+}
+
+// @Summary Delete wiki page
+// @Description Delete a wiki page and its history
+// @Tags Wiki
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Project ID"
+// @Param title path string true "Wiki page title"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /projects/{id}/wiki/{title} [delete]
+func (s *Server) handleDeleteWikiPage(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	projectID, err := strconv.Atoi(idStr)
+	if err != nil {
+		resolver := getResolver(r.Context())
+		projectID, err = resolver.ResolveProject(idStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+			return
+		}
+	}
+
+	title := chi.URLParam(r, "title")
+	if title == "" {
+		writeError(w, http.StatusBadRequest, "title is required")
+		return
+	}
+
+	if err := client.DeleteWikiPage(projectID, title); err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success":    true,
+		"project_id": projectID,
+		"title":      title,
+		"message":    "Wiki page deleted successfully",
+	})
+}
+
+// --- Documents ---
+
+// documentResponse converts a document into its REST representation
+func documentResponse(doc redmine.Document) map[string]any {
+	result := map[string]any{
+		"id":          doc.ID,
+		"title":       doc.Title,
+		"description": doc.Description,
+		"category":    map[string]any{"id": doc.Category.ID, "name": doc.Category.Name},
+		"created_on":  doc.CreatedOn,
+	}
+	if doc.Project.ID > 0 {
+		result["project"] = map[string]any{"id": doc.Project.ID, "name": doc.Project.Name}
+	}
+	if len(doc.Attachments) > 0 {
+		attachments := make([]map[string]any, len(doc.Attachments))
+		for i, a := range doc.Attachments {
+			attachments[i] = map[string]any{
+				"id":           a.ID,
+				"filename":     a.Filename,
+				"filesize":     a.Filesize,
+				"content_type": a.ContentType,
+				"description":  a.Description,
+			}
+		}
+		result["attachments"] = attachments
+	}
+	return result
+}
+
+// @Summary List documents
+// @Description List documents of a project (requires the Documents module)
+// @Tags Documents
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Project ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /projects/{id}/documents [get]
+func (s *Server) handleListDocuments(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	projectID, err := strconv.Atoi(idStr)
+	if err != nil {
+		resolver := getResolver(r.Context())
+		projectID, err = resolver.ResolveProject(idStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+			return
+		}
+	}
+
+	docs, err := client.ListDocuments(projectID)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	result := make([]map[string]any, len(docs))
+	for i, doc := range docs {
+		result[i] = documentResponse(doc)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"documents": result,
+		"count":     len(docs),
+	})
+}
+
+// @Summary Get document
+// @Description Get a document with its attachments
+// @Tags Documents
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Document ID"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /documents/{id} [get]
+func (s *Server) handleGetDocument(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid document ID")
+		return
+	}
+
+	doc, err := client.GetDocument(id)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, documentResponse(*doc))
+}
+
+// @Summary Create document
+// @Description Create a document in a project (requires the Documents module)
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Project ID"
+// @Param request body object true "Document data"
+// @Success 201 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /projects/{id}/documents [post]
+func (s *Server) handleCreateDocument(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+	resolver := getResolver(r.Context())
+
+	idStr := chi.URLParam(r, "id")
+	projectID, err := strconv.Atoi(idStr)
+	if err != nil {
+		projectID, err = resolver.ResolveProject(idStr)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid project ID or name: "+err.Error())
+			return
+		}
+	}
+
+	var req struct {
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		Category     string `json:"category"`
+		UploadTokens []struct {
+			Token       string `json:"token"`
+			Filename    string `json:"filename"`
+			ContentType string `json:"content_type"`
+			Description string `json:"description"`
+		} `json:"upload_tokens"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Title == "" {
+		writeError(w, http.StatusBadRequest, "title is required")
+		return
+	}
+
+	params := redmine.CreateDocumentParams{
+		ProjectID:   projectID,
+		Title:       req.Title,
+		Description: req.Description,
+	}
+
+	if req.Category != "" {
+		params.CategoryID, err = resolver.ResolveDocumentCategory(req.Category)
+	} else {
+		params.CategoryID, err = resolver.DefaultDocumentCategory()
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, t := range req.UploadTokens {
+		params.Uploads = append(params.Uploads, redmine.UploadToken{
+			Token:       t.Token,
+			Filename:    t.Filename,
+			ContentType: t.ContentType,
+			Description: t.Description,
+		})
+	}
+
+	doc, err := client.CreateDocument(params)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, documentResponse(*doc))
+}
+
+// --- Group F: Export ---
+
+// @Summary Export issues
+// @Description Export issues matching filters as CSV, JSON Lines or a Markdown table
+// @Tags Issues
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce text/markdown
+// @Security ApiKeyAuth
+// @Param project query string false "Project name or ID"
+// @Param tracker query string false "Tracker name or ID"
+// @Param status query string false "Status: open, closed, all, or specific name"
+// @Param assigned_to query string false "Assignee name or 'me'"
+// @Param limit query int false "Number of issues to export" default(100)
+// @Param format query string false "Output format" Enums(csv, jsonl, markdown) default(csv)
+// @Param columns query string false "Comma-separated columns for csv and markdown, built-in or custom field names (e.g. id,subject,status,Severity)"
+// @Param include_description query bool false "Add the description as a last column"
+// @Success 200 {file} binary
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /issues/export [get]
+// @Router /issues/export.csv [get]
+func (s *Server) handleExportIssues(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+	resolver := getResolver(r.Context())
+
+	params := redmine.SearchIssuesParams{}
+	q := r.URL.Query()
+
+	format, err := redmine.ParseExportFormat(q.Get("format"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defaults := redmine.DefaultCSVColumns
+	if format == redmine.ExportMarkdown {
+		defaults = redmine.DefaultMarkdownColumns
+	}
+
+	if project := q.Get("project"); project != "" {
+		projectID, err := resolver.ResolveProject(project)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
 			return
 		}
 		params.ProjectID = strconv.Itoa(projectID)
@@ -2505,41 +4040,42 @@ func (s *Server) handleExportIssuesCSV(w http.ResponseWriter, r *http.Request) {
 
 	params.Sort = q.Get("sort")
 
+	projectID, _ := strconv.Atoi(params.ProjectID)
+	columns, err := redmine.ParseExportColumns(q.Get("columns"), defaults, func(name string) (int, error) {
+		return resolver.ResolveCustomFieldByName(name, projectID, params.TrackerID)
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if q.Get("include_description") == "true" {
+		columns = redmine.WithDescriptionColumn(columns)
+	}
+
 	issues, _, err := client.SearchIssues(params)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
-	// Build CSV
 	var buf bytes.Buffer
-	csvWriter := csv.NewWriter(&buf)
-
-	// Header
-	_ = csvWriter.Write([]string{"ID", "Subject", "Project", "Tracker", "Status", "Priority", "Assignee", "Created", "Updated"})
-
-	for _, issue := range issues {
-		assignee := ""
-		if issue.AssignedTo != nil {
-			assignee = issue.AssignedTo.Name
-		}
-		_ = csvWriter.Write([]string{
-			strconv.Itoa(issue.ID),
-			issue.Subject,
-			issue.Project.Name,
-			issue.Tracker.Name,
-			issue.Status.Name,
-			issue.Priority.Name,
-			assignee,
-			issue.CreatedOn,
-			issue.UpdatedOn,
+	switch format {
+	case redmine.ExportJSONL:
+		err = redmine.WriteIssuesJSONL(&buf, issues, func(issue redmine.Issue) map[string]any {
+			return formatIssueAPI(issue, client.Links())
 		})
+	case redmine.ExportMarkdown:
+		err = redmine.WriteIssuesMarkdown(&buf, issues, columns)
+	default:
+		err = redmine.WriteIssuesCSV(&buf, issues, columns)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("failed to write %s: %v", format, err))
+		return
 	}
 
-	csvWriter.Flush()
-
-	w.Header().Set("Content-Type", "text/csv")
-	w.Header().Set("Content-Disposition", "attachment; filename=issues.csv")
+	w.Header().Set("Content-Type", redmine.ExportContentType(format))
+	w.Header().Set("Content-Disposition", "attachment; filename=issues."+redmine.ExportFileExtension(format))
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write(buf.Bytes())
 }
@@ -2547,92 +4083,82 @@ func (s *Server) handleExportIssuesCSV(w http.ResponseWriter, r *http.Request) {
 // --- Group G: Reports ---
 
 // @Summary Weekly report
-// @Description Generate a weekly time report for a user
+// @Description Generate a weekly time report for a user. The week, daily_totals, project_totals and entries keys are deprecated in favor of period and the by_* breakdowns
 // @Tags Reports
 // @Produce json
 // @Security ApiKeyAuth
-// @Param user query string false "User name or 'me'" default(me)
+// @Param user query string false "User name, ID or 'me'" default(me)
 // @Param week_of query string false "Date within the week (YYYY-MM-DD), defaults to current week"
+// @Param timezone query string false "IANA timezone deciding what today is, e.g. Asia/Taipei"
+// @Param include_previous_week query bool false "Compare with the week before, overall and per project"
+// @Param include_weekend query bool false "Cover every day of the week, not only the work days"
 // @Success 200 {object} map[string]any
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Router /reports/weekly [get]
 func (s *Server) handleWeeklyReport(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
+	resolver := getResolver(r.Context())
 
 	q := r.URL.Query()
 
-	// Determine user
-	userParam := q.Get("user")
-	if userParam == "" {
-		userParam = "me"
-	}
-
-	var userID string
-	if userParam == "me" {
-		userID = "me"
-	} else {
-		if id, err := strconv.Atoi(userParam); err == nil {
-			userID = strconv.Itoa(id)
-		} else {
-			// Try to resolve user name -- need a project context or admin API
-			// For reports, use "me" as fallback
-			userID = "me"
-		}
+	weekOf, ok := s.now(w, q.Get("timezone"))
+	if !ok {
+		return
 	}
-
-	// Determine week boundaries (Monday to Friday)
-	now := time.Now()
-	weekOf := q.Get("week_of")
-	var refDate time.Time
-	if weekOf != "" {
-		parsed, err := time.Parse("2006-01-02", weekOf)
+	if v := q.Get("week_of"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
 		if err != nil {
 			writeError(w, http.StatusBadRequest, "Invalid week_of date format, expected YYYY-MM-DD")
 			return
 		}
-		refDate = parsed
-	} else {
-		refDate = now
+		weekOf = parsed
 	}
 
-	// Calculate Monday of the week
-	weekday := refDate.Weekday()
-	if weekday == time.Sunday {
-		weekday = 7
+	user, ok := resolveReportUser(w, resolver, q.Get("user"))
+	if !ok {
+		return
 	}
-	monday := refDate.AddDate(0, 0, -int(weekday-time.Monday))
-	friday := monday.AddDate(0, 0, 4)
 
-	mondayStr := monday.Format("2006-01-02")
-	fridayStr := friday.Format("2006-01-02")
-
-	// Fetch time entries for the week
-	teParams := redmine.ListTimeEntriesParams{
-		UserID: userID,
-		From:   mondayStr,
-		To:     fridayStr,
-		Limit:  100,
+	opts := redmine.WeeklyOptions{
+		IncludePreviousWeek: q.Get("include_previous_week") == "true",
+		IncludeWeekend:      q.Get("include_weekend") == "true",
 	}
-
-	entries, _, err := client.ListTimeEntries(teParams)
+	report, err := redmine.BuildWeeklyReport(client, user, weekOf, s.week, opts)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
 		return
 	}
 
-	// Aggregate by day and by project/issue
-	dailyTotals := make(map[string]float64)
-	projectTotals := make(map[string]float64)
-	var totalHours float64
-	entryDetails := make([]map[string]any, len(entries))
+	writeJSON(w, http.StatusOK, newWeeklyReportResponse(report))
+}
 
-	for i, e := range entries {
-		dailyTotals[e.SpentOn] += e.Hours
-		projectTotals[e.Project.Name] += e.Hours
-		totalHours += e.Hours
+// weeklyReportResponse is the weekly report with the keys this endpoint
+// returned before it shared the MCP report, kept for existing clients
+type weeklyReportResponse struct {
+	*redmine.WeeklyReport
+	Week          string             `json:"week"`           // Deprecated: use period
+	DailyTotals   map[string]float64 `json:"daily_totals"`   // Deprecated: use by_day
+	ProjectTotals map[string]float64 `json:"project_totals"` // Deprecated: use by_project
+	Entries       []map[string]any   `json:"entries"`        // Deprecated: use by_issue and by_activity
+}
 
-		detail := map[string]any{
+func newWeeklyReportResponse(report *redmine.WeeklyReport) weeklyReportResponse {
+	resp := weeklyReportResponse{
+		WeeklyReport:  report,
+		Week:          strings.Replace(report.Period, " ~ ", " to ", 1),
+		DailyTotals:   make(map[string]float64, len(report.ByDay)),
+		ProjectTotals: make(map[string]float64, len(report.ByProject)),
+		Entries:       make([]map[string]any, len(report.Entries)),
+	}
+	for _, day := range report.ByDay {
+		resp.DailyTotals[day.Date] = day.Hours
+	}
+	for _, project := range report.ByProject {
+		resp.ProjectTotals[project.Project] = project.Hours
+	}
+	for i, e := range report.Entries {
+		entry := map[string]any{
 			"id":       e.ID,
 			"project":  e.Project.Name,
 			"hours":    e.Hours,
@@ -2641,142 +4167,209 @@ func (s *Server) handleWeeklyReport(w http.ResponseWriter, r *http.Request) {
 			"spent_on": e.SpentOn,
 		}
 		if e.Issue != nil {
-			detail["issue_id"] = e.Issue.ID
+			entry["issue_id"] = e.Issue.ID
 		}
-		entryDetails[i] = detail
+		resp.Entries[i] = entry
 	}
-
-	writeJSON(w, http.StatusOK, map[string]any{
-		"week":           mondayStr + " to " + fridayStr,
-		"user":           userParam,
-		"total_hours":    totalHours,
-		"daily_totals":   dailyTotals,
-		"project_totals": projectTotals,
-		"entries":        entryDetails,
-		"entry_count":    len(entries),
-	})
+	return resp
 }
 
 // @Summary Standup report
-// @Description Generate a standup report showing yesterday's work and today's open issues
+// @Description Generate a standup report showing yesterday's time entries and issue activity and today's open issues. yesterday.work is deprecated in favor of yesterday.entries
 // @Tags Reports
 // @Produce json
 // @Security ApiKeyAuth
-// @Param user query string false "User name or 'me'" default(me)
+// @Param user query string false "User name, ID or 'me'" default(me)
 // @Param date query string false "Date for the report (YYYY-MM-DD), defaults to today"
+// @Param timezone query string false "IANA timezone deciding what today is, e.g. Asia/Taipei"
+// @Param include_time_entries query bool false "Include yesterday's time entries" default(true)
 // @Success 200 {object} map[string]any
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
 // @Router /reports/standup [get]
 func (s *Server) handleStandupReport(w http.ResponseWriter, r *http.Request) {
 	client := getClient(r.Context())
+	resolver := getResolver(r.Context())
 
 	q := r.URL.Query()
 
-	// Determine user
-	userParam := q.Get("user")
-	if userParam == "" {
-		userParam = "me"
+	today, ok := s.now(w, q.Get("timezone"))
+	if !ok {
+		return
 	}
-
-	// Determine date
-	now := time.Now()
-	dateStr := q.Get("date")
-	var today time.Time
-	if dateStr != "" {
-		parsed, err := time.Parse("2006-01-02", dateStr)
+	if v := q.Get("date"); v != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", v, today.Location())
 		if err != nil {
 			writeError(w, http.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD")
 			return
 		}
 		today = parsed
-	} else {
-		today = now
 	}
 
-	// Calculate yesterday (skip weekends)
-	yesterday := today.AddDate(0, 0, -1)
-	if yesterday.Weekday() == time.Sunday {
-		yesterday = yesterday.AddDate(0, 0, -2) // Go to Friday
-	} else if yesterday.Weekday() == time.Saturday {
-		yesterday = yesterday.AddDate(0, 0, -1) // Go to Friday
+	user, ok := resolveReportUser(w, resolver, q.Get("user"))
+	if !ok {
+		return
 	}
 
-	todayStr := today.Format("2006-01-02")
-	yesterdayStr := yesterday.Format("2006-01-02")
-
-	// Fetch yesterday's time entries
-	teParams := redmine.ListTimeEntriesParams{
-		UserID: userParam,
-		From:   yesterdayStr,
-		To:     yesterdayStr,
-		Limit:  100,
+	opts := redmine.StandupOptions{IncludeTimeEntries: q.Get("include_time_entries") != "false"}
+	report, err := redmine.BuildStandupReport(client, user, today, s.week, opts)
+	if err != nil {
+		writeRedmineError(w, err)
+		return
 	}
 
-	yesterdayEntries, _, err := client.ListTimeEntries(teParams)
+	writeJSON(w, http.StatusOK, standupReportResponse{
+		StandupReport: report,
+		Yesterday:     standupDayResponse{StandupDay: report.Yesterday, Work: report.Yesterday.Entries},
+	})
+}
+
+// standupReportResponse is the standup report with the keys this endpoint
+// returned before it shared the MCP report, kept for existing clients
+type standupReportResponse struct {
+	*redmine.StandupReport
+	Yesterday standupDayResponse `json:"yesterday"`
+}
+
+type standupDayResponse struct {
+	redmine.StandupDay
+	Work []redmine.StandupEntry `json:"work,omitempty"` // Deprecated: use entries
+}
+
+// @Summary Time entry report
+// @Description Sum time entries by project, user, activity, issue or date, like the timeEntries_report MCP tool
+// @Tags Reports
+// @Produce json
+// @Produce text/csv
+// @Security ApiKeyAuth
+// @Param group_by query string true "Comma-separated dimensions: project, user, activity, issue, date"
+// @Param project query string false "Project name or ID"
+// @Param user query string false "User name, ID or 'me'"
+// @Param from query string false "Start date (YYYY-MM-DD or relative: today, '7 days ago', start_of_month, 2024-W12, ...)"
+// @Param to query string false "End date (YYYY-MM-DD or relative like from)"
+// @Param period query string false "Date shortcut such as this_week, last_month or last_30_days; from and to override its ends"
+// @Param timezone query string false "IANA timezone deciding what today is, e.g. Asia/Taipei"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /reports/time [get]
+func (s *Server) handleTimeEntryReport(w http.ResponseWriter, r *http.Request) {
+	client := getClient(r.Context())
+	q := r.URL.Query()
+
+	groupBy, err := redmine.ParseTimeEntryGroupBy(q.Get("group_by"))
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	var yesterdayHours float64
-	yesterdayWork := make([]map[string]any, len(yesterdayEntries))
-	for i, e := range yesterdayEntries {
-		yesterdayHours += e.Hours
-		work := map[string]any{
-			"project":  e.Project.Name,
-			"hours":    e.Hours,
-			"activity": e.Activity.Name,
-			"comments": e.Comments,
-		}
-		if e.Issue != nil {
-			work["issue_id"] = e.Issue.ID
-		}
-		yesterdayWork[i] = work
+	format := q.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid format: %s (valid: json, csv)", format))
+		return
 	}
 
-	// Fetch today's open issues assigned to user
-	issueParams := redmine.SearchIssuesParams{
-		AssignedToID: userParam,
-		StatusID:     "open",
-		Limit:        25,
-		Sort:         "priority:desc,updated_on:desc",
+	params, ok := s.timeEntryFilters(w, getResolver(r.Context()), q)
+	if !ok {
+		return
 	}
 
-	todayIssues, _, err := client.SearchIssues(issueParams)
+	report, err := redmine.BuildTimeEntryReport(client, params, groupBy)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeRedmineError(w, err)
+		return
+	}
+
+	if format == "json" {
+		writeJSON(w, http.StatusOK, report)
+		return
+	}
+
+	data, err := report.CSV()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to generate CSV: "+err.Error())
 		return
 	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename=time_report.csv")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// now returns the current time in the timezone query parameter, else the
+// configured timezone, writing a 400 for an unknown zone. It reports whether
+// the caller should continue.
+func (s *Server) now(w http.ResponseWriter, timezone string) (time.Time, bool) {
+	loc := s.location
+	if timezone != "" {
+		var err error
+		if loc, err = redmine.LoadTimezone(timezone); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return time.Time{}, false
+		}
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.Now().In(loc), true
+}
 
-	openIssues := make([]map[string]any, len(todayIssues))
-	for i, issue := range todayIssues {
-		openIssues[i] = map[string]any{
-			"id":       issue.ID,
-			"subject":  issue.Subject,
-			"project":  issue.Project.Name,
-			"tracker":  issue.Tracker.Name,
-			"status":   issue.Status.Name,
-			"priority": issue.Priority.Name,
+// dateRange resolves the period, from and to query parameters to
+// YYYY-MM-DD, accepting the relative expressions of redmine.ParseDateRange;
+// from and to override the matching end of the period. It writes a 400 for
+// invalid values and reports whether the caller should continue.
+func (s *Server) dateRange(w http.ResponseWriter, q url.Values) (from, to string, ok bool) {
+	now, ok := s.now(w, q.Get("timezone"))
+	if !ok {
+		return "", "", false
+	}
+	for _, key := range []string{"period", "from", "to"} {
+		expr := q.Get(key)
+		if expr == "" {
+			continue
+		}
+		start, end, err := redmine.ParseDateRange(expr, now, s.week)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s: %v", key, err))
+			return "", "", false
 		}
-		if issue.AssignedTo != nil {
-			openIssues[i]["assigned_to"] = issue.AssignedTo.Name
+		switch key {
+		case "period":
+			from, to = start.Format(redmine.DateLayout), end.Format(redmine.DateLayout)
+		case "from":
+			from = start.Format(redmine.DateLayout)
+		case "to":
+			to = end.Format(redmine.DateLayout)
 		}
 	}
+	return from, to, true
+}
 
-	writeJSON(w, http.StatusOK, map[string]any{
-		"date": todayStr,
-		"user": userParam,
-		"yesterday": map[string]any{
-			"date":        yesterdayStr,
-			"total_hours": yesterdayHours,
-			"work":        yesterdayWork,
-			"entry_count": len(yesterdayEntries),
-		},
-		"today": map[string]any{
-			"open_issues": openIssues,
-			"issue_count": len(todayIssues),
-		},
-	})
+// resolveReportUser resolves the user query parameter of a report, writing a 400
+// when no user or several match the name, or when the key may not look users
+// up by name, which asks for a user ID instead; other Redmine failures keep
+// their status. It reports whether the caller should continue.
+func resolveReportUser(w http.ResponseWriter, resolver *redmine.Resolver, user string) (redmine.ReportUser, bool) {
+	resolved, err := resolver.ResolveReportUser(user)
+	if err != nil {
+		var (
+			resolveErr *redmine.ResolveError
+			lookupErr  *redmine.UserLookupError
+		)
+		apiErr, isAPIErr := redmine.AsAPIError(err)
+		switch {
+		case errors.As(err, &resolveErr),
+			errors.As(err, &lookupErr) && isAPIErr && apiErr.StatusCode == http.StatusForbidden:
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeRedmineError(w, err)
+		}
+		return redmine.ReportUser{}, false
+	}
+	return resolved, true
 }
 
@@ -1,9 +1,21 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
 )
 
 func TestHealthCheck(t *testing.T) {
@@ -90,3 +102,1720 @@ func TestSwaggerDocs(t *testing.T) {
 		t.Errorf("expected Content-Type 'application/yaml', got '%s'", contentType)
 	}
 }
+
+func TestOpenAPIJSON_Enums(t *testing.T) {
+	var fetches int
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/trackers.json":
+			fetches++
+			_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"},{"id":2,"name":"Feature"}]}`))
+		case "/issue_statuses.json":
+			_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":5,"name":"Closed"}]}`))
+		case "/enumerations/issue_priorities.json":
+			_, _ = w.Write([]byte(`{"issue_priorities":[{"id":2,"name":"Normal"}]}`))
+		default:
+			// Activities fail, so that field stays a free string
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	fetch := func(server *Server) map[string]any {
+		req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/json" {
+			t.Fatalf("expected a JSON spec, got %d %s", w.Code, w.Header().Get("Content-Type"))
+		}
+		var doc map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		return doc
+	}
+	queryParam := func(doc map[string]any, path, name string) map[string]any {
+		for _, p := range doc["paths"].(map[string]any)[path].(map[string]any)["get"].(map[string]any)["parameters"].([]any) {
+			if param := p.(map[string]any); param["name"] == name {
+				return param["schema"].(map[string]any)
+			}
+		}
+		t.Fatalf("no %s parameter on %s", name, path)
+		return nil
+	}
+	bodyProps := func(doc map[string]any, path, method string) map[string]any {
+		op := doc["paths"].(map[string]any)[path].(map[string]any)[method].(map[string]any)
+		return op["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)["properties"].(map[string]any)
+	}
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080, APIKey: "server-key"})
+	doc := fetch(server)
+
+	if got := fmt.Sprint(queryParam(doc, "/issues", "tracker")["enum"]); got != "[Bug Feature]" {
+		t.Errorf("expected tracker names as the enum, got %s", got)
+	}
+	if got := fmt.Sprint(queryParam(doc, "/issues", "status")["enum"]); got != "[open closed all New Closed]" {
+		t.Errorf("expected status filters and names as the enum, got %s", got)
+	}
+	if _, ok := queryParam(doc, "/users", "status")["enum"]; ok {
+		t.Error("expected the integer user status to be left alone")
+	}
+	if got := fmt.Sprint(bodyProps(doc, "/issues/batch-update", "post")["priority"].(map[string]any)["enum"]); got != "[Normal]" {
+		t.Errorf("expected priority names as the enum, got %s", got)
+	}
+	if got := fmt.Sprint(bodyProps(doc, "/versions/{id}", "patch")["status"].(map[string]any)["enum"]); got != "[open locked closed]" {
+		t.Errorf("expected the version status enum to be kept, got %s", got)
+	}
+	if _, ok := bodyProps(doc, "/time_entries", "post")["activity"].(map[string]any)["enum"]; ok {
+		t.Error("expected no activity enum when the lookup fails")
+	}
+
+	// The spec is cached until the TTL expires
+	fetch(server)
+	if fetches != 1 {
+		t.Errorf("expected one tracker lookup, got %d", fetches)
+	}
+	server.openAPI.generatedAt = time.Now().Add(-2 * server.openAPI.ttl)
+	fetch(server)
+	if fetches != 2 {
+		t.Errorf("expected the spec to be regenerated after the TTL, got %d lookups", fetches)
+	}
+
+	static := fetch(NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080, APIKey: "server-key", StaticOpenAPI: true}))
+	if _, ok := queryParam(static, "/issues", "tracker")["enum"]; ok || fetches != 2 {
+		t.Errorf("expected the static spec without lookups, got %d lookups", fetches)
+	}
+}
+
+func TestUpdateIssue_ClearFields(t *testing.T) {
+	var got map[string]map[string]any
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut && r.URL.Path == "/issues/7.json" {
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &got)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.URL.Path == "/issues/7.json" {
+			_, _ = w.Write([]byte(`{"issue":{"id":7,"project":{"id":1,"name":"P"},"tracker":{"id":1,"name":"Bug"},"status":{"id":1,"name":"New"}}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{
+		RedmineURL: mockRedmine.URL,
+		Port:       8080,
+	})
+
+	body := `{"clear_assigned_to":true,"clear_due_date":true,"clear_description":true}`
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/issues/7", strings.NewReader(body))
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	for _, key := range []string{"assigned_to_id", "due_date", "description"} {
+		if v, ok := got["issue"][key]; !ok || v != "" {
+			t.Errorf("expected %q to be sent as empty string, got %v (present=%v)", key, v, ok)
+		}
+	}
+}
+
+func TestUpdateIssue_SetAndClearConflict(t *testing.T) {
+	server := NewServer(Config{
+		RedmineURL: "http://localhost",
+		Port:       8080,
+	})
+
+	body := `{"due_date":"2025-01-01","clear_due_date":true}`
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/issues/7", strings.NewReader(body))
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestUpdateIssue_TransitionError(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/issue_statuses.json":
+			_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":2,"name":"In Progress"},{"id":5,"name":"Closed"}]}`))
+		case r.URL.Path == "/issues/7.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":7,"project":{"id":1,"name":"P"},"tracker":{"id":4,"name":"Bug"},"status":{"id":1,"name":"New"}}}`))
+		case r.URL.Path == "/issues/8.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":8,"project":{"id":1,"name":"P"},"tracker":{"id":9,"name":"Task"},"status":{"id":1,"name":"New"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{
+		RedmineURL: mockRedmine.URL,
+		Port:       8080,
+	})
+	server.workflow = &redmine.WorkflowRules{
+		Trackers: map[string]redmine.WorkflowTracker{
+			"4": {
+				Name: "Bug",
+				Statuses: map[string]redmine.WorkflowStatus{
+					"1": {Name: "New"},
+					"2": {Name: "In Progress"},
+					"5": {Name: "Closed", IsClosed: true},
+				},
+				Transitions: map[string][]int{"1": {2}},
+			},
+		},
+	}
+
+	patch := func(issueID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/issues/"+issueID, strings.NewReader(`{"status":"Closed"}`))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := patch("7")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+	var body struct {
+		Error           string           `json:"error"`
+		CurrentStatus   redmine.IDName   `json:"current_status"`
+		AllowedStatuses []redmine.IDName `json:"allowed_statuses"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if body.CurrentStatus.Name != "New" {
+		t.Errorf("expected current_status New, got %+v", body.CurrentStatus)
+	}
+	if len(body.AllowedStatuses) != 1 || body.AllowedStatuses[0].Name != "In Progress" {
+		t.Errorf("expected allowed [In Progress], got %+v", body.AllowedStatuses)
+	}
+	if !strings.Contains(body.Error, "allowed: In Progress") {
+		t.Errorf("expected readable error message, got %q", body.Error)
+	}
+
+	// Trackers without rules skip validation
+	if w := patch("8"); w.Code != http.StatusOK {
+		t.Errorf("expected status %d for tracker without rules, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+}
+
+func TestListGroups(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/groups.json" {
+			_, _ = w.Write([]byte(`{"groups":[{"id":5,"name":"Firmware Team"},{"id":6,"name":"QA Team"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{
+		RedmineURL: mockRedmine.URL,
+		Port:       8080,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/groups", nil)
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp struct {
+		Groups []map[string]any `json:"groups"`
+		Count  int              `json:"count"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if resp.Count != 2 || resp.Groups[1]["name"] != "QA Team" {
+		t.Errorf("unexpected response: %s", w.Body.String())
+	}
+	if _, ok := resp.Groups[0]["users"]; ok {
+		t.Errorf("users should only be included when include_users=true")
+	}
+}
+
+func TestRedmineErrorStatuses(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":1,"login":"alice"}}`))
+		case "/groups.json", "/custom_fields.json":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	tests := []struct {
+		path     string
+		wantCode int
+		wantText string
+	}{
+		{"/api/v1/groups", http.StatusForbidden, "requires admin privileges"},
+		{"/api/v1/custom_fields", http.StatusForbidden, "requires admin privileges"},
+		{"/api/v1/issues/99/attachments", http.StatusNotFound, "failed to get issue"},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		if w.Code != tt.wantCode || !strings.Contains(w.Body.String(), tt.wantText) {
+			t.Errorf("%s: expected %d with %q, got %d: %s", tt.path, tt.wantCode, tt.wantText, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestDeleteWikiPage(t *testing.T) {
+	deleted := false
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete && r.URL.Path == "/projects/1/wiki/Obsolete.json" {
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{
+		RedmineURL: mockRedmine.URL,
+		Port:       8080,
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/projects/1/wiki/Obsolete", nil)
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if !deleted {
+		t.Error("expected wiki page to be deleted in Redmine")
+	}
+}
+
+func TestGetDocument(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/documents/7.json" {
+			_, _ = w.Write([]byte(`{"document":{"id":7,"title":"Spec","project":{"id":1,"name":"Alpha"},"category":{"id":2,"name":"Technical"},"attachments":[{"id":3,"filename":"spec.pdf","filesize":10}]}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{
+		RedmineURL: mockRedmine.URL,
+		Port:       8080,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/documents/7", nil)
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Title       string           `json:"title"`
+		Attachments []map[string]any `json:"attachments"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Title != "Spec" || len(resp.Attachments) != 1 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestRedmineErrorStatusPassthrough(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/issues/404.json":
+			w.WriteHeader(http.StatusNotFound)
+		case "/issues.json":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors":["Subject cannot be blank"]}`))
+		case "/trackers.json":
+			_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{
+		RedmineURL: mockRedmine.URL,
+		Port:       8080,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/issues/404", nil)
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/issues", strings.NewReader(`{"project":"1","tracker":"Bug","subject":"x"}`))
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	}
+	var resp struct {
+		Errors []string `json:"errors"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0] != "Subject cannot be blank" {
+		t.Errorf("expected validation errors in response, got %v", resp.Errors)
+	}
+}
+
+func TestCreateIssue_CustomFieldFormats(t *testing.T) {
+	var created map[string]map[string]any
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/trackers.json":
+			_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+		case "/projects/1.json":
+			_, _ = w.Write([]byte(`{"project":{"id":1,"issue_custom_fields":[{"id":4,"name":"Regression"}]}}`))
+		case "/custom_fields.json":
+			_, _ = w.Write([]byte(`{"custom_fields":[{"id":4,"name":"Regression","customized_type":"issue","field_format":"bool"}]}`))
+		case "/issues.json":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"issue":{"id":5,"subject":"x"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/issues", strings.NewReader(body))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"project":"1","tracker":"Bug","subject":"x","custom_fields":{"Regression":"perhaps"}}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "Expected a boolean") {
+		t.Fatalf("expected boolean validation error, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = post(`{"project":"1","tracker":"Bug","subject":"x","custom_fields":{"Regression":"yes"}}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	cfs, _ := created["issue"]["custom_fields"].([]any)
+	if len(cfs) != 1 || cfs[0].(map[string]any)["value"] != "1" {
+		t.Errorf("expected normalized boolean, got %v", created["issue"]["custom_fields"])
+	}
+}
+
+func TestReports_ResolveUserName(t *testing.T) {
+	var timeEntryUser, assignedTo string
+	usersStatus := http.StatusOK
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users.json":
+			if usersStatus != http.StatusOK {
+				w.WriteHeader(usersStatus)
+				return
+			}
+			_, _ = w.Write([]byte(`{"users":[{"id":7,"login":"bob","firstname":"Bob","lastname":"Jones"}],"total_count":1}`))
+		case "/time_entries.json":
+			timeEntryUser = r.URL.Query().Get("user_id")
+			_, _ = w.Write([]byte(`{"time_entries":[{"id":1,"project":{"id":1,"name":"Web"},"activity":{"id":9,"name":"Dev"},"hours":2,"spent_on":"2025-03-04"}],"total_count":1}`))
+		case "/issues.json":
+			assignedTo = r.URL.Query().Get("assigned_to_id")
+			_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/v1/reports/weekly?user=bob&week_of=2025-03-05")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if timeEntryUser != "7" {
+		t.Errorf("expected time entries filtered by user 7, got %q", timeEntryUser)
+	}
+	var weekly redmine.WeeklyReport
+	if err := json.NewDecoder(w.Body).Decode(&weekly); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if weekly.TotalHours != 2 || weekly.Period != "2025-03-03 ~ 2025-03-07" {
+		t.Errorf("unexpected weekly report: %+v", weekly)
+	}
+
+	// The keys the endpoint returned before are kept for existing clients
+	var legacy struct {
+		Week          string             `json:"week"`
+		DailyTotals   map[string]float64 `json:"daily_totals"`
+		ProjectTotals map[string]float64 `json:"project_totals"`
+		Entries       []map[string]any   `json:"entries"`
+	}
+	w = get("/api/v1/reports/weekly?user=bob&week_of=2025-03-05")
+	if err := json.NewDecoder(w.Body).Decode(&legacy); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if legacy.Week != "2025-03-03 to 2025-03-07" || legacy.DailyTotals["2025-03-04"] != 2 || legacy.ProjectTotals["Web"] != 2 ||
+		len(legacy.Entries) != 1 || legacy.Entries[0]["activity"] != "Dev" {
+		t.Errorf("unexpected legacy weekly keys: %+v", legacy)
+	}
+
+	w = get("/api/v1/reports/standup?user=Bob%20Jones&date=2025-03-05")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if timeEntryUser != "7" || assignedTo != "7" {
+		t.Errorf("expected filters on user 7, got user_id=%q assigned_to_id=%q", timeEntryUser, assignedTo)
+	}
+	if !strings.Contains(w.Body.String(), `"work":[`) {
+		t.Errorf("expected yesterday's entries under work too, got %s", w.Body.String())
+	}
+
+	w = get("/api/v1/reports/weekly?user=alice")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "not found") {
+		t.Errorf("expected 400 for an unknown user, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = get("/api/v1/reports/weekly?user=bob&timezone=Mars/Olympus")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "unknown timezone") {
+		t.Errorf("expected 400 for an unknown timezone, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// A key that may not list users is asked for a user ID
+	usersStatus = http.StatusForbidden
+	w = get("/api/v1/reports/standup?user=bob")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "numeric user ID") {
+		t.Errorf("expected 400 with a hint, got %d: %s", w.Code, w.Body.String())
+	}
+	// Other Redmine failures keep their status rather than blaming the request
+	usersStatus = http.StatusBadGateway
+	if w = get("/api/v1/reports/standup?user=bob"); w.Code == http.StatusBadRequest {
+		t.Errorf("expected an upstream failure not to be a 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	var got map[string]map[string]any
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && r.URL.Path == "/issues/42.json" {
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/issues/42/comments", strings.NewReader(body))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"notes":"Looks good","private_notes":true}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if got["issue"]["notes"] != "Looks good" || got["issue"]["private_notes"] != true {
+		t.Errorf("unexpected payload: %v", got)
+	}
+
+	if w := post(`{"notes":""}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for empty notes, got %d", w.Code)
+	}
+}
+
+func TestAddRelation_Delay(t *testing.T) {
+	var got map[string]map[string]any
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/issues/42/relations.json" {
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"relation":{"id":7,"issue_id":42,"issue_to_id":43,"relation_type":"precedes","delay":2}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/issues/42/relations", strings.NewReader(body))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"issue_to_id":43,"relation_type":"precedes","delay":2}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if got["relation"]["delay"] != float64(2) {
+		t.Errorf("expected the delay to be sent, got %v", got)
+	}
+	var resp map[string]any
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp["delay"] != float64(2) {
+		t.Errorf("expected the delay in the response, got %v", resp)
+	}
+
+	if w := post(`{"issue_to_id":43,"relation_type":"relates","delay":1}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a delay on a relates relation, got %d", w.Code)
+	}
+	if w := post(`{"issue_to_id":43,"relation_type":"parent_of"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown relation type, got %d", w.Code)
+	}
+}
+
+func TestAddRelation_Inverse(t *testing.T) {
+	var path string
+	var got map[string]map[string]any
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"relation":{"id":7,"issue_id":43,"issue_to_id":42,"relation_type":"precedes"}}`))
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/issues/42/relations", strings.NewReader(`{"issue_to_id":43,"relation_type":"follows"}`))
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if path != "/issues/43/relations.json" || got["relation"]["issue_to_id"] != float64(42) || got["relation"]["relation_type"] != "precedes" {
+		t.Errorf("expected 43 precedes 42 to be created, got %s %v", path, got)
+	}
+	var resp map[string]any
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp["swapped"] != true {
+		t.Errorf("expected swapped in the response, got %v", resp)
+	}
+}
+
+func TestGetIssue_PrivateNotesFlag(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/issues/77.json" {
+			_, _ = w.Write([]byte(`{"issue":{"id":77,"subject":"x","journals":[
+				{"id":1,"user":{"id":5,"name":"Sam"},"notes":"public","private_notes":false},
+				{"id":2,"user":{"id":6,"name":"Dana"},"notes":"internal","private_notes":true}]}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/issues/77", nil)
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Journals []struct {
+			Notes        string `json:"notes"`
+			PrivateNotes bool   `json:"private_notes"`
+		} `json:"journals"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Journals) != 2 || resp.Journals[0].PrivateNotes || !resp.Journals[1].PrivateNotes {
+		t.Errorf("expected private_notes flags in journals, got %+v", resp.Journals)
+	}
+}
+
+func TestProjectStatusRoutes(t *testing.T) {
+	var calls []string
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users/current.json" {
+			_, _ = w.Write([]byte(`{"user":{"id":1,"login":"test","firstname":"Test","lastname":"User"}}`))
+			return
+		}
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if r.URL.Path == "/projects/2/archive.json" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := do(http.MethodPost, "/api/v1/projects/1/reopen"); w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := do(http.MethodPost, "/api/v1/projects/2/archive"); w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "administrator") {
+		t.Errorf("expected 403 with hint, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := do(http.MethodDelete, "/api/v1/projects/1"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without confirm, got %d", w.Code)
+	}
+	if w := do(http.MethodDelete, "/api/v1/projects/1?confirm=true"); w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	want := "PUT /projects/1/reopen.json,PUT /projects/2/archive.json,DELETE /projects/1.json"
+	if got := strings.Join(calls, ","); got != want {
+		t.Errorf("unexpected Redmine requests: %s", got)
+	}
+}
+
+func TestExportIssues_Formats(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/issues.json" {
+			_, _ = w.Write([]byte(`{"issues":[{"id":5,"subject":"Login","status":{"id":1,"name":"New"},
+				"custom_fields":[{"id":3,"name":"Component","value":"UI"}]}],"total_count":1}`))
+			return
+		}
+		if r.URL.Path == "/custom_fields.json" {
+			_, _ = w.Write([]byte(`{"custom_fields":[{"id":3,"name":"Component","customized_type":"issue","field_format":"list"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+
+	tests := []struct {
+		path        string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"/api/v1/issues/export.csv?columns=id,subject", http.StatusOK, "text/csv", "ID,Subject\n5,Login\n"},
+		{"/api/v1/issues/export?columns=id,component&include_description=true", http.StatusOK, "text/csv", "ID,component,Description\n5,UI,\n"},
+		{"/api/v1/issues/export?format=jsonl", http.StatusOK, "application/x-ndjson", `"custom_fields":{"Component":"UI"}`},
+		{"/api/v1/issues/export?format=markdown&columns=id,status", http.StatusOK, "text/markdown; charset=utf-8", "| 5   | New    |"},
+		{"/api/v1/issues/export?format=xml", http.StatusBadRequest, "", "invalid format"},
+		{"/api/v1/issues/export?columns=nope", http.StatusBadRequest, "", "unknown column"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-Redmine-API-Key", "test-key")
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if tt.contentType != "" && w.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("expected content type %q, got %q", tt.contentType, w.Header().Get("Content-Type"))
+			}
+			if !strings.Contains(w.Body.String(), tt.body) {
+				t.Errorf("expected body to contain %q, got %q", tt.body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHealthzAndReadyz(t *testing.T) {
+	redmineServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer redmineServer.Close()
+
+	server := NewServer(Config{RedmineURL: redmineServer.URL, Port: 8080})
+	for _, path := range []string{"/healthz", "/readyz"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected status %d, got %d: %s", path, http.StatusOK, w.Code, w.Body.String())
+		}
+	}
+
+	redmineServer.Close()
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d with Redmine down, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestBatchUpdateIssues_DryRun(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method != http.MethodGet:
+			t.Errorf("dry run must not change anything, got %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case r.URL.Path == "/enumerations/issue_priorities.json":
+			_, _ = w.Write([]byte(`{"issue_priorities":[{"id":2,"name":"Normal"},{"id":4,"name":"Urgent"}]}`))
+		case r.URL.Path == "/issues/10.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":10,"subject":"Crash","project":{"id":1,"name":"P"},"priority":{"id":2,"name":"Normal"}}}`))
+		case r.URL.Path == "/issues/11.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":11,"subject":"Typo","project":{"id":1,"name":"P"},"priority":{"id":4,"name":"Urgent"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/issues/batch-update",
+		strings.NewReader(`{"issue_ids":[10,11,12],"priority":"Urgent","dry_run":true}`))
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		DryRun  bool             `json:"dry_run"`
+		Success []int            `json:"success"`
+		Failed  []map[string]any `json:"failed"`
+		Changes []struct {
+			ID      int                            `json:"id"`
+			Changes map[string]redmine.FieldChange `json:"changes"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if !resp.DryRun || len(resp.Success) != 2 || len(resp.Failed) != 1 || resp.Failed[0]["id"] != float64(12) {
+		t.Errorf("unexpected result: %s", w.Body.String())
+	}
+	if len(resp.Changes) != 2 || resp.Changes[0].Changes["priority"] != (redmine.FieldChange{Old: "Normal", New: "Urgent"}) ||
+		len(resp.Changes[1].Changes) != 0 {
+		t.Errorf("expected only issue 10 to change priority, got %+v", resp.Changes)
+	}
+}
+
+func TestBatchUpdateIssues_Transition(t *testing.T) {
+	var updated []string
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPut:
+			updated = append(updated, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/issue_statuses.json":
+			_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":2,"name":"In Progress"},{"id":5,"name":"Closed"}]}`))
+		case r.URL.Path == "/issues/7.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":7,"project":{"id":1,"name":"P"},"tracker":{"id":4,"name":"Bug"},"status":{"id":1,"name":"New"}}}`))
+		case r.URL.Path == "/issues/8.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":8,"project":{"id":1,"name":"P"},"tracker":{"id":4,"name":"Bug"},"status":{"id":2,"name":"In Progress"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	server.workflow = &redmine.WorkflowRules{
+		Trackers: map[string]redmine.WorkflowTracker{
+			"4": {
+				Name:        "Bug",
+				Statuses:    map[string]redmine.WorkflowStatus{"1": {Name: "New"}, "2": {Name: "In Progress"}, "5": {Name: "Closed"}},
+				Transitions: map[string][]int{"1": {2}, "2": {5}},
+			},
+		},
+	}
+
+	batch := func(dryRun bool) (success []int, failed []map[string]any) {
+		t.Helper()
+		body := fmt.Sprintf(`{"issue_ids":[7,8],"status":"Closed","dry_run":%t}`, dryRun)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/issues/batch-update", strings.NewReader(body))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		var resp struct {
+			Success []int            `json:"success"`
+			Failed  []map[string]any `json:"failed"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		return resp.Success, resp.Failed
+	}
+
+	// The preview and the real run refuse the same transition
+	for _, dryRun := range []bool{true, false} {
+		success, failed := batch(dryRun)
+		if len(success) != 1 || success[0] != 8 || len(failed) != 1 || failed[0]["id"] != float64(7) {
+			t.Errorf("dry_run=%t: expected only issue 8 to pass, got %v %v", dryRun, success, failed)
+		}
+	}
+	if len(updated) != 1 || updated[0] != "/issues/8.json" {
+		t.Errorf("expected only issue 8 to be updated, got %v", updated)
+	}
+}
+
+func TestBulkCreateIssues(t *testing.T) {
+	var created []string
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":1,"login":"test","firstname":"Test","lastname":"User"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/trackers.json":
+			_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Task"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/issues.json":
+			var body struct {
+				Issue struct {
+					Subject string `json:"subject"`
+				} `json:"issue"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			created = append(created, body.Issue.Subject)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"issue":{"id":%d,"subject":%q}}`, 100+len(created), body.Issue.Subject)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/issues/bulk-create", strings.NewReader(body))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	// One invalid item rejects the whole request before anything is created
+	w := post(`{"project":"1","tracker":"Task","issues":[{"subject":"A"},{"subject":""}]}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"index":1`) || len(created) != 0 {
+		t.Fatalf("expected a 400 listing item 1 and nothing created, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = post(`{"project":"1","tracker":"Task","issues":[{"subject":"A"},{"subject":"B"}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp redmine.BulkCreateResult
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(resp.Success) != 2 || resp.Success[1].ID != 102 || len(resp.Failed) != 0 {
+		t.Errorf("unexpected result: %s", w.Body.String())
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	var deleted bool
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/attachments/5.json":
+			_, _ = w.Write([]byte(`{"attachment":{"id":5,"filename":"typo.pdf","filesize":10,"author":{"id":1,"name":"Alice"}}}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/attachments/5.json":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/attachments/6.json":
+			_, _ = w.Write([]byte(`{"attachment":{"id":6,"filename":"theirs.pdf"}}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/attachments/6.json":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	del := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, path, nil)
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := del("/api/v1/attachments/5")
+	if w.Code != http.StatusOK || !deleted || !strings.Contains(w.Body.String(), `"filename":"typo.pdf"`) {
+		t.Errorf("expected the deleted filename in a 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = del("/api/v1/attachments/6")
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "author or an administrator") {
+		t.Errorf("expected a 403 with a hint, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := del("/api/v1/attachments/7"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing attachment, got %d", w.Code)
+	}
+}
+
+func TestUpdateAttachment(t *testing.T) {
+	var sent map[string]map[string]any
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/attachments/5.json":
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/attachments/5.json":
+			_, _ = w.Write([]byte(`{"attachment":{"id":5,"filename":"typo.pdf","description":"Fixed"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	patch := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/attachments/5", strings.NewReader(body))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := patch(`{"description":"Fixed"}`)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"description":"Fixed"`) {
+		t.Errorf("expected the updated attachment in a 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, ok := sent["attachment"]["filename"]; ok || sent["attachment"]["description"] != "Fixed" {
+		t.Errorf("expected only the description to be sent, got %v", sent)
+	}
+
+	if w := patch(`{}`); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "Nothing to update") {
+		t.Errorf("expected 400 for an empty update, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := patch(`{"filename":""}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an empty filename, got %d", w.Code)
+	}
+}
+
+func TestDownloadAttachment_Streams(t *testing.T) {
+	content := strings.Repeat("log line\n", 100000)
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/attachments/5.json":
+			_, _ = fmt.Fprintf(w, `{"attachment":{"id":5,"filename":"build.log","filesize":%d,"content_type":"text/plain"}}`, len(content))
+		case "/attachments/download/5/build.log":
+			_, _ = w.Write([]byte(content))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/v1/attachments/5/download")
+	if w.Code != http.StatusOK || w.Body.String() != content {
+		t.Fatalf("expected the file content, got %d with %d bytes", w.Code, w.Body.Len())
+	}
+	if got := w.Header().Get("Content-Length"); got != strconv.Itoa(len(content)) {
+		t.Errorf("expected Content-Length from the metadata, got %q", got)
+	}
+	if got := w.Header().Get("Content-Type"); got != "text/plain" {
+		t.Errorf("expected text/plain, got %q", got)
+	}
+
+	if w := get("/api/v1/attachments/6/download"); w.Code != http.StatusNotFound {
+		t.Errorf("expected a missing attachment to be a 404, got %d", w.Code)
+	}
+}
+
+func TestUploadAttachment_ContentType(t *testing.T) {
+	var queries []url.Values
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users/current.json" {
+			_, _ = w.Write([]byte(`{"user":{"id":1,"login":"test","firstname":"Test","lastname":"User"}}`))
+			return
+		}
+		if r.URL.Path != "/uploads.json" || r.Header.Get("Content-Type") != "application/octet-stream" {
+			t.Errorf("unexpected request %s %s with %q", r.Method, r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		queries = append(queries, r.URL.Query())
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"upload":{"token":"1.abc"}}`))
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	upload := func(partType, field string) map[string]any {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", `form-data; name="file"; filename="report.pdf"`)
+		h.Set("Content-Type", partType)
+		part, _ := mw.CreatePart(h)
+		_, _ = part.Write([]byte("%PDF-1.7"))
+		if field != "" {
+			_ = mw.WriteField("content_type", field)
+		}
+		_ = mw.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/attachments/upload", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp map[string]any
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+
+	// The part's type is passed through, a form field overrides it and a
+	// generic octet-stream part is guessed from the filename
+	want := []string{"application/x-pdf", "application/vnd.custom", "application/pdf"}
+	for i, resp := range []map[string]any{
+		upload("application/x-pdf", ""),
+		upload("application/x-pdf", "application/vnd.custom"),
+		upload("application/octet-stream", ""),
+	} {
+		if resp["content_type"] != want[i] {
+			t.Errorf("upload %d: expected %s, got %v", i, want[i], resp)
+		}
+	}
+	for i, q := range queries {
+		if q.Get("filename") != "report.pdf" || q.Get("content_type") != want[i] {
+			t.Errorf("upload %d: unexpected query %v", i, q)
+		}
+	}
+}
+
+func TestAttachToIssue_SizeLimit(t *testing.T) {
+	uploads := 0
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/uploads.json":
+			uploads++
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"upload":{"token":"1.abc"}}`))
+		case "/issues/7.json":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080, MaxAttachmentMB: 1})
+	attach := func(sizes ...int) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		for i, size := range sizes {
+			part, _ := mw.CreateFormFile("files[]", fmt.Sprintf("part%d.bin", i+1))
+			_, _ = part.Write(make([]byte, size))
+		}
+		_ = mw.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/issues/7/attach", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := attach(512*1024, 512*1024); w.Code != http.StatusOK {
+		t.Errorf("expected files within the limit to attach, got %d: %s", w.Code, w.Body.String())
+	}
+	w := attach(256*1024, 1536*1024)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "part2.bin is 1.5 MB, over the 1 MB attachment limit") {
+		t.Errorf("expected the oversized file to be rejected, got %d: %s", w.Code, w.Body.String())
+	}
+	w = attach(768*1024, 768*1024)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "2 files total 1.5 MB, over the 1 MB attachment limit") {
+		t.Errorf("expected the total to be rejected, got %d: %s", w.Code, w.Body.String())
+	}
+	if uploads != 2 {
+		t.Errorf("expected rejected requests to upload nothing, got %d uploads", uploads)
+	}
+}
+
+func TestListTimeEntries(t *testing.T) {
+	var query url.Values
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects.json":
+			_, _ = w.Write([]byte(`{"projects":[{"id":3,"name":"Web","identifier":"web"}],"total_count":1}`))
+		case "/time_entries.json":
+			query = r.URL.Query()
+			_, _ = w.Write([]byte(`{"time_entries":[
+				{"id":1,"project":{"id":3,"name":"Web"},"issue":{"id":10},"user":{"id":1,"name":"Alice"},"activity":{"id":9,"name":"Dev"},"hours":2,"spent_on":"2025-03-03"},
+				{"id":2,"project":{"id":3,"name":"Web"},"user":{"id":1,"name":"Alice"},"activity":{"id":9,"name":"Dev"},"hours":1,"spent_on":"2025-03-04"}
+			],"total_count":5}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/v1/time_entries?project=Web&user=me&issue_id=10&period=2025-W10&to=2025-03-05&limit=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if query.Get("project_id") != "3" || query.Get("user_id") != "me" || query.Get("issue_id") != "10" ||
+		query.Get("from") != "2025-03-03" || query.Get("to") != "2025-03-05" || query.Get("limit") != "2" {
+		t.Errorf("unexpected Redmine query: %v", query)
+	}
+	var resp struct {
+		TotalCount  int              `json:"total_count"`
+		Count       int              `json:"count"`
+		Truncated   bool             `json:"truncated"`
+		TimeEntries []map[string]any `json:"time_entries"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp.TotalCount != 5 || resp.Count != 2 || !resp.Truncated || resp.TimeEntries[0]["issue_id"] != float64(10) || resp.TimeEntries[0]["project"] != "Web" {
+		t.Errorf("unexpected response: %s", w.Body.String())
+	}
+
+	for _, path := range []string{"/api/v1/time_entries?period=next_decade", "/api/v1/time_entries?issue_id=abc", "/api/v1/time_entries?project=Nope"} {
+		if w := get(path); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestCreateTimeEntry_IssueOrProject(t *testing.T) {
+	var posted map[string]any
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":1,"login":"me"}}`))
+		case r.URL.Path == "/projects.json":
+			_, _ = w.Write([]byte(`{"projects":[{"id":3,"name":"Operations","identifier":"ops"}],"total_count":1}`))
+		case r.URL.Path == "/time_entries.json" && r.Method == http.MethodPost:
+			var req map[string]map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			posted = req["time_entry"]
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"time_entry":{"id":1,"project":{"id":3,"name":"Operations"},"hours":1}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	post := func(body string) *httptest.ResponseRecorder {
+		posted = nil
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/time_entries", strings.NewReader(body))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := post(`{"issue_id":42,"hours":1}`); w.Code != http.StatusCreated || posted["issue_id"] != float64(42) {
+		t.Errorf("expected an entry on issue 42, got %d %v", w.Code, posted)
+	}
+	w := post(`{"project":"ops","hours":1,"comments":"Standup"}`)
+	if w.Code != http.StatusCreated || posted["project_id"] != float64(3) || posted["issue_id"] != nil {
+		t.Errorf("expected an entry on project 3, got %d %v", w.Code, posted)
+	}
+	var resp map[string]any
+	_ = json.Unmarshal(w.Body.Bytes(), &resp)
+	if resp["project"] != "Operations" || resp["issue_id"] != nil {
+		t.Errorf("unexpected response: %v", resp)
+	}
+
+	for _, body := range []string{`{"hours":1}`, `{"issue_id":42,"project":"ops","hours":1}`} {
+		if w := post(body); w.Code != http.StatusBadRequest || posted != nil {
+			t.Errorf("expected 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestTimeEntryReport(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/time_entries.json":
+			_, _ = w.Write([]byte(`{"time_entries":[
+				{"id":1,"issue":{"id":10},"user":{"id":1,"name":"Alice"},"hours":2,"spent_on":"2025-03-03"},
+				{"id":2,"issue":{"id":10},"user":{"id":2,"name":"Bob"},"hours":1.5,"spent_on":"2025-03-04"},
+				{"id":3,"user":{"id":1,"name":"Alice"},"hours":1,"spent_on":"2025-03-04"}
+			],"total_count":3}`))
+		case "/issues.json":
+			_, _ = w.Write([]byte(`{"issues":[{"id":10,"subject":"Login bug"}],"total_count":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/v1/reports/time?group_by=issue&from=2025-03-01&to=2025-03-31")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var report redmine.TimeEntryReport
+	_ = json.Unmarshal(w.Body.Bytes(), &report)
+	if report.TotalHours != 4.5 || report.EntryCount != 3 || report.Period != "2025-03-01 ~ 2025-03-31" || len(report.Groups) != 2 {
+		t.Fatalf("unexpected report: %s", w.Body.String())
+	}
+	if first := report.Groups[0]; first["subject"] != "Login bug" || first["hours"] != 3.5 {
+		t.Errorf("unexpected first group: %v", first)
+	}
+
+	w = get("/api/v1/reports/time?group_by=user&format=csv")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("expected CSV, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	if got := w.Body.String(); got != "User,Hours\nAlice,3\nBob,1.5\nTotal,4.5\n" {
+		t.Errorf("unexpected CSV:\n%s", got)
+	}
+
+	for _, path := range []string{"/api/v1/reports/time", "/api/v1/reports/time?group_by=week", "/api/v1/reports/time?group_by=user&format=xml"} {
+		if w := get(path); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
+
+func TestCategories(t *testing.T) {
+	var created, updated map[string]map[string]any
+	var deleted bool
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/projects.json":
+			_, _ = w.Write([]byte(`{"projects":[{"id":1,"name":"Firmware","identifier":"fw"}],"total_count":1}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/1/issue_categories.json":
+			_, _ = w.Write([]byte(`{"issue_categories":[{"id":3,"name":"BMC","assigned_to":{"id":7,"name":"Alice"}},{"id":4,"name":"BIOS"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/projects/1/issue_categories.json":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"issue_category":{"id":5,"name":"Power","assigned_to":{"id":7,"name":"Alice"}}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/issue_categories/5.json":
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == "/issue_categories/5.json":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodGet, "/api/v1/projects/fw/categories", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"count":2`) || !strings.Contains(w.Body.String(), `"assigned_to":{"id":7,"name":"Alice"}`) {
+		t.Errorf("expected both categories, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = do(http.MethodPost, "/api/v1/projects/1/categories", `{"name":"Power","assigned_to":"7"}`)
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), `"id":5`) {
+		t.Errorf("expected the created category in a 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if created["issue_category"]["name"] != "Power" || created["issue_category"]["assigned_to_id"] != float64(7) {
+		t.Errorf("unexpected create payload: %v", created)
+	}
+	if w := do(http.MethodPost, "/api/v1/projects/1/categories", `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without a name, got %d", w.Code)
+	}
+
+	w = do(http.MethodPatch, "/api/v1/categories/5", `{"name":"PSU"}`)
+	if w.Code != http.StatusOK || updated["issue_category"]["name"] != "PSU" {
+		t.Errorf("expected the rename to be sent, got %d: %v", w.Code, updated)
+	}
+	if w := do(http.MethodPatch, "/api/v1/categories/5", `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an empty update, got %d", w.Code)
+	}
+
+	if w := do(http.MethodDelete, "/api/v1/categories/5", ""); w.Code != http.StatusOK || !deleted {
+		t.Errorf("expected the category to be deleted, got %d", w.Code)
+	}
+	if w := do(http.MethodDelete, "/api/v1/categories/6", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing category, got %d", w.Code)
+	}
+}
+
+func TestMemberships(t *testing.T) {
+	var added, updated map[string]map[string]any
+	var deleted bool
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/roles.json":
+			_, _ = w.Write([]byte(`{"roles":[{"id":3,"name":"Manager"},{"id":4,"name":"Developer"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/groups.json":
+			_, _ = w.Write([]byte(`{"groups":[{"id":20,"name":"QA Team"}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/1/memberships.json":
+			_, _ = w.Write([]byte(`{"memberships":[{"id":11,"user":{"id":7,"name":"Alice"},"roles":[{"id":4,"name":"Developer"}]}],"total_count":1}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/projects/1/memberships.json":
+			_ = json.NewDecoder(r.Body).Decode(&added)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"membership":{"id":12,"group":{"id":20,"name":"QA Team"},"roles":[{"id":4,"name":"Developer"}]}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/memberships/12.json":
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == "/memberships/12.json":
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodGet, "/api/v1/projects/1/memberships", "")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"count":1`) || !strings.Contains(w.Body.String(), "Developer") {
+		t.Errorf("expected the membership with its roles, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = do(http.MethodPost, "/api/v1/projects/1/memberships", `{"group":"qa team","roles":["developer"]}`)
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), `"id":12`) {
+		t.Errorf("expected the new membership in a 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if added["membership"]["group_id"] != float64(20) || fmt.Sprint(added["membership"]["role_ids"]) != "[4]" {
+		t.Errorf("expected resolved group and role IDs, got %v", added)
+	}
+	if w := do(http.MethodPost, "/api/v1/projects/1/memberships", `{"user":"7","group":"20","roles":["4"]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for both user and group, got %d", w.Code)
+	}
+	if w := do(http.MethodPost, "/api/v1/projects/1/memberships", `{"user":"7","roles":["Janitor"]}`); w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "Janitor") {
+		t.Errorf("expected 400 naming the unknown role, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = do(http.MethodPatch, "/api/v1/memberships/12", `{"roles":["Manager","4"]}`)
+	if w.Code != http.StatusOK || fmt.Sprint(updated["membership"]["role_ids"]) != "[3 4]" {
+		t.Errorf("expected the roles to be replaced, got %d: %v", w.Code, updated)
+	}
+	if w := do(http.MethodPatch, "/api/v1/memberships/12", `{"roles":[]}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without roles, got %d", w.Code)
+	}
+
+	if w := do(http.MethodDelete, "/api/v1/memberships/12", ""); w.Code != http.StatusOK || !deleted {
+		t.Errorf("expected the membership to be deleted, got %d", w.Code)
+	}
+}
+
+func TestGetIssue_SpentHoursAndChildren(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/issues/10.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":10,"subject":"Parent","status":{"id":1,"name":"New"}}}`))
+		case "/time_entries.json":
+			_, _ = w.Write([]byte(`{"time_entries":[{"id":1,"hours":2,"user":{"id":7,"name":"Alice"}},{"id":2,"hours":1,"user":{"id":8,"name":"Bob"}}],"total_count":2}`))
+		case "/issues.json":
+			_, _ = w.Write([]byte(`{"issues":[{"id":11,"subject":"Child","status":{"id":1,"name":"New"},"done_ratio":40}],"total_count":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/issues/10?include_spent_hours=true&include_children=true", nil)
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	var resp struct {
+		SpentTime redmine.IssueSpentTime `json:"spent_time"`
+		Children  []redmine.IssueChild   `json:"children"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("expected 200 with JSON, got %d: %s", w.Code, w.Body.String())
+	}
+	if resp.SpentTime.TotalHours != 3 || len(resp.SpentTime.ByUser) != 2 {
+		t.Errorf("unexpected spent time: %+v", resp.SpentTime)
+	}
+	if len(resp.Children) != 1 || resp.Children[0].ID != 11 || resp.Children[0].DoneRatio != 40 {
+		t.Errorf("unexpected children: %+v", resp.Children)
+	}
+}
+
+func TestCreateIssue_InitialState(t *testing.T) {
+	var got map[string]map[string]any
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/trackers.json":
+			_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Task"}]}`))
+		case "/issue_statuses.json":
+			_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":2,"name":"In Progress"}]}`))
+		case "/issues.json":
+			_ = json.NewDecoder(r.Body).Decode(&got)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"issue":{"id":42,"subject":"Started","status":{"id":2,"name":"In Progress"},"done_ratio":0}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/issues", strings.NewReader(body))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"project":"1","tracker":"Task","subject":"Started","status":"In Progress","done_ratio":50}`)
+	if w.Code != http.StatusCreated || got["issue"]["status_id"] != float64(2) || got["issue"]["done_ratio"] != float64(50) {
+		t.Fatalf("expected status and done_ratio to be sent, got %d %v", w.Code, got)
+	}
+	// Redmine kept the status but derived progress from it
+	if body := w.Body.String(); !strings.Contains(body, `"warning"`) || !strings.Contains(body, "done_ratio (created with 0%") || strings.Contains(body, "status (created") {
+		t.Errorf("expected a done_ratio warning, got %s", body)
+	}
+
+	if w := post(`{"project":"1","tracker":"Task","subject":"Started","done_ratio":-5}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a negative done_ratio, got %d", w.Code)
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":1,"login":"me"}}`))
+		case "/users.json":
+			_, _ = w.Write([]byte(`{"users":[{"id":5,"login":"bob","firstname":"Bob","lastname":"Lin"}],"total_count":1}`))
+		case "/users/5.json":
+			_, _ = w.Write([]byte(`{"user":{"id":5,"firstname":"Bob","lastname":"Lin",
+				"memberships":[{"id":1,"project":{"id":3,"name":"Web"},"roles":[{"id":4,"name":"Developer"}]}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/v1/users/bob")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var user map[string]any
+	_ = json.Unmarshal(w.Body.Bytes(), &user)
+	memberships, _ := user["memberships"].([]any)
+	if user["id"] != float64(5) || len(memberships) != 1 || user["note"] == nil {
+		t.Errorf("expected Bob's limited profile with one membership, got %v", user)
+	}
+
+	if w := get("/api/v1/users/9"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown user, got %d", w.Code)
+	}
+}
+
+func TestVersionProgress(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":1,"login":"me"}}`))
+		case "/projects/3/versions.json":
+			_, _ = w.Write([]byte(`{"versions":[{"id":10,"name":"1.0","status":"open"}]}`))
+		case "/versions/10.json":
+			_, _ = w.Write([]byte(`{"version":{"id":10,"name":"1.0","status":"open","due_date":"2999-01-01"}}`))
+		case "/enumerations/issue_priorities.json":
+			_, _ = w.Write([]byte(`{"issue_priorities":[{"id":2,"name":"Normal","is_default":true},{"id":4,"name":"Urgent"}]}`))
+		case "/issues.json":
+			if r.URL.Query().Get("status_id") == "open" {
+				_, _ = w.Write([]byte(`{"issues":[{"id":2,"priority":{"id":4,"name":"Urgent"},"done_ratio":40}],"total_count":1}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+		case "/time_entries.json":
+			_, _ = w.Write([]byte(`{"time_entries":[],"total_count":0}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/v1/projects/3/versions/1.0/progress")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var progress redmine.VersionProgress
+	_ = json.Unmarshal(w.Body.Bytes(), &progress)
+	if progress.Version.ID != 10 || progress.OpenCount != 1 || progress.CompletionPercent != 40 || progress.AtRisk {
+		t.Errorf("unexpected progress: %s", w.Body.String())
+	}
+
+	if w := get("/api/v1/projects/3/versions/10/progress?at_risk_days=soon"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid at_risk_days, got %d", w.Code)
+	}
+}
+
+func TestWebURLs(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":1,"login":"me"}}`))
+		case "/projects.json":
+			_, _ = w.Write([]byte(`{"projects":[{"id":3,"name":"Web","identifier":"web"}],"total_count":1}`))
+		case "/projects/3/versions.json":
+			_, _ = w.Write([]byte(`{"versions":[{"id":10,"name":"1.0","status":"open"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	get := func(path string, out any) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), out)
+	}
+
+	var projects struct {
+		Projects []struct {
+			URL string `json:"url"`
+		} `json:"projects"`
+	}
+	get("/api/v1/projects", &projects)
+	if len(projects.Projects) != 1 || projects.Projects[0].URL != mockRedmine.URL+"/projects/web" {
+		t.Errorf("expected the project page url, got %+v", projects)
+	}
+
+	var versions struct {
+		Versions []struct {
+			URL string `json:"url"`
+		} `json:"versions"`
+	}
+	get("/api/v1/projects/3/versions", &versions)
+	if len(versions.Versions) != 1 || versions.Versions[0].URL != mockRedmine.URL+"/versions/10" {
+		t.Errorf("expected the version page url, got %+v", versions)
+	}
+}
@@ -1,9 +1,16 @@
 package api
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
 )
 
 // securityHeaders adds security headers to all responses
@@ -31,6 +38,141 @@ func securityHeaders(next http.Handler) http.Handler {
 	})
 }
 
+// CORS settings for browser clients. The methods cover every route; the
+// headers are the ones the API reads.
+const (
+	corsAllowMethods  = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsAllowHeaders  = "Authorization, Content-Type, X-Redmine-API-Key, X-Request-ID"
+	corsExposeHeaders = "X-Request-ID, Content-Disposition, Retry-After"
+	corsMaxAge        = "600"
+)
+
+// cors adds CORS headers for requests from allowed origins and answers
+// preflight requests itself, since routes don't register OPTIONS. "*" in
+// allowedOrigins allows any origin. Preflights from other origins get 403.
+func cors(allowedOrigins []string) func(http.Handler) http.Handler {
+	anyOrigin := slices.Contains(allowedOrigins, "*")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			w.Header().Add("Vary", "Origin")
+			if !anyOrigin && !slices.ContainsFunc(allowedOrigins, func(o string) bool { return strings.EqualFold(o, origin) }) {
+				if preflight {
+					writeError(w, http.StatusForbidden, "origin not allowed: "+origin)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			if preflight {
+				w.Header().Set("Access-Control-Allow-Methods", corsAllowMethods)
+				w.Header().Set("Access-Control-Allow-Headers", corsAllowHeaders)
+				w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Header().Set("Access-Control-Expose-Headers", corsExposeHeaders)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ParseCORSOrigins splits a comma-separated list of allowed origins
+func ParseCORSOrigins(value string) []string {
+	var origins []string
+	for o := range strings.SplitSeq(value, ",") {
+		if o = strings.TrimSuffix(strings.TrimSpace(o), "/"); o != "" {
+			origins = append(origins, o)
+		}
+	}
+	return origins
+}
+
+const logUserContextKey contextKey = "logUser"
+
+// logUser is filled in by the auth middleware so the request log can name
+// the Redmine user
+type logUser struct {
+	id    int
+	login string
+}
+
+// setLogUser records the Redmine user of a request for its log line
+func setLogUser(ctx context.Context, user *redmine.User) {
+	if u, ok := ctx.Value(logUserContextKey).(*logUser); ok && user != nil {
+		u.id, u.login = user.ID, user.Login
+	}
+}
+
+// requestLogger logs every request with slog: method, path, status,
+// duration, request ID and the Redmine user when the key was validated.
+// The request ID from chi's RequestID middleware is echoed as X-Request-ID.
+// Server errors are logged as errors.
+func requestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		requestID := middleware.GetReqID(r.Context())
+		if requestID != "" {
+			w.Header().Set("X-Request-ID", requestID)
+		}
+
+		user := &logUser{}
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(context.WithValue(r.Context(), logUserContextKey, user)))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.Int("bytes", ww.BytesWritten()),
+			slog.String("request_id", requestID),
+			slog.String("remote", r.RemoteAddr),
+		}
+		if user.login != "" {
+			attrs = append(attrs, slog.String("user", user.login), slog.Int("user_id", user.id))
+		}
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.LogAttrs(r.Context(), level, "request", attrs...)
+	})
+}
+
+// uploadPath is the attachment upload route, which read-only mode can allow
+// since uploads only stage a file and don't change any Redmine object
+const uploadPath = "/api/v1/attachments/upload"
+
+// readOnlyGuard rejects mutating requests (POST, PUT, PATCH, DELETE) with 403.
+// When allowUploads is set, attachment uploads are still accepted.
+func readOnlyGuard(allowUploads bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+				if !(allowUploads && r.URL.Path == uploadPath) {
+					writeError(w, http.StatusForbidden, "server is in read-only mode - write operations are disabled")
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // RateLimiter implements a simple token bucket rate limiter
 type RateLimiter struct {
 	mu        sync.Mutex
@@ -96,7 +238,7 @@ func (rl *RateLimiter) Allow(key string) bool {
 // Middleware returns an HTTP middleware for rate limiting
 func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Use IP + API key as the rate limit key
+		// Use IP, or the API key or basic auth user, as the rate limit key
 		key := r.RemoteAddr
 		if apiKey := r.Header.Get("X-Redmine-API-Key"); apiKey != "" {
 			// Use first 8 chars of API key to avoid storing full key
@@ -105,6 +247,8 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 			} else {
 				key = apiKey
 			}
+		} else if username, _, ok := r.BasicAuth(); ok && username != "" {
+			key = "basic:" + username
 		}
 
 		if !rl.Allow(key) {
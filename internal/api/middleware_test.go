@@ -1,10 +1,16 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 func TestSecurityHeaders(t *testing.T) {
@@ -111,3 +117,288 @@ func TestRateLimiter_Cleanup(t *testing.T) {
 		t.Errorf("Expected 0 entries after cleanup, got %d", count)
 	}
 }
+
+func TestReadOnlyMode_RejectsWriteRoutes(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("write request reached Redmine: %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Path == "/users/current.json" {
+			_, _ = w.Write([]byte(`{"user":{"id":1,"login":"test","firstname":"Test","lastname":"User"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080, ReadOnly: true})
+
+	var writes int
+	err := chi.Walk(server.router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if method == http.MethodGet || method == http.MethodHead {
+			return nil
+		}
+		writes++
+		path := strings.NewReplacer("{id}", "1", "{user_id}", "2", "{title}", "Home").Replace(route)
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		req.Header.Set("X-Redmine-API-Key", "test-key")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), "read-only") {
+			t.Errorf("%s %s: expected 403 read-only error, got %d: %s", method, path, w.Code, w.Body.String())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk failed: %v", err)
+	}
+	if writes == 0 {
+		t.Fatal("expected write routes to be registered")
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected GET to keep working, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestReadOnlyGuard_AllowUploads(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		allowUploads bool
+		method, path string
+		want         int
+	}{
+		{false, http.MethodPost, uploadPath, http.StatusForbidden},
+		{true, http.MethodPost, uploadPath, http.StatusOK},
+		{true, http.MethodPost, "/api/v1/issues/1/attach", http.StatusForbidden},
+		{true, http.MethodDelete, "/api/v1/relations/1", http.StatusForbidden},
+		{false, http.MethodGet, "/api/v1/issues", http.StatusOK},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		readOnlyGuard(tt.allowUploads)(next).ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+		if w.Code != tt.want {
+			t.Errorf("allowUploads=%v %s %s: got %d, want %d", tt.allowUploads, tt.method, tt.path, w.Code, tt.want)
+		}
+	}
+}
+
+func TestAuthMiddleware_KeyValidation(t *testing.T) {
+	calls := make(map[string]int)
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.Header.Get("X-Redmine-API-Key")+" "+r.URL.Path]++
+		switch {
+		case r.Header.Get("X-Redmine-API-Key") != "good-key":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":1,"login":"test","firstname":"Test","lastname":"User"}}`))
+		case r.URL.Path == "/projects.json":
+			_, _ = w.Write([]byte(`{"projects":[{"id":1,"name":"Firmware","identifier":"fw"}],"total_count":1}`))
+		case r.URL.Path == "/projects/1/versions.json":
+			_, _ = w.Write([]byte(`{"versions":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	get := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/fw/versions", nil)
+		req.Header.Set("X-Redmine-API-Key", key)
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("bad-key")
+	if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "Invalid Redmine API key") {
+		t.Errorf("expected 401 for an invalid key, got %d: %s", w.Code, w.Body.String())
+	}
+	if calls["bad-key /projects.json"] != 0 {
+		t.Error("expected the handler not to run for an invalid key")
+	}
+
+	for range 3 {
+		if w := get("good-key"); w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	if calls["good-key /users/current.json"] != 1 {
+		t.Errorf("expected the key to be validated once, got %d checks", calls["good-key /users/current.json"])
+	}
+	if calls["good-key /projects.json"] != 1 {
+		t.Errorf("expected requests to share the key's resolver cache, got %d project lookups", calls["good-key /projects.json"])
+	}
+
+	// Expired keys are checked again but keep their resolver
+	server.keys.entries["good-key"].validatedAt = time.Now().Add(-2 * keyCacheTTL)
+	get("good-key")
+	if calls["good-key /users/current.json"] != 2 || calls["good-key /projects.json"] != 1 {
+		t.Errorf("expected a revalidation reusing the resolver, got %v", calls)
+	}
+	server.keys.cleanup(keyCacheTTL)
+	if len(server.keys.entries) != 1 {
+		t.Errorf("expected the revalidated key to survive cleanup, got %d entries", len(server.keys.entries))
+	}
+}
+
+func TestAuthMiddleware_BasicAuth(t *testing.T) {
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		switch {
+		case r.Header.Get("X-Redmine-API-Key") != "" || !ok || username != "jdoe" || password != "s3cret":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":3,"login":"jdoe","firstname":"Jane","lastname":"Doe"}}`))
+		case r.URL.Path == "/projects.json":
+			_, _ = w.Write([]byte(`{"projects":[{"id":1,"name":"Firmware","identifier":"fw"}],"total_count":1}`))
+		case r.URL.Path == "/projects/1/versions.json":
+			_, _ = w.Write([]byte(`{"versions":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	get := func(username, password string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/projects/fw/versions", nil)
+		if username != "" {
+			req.SetBasicAuth(username, password)
+		}
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := get("", ""); w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "Authorization: Basic") {
+		t.Errorf("expected 401 without credentials, got %d: %s", w.Code, w.Body.String())
+	}
+	w := get("jdoe", "wrong-password")
+	if w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "Invalid Redmine username or password") {
+		t.Errorf("expected 401 for a wrong password, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "wrong-password") {
+		t.Errorf("expected the password not to be echoed: %s", w.Body.String())
+	}
+	if w := get("jdoe", "s3cret"); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// The cache is keyed by a hash of the credentials, not the password
+	if len(server.keys.entries) != 1 {
+		t.Fatalf("expected the credentials to be cached, got %d entries", len(server.keys.entries))
+	}
+	for key := range server.keys.entries {
+		if strings.Contains(key, "s3cret") {
+			t.Errorf("expected the cache key not to hold the password, got %q", key)
+		}
+	}
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	server := NewServer(Config{RedmineURL: "http://localhost", Port: 8080, CORSOrigins: ParseCORSOrigins(" https://dash.example.com/ ,https://other.example.com")})
+	preflight := func(origin, method string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/issues/7", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", method)
+		req.Header.Set("Access-Control-Request-Headers", "X-Redmine-API-Key, Content-Type")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, method := range []string{http.MethodPatch, http.MethodDelete} {
+		w := preflight("https://dash.example.com", method)
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("%s: expected 204 for an allowed origin, got %d: %s", method, w.Code, w.Body.String())
+		}
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://dash.example.com" {
+			t.Errorf("%s: expected the origin to be allowed, got %q", method, got)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Methods"); !strings.Contains(got, method) {
+			t.Errorf("%s: expected the method in Allow-Methods, got %q", method, got)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Headers"); !strings.Contains(got, "X-Redmine-API-Key") {
+			t.Errorf("%s: expected the API key header to be allowed, got %q", method, got)
+		}
+	}
+
+	w := preflight("https://evil.example.com", http.MethodDelete)
+	if w.Code != http.StatusForbidden || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("expected 403 without CORS headers for another origin, got %d %v", w.Code, w.Header())
+	}
+
+	// Actual requests from allowed origins carry the CORS headers too
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("Origin", "https://other.example.com")
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, req)
+	if rec.Header().Get("Access-Control-Allow-Origin") != "https://other.example.com" || !strings.Contains(rec.Header().Get("Access-Control-Expose-Headers"), "X-Request-ID") {
+		t.Errorf("expected CORS headers on a simple request, got %v", rec.Header())
+	}
+
+	// Without configured origins there is no CORS handling
+	plain := NewServer(Config{RedmineURL: "http://localhost", Port: 8080})
+	req = httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("Origin", "https://dash.example.com")
+	rec = httptest.NewRecorder()
+	plain.router.ServeHTTP(rec, req)
+	if rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("expected no CORS headers by default, got %v", rec.Header())
+	}
+}
+
+func TestRequestLogger_Fields(t *testing.T) {
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	mockRedmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users/current.json" {
+			_, _ = w.Write([]byte(`{"user":{"id":7,"login":"alice","firstname":"Alice","lastname":"Chen"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mockRedmine.Close()
+
+	server := NewServer(Config{RedmineURL: mockRedmine.URL, Port: 8080})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	req.Header.Set("X-Redmine-API-Key", "test-key")
+	req.Header.Set("X-Request-ID", "req-123")
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	if got := w.Header().Get("X-Request-ID"); got != "req-123" {
+		t.Errorf("expected the request ID to be echoed, got %q", got)
+	}
+
+	var entry map[string]any
+	for line := range strings.SplitSeq(strings.TrimSpace(logs.String()), "\n") {
+		var e map[string]any
+		if json.Unmarshal([]byte(line), &e) == nil && e["msg"] == "request" {
+			entry = e
+		}
+	}
+	if entry == nil {
+		t.Fatalf("expected a request log line, got %s", logs.String())
+	}
+	for field, want := range map[string]any{"method": "GET", "path": "/api/v1/me", "status": float64(200), "request_id": "req-123", "user": "alice", "user_id": float64(7)} {
+		if entry[field] != want {
+			t.Errorf("expected %s=%v, got %v", field, want, entry[field])
+		}
+	}
+	if _, ok := entry["duration"]; !ok {
+		t.Error("expected a duration field")
+	}
+}
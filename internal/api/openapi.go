@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+	"gopkg.in/yaml.v3"
+)
+
+// issueStatusFilters are the status values issue searches accept besides status names
+var issueStatusFilters = []string{"open", "closed", "all"}
+
+// openAPIGenerator renders the OpenAPI spec as JSON with the tracker, status,
+// priority and activity names of the Redmine server as enums, so GPT Actions
+// stop inventing values. The spec is regenerated once the reference data
+// cache TTL has passed.
+type openAPIGenerator struct {
+	resolver *redmine.Resolver // nil serves the static spec
+	ttl      time.Duration
+
+	mu          sync.Mutex
+	spec        []byte
+	generatedAt time.Time
+}
+
+// newOpenAPIGenerator returns a generator fetching reference data with
+// client. Without credentials, or when static is set, it serves the static
+// spec.
+func newOpenAPIGenerator(client *redmine.Client, static bool) *openAPIGenerator {
+	g := &openAPIGenerator{ttl: redmine.DefaultCacheTTL}
+	if client.HasCredentials() && !static {
+		g.resolver = redmine.NewResolver(client)
+	}
+	return g
+}
+
+// get returns the JSON spec, generating it when missing or expired
+func (g *openAPIGenerator) get() ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.spec != nil && (g.resolver == nil || time.Since(g.generatedAt) < g.ttl) {
+		return g.spec, nil
+	}
+
+	spec, err := g.generate()
+	if err != nil {
+		return nil, err
+	}
+	// Failed lookups are retried after the TTL too, rather than on every request
+	g.spec, g.generatedAt = spec, time.Now()
+	return spec, nil
+}
+
+func (g *openAPIGenerator) generate() ([]byte, error) {
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(openAPISpec), &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+	if g.resolver != nil {
+		injectEnums(doc, g.fetchEnums())
+	}
+	return json.Marshal(doc)
+}
+
+// fetchEnums loads the reference names per field, bypassing the resolver
+// cache since the spec has its own TTL. Lookups that fail are logged and left
+// out, so those fields stay free strings.
+func (g *openAPIGenerator) fetchEnums() map[string][]string {
+	enums := make(map[string][]string)
+
+	if trackers, err := g.resolver.RefreshTrackers(); err != nil {
+		slog.Warn("failed to fetch trackers for OpenAPI enums", "error", err)
+	} else {
+		for _, t := range trackers {
+			enums["tracker"] = append(enums["tracker"], t.Name)
+		}
+	}
+
+	if statuses, err := g.resolver.RefreshStatuses(); err != nil {
+		slog.Warn("failed to fetch statuses for OpenAPI enums", "error", err)
+	} else {
+		for _, s := range statuses {
+			enums["status"] = append(enums["status"], s.Name)
+		}
+	}
+
+	if priorities, err := g.resolver.RefreshPriorities(); err != nil {
+		slog.Warn("failed to fetch priorities for OpenAPI enums", "error", err)
+	} else {
+		for _, p := range priorities {
+			enums["priority"] = append(enums["priority"], p.Name)
+		}
+	}
+
+	if activities, err := g.resolver.RefreshActivities(); err != nil {
+		slog.Warn("failed to fetch activities for OpenAPI enums", "error", err)
+	} else {
+		for _, a := range activities {
+			enums["activity"] = append(enums["activity"], a.Name)
+		}
+	}
+
+	return enums
+}
+
+// injectEnums walks the spec and sets enums on string query parameters and
+// body properties named after a reference field. Fields that already have an
+// enum, such as version status, are left alone. Status query parameters also
+// accept open, closed and all.
+func injectEnums(node any, enums map[string][]string) {
+	switch n := node.(type) {
+	case []any:
+		for _, v := range n {
+			injectEnums(v, enums)
+		}
+	case map[string]any:
+		if params, ok := n["parameters"].([]any); ok {
+			for _, p := range params {
+				param, ok := p.(map[string]any)
+				if !ok || param["in"] != "query" {
+					continue
+				}
+				name, _ := param["name"].(string)
+				values := enums[name]
+				if name == "status" && len(values) > 0 {
+					values = append(append([]string{}, issueStatusFilters...), values...)
+				}
+				setEnum(param["schema"], values)
+			}
+		}
+		if props, ok := n["properties"].(map[string]any); ok {
+			for name, prop := range props {
+				setEnum(prop, enums[name])
+			}
+		}
+		for _, v := range n {
+			injectEnums(v, enums)
+		}
+	}
+}
+
+// setEnum sets values as the enum of a string schema without one
+func setEnum(schema any, values []string) {
+	s, ok := schema.(map[string]any)
+	if !ok || len(values) == 0 || s["type"] != "string" {
+		return
+	}
+	if _, exists := s["enum"]; exists {
+		return
+	}
+	s["enum"] = values
+}
+
+// handleOpenAPIJSON serves the OpenAPI spec as JSON with enums from Redmine
+func (s *Server) handleOpenAPIJSON(w http.ResponseWriter, r *http.Request) {
+	spec, err := s.openAPI.get()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(spec)
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -9,6 +11,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
+	"github.com/ycho/redmine-mcp-server/internal/health"
 	"github.com/ycho/redmine-mcp-server/internal/redmine"
 
 	_ "github.com/ycho/redmine-mcp-server/docs" // swagger docs
@@ -20,8 +23,25 @@ type Config struct {
 	Port                 int
 	CustomFieldRulesFile string
 	WorkflowRulesFile    string
+	WorkflowSource       redmine.WorkflowSource
+	RateLimit            float64 // Outbound requests per second to Redmine; 0 uses REDMINE_RATE_LIMIT
+	RateBurst            int
+	ReadOnly             bool          // Reject POST/PUT/PATCH/DELETE with 403
+	ReadOnlyAllowUploads bool          // In read-only mode, still accept attachment uploads
+	ShutdownTimeout      time.Duration // Grace period for in-flight requests on shutdown; 0 uses 30s
+	MaxAttachmentMB      int           // Upload limit per file and per attach request; 0 uses redmine.DefaultMaxAttachmentMB
+	APIKey               string        // Server-side key for the enums of /openapi.json; empty serves the static spec
+	Username             string        // Server-side basic auth instead of APIKey
+	Password             string
+	StaticOpenAPI        bool         // Serve /openapi.json without enums fetched from Redmine
+	CORSOrigins          []string     // Origins allowed to call the API from a browser; "*" allows any, empty disables CORS
+	SkipSelfTest         bool         // Start without checking that Redmine answers and accepts the server-side credentials
+	HTTPClient           *http.Client // Shared by all Redmine clients and readiness probes; nil uses the defaults
 }
 
+// defaultShutdownTimeout is the shutdown grace period when none is configured
+const defaultShutdownTimeout = 30 * time.Second
+
 // Server is the REST API server
 type Server struct {
 	config      Config
@@ -29,6 +49,25 @@ type Server struct {
 	rateLimiter *RateLimiter
 	rules       *redmine.CustomFieldRules
 	workflow    *redmine.WorkflowRules
+	health      *health.Checker
+
+	// redmineLimiter throttles outbound requests, shared by all per-request clients
+	redmineLimiter *redmine.RateLimiter
+
+	// week sets the week start and work days of reports
+	week redmine.WorkWeek
+
+	// location is the default timezone deciding what "today" is in reports
+	location *time.Location
+
+	// maxAttachmentSize caps uploaded files, in bytes
+	maxAttachmentSize int64
+
+	// openAPI renders /openapi.json
+	openAPI *openAPIGenerator
+
+	// keys caches validated API keys and their resolvers
+	keys *keyCache
 }
 
 // NewServer creates a new API server
@@ -61,7 +100,28 @@ func NewServer(config Config) *Server {
 		rateLimiter: NewRateLimiter(100, time.Second, 200), // 100 req/sec, burst 200
 		rules:       rules,
 		workflow:    workflow,
+		health:      health.NewChecker(config.RedmineURL, ""),
+		week:        redmine.WorkWeekFromEnv(),
+		location:    redmine.TimezoneFromEnv(),
+		keys:        newKeyCache(keyCacheTTL),
+
+		maxAttachmentSize: redmine.DefaultMaxAttachmentMB * 1024 * 1024,
 	}
+	if config.MaxAttachmentMB > 0 {
+		s.maxAttachmentSize = int64(config.MaxAttachmentMB) * 1024 * 1024
+	}
+
+	if config.ReadOnly {
+		slog.Info("read-only mode enabled - all write requests will be rejected", "allow_uploads", config.ReadOnlyAllowUploads)
+	}
+
+	if config.RateLimit > 0 {
+		s.redmineLimiter = redmine.NewRateLimiter(config.RateLimit, config.RateBurst)
+	}
+	if config.HTTPClient != nil {
+		s.health.Client = config.HTTPClient
+	}
+	s.openAPI = newOpenAPIGenerator(s.serverClient(), config.StaticOpenAPI)
 
 	s.setupRoutes()
 
@@ -71,6 +131,7 @@ func NewServer(config Config) *Server {
 		defer ticker.Stop()
 		for range ticker.C {
 			s.rateLimiter.Cleanup(10 * time.Minute)
+			s.keys.cleanup(2 * keyCacheTTL)
 		}
 	}()
 
@@ -84,16 +145,21 @@ func (s *Server) setupRoutes() {
 	// Middleware
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(requestLogger)
 	r.Use(middleware.Recoverer)
-	r.Use(securityHeaders)            // Security headers
-	r.Use(s.rateLimiter.Middleware)   // Rate limiting
+	r.Use(securityHeaders) // Security headers
+	if len(s.config.CORSOrigins) > 0 {
+		r.Use(cors(s.config.CORSOrigins)) // Before rate limiting and auth so preflights are answered
+	}
+	r.Use(s.rateLimiter.Middleware) // Rate limiting
 
 	// Health check
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
 	})
+	r.Get("/healthz", s.health.Live)
+	r.Get("/readyz", s.health.Ready)
 
 	// Swagger UI - uses swaggo generated docs
 	r.Get("/docs/*", httpSwagger.Handler(
@@ -106,8 +172,14 @@ func (s *Server) setupRoutes() {
 		_, _ = w.Write([]byte(openAPISpec))
 	})
 
+	// OpenAPI spec with tracker/status/priority/activity enums from Redmine
+	r.Get("/openapi.json", s.handleOpenAPIJSON)
+
 	// API routes with authentication middleware
 	r.Route("/api/v1", func(r chi.Router) {
+		if s.config.ReadOnly {
+			r.Use(readOnlyGuard(s.config.ReadOnlyAllowUploads))
+		}
 		r.Use(s.authMiddleware)
 
 		// Account
@@ -118,16 +190,24 @@ func (s *Server) setupRoutes() {
 		r.Post("/projects", s.handleCreateProject)
 		r.Get("/projects/{id}", s.handleGetProject)
 		r.Patch("/projects/{id}", s.handleUpdateProject)
+		r.Delete("/projects/{id}", s.handleDeleteProject)
+		r.Post("/projects/{id}/archive", s.handleSetProjectStatus("archive"))
+		r.Post("/projects/{id}/unarchive", s.handleSetProjectStatus("unarchive"))
+		r.Post("/projects/{id}/close", s.handleSetProjectStatus("close"))
+		r.Post("/projects/{id}/reopen", s.handleSetProjectStatus("reopen"))
 
-		// Issues (note: export.csv and batch-update must come before {id} to avoid wildcard match)
-		r.Get("/issues/export.csv", s.handleExportIssuesCSV)
+		// Issues (note: export, batch-update and bulk-create must come before {id} to avoid wildcard match)
+		r.Get("/issues/export", s.handleExportIssues)
+		r.Get("/issues/export.csv", s.handleExportIssues)
 		r.Post("/issues/batch-update", s.handleBatchUpdateIssues)
+		r.Post("/issues/bulk-create", s.handleBulkCreateIssues)
 		r.Get("/issues", s.handleSearchIssues)
 		r.Get("/issues/{id}", s.handleGetIssue)
 		r.Post("/issues", s.handleCreateIssue)
 		r.Patch("/issues/{id}", s.handleUpdateIssue)
 		r.Post("/issues/{id}/subtasks", s.handleCreateSubtask)
 		r.Post("/issues/{id}/watchers", s.handleAddWatcher)
+		r.Post("/issues/{id}/comments", s.handleAddComment)
 		r.Delete("/issues/{id}/watchers/{user_id}", s.handleRemoveWatcher)
 		r.Post("/issues/{id}/relations", s.handleAddRelation)
 		r.Post("/issues/{id}/copy", s.handleCopyIssue)
@@ -136,29 +216,54 @@ func (s *Server) setupRoutes() {
 		r.Delete("/relations/{id}", s.handleDeleteRelation)
 
 		// Time entries
+		r.Get("/time_entries", s.handleListTimeEntries)
 		r.Post("/time_entries", s.handleCreateTimeEntry)
+		r.Post("/time_entries/batch", s.handleCreateTimeEntriesBatch)
 		r.Patch("/time_entries/{id}", s.handleUpdateTimeEntry)
 		r.Delete("/time_entries/{id}", s.handleDeleteTimeEntry)
 
 		// Users
 		r.Get("/users", s.handleSearchUsers)
+		r.Get("/users/{id}", s.handleGetUser)
+		r.Get("/groups", s.handleListGroups)
 
 		// Search
 		r.Get("/search", s.handleGlobalSearch)
 
 		// Versions
 		r.Get("/projects/{id}/versions", s.handleListVersions)
+		r.Get("/projects/{id}/versions/{vid}/progress", s.handleVersionProgress)
 		r.Post("/projects/{id}/versions", s.handleCreateVersion)
 		r.Patch("/versions/{id}", s.handleUpdateVersion)
 
+		// Issue categories
+		r.Get("/projects/{id}/categories", s.handleListCategories)
+		r.Post("/projects/{id}/categories", s.handleCreateCategory)
+		r.Patch("/categories/{id}", s.handleUpdateCategory)
+		r.Delete("/categories/{id}", s.handleDeleteCategory)
+
+		// Memberships
+		r.Get("/projects/{id}/memberships", s.handleListMemberships)
+		r.Post("/projects/{id}/memberships", s.handleAddMembership)
+		r.Patch("/memberships/{id}", s.handleUpdateMembership)
+		r.Delete("/memberships/{id}", s.handleDeleteMembership)
+
 		// Wiki
 		r.Get("/projects/{id}/wiki", s.handleListWikiPages)
 		r.Get("/projects/{id}/wiki/{title}", s.handleGetWikiPage)
 		r.Put("/projects/{id}/wiki/{title}", s.handleCreateOrUpdateWikiPage)
+		r.Delete("/projects/{id}/wiki/{title}", s.handleDeleteWikiPage)
+
+		// Documents
+		r.Get("/projects/{id}/documents", s.handleListDocuments)
+		r.Post("/projects/{id}/documents", s.handleCreateDocument)
+		r.Get("/documents/{id}", s.handleGetDocument)
 
 		// Attachments
 		r.Post("/attachments/upload", s.handleUploadAttachment)
 		r.Get("/attachments/{id}/download", s.handleDownloadAttachment)
+		r.Patch("/attachments/{id}", s.handleUpdateAttachment)
+		r.Delete("/attachments/{id}", s.handleDeleteAttachment)
 		r.Get("/issues/{id}/attachments", s.handleListAttachments)
 		r.Post("/issues/{id}/attach", s.handleAttachToIssue)
 
@@ -173,27 +278,14 @@ func (s *Server) setupRoutes() {
 		// Reports
 		r.Get("/reports/weekly", s.handleWeeklyReport)
 		r.Get("/reports/standup", s.handleStandupReport)
+		r.Get("/reports/time", s.handleTimeEntryReport)
 	})
 }
 
-// authMiddleware extracts the Redmine API key and creates a client
-func (s *Server) authMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		apiKey := r.Header.Get("X-Redmine-API-Key")
-		if apiKey == "" {
-			http.Error(w, `{"error": "Missing X-Redmine-API-Key header"}`, http.StatusUnauthorized)
-			return
-		}
-
-		// Create client and store in context
-		client := redmine.NewClient(s.config.RedmineURL, apiKey)
-		ctx := withClient(r.Context(), client)
-		next.ServeHTTP(w, r.WithContext(ctx))
-	})
-}
-
-// Run starts the API server
-func (s *Server) Run() error {
+// Run starts the API server and serves until ctx is cancelled, then stops
+// accepting connections and waits up to the shutdown timeout for in-flight
+// requests to finish
+func (s *Server) Run(ctx context.Context) error {
 	addr := fmt.Sprintf(":%d", s.config.Port)
 
 	slog.Info("Starting REST API server",
@@ -202,7 +294,46 @@ func (s *Server) Run() error {
 		"docs", fmt.Sprintf("http://localhost:%d/docs/index.html", s.config.Port),
 	)
 
-	return http.ListenAndServe(addr, s.router)
+	// Callers bring their own keys; the server credentials, if any, are what can be checked
+	if !s.config.SkipSelfTest {
+		redmine.LogSelfTest(redmine.SelfTest(ctx, s.serverClient()))
+	}
+
+	// Generate the spec up front so the first GPT import doesn't wait on Redmine
+	go func() {
+		if _, err := s.openAPI.get(); err != nil {
+			slog.Warn("failed to generate OpenAPI spec", "error", err)
+		}
+	}()
+
+	srv := &http.Server{Addr: addr, Handler: s.router}
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	timeout := s.config.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	slog.Info("Shutting down REST API server", "grace_period", timeout)
+	s.health.SetDraining()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		_ = srv.Close()
+		return fmt.Errorf("graceful shutdown did not finish within %s: %w", timeout, err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	slog.Info("REST API server stopped")
+	return nil
 }
 
 const openAPISpec = `openapi: 3.0.3
@@ -214,12 +345,17 @@ servers:
   - url: /api/v1
 security:
   - ApiKeyAuth: []
+  - BasicAuth: []
 components:
   securitySchemes:
     ApiKeyAuth:
       type: apiKey
       in: header
       name: X-Redmine-API-Key
+    BasicAuth:
+      type: http
+      scheme: basic
+      description: Redmine username and password, for instances with REST API keys disabled
   schemas:
     Error:
       type: object
@@ -315,6 +451,101 @@ paths:
       responses:
         '200':
           description: Project updated
+    delete:
+      summary: Delete a project permanently
+      tags: [Projects]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+          description: Project ID or identifier
+        - name: confirm
+          in: query
+          required: true
+          schema:
+            type: boolean
+          description: Must be true; deletion removes all issues, wiki pages and files
+      responses:
+        '200':
+          description: Project deleted
+        '400':
+          description: confirm=true missing
+        '403':
+          description: Requires an administrator API key
+  /projects/{id}/archive:
+    post:
+      summary: Archive a project
+      tags: [Projects]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+          description: Project ID or identifier
+      responses:
+        '200':
+          description: Project archived
+        '403':
+          description: Requires an administrator API key
+        '404':
+          description: Project not found, or the Redmine version lacks this API (Redmine 5.0+)
+  /projects/{id}/unarchive:
+    post:
+      summary: Unarchive a project
+      tags: [Projects]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+          description: Project ID or identifier
+      responses:
+        '200':
+          description: Project unarchived
+        '403':
+          description: Requires an administrator API key
+        '404':
+          description: Project not found, or the Redmine version lacks this API (Redmine 5.0+)
+  /projects/{id}/close:
+    post:
+      summary: Close a project (read-only)
+      tags: [Projects]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+          description: Project ID or identifier
+      responses:
+        '200':
+          description: Project closed
+        '403':
+          description: Requires an administrator or the close project permission
+        '404':
+          description: Project not found, or the Redmine version lacks this API (Redmine 5.1+)
+  /projects/{id}/reopen:
+    post:
+      summary: Reopen a closed project
+      tags: [Projects]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+          description: Project ID or identifier
+      responses:
+        '200':
+          description: Project reopened
+        '403':
+          description: Requires an administrator or the close project permission
+        '404':
+          description: Project not found, or the Redmine version lacks this API (Redmine 5.1+)
   /custom_fields:
     get:
       summary: List all custom fields (admin)
@@ -384,6 +615,16 @@ paths:
                 assigned_to:
                   type: string
                   description: Assignee name or ID
+                status:
+                  type: string
+                  description: Initial status name or ID. Redmine ignores statuses the workflow doesn't allow for new issues; the response then has a warning
+                priority:
+                  type: string
+                  description: Priority name or ID
+                done_ratio:
+                  type: integer
+                  minimum: 0
+                  maximum: 100
                 parent_issue_id:
                   type: integer
                 start_date:
@@ -408,6 +649,16 @@ paths:
           required: true
           schema:
             type: integer
+        - name: include_spent_hours
+          in: query
+          schema:
+            type: boolean
+          description: Add spent_time with total hours and a per-user breakdown
+        - name: include_children
+          in: query
+          schema:
+            type: boolean
+          description: Add children with id, subject, status, assignee and done_ratio of each direct subtask
       responses:
         '200':
           description: Issue details with journals and relations
@@ -437,6 +688,15 @@ paths:
                   description: Comment to add
                 custom_fields:
                   type: object
+                clear_assigned_to:
+                  type: boolean
+                  description: Unassign the issue
+                clear_due_date:
+                  type: boolean
+                  description: Remove the due date
+                clear_description:
+                  type: boolean
+                  description: Empty the description
       responses:
         '200':
           description: Issue updated
@@ -466,6 +726,15 @@ paths:
                   type: string
                 assigned_to:
                   type: string
+                status:
+                  type: string
+                  description: Initial status name or ID
+                priority:
+                  type: string
+                done_ratio:
+                  type: integer
+                  minimum: 0
+                  maximum: 100
                 custom_fields:
                   type: object
       responses:
@@ -494,6 +763,33 @@ paths:
       responses:
         '200':
           description: Watcher added
+  /issues/{id}/comments:
+    post:
+      summary: Add a comment
+      description: Adds a journal note without changing any other issue field
+      tags: [Issues]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [notes]
+              properties:
+                notes:
+                  type: string
+                  description: Comment text
+                private_notes:
+                  type: boolean
+                  description: Make the comment private
+      responses:
+        '201':
+          description: Comment added; echoes the issue ID and the first 200 characters of the note
   /issues/{id}/relations:
     post:
       summary: Create a relation
@@ -517,11 +813,68 @@ paths:
                   description: Target issue ID
                 relation_type:
                   type: string
-                  enum: [relates, duplicates, blocks, precedes, copied_to]
+                  description: "How the issue relates to issue_to_id, case-insensitive: relates, duplicates, duplicated_by, blocks, blocked_by, precedes, follows, copied_to, copied_from. Inverse types are created as the forward type with the issues swapped"
+                delay:
+                  type: integer
+                  description: Days between the preceding and the following issue (precedes and follows only)
       responses:
         '201':
-          description: Relation created
+          description: Relation created; issue_id and issue_to_id are as stored, and swapped is true when an inverse type swapped them
   /time_entries:
+    get:
+      summary: List time entries
+      tags: [Time Entries]
+      parameters:
+        - name: project
+          in: query
+          schema:
+            type: string
+          description: Project name or ID
+        - name: user
+          in: query
+          schema:
+            type: string
+          description: "User name, ID or 'me'"
+        - name: issue_id
+          in: query
+          schema:
+            type: integer
+          description: Issue ID
+        - name: from
+          in: query
+          schema:
+            type: string
+          description: "Start date: YYYY-MM-DD or relative (today, '7 days ago', start_of_month, 2024-W12, ...)"
+        - name: to
+          in: query
+          schema:
+            type: string
+          description: End date, YYYY-MM-DD or relative like from
+        - name: period
+          in: query
+          schema:
+            type: string
+          description: Date shortcut such as this_week, last_month or last_30_days; from and to override its ends
+        - name: timezone
+          in: query
+          schema:
+            type: string
+          description: IANA timezone deciding what today is, e.g. Asia/Taipei. Defaults to REDMINE_MCP_TIMEZONE, else server local time
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            default: 25
+        - name: offset
+          in: query
+          schema:
+            type: integer
+            default: 0
+      responses:
+        '200':
+          description: Time entries with total_count and truncated
+        '400':
+          description: Invalid date, issue_id, or project or user that cannot be resolved
     post:
       summary: Create a time entry
       tags: [Time Entries]
@@ -530,10 +883,14 @@ paths:
           application/json:
             schema:
               type: object
-              required: [issue_id, hours]
+              required: [hours]
               properties:
                 issue_id:
                   type: integer
+                  description: Issue to log time on; give this or project
+                project:
+                  type: string
+                  description: Project name or ID, to log time on the project without an issue (e.g. meetings)
                 hours:
                   type: number
                 activity:
@@ -544,6 +901,38 @@ paths:
       responses:
         '201':
           description: Time entry created
+  /time_entries/batch:
+    post:
+      summary: Create multiple time entries (partial success)
+      tags: [Time Entries]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [entries]
+              properties:
+                entries:
+                  type: array
+                  items:
+                    type: object
+                    required: [issue_id, hours]
+                    properties:
+                      issue_id:
+                        type: integer
+                      hours:
+                        type: number
+                      spent_on:
+                        type: string
+                        format: date
+                      activity:
+                        type: string
+                        description: Activity name or ID
+                      comments:
+                        type: string
+      responses:
+        '200':
+          description: Created entries and failures with reasons
   /attachments/upload:
     post:
       summary: Upload a file
@@ -558,10 +947,58 @@ paths:
                 file:
                   type: string
                   format: binary
-                  description: File to upload (max 5MB)
+                  description: File to upload (max 5 MB unless REDMINE_MCP_MAX_ATTACHMENT_MB is set)
+                content_type:
+                  type: string
+                  description: MIME type; defaults to the part's Content-Type, then a guess from the filename and content
       responses:
         '200':
           description: Upload token
+  /attachments/{id}:
+    patch:
+      summary: Rename an attachment or change its description
+      tags: [Attachments]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+          description: Attachment ID
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                filename:
+                  type: string
+                description:
+                  type: string
+                  description: Empty string clears the description
+      responses:
+        '200':
+          description: The updated attachment
+        '400':
+          description: Nothing to update, or an empty filename
+    delete:
+      summary: Delete an attachment permanently
+      tags: [Attachments]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+          description: Attachment ID
+      responses:
+        '200':
+          description: Attachment deleted, with its filename, size and author for the record
+        '403':
+          description: Only the attachment's author or an administrator can delete it
+        '404':
+          description: Attachment not found
   /attachments/{id}/download:
     get:
       summary: Download an attachment
@@ -618,7 +1055,7 @@ paths:
                   items:
                     type: string
                     format: binary
-                  description: Files to attach (max 5MB each)
+                  description: Files to attach (max 5 MB in total unless REDMINE_MCP_MAX_ATTACHMENT_MB is set)
                 notes:
                   type: string
                   description: Notes/comment to add
@@ -730,6 +1167,41 @@ paths:
       responses:
         '200':
           description: List of users
+  /users/{id}:
+    get:
+      summary: Get a user with group memberships and roles per project
+      description: Login, status and last login are only returned to admins and for the API key's own user
+      tags: [Users]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+          description: "User ID, name, login or 'me'"
+        - name: project
+          in: query
+          schema:
+            type: string
+          description: Project name or ID to look a user name up in, for keys that can't list users
+      responses:
+        '200':
+          description: User details
+        '404':
+          description: User not found
+  /groups:
+    get:
+      summary: List groups (admin)
+      tags: [Users]
+      parameters:
+        - name: include_users
+          in: query
+          schema:
+            type: boolean
+          description: Include the users in each group
+      responses:
+        '200':
+          description: List of groups
   /issues/batch-update:
     post:
       summary: Batch update issues
@@ -758,9 +1230,72 @@ paths:
                 notes:
                   type: string
                   description: Comment to add
+                dry_run:
+                  type: boolean
+                  description: Resolve and validate without updating; the response adds the old and new status, assignee and priority of each issue under changes
       responses:
         '200':
           description: Batch update results with success and failed arrays
+  /issues/bulk-create:
+    post:
+      summary: Create issues in bulk
+      description: Creates up to 50 issues. Every item is validated first and nothing is created if any item is invalid; creation then continues on individual failures.
+      tags: [Issues]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [issues]
+              properties:
+                project:
+                  type: string
+                  description: Default project name or ID for items without one
+                tracker:
+                  type: string
+                  description: Default tracker name or ID for items without one
+                issues:
+                  type: array
+                  maxItems: 50
+                  items:
+                    type: object
+                    required: [subject]
+                    description: Same fields as POST /issues
+                    properties:
+                      project:
+                        type: string
+                      tracker:
+                        type: string
+                      subject:
+                        type: string
+                      description:
+                        type: string
+                      assigned_to:
+                        type: string
+                      status:
+                        type: string
+                      priority:
+                        type: string
+                      done_ratio:
+                        type: integer
+                      parent_issue_id:
+                        type: integer
+                      start_date:
+                        type: string
+                      due_date:
+                        type: string
+                      is_private:
+                        type: boolean
+                      custom_fields:
+                        type: object
+                link_sequentially:
+                  type: boolean
+                  description: Add a precedes relation from each created issue to the next one
+      responses:
+        '200':
+          description: Created issues under success, creation failures under failed, and added relations under relations
+        '400':
+          description: Validation problems of every invalid item; nothing was created
   /issues/{id}/copy:
     post:
       summary: Copy an issue
@@ -834,17 +1369,50 @@ paths:
       responses:
         '201':
           description: Created version
-  /versions/{id}:
-    patch:
-      summary: Update a version
+  /projects/{id}/versions/{vid}/progress:
+    get:
+      summary: Version progress
       tags: [Versions]
       parameters:
         - name: id
           in: path
           required: true
           schema:
-            type: integer
-          description: Version ID
+            type: string
+          description: Project ID or name
+        - name: vid
+          in: path
+          required: true
+          schema:
+            type: string
+          description: Version ID or name
+        - name: at_risk_days
+          in: query
+          schema:
+            type: integer
+            default: 7
+          description: At risk when fewer days than this remain and issues above the default priority are open
+        - name: timezone
+          in: query
+          schema:
+            type: string
+          description: IANA timezone deciding what today is, e.g. Asia/Taipei
+      responses:
+        '200':
+          description: Issue counts, completion percent, open issues by priority, overdue issues, estimated and spent hours, days remaining and at_risk
+        '400':
+          description: Unknown project or version, or invalid at_risk_days or timezone
+  /versions/{id}:
+    patch:
+      summary: Update a version
+      tags: [Versions]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+          description: Version ID
       requestBody:
         content:
           application/json:
@@ -867,6 +1435,169 @@ paths:
       responses:
         '200':
           description: Version updated
+  /projects/{id}/categories:
+    get:
+      summary: List issue categories
+      tags: [Categories]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+          description: Project ID or name
+      responses:
+        '200':
+          description: Categories with their default assignees
+    post:
+      summary: Create an issue category
+      tags: [Categories]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+          description: Project ID or name
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                assigned_to:
+                  type: string
+                  description: Default assignee name or ID
+      responses:
+        '201':
+          description: Created category
+  /categories/{id}:
+    patch:
+      summary: Update an issue category
+      tags: [Categories]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+          description: Category ID
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                assigned_to:
+                  type: string
+                  description: Default assignee name or ID
+      responses:
+        '200':
+          description: Category updated
+        '400':
+          description: Neither name nor assigned_to given
+    delete:
+      summary: Delete an issue category
+      tags: [Categories]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+          description: Category ID
+      responses:
+        '200':
+          description: Category deleted
+  /projects/{id}/memberships:
+    get:
+      summary: List project memberships
+      tags: [Memberships]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+          description: Project ID or name
+      responses:
+        '200':
+          description: Users and groups with their roles
+    post:
+      summary: Add a user or group to a project
+      tags: [Memberships]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+          description: Project ID or name
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [roles]
+              properties:
+                user:
+                  type: string
+                  description: User name or ID (either user or group)
+                group:
+                  type: string
+                  description: Group name or ID (either user or group)
+                roles:
+                  type: array
+                  items:
+                    type: string
+                  description: Role names or IDs
+      responses:
+        '201':
+          description: Created membership
+  /memberships/{id}:
+    patch:
+      summary: Replace the roles of a membership
+      tags: [Memberships]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+          description: Membership ID
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [roles]
+              properties:
+                roles:
+                  type: array
+                  items:
+                    type: string
+                  description: Role names or IDs
+      responses:
+        '200':
+          description: Membership updated
+    delete:
+      summary: Remove a membership
+      tags: [Memberships]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+          description: Membership ID
+      responses:
+        '200':
+          description: Membership removed
   /projects/{id}/wiki:
     get:
       summary: List wiki pages
@@ -930,12 +1661,163 @@ paths:
                 comments:
                   type: string
                   description: Edit comment / version note
+                parent_title:
+                  type: string
+                  description: Title of the parent page
       responses:
         '200':
           description: Wiki page saved
+    delete:
+      summary: Delete wiki page
+      tags: [Wiki]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+          description: Project ID
+        - name: title
+          in: path
+          required: true
+          schema:
+            type: string
+          description: Wiki page title
+      responses:
+        '200':
+          description: Wiki page deleted
+  /projects/{id}/documents:
+    get:
+      summary: List documents
+      tags: [Documents]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+          description: Project ID
+      responses:
+        '200':
+          description: List of documents
+    post:
+      summary: Create document
+      tags: [Documents]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+          description: Project ID
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [title]
+              properties:
+                title:
+                  type: string
+                description:
+                  type: string
+                category:
+                  type: string
+                  description: Document category name or ID (defaults to the default category)
+                upload_tokens:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      token:
+                        type: string
+                      filename:
+                        type: string
+                      content_type:
+                        type: string
+                      description:
+                        type: string
+      responses:
+        '201':
+          description: Document created
+  /documents/{id}:
+    get:
+      summary: Get document
+      tags: [Documents]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+          description: Document ID
+      responses:
+        '200':
+          description: Document with attachments
+  /issues/export:
+    get:
+      summary: Export issues as CSV, JSON Lines or a Markdown table
+      tags: [Issues]
+      parameters:
+        - name: project
+          in: query
+          schema:
+            type: string
+          description: Project name or ID
+        - name: tracker
+          in: query
+          schema:
+            type: string
+          description: Tracker name or ID
+        - name: status
+          in: query
+          schema:
+            type: string
+          description: "Status: open, closed, all, or specific name"
+        - name: assigned_to
+          in: query
+          schema:
+            type: string
+          description: "Assignee name or 'me'"
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            default: 100
+        - name: format
+          in: query
+          schema:
+            type: string
+            enum: [csv, jsonl, markdown]
+            default: csv
+          description: Output format
+        - name: columns
+          in: query
+          schema:
+            type: string
+          description: "Comma-separated columns for csv and markdown: id, subject, project, tracker, status, priority, assignee, author, start, due, done_ratio, estimated_hours, spent_hours, created, updated, description, or custom field names/IDs (multiple values joined with ';')"
+        - name: include_description
+          in: query
+          schema:
+            type: boolean
+            default: false
+          description: Add the description as a last column, with line breaks escaped as \n
+      responses:
+        '200':
+          description: Exported issues
+          content:
+            text/csv:
+              schema:
+                type: string
+            application/x-ndjson:
+              schema:
+                type: string
+            text/markdown:
+              schema:
+                type: string
   /issues/export.csv:
     get:
-      summary: Export issues as CSV
+      summary: Export issues (same as /issues/export, kept for existing clients)
       tags: [Issues]
       parameters:
         - name: project
@@ -963,13 +1845,37 @@ paths:
           schema:
             type: integer
             default: 100
+        - name: format
+          in: query
+          schema:
+            type: string
+            enum: [csv, jsonl, markdown]
+            default: csv
+          description: Output format
+        - name: columns
+          in: query
+          schema:
+            type: string
+          description: "Comma-separated columns for csv and markdown: id, subject, project, tracker, status, priority, assignee, author, start, due, done_ratio, estimated_hours, spent_hours, created, updated, description, or custom field names/IDs (multiple values joined with ';')"
+        - name: include_description
+          in: query
+          schema:
+            type: boolean
+            default: false
+          description: Add the description as a last column, with line breaks escaped as \n
       responses:
         '200':
-          description: CSV file
+          description: Exported issues
           content:
             text/csv:
               schema:
                 type: string
+            application/x-ndjson:
+              schema:
+                type: string
+            text/markdown:
+              schema:
+                type: string
   /search:
     get:
       summary: Search across all Redmine resources
@@ -1001,6 +1907,36 @@ paths:
           schema:
             type: boolean
           description: Include wiki pages
+        - name: news
+          in: query
+          schema:
+            type: boolean
+          description: Include news
+        - name: documents
+          in: query
+          schema:
+            type: boolean
+          description: Include documents
+        - name: changesets
+          in: query
+          schema:
+            type: boolean
+          description: Include changesets
+        - name: messages
+          in: query
+          schema:
+            type: boolean
+          description: Include forum messages
+        - name: projects
+          in: query
+          schema:
+            type: boolean
+          description: Include projects
+        - name: offset
+          in: query
+          schema:
+            type: integer
+            default: 0
         - name: limit
           in: query
           schema:
@@ -1019,16 +1955,35 @@ paths:
           schema:
             type: string
             default: me
-          description: "User name or 'me'"
+          description: "User name, ID or 'me'. Names need an API key allowed to list users"
         - name: week_of
           in: query
           schema:
             type: string
             format: date
           description: Date within the target week (YYYY-MM-DD)
+        - name: timezone
+          in: query
+          schema:
+            type: string
+          description: IANA timezone deciding what today is, e.g. Asia/Taipei. Defaults to REDMINE_MCP_TIMEZONE, else server local time
+        - name: include_previous_week
+          in: query
+          schema:
+            type: boolean
+            default: false
+          description: Add previous_week with the week before's total and per-project hours and the change to this week
+        - name: include_weekend
+          in: query
+          schema:
+            type: boolean
+            default: false
+          description: Cover every day of the week, not only the work days
       responses:
         '200':
-          description: Weekly report with daily totals and entries
+          description: "Weekly report with hours by day, project, issue and activity. Deprecated: week, daily_totals, project_totals and entries are the keys from before period and the by_* breakdowns, kept for existing clients"
+        '400':
+          description: Invalid date or timezone, or no user or several matching the name
   /reports/standup:
     get:
       summary: Standup report
@@ -1039,16 +1994,81 @@ paths:
           schema:
             type: string
             default: me
-          description: "User name or 'me'"
+          description: "User name, ID or 'me'. Names need an API key allowed to list users"
         - name: date
           in: query
           schema:
             type: string
             format: date
           description: Report date (YYYY-MM-DD), defaults to today
+        - name: timezone
+          in: query
+          schema:
+            type: string
+          description: IANA timezone deciding what today is, e.g. Asia/Taipei. Defaults to REDMINE_MCP_TIMEZONE, else server local time
+        - name: include_time_entries
+          in: query
+          schema:
+            type: boolean
+            default: true
+          description: Include yesterday's time entries; false leaves only the issue activity
+      responses:
+        '200':
+          description: "Standup report with yesterday's time entries and issue activity and today's open issues. Deprecated: yesterday.work repeats yesterday.entries, kept for existing clients"
+        '400':
+          description: Invalid date or timezone, or no user or several matching the name
+  /reports/time:
+    get:
+      summary: Time entry report
+      tags: [Reports]
+      parameters:
+        - name: group_by
+          in: query
+          required: true
+          schema:
+            type: string
+          description: "Comma-separated dimensions: project, user, activity, issue, date. Date groups are sorted chronologically"
+        - name: project
+          in: query
+          schema:
+            type: string
+          description: Project name or ID
+        - name: user
+          in: query
+          schema:
+            type: string
+          description: "User name, ID or 'me'"
+        - name: from
+          in: query
+          schema:
+            type: string
+          description: "Start date: YYYY-MM-DD or relative (today, '7 days ago', start_of_month, 2024-W12, ...)"
+        - name: to
+          in: query
+          schema:
+            type: string
+          description: End date, YYYY-MM-DD or relative like from
+        - name: period
+          in: query
+          schema:
+            type: string
+          description: Date shortcut such as this_week, last_month or last_30_days; from and to override its ends
+        - name: timezone
+          in: query
+          schema:
+            type: string
+          description: IANA timezone deciding what today is, e.g. Asia/Taipei. Defaults to REDMINE_MCP_TIMEZONE, else server local time
+        - name: format
+          in: query
+          schema:
+            type: string
+            enum: [json, csv]
+            default: json
       responses:
         '200':
-          description: Standup report with yesterday's work and today's open issues
+          description: Total hours, entry count and hours per group, or CSV with a total row
+        '400':
+          description: Invalid group_by, format, date, or project or user that cannot be resolved
   /trackers:
     get:
       summary: List trackers
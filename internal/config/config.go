@@ -0,0 +1,271 @@
+// Package config loads a YAML file declaring the options of every command
+// in one place. Each option of the file maps to the environment variable
+// and flag that already set it, and the file only fills in what neither
+// sets, so flags override environment variables, which override the file,
+// which overrides the built-in defaults.
+//
+// String values may reference environment variables as ${NAME}, which
+// keeps secrets such as the API key out of the file.
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+	"gopkg.in/yaml.v3"
+)
+
+// Kind is the type of value an option takes
+type Kind int
+
+const (
+	Text     Kind = iota // a string or a number, such as a URL or "5:10"
+	Bool                 // true or false
+	Int                  // a whole number
+	Duration             // a duration such as "30s"
+	List                 // a list of strings, or one comma-separated string
+)
+
+// Option is a setting a config file can set, and where it is set otherwise
+type Option struct {
+	Key  string // dotted key in the file, e.g. "redmine.url"
+	Env  string // environment variable; empty when there is none
+	Flag string // command-line flag; empty when there is none
+	Kind Kind
+}
+
+// Options are the settings a config file can set
+var Options = []Option{
+	{Key: "redmine.url", Env: "REDMINE_URL", Flag: "redmine-url"},
+	{Key: "redmine.api_key", Env: "REDMINE_API_KEY"},
+	{Key: "redmine.username", Env: "REDMINE_USERNAME"},
+	{Key: "redmine.password", Env: "REDMINE_PASSWORD"},
+	{Key: "redmine.rate_limit", Env: "REDMINE_RATE_LIMIT", Flag: "rate-limit"},
+	{Key: "redmine.max_retries", Env: "REDMINE_MAX_RETRIES", Kind: Int},
+	{Key: "redmine.timeout", Env: "REDMINE_TIMEOUT", Flag: "redmine-timeout", Kind: Duration},
+	{Key: "redmine.ca_file", Env: "REDMINE_CA_FILE", Flag: "redmine-ca-file"},
+	{Key: "redmine.insecure_skip_verify", Env: "REDMINE_INSECURE_SKIP_VERIFY", Flag: "redmine-insecure-skip-verify", Kind: Bool},
+	{Key: "redmine.proxy", Env: "REDMINE_PROXY", Flag: "redmine-proxy"},
+	{Key: "redmine.max_attachment_mb", Env: "REDMINE_MCP_MAX_ATTACHMENT_MB", Flag: "max-attachment-mb", Kind: Int},
+	{Key: "redmine.timezone", Env: "REDMINE_MCP_TIMEZONE"},
+	{Key: "redmine.week_start", Env: "REDMINE_MCP_WEEK_START"},
+	{Key: "redmine.work_days", Env: "REDMINE_MCP_WORK_DAYS"},
+
+	{Key: "server.port", Flag: "port", Kind: Int},
+	{Key: "server.log_level", Flag: "log-level"},
+	{Key: "server.lang", Env: "REDMINE_MCP_LANG", Flag: "lang"},
+	{Key: "server.shutdown_timeout", Env: "REDMINE_SHUTDOWN_TIMEOUT", Flag: "shutdown-timeout", Kind: Duration},
+	{Key: "server.skip_selftest", Env: "REDMINE_SKIP_SELFTEST", Flag: "skip-selftest", Kind: Bool},
+
+	{Key: "rules.custom_fields_file", Env: "CUSTOM_FIELD_RULES_FILE", Flag: "custom-field-rules"},
+	{Key: "rules.workflow_file", Env: "WORKFLOW_RULES_FILE", Flag: "workflow-rules"},
+	{Key: "rules.workflow_source", Env: "WORKFLOW_SOURCE", Flag: "workflow-source"},
+
+	{Key: "mcp.read_only", Env: "REDMINE_MCP_READ_ONLY", Kind: Bool},
+	{Key: "mcp.allowed_write_tools", Env: "REDMINE_MCP_ALLOWED_WRITE_TOOLS", Kind: List},
+	{Key: "mcp.allow_impersonation", Env: "REDMINE_MCP_ALLOW_IMPERSONATION", Kind: Bool},
+	{Key: "mcp.audit_log", Env: "REDMINE_MCP_AUDIT_LOG"},
+	{Key: "mcp.max_response_bytes", Env: "REDMINE_MCP_MAX_RESPONSE_BYTES", Kind: Int},
+	{Key: "mcp.custom_field_hint_length", Env: "REDMINE_MCP_CUSTOM_FIELD_HINT_LENGTH", Flag: "custom-field-hint-length", Kind: Int},
+	{Key: "mcp.tool_defaults_file", Env: "REDMINE_MCP_TOOL_DEFAULTS", Flag: "tool-defaults"},
+	{Key: "mcp.templates_file", Env: "REDMINE_MCP_TEMPLATES_FILE", Flag: "templates"},
+
+	{Key: "api.read_only", Env: "REDMINE_API_READ_ONLY", Kind: Bool},
+	{Key: "api.read_only_allow_uploads", Env: "REDMINE_API_READ_ONLY_ALLOW_UPLOADS", Kind: Bool},
+	{Key: "api.cors_origins", Env: "REDMINE_API_CORS_ORIGINS", Flag: "cors-origins", Kind: List},
+	{Key: "api.static_openapi", Env: "REDMINE_API_STATIC_OPENAPI", Flag: "static-openapi", Kind: Bool},
+
+	{Key: "watch.webhook_url", Env: "REDMINE_WATCH_WEBHOOK_URL", Flag: "webhook-url"},
+	{Key: "watch.interval", Flag: "interval", Kind: Duration},
+	{Key: "watch.state_file", Flag: "state-file"},
+}
+
+// File is a loaded config file. Values holds each option the file sets, by
+// key, in the string form its environment variable or flag takes.
+type File struct {
+	Path   string
+	Values map[string]string
+}
+
+// envRef matches a ${NAME} reference to an environment variable
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Load reads and validates a config file, reporting every unknown key and
+// invalid value with its line
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	f := &File{Path: path, Values: make(map[string]string)}
+	if len(root.Content) == 0 {
+		return f, nil
+	}
+	var problems []string
+	f.load("", root.Content[0], &problems)
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid config file %s:\n- %s", path, strings.Join(problems, "\n- "))
+	}
+	return f, nil
+}
+
+// load records the options of a mapping whose keys start with prefix
+func (f *File) load(prefix string, node *yaml.Node, problems *[]string) {
+	if node.Kind != yaml.MappingNode {
+		*problems = append(*problems, fmt.Sprintf("line %d: %s must be a mapping of options", node.Line, sectionName(prefix)))
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		i := slices.IndexFunc(Options, func(o Option) bool { return o.Key == key })
+		switch {
+		case i >= 0:
+			value, err := Options[i].parse(valueNode)
+			if err != nil {
+				*problems = append(*problems, fmt.Sprintf("line %d: %s: %v", valueNode.Line, key, err))
+			} else if valueNode.Tag != "!!null" {
+				f.Values[key] = value
+			}
+		case isSection(key):
+			f.load(key, valueNode, problems)
+		default:
+			*problems = append(*problems, fmt.Sprintf("line %d: unknown option %s%s", keyNode.Line, key, suggestKey(key)))
+		}
+	}
+}
+
+// parse converts a value of the file to the string form of the option's
+// environment variable, checking it has the option's kind
+func (o Option) parse(node *yaml.Node) (string, error) {
+	if o.Kind == List && node.Kind == yaml.SequenceNode {
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return "", fmt.Errorf("list items must be strings")
+			}
+			s, err := interpolate(item.Value)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	}
+	if node.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("expected a single value")
+	}
+	s, err := interpolate(node.Value)
+	if err != nil {
+		return "", err
+	}
+
+	switch o.Kind {
+	case Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return "", fmt.Errorf("expected true or false, got %q", s)
+		}
+		return strconv.FormatBool(b), nil
+	case Int:
+		if _, err := strconv.Atoi(s); err != nil {
+			return "", fmt.Errorf("expected a whole number, got %q", s)
+		}
+	case Duration:
+		if _, err := time.ParseDuration(s); err != nil {
+			return "", fmt.Errorf("expected a duration such as 30s or 5m, got %q", s)
+		}
+	}
+	return s, nil
+}
+
+// interpolate replaces ${NAME} references with the environment variables
+func interpolate(s string) (string, error) {
+	var missing []string
+	out := envRef.ReplaceAllStringFunc(s, func(ref string) string {
+		name := envRef.FindStringSubmatch(ref)[1]
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// Apply sets the options of the file that no flag or environment variable
+// sets: the environment variable, which commands read at startup, and the
+// flag of flags, when the command has it
+func (f *File) Apply(flags *pflag.FlagSet) error {
+	for _, o := range Options {
+		value, ok := f.Values[o.Key]
+		if !ok {
+			continue
+		}
+		var flag *pflag.Flag
+		if o.Flag != "" {
+			flag = flags.Lookup(o.Flag)
+		}
+		if flag != nil && flag.Changed {
+			continue
+		}
+		if o.Env != "" {
+			// Flag defaults were read from the environment already
+			if os.Getenv(o.Env) != "" {
+				continue
+			}
+			if err := os.Setenv(o.Env, value); err != nil {
+				return fmt.Errorf("failed to set %s from %s: %w", o.Env, f.Path, err)
+			}
+		}
+		if flag != nil {
+			if err := flag.Value.Set(value); err != nil {
+				return fmt.Errorf("invalid %s in %s: %w", o.Key, f.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isSection reports whether key is the prefix of option keys
+func isSection(key string) bool {
+	return slices.ContainsFunc(Options, func(o Option) bool { return strings.HasPrefix(o.Key, key+".") })
+}
+
+func sectionName(prefix string) string {
+	if prefix == "" {
+		return "the file"
+	}
+	return prefix
+}
+
+// suggestKey returns a "did you mean" hint for an unknown key
+func suggestKey(key string) string {
+	keys := make([]string, len(Options))
+	for i, o := range Options {
+		keys[i] = o.Key
+	}
+	if suggestions := redmine.SuggestNames(key, keys, redmine.MaxSuggestions); len(suggestions) > 0 {
+		return " (did you mean " + strings.Join(suggestions, ", ") + "?)"
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("TEST_REDMINE_KEY", "s3cret")
+	path := writeConfig(t, `
+redmine:
+  url: https://redmine.example.com
+  api_key: ${TEST_REDMINE_KEY}
+  rate_limit: 5
+server:
+  port: 9090
+  shutdown_timeout: 10s
+  lang:
+mcp:
+  read_only: true
+  allowed_write_tools: [issues_create, timeEntries_create]
+api:
+  cors_origins: https://a.example.com,https://b.example.com
+`)
+	f, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"redmine.url":             "https://redmine.example.com",
+		"redmine.api_key":         "s3cret",
+		"redmine.rate_limit":      "5",
+		"server.port":             "9090",
+		"server.shutdown_timeout": "10s",
+		"mcp.read_only":           "true",
+		"mcp.allowed_write_tools": "issues_create,timeEntries_create",
+		"api.cors_origins":        "https://a.example.com,https://b.example.com",
+	}
+	for key, value := range want {
+		if f.Values[key] != value {
+			t.Errorf("%s = %q, want %q", key, f.Values[key], value)
+		}
+	}
+	if _, ok := f.Values["server.lang"]; ok || len(f.Values) != len(want) {
+		t.Errorf("unexpected values: %v", f.Values)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	path := writeConfig(t, `redmine:
+  ulr: https://redmine.example.com
+  api_key: ${TEST_UNSET_VARIABLE}
+server:
+  port: eighty
+  shutdown_timeout: 10
+mcp: true
+logging: debug
+`)
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{
+		"line 2: unknown option redmine.ulr (did you mean redmine.url?)",
+		"line 3: redmine.api_key: environment variable TEST_UNSET_VARIABLE is not set",
+		`line 5: server.port: expected a whole number, got "eighty"`,
+		"line 6: server.shutdown_timeout: expected a duration",
+		"line 7: mcp must be a mapping of options",
+		"line 8: unknown option logging",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in:\n%v", want, err)
+		}
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestApply(t *testing.T) {
+	f := &File{Path: "config.yaml", Values: map[string]string{
+		"redmine.url":             "https://file.example.com",
+		"redmine.rate_limit":      "5",
+		"server.port":             "9090",
+		"server.shutdown_timeout": "10s",
+		"mcp.read_only":           "true",
+		"watch.interval":          "5m",
+	}}
+	t.Setenv("REDMINE_URL", "https://env.example.com")
+	t.Setenv("REDMINE_RATE_LIMIT", "")
+	t.Setenv("REDMINE_SHUTDOWN_TIMEOUT", "")
+	t.Setenv("REDMINE_MCP_READ_ONLY", "")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	url := flags.String("redmine-url", os.Getenv("REDMINE_URL"), "")
+	rateLimit := flags.String("rate-limit", "", "")
+	port := flags.Int("port", 8080, "")
+	shutdown := flags.Duration("shutdown-timeout", 30*time.Second, "")
+	if err := flags.Parse([]string{"--shutdown-timeout=1m"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.Apply(flags); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The environment overrides the file, and flags override both
+	if *url != "https://env.example.com" || os.Getenv("REDMINE_URL") != "https://env.example.com" {
+		t.Errorf("expected the environment's URL, got %q", *url)
+	}
+	if *shutdown != time.Minute || os.Getenv("REDMINE_SHUTDOWN_TIMEOUT") != "" {
+		t.Errorf("expected the flag's shutdown timeout, got %v", *shutdown)
+	}
+	// The file overrides defaults, for flags and settings read from the environment
+	if *rateLimit != "5" || os.Getenv("REDMINE_RATE_LIMIT") != "5" {
+		t.Errorf("expected the file's rate limit, got %q", *rateLimit)
+	}
+	if *port != 9090 {
+		t.Errorf("expected the file's port, got %d", *port)
+	}
+	if os.Getenv("REDMINE_MCP_READ_ONLY") != "true" {
+		t.Error("expected the file to set REDMINE_MCP_READ_ONLY")
+	}
+}
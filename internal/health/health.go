@@ -0,0 +1,110 @@
+// Package health serves the liveness and readiness endpoints shared by the
+// HTTP modes of the MCP server and the REST API.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultReadyTimeout bounds the Redmine request made by a readiness probe
+const DefaultReadyTimeout = 3 * time.Second
+
+// Checker answers liveness and readiness probes. Readiness requires Redmine
+// to answer GET /users/current.json; any response below 500, including 401
+// for a server without its own API key, means Redmine is reachable.
+type Checker struct {
+	RedmineURL string
+	APIKey     string // Optional; sent so a configured key is exercised too
+	Username   string // Optional; basic auth sent instead of APIKey
+	Password   string
+	Timeout    time.Duration
+	Client     *http.Client
+
+	draining atomic.Bool
+}
+
+// NewChecker creates a checker for redmineURL with DefaultReadyTimeout
+func NewChecker(redmineURL, apiKey string) *Checker {
+	return &Checker{
+		RedmineURL: strings.TrimSuffix(redmineURL, "/"),
+		APIKey:     apiKey,
+		Timeout:    DefaultReadyTimeout,
+		Client:     http.DefaultClient,
+	}
+}
+
+// SetDraining marks the server as shutting down, so readiness fails and the
+// load balancer stops sending new traffic while in-flight requests finish
+func (c *Checker) SetDraining() {
+	c.draining.Store(true)
+}
+
+// Live handles GET /healthz: the process is up and serving HTTP
+func (c *Checker) Live(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"status": "ok"})
+}
+
+// Ready handles GET /readyz: 200 when Redmine is reachable, 503 otherwise
+func (c *Checker) Ready(w http.ResponseWriter, r *http.Request) {
+	if c.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]any{"status": "shutting_down"})
+		return
+	}
+
+	start := time.Now()
+	code, err := c.pingRedmine(r.Context())
+	latency := time.Since(start).Milliseconds()
+	if err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]any{
+			"status":     "unavailable",
+			"redmine":    "unreachable",
+			"error":      err.Error(),
+			"latency_ms": latency,
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"status":         "ready",
+		"redmine":        "reachable",
+		"redmine_status": code,
+		"latency_ms":     latency,
+	})
+}
+
+// pingRedmine requests /users/current.json and returns its status code
+func (c *Checker) pingRedmine(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.RedmineURL+"/users/current.json", nil)
+	if err != nil {
+		return 0, err
+	}
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	} else if c.APIKey != "" {
+		req.Header.Set("X-Redmine-API-Key", c.APIKey)
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return resp.StatusCode, fmt.Errorf("redmine returned HTTP %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body map[string]any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
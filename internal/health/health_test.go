@@ -0,0 +1,88 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func probe(t *testing.T, handler http.HandlerFunc) (int, map[string]any) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var body map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to parse body %q: %v", w.Body.String(), err)
+	}
+	return w.Code, body
+}
+
+func TestChecker_Live(t *testing.T) {
+	c := NewChecker("http://127.0.0.1:1", "")
+	if code, body := probe(t, c.Live); code != http.StatusOK || body["status"] != "ok" {
+		t.Errorf("expected 200 ok, got %d %v", code, body)
+	}
+}
+
+func TestChecker_Ready(t *testing.T) {
+	status := http.StatusUnauthorized
+	var gotKey string
+	redmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/current.json" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		gotKey = r.Header.Get("X-Redmine-API-Key")
+		w.WriteHeader(status)
+	}))
+	defer redmine.Close()
+
+	// Without credentials Redmine answers 401, which still proves it's up
+	c := NewChecker(redmine.URL+"/", "")
+	if code, body := probe(t, c.Ready); code != http.StatusOK || body["redmine"] != "reachable" || body["redmine_status"] != float64(401) {
+		t.Errorf("expected ready, got %d %v", code, body)
+	}
+
+	c = NewChecker(redmine.URL, "secret")
+	status = http.StatusOK
+	if code, _ := probe(t, c.Ready); code != http.StatusOK || gotKey != "secret" {
+		t.Errorf("expected ready with the API key sent, got %d (key %q)", code, gotKey)
+	}
+
+	status = http.StatusBadGateway
+	if code, body := probe(t, c.Ready); code != http.StatusServiceUnavailable || body["redmine"] != "unreachable" {
+		t.Errorf("expected unavailable on 5xx, got %d %v", code, body)
+	}
+
+	status = http.StatusOK
+	c.SetDraining()
+	if code, body := probe(t, c.Ready); code != http.StatusServiceUnavailable || body["status"] != "shutting_down" {
+		t.Errorf("expected shutting_down while draining, got %d %v", code, body)
+	}
+	if code, _ := probe(t, c.Live); code != http.StatusOK {
+		t.Errorf("expected liveness to pass while draining, got %d", code)
+	}
+}
+
+func TestChecker_ReadyTimeout(t *testing.T) {
+	release := make(chan struct{})
+	redmine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer redmine.Close()
+	defer close(release)
+
+	c := NewChecker(redmine.URL, "")
+	c.Timeout = 50 * time.Millisecond
+	start := time.Now()
+	if code, _ := probe(t, c.Ready); code != http.StatusServiceUnavailable {
+		t.Errorf("expected unavailable on timeout, got %d", code)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the probe to give up after the timeout, took %s", elapsed)
+	}
+}
@@ -0,0 +1,127 @@
+// Package i18n translates the messages the server itself generates: tool
+// errors, result notes and rendered headings. Data coming from Redmine is
+// never translated.
+//
+// Catalogs are keyed by the English message, gettext style, so a message
+// without a translation falls back to English and adding a translation
+// never touches the calling code. A translated format must use the same
+// verbs as the English one, which keeps %w wrapping intact in Errorf.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultLanguage is the language the messages are written in
+const DefaultLanguage = "en"
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// Printer formats messages in one language
+type Printer struct {
+	lang     string
+	messages map[string]string
+}
+
+// Languages returns the supported language tags, the default first
+func Languages() []string {
+	langs := []string{DefaultLanguage}
+	entries, _ := locales.ReadDir("locales")
+	for _, e := range entries {
+		langs = append(langs, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	return langs
+}
+
+// NewPrinter returns a Printer for lang, matched ignoring case and with "_"
+// accepted for "-" (zh_TW). An empty lang selects English.
+func NewPrinter(lang string) (*Printer, error) {
+	tag := strings.ReplaceAll(strings.TrimSpace(lang), "_", "-")
+	if tag == "" {
+		tag = DefaultLanguage
+	}
+	i := slices.IndexFunc(Languages(), func(l string) bool { return strings.EqualFold(l, tag) })
+	if i < 0 {
+		return nil, fmt.Errorf("unsupported language %q (use %s)", lang, strings.Join(Languages(), ", "))
+	}
+	tag = Languages()[i]
+	if tag == DefaultLanguage {
+		return &Printer{lang: tag}, nil
+	}
+
+	data, err := locales.ReadFile(path.Join("locales", tag+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s messages: %w", tag, err)
+	}
+	messages := make(map[string]string)
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("failed to parse %s messages: %w", tag, err)
+	}
+	return &Printer{lang: tag, messages: messages}, nil
+}
+
+// Language returns the language tag of the printer
+func (p *Printer) Language() string {
+	if p == nil {
+		return DefaultLanguage
+	}
+	return p.lang
+}
+
+// T returns the translation of msg, or msg when there is none. A nil
+// Printer returns msg.
+func (p *Printer) T(msg string) string {
+	if p == nil {
+		return msg
+	}
+	if translated, ok := p.messages[msg]; ok && translated != "" {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf formats the translation of format
+func (p *Printer) Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(p.T(format), args...)
+}
+
+// Errorf formats the translation of format as an error; %w wraps as in fmt.Errorf
+func (p *Printer) Errorf(format string, args ...any) error {
+	return fmt.Errorf(p.T(format), args...)
+}
+
+var defaultPrinter atomic.Pointer[Printer]
+
+// SetDefault makes p the printer of the package-level functions, like
+// slog.SetDefault. It is set once at startup from REDMINE_MCP_LANG.
+func SetDefault(p *Printer) {
+	defaultPrinter.Store(p)
+}
+
+// Default returns the printer of the package-level functions; English until
+// SetDefault is called
+func Default() *Printer {
+	return defaultPrinter.Load()
+}
+
+// T translates msg with the default printer
+func T(msg string) string {
+	return Default().T(msg)
+}
+
+// Sprintf formats the translation of format with the default printer
+func Sprintf(format string, args ...any) string {
+	return Default().Sprintf(format, args...)
+}
+
+// Errorf formats the translation of format as an error with the default printer
+func Errorf(format string, args ...any) error {
+	return Default().Errorf(format, args...)
+}
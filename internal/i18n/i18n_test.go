@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+// verbPattern matches a formatting verb with its flags, argument index,
+// width and precision; %% is matched so it can be skipped
+var verbPattern = regexp.MustCompile(`%(?:%|[-+# 0]*(?:\[\d+\])?\d*(?:\.\d+)?[a-zA-Z])`)
+
+// verbs returns the verbs of a format in argument order
+func verbs(format string) []string {
+	var out []string
+	for _, v := range verbPattern.FindAllString(format, -1) {
+		if v != "%%" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func TestCatalogsKeepVerbs(t *testing.T) {
+	for _, lang := range Languages()[1:] {
+		p, err := NewPrinter(lang)
+		if err != nil {
+			t.Fatalf("%s: %v", lang, err)
+		}
+		if len(p.messages) == 0 {
+			t.Errorf("%s: catalog is empty", lang)
+		}
+		for key, msg := range p.messages {
+			if msg == "" {
+				t.Errorf("%s: empty translation of %q", lang, key)
+			}
+			if !slices.Equal(verbs(key), verbs(msg)) {
+				t.Errorf("%s: %q uses verbs %v, want %v as in %q", lang, msg, verbs(msg), verbs(key), key)
+			}
+		}
+	}
+}
+
+func TestNewPrinter(t *testing.T) {
+	for _, lang := range []string{"", "en", "EN"} {
+		p, err := NewPrinter(lang)
+		if err != nil || p.Language() != "en" {
+			t.Errorf("NewPrinter(%q) = %v, %v; want en", lang, p.Language(), err)
+		}
+	}
+	for _, lang := range []string{"zh-TW", "zh_TW", "zh-tw"} {
+		p, err := NewPrinter(lang)
+		if err != nil || p.Language() != "zh-TW" {
+			t.Errorf("NewPrinter(%q) = %v, %v; want zh-TW", lang, p.Language(), err)
+		}
+	}
+	if _, err := NewPrinter("fr"); err == nil {
+		t.Error("expected an error for an unsupported language")
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	zh, err := NewPrinter("zh-TW")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := zh.Sprintf("Failed to resolve project: %v", "boom"); got != "無法解析專案：boom" {
+		t.Errorf("unexpected translation: %q", got)
+	}
+	// Messages without a translation fall back to English
+	if got := zh.Sprintf("no translation for %d", 1); got != "no translation for 1" {
+		t.Errorf("expected the English fallback, got %q", got)
+	}
+
+	var nilPrinter *Printer
+	if got := nilPrinter.T("Notes"); got != "Notes" {
+		t.Errorf("expected a nil printer to return English, got %q", got)
+	}
+
+	// Translated errors still wrap
+	cause := errors.New("bad date")
+	wrapped := zh.Errorf("invalid start date: %w", cause)
+	if !errors.Is(wrapped, cause) {
+		t.Error("expected the translated error to wrap its cause")
+	}
+	if wrapped.Error() != "開始日期無效：bad date" {
+		t.Errorf("unexpected error message: %q", wrapped.Error())
+	}
+}
+
+func TestDefault(t *testing.T) {
+	t.Cleanup(func() { SetDefault(nil) })
+
+	if got := T("Notes"); got != "Notes" {
+		t.Errorf("expected English before SetDefault, got %q", got)
+	}
+	zh, err := NewPrinter("zh-TW")
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetDefault(zh)
+	if got := T("Notes"); got != "筆記" {
+		t.Errorf("expected the default printer to translate, got %q", got)
+	}
+	if got := Sprintf("%d of %d subtasks could not be created", 1, 3); got != "1 個子任務（共 3 個）無法建立" {
+		t.Errorf("unexpected translation: %q", got)
+	}
+}
@@ -0,0 +1,276 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// auditRecord describes one call of a write tool
+type auditRecord struct {
+	Timestamp  string         `json:"timestamp"`
+	Tool       string         `json:"tool"`
+	SessionID  string         `json:"session_id,omitempty"`
+	UserID     int            `json:"user_id,omitempty"`
+	User       string         `json:"user,omitempty"`
+	TargetType string         `json:"target_type"`
+	TargetID   string         `json:"target_id,omitempty"`
+	CreatedID  string         `json:"created_id,omitempty"` // Object created by the call, when it differs from the target
+	Changes    map[string]any `json:"changes,omitempty"`
+	Success    bool           `json:"success"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// auditLog writes audit records as JSON lines appended to path, or as slog
+// records when path is empty. The file is reopened for every record so it
+// can be rotated externally.
+type auditLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// auditLogFromEnv reads REDMINE_MCP_AUDIT_LOG once per process; every session
+// shares the same log
+var auditLogFromEnv = sync.OnceValue(func() *auditLog {
+	path := os.Getenv("REDMINE_MCP_AUDIT_LOG")
+	if path != "" {
+		slog.Info("audit log enabled", "file", path)
+	}
+	return &auditLog{path: path}
+})
+
+// write records rec. A failure is logged but never fails the tool call,
+// which has already changed Redmine by the time it is audited.
+func (a *auditLog) write(rec auditRecord) {
+	if a.path == "" {
+		slog.Info("audit",
+			"tool", rec.Tool,
+			"session_id", rec.SessionID,
+			"user_id", rec.UserID,
+			"user", rec.User,
+			"target_type", rec.TargetType,
+			"target_id", rec.TargetID,
+			"created_id", rec.CreatedID,
+			"changes", rec.Changes,
+			"success", rec.Success,
+			"error", rec.Error,
+		)
+		return
+	}
+
+	line, err := json.Marshal(rec)
+	if err != nil {
+		slog.Error("failed to encode audit record", "tool", rec.Tool, "error", err)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		slog.Error("failed to open audit log", "file", a.path, "tool", rec.Tool, "error", err)
+		return
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		slog.Error("failed to write audit log", "file", a.path, "tool", rec.Tool, "error", err)
+	}
+	if err := f.Close(); err != nil {
+		slog.Error("failed to close audit log", "file", a.path, "error", err)
+	}
+}
+
+// auditTarget names the object a write tool acts on and the arguments that
+// identify it, in order of preference
+type auditTarget struct {
+	objectType string
+	idArgs     []string
+}
+
+// auditTargets maps write tools to their target; tools not listed use the
+// type of their name prefix from auditPrefixTargets
+var auditTargets = map[string]auditTarget{
+	"issues_createSubtask":        {"issue", []string{"parent_issue_id"}},
+	"issues_batchUpdate":          {"issue", []string{"issue_ids"}},
+	"issues_removeRelation":       {"relation", []string{"relation_id"}},
+	"attachments_upload":          {"attachment", []string{"filename"}},
+	"attachments_uploadAndAttach": {"issue", []string{"issue_id"}},
+	"timeEntries_report":          {"issue", []string{"attach_to"}},
+	"reports_project_analysis":    {"project", []string{"project"}},
+	"wiki_uploadAndAttach":        {"wiki_page", []string{"title"}},
+}
+
+var auditPrefixTargets = map[string]auditTarget{
+	"projects":    {"project", []string{"project", "identifier"}},
+	"issues":      {"issue", []string{"issue_id"}},
+	"timeEntries": {"time_entry", []string{"time_entry_id", "issue_id"}},
+	"versions":    {"version", []string{"version_id"}},
+	"categories":  {"category", []string{"category_id"}},
+	"memberships": {"membership", []string{"membership_id"}},
+	"wiki":        {"wiki_page", []string{"title"}},
+	"documents":   {"document", []string{"document_id"}},
+}
+
+// auditResultIDs are the result fields holding the ID of a created object
+var auditResultIDs = []string{"id", "membership_id", "new_root_id"}
+
+// auditSkipArgs are arguments that steer a call rather than change data
+var auditSkipArgs = map[string]bool{"confirm": true, "parallelism": true, "force_refresh": true}
+
+func auditTargetFor(tool string) auditTarget {
+	if t, ok := auditTargets[tool]; ok {
+		return t
+	}
+	prefix, _, _ := strings.Cut(tool, "_")
+	if t, ok := auditPrefixTargets[prefix]; ok {
+		return t
+	}
+	return auditTarget{objectType: prefix}
+}
+
+// auditWrite wraps a write tool's handler so every call is audited with its
+// outcome, whether it succeeded, failed or was blocked by the write policy
+func (h *ToolHandlers) auditWrite(tool string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, req)
+		h.audit.write(h.auditRecord(ctx, tool, req, result, err))
+		return result, err
+	}
+}
+
+func (h *ToolHandlers) auditRecord(ctx context.Context, tool string, req mcp.CallToolRequest, result *mcp.CallToolResult, callErr error) auditRecord {
+	target := auditTargetFor(tool)
+	args := req.GetArguments()
+
+	rec := auditRecord{
+		Timestamp:  time.Now().UTC().Format(time.RFC3339),
+		Tool:       tool,
+		TargetType: target.objectType,
+		Changes:    make(map[string]any),
+		Success:    callErr == nil && result != nil && !result.IsError,
+	}
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		rec.SessionID = session.SessionID()
+	}
+	if user := h.auditUser(); user != nil {
+		rec.UserID, rec.User = user.ID, strings.TrimSpace(user.Firstname+" "+user.Lastname)
+		if rec.User == "" {
+			rec.User = user.Login
+		}
+	}
+
+	for _, key := range target.idArgs {
+		if v, ok := args[key]; ok && v != nil && v != "" {
+			rec.TargetID = auditText(v)
+			break
+		}
+	}
+	for key, v := range args {
+		if auditSkipArgs[key] || slices.Contains(target.idArgs, key) {
+			continue
+		}
+		rec.Changes[key] = auditValue(key, v)
+	}
+
+	switch {
+	case callErr != nil:
+		rec.Error = callErr.Error()
+	case result != nil && result.IsError:
+		rec.Error = resultText(result)
+	case result != nil:
+		if id := auditResultID(result); id != "" {
+			if rec.TargetID == "" {
+				rec.TargetID = id
+			} else if id != rec.TargetID {
+				rec.CreatedID = id
+			}
+		}
+	}
+	return rec
+}
+
+// auditUser returns the acting user, fetched once per handler set since each
+// one is bound to a single API key. A failed lookup is retried next time.
+func (h *ToolHandlers) auditUser() *redmine.User {
+	h.auditUserMu.Lock()
+	defer h.auditUserMu.Unlock()
+	if h.auditUserCache != nil {
+		return h.auditUserCache
+	}
+	user, err := h.client.GetCurrentUser()
+	if err != nil {
+		slog.Warn("failed to get current user for audit log", "error", err)
+		return nil
+	}
+	h.auditUserCache = user
+	return user
+}
+
+// auditResultID extracts the created object's ID from a JSON tool result
+func auditResultID(result *mcp.CallToolResult) string {
+	var body map[string]any
+	if err := json.Unmarshal([]byte(resultText(result)), &body); err != nil {
+		return ""
+	}
+	for _, key := range auditResultIDs {
+		if v, ok := body[key]; ok && v != nil {
+			return auditText(v)
+		}
+	}
+	return ""
+}
+
+// auditValue summarizes an argument for the log: uploaded content is reduced
+// to its size and long values are shortened
+func auditValue(key string, v any) any {
+	if key == "content" {
+		if s, ok := v.(string); ok {
+			return fmt.Sprintf("(%d bytes)", len(s))
+		}
+	}
+	switch v.(type) {
+	case string, []any, map[string]any:
+		return redmine.NotePreview(auditText(v))
+	default:
+		return v
+	}
+}
+
+// auditText renders an argument as text, printing whole numbers without a
+// decimal point since JSON numbers arrive as float64
+func auditText(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		if v == float64(int64(v)) {
+			return fmt.Sprintf("%d", int64(v))
+		}
+		return fmt.Sprint(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, c := range result.Content {
+		if text, ok := c.(mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,201 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// handlerRecorder keeps the handlers of registered tools by name
+type handlerRecorder map[string]server.ToolHandlerFunc
+
+func (r handlerRecorder) AddTool(tool gomcp.Tool, handler server.ToolHandlerFunc) {
+	r[tool.Name] = handler
+}
+
+func callTool(t *testing.T, handlers handlerRecorder, name string, args map[string]any) *gomcp.CallToolResult {
+	t.Helper()
+	req := gomcp.CallToolRequest{}
+	req.Params.Name = name
+	req.Params.Arguments = args
+	result, err := handlers[name](context.Background(), req)
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", name, err)
+	}
+	return result
+}
+
+func readAuditLog(t *testing.T, path string) []auditRecord {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	var records []auditRecord
+	for line := range strings.SplitSeq(strings.TrimSpace(string(data)), "\n") {
+		var rec auditRecord
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid audit line %q: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestAuditWrite(t *testing.T) {
+	var userLookups int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/users/current.json":
+			userLookups++
+			_, _ = w.Write([]byte(`{"user":{"id":7,"login":"ai-bot","firstname":"AI","lastname":"Bot"}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/issues/5.json":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPut && r.URL.Path == "/issues/6.json":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors":["Notes is invalid"]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/projects/3/versions.json":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"version":{"id":12,"name":"v1.0","project":{"id":3,"name":"Web"}}}`))
+		case r.Method == http.MethodGet:
+			// Reference data for enum hints and the read-only search
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+	h.readOnly, h.allowedWrites = false, nil
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	h.audit = &auditLog{path: logPath}
+	handlers := handlerRecorder{}
+	h.RegisterTools(handlers)
+
+	callTool(t, handlers, "issues_addComment", map[string]any{"issue_id": float64(5), "notes": "Looks good"})
+	if result := callTool(t, handlers, "issues_addComment", map[string]any{"issue_id": float64(6), "notes": "x"}); !result.IsError {
+		t.Fatal("expected the second comment to fail")
+	}
+	callTool(t, handlers, "versions_create", map[string]any{"project": "3", "name": "v1.0"})
+	callTool(t, handlers, "issues_search", map[string]any{})
+
+	records := readAuditLog(t, logPath)
+	if len(records) != 3 {
+		t.Fatalf("expected 3 audit records for the write tools only, got %d: %+v", len(records), records)
+	}
+
+	comment := records[0]
+	if comment.Tool != "issues_addComment" || comment.TargetType != "issue" || comment.TargetID != "5" ||
+		comment.UserID != 7 || comment.User != "AI Bot" || !comment.Success || comment.Timestamp == "" {
+		t.Errorf("unexpected comment record: %+v", comment)
+	}
+	if comment.Changes["notes"] != "Looks good" || comment.Changes["issue_id"] != nil {
+		t.Errorf("expected notes but not the target ID in changes, got %v", comment.Changes)
+	}
+
+	if failed := records[1]; failed.Success || !strings.Contains(failed.Error, "Notes is invalid") || failed.TargetID != "6" {
+		t.Errorf("unexpected failure record: %+v", failed)
+	}
+
+	// The new object's ID comes from the result when no argument names it
+	if created := records[2]; created.TargetType != "version" || created.TargetID != "12" || created.Changes["project"] != "3" {
+		t.Errorf("unexpected create record: %+v", created)
+	}
+
+	if userLookups != 1 {
+		t.Errorf("expected the acting user to be looked up once, got %d", userLookups)
+	}
+}
+
+func TestAuditWrite_OptionalWrite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":7,"login":"ai-bot"}}`))
+		case r.URL.Path == "/time_entries.json":
+			_, _ = w.Write([]byte(`{"time_entries":[{"id":1,"project":{"id":1,"name":"Web"},"user":{"id":7,"name":"AI Bot"},"activity":{"id":9,"name":"Dev"},"hours":2,"spent_on":"2024-01-01"}],"total_count":1}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/uploads.json":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"upload":{"token":"abc"}}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/issues/5.json":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+	h.readOnly, h.allowedWrites = false, nil
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	h.audit = &auditLog{path: logPath}
+	handlers := handlerRecorder{}
+	h.RegisterTools(handlers)
+
+	// Only the report saved to Redmine is a write
+	callTool(t, handlers, "timeEntries_report", map[string]any{"group_by": "project", "format": "csv"})
+	if result := callTool(t, handlers, "timeEntries_report", map[string]any{"group_by": "project", "format": "csv", "attach_to": "issue:5"}); result.IsError {
+		t.Fatalf("unexpected error: %s", resultText(result))
+	}
+
+	records := readAuditLog(t, logPath)
+	if len(records) != 1 {
+		t.Fatalf("expected 1 audit record, got %d: %+v", len(records), records)
+	}
+	if rec := records[0]; rec.Tool != "timeEntries_report" || rec.TargetType != "issue" || rec.TargetID != "issue:5" || !rec.Success {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+}
+
+func TestAuditWrite_LogFailureDoesNotFailCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/users/current.json" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+	h.readOnly, h.allowedWrites = false, nil
+	h.audit = &auditLog{path: filepath.Join(t.TempDir(), "missing", "audit.jsonl")}
+	handlers := handlerRecorder{}
+	h.RegisterTools(handlers)
+
+	if result := callTool(t, handlers, "issues_addComment", map[string]any{"issue_id": float64(5), "notes": "ok"}); result.IsError {
+		t.Errorf("expected the call to succeed despite the audit failure, got %v", result.Content)
+	}
+}
+
+func TestAuditValue(t *testing.T) {
+	if got := auditValue("content", "aGVsbG8="); got != "(8 bytes)" {
+		t.Errorf("expected content to be reduced to its size, got %v", got)
+	}
+	if got := auditValue("notes", strings.Repeat("a", 300)).(string); len(got) != redmine.NotePreviewLength+3 {
+		t.Errorf("expected long values to be shortened, got %d characters", len(got))
+	}
+	if got := auditValue("custom_fields", map[string]any{"Severity": "High"}); got != `{"Severity":"High"}` {
+		t.Errorf("unexpected map value: %v", got)
+	}
+	if got := auditValue("done_ratio", float64(50)); got != float64(50) {
+		t.Errorf("expected numbers to be kept, got %v", got)
+	}
+	if got := auditText(float64(42)); got != "42" {
+		t.Errorf("expected whole numbers without decimals, got %s", got)
+	}
+}
@@ -0,0 +1,271 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+)
+
+// DefaultResponseBudget is the size in bytes above which JSON tool results
+// are trimmed, below what MCP clients start truncating
+const DefaultResponseBudget = 100 * 1024
+
+// textLimits are the lengths long texts are shortened to, in turn, until a
+// result fits its budget
+var textLimits = []int{2000, 1000, 500, 200}
+
+// responseBudgetFromEnv reads REDMINE_MCP_MAX_RESPONSE_BYTES once per
+// process; 0 disables trimming
+var responseBudgetFromEnv = sync.OnceValue(func() int {
+	value := os.Getenv("REDMINE_MCP_MAX_RESPONSE_BYTES")
+	if value == "" {
+		return DefaultResponseBudget
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		slog.Warn("ignoring invalid REDMINE_MCP_MAX_RESPONSE_BYTES", "value", value)
+		return DefaultResponseBudget
+	}
+	return n
+})
+
+// fitBudget trims a JSON result until it fits in budget bytes, losing the
+// least first: journal details, then older journals, then the end of long
+// texts, then the end of the longest list. The result stays valid JSON and
+// says what was omitted and how to fetch it in "truncated" and
+// "truncation_notes". ok is false when data already fits.
+func fitBudget(data []byte, budget int) (trimmed []byte, ok bool) {
+	if budget <= 0 || len(data) <= budget {
+		return data, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var root any
+	if err := dec.Decode(&root); err != nil {
+		return data, false
+	}
+	t := &trimmer{root: root, budget: budget}
+
+	for _, stage := range []func() bool{t.dropJournalDetails, t.capJournals, t.shortenTexts, t.capLongestList} {
+		if stage() {
+			break
+		}
+	}
+	out := t.marshal()
+	if len(out) > budget {
+		slog.Warn("tool result exceeds the response budget after trimming", "bytes", len(out), "budget", budget)
+	}
+	return out, true
+}
+
+// trimmer shrinks a decoded JSON result, recording what it omitted
+type trimmer struct {
+	root   any
+	budget int
+	notes  []string
+}
+
+// fits reports whether the result with its notes is within the budget
+func (t *trimmer) fits() bool {
+	return len(t.marshal()) <= t.budget
+}
+
+// marshal renders the result like jsonResult, with the truncation notes
+func (t *trimmer) marshal() []byte {
+	root := t.root
+	if len(t.notes) > 0 {
+		obj, ok := root.(map[string]any)
+		if !ok {
+			obj = map[string]any{"items": root}
+		}
+		obj["truncated"] = true
+		obj["truncation_notes"] = t.notes
+		root = obj
+	}
+	out, _ := json.MarshalIndent(root, "", "  ")
+	return out
+}
+
+// dropJournalDetails removes the field changes of journals
+func (t *trimmer) dropJournalDetails() bool {
+	dropped := false
+	forEachObject(t.root, func(obj map[string]any) {
+		for _, j := range objectList(obj["journals"]) {
+			if _, ok := j["details"]; ok {
+				delete(j, "details")
+				dropped = true
+			}
+		}
+	})
+	if !dropped {
+		return false
+	}
+	t.notes = append(t.notes, i18n.T("journal details (field changes) were dropped; use issues_history for them"))
+	return t.fits()
+}
+
+// capJournals keeps halving journals to the most recent ones
+func (t *trimmer) capJournals() bool {
+	var owners []map[string]any
+	forEachObject(t.root, func(obj map[string]any) {
+		if len(objectList(obj["journals"])) > 1 {
+			owners = append(owners, obj)
+		}
+	})
+	if len(owners) == 0 {
+		return false
+	}
+
+	// A result paged with journals_offset already says how many there are
+	originals := make([][]any, len(owners))
+	offsets := make([]int, len(owners))
+	longest := 0
+	for i, obj := range owners {
+		originals[i] = obj["journals"].([]any)
+		longest = max(longest, len(originals[i]))
+		offsets[i] = numberField(obj, "journals_offset", 0)
+		obj["total_journals"] = numberField(obj, "total_journals", len(originals[i]))
+	}
+	note := len(t.notes)
+	t.notes = append(t.notes, "")
+	for keep := longest / 2; ; keep /= 2 {
+		keep = max(keep, 1)
+		for i, obj := range owners {
+			journals := originals[i]
+			n := min(keep, len(journals))
+			obj["journals"] = journals[len(journals)-n:]
+			t.notes[note] = i18n.Sprintf("only the %d most recent of %d journals are included; call issues_getById with journals_offset=%d for older ones", n, obj["total_journals"], offsets[i]+n)
+		}
+		if t.fits() || keep == 1 {
+			return t.fits()
+		}
+	}
+}
+
+// shortenTexts cuts long strings to each of textLimits in turn
+func (t *trimmer) shortenTexts() bool {
+	note := -1
+	for _, limit := range textLimits {
+		shortened := false
+		forEachObject(t.root, func(obj map[string]any) {
+			for key, v := range obj {
+				if s, ok := v.(string); ok && utf8.RuneCountInString(s) > limit {
+					obj[key] = shortenText(s, limit)
+					shortened = true
+				}
+			}
+		})
+		if !shortened {
+			continue
+		}
+		if note < 0 {
+			note = len(t.notes)
+			t.notes = append(t.notes, "")
+		}
+		t.notes[note] = i18n.Sprintf("texts longer than %d characters were shortened; fetch the single item for the full text", limit)
+		if t.fits() {
+			return true
+		}
+	}
+	return false
+}
+
+// shortenText keeps the first limit characters of s, saying how many were cut
+func shortenText(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit]) + i18n.Sprintf("… [%d more characters]", len(runes)-limit)
+}
+
+// capLongestList keeps halving the largest list of the result, updating the
+// count and has_more of a paginated list to what is left
+func (t *trimmer) capLongestList() bool {
+	var (
+		owner   map[string]any
+		key     string
+		list    []any
+		maxSize int
+	)
+	consider := func(obj map[string]any, k string, l []any) {
+		if len(l) < 2 {
+			return
+		}
+		data, _ := json.Marshal(l)
+		if len(data) > maxSize {
+			owner, key, list, maxSize = obj, k, l, len(data)
+		}
+	}
+	if l, ok := t.root.([]any); ok {
+		t.root = map[string]any{"items": l}
+	}
+	forEachObject(t.root, func(obj map[string]any) {
+		for k, v := range obj {
+			if l, ok := v.([]any); ok {
+				consider(obj, k, l)
+			}
+		}
+	})
+	if owner == nil {
+		return false
+	}
+
+	note := len(t.notes)
+	t.notes = append(t.notes, "")
+	for keep := len(list) / 2; ; keep /= 2 {
+		keep = max(keep, 1)
+		owner[key] = list[:keep]
+		if _, paged := owner["count"]; paged {
+			owner["count"] = keep
+			owner["has_more"] = true
+		}
+		t.notes[note] = i18n.Sprintf("only the first %d of %d %s are included; narrow the query, or page with limit and offset where the tool supports them", keep, len(list), key)
+		if t.fits() || keep == 1 {
+			return t.fits()
+		}
+	}
+}
+
+// forEachObject calls fn on every object in v, outermost first
+func forEachObject(v any, fn func(map[string]any)) {
+	switch v := v.(type) {
+	case map[string]any:
+		fn(v)
+		for _, child := range v {
+			forEachObject(child, fn)
+		}
+	case []any:
+		for _, child := range v {
+			forEachObject(child, fn)
+		}
+	}
+}
+
+// numberField returns the integer field key of obj, or def
+func numberField(obj map[string]any, key string, def int) int {
+	if v, ok := obj[key].(json.Number); ok {
+		if n, err := v.Int64(); err == nil {
+			return int(n)
+		}
+	}
+	return def
+}
+
+// objectList returns the objects of a JSON list
+func objectList(v any) []map[string]any {
+	list, _ := v.([]any)
+	objects := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		if obj, ok := item.(map[string]any); ok {
+			objects = append(objects, obj)
+		}
+	}
+	return objects
+}
@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// trimmed runs fitBudget on v and decodes the result, checking it fits
+func trimmed(t *testing.T, v any, budget int) map[string]any {
+	t.Helper()
+	data, _ := json.MarshalIndent(v, "", "  ")
+	out, ok := fitBudget(data, budget)
+	if !ok {
+		t.Fatalf("expected %d bytes to be trimmed to %d", len(data), budget)
+	}
+	if len(out) > budget {
+		t.Errorf("expected at most %d bytes, got %d", budget, len(out))
+	}
+	var result map[string]any
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("expected valid JSON: %v", err)
+	}
+	if result["truncated"] != true {
+		t.Errorf("expected the truncated flag, got %v", result["truncated"])
+	}
+	return result
+}
+
+func notesOf(result map[string]any) string {
+	return fmt.Sprint(result["truncation_notes"])
+}
+
+func TestFitBudget_Fits(t *testing.T) {
+	data := []byte(`{"id": 1}`)
+	if out, ok := fitBudget(data, 100); ok || string(out) != string(data) {
+		t.Errorf("expected a small result to be kept, got %s", out)
+	}
+	if _, ok := fitBudget(data, 0); ok {
+		t.Error("expected a zero budget to disable trimming")
+	}
+}
+
+func TestFitBudget_Journals(t *testing.T) {
+	journals := make([]map[string]any, 400)
+	for i := range journals {
+		journals[i] = map[string]any{
+			"id":      i + 1,
+			"notes":   strings.Repeat("note ", 20),
+			"details": []map[string]any{{"name": "status_id", "old_value": "1", "new_value": "2"}},
+		}
+	}
+	issue := map[string]any{"id": 42, "description": strings.Repeat("x", 5000), "journals": journals}
+
+	// Dropping details is enough for a little over the budget
+	data, _ := json.MarshalIndent(issue, "", "  ")
+	result := trimmed(t, issue, len(data)*3/4)
+	kept := result["journals"].([]any)
+	if len(kept) != 400 || kept[0].(map[string]any)["details"] != nil {
+		t.Errorf("expected all journals without details, got %d", len(kept))
+	}
+
+	// Further over, only the latest journals are kept
+	result = trimmed(t, issue, 20*1024)
+	kept = result["journals"].([]any)
+	if len(kept) >= 400 || result["total_journals"] != float64(400) {
+		t.Fatalf("expected fewer journals out of 400, got %d of %v", len(kept), result["total_journals"])
+	}
+	if last := kept[len(kept)-1].(map[string]any)["id"]; last != float64(400) {
+		t.Errorf("expected the most recent journals to be kept, got last id %v", last)
+	}
+	if want := fmt.Sprintf("journals_offset=%d", len(kept)); !strings.Contains(notesOf(result), want) {
+		t.Errorf("expected the notes to point to %s, got %s", want, notesOf(result))
+	}
+	if result["description"] != strings.Repeat("x", 5000) {
+		t.Error("expected the description to be kept while journals suffice")
+	}
+
+	// An issue paged with journals_offset keeps counting from there
+	issue["journals_offset"], issue["total_journals"] = 10, 410
+	result = trimmed(t, issue, 20*1024)
+	kept = result["journals"].([]any)
+	if want := fmt.Sprintf("journals_offset=%d", 10+len(kept)); !strings.Contains(notesOf(result), want) || result["total_journals"] != float64(410) {
+		t.Errorf("expected the notes to point to %s of 410, got %s", want, notesOf(result))
+	}
+}
+
+func TestFitBudget_TextsAndLists(t *testing.T) {
+	result := trimmed(t, map[string]any{"id": 1, "description": strings.Repeat("é", 50000)}, 5000)
+	description := result["description"].(string)
+	if !strings.HasPrefix(description, strings.Repeat("é", 200)) || !strings.Contains(description, "more characters]") {
+		t.Errorf("expected a shortened description with a marker, got %d bytes", len(description))
+	}
+
+	issues := make([]map[string]any, 500)
+	for i := range issues {
+		issues[i] = map[string]any{"id": i + 1, "subject": strings.Repeat("s", 100)}
+	}
+	result = trimmed(t, map[string]any{"issues": issues, "total_count": 500}, 10*1024)
+	kept := result["issues"].([]any)
+	if len(kept) >= 500 || kept[0].(map[string]any)["id"] != float64(1) || result["total_count"] != float64(500) {
+		t.Errorf("expected the first issues to be kept, got %d", len(kept))
+	}
+	if !strings.Contains(notesOf(result), fmt.Sprintf("only the first %d of 500 issues", len(kept))) {
+		t.Errorf("unexpected notes: %s", notesOf(result))
+	}
+
+	// The pagination fields describe the trimmed list
+	page := map[string]any{"issues": issues, "count": 500, "offset": 0, "limit": 500, "total_count": 1000, "has_more": true}
+	result = trimmed(t, page, 10*1024)
+	kept = result["issues"].([]any)
+	if result["count"] != float64(len(kept)) || result["has_more"] != true || result["total_count"] != float64(1000) {
+		t.Errorf("expected count %d with has_more, got %v %v", len(kept), result["count"], result["has_more"])
+	}
+	page = map[string]any{"issues": issues, "count": 500, "offset": 0, "limit": 500, "total_count": 500, "has_more": false}
+	if result = trimmed(t, page, 10*1024); result["has_more"] != true {
+		t.Errorf("expected has_more once the last page is trimmed, got %v", result["has_more"])
+	}
+
+	// A top-level list is wrapped to carry the notes
+	result = trimmed(t, issues, 10*1024)
+	if _, ok := result["items"].([]any); !ok {
+		t.Errorf("expected the list under items, got %v", result)
+	}
+}
+
+func TestIssuesGetById_LongHistory(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		journals := make([]string, 400)
+		for i := range journals {
+			journals[i] = fmt.Sprintf(`{"id":%d,"user":{"id":1,"name":"Jane"},"notes":%q,"created_on":"2024-01-01T00:00:00Z"}`, i+1, strings.Repeat("a long comment ", 30))
+		}
+		_, _ = fmt.Fprintf(w, `{"issue":{"id":42,"subject":"Flaky","project":{"id":1,"name":"Web"},"journals":[%s]}}`, strings.Join(journals, ","))
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	get := func(args map[string]any) map[string]any {
+		t.Helper()
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleIssuesGetById(context.Background(), req)
+		if err != nil || result.IsError {
+			t.Fatalf("unexpected error: %v %v", err, result)
+		}
+		text := result.Content[0].(gomcp.TextContent).Text
+		if len(text) > DefaultResponseBudget {
+			t.Errorf("expected at most %d bytes, got %d", DefaultResponseBudget, len(text))
+		}
+		var out map[string]any
+		if err := json.Unmarshal([]byte(text), &out); err != nil {
+			t.Fatalf("expected valid JSON: %v", err)
+		}
+		return out
+	}
+
+	out := get(map[string]any{"issue_id": float64(42)})
+	kept := out["journals"].([]any)
+	if out["truncated"] != true || out["total_journals"] != float64(400) || len(kept) >= 400 {
+		t.Fatalf("expected trimmed journals, got %d of %v", len(kept), out["total_journals"])
+	}
+
+	// The offset in the notes pages back to the older journals
+	out = get(map[string]any{"issue_id": float64(42), "journals_offset": float64(len(kept))})
+	older := out["journals"].([]any)
+	if last := older[len(older)-1].(map[string]any)["id"]; last != float64(400-len(kept)) {
+		t.Errorf("expected journals before #%d, got last id %v", 400-len(kept)+1, last)
+	}
+	if out["journals_offset"] != float64(len(kept)) || out["total_journals"] != float64(400) {
+		t.Errorf("unexpected paging fields: %v %v", out["journals_offset"], out["total_journals"])
+	}
+}
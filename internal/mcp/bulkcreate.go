@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// bulkCreateItemSchema describes one issues_bulkCreate item; it takes the
+// same fields as issues_create
+var bulkCreateItemSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"project":         map[string]any{"type": "string", "description": "Project name or ID (overrides the top-level project)"},
+		"tracker":         map[string]any{"type": "string", "description": "Tracker name or ID (overrides the top-level tracker)"},
+		"subject":         map[string]any{"type": "string", "description": "Issue subject/title"},
+		"description":     map[string]any{"type": "string", "description": "Issue description"},
+		"assigned_to":     map[string]any{"type": "string", "description": "Assignee user name or ID; a group name when no user matches"},
+		"priority":        map[string]any{"type": "string", "description": "Priority name or ID"},
+		"category":        map[string]any{"type": "string", "description": "Issue category name or ID within the project"},
+		"version":         map[string]any{"type": "string", "description": "Target version name or ID within the project"},
+		"parent_issue_id": map[string]any{"type": "number", "description": "Parent issue ID"},
+		"start_date":      map[string]any{"type": "string", "description": "Start date (YYYY-MM-DD)"},
+		"due_date":        map[string]any{"type": "string", "description": "Due date (YYYY-MM-DD)"},
+		"estimated_hours": map[string]any{"type": "number", "description": "Estimated time in hours"},
+		"custom_fields":   map[string]any{"type": "object", "description": "Custom fields as key-value pairs (field name -> value)"},
+		"is_private":      map[string]any{"type": "boolean", "description": "Whether the issue is private"},
+	},
+	"required": []string{"subject"},
+}
+
+func (h *ToolHandlers) handleIssuesBulkCreate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("issues_bulkCreate"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	itemsRaw := getArrayArg(req, "issues")
+	if len(itemsRaw) == 0 {
+		return mcp.NewToolResultError(i18n.T("issues is required and must be a non-empty array")), nil
+	}
+	if len(itemsRaw) > redmine.MaxBulkCreateIssues {
+		return mcp.NewToolResultError(i18n.Sprintf("issues has %d items; at most %d can be created at once", len(itemsRaw), redmine.MaxBulkCreateIssues)), nil
+	}
+
+	defaults := map[string]string{
+		"project": req.GetString("project", ""),
+		"tracker": req.GetString("tracker", ""),
+	}
+	refs := newBulkRefs(h.resolver)
+
+	// Validate every item before creating any, so one bad item doesn't leave
+	// half a plan behind
+	items := make([]redmine.CreateIssueParams, len(itemsRaw))
+	var problems []string
+	for i, raw := range itemsRaw {
+		item, ok := raw.(map[string]any)
+		if !ok {
+			problems = append(problems, i18n.Sprintf("issues[%d]: must be an object", i))
+			continue
+		}
+		params, itemProblems := h.buildBulkCreateItem(item, defaults, refs)
+		for _, p := range itemProblems {
+			problems = append(problems, i18n.Sprintf("issues[%d] %q: %s", i, params.Subject, p))
+		}
+		items[i] = params
+	}
+	if len(problems) > 0 {
+		return mcp.NewToolResultError(i18n.Sprintf("Validation failed, no issues were created:\n- %s", strings.Join(problems, "\n- "))), nil
+	}
+
+	return jsonResult(redmine.BulkCreateIssues(h.client, items, req.GetBool("link_sequentially", false)))
+}
+
+// buildBulkCreateItem resolves one bulk create item, falling back to the
+// top-level defaults for project and tracker, and returns every problem found
+func (h *ToolHandlers) buildBulkCreateItem(item map[string]any, defaults map[string]string, refs *bulkRefs) (redmine.CreateIssueParams, []string) {
+	var problems []string
+	params := redmine.CreateIssueParams{}
+	params.Subject, _ = item["subject"].(string)
+	if strings.TrimSpace(params.Subject) == "" {
+		problems = append(problems, "subject is required")
+	}
+
+	project := stringOr(item["project"], defaults["project"])
+	tracker := stringOr(item["tracker"], defaults["tracker"])
+	if project == "" || tracker == "" {
+		return params, append(problems, "project and tracker are required, either on the item or at the top level")
+	}
+
+	var err error
+	if params.ProjectID, err = refs.project(project); err != nil {
+		problems = append(problems, i18n.Sprintf("Failed to resolve project: %v", err))
+	}
+	if params.TrackerID, err = refs.tracker(tracker); err != nil {
+		problems = append(problems, i18n.Sprintf("Failed to resolve tracker: %v", err))
+	}
+	if len(problems) > 0 {
+		return params, problems
+	}
+
+	// The remaining fields are resolved by the same code as issues_create;
+	// the assignee is resolved here first so each name is looked up once
+	args := maps.Clone(item)
+	if assignee, _ := args["assigned_to"].(string); assignee != "" {
+		userID, err := refs.user(assignee, params.ProjectID)
+		if err != nil {
+			problems = append(problems, i18n.Sprintf("Failed to resolve assignee: %v", err))
+			delete(args, "assigned_to")
+		} else {
+			args["assigned_to"] = strconv.Itoa(userID)
+		}
+	}
+	if parentID, ok := args["parent_issue_id"].(float64); ok {
+		params.ParentIssueID = int(parentID)
+	}
+
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = args
+	return params, append(problems, h.buildCreateIssueParams(req, &params)...)
+}
+
+// bulkRefs resolves the project, tracker and assignee names shared by the
+// items of a bulk create once each
+type bulkRefs struct {
+	resolver *redmine.Resolver
+	projects map[string]int
+	trackers map[string]int
+	users    map[string]int
+}
+
+func newBulkRefs(resolver *redmine.Resolver) *bulkRefs {
+	return &bulkRefs{
+		resolver: resolver,
+		projects: make(map[string]int),
+		trackers: make(map[string]int),
+		users:    make(map[string]int),
+	}
+}
+
+func (r *bulkRefs) project(name string) (int, error) {
+	return cachedResolve(r.projects, name, r.resolver.ResolveProject)
+}
+
+func (r *bulkRefs) tracker(name string) (int, error) {
+	return cachedResolve(r.trackers, name, r.resolver.ResolveTracker)
+}
+
+// user resolves an assignee in a project's context, since the membership
+// fallback depends on it
+func (r *bulkRefs) user(name string, projectID int) (int, error) {
+	return cachedResolve(r.users, name+"\x00"+strconv.Itoa(projectID), func(string) (int, error) {
+		return r.resolver.ResolveAssignee(name, projectID)
+	})
+}
+
+func cachedResolve(cache map[string]int, key string, resolve func(string) (int, error)) (int, error) {
+	if id, ok := cache[key]; ok {
+		return id, nil
+	}
+	id, err := resolve(key)
+	if err != nil {
+		return 0, err
+	}
+	cache[key] = id
+	return id, nil
+}
+
+// stringOr returns v if it is a non-blank string, else def
+func stringOr(v any, def string) string {
+	if s, ok := v.(string); ok && strings.TrimSpace(s) != "" {
+		return s
+	}
+	return def
+}
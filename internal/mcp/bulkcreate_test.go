@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// bulkCreateServer mocks Redmine for bulk create tests; subjects starting
+// with "Fail" are rejected by the create API
+func bulkCreateServer(t *testing.T, created *[]map[string]any, relations *[]map[string]any) *httptest.Server {
+	t.Helper()
+	nextID := 100
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Task"},{"id":2,"name":"Bug"}]}`))
+	})
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]any
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		subject, _ := body["issue"]["subject"].(string)
+		if strings.HasPrefix(subject, "Fail") {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors":["Due date must be greater than start date"]}`))
+			return
+		}
+		*created = append(*created, body["issue"])
+		nextID++
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"issue":{"id":%d,"subject":%q,"project":{"id":1,"name":"Web"},"tracker":{"id":1,"name":"Task"}}}`, nextID, subject)
+	})
+	mux.HandleFunc("POST /issues/{id}/relations.json", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]any
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		rel := body["relation"]
+		rel["issue_id"] = r.PathValue("id")
+		*relations = append(*relations, rel)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"relation":{"id":1}}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+// --- TestHandleIssuesBulkCreate ---
+
+func TestHandleIssuesBulkCreate(t *testing.T) {
+	var created, relations []map[string]any
+	mockServer := bulkCreateServer(t, &created, &relations)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"project": "1",
+		"tracker": "Task",
+		"issues": []any{
+			map[string]any{"subject": "Design"},
+			map[string]any{"subject": "Fail build"},
+			map[string]any{"subject": "Ship", "tracker": "Bug", "parent_issue_id": float64(7)},
+		},
+		"link_sequentially": true,
+	}
+	result, err := h.handleIssuesBulkCreate(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+
+	var out redmine.BulkCreateResult
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if len(out.Success) != 2 || out.Success[0].Index != 0 || out.Success[1].Index != 2 {
+		t.Errorf("expected items 0 and 2 to be created, got %+v", out.Success)
+	}
+	if len(out.Failed) != 1 || out.Failed[0].Index != 1 || !strings.Contains(out.Failed[0].Error, "Due date") {
+		t.Errorf("expected item 1 to fail with Redmine's error, got %+v", out.Failed)
+	}
+
+	// The top-level defaults apply unless an item overrides them
+	if len(created) != 2 || created[0]["tracker_id"] != float64(1) || created[1]["tracker_id"] != float64(2) ||
+		created[1]["parent_issue_id"] != float64(7) {
+		t.Errorf("unexpected create payloads: %v", created)
+	}
+
+	// The failed item is skipped, so the created issues still form one chain
+	if len(relations) != 1 || relations[0]["issue_id"] != "101" || relations[0]["issue_to_id"] != float64(102) ||
+		relations[0]["relation_type"] != "precedes" {
+		t.Errorf("expected 101 to precede 102, got %v", relations)
+	}
+	if len(out.Relations) != 1 || out.Relations[0].Error != "" {
+		t.Errorf("unexpected relations in result: %+v", out.Relations)
+	}
+}
+
+// --- TestHandleIssuesBulkCreate_ValidationErrors ---
+
+func TestHandleIssuesBulkCreate_ValidationErrors(t *testing.T) {
+	var created, relations []map[string]any
+	mockServer := bulkCreateServer(t, &created, &relations)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"project": "1",
+		"issues": []any{
+			map[string]any{"subject": "Design", "tracker": "Task"},
+			map[string]any{"subject": "No tracker"},
+			map[string]any{"subject": "Bad estimate", "tracker": "Task", "estimated_hours": float64(-2)},
+			"not an object",
+		},
+	}
+	result, err := h.handleIssuesBulkCreate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected validation to fail")
+	}
+	text := result.Content[0].(gomcp.TextContent).Text
+	for _, want := range []string{"no issues were created", `issues[1] "No tracker"`, `issues[2] "Bad estimate"`, "issues[3]: must be an object"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected %q in %q", want, text)
+		}
+	}
+	if len(created) != 0 {
+		t.Errorf("expected nothing to be created, got %v", created)
+	}
+}
+
+// --- TestHandleIssuesBulkCreate_TooMany ---
+
+func TestHandleIssuesBulkCreate_TooMany(t *testing.T) {
+	h := NewToolHandlers(redmine.NewClient("http://unused", "test-api-key"), nil, nil)
+
+	items := make([]any, redmine.MaxBulkCreateIssues+1)
+	for i := range items {
+		items[i] = map[string]any{"subject": fmt.Sprintf("Issue %d", i)}
+	}
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1", "tracker": "1", "issues": items}
+	result, err := h.handleIssuesBulkCreate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "at most 50") {
+		t.Errorf("expected the item limit to be enforced, got %v", result.Content)
+	}
+}
@@ -0,0 +1,97 @@
+package mcp
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// capacityUser tracks logged hours for one user in a capacity report
+type capacityUser struct {
+	ID    int
+	Name  string
+	Hours float64
+}
+
+// countWorkingDays counts the week's work days in [from, to] (inclusive),
+// skipping excluded dates
+func countWorkingDays(from, to string, exclude map[string]bool, week redmine.WorkWeek) (int, error) {
+	start, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return 0, i18n.Errorf("invalid from date: %s (use YYYY-MM-DD)", from)
+	}
+	end, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return 0, i18n.Errorf("invalid to date: %s (use YYYY-MM-DD)", to)
+	}
+	if end.Before(start) {
+		return 0, i18n.Errorf("from date %s is after to date %s", from, to)
+	}
+
+	days := 0
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		if !week.IsWorkDay(d) {
+			continue
+		}
+		if exclude[d.Format("2006-01-02")] {
+			continue
+		}
+		days++
+	}
+	return days, nil
+}
+
+// buildCapacityReport sums logged hours per user and compares them to the expected
+// hours. Users in members without entries are reported with zero logged hours.
+// Rows are sorted by delta ascending so the largest under-logging comes first.
+func buildCapacityReport(entries []redmine.TimeEntry, members []redmine.IDName, expectedHours float64) []map[string]any {
+	users := make(map[int]*capacityUser)
+	for _, m := range members {
+		users[m.ID] = &capacityUser{ID: m.ID, Name: m.Name}
+	}
+	for _, e := range entries {
+		u, ok := users[e.User.ID]
+		if !ok {
+			u = &capacityUser{ID: e.User.ID, Name: e.User.Name}
+			users[e.User.ID] = u
+		}
+		u.Hours += e.Hours
+	}
+
+	sorted := make([]*capacityUser, 0, len(users))
+	for _, u := range users {
+		sorted = append(sorted, u)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		di := sorted[i].Hours - expectedHours
+		dj := sorted[j].Hours - expectedHours
+		if di != dj {
+			return di < dj
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	rows := make([]map[string]any, len(sorted))
+	for i, u := range sorted {
+		row := map[string]any{
+			"user_id":        u.ID,
+			"user":           u.Name,
+			"logged_hours":   roundHours(u.Hours),
+			"expected_hours": roundHours(expectedHours),
+			"delta":          roundHours(u.Hours - expectedHours),
+		}
+		if expectedHours > 0 {
+			row["utilization"] = roundHours(u.Hours / expectedHours * 100)
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// roundHours rounds to two decimals; unlike roundFloat it handles negative values
+func roundHours(v float64) float64 {
+	return math.Round(v*100) / 100
+}
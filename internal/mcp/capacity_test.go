@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestCountWorkingDays(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		exclude map[string]bool
+		want    int
+		wantErr bool
+	}{
+		{"full week", "2025-01-06", "2025-01-12", nil, 5, false},
+		{"weekend only", "2025-01-11", "2025-01-12", nil, 0, false},
+		{"single weekday", "2025-01-08", "2025-01-08", nil, 1, false},
+		{"two weeks with holiday", "2025-01-06", "2025-01-17", map[string]bool{"2025-01-07": true}, 9, false},
+		{"excluded weekend is not double counted", "2025-01-06", "2025-01-12", map[string]bool{"2025-01-11": true}, 5, false},
+		{"reversed range", "2025-01-10", "2025-01-06", nil, 0, true},
+		{"invalid date", "2025-13-01", "2025-01-06", nil, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := countWorkingDays(tt.from, tt.to, tt.exclude, redmine.DefaultWorkWeek)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %d working days, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCountWorkingDays_SundayWeek(t *testing.T) {
+	week, err := redmine.ParseWorkWeek("sunday", "sun-thu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Sunday 2025-06-01 to Saturday 2025-06-07, then across the May/June boundary
+	if got, _ := countWorkingDays("2025-06-01", "2025-06-07", nil, week); got != 5 {
+		t.Errorf("expected 5 working days, got %d", got)
+	}
+	if got, _ := countWorkingDays("2025-05-29", "2025-06-01", nil, week); got != 2 {
+		t.Errorf("expected Friday and Saturday to be skipped, got %d working days", got)
+	}
+}
+
+func TestBuildCapacityReport(t *testing.T) {
+	entries := []redmine.TimeEntry{
+		{User: redmine.IDName{ID: 1, Name: "Alice"}, Hours: 30},
+		{User: redmine.IDName{ID: 1, Name: "Alice"}, Hours: 12},
+		{User: redmine.IDName{ID: 2, Name: "Bob"}, Hours: 20.5},
+	}
+	members := []redmine.IDName{{ID: 1, Name: "Alice"}, {ID: 3, Name: "Carol"}}
+
+	rows := buildCapacityReport(entries, members, 40)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 users, got %d", len(rows))
+	}
+
+	wantOrder := []string{"Carol", "Bob", "Alice"}
+	for i, name := range wantOrder {
+		if rows[i]["user"] != name {
+			t.Errorf("row %d: expected %s, got %v", i, name, rows[i]["user"])
+		}
+	}
+	if rows[0]["logged_hours"] != 0.0 || rows[0]["delta"] != -40.0 {
+		t.Errorf("expected member without entries to have 0 logged and -40 delta, got %v", rows[0])
+	}
+	if rows[1]["delta"] != -19.5 {
+		t.Errorf("expected Bob delta=-19.5, got %v", rows[1]["delta"])
+	}
+	if rows[2]["logged_hours"] != 42.0 || rows[2]["delta"] != 2.0 {
+		t.Errorf("expected Alice logged=42 delta=2, got %v", rows[2])
+	}
+	if rows[2]["utilization"] != 105.0 {
+		t.Errorf("expected Alice utilization=105, got %v", rows[2]["utilization"])
+	}
+}
+
+func TestHandleReportsCapacity(t *testing.T) {
+	var gotFrom, gotTo string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/time_entries.json":
+			gotFrom = r.URL.Query().Get("from")
+			gotTo = r.URL.Query().Get("to")
+			_, _ = w.Write([]byte(`{"time_entries":[
+				{"id":1,"user":{"id":1,"name":"Alice"},"hours":8,"spent_on":"2025-01-06"},
+				{"id":2,"user":{"id":1,"name":"Alice"},"hours":8,"spent_on":"2025-01-07"},
+				{"id":3,"user":{"id":2,"name":"Bob"},"hours":4,"spent_on":"2025-01-06"}
+			],"total_count":3}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, nil)
+
+	data := callTimeEntriesTool(t, h.handleReportsCapacity, map[string]any{
+		"from":                   "2025-01-06",
+		"to":                     "2025-01-10",
+		"expected_hours_per_day": float64(6),
+		"exclude_dates":          []any{"2025-01-10"},
+	})
+
+	if gotFrom != "2025-01-06" || gotTo != "2025-01-10" {
+		t.Errorf("expected range 2025-01-06..2025-01-10, got %s..%s", gotFrom, gotTo)
+	}
+	if data["working_days"].(float64) != 4 || data["expected_hours"].(float64) != 24 {
+		t.Errorf("expected 4 working days and 24 expected hours, got %v / %v", data["working_days"], data["expected_hours"])
+	}
+
+	users := data["users"].([]any)
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(users))
+	}
+	first := users[0].(map[string]any)
+	if first["user"] != "Bob" || first["delta"].(float64) != -20 {
+		t.Errorf("expected Bob first with delta=-20, got %v", first)
+	}
+
+	t.Run("invalid exclude date", func(t *testing.T) {
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"period": "last_week", "exclude_dates": []any{"tomorrow"}}
+		result, err := h.handleReportsCapacity(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsError {
+			t.Error("expected error result for invalid exclude date")
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// DefaultCustomFieldHintLength caps the custom_fields parameter description
+// once known fields are listed, so large installs don't bloat the tool schema
+const DefaultCustomFieldHintLength = 1500
+
+// maxHintValues is how many allowed values of a field the hint lists
+const maxHintValues = 8
+
+// customFieldHintTools maps the tools whose custom_fields parameter lists the
+// known fields to the description the hint is appended to
+var customFieldHintTools = map[string]string{
+	"issues_create": "Custom fields as key-value pairs (field name -> value)",
+	"issues_update": "Custom fields to update as key-value pairs",
+}
+
+// customFieldHintRules returns the rules the hints describe: the rules file,
+// else rules generated from the admin custom field list when registration
+// could read it. nil means no hints.
+func (h *ToolHandlers) customFieldHintRules() *redmine.CustomFieldRules {
+	if h.rules != nil {
+		return h.rules
+	}
+	if !h.customFieldsReadable {
+		return nil
+	}
+	fields, err := h.resolver.GetCustomFields()
+	if err != nil {
+		return nil
+	}
+	return redmine.GenerateCustomFieldRules(fields)
+}
+
+// customFieldsDescription appends the known custom fields, their allowed
+// values and the fields each tracker requires to base, within the configured
+// length
+func (h *ToolHandlers) customFieldsDescription(base string) string {
+	rules := h.customFieldHintRules()
+	if rules == nil {
+		return base
+	}
+	trackerNames := make(map[int]string)
+	if trackers, err := h.resolver.GetTrackers(); err == nil {
+		for _, t := range trackers {
+			trackerNames[t.ID] = t.Name
+		}
+	}
+	return base + customFieldHint(rules, trackerNames, h.customFieldHintLength-len(base))
+}
+
+// customFieldHint describes the fields of rules in at most maxLen bytes. The
+// fields each tracker requires come first and get the budget first, since
+// missing them fails a create; fields that don't fit are counted instead.
+func customFieldHint(rules *redmine.CustomFieldRules, trackerNames map[int]string, maxLen int) string {
+	if len(rules.Fields) == 0 {
+		return ""
+	}
+
+	ids := slices.Collect(maps.Keys(rules.Fields))
+	slices.SortFunc(ids, func(a, b string) int {
+		return strings.Compare(rules.Fields[a].Name, rules.Fields[b].Name)
+	})
+
+	var fields []string
+	required := make(map[int][]string)
+	for _, id := range ids {
+		rule := rules.Fields[id]
+		fields = append(fields, fieldHint(rule))
+		for _, trackerID := range rule.RequiredByTrackers {
+			required[trackerID] = append(required[trackerID], rule.Name)
+		}
+	}
+
+	var trackers []string
+	for _, trackerID := range slices.Sorted(maps.Keys(required)) {
+		name := trackerNames[trackerID]
+		if name == "" {
+			name = "tracker " + strconv.Itoa(trackerID)
+		}
+		trackers = append(trackers, fmt.Sprintf("%s: %s", name, strings.Join(required[trackerID], ", ")))
+	}
+
+	var hint string
+	if len(trackers) > 0 {
+		hint = joinWithin(". Required per tracker: ", trackers, maxLen)
+	}
+	return hint + joinWithin(". Known fields: ", fields, maxLen-len(hint))
+}
+
+// fieldHint names a field with its first allowed values
+func fieldHint(rule redmine.CustomFieldRule) string {
+	if len(rule.Values) == 0 {
+		return rule.Name
+	}
+	values := rule.Values
+	more := ""
+	if len(values) > maxHintValues {
+		more = fmt.Sprintf(", +%d more", len(values)-maxHintValues)
+		values = values[:maxHintValues]
+	}
+	return fmt.Sprintf("%s (%s%s)", rule.Name, strings.Join(values, ", "), more)
+}
+
+// joinWithin joins items after prefix with "; " while the result fits in
+// limit bytes, ending with a count of the items left out. When no item fits
+// it returns just the count, or "" if that doesn't fit either.
+func joinWithin(prefix string, items []string, limit int) string {
+	omitted := func(n int) string {
+		return fmt.Sprintf("; +%d more (see customFields_list)", n)
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	listed := 0
+	for i, item := range items {
+		sep := ""
+		if i > 0 {
+			sep = "; "
+		}
+		need := len(sep) + len(item)
+		if left := len(items) - i - 1; left > 0 {
+			need += len(omitted(left))
+		}
+		if b.Len()+need > limit {
+			break
+		}
+		b.WriteString(sep + item)
+		listed++
+	}
+	if listed == 0 {
+		if count := prefix + strings.TrimPrefix(omitted(len(items)), "; "); len(count) <= limit {
+			return count
+		}
+		return ""
+	}
+	if listed < len(items) {
+		b.WriteString(omitted(len(items) - listed))
+	}
+	return b.String()
+}
+
+// refreshCustomFieldHints is a tool list filter rebuilding the custom_fields
+// hints from the current reference data, so they follow the resolver cache
+// as it refreshes instead of staying as they were at registration
+func (h *ToolHandlers) refreshCustomFieldHints(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	for i, tool := range tools {
+		base, ok := customFieldHintTools[tool.Name]
+		if !ok {
+			continue
+		}
+		prop, ok := tool.InputSchema.Properties["custom_fields"].(map[string]any)
+		if !ok {
+			continue
+		}
+		description := h.customFieldsDescription(base)
+		if prop["description"] == description {
+			continue
+		}
+		// Listed tools share their schema maps with the registered tools
+		prop = maps.Clone(prop)
+		prop["description"] = description
+		tools[i].InputSchema.Properties = maps.Clone(tool.InputSchema.Properties)
+		tools[i].InputSchema.Properties["custom_fields"] = prop
+	}
+	return tools
+}
+
+// checkCustomFieldsReadable notes whether the admin custom field list can be
+// read, so hints are only built from it when it can
+func (h *ToolHandlers) checkCustomFieldsReadable() {
+	if h.rules != nil {
+		return
+	}
+	if _, err := h.resolver.GetCustomFields(); err != nil {
+		slog.Debug("custom field hints disabled: no rules file and the custom field list needs an admin key", "error", err)
+		return
+	}
+	h.customFieldsReadable = true
+}
@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// schemaRecorder collects registered tools with their schemas
+type schemaRecorder struct {
+	tools []mcp.Tool
+}
+
+func (r *schemaRecorder) AddTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	r.tools = append(r.tools, tool)
+}
+
+func customFieldsDescriptionOf(t *testing.T, tools []mcp.Tool, name string) string {
+	t.Helper()
+	for _, tool := range tools {
+		if tool.Name == name {
+			prop, _ := tool.InputSchema.Properties["custom_fields"].(map[string]any)
+			description, _ := prop["description"].(string)
+			return description
+		}
+	}
+	t.Fatalf("tool %s is not registered", name)
+	return ""
+}
+
+func TestCustomFieldHint(t *testing.T) {
+	rules := &redmine.CustomFieldRules{Fields: map[string]redmine.CustomFieldRule{
+		"1": {Name: "Severity", Values: []string{"Low", "Medium", "High"}, RequiredByTrackers: []int{1}},
+		"2": {Name: "Component", RequiredByTrackers: []int{1, 2}},
+		"3": {Name: "Browser", Values: []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}},
+	}}
+	trackers := map[int]string{1: "Bug"}
+
+	hint := customFieldHint(rules, trackers, 1000)
+	want := ". Required per tracker: Bug: Component, Severity; tracker 2: Component" +
+		". Known fields: Browser (a, b, c, d, e, f, g, h, +2 more); Component; Severity (Low, Medium, High)"
+	if hint != want {
+		t.Errorf("unexpected hint:\n got %s\nwant %s", hint, want)
+	}
+
+	for _, maxLen := range []int{60, 120, 150} {
+		hint := customFieldHint(rules, trackers, maxLen)
+		if len(hint) > maxLen {
+			t.Errorf("%d: hint is %d bytes long: %s", maxLen, len(hint), hint)
+		}
+		if !strings.Contains(hint, "more (see customFields_list)") {
+			t.Errorf("%d: expected left out items to be counted, got %s", maxLen, hint)
+		}
+	}
+	if hint := customFieldHint(rules, trackers, 10); hint != "" {
+		t.Errorf("expected no hint when nothing fits, got %s", hint)
+	}
+}
+
+func TestCustomFieldHints_RegistrationAndRefresh(t *testing.T) {
+	var version atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+	})
+	mux.HandleFunc("GET /custom_fields.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"custom_fields":[
+			{"id":1,"name":"Severity","customized_type":"issue","is_required":true,"possible_values":[{"value":"Low"},{"value":"High"}],"trackers":[{"id":1,"name":"Bug"}]},
+			{"id":2,"name":"Team %d","customized_type":"issue"},
+			{"id":3,"name":"Department","customized_type":"user"}
+		]}`, version.Load())
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	h := NewToolHandlers(redmine.NewClient(ts.URL, "admin-key"), nil, nil)
+	rec := &schemaRecorder{}
+	h.RegisterTools(rec)
+
+	create := customFieldsDescriptionOf(t, rec.tools, "issues_create")
+	if !strings.HasPrefix(create, "Custom fields as key-value pairs (field name -> value). Required per tracker: Bug: Severity. Known fields: ") ||
+		!strings.Contains(create, "Severity (Low, High)") || !strings.Contains(create, "Team 0") || strings.Contains(create, "Department") {
+		t.Errorf("unexpected issues_create hint: %s", create)
+	}
+	if update := customFieldsDescriptionOf(t, rec.tools, "issues_update"); !strings.Contains(update, "Team 0") {
+		t.Errorf("expected issues_update to list the fields too, got %s", update)
+	}
+
+	// Listing again after the resolver cache expired picks up the changed fields
+	version.Store(1)
+	h.resolver.SetCacheTTL(0)
+	listed := h.refreshCustomFieldHints(context.Background(), append([]mcp.Tool{}, rec.tools...))
+	if got := customFieldsDescriptionOf(t, listed, "issues_create"); !strings.Contains(got, "Team 1") {
+		t.Errorf("expected the refreshed hint to list Team 1, got %s", got)
+	}
+	if got := customFieldsDescriptionOf(t, rec.tools, "issues_create"); got != create {
+		t.Errorf("expected the registered schema to be left unchanged, got %s", got)
+	}
+}
+
+func TestCustomFieldHints_NoAdmin(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/custom_fields.json" {
+			calls.Add(1)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	h := NewToolHandlers(redmine.NewClient(ts.URL, "user-key"), nil, nil)
+	rec := &schemaRecorder{}
+	h.RegisterTools(rec)
+	h.refreshCustomFieldHints(context.Background(), rec.tools)
+
+	if got := customFieldsDescriptionOf(t, rec.tools, "issues_create"); got != customFieldHintTools["issues_create"] {
+		t.Errorf("expected the plain description without admin access, got %s", got)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected the custom field list to be tried once, got %d calls", calls.Load())
+	}
+}
@@ -0,0 +1,221 @@
+package mcp
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// maxCopyAttachmentsSize caps the total size of attachments re-uploaded when
+// copying an issue; attachments beyond it are skipped and reported
+const maxCopyAttachmentsSize = 20 * 1024 * 1024
+
+// maxCopyTreeIssues caps how many issues issues_copyTree copies in one call
+const maxCopyTreeIssues = 200
+
+// copyOptions controls what copyIssue carries over besides the basic fields
+type copyOptions struct {
+	ProjectID       int    // Target project; 0 keeps the source project
+	Subject         string // Overrides the source subject when set
+	ParentIssueID   int    // Parent of the copy; 0 leaves it top-level
+	DateOffsetDays  int    // Shifts start and due dates by this many days
+	CopyWatchers    bool
+	CopyAttachments bool
+	LinkToSource    bool // Create a copied_to relation from the source to the copy
+}
+
+// copyIssue creates a copy of source. Failing to create the issue is an error;
+// watchers, attachments and the relation are best effort and their outcomes are
+// returned in the report so a partial failure doesn't hide the new issue.
+func (h *ToolHandlers) copyIssue(source *redmine.Issue, opts copyOptions) (*redmine.Issue, map[string]any, error) {
+	params := redmine.CreateIssueParams{
+		ProjectID:     source.Project.ID,
+		TrackerID:     source.Tracker.ID,
+		Subject:       source.Subject,
+		Description:   source.Description,
+		PriorityID:    source.Priority.ID,
+		StartDate:     source.StartDate,
+		DueDate:       source.DueDate,
+		ParentIssueID: opts.ParentIssueID,
+	}
+	if opts.ProjectID > 0 {
+		params.ProjectID = opts.ProjectID
+	}
+	if opts.Subject != "" {
+		params.Subject = opts.Subject
+	}
+	if source.AssignedTo != nil {
+		params.AssignedToID = source.AssignedTo.ID
+	}
+	if opts.DateOffsetDays != 0 {
+		var err error
+		if params.StartDate, err = shiftDate(params.StartDate, opts.DateOffsetDays); err != nil {
+			return nil, nil, i18n.Errorf("invalid start date: %w", err)
+		}
+		if params.DueDate, err = shiftDate(params.DueDate, opts.DateOffsetDays); err != nil {
+			return nil, nil, i18n.Errorf("invalid due date: %w", err)
+		}
+	}
+
+	// Copy custom fields
+	if len(source.CustomFields) > 0 {
+		cfMap := make(map[string]any)
+		for _, cf := range source.CustomFields {
+			if cf.Value != nil && cf.Value != "" {
+				cfMap[strconv.Itoa(cf.ID)] = cf.Value
+			}
+		}
+		if len(cfMap) > 0 {
+			params.CustomFields = cfMap
+		}
+	}
+
+	report := make(map[string]any)
+
+	// Attachments are uploaded first so they can be attached on creation
+	if opts.CopyAttachments {
+		var attachmentReport map[string]any
+		params.Uploads, attachmentReport = h.reuploadAttachments(source.Attachments)
+		report["attachments"] = attachmentReport
+	}
+
+	newIssue, err := h.client.CreateIssue(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opts.CopyWatchers {
+		copied := []string{}
+		var failed []map[string]any
+		for _, watcher := range source.Watchers {
+			if err := h.client.AddWatcher(newIssue.ID, watcher.ID); err != nil {
+				failed = append(failed, map[string]any{"user": watcher.Name, "error": err.Error()})
+				continue
+			}
+			copied = append(copied, watcher.Name)
+		}
+		watcherReport := map[string]any{"copied": copied}
+		if len(failed) > 0 {
+			watcherReport["failed"] = failed
+		}
+		report["watchers"] = watcherReport
+	}
+
+	if opts.LinkToSource {
+		if relation, err := h.client.CreateRelation(source.ID, newIssue.ID, "copied_to", nil); err != nil {
+			report["relation"] = map[string]any{"created": false, "error": err.Error()}
+		} else {
+			report["relation"] = map[string]any{"created": true, "id": relation.ID, "relation_type": "copied_to"}
+		}
+	}
+
+	return newIssue, report, nil
+}
+
+// reuploadAttachments downloads each attachment and uploads it again to get
+// fresh upload tokens, stopping at maxCopyAttachmentsSize in total
+func (h *ToolHandlers) reuploadAttachments(attachments []redmine.Attachment) ([]redmine.UploadToken, map[string]any) {
+	var tokens []redmine.UploadToken
+	copied := []string{}
+	var skipped, failed []map[string]any
+	total := 0
+
+	for _, a := range attachments {
+		if total+a.Filesize > maxCopyAttachmentsSize {
+			skipped = append(skipped, map[string]any{
+				"filename": a.Filename,
+				"reason":   i18n.Sprintf("total attachment size would exceed %d MB", maxCopyAttachmentsSize/(1024*1024)),
+			})
+			continue
+		}
+
+		data, contentType, filename, err := h.client.DownloadAttachment(a.ID)
+		if err != nil {
+			failed = append(failed, map[string]any{"filename": a.Filename, "error": err.Error()})
+			continue
+		}
+		if total+len(data) > maxCopyAttachmentsSize {
+			skipped = append(skipped, map[string]any{
+				"filename": a.Filename,
+				"reason":   i18n.Sprintf("total attachment size would exceed %d MB", maxCopyAttachmentsSize/(1024*1024)),
+			})
+			continue
+		}
+
+		token, err := h.client.UploadFile(filename, contentType, bytes.NewReader(data))
+		if err != nil {
+			failed = append(failed, map[string]any{"filename": a.Filename, "error": err.Error()})
+			continue
+		}
+		total += len(data)
+		token.Description = a.Description
+		tokens = append(tokens, *token)
+		copied = append(copied, filename)
+	}
+
+	report := map[string]any{"copied": copied}
+	if len(skipped) > 0 {
+		report["skipped"] = skipped
+	}
+	if len(failed) > 0 {
+		report["failed"] = failed
+	}
+	return tokens, report
+}
+
+// shiftDate moves a YYYY-MM-DD date by days; an empty date stays empty
+func shiftDate(date string, days int) (string, error) {
+	if date == "" {
+		return "", nil
+	}
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", err
+	}
+	return t.AddDate(0, 0, days).Format("2006-01-02"), nil
+}
+
+// treeCopy accumulates the outcome of copying an issue tree
+type treeCopy struct {
+	idMap   map[string]int
+	failed  []map[string]any
+	skipped []map[string]any
+}
+
+// copyTree copies node under parentID (0 for top level) and then its
+// children under the copy. When a node fails, its descendants are skipped
+// since there is no copy to attach them to.
+func (h *ToolHandlers) copyTree(node *issueTreeNode, parentID int, opts copyOptions, prefix, suffix string, out *treeCopy) {
+	opts.ParentIssueID = parentID
+	opts.Subject = prefix + node.issue.Subject + suffix
+
+	newIssue, _, err := h.copyIssue(&node.issue, opts)
+	if err != nil {
+		out.failed = append(out.failed, map[string]any{
+			"source_id": node.issue.ID,
+			"subject":   node.issue.Subject,
+			"error":     err.Error(),
+		})
+		skipDescendants(node, out)
+		return
+	}
+	out.idMap[strconv.Itoa(node.issue.ID)] = newIssue.ID
+
+	for _, child := range node.children {
+		h.copyTree(child, newIssue.ID, opts, prefix, suffix, out)
+	}
+}
+
+func skipDescendants(node *issueTreeNode, out *treeCopy) {
+	for _, child := range node.children {
+		out.skipped = append(out.skipped, map[string]any{
+			"source_id": child.issue.ID,
+			"subject":   child.issue.Subject,
+			"reason":    i18n.Sprintf("parent issue #%d was not copied", node.issue.ID),
+		})
+		skipDescendants(child, out)
+	}
+}
@@ -0,0 +1,185 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// --- TestShiftDate ---
+
+func TestShiftDate(t *testing.T) {
+	tests := []struct {
+		date string
+		days int
+		want string
+	}{
+		{"", 7, ""},
+		{"2025-01-30", 3, "2025-02-02"},
+		{"2025-03-01", -1, "2025-02-28"},
+		{"2024-12-31", 0, "2024-12-31"},
+	}
+	for _, tt := range tests {
+		got, err := shiftDate(tt.date, tt.days)
+		if err != nil || got != tt.want {
+			t.Errorf("shiftDate(%q, %d) = %q, %v; want %q", tt.date, tt.days, got, err, tt.want)
+		}
+	}
+	if _, err := shiftDate("30/01/2025", 1); err == nil {
+		t.Error("expected an error for a malformed date")
+	}
+}
+
+// --- TestCopyIssue_DateOffset ---
+
+func TestCopyIssue_DateOffset(t *testing.T) {
+	tests := []struct {
+		name               string
+		start, due         string
+		wantStart, wantDue string
+	}{
+		{"both dates", "2025-01-06", "2025-01-10", "2025-01-13", "2025-01-17"},
+		{"start only", "2025-01-06", "", "2025-01-13", ""},
+		{"due only", "", "2025-01-10", "", "2025-01-17"},
+		{"no dates", "", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]map[string]any
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				created = body["issue"]
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"issue":{"id":50}}`))
+			}))
+			defer server.Close()
+			h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+			source := &redmine.Issue{ID: 1, Subject: "Task", StartDate: tt.start, DueDate: tt.due}
+			if _, _, err := h.copyIssue(source, copyOptions{DateOffsetDays: 7}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			start, _ := created["start_date"].(string)
+			due, _ := created["due_date"].(string)
+			if start != tt.wantStart || due != tt.wantDue {
+				t.Errorf("expected start %q due %q, got start %q due %q", tt.wantStart, tt.wantDue, start, due)
+			}
+		})
+	}
+}
+
+// --- TestHandleIssuesCopyTree ---
+
+func TestHandleIssuesCopyTree(t *testing.T) {
+	// Issue 1 has children 2 and 3, and 3 has child 4. Creating the copy of
+	// 3 fails, so 4 must be skipped while 1 and 2 are still copied.
+	children := map[string]string{
+		"1": `{"id":2,"subject":"Design","project":{"id":1},"tracker":{"id":1},"priority":{"id":2},"due_date":"2025-01-10"},
+			{"id":3,"subject":"Build","project":{"id":1},"tracker":{"id":1},"priority":{"id":2}}`,
+		"3": `{"id":4,"subject":"Backend","project":{"id":1},"tracker":{"id":1},"priority":{"id":2}}`,
+	}
+	var mu sync.Mutex
+	var posts []map[string]any
+	nextID := 100
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/issues/1.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":1,"subject":"Release","project":{"id":1},"tracker":{"id":1},"priority":{"id":2},"start_date":"2025-01-06"}}`))
+		case r.URL.Path == "/issues.json" && r.Method == http.MethodGet:
+			items := children[r.URL.Query().Get("parent_id")]
+			fmt.Fprintf(w, `{"issues":[%s],"total_count":%d}`, items, strings.Count(items, `"subject"`))
+		case r.URL.Path == "/issues.json" && r.Method == http.MethodPost:
+			data, _ := io.ReadAll(r.Body)
+			var body map[string]map[string]any
+			_ = json.Unmarshal(data, &body)
+			mu.Lock()
+			defer mu.Unlock()
+			posts = append(posts, body["issue"])
+			if strings.Contains(body["issue"]["subject"].(string), "Build") {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"errors":["Tracker is not included in the list"]}`))
+				return
+			}
+			nextID++
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"issue":{"id":%d}}`, nextID)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"issue_id":         float64(1),
+		"subject_suffix":   " (v2)",
+		"date_offset_days": float64(14),
+	}
+	result, err := h.handleIssuesCopyTree(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected partial success, got error: %v", result.Content)
+	}
+	var out map[string]any
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+
+	if out["success"] != false || out["new_root_id"] != float64(101) || out["copied_count"] != float64(2) {
+		t.Errorf("unexpected summary: %v", out)
+	}
+	idMap := out["id_map"].(map[string]any)
+	if idMap["1"] != float64(101) || idMap["2"] != float64(102) || len(idMap) != 2 {
+		t.Errorf("unexpected id map: %v", idMap)
+	}
+	failed := out["failed"].([]any)
+	if len(failed) != 1 || failed[0].(map[string]any)["source_id"] != float64(3) {
+		t.Errorf("expected issue 3 to fail, got %v", failed)
+	}
+	skipped := out["skipped"].([]any)
+	if len(skipped) != 1 || skipped[0].(map[string]any)["source_id"] != float64(4) {
+		t.Errorf("expected issue 4 to be skipped, got %v", skipped)
+	}
+
+	// Root, Design and the failed Build attempt; Backend is never posted
+	if len(posts) != 3 {
+		t.Fatalf("expected 3 create requests, got %d", len(posts))
+	}
+	if posts[0]["subject"] != "Release (v2)" || posts[0]["start_date"] != "2025-01-20" || posts[0]["parent_issue_id"] != nil {
+		t.Errorf("unexpected root copy: %v", posts[0])
+	}
+	if posts[1]["parent_issue_id"] != float64(101) || posts[1]["due_date"] != "2025-01-24" || posts[1]["start_date"] != nil {
+		t.Errorf("unexpected child copy: %v", posts[1])
+	}
+}
+
+// --- TestHandleIssuesCopyTree_ReadOnly ---
+
+func TestHandleIssuesCopyTree_ReadOnly(t *testing.T) {
+	h := NewToolHandlers(redmine.NewClient("http://unused", "test-key"), nil, nil)
+	h.readOnly = true
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"issue_id": float64(1)}
+	result, _ := h.handleIssuesCopyTree(context.Background(), req)
+	if !result.IsError {
+		t.Error("expected read-only mode to block issues_copyTree")
+	}
+}
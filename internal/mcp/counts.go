@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// maxCountsIssues caps how many issues issues_search fetches details for when
+// include_counts is set, since the list endpoint has no counts
+const maxCountsIssues = 25
+
+// countsParallelism bounds the concurrent detail requests for include_counts
+const countsParallelism = 5
+
+// addIssueCounts fetches each issue's details and adds journals_count,
+// attachments_count, watchers_count and last_note_on to its result. A failed
+// fetch is reported on that issue as counts_error; cancelling ctx stops the
+// remaining fetches and returns the context error.
+func (h *ToolHandlers) addIssueCounts(ctx context.Context, issues []redmine.Issue, results []map[string]any) error {
+	if len(issues) > maxCountsIssues {
+		return i18n.Errorf("include_counts supports at most %d issues but the search returned %d; narrow the filters or lower limit", maxCountsIssues, len(issues))
+	}
+
+	client := h.client.WithContext(ctx)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(countsParallelism, len(issues)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				detail, err := client.GetIssue(issues[i].ID)
+				if err != nil {
+					results[i]["counts_error"] = err.Error()
+					continue
+				}
+				lastNoteOn := ""
+				for _, j := range detail.Journals {
+					if j.Notes != "" && j.CreatedOn > lastNoteOn {
+						lastNoteOn = j.CreatedOn
+					}
+				}
+				results[i]["journals_count"] = len(detail.Journals)
+				results[i]["attachments_count"] = len(detail.Attachments)
+				results[i]["watchers_count"] = len(detail.Watchers)
+				results[i]["last_note_on"] = lastNoteOn
+			}
+		}()
+	}
+
+send:
+	for i := range issues {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return ctx.Err()
+}
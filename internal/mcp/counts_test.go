@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestHandleIssuesSearch_IncludeCounts(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/issues.json" {
+			var issues []string
+			for id := 1; id <= 8; id++ {
+				issues = append(issues, fmt.Sprintf(`{"id":%d,"subject":"Issue %d","status":{"id":1,"name":"New"}}`, id, id))
+			}
+			_, _ = fmt.Fprintf(w, `{"issues":[%s],"total_count":8}`, strings.Join(issues, ","))
+			return
+		}
+
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		switch r.URL.Path {
+		case "/issues/1.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":1,"journals":[
+				{"id":1,"notes":"First","created_on":"2025-03-01T09:00:00Z"},
+				{"id":2,"notes":"Second","created_on":"2025-03-02T09:00:00Z"},
+				{"id":3,"notes":"","created_on":"2025-03-03T09:00:00Z"}
+			],"attachments":[{"id":4,"filename":"log.txt"}],"watchers":[{"id":5,"name":"Alice"},{"id":6,"name":"Bob"}]}}`))
+		case "/issues/2.json":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			var id int
+			_, _ = fmt.Sscanf(r.URL.Path, "/issues/%d.json", &id)
+			_, _ = fmt.Fprintf(w, `{"issue":{"id":%d}}`, id)
+		}
+	}))
+	defer server.Close()
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"include_counts": true}
+	result, err := h.handleIssuesSearch(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	var out struct {
+		Issues []map[string]any `json:"issues"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if len(out.Issues) != 8 {
+		t.Fatalf("expected 8 issues, got %d", len(out.Issues))
+	}
+
+	first := out.Issues[0]
+	if first["journals_count"] != float64(3) || first["attachments_count"] != float64(1) ||
+		first["watchers_count"] != float64(2) || first["last_note_on"] != "2025-03-02T09:00:00Z" {
+		t.Errorf("unexpected counts: %v", first)
+	}
+	if _, ok := out.Issues[1]["counts_error"]; !ok {
+		t.Errorf("expected counts_error for the forbidden issue, got %v", out.Issues[1])
+	}
+	if last := out.Issues[7]; last["journals_count"] != float64(0) || last["last_note_on"] != "" {
+		t.Errorf("unexpected counts for an issue without journals: %v", last)
+	}
+	if got := peak.Load(); got > countsParallelism {
+		t.Errorf("expected at most %d concurrent requests, got %d", countsParallelism, got)
+	}
+}
+
+func TestHandleIssuesSearch_IncludeCountsTooMany(t *testing.T) {
+	var details atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/issues.json" {
+			details.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var issues []string
+		for id := 1; id <= maxCountsIssues+1; id++ {
+			issues = append(issues, fmt.Sprintf(`{"id":%d}`, id))
+		}
+		_, _ = fmt.Fprintf(w, `{"issues":[%s],"total_count":%d}`, strings.Join(issues, ","), maxCountsIssues+1)
+	}))
+	defer server.Close()
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"include_counts": true, "limit": float64(50)}
+	result, err := h.handleIssuesSearch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "narrow") {
+		t.Errorf("expected an error suggesting narrower filters, got %v", result.Content)
+	}
+	if details.Load() != 0 {
+		t.Errorf("expected no detail requests, got %d", details.Load())
+	}
+}
+
+func TestAddIssueCounts_Cancelled(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issue":{"id":1}}`))
+	}))
+	defer server.Close()
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	issues := make([]redmine.Issue, 10)
+	results := make([]map[string]any, len(issues))
+	for i := range issues {
+		issues[i].ID = i + 1
+		results[i] = map[string]any{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := h.addIssueCounts(ctx, issues, results); err == nil {
+		t.Error("expected the context error")
+	}
+	if requests.Load() != 0 {
+		t.Errorf("expected no requests after cancellation, got %d", requests.Load())
+	}
+}
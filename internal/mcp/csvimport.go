@@ -0,0 +1,325 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// importRowResult reports what an issues_importCSV row did, or would do in a
+// dry run
+type importRowResult struct {
+	Line        int            `json:"line"`
+	Action      string         `json:"action"` // created, updated or failed; create or update in a dry run
+	IssueID     int            `json:"issue_id,omitempty"`
+	UpdatesLine int            `json:"updates_line,omitempty"` // Line whose planned issue this row updates, in a dry run
+	Subject     string         `json:"subject,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	Ignored     []string       `json:"ignored_fields,omitempty"` // Fields the action can't set
+	Payload     map[string]any `json:"payload,omitempty"`        // Request body, in a dry run
+}
+
+// importUpdateOnlyFields and importCreateOnlyFields are fields only one of
+// issues_update and issues_create accepts
+var (
+	importUpdateOnlyFields = []string{"status", "done_ratio", "notes"}
+	importCreateOnlyFields = []string{"project", "parent_issue_id"}
+)
+
+func (h *ToolHandlers) handleIssuesImportCSV(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dryRun := req.GetBool("dry_run", false)
+	if !dryRun {
+		if err := h.checkWrite("issues_importCSV"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	contentB64, err := req.RequireString("content")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	project, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	mode, err := redmine.ParseImportMode(req.GetString("mode", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var mapping map[string]string
+	if m := getMapArg(req, "mapping"); m != nil {
+		mapping = make(map[string]string, len(m))
+		for header, v := range m {
+			target, ok := v.(string)
+			if !ok {
+				return mcp.NewToolResultError(i18n.Sprintf("mapping[%q] must be a field or custom field name", header)), nil
+			}
+			mapping[header] = target
+		}
+	}
+
+	data, err := base64.StdEncoding.DecodeString(contentB64)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Invalid base64 content: %v", err)), nil
+	}
+	file, err := redmine.ParseImportCSV(data, mapping)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	switch {
+	case mode == redmine.ImportUpdateByID && !file.HasField("id"):
+		return mcp.NewToolResultError(i18n.T("update_by_id needs an id column")), nil
+	case mode != redmine.ImportUpdateByID && !file.HasField("subject"):
+		return mcp.NewToolResultError(i18n.Sprintf("%s needs a subject column", mode)), nil
+	}
+
+	projectID, err := h.resolver.ResolveProject(project)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	// Upserts match subjects against every issue of the project, open or
+	// closed, and refuse projects too large to load whole, where rows of the
+	// issues left out would be created again. A dry run records the issues it
+	// would create under the negated line number, since they have no ID yet.
+	var bySubject map[string][]int
+	if mode == redmine.ImportUpsertBySubject {
+		issues, total, err := h.client.SearchIssuesAll(redmine.SearchIssuesParams{
+			ProjectID: strconv.Itoa(projectID),
+			StatusID:  "*",
+			Limit:     redmine.MaxSearchIssuesLimit,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to load project issues: %v", err)), nil
+		}
+		if total > len(issues) {
+			return mcp.NewToolResultError(i18n.Sprintf("upsert_by_subject matches against at most %d issues, but the project has %d; use update_by_id with an id column instead",
+				len(issues), total)), nil
+		}
+		bySubject = make(map[string][]int)
+		for _, issue := range issues {
+			key := subjectKey(issue.Subject)
+			bySubject[key] = append(bySubject[key], issue.ID)
+		}
+	}
+
+	defaults := map[string]string{
+		"project": strconv.Itoa(projectID),
+		"tracker": req.GetString("tracker", ""),
+	}
+	refs := newBulkRefs(h.resolver)
+
+	results := make([]importRowResult, 0, len(file.Rows))
+	counts := map[string]int{}
+	for _, row := range file.Rows {
+		result := importRowResult{Line: row.Line, Subject: row.Fields["subject"]}
+		args, err := importArgs(row)
+		var issueID int
+		if err == nil {
+			issueID, err = importTarget(mode, row, bySubject)
+		}
+		switch {
+		case err != nil:
+			result.Action, result.Error = "failed", err.Error()
+		case issueID > 0:
+			result.IssueID = issueID
+			result = h.importUpdate(result, args, dryRun)
+		case issueID < 0:
+			result.Action, result.UpdatesLine = "update", -issueID
+		default:
+			result = h.importCreate(result, args, defaults, refs, dryRun)
+			// A later row with the same subject updates this issue
+			switch {
+			case mode != redmine.ImportUpsertBySubject:
+			case result.IssueID > 0:
+				bySubject[subjectKey(result.Subject)] = []int{result.IssueID}
+			case result.Action == "create":
+				bySubject[subjectKey(result.Subject)] = []int{-row.Line}
+			}
+		}
+		counts[result.Action]++
+		results = append(results, result)
+	}
+
+	out := map[string]any{
+		"mode":    mode,
+		"dry_run": dryRun,
+		"rows":    len(file.Rows),
+		"columns": file.Columns,
+		"results": results,
+	}
+	if dryRun {
+		out["create"], out["update"] = counts["create"], counts["update"]
+	} else {
+		out["created"], out["updated"] = counts["created"], counts["updated"]
+	}
+	out["failed"] = counts["failed"]
+	if len(file.Ignored) > 0 {
+		out["ignored_columns"] = file.Ignored
+	}
+	return jsonResult(out)
+}
+
+// importTarget returns the issue a row updates, or 0 when it creates one
+func importTarget(mode string, row redmine.ImportRow, bySubject map[string][]int) (int, error) {
+	switch mode {
+	case redmine.ImportUpdateByID:
+		id, err := strconv.Atoi(strings.TrimPrefix(row.Fields["id"], "#"))
+		if err != nil || id <= 0 {
+			return 0, i18n.Errorf("id %q is not an issue ID", row.Fields["id"])
+		}
+		return id, nil
+	case redmine.ImportUpsertBySubject:
+		subject := row.Fields["subject"]
+		if subject == "" {
+			return 0, i18n.Errorf("subject is required to match an issue")
+		}
+		switch ids := bySubject[subjectKey(subject)]; len(ids) {
+		case 0:
+			return 0, nil
+		case 1:
+			return ids[0], nil
+		default:
+			return 0, i18n.Errorf("%d issues in the project have this subject: %s", len(ids), joinInts(ids))
+		}
+	default:
+		return 0, nil
+	}
+}
+
+// importCreate creates a row's issue through the issues_bulkCreate item
+// builder, so it is resolved and validated like issues_create
+func (h *ToolHandlers) importCreate(result importRowResult, args map[string]any, defaults map[string]string, refs *bulkRefs, dryRun bool) importRowResult {
+	result.Ignored = dropArgs(args, importUpdateOnlyFields)
+	delete(args, "id")
+
+	params, problems := h.buildBulkCreateItem(args, defaults, refs)
+	if len(problems) > 0 {
+		result.Action, result.Error = "failed", strings.Join(problems, "; ")
+		return result
+	}
+	if dryRun {
+		result.Action, result.Payload = "create", params.Payload()
+		return result
+	}
+
+	issue, err := h.client.CreateIssue(params)
+	if err != nil {
+		result.Action, result.Error = "failed", i18n.Sprintf("Failed to create issue: %v", err)
+		return result
+	}
+	result.Action, result.IssueID = "created", issue.ID
+	return result
+}
+
+// importUpdate updates result.IssueID from a row through the issues_update
+// argument builder, so it is resolved and validated like issues_update
+func (h *ToolHandlers) importUpdate(result importRowResult, args map[string]any, dryRun bool) importRowResult {
+	result.Ignored = dropArgs(args, importCreateOnlyFields)
+	delete(args, "id")
+
+	issue, err := h.client.GetIssue(result.IssueID)
+	if err != nil {
+		result.Action, result.Error = "failed", i18n.Sprintf("Failed to get issue: %v", err)
+		return result
+	}
+	if result.Subject == "" {
+		result.Subject = issue.Subject
+	}
+
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = args
+	params := redmine.UpdateIssueParams{IssueID: issue.ID}
+	err = h.buildUpdateIssueParams(req, issue, &params)
+	if err == nil {
+		err = params.Validate()
+	}
+	if err != nil {
+		result.Action, result.Error = "failed", err.Error()
+		return result
+	}
+	if dryRun {
+		result.Action, result.Payload = "update", params.Payload()
+		return result
+	}
+
+	if err := h.client.UpdateIssue(params); err != nil {
+		result.Action, result.Error = "failed", i18n.Sprintf("Failed to update issue: %v", err)
+		return result
+	}
+	result.Action = "updated"
+	return result
+}
+
+// importArgs converts a row's cells to tool arguments of the types
+// issues_create and issues_update expect
+func importArgs(row redmine.ImportRow) (map[string]any, error) {
+	args := make(map[string]any, len(row.Fields)+1)
+	for field, value := range row.Fields {
+		switch field {
+		case "done_ratio", "estimated_hours", "parent_issue_id":
+			n, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimPrefix(value, "#"), "%"), 64)
+			if err != nil {
+				return nil, i18n.Errorf("%s: %q is not a number", field, value)
+			}
+			args[field] = n
+		case "is_private":
+			b, err := parseImportBool(value)
+			if err != nil {
+				return nil, i18n.Errorf("%s: %v", field, err)
+			}
+			args[field] = b
+		default:
+			args[field] = value
+		}
+	}
+	if len(row.CustomFields) > 0 {
+		customFields := make(map[string]any, len(row.CustomFields))
+		for name, value := range row.CustomFields {
+			customFields[name] = value
+		}
+		args["custom_fields"] = customFields
+	}
+	return args, nil
+}
+
+func parseImportBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "1", "true", "yes", "y", "x":
+		return true, nil
+	case "0", "false", "no", "n":
+		return false, nil
+	}
+	return false, i18n.Errorf("%q is not yes or no", value)
+}
+
+// dropArgs removes fields from args and returns the ones that were set
+func dropArgs(args map[string]any, fields []string) []string {
+	var dropped []string
+	for _, field := range fields {
+		if _, ok := args[field]; ok {
+			dropped = append(dropped, field)
+			delete(args, field)
+		}
+	}
+	return dropped
+}
+
+func subjectKey(subject string) string {
+	return strings.ToLower(strings.TrimSpace(subject))
+}
+
+func joinInts(ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = "#" + strconv.Itoa(id)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,258 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+type importOutput struct {
+	DryRun  bool              `json:"dry_run"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Create  int               `json:"create"`
+	Failed  int               `json:"failed"`
+	Ignored []string          `json:"ignored_columns"`
+	Results []importRowResult `json:"results"`
+}
+
+func callImportCSV(t *testing.T, h *ToolHandlers, csv string, args map[string]any) importOutput {
+	t.Helper()
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"content": base64.StdEncoding.EncodeToString([]byte(csv)), "project": "1"}
+	for k, v := range args {
+		req.Params.Arguments.(map[string]any)[k] = v
+	}
+	result, err := h.handleIssuesImportCSV(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	var out importOutput
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	return out
+}
+
+// --- TestHandleIssuesImportCSV_Upsert ---
+
+func TestHandleIssuesImportCSV_Upsert(t *testing.T) {
+	var creates []map[string]any
+	updates := map[string]map[string]any{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Task"}]}`))
+	})
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("project_id") != "1" || r.URL.Query().Get("status_id") != "*" {
+			t.Errorf("expected all issues of project 1, got %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"issues":[{"id":5,"subject":"Existing"},{"id":6,"subject":"Dup"},{"id":7,"subject":"dup "}],"total_count":3}`))
+	})
+	mux.HandleFunc("GET /issues/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(r.PathValue("id"), ".json")
+		_, _ = fmt.Fprintf(w, `{"issue":{"id":%s,"subject":"Issue %s","project":{"id":1,"name":"Web"},"tracker":{"id":1,"name":"Task"}}}`, id, id)
+	})
+	mux.HandleFunc("PUT /issues/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]any
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		updates[strings.TrimSuffix(r.PathValue("id"), ".json")] = body["issue"]
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]any
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &body)
+		if body["issue"]["due_date"] == "tomorrow" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors":["Due date is not a valid date"]}`))
+			return
+		}
+		creates = append(creates, body["issue"])
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"issue":{"id":101,"subject":"New one"}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	csv := "Subject,Due,Notes,Author\n" +
+		"Existing,2025-05-01,Moved,Alice\n" +
+		"New one,2025-06-01,Not on create,\n" +
+		"DUP,2025-07-01,,\n" +
+		"New one,2025-06-02,,\n" +
+		"Bad date,tomorrow,,\n"
+	out := callImportCSV(t, h, csv, map[string]any{"mode": "upsert_by_subject", "tracker": "Task"})
+
+	if out.Created != 1 || out.Updated != 2 || out.Failed != 2 {
+		t.Fatalf("expected 1 created, 2 updated and 2 failed, got %+v", out)
+	}
+	if len(out.Ignored) != 1 || out.Ignored[0] != "Author" {
+		t.Errorf("expected the Author column to be ignored, got %v", out.Ignored)
+	}
+
+	byLine := map[int]importRowResult{}
+	for _, r := range out.Results {
+		byLine[r.Line] = r
+	}
+	if r := byLine[2]; r.Action != "updated" || r.IssueID != 5 {
+		t.Errorf("expected line 2 to update #5, got %+v", r)
+	}
+	if r := byLine[3]; r.Action != "created" || r.IssueID != 101 || len(r.Ignored) != 1 || r.Ignored[0] != "notes" {
+		t.Errorf("expected line 3 to create #101 without its notes, got %+v", r)
+	}
+	if r := byLine[4]; r.Action != "failed" || !strings.Contains(r.Error, "#6, #7") {
+		t.Errorf("expected line 4 to fail on an ambiguous subject, got %+v", r)
+	}
+	if r := byLine[5]; r.Action != "updated" || r.IssueID != 101 {
+		t.Errorf("expected line 5 to update the issue created by line 3, got %+v", r)
+	}
+	if r := byLine[6]; r.Action != "failed" || !strings.Contains(r.Error, "Due date is not a valid date") {
+		t.Errorf("expected line 6 to be reported as failed, got %+v", r)
+	}
+
+	if len(creates) != 1 || creates[0]["tracker_id"] != float64(1) || creates[0]["due_date"] != "2025-06-01" {
+		t.Errorf("unexpected creates: %v", creates)
+	}
+	if updates["5"]["notes"] != "Moved" || updates["5"]["due_date"] != "2025-05-01" || updates["101"]["due_date"] != "2025-06-02" {
+		t.Errorf("unexpected updates: %v", updates)
+	}
+}
+
+// --- TestHandleIssuesImportCSV_DryRun ---
+
+func TestHandleIssuesImportCSV_DryRun(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Task"},{"id":2,"name":"Bug"}]}`))
+	})
+	mux.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("dry run must not create anything, got %s", r.URL.Path)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	h.readOnly = true
+
+	csv := "Title,Kind,Hours\nWrite docs,Bug,2.5\nNo hours,Task,lots\n"
+	out := callImportCSV(t, h, csv, map[string]any{
+		"mapping": map[string]any{"Title": "subject", "Kind": "tracker", "Hours": "estimated_hours"},
+		"dry_run": true,
+	})
+
+	if !out.DryRun || out.Create != 1 || out.Failed != 1 {
+		t.Fatalf("expected one valid and one invalid row, got %+v", out)
+	}
+	payload, _ := out.Results[0].Payload["issue"].(map[string]any)
+	if out.Results[0].Action != "create" || payload["tracker_id"] != float64(2) || payload["estimated_hours"] != 2.5 {
+		t.Errorf("unexpected preview: %+v", out.Results[0])
+	}
+	if r := out.Results[1]; r.Action != "failed" || !strings.Contains(r.Error, `"lots" is not a number`) {
+		t.Errorf("expected the bad number to be reported, got %+v", r)
+	}
+}
+
+// --- TestHandleIssuesImportCSV_UpsertDryRun ---
+
+func TestHandleIssuesImportCSV_UpsertDryRun(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Task"}]}`))
+	})
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	})
+	mux.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("dry run must not create anything, got %s", r.URL.Path)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	// The second row updates the issue the first would create, as in a real run
+	csv := "Subject,Due\nNew one,2025-06-01\nnew one,2025-06-02\n"
+	out := callImportCSV(t, h, csv, map[string]any{"mode": "upsert_by_subject", "tracker": "Task", "dry_run": true})
+	if out.Create != 1 || out.Failed != 0 || len(out.Results) != 2 {
+		t.Fatalf("expected one create and one update, got %+v", out)
+	}
+	if r := out.Results[1]; r.Action != "update" || r.UpdatesLine != 2 {
+		t.Errorf("expected line 3 to update the issue planned at line 2, got %+v", r)
+	}
+}
+
+func TestHandleIssuesImportCSV_UpsertTooManyIssues(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		// Past the fetch cap, so some subjects can't be matched
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		var issues []string
+		for id := offset + 1; id <= offset+100; id++ {
+			issues = append(issues, fmt.Sprintf(`{"id":%d,"subject":"Issue %d"}`, id, id))
+		}
+		_, _ = fmt.Fprintf(w, `{"issues":[%s],"total_count":1500}`, strings.Join(issues, ","))
+	})
+	mux.HandleFunc("POST /", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("a refused import must not create anything, got %s", r.URL.Path)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"content": base64.StdEncoding.EncodeToString([]byte("Subject\nIssue 1200\n")),
+		"project": "1",
+		"mode":    "upsert_by_subject",
+	}
+	result, err := h.handleIssuesImportCSV(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "at most 1000 issues, but the project has 1500") {
+		t.Errorf("expected the upsert to be refused, got %v", result.Content)
+	}
+}
+
+// --- TestHandleIssuesImportCSV_MissingColumn ---
+
+func TestHandleIssuesImportCSV_MissingColumn(t *testing.T) {
+	h := NewToolHandlers(redmine.NewClient("http://unused", "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"content": base64.StdEncoding.EncodeToString([]byte("Subject\nFix\n")),
+		"project": "1",
+		"mode":    "update_by_id",
+	}
+	result, err := h.handleIssuesImportCSV(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "needs an id column") {
+		t.Errorf("expected update_by_id without an id column to fail, got %v", result.Content)
+	}
+
+	req.Params.Arguments = map[string]any{
+		"content": base64.StdEncoding.EncodeToString([]byte("ID\n7\n")),
+		"project": "1",
+		"mode":    "upsert_by_subject",
+	}
+	result, _ = h.handleIssuesImportCSV(context.Background(), req)
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "upsert_by_subject needs a subject column") {
+		t.Errorf("expected upsert_by_subject without a subject column to fail, got %v", result.Content)
+	}
+}
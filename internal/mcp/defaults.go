@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// ToolDefault is what a tool uses for the parameters a caller leaves out,
+// instead of the built-in defaults (open issues, 25 per page, Redmine's sort)
+type ToolDefault struct {
+	Status string `yaml:"status"`
+	Limit  int    `yaml:"limit"`
+	Sort   string `yaml:"sort"`
+}
+
+// ToolDefaults maps tool names to their operator-configured defaults
+type ToolDefaults map[string]ToolDefault
+
+// defaultableTools lists the tools that consult ToolDefaults. issues_stats
+// always counts every matching issue, so only its status default applies.
+var defaultableTools = []string{"issues_search", "issues_exportCSV", "issues_stats"}
+
+// LoadToolDefaults reads per-tool defaults from a YAML (or JSON) file keyed by
+// tool name, e.g.
+//
+//	issues_search:
+//	  status: "*"
+//	  limit: 50
+//	  sort: updated_on:desc
+func LoadToolDefaults(path string) (ToolDefaults, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool defaults: %w", err)
+	}
+	var defaults ToolDefaults
+	if err := yaml.Unmarshal(data, &defaults); err != nil {
+		return nil, fmt.Errorf("failed to parse tool defaults: %w", err)
+	}
+	for tool, d := range defaults {
+		if !slices.Contains(defaultableTools, tool) {
+			return nil, fmt.Errorf("tool defaults: unsupported tool %q (use %s)", tool, strings.Join(defaultableTools, ", "))
+		}
+		if d.Limit < 0 {
+			return nil, fmt.Errorf("tool defaults: %s limit must be 0 or greater", tool)
+		}
+	}
+	return defaults, nil
+}
+
+// toolDefault returns the configured defaults of the tool req calls
+func (h *ToolHandlers) toolDefault(req mcp.CallToolRequest) ToolDefault {
+	return h.defaults[req.Params.Name]
+}
+
+// limitArg returns the limit argument, else the configured default limit of
+// the tool, else fallback
+func (h *ToolHandlers) limitArg(req mcp.CallToolRequest, fallback int) int {
+	if d := h.toolDefault(req); d.Limit > 0 {
+		fallback = d.Limit
+	}
+	return req.GetInt("limit", fallback)
+}
+
+// appliedFilters echoes the issue query sent to Redmine, so callers see the
+// defaults filled in for the arguments they left out
+func appliedFilters(params redmine.SearchIssuesParams) map[string]string {
+	values := params.Values()
+	applied := make(map[string]string, len(values))
+	for k := range values {
+		applied[k] = values.Get(k)
+	}
+	return applied
+}
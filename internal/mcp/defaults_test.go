@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestLoadToolDefaults(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	defaults, err := LoadToolDefaults(write("ok.yaml", "issues_search:\n  status: \"*\"\n  limit: 50\n  sort: updated_on:desc\nissues_stats:\n  status: closed\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := defaults["issues_search"]; d != (ToolDefault{Status: "*", Limit: 50, Sort: "updated_on:desc"}) {
+		t.Errorf("unexpected issues_search defaults: %+v", d)
+	}
+	if defaults["issues_stats"].Status != "closed" {
+		t.Errorf("unexpected issues_stats defaults: %+v", defaults["issues_stats"])
+	}
+
+	if _, err := LoadToolDefaults(write("unknown.yaml", "issues_create:\n  status: open\n")); err == nil {
+		t.Error("expected an error for a tool without defaults")
+	}
+	if _, err := LoadToolDefaults(write("limit.yaml", "issues_search:\n  limit: -1\n")); err == nil {
+		t.Error("expected an error for a negative limit")
+	}
+	if _, err := LoadToolDefaults(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestHandleIssuesSearch_ToolDefaults(t *testing.T) {
+	var query url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	search := func(args map[string]any) map[string]string {
+		t.Helper()
+		req := gomcp.CallToolRequest{}
+		req.Params.Name = "issues_search"
+		req.Params.Arguments = args
+		result, err := h.handleIssuesSearch(context.Background(), req)
+		if err != nil || result.IsError {
+			t.Fatalf("expected success, got %v %v", err, result.Content)
+		}
+		var resp struct {
+			AppliedFilters map[string]string `json:"applied_filters"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp.AppliedFilters
+	}
+
+	// The built-in open status default is reported back
+	applied := search(map[string]any{"subject": "login"})
+	if applied["status_id"] != "open" || applied["limit"] != "25" || applied["subject"] != "~login" {
+		t.Errorf("unexpected applied filters: %v", applied)
+	}
+
+	h.defaults = ToolDefaults{"issues_search": {Status: "all", Limit: 50, Sort: "updated_on:desc"}}
+	applied = search(map[string]any{})
+	if query.Get("status_id") != "*" || query.Get("limit") != "50" || query.Get("sort") != "updated_on:desc" {
+		t.Errorf("expected the configured defaults to be sent, got %v", query)
+	}
+	if applied["status_id"] != "*" || applied["sort"] != "updated_on:desc" {
+		t.Errorf("expected the configured defaults to be reported, got %v", applied)
+	}
+
+	// Arguments still win over the configured defaults
+	search(map[string]any{"status": "closed", "limit": float64(5), "sort": "id"})
+	if query.Get("status_id") != "closed" || query.Get("limit") != "5" || query.Get("sort") != "id" {
+		t.Errorf("expected the arguments to override the defaults, got %v", query)
+	}
+}
+
+func TestHandleIssuesSearch_SortArgumentNormalized(t *testing.T) {
+	var query url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	h.defaults = ToolDefaults{"issues_search": {Sort: "updated_on:desc"}}
+	req := gomcp.CallToolRequest{}
+	req.Params.Name = "issues_search"
+	req.Params.Arguments = map[string]any{"sort": "ID asc"}
+	result, err := h.handleIssuesSearch(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+
+	// A loosely written sort argument is normalized and still wins over the default
+	if query.Get("sort") != "id" {
+		t.Errorf("expected the normalized sort argument to be sent, got %v", query)
+	}
+}
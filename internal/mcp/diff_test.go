@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldText string
+		newText string
+		context int
+		want    string
+	}{
+		{
+			name:    "identical",
+			oldText: "a\nb\n",
+			newText: "a\r\nb",
+			context: 3,
+			want:    "",
+		},
+		{
+			name:    "single change with context",
+			oldText: "h1. Setup\n\nInstall Go 1.21\nRun make\n",
+			newText: "h1. Setup\n\nInstall Go 1.24\nRun make\n",
+			context: 1,
+			want: "--- old\n+++ new\n" +
+				"@@ -2,3 +2,3 @@\n" +
+				" \n-Install Go 1.21\n+Install Go 1.24\n Run make\n",
+		},
+		{
+			name:    "separate hunks",
+			oldText: "1\n2\n3\n4\n5\n6\n7\n8\n",
+			newText: "one\n2\n3\n4\n5\n6\n7\n8\nnine\n",
+			context: 1,
+			want: "--- old\n+++ new\n" +
+				"@@ -1,2 +1,2 @@\n-1\n+one\n 2\n" +
+				"@@ -8 +8,2 @@\n 8\n+nine\n",
+		},
+		{
+			name:    "from empty",
+			oldText: "",
+			newText: "new page\n",
+			context: 3,
+			want:    "--- old\n+++ new\n@@ -0,0 +1 @@\n+new page\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := unifiedDiff(tt.oldText, tt.newText, "old", "new", tt.context)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("unexpected diff:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiff_TooLarge(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 3000; i++ {
+		a = append(a, "a"+strings.Repeat("x", i%7))
+		b = append(b, "b"+strings.Repeat("y", i%5))
+	}
+	if _, err := unifiedDiff(strings.Join(a, "\n"), strings.Join(b, "\n"), "old", "new", 3); err == nil {
+		t.Error("expected error for texts too large to diff")
+	}
+}
@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// dueDateFilters returns the Redmine due_date filters for overdue issues (due
+// before today) and issues due within the next withinDays days (today
+// included). dueSoon is empty when withinDays is 0.
+func dueDateFilters(today time.Time, withinDays int) (overdue, dueSoon string) {
+	overdue = "<=" + today.AddDate(0, 0, -1).Format("2006-01-02")
+	if withinDays > 0 {
+		dueSoon = "><" + today.Format("2006-01-02") + "|" + today.AddDate(0, 0, withinDays).Format("2006-01-02")
+	}
+	return overdue, dueSoon
+}
+
+// formatDueIssues formats issues with a due date relative to today (a UTC
+// midnight, as due dates parse), skipping
+// issues without one. Overdue issues get days_overdue and are sorted most
+// late first; others get days_until_due and are sorted by due date.
+func formatDueIssues(issues []redmine.Issue, today time.Time, overdue bool, links redmine.Links) []map[string]any {
+	type dueIssue struct {
+		issue redmine.Issue
+		days  int
+	}
+
+	var due []dueIssue
+	for _, issue := range issues {
+		date, err := time.Parse("2006-01-02", issue.DueDate)
+		if err != nil {
+			continue
+		}
+		days := int(date.Sub(today).Hours() / 24)
+		if overdue {
+			days = -days
+		}
+		due = append(due, dueIssue{issue: issue, days: days})
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		if due[i].days != due[j].days {
+			if overdue {
+				return due[i].days > due[j].days
+			}
+			return due[i].days < due[j].days
+		}
+		return due[i].issue.ID < due[j].issue.ID
+	})
+
+	result := make([]map[string]any, len(due))
+	for i, d := range due {
+		result[i] = formatIssue(d.issue, links)
+		if overdue {
+			result[i]["days_overdue"] = d.days
+		} else {
+			result[i]["days_until_due"] = d.days
+		}
+	}
+	return result
+}
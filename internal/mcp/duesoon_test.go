@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestDueDateFilters(t *testing.T) {
+	today := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	overdue, dueSoon := dueDateFilters(today, 7)
+	if overdue != "<=2024-03-14" {
+		t.Errorf("expected overdue filter <=2024-03-14, got %q", overdue)
+	}
+	if dueSoon != "><2024-03-15|2024-03-22" {
+		t.Errorf("expected due soon filter ><2024-03-15|2024-03-22, got %q", dueSoon)
+	}
+
+	if _, dueSoon := dueDateFilters(today, 0); dueSoon != "" {
+		t.Errorf("expected no due soon filter for within_days=0, got %q", dueSoon)
+	}
+}
+
+func TestFormatDueIssues(t *testing.T) {
+	today := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	issues := []redmine.Issue{
+		{ID: 1, DueDate: "2024-03-14"},
+		{ID: 2, DueDate: "2024-03-01"},
+		{ID: 3},
+		{ID: 4, DueDate: "2024-03-10"},
+	}
+
+	overdue := formatDueIssues(issues, today, true, redmine.Links{})
+	if len(overdue) != 3 {
+		t.Fatalf("expected issues without due date to be skipped, got %d", len(overdue))
+	}
+	wantIDs := []int{2, 4, 1}
+	wantDays := []int{14, 5, 1}
+	for i := range wantIDs {
+		if overdue[i]["id"] != wantIDs[i] || overdue[i]["days_overdue"] != wantDays[i] {
+			t.Errorf("position %d: expected issue %d %d days late, got %v", i, wantIDs[i], wantDays[i], overdue[i])
+		}
+	}
+
+	dueSoon := formatDueIssues([]redmine.Issue{
+		{ID: 5, DueDate: "2024-03-20"},
+		{ID: 6, DueDate: "2024-03-15"},
+	}, today, false, redmine.Links{})
+	if dueSoon[0]["id"] != 6 || dueSoon[0]["days_until_due"] != 0 || dueSoon[1]["days_until_due"] != 5 {
+		t.Errorf("expected issues sorted by due date, got %v", dueSoon)
+	}
+}
+
+func TestHandleIssuesDueSoon(t *testing.T) {
+	var filters []string
+	var statuses []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		filters = append(filters, q.Get("due_date"))
+		statuses = append(statuses, q.Get("status_id"))
+		if q.Get("due_date")[:2] == "<=" {
+			_, _ = w.Write([]byte(`{"issues":[{"id":1,"subject":"Late","due_date":"2000-01-01"}],"total_count":1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	}))
+	defer server.Close()
+
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"assigned_to": "me", "within_days": float64(3), "include_closed": true}
+	result, err := h.handleIssuesDueSoon(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+
+	if len(filters) != 2 || filters[0][:2] != "<=" || filters[1][:2] != "><" {
+		t.Errorf("expected overdue then due soon queries, got %v", filters)
+	}
+	for _, s := range statuses {
+		if s != "*" {
+			t.Errorf("expected include_closed to search all statuses, got %q", s)
+		}
+	}
+
+	var out struct {
+		Overdue      []map[string]any `json:"overdue"`
+		OverdueCount int              `json:"overdue_count"`
+		DueSoon      []map[string]any `json:"due_soon"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if out.OverdueCount != 1 || out.Overdue[0]["days_overdue"] == nil {
+		t.Errorf("unexpected overdue section: %v", out.Overdue)
+	}
+	if out.DueSoon == nil || len(out.DueSoon) != 0 {
+		t.Errorf("expected empty due_soon list, got %v", out.DueSoon)
+	}
+}
+
+func TestHandleIssuesDueSoon_Timezone(t *testing.T) {
+	var filters []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filters = append(filters, r.URL.Query().Get("due_date"))
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	}))
+	defer server.Close()
+
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+	// UTC+14 and UTC-12 never share a date, so the argument must win over the default
+	h.location = time.FixedZone("UTC-12", -12*60*60)
+	kiritimati, err := time.LoadLocation("Pacific/Kiritimati")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"within_days": float64(0), "timezone": "Pacific/Kiritimati"}
+	result, err := h.handleIssuesDueSoon(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	now := time.Now().In(kiritimati)
+	want := "<=" + time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+	if len(filters) != 1 || filters[0] != want {
+		t.Errorf("expected overdue filter %s, got %v", want, filters)
+	}
+
+	req.Params.Arguments = map[string]any{"timezone": "Mars/Olympus"}
+	result, err = h.handleIssuesDueSoon(context.Background(), req)
+	if err != nil || !result.IsError {
+		t.Errorf("expected an unknown timezone to be rejected, got %v %v", err, result.Content)
+	}
+}
@@ -0,0 +1,162 @@
+package mcp
+
+import (
+	"sort"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// defaultOverEstimatePercent is the variance above which an issue is flagged as over estimate
+const defaultOverEstimatePercent = 20
+
+// estimateTotals accumulates estimated and spent hours for a group of issues
+type estimateTotals struct {
+	Name       string
+	Estimated  float64
+	Spent      float64
+	IssueCount int
+}
+
+func (t *estimateTotals) format() map[string]any {
+	result := map[string]any{
+		"name":            t.Name,
+		"estimated_hours": roundHours(t.Estimated),
+		"spent_hours":     roundHours(t.Spent),
+		"variance_hours":  roundHours(t.Spent - t.Estimated),
+		"issue_count":     t.IssueCount,
+	}
+	if pct, ok := variancePercent(t.Estimated, t.Spent); ok {
+		result["variance_percent"] = pct
+	}
+	return result
+}
+
+// variancePercent returns how far spent exceeds estimated, as a percentage of the estimate.
+// It reports false when there is no estimate to compare against.
+func variancePercent(estimated, spent float64) (float64, bool) {
+	if estimated <= 0 {
+		return 0, false
+	}
+	return roundHours((spent - estimated) / estimated * 100), true
+}
+
+// buildEstimateReport joins time entries to issues by issue ID and compares spent hours
+// with each issue's estimate. Issues are sorted by variance descending so the worst
+// overruns come first; per-version and per-assignee totals are sorted by name.
+func buildEstimateReport(issues []redmine.Issue, entries []redmine.TimeEntry, overPercent float64) map[string]any {
+	spent := make(map[int]float64)
+	for _, e := range entries {
+		if e.Issue != nil {
+			spent[e.Issue.ID] += e.Hours
+		}
+	}
+
+	type issueRow struct {
+		row      map[string]any
+		variance float64
+	}
+
+	var (
+		rows        []issueRow
+		overIDs     []int
+		unestimated int
+		total       = &estimateTotals{Name: "total"}
+		byVersion   = make(map[string]*estimateTotals)
+		byAssignee  = make(map[string]*estimateTotals)
+	)
+
+	addTo := func(groups map[string]*estimateTotals, name string, estimated, spent float64) {
+		g, ok := groups[name]
+		if !ok {
+			g = &estimateTotals{Name: name}
+			groups[name] = g
+		}
+		g.Estimated += estimated
+		g.Spent += spent
+		g.IssueCount++
+	}
+
+	for _, issue := range issues {
+		var estimated float64
+		if issue.EstimatedHours != nil {
+			estimated = *issue.EstimatedHours
+		} else {
+			unestimated++
+		}
+		hours := spent[issue.ID]
+
+		version := noneBucket
+		if issue.FixedVersion != nil {
+			version = issue.FixedVersion.Name
+		}
+		assignee := noneBucket
+		if issue.AssignedTo != nil {
+			assignee = issue.AssignedTo.Name
+		}
+
+		row := map[string]any{
+			"id":              issue.ID,
+			"subject":         issue.Subject,
+			"status":          issue.Status.Name,
+			"version":         version,
+			"assigned_to":     assignee,
+			"estimated_hours": roundHours(estimated),
+			"spent_hours":     roundHours(hours),
+			"variance_hours":  roundHours(hours - estimated),
+		}
+		over := false
+		if pct, ok := variancePercent(estimated, hours); ok {
+			row["variance_percent"] = pct
+			over = pct > overPercent
+		}
+		row["over_estimate"] = over
+		if over {
+			overIDs = append(overIDs, issue.ID)
+		}
+		rows = append(rows, issueRow{row: row, variance: hours - estimated})
+
+		total.Estimated += estimated
+		total.Spent += hours
+		total.IssueCount++
+		addTo(byVersion, version, estimated, hours)
+		addTo(byAssignee, assignee, estimated, hours)
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].variance > rows[j].variance
+	})
+	issueRows := make([]map[string]any, len(rows))
+	for i, r := range rows {
+		issueRows[i] = r.row
+	}
+
+	sortedTotals := func(groups map[string]*estimateTotals) []map[string]any {
+		names := make([]string, 0, len(groups))
+		for name := range groups {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		result := make([]map[string]any, len(names))
+		for i, name := range names {
+			result[i] = groups[name].format()
+		}
+		return result
+	}
+
+	summary := total.format()
+	delete(summary, "name")
+	summary["unestimated_issues"] = unestimated
+	summary["over_estimate_issues"] = len(overIDs)
+
+	if overIDs == nil {
+		overIDs = []int{}
+	}
+
+	return map[string]any{
+		"summary":           summary,
+		"issues":            issueRows,
+		"by_version":        sortedTotals(byVersion),
+		"by_assignee":       sortedTotals(byAssignee),
+		"over_estimate_ids": overIDs,
+	}
+}
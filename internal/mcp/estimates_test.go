@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func hoursPtr(v float64) *float64 {
+	return &v
+}
+
+func TestBuildEstimateReport(t *testing.T) {
+	v1 := &redmine.IDName{ID: 1, Name: "v1.0"}
+	alice := &redmine.IDName{ID: 10, Name: "Alice"}
+	issues := []redmine.Issue{
+		{ID: 1, Subject: "On track", FixedVersion: v1, AssignedTo: alice, EstimatedHours: hoursPtr(10)},
+		{ID: 2, Subject: "Overrun", FixedVersion: v1, EstimatedHours: hoursPtr(4)},
+		{ID: 3, Subject: "No estimate", AssignedTo: alice},
+	}
+	entries := []redmine.TimeEntry{
+		{Issue: &redmine.IDName{ID: 1}, Hours: 11},
+		{Issue: &redmine.IDName{ID: 2}, Hours: 3},
+		{Issue: &redmine.IDName{ID: 2}, Hours: 3},
+		{Issue: &redmine.IDName{ID: 3}, Hours: 2},
+		{Hours: 5}, // project-level entry without an issue
+	}
+
+	report := buildEstimateReport(issues, entries, 20)
+
+	rows := report["issues"].([]map[string]any)
+	if len(rows) != 3 || rows[0]["id"] != 2 {
+		t.Fatalf("expected overrun issue first, got %v", rows)
+	}
+	if rows[0]["variance_percent"] != 50.0 || rows[0]["over_estimate"] != true {
+		t.Errorf("expected issue 2 to be 50%% over estimate, got %v", rows[0])
+	}
+	for _, r := range rows {
+		switch r["id"] {
+		case 1:
+			if r["over_estimate"] != false || r["variance_percent"] != 10.0 {
+				t.Errorf("expected issue 1 within threshold, got %v", r)
+			}
+		case 3:
+			if _, ok := r["variance_percent"]; ok {
+				t.Errorf("expected no variance_percent without an estimate, got %v", r)
+			}
+		}
+	}
+
+	ids := report["over_estimate_ids"].([]int)
+	if len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("expected only issue 2 flagged, got %v", ids)
+	}
+
+	summary := report["summary"].(map[string]any)
+	if summary["estimated_hours"] != 14.0 || summary["spent_hours"] != 19.0 || summary["unestimated_issues"] != 1 {
+		t.Errorf("unexpected summary: %v", summary)
+	}
+
+	byVersion := report["by_version"].([]map[string]any)
+	if len(byVersion) != 2 || byVersion[0]["name"] != noneBucket || byVersion[1]["spent_hours"] != 17.0 {
+		t.Errorf("unexpected by_version: %v", byVersion)
+	}
+	byAssignee := report["by_assignee"].([]map[string]any)
+	if len(byAssignee) != 2 || byAssignee[1]["name"] != "Alice" || byAssignee[1]["issue_count"] != 2 {
+		t.Errorf("unexpected by_assignee: %v", byAssignee)
+	}
+}
+
+func TestHandleReportsEstimateVsActual(t *testing.T) {
+	timeEntryCalls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("status_id") != "*" {
+			t.Errorf("expected all statuses, got %q", r.URL.Query().Get("status_id"))
+		}
+		_, _ = w.Write([]byte(`{"issues":[{"id":5,"subject":"Login","estimated_hours":8}],"total_count":1}`))
+	})
+	mux.HandleFunc("GET /time_entries.json", func(w http.ResponseWriter, r *http.Request) {
+		timeEntryCalls++
+		if r.URL.Query().Get("issue_id") != "" {
+			t.Errorf("expected project-wide time entry query, got %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"time_entries":[{"issue":{"id":5},"hours":12}],"total_count":1}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1"}
+	result, err := h.handleReportsEstimateVsActual(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if timeEntryCalls != 1 {
+		t.Errorf("expected a single time entry query, got %d", timeEntryCalls)
+	}
+
+	var report struct {
+		OverEstimateIDs []int `json:"over_estimate_ids"`
+		Summary         struct {
+			VariancePercent float64 `json:"variance_percent"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &report); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if len(report.OverEstimateIDs) != 1 || report.Summary.VariancePercent != 50 {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
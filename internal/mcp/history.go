@@ -0,0 +1,231 @@
+package mcp
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// historyLookups maps IDs found in journal details to display names
+type historyLookups struct {
+	statuses     map[int]string
+	priorities   map[int]string
+	trackers     map[int]string
+	users        map[int]string
+	versions     map[int]string
+	customFields map[int]string
+}
+
+// historyAttrFields maps Redmine journal attribute names to user-facing field names
+var historyAttrFields = map[string]string{
+	"status_id":        "status",
+	"assigned_to_id":   "assignee",
+	"priority_id":      "priority",
+	"tracker_id":       "tracker",
+	"fixed_version_id": "version",
+	"parent_id":        "parent",
+	"category_id":      "category",
+	"project_id":       "project",
+}
+
+// historyFieldAliases maps accepted filter values to user-facing field names
+var historyFieldAliases = map[string]string{
+	"assigned_to":    "assignee",
+	"fixed_version":  "version",
+	"target_version": "version",
+	"progress":       "done_ratio",
+	"% done":         "done_ratio",
+}
+
+// buildHistoryLookups collects ID -> name tables for an issue's history using
+// the resolver caches and the users referenced by the issue itself
+func (h *ToolHandlers) buildHistoryLookups(issue *redmine.Issue) historyLookups {
+	lookups := historyLookups{
+		statuses:     make(map[int]string),
+		priorities:   make(map[int]string),
+		trackers:     make(map[int]string),
+		users:        make(map[int]string),
+		versions:     make(map[int]string),
+		customFields: make(map[int]string),
+	}
+
+	// Lookups are best effort: a failure leaves the raw ID in the output
+	if statuses, err := h.resolver.GetStatuses(); err == nil {
+		for _, s := range statuses {
+			lookups.statuses[s.ID] = s.Name
+		}
+	}
+	if priorities, err := h.resolver.GetPriorities(); err == nil {
+		for _, p := range priorities {
+			lookups.priorities[p.ID] = p.Name
+		}
+	}
+	if trackers, err := h.resolver.GetTrackers(); err == nil {
+		for _, t := range trackers {
+			lookups.trackers[t.ID] = t.Name
+		}
+	}
+	if versions, err := h.client.ListVersions(issue.Project.ID); err == nil {
+		for _, v := range versions {
+			lookups.versions[v.ID] = v.Name
+		}
+	}
+	if memberships, _, err := h.client.GetProjectMemberships(issue.Project.ID, 0, redmine.MaxMembershipsLimit); err == nil {
+		for _, m := range memberships {
+			if m.User != nil {
+				lookups.users[m.User.ID] = m.User.Name
+			}
+			if m.Group != nil {
+				lookups.users[m.Group.ID] = m.Group.Name
+			}
+		}
+	}
+
+	// Users referenced by the issue itself
+	lookups.users[issue.Author.ID] = issue.Author.Name
+	if issue.AssignedTo != nil {
+		lookups.users[issue.AssignedTo.ID] = issue.AssignedTo.Name
+	}
+	for _, w := range issue.Watchers {
+		lookups.users[w.ID] = w.Name
+	}
+	for _, j := range issue.Journals {
+		lookups.users[j.User.ID] = j.User.Name
+	}
+
+	// Custom field names come with the issue; fall back to the admin cache for removed fields
+	for _, cf := range issue.CustomFields {
+		lookups.customFields[cf.ID] = cf.Name
+	}
+	if defs, err := h.resolver.GetCustomFields(); err == nil {
+		for _, def := range defs {
+			if _, ok := lookups.customFields[def.ID]; !ok {
+				lookups.customFields[def.ID] = def.Name
+			}
+		}
+	}
+
+	return lookups
+}
+
+// buildIssueHistory flattens journal details into a chronological change list.
+// If field is non-empty, only changes to that field are returned.
+func buildIssueHistory(journals []redmine.Journal, lookups historyLookups, field string) []map[string]any {
+	sorted := make([]redmine.Journal, len(journals))
+	copy(sorted, journals)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedOn < sorted[j].CreatedOn
+	})
+
+	filter := normalizeHistoryField(field)
+
+	history := []map[string]any{}
+	for _, j := range sorted {
+		for _, d := range j.Details {
+			name, oldValue, newValue := lookups.describeDetail(d)
+			if filter != "" && !historyFieldMatches(filter, name, d) {
+				continue
+			}
+
+			entry := map[string]any{
+				"journal_id": j.ID,
+				"created_on": j.CreatedOn,
+				"user":       j.User.Name,
+				"field":      name,
+				"property":   d.Property,
+				"old_value":  oldValue,
+				"new_value":  newValue,
+			}
+			if d.Property == "cf" {
+				if id, err := strconv.Atoi(d.Name); err == nil {
+					entry["custom_field_id"] = id
+				}
+			}
+			history = append(history, entry)
+		}
+	}
+
+	return history
+}
+
+// describeDetail returns the field name and the old/new values with IDs mapped to names
+func (l historyLookups) describeDetail(d redmine.Detail) (string, string, string) {
+	switch d.Property {
+	case "attr":
+		name, ok := historyAttrFields[d.Name]
+		if !ok {
+			return d.Name, d.OldValue, d.NewValue
+		}
+		var table map[int]string
+		switch name {
+		case "status":
+			table = l.statuses
+		case "priority":
+			table = l.priorities
+		case "tracker":
+			table = l.trackers
+		case "assignee":
+			table = l.users
+		case "version":
+			table = l.versions
+		}
+		return name, lookupName(table, d.OldValue), lookupName(table, d.NewValue)
+	case "cf":
+		if id, err := strconv.Atoi(d.Name); err == nil {
+			if cfName, ok := l.customFields[id]; ok {
+				return cfName, d.OldValue, d.NewValue
+			}
+		}
+		return "cf_" + d.Name, d.OldValue, d.NewValue
+	case "attachment":
+		return "attachment", d.OldValue, d.NewValue
+	case "relation":
+		return "relation:" + d.Name, d.OldValue, d.NewValue
+	default:
+		return d.Name, d.OldValue, d.NewValue
+	}
+}
+
+// lookupName maps a numeric ID string to a name, returning the raw value when unknown
+func lookupName(table map[int]string, value string) string {
+	if value == "" || table == nil {
+		return value
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return value
+	}
+	if name, ok := table[id]; ok {
+		return name
+	}
+	return value
+}
+
+// normalizeHistoryField lowercases a field filter and maps aliases to field names
+func normalizeHistoryField(field string) string {
+	f := strings.ToLower(strings.TrimSpace(field))
+	if alias, ok := historyFieldAliases[f]; ok {
+		return alias
+	}
+	if name, ok := historyAttrFields[f]; ok {
+		return name
+	}
+	return f
+}
+
+// historyFieldMatches reports whether a detail matches the normalized field filter.
+// Custom fields match by name (case-insensitive), "cf_<id>" or bare ID.
+func historyFieldMatches(filter, name string, d redmine.Detail) bool {
+	if strings.ToLower(name) == filter {
+		return true
+	}
+	if d.Property == "attr" && strings.ToLower(d.Name) == filter {
+		return true
+	}
+	if d.Property == "cf" && (filter == d.Name || filter == "cf_"+d.Name) {
+		return true
+	}
+	return false
+}
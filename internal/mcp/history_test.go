@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func testHistoryLookups() historyLookups {
+	return historyLookups{
+		statuses:     map[int]string{1: "New", 2: "In Progress", 5: "Closed"},
+		priorities:   map[int]string{2: "Normal", 3: "High"},
+		trackers:     map[int]string{1: "Bug"},
+		users:        map[int]string{7: "Alice", 8: "Bob"},
+		versions:     map[int]string{10: "v1.0"},
+		customFields: map[int]string{23: "Component"},
+	}
+}
+
+func testHistoryJournals() []redmine.Journal {
+	return []redmine.Journal{
+		{
+			ID:        2,
+			User:      redmine.IDName{ID: 8, Name: "Bob"},
+			CreatedOn: "2025-01-03T09:00:00Z",
+			Details: []redmine.Detail{
+				{Property: "attr", Name: "status_id", OldValue: "2", NewValue: "5"},
+				{Property: "cf", Name: "23", OldValue: "HW", NewValue: "SW"},
+				{Property: "cf", Name: "99", OldValue: "", NewValue: "x"},
+			},
+		},
+		{
+			ID:        1,
+			User:      redmine.IDName{ID: 7, Name: "Alice"},
+			CreatedOn: "2025-01-02T08:00:00Z",
+			Details: []redmine.Detail{
+				{Property: "attr", Name: "status_id", OldValue: "1", NewValue: "2"},
+				{Property: "attr", Name: "assigned_to_id", OldValue: "", NewValue: "8"},
+				{Property: "attr", Name: "priority_id", OldValue: "2", NewValue: "3"},
+				{Property: "attr", Name: "done_ratio", OldValue: "0", NewValue: "30"},
+			},
+		},
+		{
+			ID:        3,
+			User:      redmine.IDName{ID: 7, Name: "Alice"},
+			Notes:     "Comment only",
+			CreatedOn: "2025-01-04T08:00:00Z",
+		},
+	}
+}
+
+func TestBuildIssueHistory(t *testing.T) {
+	t.Run("chronological with names mapped", func(t *testing.T) {
+		history := buildIssueHistory(testHistoryJournals(), testHistoryLookups(), "")
+		if len(history) != 7 {
+			t.Fatalf("expected 7 changes, got %d", len(history))
+		}
+
+		first := history[0]
+		if first["journal_id"] != 1 || first["user"] != "Alice" {
+			t.Errorf("expected first change from journal 1 by Alice, got %v", first)
+		}
+		if first["field"] != "status" || first["old_value"] != "New" || first["new_value"] != "In Progress" {
+			t.Errorf("unexpected status change: %v", first)
+		}
+		if history[1]["field"] != "assignee" || history[1]["old_value"] != "" || history[1]["new_value"] != "Bob" {
+			t.Errorf("unexpected assignee change: %v", history[1])
+		}
+		if history[2]["new_value"] != "High" {
+			t.Errorf("expected priority new_value='High', got %v", history[2]["new_value"])
+		}
+		if history[3]["field"] != "done_ratio" || history[3]["new_value"] != "30" {
+			t.Errorf("unexpected done_ratio change: %v", history[3])
+		}
+	})
+
+	t.Run("custom field detail uses field name", func(t *testing.T) {
+		history := buildIssueHistory(testHistoryJournals(), testHistoryLookups(), "")
+		cf := history[5]
+		if cf["property"] != "cf" {
+			t.Fatalf("expected property='cf', got %v", cf["property"])
+		}
+		if cf["field"] != "Component" {
+			t.Errorf("expected field='Component', got %v", cf["field"])
+		}
+		if cf["custom_field_id"] != 23 {
+			t.Errorf("expected custom_field_id=23, got %v", cf["custom_field_id"])
+		}
+		if cf["old_value"] != "HW" || cf["new_value"] != "SW" {
+			t.Errorf("unexpected custom field values: %v", cf)
+		}
+	})
+
+	t.Run("unknown custom field falls back to cf_ID", func(t *testing.T) {
+		history := buildIssueHistory(testHistoryJournals(), testHistoryLookups(), "")
+		if history[6]["field"] != "cf_99" {
+			t.Errorf("expected field='cf_99', got %v", history[6]["field"])
+		}
+	})
+
+	t.Run("unknown IDs are left as-is", func(t *testing.T) {
+		journals := []redmine.Journal{{
+			ID:      1,
+			Details: []redmine.Detail{{Property: "attr", Name: "status_id", OldValue: "1", NewValue: "42"}},
+		}}
+		history := buildIssueHistory(journals, testHistoryLookups(), "")
+		if history[0]["new_value"] != "42" {
+			t.Errorf("expected raw ID '42', got %v", history[0]["new_value"])
+		}
+	})
+
+	filterTests := []struct {
+		field string
+		want  int
+	}{
+		{"status", 2},
+		{"Status", 2},
+		{"status_id", 2},
+		{"assignee", 1},
+		{"assigned_to", 1},
+		{"priority", 1},
+		{"done_ratio", 1},
+		{"Component", 1},
+		{"component", 1},
+		{"cf_23", 1},
+		{"23", 1},
+		{"nonexistent", 0},
+	}
+	for _, tt := range filterTests {
+		t.Run("filter "+tt.field, func(t *testing.T) {
+			history := buildIssueHistory(testHistoryJournals(), testHistoryLookups(), tt.field)
+			if len(history) != tt.want {
+				t.Errorf("field %q: expected %d changes, got %d", tt.field, tt.want, len(history))
+			}
+		})
+	}
+}
+
+func TestHandleIssuesHistory(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/issues/42.json":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"issue": map[string]any{
+					"id":            42,
+					"subject":       "Audit me",
+					"project":       map[string]any{"id": 1, "name": "P"},
+					"custom_fields": []map[string]any{{"id": 23, "name": "Component", "value": "SW"}},
+					"journals": []map[string]any{{
+						"id":         1,
+						"user":       map[string]any{"id": 7, "name": "Alice"},
+						"created_on": "2025-01-02T08:00:00Z",
+						"details": []map[string]any{
+							{"property": "attr", "name": "status_id", "old_value": "1", "new_value": "2"},
+							{"property": "cf", "name": "23", "old_value": "HW", "new_value": "SW"},
+						},
+					}},
+				},
+			})
+		case "/issue_statuses.json":
+			_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":2,"name":"In Progress"}]}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"issue_id": float64(42), "field": "status"}
+
+	result, err := h.handleIssuesHistory(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &data); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if data["count"].(float64) != 1 {
+		t.Fatalf("expected count=1, got %v", data["count"])
+	}
+	change := data["history"].([]any)[0].(map[string]any)
+	if change["old_value"] != "New" || change["new_value"] != "In Progress" {
+		t.Errorf("expected status names, got %v", change)
+	}
+}
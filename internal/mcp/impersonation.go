@@ -0,0 +1,49 @@
+package mcp
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// impersonationFromEnv reports whether REDMINE_MCP_ALLOW_IMPERSONATION
+// enables as_user; it is off unless set to true
+func impersonationFromEnv() bool {
+	allowed := os.Getenv("REDMINE_MCP_ALLOW_IMPERSONATION") == "true"
+	if allowed {
+		slog.Info("impersonation enabled - write tools accept as_user")
+	}
+	return allowed
+}
+
+// asUserParam is the as_user parameter of the write tools that can act on
+// behalf of another user. It is left out when impersonation is disabled.
+func (h *ToolHandlers) asUserParam() mcp.ToolOption {
+	if !h.allowImpersonation {
+		return func(*mcp.Tool) {}
+	}
+	return mcp.WithString("as_user",
+		mcp.Description("Login or user ID to act as, e.g. to log time for the engineer who did the work. "+
+			"Needs an administrator API key; Redmine records the change as made by that user"),
+	)
+}
+
+// actingClient returns the client a write runs with: h.client, or with
+// as_user a client acting as that user, whose login is returned too
+func (h *ToolHandlers) actingClient(req mcp.CallToolRequest) (*redmine.Client, string, error) {
+	asUser := req.GetString("as_user", "")
+	if asUser == "" {
+		return h.client, "", nil
+	}
+	if !h.allowImpersonation {
+		return nil, "", i18n.Errorf("as_user is disabled; set REDMINE_MCP_ALLOW_IMPERSONATION=true to act as other users")
+	}
+	client, user, err := h.client.SwitchUser(asUser)
+	if err != nil {
+		return nil, "", i18n.Errorf("Failed to act as %s: %v", asUser, err)
+	}
+	return client, user.Login, nil
+}
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestAsUserParam(t *testing.T) {
+	for _, allowed := range []string{"", "true"} {
+		t.Setenv("REDMINE_MCP_ALLOW_IMPERSONATION", allowed)
+		h := NewToolHandlers(redmine.NewClient("http://redmine.invalid", ""), nil, nil)
+		rec := &schemaRecorder{}
+		h.RegisterTools(rec)
+
+		for _, tool := range rec.tools {
+			_, has := tool.InputSchema.Properties["as_user"]
+			want := allowed == "true" && (tool.Name == "issues_create" || tool.Name == "issues_update" || tool.Name == "timeEntries_create")
+			if has != want {
+				t.Errorf("allowed=%q: %s has as_user = %v, want %v", allowed, tool.Name, has, want)
+			}
+		}
+	}
+}
+
+func TestHandleTimeEntriesCreate_AsUser(t *testing.T) {
+	var switched []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		login := r.Header.Get(redmine.SwitchUserHeader)
+		if login != "" {
+			switched = append(switched, r.Method+" "+r.URL.Path)
+			if login != "jsmith" {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+		}
+		switch {
+		case r.URL.Path == "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":2,"login":"jsmith"}}`))
+		case r.URL.Path == "/time_entries.json" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"time_entry":{"id":1,"project":{"id":3,"name":"Operations"},"user":{"id":2,"name":"John Smith"},"hours":2}}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	create := func(h *ToolHandlers, asUser string) (*gomcp.CallToolResult, string) {
+		t.Helper()
+		switched = nil
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"issue_id": float64(42), "hours": float64(2), "as_user": asUser}
+		result, err := h.handleTimeEntriesCreate(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result, result.Content[0].(gomcp.TextContent).Text
+	}
+
+	// Disabled by default
+	t.Setenv("REDMINE_MCP_ALLOW_IMPERSONATION", "")
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	result, text := create(h, "jsmith")
+	if !result.IsError || !strings.Contains(text, "REDMINE_MCP_ALLOW_IMPERSONATION") {
+		t.Errorf("expected as_user to be disabled, got %s", text)
+	}
+	if len(switched) != 0 {
+		t.Errorf("expected no request as another user, got %v", switched)
+	}
+
+	t.Setenv("REDMINE_MCP_ALLOW_IMPERSONATION", "true")
+	h = NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	result, text = create(h, "jsmith")
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", text)
+	}
+	var out map[string]any
+	_ = json.Unmarshal([]byte(text), &out)
+	if out["as_user"] != "jsmith" {
+		t.Errorf("expected the result to name the acting user, got %v", out)
+	}
+	if strings.Join(switched, ", ") != "GET /users/current.json, POST /time_entries.json" {
+		t.Errorf("expected the switch to be confirmed and then used, got %v", switched)
+	}
+
+	// Redmine refuses unknown logins with 412
+	result, text = create(h, "nobody")
+	if !result.IsError || !strings.Contains(text, "412") || !strings.Contains(text, "administrator") {
+		t.Errorf("expected a clear 412 error, got %s", text)
+	}
+	if strings.Join(switched, ", ") != "GET /users/current.json" {
+		t.Errorf("expected no write after a refused switch, got %v", switched)
+	}
+}
@@ -0,0 +1,188 @@
+package mcp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// defaultMarkdownNotes is how many of the latest journal notes the Markdown
+// rendering of an issue shows
+const defaultMarkdownNotes = 5
+
+// IssueMarkdownOptions selects the optional parts of an issue's Markdown rendering
+type IssueMarkdownOptions struct {
+	Notes     int                     // latest journal notes shown; 0 shows none
+	SpentTime *redmine.IssueSpentTime // adds the hours logged by each user when set
+	Children  []redmine.IssueChild    // adds the subtasks when set
+}
+
+// RenderIssueMarkdown renders an issue as a compact Markdown document for
+// chat display: a linked title, a metadata table, the description, custom
+// fields, the latest notes and the attachments. Empty sections are left out.
+func RenderIssueMarkdown(issue redmine.Issue, links redmine.Links, opts IssueMarkdownOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# [%s #%d](%s): %s\n\n", issue.Tracker.Name, issue.ID, links.Issue(issue.ID), issue.Subject)
+
+	rows := [][2]string{
+		{i18n.T("Project"), issue.Project.Name},
+		{i18n.T("Status"), issue.Status.Name},
+		{i18n.T("Priority"), issue.Priority.Name},
+		{i18n.T("Assignee"), "-"},
+		{i18n.T("Author"), issue.Author.Name},
+	}
+	if issue.AssignedTo != nil {
+		rows[3][1] = issue.AssignedTo.Name
+	}
+	if issue.FixedVersion != nil {
+		rows = append(rows, [2]string{i18n.T("Version"), issue.FixedVersion.Name})
+	}
+	if issue.Category != nil {
+		rows = append(rows, [2]string{i18n.T("Category"), issue.Category.Name})
+	}
+	if issue.Parent != nil {
+		rows = append(rows, [2]string{i18n.T("Parent"), fmt.Sprintf("[#%d](%s)", issue.Parent.ID, links.Issue(issue.Parent.ID))})
+	}
+	if issue.StartDate != "" {
+		rows = append(rows, [2]string{i18n.T("Start date"), issue.StartDate})
+	}
+	if issue.DueDate != "" {
+		rows = append(rows, [2]string{i18n.T("Due date"), issue.DueDate})
+	}
+	rows = append(rows, [2]string{i18n.T("Done"), strconv.Itoa(issue.DoneRatio) + "%"})
+	if issue.EstimatedHours != nil {
+		rows = append(rows, [2]string{i18n.T("Estimated"), formatMarkdownHours(*issue.EstimatedHours)})
+	}
+	if opts.SpentTime != nil {
+		rows = append(rows, [2]string{i18n.T("Spent"), formatMarkdownHours(opts.SpentTime.TotalHours)})
+	} else if issue.SpentHours != nil {
+		rows = append(rows, [2]string{i18n.T("Spent"), formatMarkdownHours(*issue.SpentHours)})
+	}
+	rows = append(rows,
+		[2]string{i18n.T("Created"), markdownTime(issue.CreatedOn)},
+		[2]string{i18n.T("Updated"), markdownTime(issue.UpdatedOn)},
+	)
+	if issue.ClosedOn != "" {
+		rows = append(rows, [2]string{i18n.T("Closed"), markdownTime(issue.ClosedOn)})
+	}
+	fmt.Fprintf(&b, "| %s | %s |\n| --- | --- |\n", i18n.T("Field"), i18n.T("Value"))
+	for _, row := range rows {
+		fmt.Fprintf(&b, "| %s | %s |\n", row[0], markdownTableCell(row[1]))
+	}
+
+	if desc := strings.TrimSpace(issue.Description); desc != "" {
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n", i18n.T("Description"), desc)
+	}
+
+	var fields []string
+	for _, cf := range issue.CustomFields {
+		if value := customFieldText(cf.Value); value != "" {
+			fields = append(fields, fmt.Sprintf("- **%s**: %s", cf.Name, value))
+		}
+	}
+	if len(fields) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n", i18n.T("Custom fields"), strings.Join(fields, "\n"))
+	}
+
+	if len(opts.Children) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", i18n.T("Subtasks"))
+		for _, c := range opts.Children {
+			fmt.Fprintf(&b, "- [#%d](%s) %s (%s, %d%%", c.ID, links.Issue(c.ID), c.Subject, c.Status, c.DoneRatio)
+			if c.AssignedTo != "" {
+				b.WriteString(", " + c.AssignedTo)
+			}
+			b.WriteString(")\n")
+		}
+	}
+
+	var notes []redmine.Journal
+	for _, j := range issue.Journals {
+		if strings.TrimSpace(j.Notes) != "" {
+			notes = append(notes, j)
+		}
+	}
+	if opts.Notes > 0 && len(notes) > 0 {
+		shown := notes[max(len(notes)-opts.Notes, 0):]
+		if len(shown) < len(notes) {
+			fmt.Fprintf(&b, "\n## %s\n", i18n.Sprintf("Notes (latest %d of %d)", len(shown), len(notes)))
+		} else {
+			fmt.Fprintf(&b, "\n## %s\n", i18n.T("Notes"))
+		}
+		for _, j := range shown {
+			fmt.Fprintf(&b, "\n**%s**, %s", j.User.Name, markdownTime(j.CreatedOn))
+			if j.PrivateNotes {
+				b.WriteString(" (" + i18n.T("private") + ")")
+			}
+			b.WriteString("\n\n")
+			for _, line := range strings.Split(strings.TrimSpace(j.Notes), "\n") {
+				b.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+			}
+		}
+	}
+
+	if len(issue.Attachments) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", i18n.T("Attachments"))
+		for _, a := range issue.Attachments {
+			fmt.Fprintf(&b, "- [%s](%s) (%s", a.Filename, links.Attachment(a.ID, a.Filename), formatFileSize(a.Filesize))
+			if a.Author.Name != "" {
+				b.WriteString(", " + a.Author.Name)
+			}
+			b.WriteString(")")
+			if a.Description != "" {
+				b.WriteString(": " + a.Description)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// markdownTableCell keeps a value on one table row and escapes the cell separator
+func markdownTableCell(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "|", `\|`)
+}
+
+// markdownTime shortens a Redmine timestamp to "YYYY-MM-DD HH:MM"
+func markdownTime(timestamp string) string {
+	if len(timestamp) < len("2006-01-02T15:04") {
+		return timestamp
+	}
+	return strings.Replace(timestamp[:len("2006-01-02T15:04")], "T", " ", 1)
+}
+
+func formatMarkdownHours(hours float64) string {
+	return strconv.FormatFloat(hours, 'f', -1, 64) + "h"
+}
+
+// customFieldText joins multi-value custom fields with commas
+func customFieldText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if s := customFieldText(item); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return fmt.Sprint(value)
+}
+
+// formatFileSize formats a size in bytes as B, KB or MB
+func formatFileSize(size int) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%d B", size)
+}
@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// checkGolden compares got with testdata/name, rewriting the file with -update
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s (run with -update to accept):\n--- got\n%s\n--- want\n%s", path, got, want)
+	}
+}
+
+func TestRenderIssueMarkdown(t *testing.T) {
+	links := redmine.NewClient("https://redmine.example.com", "").Links()
+	estimated := 8.0
+	issue := redmine.Issue{
+		ID:           42,
+		Subject:      "Login fails | SSO",
+		Project:      redmine.IDName{ID: 1, Name: "Web"},
+		Tracker:      redmine.IDName{ID: 1, Name: "Bug"},
+		Status:       redmine.IDName{ID: 2, Name: "In Progress"},
+		Priority:     redmine.IDName{ID: 3, Name: "High"},
+		Author:       redmine.IDName{ID: 5, Name: "Alice"},
+		AssignedTo:   &redmine.IDName{ID: 6, Name: "Bob"},
+		FixedVersion: &redmine.IDName{ID: 10, Name: "1.0"},
+		Parent: &struct {
+			ID int `json:"id"`
+		}{ID: 40},
+		Description:    "Steps:\n1. Open /login\n2. Click *SSO*",
+		StartDate:      "2025-01-01",
+		DueDate:        "2025-01-31",
+		DoneRatio:      40,
+		EstimatedHours: &estimated,
+		CreatedOn:      "2024-12-01T10:00:00Z",
+		UpdatedOn:      "2025-01-15T14:30:00Z",
+		CustomFields: []redmine.CustomField{
+			{ID: 1, Name: "Severity", Value: "Major"},
+			{ID: 2, Name: "Browsers", Value: []any{"Firefox", "Safari"}},
+			{ID: 3, Name: "Empty", Value: ""},
+		},
+		Journals: []redmine.Journal{
+			{ID: 1, User: redmine.IDName{Name: "Alice"}, Notes: "First look", CreatedOn: "2025-01-02T09:00:00Z"},
+			{ID: 2, User: redmine.IDName{Name: "Bob"}, CreatedOn: "2025-01-03T09:00:00Z"},
+			{ID: 3, User: redmine.IDName{Name: "Bob"}, Notes: "Reproduced on staging.\n\nLogs attached.", CreatedOn: "2025-01-04T11:20:00Z"},
+			{ID: 4, User: redmine.IDName{Name: "Carol"}, Notes: "Customer escalated", PrivateNotes: true, CreatedOn: "2025-01-05T08:00:00Z"},
+		},
+		Attachments: []redmine.Attachment{
+			{ID: 200, Filename: "sso log.txt", Filesize: 2560, Author: redmine.IDName{Name: "Bob"}, Description: "Staging log"},
+		},
+	}
+	opts := IssueMarkdownOptions{
+		Notes:     2,
+		SpentTime: &redmine.IssueSpentTime{TotalHours: 3.5},
+		Children:  []redmine.IssueChild{{ID: 43, Subject: "Fix redirect", Status: "New", AssignedTo: "Bob"}},
+	}
+	checkGolden(t, "issue_full.md", RenderIssueMarkdown(issue, links, opts))
+
+	minimal := redmine.Issue{
+		ID:        7,
+		Subject:   "Minimal",
+		Project:   redmine.IDName{ID: 1, Name: "Web"},
+		Tracker:   redmine.IDName{ID: 2, Name: "Task"},
+		Status:    redmine.IDName{ID: 1, Name: "New"},
+		Priority:  redmine.IDName{ID: 2, Name: "Normal"},
+		Author:    redmine.IDName{ID: 5, Name: "Alice"},
+		CreatedOn: "2025-01-01T00:00:00Z",
+		UpdatedOn: "2025-01-01T00:00:00Z",
+	}
+	checkGolden(t, "issue_minimal.md", RenderIssueMarkdown(minimal, links, IssueMarkdownOptions{Notes: defaultMarkdownNotes}))
+}
+
+func TestRenderIssueMarkdown_Localized(t *testing.T) {
+	zh, err := i18n.NewPrinter("zh-TW")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i18n.SetDefault(zh)
+	t.Cleanup(func() { i18n.SetDefault(nil) })
+
+	issue := redmine.Issue{
+		ID:          7,
+		Subject:     "Login fails",
+		Tracker:     redmine.IDName{ID: 1, Name: "Bug"},
+		Status:      redmine.IDName{ID: 1, Name: "New"},
+		Description: "Steps",
+	}
+	md := RenderIssueMarkdown(issue, redmine.Links{}, IssueMarkdownOptions{})
+	// Headings are translated, Redmine data is not
+	for _, want := range []string{"| 欄位 | 值 |", "| 狀態 | New |", "## 描述"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected %q in:\n%s", want, md)
+		}
+	}
+}
+
+func TestHandleIssuesGetById_Markdown(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issue":{"id":42,"subject":"Login fails","tracker":{"id":1,"name":"Bug"},"status":{"id":1,"name":"New"},
+			"journals":[{"id":1,"user":{"id":1,"name":"Alice"},"notes":"Internal","private_notes":true,"created_on":"2025-01-02T09:00:00Z"}]}}`))
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"issue_id": float64(42), "format": "markdown", "include_private_notes": false}
+	result, err := h.handleIssuesGetById(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	text := result.Content[0].(gomcp.TextContent).Text
+	if !strings.HasPrefix(text, "# [Bug #42]("+mockServer.URL+"/issues/42): Login fails") {
+		t.Errorf("unexpected markdown title: %s", text)
+	}
+	if strings.Contains(text, "Internal") {
+		t.Errorf("expected private notes to stay hidden, got %s", text)
+	}
+
+	req.Params.Arguments = map[string]any{"issue_id": float64(42), "format": "html"}
+	if result, _ := h.handleIssuesGetById(context.Background(), req); !result.IsError {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -2,6 +2,9 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -70,5 +73,106 @@ func TestMembershipsHandlers(t *testing.T) {
 			t.Fatal("expected error result in read-only mode")
 		}
 	})
+}
+
+func TestHandleMembershipsList_User(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects/1/memberships.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"memberships":[
+			{"id":10,"project":{"id":1,"name":"Web"},"user":{"id":5,"name":"Alice Smith"},"roles":[{"id":3,"name":"Developer"},{"id":4,"name":"Reporter","inherited":true}]},
+			{"id":11,"project":{"id":1,"name":"Web"},"group":{"id":20,"name":"QA"},"roles":[{"id":4,"name":"Reporter"}]},
+			{"id":12,"project":{"id":1,"name":"Web"},"group":{"id":21,"name":"Ops"},"roles":[{"id":5,"name":"Manager"}]},
+			{"id":13,"project":{"id":1,"name":"Web"},"user":{"id":6,"name":"Bob Jones"},"roles":[{"id":3,"name":"Developer"}]}
+		]}`))
+	})
+	mux.HandleFunc("GET /users/5.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include") != "groups" {
+			t.Errorf("expected the user's groups to be included, got %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"user":{"id":5,"firstname":"Alice","lastname":"Smith","groups":[{"id":20,"name":"QA"}]}}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	h := NewToolHandlers(redmine.NewClient(ts.URL, "key"), nil, nil)
+	call := func(args map[string]any) map[string]any {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleMembershipsList(context.Background(), req)
+		if err != nil || result.IsError {
+			t.Fatalf("unexpected failure: %v %v", err, result.Content)
+		}
+		var out map[string]any
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &out); err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	all := call(map[string]any{"project": "1"})
+	roles := all["memberships"].([]any)[0].(map[string]any)["roles"].([]any)
+	if roles[1].(map[string]any)["inherited"] != true || roles[0].(map[string]any)["inherited"] != nil {
+		t.Errorf("expected only the group role to be marked inherited, got %v", roles)
+	}
+
+	out := call(map[string]any{"project": "1", "user": "5"})
+	var got struct {
+		User           map[string]any          `json:"user"`
+		EffectiveRoles []redmine.EffectiveRole `json:"effective_roles"`
+		Count          int                     `json:"count"`
+	}
+	data, _ := json.Marshal(out)
+	_ = json.Unmarshal(data, &got)
+	if got.User["name"] != "Alice Smith" || got.Count != 2 || len(got.EffectiveRoles) != 2 {
+		t.Fatalf("expected Alice's own and QA's memberships, got %s", data)
+	}
+	dev, reporter := got.EffectiveRoles[0], got.EffectiveRoles[1]
+	if dev.Name != "Developer" || !dev.Direct || dev.Inherited {
+		t.Errorf("expected Developer to be direct only, got %+v", dev)
+	}
+	if reporter.Name != "Reporter" || reporter.Direct || !reporter.Inherited ||
+		len(reporter.ViaGroups) != 1 || reporter.ViaGroups[0].Name != "QA" {
+		t.Errorf("expected Reporter to be inherited via QA, got %+v", reporter)
+	}
+}
+
+func TestHandleMembershipsList_UserOnLaterPage(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects/1/memberships.json", func(w http.ResponseWriter, r *http.Request) {
+		// Redmine pages memberships, so the user's may only come after the first page
+		if r.URL.Query().Get("offset") == "0" {
+			_, _ = w.Write([]byte(`{"memberships":[
+				{"id":13,"project":{"id":1,"name":"Web"},"user":{"id":6,"name":"Bob Jones"},"roles":[{"id":3,"name":"Developer"}]}
+			],"total_count":2,"offset":0,"limit":100}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"memberships":[
+			{"id":14,"project":{"id":1,"name":"Web"},"user":{"id":5,"name":"Alice Smith"},"roles":[{"id":5,"name":"Manager"}]}
+		],"total_count":2,"offset":1,"limit":100}`))
+	})
+	mux.HandleFunc("GET /users/5.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"user":{"id":5,"firstname":"Alice","lastname":"Smith"}}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
 
+	h := NewToolHandlers(redmine.NewClient(ts.URL, "key"), nil, nil)
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1", "user": "5"}
+	result, err := h.handleMembershipsList(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("unexpected failure: %v %v", err, result.Content)
+	}
+	var got struct {
+		EffectiveRoles []redmine.EffectiveRole `json:"effective_roles"`
+		Note           string                  `json:"note"`
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if err := json.Unmarshal([]byte(text), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.EffectiveRoles) != 1 || got.EffectiveRoles[0].Name != "Manager" || got.Note != "" {
+		t.Errorf("expected the Manager role from the second page, got %s", text)
+	}
 }
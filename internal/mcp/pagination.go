@@ -0,0 +1,26 @@
+package mcp
+
+// unknownTotal is the total of a list whose API doesn't report one
+const unknownTotal = -1
+
+// paginate adds the pagination fields every listing tool returns next to its
+// items: count, offset, limit, has_more and total_count when the API reports
+// it. Without a total, has_more assumes a full page means more may follow.
+func paginate(result map[string]any, count, offset, limit, total int) map[string]any {
+	result["count"] = count
+	result["offset"] = offset
+	result["limit"] = limit
+	if total == unknownTotal {
+		result["has_more"] = limit > 0 && count >= limit
+		return result
+	}
+	result["total_count"] = total
+	result["has_more"] = offset+count < total
+	return result
+}
+
+// wholeList paginates a list the API returns in full, such as a project's
+// versions or wiki pages
+func wholeList(result map[string]any, count int) map[string]any {
+	return paginate(result, count, 0, count, count)
+}
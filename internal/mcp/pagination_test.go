@@ -0,0 +1,100 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name                        string
+		count, offset, limit, total int
+		wantMore                    bool
+	}{
+		{"last page", 5, 20, 25, 25, false},
+		{"more after", 25, 0, 25, 40, true},
+		{"unknown total, full page", 25, 0, 25, unknownTotal, true},
+		{"unknown total, short page", 3, 0, 25, unknownTotal, false},
+	}
+	for _, tt := range tests {
+		result := paginate(map[string]any{"items": nil}, tt.count, tt.offset, tt.limit, tt.total)
+		if result["has_more"] != tt.wantMore || result["count"] != tt.count ||
+			result["offset"] != tt.offset || result["limit"] != tt.limit {
+			t.Errorf("%s: unexpected result %v", tt.name, result)
+		}
+		if _, ok := result["total_count"]; ok != (tt.total != unknownTotal) {
+			t.Errorf("%s: expected total_count only when known, got %v", tt.name, result["total_count"])
+		}
+	}
+}
+
+func TestListTools_Pagination(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects/1/memberships.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") != "0" || r.URL.Query().Get("limit") != "100" {
+			t.Errorf("expected offset 0 and Redmine's page size of 100, got %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"memberships":[
+			{"id":10,"project":{"id":1,"name":"Web"},"user":{"id":5,"name":"Alice Smith"},"roles":[{"id":3,"name":"Developer"}]},
+			{"id":11,"project":{"id":1,"name":"Web"},"group":{"id":20,"name":"QA"},"roles":[{"id":4,"name":"Reporter"}]}
+		],"total_count":150,"offset":0,"limit":100}`))
+	})
+	mux.HandleFunc("GET /projects/1/versions.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"versions":[{"id":1,"name":"1.0"},{"id":2,"name":"1.1"}]}`))
+	})
+	mux.HandleFunc("GET /time_entries.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") != "10" || r.URL.Query().Get("limit") != "2" {
+			t.Errorf("expected offset 10 and limit 2, got %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"time_entries":[
+			{"id":1,"project":{"id":1,"name":"Web"},"user":{"id":5,"name":"Alice"},"activity":{"id":9,"name":"Dev"},"hours":1,"spent_on":"2024-01-01"},
+			{"id":2,"project":{"id":1,"name":"Web"},"user":{"id":5,"name":"Alice"},"activity":{"id":9,"name":"Dev"},"hours":2,"spent_on":"2024-01-02"}
+		],"total_count":12,"offset":10,"limit":2}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	h := NewToolHandlers(redmine.NewClient(ts.URL, "key"), nil, nil)
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) map[string]any {
+		t.Helper()
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := handler(context.Background(), req)
+		if err != nil || result.IsError {
+			t.Fatalf("unexpected failure: %v %v", err, result.Content)
+		}
+		var out map[string]any
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &out); err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+	page := func(out map[string]any) [4]any {
+		return [4]any{out["count"], out["total_count"], out["offset"], out["has_more"]}
+	}
+
+	// Memberships report Redmine's total, beyond what one request returns
+	// and the page size Redmine actually uses, even when asked for more
+	out := call(h.handleMembershipsList, map[string]any{"project": "1", "limit": float64(1000)})
+	if got, want := page(out), [4]any{float64(2), float64(150), float64(0), true}; got != want || out["limit"] != float64(100) {
+		t.Errorf("memberships_list: got %v limit %v, want %v", got, out["limit"], want)
+	}
+
+	// Versions come back whole
+	out = call(h.handleVersionsList, map[string]any{"project": "1"})
+	if got, want := page(out), [4]any{float64(2), float64(2), float64(0), false}; got != want || len(out["versions"].([]any)) != 2 {
+		t.Errorf("versions_list: got %v, want %v", got, want)
+	}
+
+	// Time entries echo the page they were asked for
+	out = call(h.handleTimeEntriesList, map[string]any{"offset": float64(10), "limit": float64(2)})
+	if got, want := page(out), [4]any{float64(2), float64(12), float64(10), false}; got != want || out["limit"] != float64(2) {
+		t.Errorf("timeEntries_list: got %v limit %v, want %v", got, out["limit"], want)
+	}
+}
@@ -0,0 +1,50 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func (h *ToolHandlers) handlePermissionsCheck(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	project := req.GetString("project", "")
+	issueID := int(req.GetFloat("issue_id", 0))
+	if project == "" && issueID <= 0 {
+		return mcp.NewToolResultError(i18n.T("project or issue_id is required")), nil
+	}
+
+	projectID := 0
+	if project != "" {
+		var err error
+		projectID, err = h.resolver.ResolveProject(project)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+		}
+	}
+
+	report, err := redmine.CheckPermissions(h.client.WithContext(ctx), projectID, issueID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to check permissions: %v", err)), nil
+	}
+
+	result := map[string]any{
+		"user":         report.User,
+		"admin":        report.Admin,
+		"project":      report.Project,
+		"roles":        report.Roles,
+		"capabilities": report.Capabilities,
+	}
+	if report.Issue != nil {
+		result["issue"] = report.Issue
+		if projectID > 0 && projectID != report.Project.ID {
+			result["note"] = i18n.Sprintf("Issue #%d belongs to %s, so that project was checked", issueID, report.Project.Name)
+		}
+	}
+	if h.readOnly {
+		result["read_only"] = true
+		result["note"] = i18n.T("This server is in read-only mode, so its write tools are blocked whatever Redmine allows")
+	}
+	return jsonResult(result)
+}
@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestHandlePermissionsCheck(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/projects.json":
+			_, _ = w.Write([]byte(`{"projects":[{"id":1,"name":"Web","identifier":"web"},{"id":2,"name":"API","identifier":"api"}],"total_count":2}`))
+		case "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":7,"firstname":"Jane","lastname":"Doe","memberships":[{"id":1,"project":{"id":2,"name":"API"},"roles":[{"id":4,"name":"Developer"}]}]}}`))
+		case "/projects/2.json":
+			_, _ = w.Write([]byte(`{"project":{"id":2,"name":"API","status":1}}`))
+		case "/issues.json":
+			_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+		case "/issues/42.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":42,"subject":"Timeout","project":{"id":2,"name":"API"},"author":{"id":1}}}`))
+		case "/roles/4.json":
+			_, _ = w.Write([]byte(`{"role":{"id":4,"name":"Developer","permissions":["add_issues","edit_issues"]}}`))
+		case "/custom_fields.json":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	check := func(args map[string]any) (*gomcp.CallToolResult, string) {
+		t.Helper()
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handlePermissionsCheck(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result, result.Content[0].(gomcp.TextContent).Text
+	}
+
+	if result, text := check(map[string]any{}); !result.IsError || !strings.Contains(text, "project or issue_id") {
+		t.Errorf("expected project or issue_id to be required, got %s", text)
+	}
+
+	// The issue's project is checked even when another project is named
+	result, text := check(map[string]any{"project": "Web", "issue_id": float64(42)})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", text)
+	}
+	var out struct {
+		Project      redmine.IDName       `json:"project"`
+		Roles        []string             `json:"roles"`
+		Capabilities []redmine.Capability `json:"capabilities"`
+		Note         string               `json:"note"`
+	}
+	if err := json.Unmarshal([]byte(text), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Project.ID != 2 || !strings.Contains(out.Note, "API") {
+		t.Errorf("expected the issue's project with a note, got %+v", out)
+	}
+	states := map[string]string{}
+	for _, c := range out.Capabilities {
+		states[c.Name] = c.Confidence
+		if c.Allowed != nil && !*c.Allowed {
+			states[c.Name] = "denied " + c.Confidence
+		}
+	}
+	want := map[string]string{
+		"view_issue":           "confirmed",
+		"edit_issues":          "likely",
+		"log_time":             "denied likely",
+		"manage_custom_fields": "denied confirmed",
+	}
+	for name, state := range want {
+		if states[name] != state {
+			t.Errorf("%s = %q, want %q", name, states[name], state)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+)
+
+// writeTools lists the tools that change Redmine data. Their calls are audited
+// and their handlers call checkWrite with their own name; tools that only
+// write optionally are in optionalWriteTools instead and stay registered.
+var writeTools = map[string]bool{
+	"projects_create":             true,
+	"projects_update":             true,
+	"projects_setStatus":          true,
+	"issues_create":               true,
+	"issues_bulkCreate":           true,
+	"issues_createFromTemplate":   true,
+	"issues_update":               true,
+	"issues_createSubtask":        true,
+	"issues_addWatcher":           true,
+	"issues_addComment":           true,
+	"issues_removeWatcher":        true,
+	"issues_addRelation":          true,
+	"issues_removeRelation":       true,
+	"issues_batchUpdate":          true,
+	"issues_copy":                 true,
+	"issues_copyTree":             true,
+	"issues_importCSV":            true,
+	"timeEntries_create":          true,
+	"timeEntries_createBatch":     true,
+	"timeEntries_update":          true,
+	"timeEntries_delete":          true,
+	"attachments_upload":          true,
+	"attachments_delete":          true,
+	"attachments_update":          true,
+	"attachments_uploadAndAttach": true,
+	"versions_create":             true,
+	"versions_update":             true,
+	"categories_create":           true,
+	"categories_update":           true,
+	"categories_delete":           true,
+	"memberships_add":             true,
+	"memberships_update":          true,
+	"memberships_remove":          true,
+	"wiki_createOrUpdate":         true,
+	"wiki_uploadAndAttach":        true,
+	"wiki_delete":                 true,
+	"documents_create":            true,
+}
+
+// optionalWriteTools maps tools that write only when given an argument, like
+// timeEntries_report attaching its output, to that argument. Their handlers
+// call checkWrite when it is set, and those calls are audited.
+var optionalWriteTools = map[string]string{
+	"timeEntries_report":       "attach_to",
+	"reports_project_analysis": "attach_to",
+}
+
+// allowedWriteToolsFromEnv reads REDMINE_MCP_ALLOWED_WRITE_TOOLS once per process
+var allowedWriteToolsFromEnv = sync.OnceValue(func() map[string]bool {
+	allowed, unknown := parseAllowedWriteTools(os.Getenv("REDMINE_MCP_ALLOWED_WRITE_TOOLS"))
+	if len(unknown) > 0 {
+		slog.Warn("ignoring unknown tools in REDMINE_MCP_ALLOWED_WRITE_TOOLS", "tools", unknown)
+	}
+	if allowed != nil {
+		slog.Info("write tools restricted by allow-list", "allowed", sortedKeys(allowed))
+	}
+	return allowed
+})
+
+// parseAllowedWriteTools parses a comma-separated list of write tool names.
+// An empty value returns nil, meaning every write tool is allowed. Names that
+// aren't write tools are returned separately so they can be reported.
+func parseAllowedWriteTools(value string) (allowed map[string]bool, unknown []string) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	allowed = make(map[string]bool)
+	for name := range strings.SplitSeq(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, optional := optionalWriteTools[name]; !writeTools[name] && !optional {
+			unknown = append(unknown, name)
+			continue
+		}
+		allowed[name] = true
+	}
+	return allowed, unknown
+}
+
+// checkWrite returns an error if the write policy denies tool: read-only mode
+// blocks every write, and an allow-list blocks the write tools it doesn't name.
+func (h *ToolHandlers) checkWrite(tool string) error {
+	if err := h.checkReadOnly(); err != nil {
+		return err
+	}
+	if h.allowedWrites != nil && !h.allowedWrites[tool] {
+		allowed := "none"
+		if len(h.allowedWrites) > 0 {
+			allowed = strings.Join(sortedKeys(h.allowedWrites), ", ")
+		}
+		return i18n.Errorf("%s is not allowed by REDMINE_MCP_ALLOWED_WRITE_TOOLS - allowed write tools: %s", tool, allowed)
+	}
+	return nil
+}
+
+// policyServer registers tools on an McpServer, leaving out write tools the
+// allow-list denies so the model never sees them, and wrapping the remaining
+// write tools with audit. Read-only mode keeps them registered, since several
+// support dry runs.
+type policyServer struct {
+	McpServer
+	allowed map[string]bool
+	audit   func(tool string, next server.ToolHandlerFunc) server.ToolHandlerFunc
+}
+
+func (s policyServer) AddTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if arg, ok := optionalWriteTools[tool.Name]; ok && s.audit != nil {
+		read, write := handler, s.audit(tool.Name, handler)
+		handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if req.GetString(arg, "") != "" {
+				return write(ctx, req)
+			}
+			return read(ctx, req)
+		}
+	}
+	if !writeTools[tool.Name] {
+		s.McpServer.AddTool(tool, handler)
+		return
+	}
+	if s.allowed != nil && !s.allowed[tool.Name] {
+		return
+	}
+	if s.audit != nil {
+		handler = s.audit(tool.Name, handler)
+	}
+	s.McpServer.AddTool(tool, handler)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
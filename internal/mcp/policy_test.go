@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// toolRecorder collects the names of registered tools
+type toolRecorder struct {
+	names []string
+}
+
+func (r *toolRecorder) AddTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	r.names = append(r.names, tool.Name)
+}
+
+func registeredTools(h *ToolHandlers) []string {
+	rec := &toolRecorder{}
+	h.RegisterTools(rec)
+	return rec.names
+}
+
+func TestParseAllowedWriteTools(t *testing.T) {
+	if allowed, _ := parseAllowedWriteTools("  "); allowed != nil {
+		t.Errorf("expected nil allow-list for empty value, got %v", allowed)
+	}
+
+	allowed, unknown := parseAllowedWriteTools("timeEntries_create, issues_update,,issues_search,bogus")
+	if len(allowed) != 2 || !allowed["timeEntries_create"] || !allowed["issues_update"] {
+		t.Errorf("unexpected allow-list: %v", allowed)
+	}
+	if !slices.Equal(unknown, []string{"issues_search", "bogus"}) {
+		t.Errorf("expected read and unknown tools to be reported, got %v", unknown)
+	}
+}
+
+func TestWritePolicy_Default(t *testing.T) {
+	h := NewToolHandlers(&redmine.Client{}, nil, nil)
+	h.readOnly, h.allowedWrites = false, nil
+
+	names := registeredTools(h)
+	for tool := range writeTools {
+		if !slices.Contains(names, tool) {
+			t.Errorf("write tool %s is not registered", tool)
+		}
+		if err := h.checkWrite(tool); err != nil {
+			t.Errorf("expected %s to be allowed by default, got %v", tool, err)
+		}
+	}
+}
+
+func TestWritePolicy_ReadOnly(t *testing.T) {
+	h := NewToolHandlers(&redmine.Client{}, nil, nil)
+	h.readOnly, h.allowedWrites = true, map[string]bool{"issues_update": true}
+
+	err := h.checkWrite("issues_update")
+	if err == nil || !strings.Contains(err.Error(), "read-only mode") {
+		t.Errorf("expected read-only mode to win over the allow-list, got %v", err)
+	}
+	if names := registeredTools(h); !slices.Contains(names, "issues_update") {
+		t.Error("expected write tools to stay registered in read-only mode")
+	}
+}
+
+func TestWritePolicy_Selective(t *testing.T) {
+	h := NewToolHandlers(&redmine.Client{}, nil, nil)
+	h.readOnly = false
+	h.allowedWrites = map[string]bool{"timeEntries_create": true, "issues_update": true}
+
+	if err := h.checkWrite("timeEntries_create"); err != nil {
+		t.Errorf("expected timeEntries_create to be allowed, got %v", err)
+	}
+
+	names := registeredTools(h)
+	for _, want := range []string{"timeEntries_create", "issues_update", "issues_search", "timeEntries_report"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("expected %s to be registered", want)
+		}
+	}
+	for _, denied := range []string{"issues_create", "projects_update", "wiki_delete"} {
+		if slices.Contains(names, denied) {
+			t.Errorf("expected denied write tool %s to be left out", denied)
+		}
+	}
+
+	// Denied handlers still refuse when called directly, naming the policy
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"id": float64(1)}
+	result, err := h.handleTimeEntriesDelete(context.Background(), req)
+	if err != nil {
+		t.Fatalf("handler should not return error: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if !result.IsError || !strings.Contains(text, "REDMINE_MCP_ALLOWED_WRITE_TOOLS") || !strings.Contains(text, "issues_update, timeEntries_create") {
+		t.Errorf("expected allow-list error, got %q", text)
+	}
+}
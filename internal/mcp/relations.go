@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// maxRelationEnrichment caps how many related issues issues_listRelations looks up
+const maxRelationEnrichment = 25
+
+// relationLabels describes a relation as seen from its source issue, where
+// the label differs from the relation type
+var relationLabels = map[string]string{
+	"relates":   "relates to",
+	"copied_to": "copied to",
+}
+
+// inverseRelationLabels describes a relation as seen from its target issue
+var inverseRelationLabels = map[string]string{
+	"relates":    "relates to",
+	"duplicates": "duplicated by",
+	"blocks":     "blocked by",
+	"precedes":   "follows",
+	"copied_to":  "copied from",
+}
+
+// relationDirection returns the other issue of a relation and a direction
+// label ("blocks", "blocked by", ...) from the point of view of issueID
+func relationDirection(r redmine.Relation, issueID int) (int, string) {
+	if r.IssueID == issueID {
+		if label, ok := relationLabels[r.RelationType]; ok {
+			return r.IssueToID, label
+		}
+		return r.IssueToID, r.RelationType
+	}
+	if label, ok := inverseRelationLabels[r.RelationType]; ok {
+		return r.IssueID, label
+	}
+	return r.IssueID, r.RelationType + " (inverse)"
+}
+
+// formatRelations describes each relation of issueID, using related for the
+// subject and status of the other issue when it was looked up
+func formatRelations(relations []redmine.Relation, issueID int, related map[int]redmine.Issue) []map[string]any {
+	result := make([]map[string]any, len(relations))
+	for i, r := range relations {
+		otherID, direction := relationDirection(r, issueID)
+		entry := map[string]any{
+			"relation_id":   r.ID,
+			"relation_type": r.RelationType,
+			"direction":     direction,
+			"issue_id":      otherID,
+		}
+		if r.Delay != nil {
+			entry["delay"] = *r.Delay
+		}
+
+		summary := fmt.Sprintf("%s #%d", direction, otherID)
+		if other, ok := related[otherID]; ok {
+			entry["subject"] = other.Subject
+			entry["status"] = other.Status.Name
+			summary += fmt.Sprintf(" (%s)", other.Subject)
+		}
+		entry["summary"] = summary
+		result[i] = entry
+	}
+	return result
+}
@@ -0,0 +1,178 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestRelationDirection(t *testing.T) {
+	tests := []struct {
+		relation  redmine.Relation
+		wantOther int
+		wantLabel string
+	}{
+		{redmine.Relation{IssueID: 123, IssueToID: 456, RelationType: "blocks"}, 456, "blocks"},
+		{redmine.Relation{IssueID: 789, IssueToID: 123, RelationType: "blocks"}, 789, "blocked by"},
+		{redmine.Relation{IssueID: 123, IssueToID: 5, RelationType: "relates"}, 5, "relates to"},
+		{redmine.Relation{IssueID: 5, IssueToID: 123, RelationType: "precedes"}, 5, "follows"},
+		{redmine.Relation{IssueID: 5, IssueToID: 123, RelationType: "copied_to"}, 5, "copied from"},
+	}
+	for _, tt := range tests {
+		other, label := relationDirection(tt.relation, 123)
+		if other != tt.wantOther || label != tt.wantLabel {
+			t.Errorf("relationDirection(%+v) = %d %q, want %d %q", tt.relation, other, label, tt.wantOther, tt.wantLabel)
+		}
+	}
+}
+
+func TestHandleIssuesListRelations(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /issues/123/relations.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"relations":[
+			{"id":1,"issue_id":123,"issue_to_id":456,"relation_type":"blocks"},
+			{"id":2,"issue_id":789,"issue_to_id":123,"relation_type":"blocks"},
+			{"id":3,"issue_id":123,"issue_to_id":456,"relation_type":"precedes","delay":2}
+		]}`))
+	})
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("issue_id"); got != "456,789" {
+			t.Errorf("expected one lookup of the related issues, got issue_id=%q", got)
+		}
+		_, _ = w.Write([]byte(`{"issues":[
+			{"id":456,"subject":"Deploy to staging","status":{"id":1,"name":"New"}},
+			{"id":789,"subject":"Fix build","status":{"id":2,"name":"In Progress"}}
+		],"total_count":2}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"issue_id": float64(123)}
+	result, err := h.handleIssuesListRelations(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+
+	var out struct {
+		Relations []map[string]any `json:"relations"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+
+	wantSummaries := []string{
+		"blocks #456 (Deploy to staging)",
+		"blocked by #789 (Fix build)",
+		"precedes #456 (Deploy to staging)",
+	}
+	if len(out.Relations) != len(wantSummaries) {
+		t.Fatalf("expected %d relations, got %v", len(wantSummaries), out.Relations)
+	}
+	for i, want := range wantSummaries {
+		if out.Relations[i]["summary"] != want {
+			t.Errorf("relation %d: expected %q, got %v", i, want, out.Relations[i]["summary"])
+		}
+	}
+	if out.Relations[1]["relation_id"] != float64(2) || out.Relations[1]["status"] != "In Progress" {
+		t.Errorf("unexpected relation fields: %v", out.Relations[1])
+	}
+	if out.Relations[2]["delay"] != float64(2) {
+		t.Errorf("expected delay on precedes relation, got %v", out.Relations[2])
+	}
+}
+
+func TestHandleIssuesAddRelation_Delay(t *testing.T) {
+	var got map[string]map[string]any
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		rel := got["relation"]
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"relation": map[string]any{
+			"id": 5, "issue_id": 10, "issue_to_id": rel["issue_to_id"], "relation_type": rel["relation_type"], "delay": rel["delay"],
+		}})
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-key"), nil, nil)
+	add := func(args map[string]any) *gomcp.CallToolResult {
+		t.Helper()
+		got = nil
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleIssuesAddRelation(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	result := add(map[string]any{"issue_id": float64(10), "issue_to_id": float64(11), "relation_type": "precedes", "delay": float64(2)})
+	if result.IsError {
+		t.Fatalf("expected success, got %v", result.Content)
+	}
+	if got["relation"]["delay"] != float64(2) {
+		t.Errorf("expected the delay to be sent, got %v", got)
+	}
+	if !strings.Contains(result.Content[0].(gomcp.TextContent).Text, `"delay": 2`) {
+		t.Errorf("expected the delay in the result, got %s", result.Content[0].(gomcp.TextContent).Text)
+	}
+
+	// Without a delay none is sent
+	add(map[string]any{"issue_id": float64(10), "issue_to_id": float64(11), "relation_type": "blocks"})
+	if _, ok := got["relation"]["delay"]; ok {
+		t.Errorf("expected no delay, got %v", got)
+	}
+
+	result = add(map[string]any{"issue_id": float64(10), "issue_to_id": float64(11), "relation_type": "blocks", "delay": float64(0)})
+	if !result.IsError || got != nil {
+		t.Errorf("expected a delay on a blocks relation to be rejected before calling Redmine, got %v", result.Content)
+	}
+}
+
+func TestHandleIssuesAddRelation_Inverse(t *testing.T) {
+	var path string
+	var got map[string]map[string]any
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"relation":{"id":5,"issue_id":11,"issue_to_id":10,"relation_type":"blocks"}}`))
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-key"), nil, nil)
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"issue_id": float64(10), "issue_to_id": float64(11), "relation_type": "Blocked By"}
+	result, err := h.handleIssuesAddRelation(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if path != "/issues/11/relations.json" || got["relation"]["issue_to_id"] != float64(10) || got["relation"]["relation_type"] != "blocks" {
+		t.Errorf("expected 11 blocks 10 to be created, got %s %v", path, got)
+	}
+
+	var out map[string]any
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out["summary"] != "#11 blocks #10" || out["requested"] != "#10 Blocked By #11" || out["swapped"] != true {
+		t.Errorf("expected the direction to be explicit, got %v", out)
+	}
+
+	req.Params.Arguments = map[string]any{"issue_id": float64(10), "issue_to_id": float64(11), "relation_type": "parent_of"}
+	if result, _ := h.handleIssuesAddRelation(context.Background(), req); !result.IsError {
+		t.Error("expected an error for an unknown relation type")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
 	"github.com/ycho/redmine-mcp-server/internal/redmine"
 )
 
@@ -78,13 +79,13 @@ func (rg *ReportGenerator) GenerateProjectAnalysis(params ProjectAnalysisParams)
 	// Fetch all time entries for the project
 	allEntries, err := rg.fetchAllTimeEntries(params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch time entries: %w", err)
+		return nil, i18n.Errorf("failed to fetch time entries: %w", err)
 	}
 
 	// Fetch all issues for the project
 	allIssues, err := rg.fetchAllIssues(params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch issues: %w", err)
+		return nil, i18n.Errorf("failed to fetch issues: %w", err)
 	}
 
 	// Build issue map for quick lookup
@@ -152,7 +153,7 @@ func (rg *ReportGenerator) fetchAllIssues(params ProjectAnalysisParams) ([]redmi
 	if params.Version != "" {
 		versionID, err := rg.resolver.ResolveVersion(params.Version, params.ProjectID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to resolve version: %w", err)
+			return nil, i18n.Errorf("failed to resolve version: %w", err)
 		}
 		issueParams.VersionID = strconv.Itoa(versionID)
 	}
@@ -614,9 +615,9 @@ func (rg *ReportGenerator) AttachResult(content []byte, filename string, params
 	}
 
 	// Standard Redmine upload for other targets
-	token, err := rg.client.UploadFile(filename, bytes.NewReader(content))
+	token, err := rg.client.UploadFile(filename, "", bytes.NewReader(content))
 	if err != nil {
-		return "", fmt.Errorf("failed to upload file: %w", err)
+		return "", i18n.Errorf("failed to upload file: %w", err)
 	}
 
 	// Parse attach_to
@@ -626,7 +627,7 @@ func (rg *ReportGenerator) AttachResult(content []byte, filename string, params
 	case strings.HasPrefix(attachTo, "issue:"):
 		return rg.attachToIssue(token, filename, params, attachTo)
 	default:
-		return "", fmt.Errorf("invalid attach_to value: %s (valid: dmsf, dmsf:FolderID, files, files:VersionName, issue:ID)", attachTo)
+		return "", i18n.Errorf("invalid attach_to value: %s (valid: dmsf, dmsf:FolderID, files, files:VersionName, issue:ID)", attachTo)
 	}
 }
 
@@ -643,14 +644,14 @@ func (rg *ReportGenerator) attachToFiles(token *redmine.UploadToken, filename st
 		versionName := strings.TrimPrefix(attachTo, "files:")
 		versionID, err := rg.resolver.ResolveVersion(versionName, params.ProjectID)
 		if err != nil {
-			return "", fmt.Errorf("failed to resolve version '%s': %w", versionName, err)
+			return "", i18n.Errorf("failed to resolve version '%s': %w", versionName, err)
 		}
 		fileParams.VersionID = versionID
 	}
 
 	file, err := rg.client.CreateProjectFile(fileParams)
 	if err != nil {
-		return "", fmt.Errorf("failed to create project file: %w", err)
+		return "", i18n.Errorf("failed to create project file: %w", err)
 	}
 
 	return file.ContentURL, nil
@@ -660,7 +661,7 @@ func (rg *ReportGenerator) attachToIssue(token *redmine.UploadToken, filename st
 	issueIDStr := strings.TrimPrefix(attachTo, "issue:")
 	issueID, err := strconv.Atoi(issueIDStr)
 	if err != nil {
-		return "", fmt.Errorf("invalid issue ID: %s", issueIDStr)
+		return "", i18n.Errorf("invalid issue ID: %s", issueIDStr)
 	}
 
 	// Update issue with attachment
@@ -671,7 +672,7 @@ func (rg *ReportGenerator) attachToIssue(token *redmine.UploadToken, filename st
 	}
 
 	if err := rg.client.UpdateIssue(updateParams); err != nil {
-		return "", fmt.Errorf("failed to attach to issue: %w", err)
+		return "", i18n.Errorf("failed to attach to issue: %w", err)
 	}
 
 	return fmt.Sprintf("/issues/%d", issueID), nil
@@ -685,7 +686,7 @@ func (rg *ReportGenerator) attachToDMSF(content []byte, filename string, params
 		var err error
 		folderID, err = strconv.Atoi(folderIDStr)
 		if err != nil {
-			return "", fmt.Errorf("invalid DMSF folder ID: %s", folderIDStr)
+			return "", i18n.Errorf("invalid DMSF folder ID: %s", folderIDStr)
 		}
 	}
 
@@ -702,7 +703,7 @@ func (rg *ReportGenerator) attachToDMSF(content []byte, filename string, params
 
 	file, err := rg.client.DMSFCreateFile(dmsfParams)
 	if err != nil {
-		return "", fmt.Errorf("failed to create DMSF file: %w", err)
+		return "", i18n.Errorf("failed to create DMSF file: %w", err)
 	}
 
 	// Return the full DMSF file download URL
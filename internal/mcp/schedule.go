@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// scheduleRelationTypes are the relation types that constrain scheduling
+var scheduleRelationTypes = []string{"precedes", "blocks"}
+
+// scheduleRelations returns the outgoing precedes/blocks relations of an issue.
+// Redmine stores follows/blocked_by as the reverse precedes/blocks relation,
+// so every scheduling dependency appears exactly once on its source issue.
+func scheduleRelations(issue redmine.Issue) []map[string]any {
+	relations := []map[string]any{}
+	for _, r := range issue.Relations {
+		if r.IssueID != issue.ID || !slices.Contains(scheduleRelationTypes, r.RelationType) {
+			continue
+		}
+		relation := map[string]any{
+			"type":        r.RelationType,
+			"issue_to_id": r.IssueToID,
+		}
+		if r.RelationType == "precedes" {
+			delay := 0
+			if r.Delay != nil {
+				delay = *r.Delay
+			}
+			relation["delay"] = delay
+		}
+		relations = append(relations, relation)
+	}
+	return relations
+}
+
+// formatScheduleIssue returns the Gantt fields of an issue
+func formatScheduleIssue(issue redmine.Issue) map[string]any {
+	result := map[string]any{
+		"id":         issue.ID,
+		"subject":    issue.Subject,
+		"start_date": issue.StartDate,
+		"due_date":   issue.DueDate,
+		"done_ratio": issue.DoneRatio,
+		"relations":  scheduleRelations(issue),
+	}
+	if issue.AssignedTo != nil {
+		result["assigned_to"] = issue.AssignedTo.Name
+	}
+	if issue.Parent != nil {
+		result["parent_id"] = issue.Parent.ID
+	}
+	return result
+}
+
+// scheduleCSV renders schedule rows as CSV. Relations are listed per type as
+// semicolon-separated target IDs, with a non-zero precedes delay appended as "+Nd".
+func scheduleCSV(issues []redmine.Issue) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write([]string{"ID", "Subject", "Start Date", "Due Date", "Done Ratio", "Assignee", "Parent ID", "Precedes", "Blocks"})
+
+	for _, issue := range issues {
+		var assignee, parentID string
+		if issue.AssignedTo != nil {
+			assignee = issue.AssignedTo.Name
+		}
+		if issue.Parent != nil {
+			parentID = strconv.Itoa(issue.Parent.ID)
+		}
+
+		var precedes, blocks []string
+		for _, r := range scheduleRelations(issue) {
+			target := strconv.Itoa(r["issue_to_id"].(int))
+			if r["type"] == "blocks" {
+				blocks = append(blocks, target)
+				continue
+			}
+			if delay := r["delay"].(int); delay != 0 {
+				target += fmt.Sprintf("%+dd", delay)
+			}
+			precedes = append(precedes, target)
+		}
+
+		_ = writer.Write([]string{
+			strconv.Itoa(issue.ID),
+			issue.Subject,
+			issue.StartDate,
+			issue.DueDate,
+			strconv.Itoa(issue.DoneRatio),
+			assignee,
+			parentID,
+			strings.Join(precedes, ";"),
+			strings.Join(blocks, ";"),
+		})
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
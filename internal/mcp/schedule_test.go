@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestScheduleRelations(t *testing.T) {
+	delay := 2
+	issue := redmine.Issue{
+		ID: 10,
+		Relations: []redmine.Relation{
+			{ID: 1, IssueID: 10, IssueToID: 11, RelationType: "precedes", Delay: &delay},
+			{ID: 2, IssueID: 10, IssueToID: 12, RelationType: "blocks"},
+			{ID: 3, IssueID: 10, IssueToID: 13, RelationType: "relates"},
+			{ID: 4, IssueID: 9, IssueToID: 10, RelationType: "precedes"},
+		},
+	}
+
+	relations := scheduleRelations(issue)
+	if len(relations) != 2 {
+		t.Fatalf("expected only outgoing precedes/blocks relations, got %v", relations)
+	}
+	if relations[0]["issue_to_id"] != 11 || relations[0]["delay"] != 2 {
+		t.Errorf("unexpected precedes relation: %v", relations[0])
+	}
+	if _, ok := relations[1]["delay"]; ok || relations[1]["type"] != "blocks" {
+		t.Errorf("unexpected blocks relation: %v", relations[1])
+	}
+}
+
+func TestScheduleCSV(t *testing.T) {
+	delay := 2
+	issues := []redmine.Issue{
+		{
+			ID: 10, Subject: "Design", StartDate: "2024-03-01", DueDate: "2024-03-05", DoneRatio: 50,
+			AssignedTo: &redmine.IDName{ID: 7, Name: "Alice"},
+			Relations: []redmine.Relation{
+				{IssueID: 10, IssueToID: 11, RelationType: "precedes", Delay: &delay},
+				{IssueID: 10, IssueToID: 12, RelationType: "precedes"},
+				{IssueID: 10, IssueToID: 13, RelationType: "blocks"},
+			},
+		},
+		{ID: 11, Subject: "Build, test", Parent: &struct {
+			ID int `json:"id"`
+		}{ID: 10}},
+	}
+
+	data, err := scheduleCSV(issues)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got %q", string(data))
+	}
+	if lines[1] != "10,Design,2024-03-01,2024-03-05,50,Alice,,11+2d;12,13" {
+		t.Errorf("unexpected first row: %q", lines[1])
+	}
+	if lines[2] != `11,"Build, test",,,0,,10,,` {
+		t.Errorf("unexpected second row: %q", lines[2])
+	}
+}
+
+func TestHandleIssuesSchedule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		if r.URL.Path != "/issues.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if q.Get("include") != "relations" || q.Get("project_id") != "1" || q.Get("status_id") != "open" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"issues":[
+			{"id":10,"subject":"Design","start_date":"2024-03-01","due_date":"2024-03-05","done_ratio":50,
+			 "relations":[{"id":1,"issue_id":10,"issue_to_id":11,"relation_type":"precedes","delay":1}]},
+			{"id":11,"subject":"Build","parent":{"id":10},
+			 "relations":[{"id":1,"issue_id":10,"issue_to_id":11,"relation_type":"precedes","delay":1}]}
+		],"total_count":2}`))
+	}))
+	defer server.Close()
+
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1"}
+	result, err := h.handleIssuesSchedule(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+
+	var out struct {
+		Issues []struct {
+			ID        int              `json:"id"`
+			ParentID  int              `json:"parent_id"`
+			Relations []map[string]any `json:"relations"`
+		} `json:"issues"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if len(out.Issues) != 2 || len(out.Issues[0].Relations) != 1 || len(out.Issues[1].Relations) != 0 {
+		t.Errorf("expected relation only on its source issue, got %+v", out.Issues)
+	}
+	if out.Issues[1].ParentID != 10 {
+		t.Errorf("expected parent_id 10, got %d", out.Issues[1].ParentID)
+	}
+
+	req.Params.Arguments = map[string]any{"project": "1", "format": "csv"}
+	result, err = h.handleIssuesSchedule(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected CSV success, got %v %v", err, result.Content)
+	}
+	if text := result.Content[0].(gomcp.TextContent).Text; !strings.Contains(text, "10,Design,2024-03-01,2024-03-05,50,,,11+1d,") {
+		t.Errorf("unexpected CSV output: %q", text)
+	}
+}
@@ -1,15 +1,21 @@
 package mcp
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/ycho/redmine-mcp-server/internal/health"
 	"github.com/ycho/redmine-mcp-server/internal/redmine"
 )
 
@@ -20,14 +26,33 @@ const (
 
 // Config holds MCP server configuration
 type Config struct {
-	RedmineURL           string
-	RedmineAPIKey        string
-	Port                 int
-	SSEMode              bool
-	CustomFieldRulesFile string
-	WorkflowRulesFile    string
+	RedmineURL            string
+	RedmineAPIKey         string
+	RedmineUsername       string // Basic auth instead of RedmineAPIKey, for instances with REST API keys disabled
+	RedminePassword       string
+	Port                  int
+	SSEMode               bool
+	CustomFieldRulesFile  string
+	WorkflowRulesFile     string
+	WorkflowSource        redmine.WorkflowSource
+	RateLimit             float64 // Outbound requests per second to Redmine; 0 uses REDMINE_RATE_LIMIT
+	RateBurst             int
+	ShutdownTimeout       time.Duration // HTTP mode grace period for in-flight tool calls on shutdown; 0 uses 30s
+	MaxAttachmentMB       int           // Per-file upload limit; 0 uses redmine.DefaultMaxAttachmentMB
+	CustomFieldHintLength int           // Max custom_fields description length; 0 uses DefaultCustomFieldHintLength
+	ToolDefaultsFile      string        // YAML file of per-tool defaults for omitted arguments
+	TemplatesFile         string        // YAML or JSON file of issue templates
+	SkipSelfTest          bool          // Start without checking that Redmine answers and accepts the API key
+	HTTPClient            *http.Client  // Shared by all Redmine clients and readiness probes; nil uses the defaults
 }
 
+// defaultShutdownTimeout is the shutdown grace period when none is configured
+const defaultShutdownTimeout = 30 * time.Second
+
+// streamFlushDelay gives responses of drained tool calls time to be written
+// to their SSE streams before the streams are closed
+const streamFlushDelay = 500 * time.Millisecond
+
 // Server wraps the MCP server
 type Server struct {
 	config  Config
@@ -42,24 +67,27 @@ func NewServer(config Config) *Server {
 	}
 }
 
-// Run starts the MCP server
-func (s *Server) Run() error {
-	// Create MCP server
+// Run starts the MCP server. In HTTP mode it serves until ctx is cancelled
+// and then shuts down gracefully; stdio mode ends when stdin closes.
+func (s *Server) Run(ctx context.Context) error {
+	if s.config.SSEMode {
+		return s.runSSE(ctx)
+	}
+
+	// Stdio mode - use env var for API key
+	factory := s.newServerFactory()
+	s.handler = factory.newHandlers(s.config.RedmineAPIKey, s.basicAuth()...)
+	if !s.config.SkipSelfTest {
+		info, err := redmine.SelfTest(ctx, s.handler.client)
+		redmine.LogSelfTest(info, err)
+		s.handler.setServerInfo(info)
+	}
 	s.mcp = server.NewMCPServer(
 		ServerName,
 		ServerVersion,
 		server.WithToolCapabilities(false),
+		server.WithToolFilter(s.handler.refreshCustomFieldHints),
 	)
-
-	if s.config.SSEMode {
-		return s.runSSE()
-	}
-
-	// Stdio mode - use env var for API key
-	client := redmine.NewClient(s.config.RedmineURL, s.config.RedmineAPIKey)
-	rules := s.loadCustomFieldRules()
-	workflow := s.loadWorkflowRules()
-	s.handler = NewToolHandlers(client, rules, workflow)
 	s.handler.RegisterTools(s.mcp)
 
 	slog.Info("Starting MCP server in stdio mode",
@@ -69,6 +97,15 @@ func (s *Server) Run() error {
 	return server.ServeStdio(s.mcp)
 }
 
+// basicAuth returns the client options of the server's basic auth
+// credentials, if configured
+func (s *Server) basicAuth() []redmine.ClientOption {
+	if s.config.RedmineUsername == "" {
+		return nil
+	}
+	return []redmine.ClientOption{redmine.WithBasicAuth(s.config.RedmineUsername, s.config.RedminePassword)}
+}
+
 // loadCustomFieldRules loads custom field validation rules from the configured file
 func (s *Server) loadCustomFieldRules() *redmine.CustomFieldRules {
 	if s.config.CustomFieldRulesFile == "" {
@@ -101,8 +138,39 @@ func (s *Server) loadWorkflowRules() *redmine.WorkflowRules {
 	return rules
 }
 
-// runSSE starts the server in HTTP mode with both SSE and Streamable HTTP transports
-func (s *Server) runSSE() error {
+// loadToolDefaults loads per-tool argument defaults from the configured file
+func (s *Server) loadToolDefaults() ToolDefaults {
+	if s.config.ToolDefaultsFile == "" {
+		return nil
+	}
+	defaults, err := LoadToolDefaults(s.config.ToolDefaultsFile)
+	if err != nil {
+		slog.Warn("Failed to load tool defaults", "file", s.config.ToolDefaultsFile, "error", err)
+		return nil
+	}
+	slog.Info("Loaded tool defaults", "file", s.config.ToolDefaultsFile, "tools", len(defaults))
+	return defaults
+}
+
+// loadIssueTemplates loads issue templates from the configured file
+func (s *Server) loadIssueTemplates() redmine.IssueTemplates {
+	if s.config.TemplatesFile == "" {
+		return nil
+	}
+	templates, err := redmine.LoadIssueTemplates(s.config.TemplatesFile)
+	if err != nil {
+		slog.Warn("Failed to load issue templates", "file", s.config.TemplatesFile, "error", err)
+		return nil
+	}
+	slog.Info("Loaded issue templates", "file", s.config.TemplatesFile, "templates", len(templates))
+	return templates
+}
+
+// runSSE starts the server in HTTP mode with both SSE and Streamable HTTP
+// transports. When ctx is cancelled readiness fails, new connections are
+// refused and running tool calls get the shutdown timeout to finish before
+// the remaining SSE and streaming connections are closed.
+func (s *Server) runSSE(ctx context.Context) error {
 	addr := fmt.Sprintf(":%d", s.config.Port)
 
 	slog.Info("Starting MCP server in HTTP mode (SSE + Streamable HTTP)",
@@ -110,18 +178,28 @@ func (s *Server) runSSE() error {
 		"redmine_url", s.config.RedmineURL,
 	)
 
-	rules := s.loadCustomFieldRules()
-	workflow := s.loadWorkflowRules()
+	factory := s.newServerFactory()
+
+	// Sessions bring their own keys; the server key, if any, is what can be checked
+	if !s.config.SkipSelfTest {
+		redmine.LogSelfTest(redmine.SelfTest(ctx, factory.newClient(s.config.RedmineAPIKey, s.basicAuth()...)))
+	}
 
 	// SSE transport: /sse, /message
-	sseMgr := newSessionManager(s.config.RedmineURL, rules, workflow)
+	sseMgr := newSessionManager(factory)
 
 	// Streamable HTTP transport: /mcp
-	streamableMgr := newStreamableSessionManager(s.config.RedmineURL, rules, workflow)
+	streamableMgr := newStreamableSessionManager(factory)
 
 	// Rate limiter: 100 requests per minute per IP
 	rateLimiter := newSimpleRateLimiter(100, time.Minute)
 
+	checker := health.NewChecker(s.config.RedmineURL, s.config.RedmineAPIKey)
+	checker.Username, checker.Password = s.config.RedmineUsername, s.config.RedminePassword
+	if s.config.HTTPClient != nil {
+		checker.Client = s.config.HTTPClient
+	}
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/sse", sseMgr.handleSSE)
 	mux.HandleFunc("/message", sseMgr.handleMessage)
@@ -130,28 +208,184 @@ func (s *Server) runSSE() error {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
 	})
+	mux.HandleFunc("GET /healthz", checker.Live)
+	mux.HandleFunc("GET /readyz", checker.Ready)
 
 	// Apply middleware chain
 	handler := securityHeadersMiddleware(rateLimiter.middleware(mux))
 
-	return http.ListenAndServe(addr, handler)
+	// Request contexts derive from streams, so cancelling it ends the
+	// long-lived SSE and streaming connections that Shutdown would wait on
+	streams, closeStreams := context.WithCancel(context.Background())
+	defer closeStreams()
+	srv := &http.Server{
+		Addr:        addr,
+		Handler:     handler,
+		BaseContext: func(net.Listener) context.Context { return streams },
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	timeout := s.config.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	slog.Info("Shutting down MCP server", "grace_period", timeout, "tool_calls_in_flight", factory.inFlight.Load())
+	checker.SetDraining()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- srv.Shutdown(shutdownCtx) }()
+
+	if !factory.waitIdle(shutdownCtx) {
+		slog.Warn("Shutdown grace period expired with tool calls still running", "tool_calls_in_flight", factory.inFlight.Load())
+	} else {
+		select {
+		case <-time.After(streamFlushDelay):
+		case <-shutdownCtx.Done():
+		}
+	}
+	closeStreams()
+
+	if err := <-shutdownErr; err != nil {
+		_ = srv.Close()
+		return fmt.Errorf("graceful shutdown did not finish within %s: %w", timeout, err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	slog.Info("MCP server stopped")
+	return nil
 }
 
-// sessionManager manages SSE sessions for multi-tenant access
-type sessionManager struct {
-	mu         sync.RWMutex
-	servers    map[string]*server.SSEServer // API key -> SSE server
+// serverFactory builds per-credential MCP servers. Each API key gets its own
+// redmine.Client and ToolHandlers so actions are attributed to the caller.
+type serverFactory struct {
 	redmineURL string
 	rules      *redmine.CustomFieldRules
 	workflow   *redmine.WorkflowRules
+	wfSource   redmine.WorkflowSource
+	limiter    *redmine.RateLimiter   // shared by all sessions; nil keeps the client default
+	httpClient *http.Client           // shared by all sessions; nil keeps the client default
+	maxUpload  int64                  // per-file upload limit in bytes; 0 keeps the handler default
+	hintLength int                    // custom_fields description cap; 0 keeps the handler default
+	defaults   ToolDefaults           // per-tool defaults of omitted arguments
+	templates  redmine.IssueTemplates // issue templates of issues_createFromTemplate
+	inFlight   atomic.Int64           // running tool calls across all sessions
+}
+
+func (s *Server) newServerFactory() *serverFactory {
+	f := &serverFactory{
+		redmineURL: s.config.RedmineURL,
+		rules:      s.loadCustomFieldRules(),
+		workflow:   s.loadWorkflowRules(),
+		wfSource:   s.config.WorkflowSource,
+		maxUpload:  int64(s.config.MaxAttachmentMB) * 1024 * 1024,
+		hintLength: s.config.CustomFieldHintLength,
+		defaults:   s.loadToolDefaults(),
+		templates:  s.loadIssueTemplates(),
+		httpClient: s.config.HTTPClient,
+	}
+	if s.config.RateLimit > 0 {
+		f.limiter = redmine.NewRateLimiter(s.config.RateLimit, s.config.RateBurst)
+	}
+	return f
+}
+
+// newClient creates a Redmine client for apiKey sharing the server's rate
+// limiter and HTTP client
+func (f *serverFactory) newClient(apiKey string, opts ...redmine.ClientOption) *redmine.Client {
+	client := redmine.NewClient(f.redmineURL, apiKey, append([]redmine.ClientOption{redmine.WithHTTPClient(f.httpClient)}, opts...)...)
+	if f.limiter != nil {
+		client.SetRateLimiter(f.limiter)
+	}
+	return client
+}
+
+// newHandlers creates tool handlers backed by a client for apiKey
+func (f *serverFactory) newHandlers(apiKey string, opts ...redmine.ClientOption) *ToolHandlers {
+	handler := NewToolHandlers(f.newClient(apiKey, opts...), f.rules, f.workflow)
+	handler.workflowSource = f.wfSource
+	handler.defaults = f.defaults
+	handler.templates = f.templates
+	if f.maxUpload > 0 {
+		handler.maxAttachmentSize = f.maxUpload
+	}
+	if f.hintLength > 0 {
+		handler.customFieldHintLength = f.hintLength
+	}
+	return handler
+}
+
+// newMCPServer creates an MCP server with all tools registered for apiKey.
+// Without an API key every tool call fails with a credentials error instead
+// of reaching Redmine.
+func (f *serverFactory) newMCPServer(apiKey string) *server.MCPServer {
+	handlers := f.newHandlers(apiKey)
+	opts := []server.ServerOption{
+		server.WithToolCapabilities(false),
+		server.WithToolHandlerMiddleware(f.trackInFlight),
+		server.WithToolFilter(handlers.refreshCustomFieldHints),
+	}
+	if apiKey == "" {
+		opts = append(opts, server.WithToolHandlerMiddleware(requireCredentials))
+	}
+
+	mcpServer := server.NewMCPServer(ServerName, ServerVersion, opts...)
+	handlers.RegisterTools(mcpServer)
+	return mcpServer
+}
+
+// trackInFlight counts running tool calls so shutdown can wait for them
+func (f *serverFactory) trackInFlight(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		f.inFlight.Add(1)
+		defer f.inFlight.Add(-1)
+		return next(ctx, req)
+	}
+}
+
+// waitIdle waits until no tool calls are running; false means ctx ended first
+func (f *serverFactory) waitIdle(ctx context.Context) bool {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for f.inFlight.Load() > 0 {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+	return true
+}
+
+// requireCredentials rejects tool calls from sessions that connected without an API key
+func requireCredentials(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultError(missingKeyMessage), nil
+	}
+}
+
+const missingKeyMessage = "Redmine credentials required: connect with an X-Redmine-API-Key header, Authorization: Bearer token, or api_key query parameter"
+
+// sessionManager manages SSE sessions for multi-tenant access
+type sessionManager struct {
+	mu      sync.RWMutex
+	servers map[string]*server.SSEServer // API key -> SSE server
+	factory *serverFactory
 }
 
-func newSessionManager(redmineURL string, rules *redmine.CustomFieldRules, workflow *redmine.WorkflowRules) *sessionManager {
+func newSessionManager(factory *serverFactory) *sessionManager {
 	return &sessionManager{
-		servers:    make(map[string]*server.SSEServer),
-		redmineURL: redmineURL,
-		rules:      rules,
-		workflow:   workflow,
+		servers: make(map[string]*server.SSEServer),
+		factory: factory,
 	}
 }
 
@@ -171,37 +405,21 @@ func (m *sessionManager) getOrCreateServer(apiKey string) *server.SSEServer {
 		return srv
 	}
 
-	// Create client for this API key
-	client := redmine.NewClient(m.redmineURL, apiKey)
-
-	// Create MCP server
-	mcpServer := server.NewMCPServer(
-		ServerName,
-		ServerVersion,
-		server.WithToolCapabilities(false),
+	// Carry the connect query (e.g. api_key) over to the message endpoint so
+	// clients that authenticate via query parameter reach the same server
+	sseServer := server.NewSSEServer(m.factory.newMCPServer(apiKey),
+		server.WithBaseURL(""),
+		server.WithAppendQueryToMessageEndpoint(),
 	)
-
-	// Register tools
-	handler := NewToolHandlers(client, m.rules, m.workflow)
-	handler.RegisterTools(mcpServer)
-
-	// Create SSE server
-	sseServer := server.NewSSEServer(mcpServer, server.WithBaseURL(""))
 	m.servers[apiKey] = sseServer
 
-	slog.Info("Created new SSE server for API key", "key_prefix", apiKey[:8]+"...")
+	slog.Info("Created new SSE server for API key", "key_prefix", keyPrefix(apiKey))
 
 	return sseServer
 }
 
 func (m *sessionManager) handleSSE(w http.ResponseWriter, r *http.Request) {
-	apiKey := extractAPIKey(r)
-	if apiKey == "" {
-		http.Error(w, "Missing API key (use X-Redmine-API-Key header or Authorization: Bearer token)", http.StatusUnauthorized)
-		return
-	}
-
-	sseServer := m.getOrCreateServer(apiKey)
+	sseServer := m.getOrCreateServer(extractAPIKey(r))
 	sseServer.ServeHTTP(w, r)
 }
 
@@ -211,31 +429,21 @@ func (m *sessionManager) handleMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	apiKey := extractAPIKey(r)
-	if apiKey == "" {
-		http.Error(w, "Missing API key (use X-Redmine-API-Key header or Authorization: Bearer token)", http.StatusUnauthorized)
-		return
-	}
-
-	sseServer := m.getOrCreateServer(apiKey)
+	sseServer := m.getOrCreateServer(extractAPIKey(r))
 	sseServer.ServeHTTP(w, r)
 }
 
 // streamableSessionManager manages Streamable HTTP sessions for multi-tenant access
 type streamableSessionManager struct {
-	mu         sync.RWMutex
-	servers    map[string]*server.StreamableHTTPServer
-	redmineURL string
-	rules      *redmine.CustomFieldRules
-	workflow   *redmine.WorkflowRules
+	mu      sync.RWMutex
+	servers map[string]*server.StreamableHTTPServer
+	factory *serverFactory
 }
 
-func newStreamableSessionManager(redmineURL string, rules *redmine.CustomFieldRules, workflow *redmine.WorkflowRules) *streamableSessionManager {
+func newStreamableSessionManager(factory *serverFactory) *streamableSessionManager {
 	return &streamableSessionManager{
-		servers:    make(map[string]*server.StreamableHTTPServer),
-		redmineURL: redmineURL,
-		rules:      rules,
-		workflow:   workflow,
+		servers: make(map[string]*server.StreamableHTTPServer),
+		factory: factory,
 	}
 }
 
@@ -255,38 +463,22 @@ func (m *streamableSessionManager) getOrCreateServer(apiKey string) *server.Stre
 		return srv
 	}
 
-	client := redmine.NewClient(m.redmineURL, apiKey)
-
-	mcpServer := server.NewMCPServer(
-		ServerName,
-		ServerVersion,
-		server.WithToolCapabilities(false),
-	)
-
-	handler := NewToolHandlers(client, m.rules, m.workflow)
-	handler.RegisterTools(mcpServer)
-
-	httpServer := server.NewStreamableHTTPServer(mcpServer)
+	httpServer := server.NewStreamableHTTPServer(m.factory.newMCPServer(apiKey))
 	m.servers[apiKey] = httpServer
 
-	slog.Info("Created new Streamable HTTP server for API key", "key_prefix", apiKey[:8]+"...")
+	slog.Info("Created new Streamable HTTP server for API key", "key_prefix", keyPrefix(apiKey))
 
 	return httpServer
 }
 
 func (m *streamableSessionManager) handleMCP(w http.ResponseWriter, r *http.Request) {
-	apiKey := extractAPIKey(r)
-	if apiKey == "" {
-		http.Error(w, "Missing API key (use X-Redmine-API-Key header or Authorization: Bearer token)", http.StatusUnauthorized)
-		return
-	}
-
-	httpServer := m.getOrCreateServer(apiKey)
+	httpServer := m.getOrCreateServer(extractAPIKey(r))
 	httpServer.ServeHTTP(w, r)
 }
 
 // extractAPIKey extracts the API key from the request.
-// It first checks for X-Redmine-API-Key header, then falls back to Authorization: Bearer token.
+// It checks the X-Redmine-API-Key header, then Authorization: Bearer token,
+// then the api_key query parameter.
 func extractAPIKey(r *http.Request) string {
 	// First, try X-Redmine-API-Key header
 	if apiKey := r.Header.Get("X-Redmine-API-Key"); apiKey != "" {
@@ -299,7 +491,16 @@ func extractAPIKey(r *http.Request) string {
 		return strings.TrimPrefix(auth, "Bearer ")
 	}
 
-	return ""
+	// Finally, the api_key query parameter (for clients that can't set headers)
+	return r.URL.Query().Get("api_key")
+}
+
+// keyPrefix returns a loggable prefix of an API key
+func keyPrefix(apiKey string) string {
+	if apiKey == "" {
+		return "(none)"
+	}
+	return apiKey[:min(len(apiKey), 8)] + "..."
 }
 
 // GetEnvConfig gets configuration from environment variables
@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestExtractAPIKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		header map[string]string
+		want   string
+	}{
+		{"header", "/sse", map[string]string{"X-Redmine-API-Key": "abc"}, "abc"},
+		{"bearer", "/sse", map[string]string{"Authorization": "Bearer xyz"}, "xyz"},
+		{"query", "/sse?api_key=qqq", nil, "qqq"},
+		{"header wins over query", "/sse?api_key=qqq", map[string]string{"X-Redmine-API-Key": "abc"}, "abc"},
+		{"none", "/sse", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+			if got := extractAPIKey(r); got != tt.want {
+				t.Errorf("extractAPIKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyPrefix(t *testing.T) {
+	if got := keyPrefix("abc"); got != "abc..." {
+		t.Errorf("expected short key to be kept, got %q", got)
+	}
+	if got := keyPrefix("0123456789abcdef"); got != "01234567..." {
+		t.Errorf("expected 8 character prefix, got %q", got)
+	}
+	if got := keyPrefix(""); got != "(none)" {
+		t.Errorf("expected placeholder for empty key, got %q", got)
+	}
+}
+
+func TestServerFactory_KeylessToolsRequireCredentials(t *testing.T) {
+	called := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer ts.Close()
+
+	factory := &serverFactory{redmineURL: ts.URL}
+	mcpServer := factory.newMCPServer("")
+
+	msg := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"me","arguments":{}}}`
+	resp := mcpServer.HandleMessage(context.Background(), json.RawMessage(msg))
+
+	data, _ := json.Marshal(resp)
+	var out struct {
+		Result gomcp.CallToolResult `json:"result"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if !out.Result.IsError {
+		t.Fatalf("expected tool error, got %s", data)
+	}
+	if !strings.Contains(string(data), "credentials required") {
+		t.Errorf("expected credentials error, got %s", data)
+	}
+	if called {
+		t.Error("expected no request to Redmine without credentials")
+	}
+}
+
+func TestSessionManager_QueryKeyCarriedToMessageEndpoint(t *testing.T) {
+	redmineServer := httptest.NewServer(http.NotFoundHandler())
+	defer redmineServer.Close()
+
+	mgr := newSessionManager(&serverFactory{redmineURL: redmineServer.URL})
+	ts := httptest.NewServer(http.HandlerFunc(mgr.handleSSE))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/sse?api_key=secret-key", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	var endpoint string
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			endpoint = data
+			break
+		}
+	}
+	if !strings.Contains(endpoint, "sessionId=") || !strings.Contains(endpoint, "api_key=secret-key") {
+		t.Errorf("expected message endpoint to carry the api_key, got %q", endpoint)
+	}
+
+	mgr.mu.RLock()
+	_, ok := mgr.servers["secret-key"]
+	mgr.mu.RUnlock()
+	if !ok {
+		t.Error("expected a server for the query parameter key")
+	}
+}
@@ -0,0 +1,36 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// setServerInfo records the latest self-test for server_info
+func (h *ToolHandlers) setServerInfo(info *redmine.ServerInfo) {
+	h.serverInfoMu.Lock()
+	defer h.serverInfoMu.Unlock()
+	h.serverInfo = info
+}
+
+func (h *ToolHandlers) handleServerInfo(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	h.serverInfoMu.Lock()
+	info := h.serverInfo
+	h.serverInfoMu.Unlock()
+
+	// Sessions without a startup self-test, and failed ones, are checked now
+	if info == nil || info.Error != "" || req.GetBool("refresh", false) {
+		info, _ = redmine.SelfTest(ctx, h.client)
+		h.setServerInfo(info)
+	}
+
+	return jsonResult(map[string]any{
+		"server":        map[string]any{"name": ServerName, "version": ServerVersion},
+		"redmine":       info,
+		"read_only":     h.readOnly,
+		"impersonation": h.allowImpersonation,
+		"language":      i18n.Default().Language(),
+	})
+}
@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestHandleServerInfo(t *testing.T) {
+	var userRequests atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users/current.json":
+			userRequests.Add(1)
+			_, _ = w.Write([]byte(`{"user":{"id":3,"login":"jdoe","firstname":"Jane","lastname":"Doe"}}`))
+		case "/custom_fields.json":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	info := func(args map[string]any) map[string]any {
+		t.Helper()
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleServerInfo(context.Background(), req)
+		if err != nil || result.IsError {
+			t.Fatalf("unexpected error: %v %v", err, result)
+		}
+		var out map[string]any
+		if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	// Without a startup self-test the first call checks Redmine
+	out := info(nil)
+	rm, _ := out["redmine"].(map[string]any)
+	user, _ := rm["user"].(map[string]any)
+	if user["login"] != "jdoe" || rm["admin"] != false || rm["redmine_url"] != mockServer.URL {
+		t.Errorf("unexpected server info: %v", out)
+	}
+	if server, _ := out["server"].(map[string]any); server["name"] != ServerName {
+		t.Errorf("expected the server name, got %v", out["server"])
+	}
+
+	// Later calls report the last check until refreshed
+	info(nil)
+	if n := userRequests.Load(); n != 1 {
+		t.Errorf("expected the check to be reused, got %d requests", n)
+	}
+	info(map[string]any{"refresh": true})
+	if n := userRequests.Load(); n != 2 {
+		t.Errorf("expected refresh to check again, got %d requests", n)
+	}
+}
@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// noneBucket labels issues that have no value for the grouping field
+const noneBucket = "(none)"
+
+// issueStatsFields lists the built-in issues_stats group_by values; anything
+// else is treated as a custom field name
+var issueStatsFields = []string{"status", "tracker", "priority", "assignee", "category", "version"}
+
+// issueGroupValues returns the group labels an issue falls under. Multi-value
+// custom fields count the issue once per value. The second result reports
+// whether the issue carries the custom field at all.
+func issueGroupValues(issue redmine.Issue, groupBy string) ([]string, bool) {
+	nameOf := func(v *redmine.IDName) []string {
+		if v == nil || v.Name == "" {
+			return []string{noneBucket}
+		}
+		return []string{v.Name}
+	}
+
+	switch groupBy {
+	case "status":
+		return nameOf(&issue.Status), true
+	case "tracker":
+		return nameOf(&issue.Tracker), true
+	case "priority":
+		return nameOf(&issue.Priority), true
+	case "assignee":
+		return nameOf(issue.AssignedTo), true
+	case "category":
+		return nameOf(issue.Category), true
+	case "version":
+		return nameOf(issue.FixedVersion), true
+	}
+
+	for _, cf := range issue.CustomFields {
+		if !strings.EqualFold(cf.Name, groupBy) {
+			continue
+		}
+		var values []string
+		switch v := cf.Value.(type) {
+		case []any:
+			for _, item := range v {
+				if s := strings.TrimSpace(fmt.Sprintf("%v", item)); s != "" {
+					values = append(values, s)
+				}
+			}
+		case nil:
+		default:
+			if s := strings.TrimSpace(fmt.Sprintf("%v", v)); s != "" {
+				values = append(values, s)
+			}
+		}
+		if len(values) == 0 {
+			values = []string{noneBucket}
+		}
+		return values, true
+	}
+	return []string{noneBucket}, false
+}
+
+// buildIssueStats counts issues per group, sorted by count descending then by name.
+// It fails when groupBy is a custom field that none of the issues carry.
+func buildIssueStats(issues []redmine.Issue, groupBy string) ([]map[string]any, error) {
+	counts := make(map[string]int)
+	found := false
+	for _, issue := range issues {
+		values, ok := issueGroupValues(issue, groupBy)
+		found = found || ok
+		for _, v := range values {
+			counts[v]++
+		}
+	}
+
+	if len(issues) > 0 && !found {
+		return nil, i18n.Errorf("unknown group_by '%s': not one of %s and no matching issue has a custom field with that name",
+			groupBy, strings.Join(issueStatsFields, ", "))
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	groups := make([]map[string]any, len(names))
+	for i, name := range names {
+		groups[i] = map[string]any{
+			"group": name,
+			"count": counts[name],
+		}
+	}
+	return groups, nil
+}
@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestBuildIssueStats(t *testing.T) {
+	alice := &redmine.IDName{ID: 1, Name: "Alice"}
+	bob := &redmine.IDName{ID: 2, Name: "Bob"}
+	issues := []redmine.Issue{
+		{ID: 1, AssignedTo: alice, CustomFields: []redmine.CustomField{{ID: 5, Name: "Component", Value: "BMC"}}},
+		{ID: 2, AssignedTo: bob, CustomFields: []redmine.CustomField{{ID: 5, Name: "Component", Value: []any{"BMC", "BIOS"}}}},
+		{ID: 3, AssignedTo: alice, CustomFields: []redmine.CustomField{{ID: 5, Name: "Component", Value: ""}}},
+		{ID: 4},
+	}
+
+	t.Run("assignee with none bucket", func(t *testing.T) {
+		groups, err := buildIssueStats(issues, "assignee")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []struct {
+			group string
+			count int
+		}{{"Alice", 2}, {"(none)", 1}, {"Bob", 1}}
+		if len(groups) != len(want) {
+			t.Fatalf("expected %d groups, got %v", len(want), groups)
+		}
+		for i, w := range want {
+			if groups[i]["group"] != w.group || groups[i]["count"] != w.count {
+				t.Errorf("group %d: expected %s=%d, got %v", i, w.group, w.count, groups[i])
+			}
+		}
+	})
+
+	t.Run("custom field", func(t *testing.T) {
+		groups, err := buildIssueStats(issues, "component")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		counts := make(map[string]int)
+		for _, g := range groups {
+			counts[g["group"].(string)] = g["count"].(int)
+		}
+		if counts["BMC"] != 2 || counts["BIOS"] != 1 || counts["(none)"] != 2 {
+			t.Errorf("unexpected counts: %v", counts)
+		}
+		if groups[0]["group"] != "(none)" && groups[0]["group"] != "BMC" {
+			t.Errorf("expected highest count first, got %v", groups[0])
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		if _, err := buildIssueStats(issues, "Severity"); err == nil || !strings.Contains(err.Error(), "unknown group_by") {
+			t.Errorf("expected unknown group_by error, got %v", err)
+		}
+	})
+}
+
+func TestHandleIssuesStats_FetchesAllPages(t *testing.T) {
+	const total = 230
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/issues.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("status_id") != "open" {
+			t.Errorf("expected default open status filter, got %q", q.Get("status_id"))
+		}
+		offsets = append(offsets, q.Get("offset"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+
+		var issues []string
+		for i := offset; i < min(offset+limit, total); i++ {
+			tracker := `{"id":1,"name":"Bug"}`
+			if i%3 == 0 {
+				tracker = `{"id":2,"name":"Feature"}`
+			}
+			issues = append(issues, fmt.Sprintf(`{"id":%d,"tracker":%s,"status":{"id":1,"name":"New"}}`, i+1, tracker))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"issues":[%s],"total_count":%d}`, strings.Join(issues, ","), total)
+	}))
+	defer server.Close()
+
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"group_by": "Tracker"}
+	result, err := h.handleIssuesStats(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+
+	var out struct {
+		GroupBy string `json:"group_by"`
+		Total   int    `json:"total"`
+		Groups  []struct {
+			Group string `json:"group"`
+			Count int    `json:"count"`
+		} `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+
+	if len(offsets) != 3 || offsets[2] != "200" {
+		t.Errorf("expected three pages, got offsets %v", offsets)
+	}
+	if out.GroupBy != "tracker" || out.Total != total {
+		t.Errorf("expected tracker grouping over %d issues, got %+v", total, out)
+	}
+	if len(out.Groups) != 2 || out.Groups[0].Group != "Bug" || out.Groups[0].Count != 153 || out.Groups[1].Count != 77 {
+		t.Errorf("unexpected groups: %+v", out.Groups)
+	}
+}
+
+func TestHandleIssuesStats_Capped(t *testing.T) {
+	const total = 1500
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		var issues []string
+		for i := offset; i < min(offset+limit, total); i++ {
+			issues = append(issues, fmt.Sprintf(`{"id":%d,"tracker":{"id":1,"name":"Bug"}}`, i+1))
+		}
+		fmt.Fprintf(w, `{"issues":[%s],"total_count":%d}`, strings.Join(issues, ","), total)
+	}))
+	defer server.Close()
+
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"group_by": "tracker"}
+	result, err := h.handleIssuesStats(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	var out map[string]any
+	_ = json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out)
+	if requests != redmine.MaxSearchIssuesLimit/100 || out["total"] != float64(redmine.MaxSearchIssuesLimit) {
+		t.Errorf("expected the fetch to stop at %d issues, got %d requests and %v", redmine.MaxSearchIssuesLimit, requests, out["total"])
+	}
+	if note, _ := out["note"].(string); !strings.Contains(note, "1000 of 1500") {
+		t.Errorf("expected a note on the capped fetch, got %q", note)
+	}
+}
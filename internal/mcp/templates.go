@@ -0,0 +1,164 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func (h *ToolHandlers) handleTemplatesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	templates := make([]map[string]any, len(h.templates))
+	for i, t := range h.templates {
+		entry := map[string]any{
+			"name":      t.Name,
+			"variables": t.Variables(),
+			"subtasks":  len(t.Subtasks),
+		}
+		if t.Summary != "" {
+			entry["summary"] = t.Summary
+		}
+		if required := t.RequiredVariables(); len(required) > 0 {
+			entry["required_variables"] = required
+		}
+		if len(t.Defaults) > 0 {
+			entry["defaults"] = t.Defaults
+		}
+		for _, field := range []string{"project", "tracker", "subject"} {
+			if v, ok := t.Fields[field]; ok {
+				entry[field] = v
+			}
+		}
+		templates[i] = entry
+	}
+
+	result := wholeList(map[string]any{
+		"templates": templates,
+	}, len(templates))
+	if len(templates) == 0 {
+		result["note"] = i18n.T("No issue templates are configured; set REDMINE_MCP_TEMPLATES_FILE to a templates file")
+	}
+	return jsonResult(result)
+}
+
+func (h *ToolHandlers) handleIssuesCreateFromTemplate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("issues_createFromTemplate"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	name, err := req.RequireString("template")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	tmpl, err := h.templates.Find(name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	issue, subtasks, err := tmpl.Render(getMapArg(req, "variables"))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Template %s: %v", tmpl.Name, err)), nil
+	}
+	applyTemplateOverrides(issue, getMapArg(req, "overrides"))
+
+	// Validate the issue and every subtask before creating any
+	refs := newBulkRefs(h.resolver)
+	parent, problems := h.buildBulkCreateItem(normalizeTemplateFields(issue), map[string]string{}, refs)
+	for i := range problems {
+		problems[i] = "issue: " + problems[i]
+	}
+	// Subtasks default to the issue's project and tracker
+	defaults := map[string]string{
+		"project": stringOr(issue["project"], ""),
+		"tracker": stringOr(issue["tracker"], ""),
+	}
+	items := make([]redmine.CreateIssueParams, len(subtasks))
+	for i, sub := range subtasks {
+		params, itemProblems := h.buildBulkCreateItem(normalizeTemplateFields(sub), defaults, refs)
+		for _, p := range itemProblems {
+			problems = append(problems, fmt.Sprintf("subtasks[%d] %q: %s", i, params.Subject, p))
+		}
+		items[i] = params
+	}
+	if len(problems) > 0 {
+		return mcp.NewToolResultError(i18n.Sprintf("Validation failed, no issues were created:\n- %s", strings.Join(problems, "\n- "))), nil
+	}
+
+	created, err := h.client.CreateIssue(parent)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create issue: %v", err)), nil
+	}
+	for i := range items {
+		items[i].ParentIssueID = created.ID
+	}
+	subResult := redmine.BulkCreateIssues(h.client, items, false)
+
+	ids := []int{created.ID}
+	for _, s := range subResult.Success {
+		ids = append(ids, s.ID)
+	}
+	result := map[string]any{
+		"template":    tmpl.Name,
+		"id":          created.ID,
+		"issue":       h.createdIssueResult(parent, created),
+		"subtasks":    subResult.Success,
+		"created_ids": ids,
+	}
+	if len(subResult.Failed) > 0 {
+		result["failed"] = subResult.Failed
+		result["note"] = i18n.Sprintf("%d of %d subtasks could not be created", len(subResult.Failed), len(items))
+	}
+	return jsonResult(result)
+}
+
+// applyTemplateOverrides replaces rendered issue fields with the caller's
+// overrides; custom fields are merged so overriding one keeps the others
+func applyTemplateOverrides(issue, overrides map[string]any) {
+	for k, v := range overrides {
+		if k == "custom_fields" {
+			if over, ok := v.(map[string]any); ok {
+				merged := map[string]any{}
+				if base, ok := issue[k].(map[string]any); ok {
+					maps.Copy(merged, base)
+				}
+				maps.Copy(merged, over)
+				issue[k] = merged
+				continue
+			}
+		}
+		issue[k] = v
+	}
+}
+
+// normalizeTemplateFields converts YAML scalars to the types issues_create
+// arguments have in JSON: numbers for number fields and strings for string
+// fields, so "project: 3" in a template works like "3"
+func normalizeTemplateFields(fields map[string]any) map[string]any {
+	props, _ := bulkCreateItemSchema["properties"].(map[string]any)
+	for k, v := range fields {
+		prop, _ := props[k].(map[string]any)
+		switch prop["type"] {
+		case "string":
+			if v != nil {
+				if _, ok := v.(string); !ok {
+					fields[k] = fmt.Sprint(v)
+				}
+			}
+		case "number":
+			switch n := v.(type) {
+			case int:
+				fields[k] = float64(n)
+			case string:
+				if f, err := strconv.ParseFloat(strings.TrimSpace(n), 64); err == nil {
+					fields[k] = f
+				}
+			}
+		}
+	}
+	return fields
+}
@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestHandleIssuesCreateFromTemplate(t *testing.T) {
+	var created, relations []map[string]any
+	mockServer := bulkCreateServer(t, &created, &relations)
+	defer mockServer.Close()
+
+	path := filepath.Join(t.TempDir(), "templates.yaml")
+	content := `templates:
+  - name: change
+    project: 1
+    tracker: Task
+    subject: "Change: {{.title}}"
+    description: "Rollback plan: {{.rollback}}"
+    defaults:
+      rollback: revert the deploy
+    subtasks:
+      - subject: "Review {{.title}}"
+        estimated_hours: 1
+      - subject: "Deploy {{.title}}"
+        tracker: Bug
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	templates, err := redmine.LoadIssueTemplates(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	h.templates = templates
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"template": "change"}
+	result, _ := h.handleIssuesCreateFromTemplate(context.Background(), req)
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "title ({{.title}})") {
+		t.Fatalf("expected an error naming the missing variable, got %v", result.Content)
+	}
+	if len(created) != 0 {
+		t.Fatalf("expected nothing to be created, got %v", created)
+	}
+
+	req.Params.Arguments = map[string]any{
+		"template":  "change",
+		"variables": map[string]any{"title": "TLS 1.3"},
+		"overrides": map[string]any{"estimated_hours": float64(4)},
+	}
+	result, err = h.handleIssuesCreateFromTemplate(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	var out struct {
+		ID         int   `json:"id"`
+		CreatedIDs []int `json:"created_ids"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.ID != 101 || len(out.CreatedIDs) != 3 || out.CreatedIDs[2] != 103 {
+		t.Errorf("unexpected created ids: %+v", out)
+	}
+
+	if len(created) != 3 {
+		t.Fatalf("expected 3 issues to be created, got %v", created)
+	}
+	parent := created[0]
+	if parent["subject"] != "Change: TLS 1.3" || parent["description"] != "Rollback plan: revert the deploy" ||
+		parent["estimated_hours"] != float64(4) || parent["project_id"] != float64(1) {
+		t.Errorf("unexpected parent: %v", parent)
+	}
+	// Subtasks hang under the parent and take its project and tracker unless they set their own
+	if created[1]["subject"] != "Review TLS 1.3" || created[1]["parent_issue_id"] != float64(101) ||
+		created[1]["tracker_id"] != float64(1) || created[1]["estimated_hours"] != float64(1) {
+		t.Errorf("unexpected first subtask: %v", created[1])
+	}
+	if created[2]["tracker_id"] != float64(2) || created[2]["parent_issue_id"] != float64(101) {
+		t.Errorf("unexpected second subtask: %v", created[2])
+	}
+}
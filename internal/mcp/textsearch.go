@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"strings"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// maxTextSearchMatches caps how many full-text matches issues_search scans
+// before applying its structured filters
+const maxTextSearchMatches = 500
+
+// textSearchPageSize is both the /search.json page size and the number of
+// issue IDs hydrated per /issues.json request
+const textSearchPageSize = 100
+
+// searchIssuesByText finds issues whose subject, description or notes match
+// text using Redmine's search API, then loads them through /issues.json
+// restricted to the matched IDs so the structured filters in params apply to
+// the matches. Redmine can't combine both in one query, so at most
+// maxTextSearchMatches matches are considered and truncated reports when
+// there were more. Issues keep the search order (most recent first).
+func (h *ToolHandlers) searchIssuesByText(text string, params redmine.SearchIssuesParams) (issues []redmine.Issue, truncated bool, err error) {
+	search := redmine.GlobalSearchParams{
+		Query:     text,
+		ProjectID: params.ProjectID,
+		Issues:    true,
+		Limit:     textSearchPageSize,
+	}
+	if params.ProjectID != "" {
+		search.Scope = "subprojects"
+	}
+
+	var ids []int
+	seen := make(map[int]bool)
+	for {
+		results, total, err := h.client.GlobalSearch(search)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, r := range results {
+			// Issue results are typed "issue", "issue-closed" or "issue-note"
+			if strings.HasPrefix(r.Type, "issue") && !seen[r.ID] {
+				seen[r.ID] = true
+				ids = append(ids, r.ID)
+			}
+		}
+		search.Offset += len(results)
+		if len(results) == 0 || search.Offset >= total {
+			break
+		}
+		if search.Offset >= maxTextSearchMatches {
+			truncated = true
+			break
+		}
+	}
+
+	byID := make(map[int]redmine.Issue, len(ids))
+	for start := 0; start < len(ids); start += textSearchPageSize {
+		chunk := params
+		chunk.IssueIDs = ids[start:min(start+textSearchPageSize, len(ids))]
+		chunk.Limit = textSearchPageSize
+		chunk.Offset = 0
+		found, _, err := h.client.SearchIssues(chunk)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, issue := range found {
+			byID[issue.ID] = issue
+		}
+	}
+
+	issues = make([]redmine.Issue, 0, len(byID))
+	for _, id := range ids {
+		if issue, ok := byID[id]; ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues, truncated, nil
+}
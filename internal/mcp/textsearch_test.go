@@ -0,0 +1,76 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestHandleIssuesSearch_Text(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/projects/3/search.json":
+			if q.Get("q") != "timeout" || q.Get("issues") != "1" || q.Get("scope") != "subprojects" {
+				t.Errorf("unexpected search query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"results":[
+				{"id":5,"title":"Bug #5: Login","type":"issue"},
+				{"id":12,"title":"Wiki: Timeouts","type":"wiki-page"},
+				{"id":3,"title":"Bug #3: Sync","type":"issue-closed"},
+				{"id":9,"title":"Task #9: Retry","type":"issue-note"}
+			],"total_count":4}`))
+		case "/issues.json":
+			// Hydration: the structured filters still apply, here the default open status
+			if q.Get("issue_id") != "5,3,9" || q.Get("status_id") != "open" || q.Get("project_id") != "3" {
+				t.Errorf("unexpected hydration query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"issues":[
+				{"id":9,"subject":"Retry","status":{"id":1,"name":"New"}},
+				{"id":5,"subject":"Login","status":{"id":1,"name":"New"}}
+			],"total_count":2}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	search := func(args map[string]any) map[string]any {
+		t.Helper()
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleIssuesSearch(context.Background(), req)
+		if err != nil || result.IsError {
+			t.Fatalf("expected success, got %v %v", err, result.Content)
+		}
+		var out map[string]any
+		if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+			t.Fatalf("failed to parse result: %v", err)
+		}
+		return out
+	}
+
+	out := search(map[string]any{"text": "timeout", "project": "3"})
+	if out["total_count"] != float64(2) || out["count"] != float64(2) || out["has_more"] != false {
+		t.Errorf("unexpected counts: %v", out)
+	}
+	issues := out["issues"].([]any)
+	// Search order is kept, not the order /issues.json returned them in
+	if issues[0].(map[string]any)["id"] != float64(5) || issues[1].(map[string]any)["id"] != float64(9) {
+		t.Errorf("expected issues 5 then 9, got %v", issues)
+	}
+
+	out = search(map[string]any{"text": "timeout", "project": "3", "limit": float64(1), "offset": float64(1)})
+	issues = out["issues"].([]any)
+	if len(issues) != 1 || issues[0].(map[string]any)["id"] != float64(9) || out["total_count"] != float64(2) {
+		t.Errorf("unexpected second page: %v", out)
+	}
+}
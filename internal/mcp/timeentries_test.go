@@ -0,0 +1,550 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+func TestHandleTimeEntriesCreate_IssueOrProject(t *testing.T) {
+	var posted map[string]any
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/projects.json":
+			_, _ = w.Write([]byte(`{"projects":[{"id":3,"name":"Operations","identifier":"ops"}],"total_count":1}`))
+		case r.URL.Path == "/time_entries.json" && r.Method == http.MethodPost:
+			var req map[string]map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			posted = req["time_entry"]
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"time_entry":{"id":1,"project":{"id":3,"name":"Operations"},"hours":1.5}}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	create := func(args map[string]any) (*gomcp.CallToolResult, map[string]any) {
+		t.Helper()
+		posted = nil
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleTimeEntriesCreate(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var out map[string]any
+		if !result.IsError {
+			_ = json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out)
+		}
+		return result, out
+	}
+
+	// On an issue
+	_, out := create(map[string]any{"issue_id": float64(42), "hours": 1.5})
+	if posted["issue_id"] != float64(42) || posted["project_id"] != nil || out["issue_id"] != float64(42) {
+		t.Errorf("expected the entry to be logged on issue 42, got %v -> %v", posted, out)
+	}
+
+	// On a project, e.g. a meeting
+	_, out = create(map[string]any{"project": "Operations", "hours": 1.5, "comments": "Sprint planning"})
+	if posted["project_id"] != float64(3) || posted["issue_id"] != nil {
+		t.Errorf("expected the entry to be logged on project 3, got %v", posted)
+	}
+	if out["project"] != "Operations" || out["issue_id"] != nil {
+		t.Errorf("unexpected result: %v", out)
+	}
+
+	// Exactly one of them
+	for _, args := range []map[string]any{
+		{"hours": 1.5},
+		{"issue_id": float64(42), "project": "Operations", "hours": 1.5},
+	} {
+		if result, _ := create(args); !result.IsError || posted != nil {
+			t.Errorf("expected %v to be rejected before calling Redmine", args)
+		}
+	}
+}
+
+func TestHandleTimeEntriesCreateBatch(t *testing.T) {
+	activityRequests := 0
+	var posted []map[string]any
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/enumerations/time_entry_activities.json":
+			activityRequests++
+			_, _ = w.Write([]byte(`{"time_entry_activities":[{"id":9,"name":"Development"},{"id":10,"name":"Testing"}]}`))
+		case r.URL.Path == "/time_entries.json" && r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			var req map[string]map[string]any
+			_ = json.Unmarshal(body, &req)
+			te := req["time_entry"]
+			if te["issue_id"].(float64) == 999 {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"errors":["Issue is invalid"]}`))
+				return
+			}
+			posted = append(posted, te)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"time_entry": map[string]any{
+					"id":       len(posted),
+					"hours":    te["hours"],
+					"spent_on": te["spent_on"],
+					"activity": map[string]any{"id": 9, "name": "Development"},
+				},
+			})
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, nil)
+
+	t.Run("partial success with cached activity", func(t *testing.T) {
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{
+			"entries": []any{
+				map[string]any{"issue_id": float64(1), "hours": float64(2), "spent_on": "2025-01-06", "activity": "Development"},
+				map[string]any{"issue_id": float64(1), "hours": float64(3), "spent_on": "2025-01-07", "activity": "Development"},
+				map[string]any{"issue_id": float64(999), "hours": float64(1), "activity": "Development"},
+				map[string]any{"issue_id": float64(2), "hours": float64(1), "activity": "Nonexistent"},
+				map[string]any{"hours": float64(1)},
+			},
+		}
+
+		result, err := h.handleTimeEntriesCreateBatch(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("expected success, got error: %v", result.Content)
+		}
+
+		var data map[string]any
+		if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &data); err != nil {
+			t.Fatalf("failed to parse result JSON: %v", err)
+		}
+
+		success := data["success"].([]any)
+		failed := data["failed"].([]any)
+		if len(success) != 2 {
+			t.Errorf("expected 2 successes, got %d", len(success))
+		}
+		if len(failed) != 3 {
+			t.Errorf("expected 3 failures, got %d", len(failed))
+		}
+		for i, wantIndex := range []float64{2, 3, 4} {
+			if got := failed[i].(map[string]any)["index"]; got != wantIndex {
+				t.Errorf("expected failure %d to have index %v, got %v", i, wantIndex, got)
+			}
+		}
+		if activityRequests != 1 {
+			t.Errorf("expected activities to be fetched once, got %d", activityRequests)
+		}
+		if posted[0]["activity_id"].(float64) != 9 {
+			t.Errorf("expected activity_id=9, got %v", posted[0]["activity_id"])
+		}
+	})
+
+	t.Run("empty entries returns error", func(t *testing.T) {
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"entries": []any{}}
+
+		result, err := h.handleTimeEntriesCreateBatch(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsError {
+			t.Fatal("expected error result for empty entries")
+		}
+	})
+
+	t.Run("read-only mode blocks the whole batch", func(t *testing.T) {
+		h.readOnly = true
+		defer func() { h.readOnly = false }()
+		posted = nil
+
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{
+			"entries": []any{
+				map[string]any{"issue_id": float64(1), "hours": float64(2)},
+			},
+		}
+
+		result, err := h.handleTimeEntriesCreateBatch(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsError {
+			t.Fatal("expected error result in read-only mode")
+		}
+		if len(posted) != 0 {
+			t.Errorf("expected no entries to be created, got %d", len(posted))
+		}
+	})
+}
+
+// newPagedTimeEntriesServer serves /time_entries.json from a fixed number of
+// entries, capping each page at 100 like Redmine does.
+func newPagedTimeEntriesServer(t *testing.T, total int, requests *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/time_entries.json" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		*requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if limit > 100 {
+			limit = 100
+		}
+		entries := []map[string]any{}
+		for id := offset + 1; id <= offset+limit && id <= total; id++ {
+			entries = append(entries, map[string]any{
+				"id":       id,
+				"project":  map[string]any{"id": 1, "name": "P"},
+				"user":     map[string]any{"id": 1, "name": "Alice"},
+				"activity": map[string]any{"id": 9, "name": "Development"},
+				"hours":    1.0,
+				"spent_on": "2025-01-06",
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"time_entries": entries,
+			"total_count":  total,
+		})
+	}))
+}
+
+func callTimeEntriesTool(t *testing.T, handler func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error), args map[string]any) map[string]any {
+	t.Helper()
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = args
+	result, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+	var data map[string]any
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &data); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	return data
+}
+
+func TestHandleTimeEntriesList_Pagination(t *testing.T) {
+	requests := 0
+	mockServer := newPagedTimeEntriesServer(t, 250, &requests)
+	defer mockServer.Close()
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, nil)
+
+	t.Run("single page reports truncation", func(t *testing.T) {
+		requests = 0
+		data := callTimeEntriesTool(t, h.handleTimeEntriesList, map[string]any{})
+		if data["count"].(float64) != 25 || data["total_count"].(float64) != 250 {
+			t.Errorf("expected 25/250, got %v/%v", data["count"], data["total_count"])
+		}
+		if data["has_more"] != true {
+			t.Errorf("expected has_more=true, got %v", data["has_more"])
+		}
+		if requests != 1 {
+			t.Errorf("expected 1 request, got %d", requests)
+		}
+	})
+
+	t.Run("offset is passed through", func(t *testing.T) {
+		data := callTimeEntriesTool(t, h.handleTimeEntriesList, map[string]any{"offset": float64(240)})
+		if data["count"].(float64) != 10 {
+			t.Errorf("expected count=10, got %v", data["count"])
+		}
+		if data["has_more"] != false {
+			t.Errorf("expected has_more=false, got %v", data["has_more"])
+		}
+		first := data["time_entries"].([]any)[0].(map[string]any)
+		if first["id"].(float64) != 241 {
+			t.Errorf("expected first id=241, got %v", first["id"])
+		}
+	})
+
+	t.Run("fetch_all aggregates every page", func(t *testing.T) {
+		requests = 0
+		data := callTimeEntriesTool(t, h.handleTimeEntriesList, map[string]any{"fetch_all": true})
+		if data["count"].(float64) != 250 {
+			t.Errorf("expected count=250, got %v", data["count"])
+		}
+		if data["has_more"] != false {
+			t.Errorf("expected has_more=false, got %v", data["has_more"])
+		}
+		if requests != 3 {
+			t.Errorf("expected 3 requests, got %d", requests)
+		}
+	})
+}
+
+func TestHandleTimeEntriesList_FetchAllCap(t *testing.T) {
+	requests := 0
+	mockServer := newPagedTimeEntriesServer(t, 1500, &requests)
+	defer mockServer.Close()
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, nil)
+
+	data := callTimeEntriesTool(t, h.handleTimeEntriesList, map[string]any{"fetch_all": true})
+	// The response budget trims the list again, keeping count in step
+	fetched := fmt.Sprintf("of %d time_entries", maxTimeEntriesFetch)
+	if !strings.Contains(fmt.Sprint(data["truncation_notes"]), fetched) || data["count"] != float64(len(data["time_entries"].([]any))) {
+		t.Errorf("expected %d fetched entries and a matching count, got %v %v", maxTimeEntriesFetch, data["count"], data["truncation_notes"])
+	}
+	if data["has_more"] != true {
+		t.Errorf("expected has_more=true, got %v", data["has_more"])
+	}
+}
+
+func TestHandleTimeEntriesReport_AllPages(t *testing.T) {
+	requests := 0
+	mockServer := newPagedTimeEntriesServer(t, 1500, &requests)
+	defer mockServer.Close()
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, nil)
+
+	data := callTimeEntriesTool(t, h.handleTimeEntriesReport, map[string]any{"group_by": "user"})
+	if data["entry_count"].(float64) != 1500 {
+		t.Errorf("expected entry_count=1500, got %v", data["entry_count"])
+	}
+	if data["total_hours"].(float64) != 1500 {
+		t.Errorf("expected total_hours=1500, got %v", data["total_hours"])
+	}
+}
+
+func TestHandleTimeEntriesReport_GroupByIssue(t *testing.T) {
+	var issueQuery string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/time_entries.json":
+			_, _ = w.Write([]byte(`{"time_entries":[
+				{"id":1,"issue":{"id":10},"hours":2,"spent_on":"2025-01-06"},
+				{"id":2,"issue":{"id":11},"hours":1,"spent_on":"2025-01-06"}
+			],"total_count":2}`))
+		case "/issues.json":
+			issueQuery = r.URL.Query().Get("issue_id")
+			_, _ = w.Write([]byte(`{"issues":[{"id":10,"subject":"First"},{"id":11,"subject":"Second"}],"total_count":2}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, nil)
+
+	data := callTimeEntriesTool(t, h.handleTimeEntriesReport, map[string]any{"group_by": "issue"})
+	if issueQuery != "10,11" {
+		t.Errorf("expected subjects to be looked up with issue_id=10,11, got %q", issueQuery)
+	}
+	groups := data["groups"].([]any)
+	first := groups[0].(map[string]any)
+	if first["issue_id"].(float64) != 10 || first["subject"] != "First" {
+		t.Errorf("unexpected first group: %v", first)
+	}
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"group_by": "week"}
+	result, err := h.handleTimeEntriesReport(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error result for unsupported group_by")
+	}
+}
+
+func TestParseAttachToIssue(t *testing.T) {
+	if id, err := parseAttachToIssue("issue:42"); err != nil || id != 42 {
+		t.Errorf("expected 42, got %d (err: %v)", id, err)
+	}
+	for _, bad := range []string{"wiki:Page", "issue:", "issue:abc", "42"} {
+		if _, err := parseAttachToIssue(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestHandleTimeEntriesReport_AttachCSV(t *testing.T) {
+	var uploaded string
+	var updateBody map[string]map[string]any
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/time_entries.json":
+			_, _ = w.Write([]byte(`{"time_entries":[
+				{"id":1,"user":{"id":1,"name":"Alice"},"hours":2,"spent_on":"2025-01-06"},
+				{"id":2,"user":{"id":2,"name":"Bob"},"hours":3,"spent_on":"2025-01-06"}
+			],"total_count":2}`))
+		case r.URL.Path == "/uploads.json":
+			body, _ := io.ReadAll(r.Body)
+			uploaded = string(body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"upload":{"token":"tok123"}}`))
+		case r.URL.Path == "/issues/42.json" && r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &updateBody)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/issues/42.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":42,"attachments":[{"id":7,"filename":"other.txt"},{"id":8,"filename":"` +
+				"time_report_" + time.Now().Format("20060102") + `.csv"}]}}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, nil)
+
+	t.Run("csv format returns text", func(t *testing.T) {
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"group_by": "user", "format": "csv"}
+		result, err := h.handleTimeEntriesReport(context.Background(), req)
+		if err != nil || result.IsError {
+			t.Fatalf("unexpected failure: %v %v", err, result.Content)
+		}
+		text := result.Content[0].(gomcp.TextContent).Text
+		if text != "User,Hours\nBob,3\nAlice,2\nTotal,5\n" {
+			t.Errorf("unexpected CSV:\n%s", text)
+		}
+	})
+
+	t.Run("attach_to uploads CSV to the issue", func(t *testing.T) {
+		data := callTimeEntriesTool(t, h.handleTimeEntriesReport, map[string]any{"group_by": "user", "attach_to": "issue:42"})
+		if !strings.Contains(uploaded, "Total,5") {
+			t.Errorf("expected uploaded CSV to contain total row, got %q", uploaded)
+		}
+		uploads, _ := updateBody["issue"]["uploads"].([]any)
+		if len(uploads) != 1 || uploads[0].(map[string]any)["token"] != "tok123" {
+			t.Errorf("expected upload token to be attached, got %v", updateBody)
+		}
+		attachment := data["attachment"].(map[string]any)
+		if attachment["id"].(float64) != 8 || attachment["issue_id"].(float64) != 42 {
+			t.Errorf("unexpected attachment info: %v", attachment)
+		}
+	})
+
+	t.Run("invalid attach_to is rejected", func(t *testing.T) {
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"group_by": "user", "attach_to": "wiki:Timesheet"}
+		result, err := h.handleTimeEntriesReport(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsError {
+			t.Fatal("expected error result for invalid attach_to")
+		}
+	})
+}
+
+func TestHandleTimeEntriesUpdate_ExplicitFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       map[string]any
+		wantFields map[string]any
+	}{
+		{
+			name:       "empty comments is sent",
+			args:       map[string]any{"time_entry_id": float64(1), "comments": ""},
+			wantFields: map[string]any{"comments": ""},
+		},
+		{
+			name:       "zero hours is sent",
+			args:       map[string]any{"time_entry_id": float64(1), "hours": float64(0)},
+			wantFields: map[string]any{"hours": 0.0},
+		},
+		{
+			name:       "omitted comments is not sent",
+			args:       map[string]any{"time_entry_id": float64(1), "spent_on": "2025-01-06"},
+			wantFields: map[string]any{"spent_on": "2025-01-06"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]map[string]any
+			mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(body, &got)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer mockServer.Close()
+
+			client := redmine.NewClient(mockServer.URL, "test-api-key")
+			h := NewToolHandlers(client, nil, nil)
+			callTimeEntriesTool(t, h.handleTimeEntriesUpdate, tt.args)
+
+			te := got["time_entry"]
+			if len(te) != len(tt.wantFields) {
+				t.Errorf("expected fields %v, got %v", tt.wantFields, te)
+			}
+			for k, want := range tt.wantFields {
+				if v, ok := te[k]; !ok || v != want {
+					t.Errorf("expected %s=%v, got %v (present=%v)", k, want, v, ok)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleTimeEntriesList_RelativeDates(t *testing.T) {
+	var query url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"time_entries":[],"total_count":0}`))
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	callTimeEntriesTool(t, h.handleTimeEntriesList, map[string]any{"from": "2024-W12", "to": "2024-W13"})
+	if query.Get("from") != "2024-03-18" || query.Get("to") != "2024-03-31" {
+		t.Errorf("expected ISO weeks to cover whole weeks, got from=%s to=%s", query.Get("from"), query.Get("to"))
+	}
+
+	// An unknown period must not turn into an unfiltered query
+	query = nil
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"period": "next_decade"}
+	result, err := h.handleTimeEntriesList(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "this_week") {
+		t.Errorf("expected an error listing accepted periods, got %v", result.Content)
+	}
+	if query != nil {
+		t.Errorf("expected no request for an invalid period, got %v", query)
+	}
+}
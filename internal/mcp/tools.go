@@ -4,55 +4,105 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
-	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
 	"github.com/ycho/redmine-mcp-server/internal/redmine"
 )
 
-// resolveDatePeriod converts period shortcuts to from/to dates
-func resolveDatePeriod(period string) (from, to string) {
-	now := time.Now()
-
-	switch period {
-	case "this_week":
-		// Start of this week (Monday)
-		weekday := int(now.Weekday())
-		if weekday == 0 {
-			weekday = 7
-		}
-		start := now.AddDate(0, 0, -weekday+1)
-		end := start.AddDate(0, 0, 6)
-		return start.Format("2006-01-02"), end.Format("2006-01-02")
-	case "last_week":
-		weekday := int(now.Weekday())
-		if weekday == 0 {
-			weekday = 7
-		}
-		start := now.AddDate(0, 0, -weekday-6)
-		end := start.AddDate(0, 0, 6)
-		return start.Format("2006-01-02"), end.Format("2006-01-02")
-	case "this_month":
-		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
-		end := start.AddDate(0, 1, -1)
-		return start.Format("2006-01-02"), end.Format("2006-01-02")
-	case "last_month":
-		start := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, now.Location())
-		end := start.AddDate(0, 1, -1)
-		return start.Format("2006-01-02"), end.Format("2006-01-02")
-	default:
-		return "", ""
+// timezoneDescription documents the timezone argument of tools that work out
+// what "today" is
+const timezoneDescription = "IANA timezone deciding what 'today' is, e.g. Asia/Taipei " +
+	"(default: REDMINE_MCP_TIMEZONE, else the server's local time)"
+
+// now returns the current time in the zone that decides what "today" is: the
+// request's timezone argument, else REDMINE_MCP_TIMEZONE, else local time
+func (h *ToolHandlers) now(req mcp.CallToolRequest) (time.Time, error) {
+	loc := h.location
+	if name := req.GetString("timezone", ""); name != "" {
+		var err error
+		if loc, err = redmine.LoadTimezone(name); err != nil {
+			return time.Time{}, i18n.Errorf("invalid timezone: %w", err)
+		}
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.Now().In(loc), nil
+}
+
+// resolveDatePeriod converts a period shortcut, or any date expression
+// redmine.ParseDateRange accepts, to from/to dates relative to now. An empty
+// period returns empty dates; an unknown one is an error rather than an
+// unbounded range.
+func (h *ToolHandlers) resolveDatePeriod(period string, now time.Time) (from, to string, err error) {
+	if period == "" {
+		return "", "", nil
+	}
+	start, end, err := redmine.ParseDateRange(period, now, h.week)
+	if err != nil {
+		return "", "", i18n.Errorf("invalid period: %w", err)
+	}
+	return start.Format(redmine.DateLayout), end.Format(redmine.DateLayout), nil
+}
+
+// dateArg resolves a date argument to YYYY-MM-DD, accepting the relative
+// expressions of redmine.ParseDateRange. A range such as an ISO week resolves
+// to its last day when end is set, else to its first. A missing argument
+// returns "".
+func (h *ToolHandlers) dateArg(req mcp.CallToolRequest, key string, end bool) (string, error) {
+	expr := req.GetString(key, "")
+	if expr == "" {
+		return "", nil
+	}
+	now, err := h.now(req)
+	if err != nil {
+		return "", err
+	}
+	start, last, err := redmine.ParseDateRange(expr, now, h.week)
+	if err != nil {
+		return "", i18n.Errorf("invalid %s: %w", key, err)
+	}
+	if end {
+		return last.Format(redmine.DateLayout), nil
+	}
+	return start.Format(redmine.DateLayout), nil
+}
+
+// dateRangeArgs resolves the period, from and to arguments of a tool that
+// filters by date; from and to override the matching end of the period
+func (h *ToolHandlers) dateRangeArgs(req mcp.CallToolRequest, defaultPeriod string) (from, to string, err error) {
+	now, err := h.now(req)
+	if err != nil {
+		return "", "", err
+	}
+	if from, to, err = h.resolveDatePeriod(req.GetString("period", defaultPeriod), now); err != nil {
+		return "", "", err
+	}
+	if v, err := h.dateArg(req, "from", false); err != nil {
+		return "", "", err
+	} else if v != "" {
+		from = v
 	}
+	if v, err := h.dateArg(req, "to", true); err != nil {
+		return "", "", err
+	} else if v != "" {
+		to = v
+	}
+	return from, to, nil
 }
 
 // ToolHandlers contains all MCP tool handlers
@@ -62,6 +112,45 @@ type ToolHandlers struct {
 	rules    *redmine.CustomFieldRules
 	workflow *redmine.WorkflowRules
 	readOnly bool
+
+	// allowedWrites restricts write tools to an allow-list; nil allows all
+	allowedWrites map[string]bool
+
+	// workflowSource selects file, server or hybrid transition validation
+	workflowSource redmine.WorkflowSource
+
+	// week sets the week start and work days of periods and reports
+	week redmine.WorkWeek
+
+	// location is the default timezone of date math; nil is local time
+	location *time.Location
+
+	// defaults replaces built-in defaults of omitted arguments, per tool
+	defaults ToolDefaults
+
+	// templates are the issue templates of issues_createFromTemplate
+	templates redmine.IssueTemplates
+
+	// allowImpersonation enables as_user on the write tools that support it
+	allowImpersonation bool
+
+	// maxAttachmentSize caps each uploaded file, in bytes
+	maxAttachmentSize int64
+
+	// customFieldHintLength caps the custom_fields descriptions listing known
+	// fields; customFieldsReadable records whether the admin custom field list
+	// can back them when there is no rules file
+	customFieldHintLength int
+	customFieldsReadable  bool
+
+	// audit records every write tool call; auditUserCache holds the acting user
+	audit          *auditLog
+	auditUserMu    sync.Mutex
+	auditUserCache *redmine.User
+
+	// serverInfo is the latest self-test, run at startup or by server_info
+	serverInfoMu sync.Mutex
+	serverInfo   *redmine.ServerInfo
 }
 
 // NewToolHandlers creates new tool handlers
@@ -76,6 +165,15 @@ func NewToolHandlers(client *redmine.Client, rules *redmine.CustomFieldRules, wo
 		rules:    rules,
 		workflow: workflow,
 		readOnly: readOnly,
+
+		allowedWrites:      allowedWriteToolsFromEnv(),
+		audit:              auditLogFromEnv(),
+		week:               redmine.WorkWeekFromEnv(),
+		location:           redmine.TimezoneFromEnv(),
+		allowImpersonation: impersonationFromEnv(),
+
+		maxAttachmentSize:     redmine.DefaultMaxAttachmentMB * 1024 * 1024,
+		customFieldHintLength: DefaultCustomFieldHintLength,
 	}
 }
 
@@ -91,6 +189,12 @@ type referenceData struct {
 // Never fails — returns empty slices on error so tools still register without enums.
 func (h *ToolHandlers) fetchReferenceData() *referenceData {
 	ref := &referenceData{}
+	if !h.client.HasCredentials() {
+		// Keyless sessions can't call any tool, so skip the lookups
+		return ref
+	}
+
+	h.checkCustomFieldsReadable()
 
 	if trackers, err := h.resolver.GetTrackers(); err != nil {
 		slog.Warn("failed to fetch trackers for enum hints", "error", err)
@@ -139,19 +243,40 @@ func enumOpt(values []string) []mcp.PropertyOption {
 // checkReadOnly returns an error if the server is in read-only mode.
 func (h *ToolHandlers) checkReadOnly() error {
 	if h.readOnly {
-		return fmt.Errorf("server is in read-only mode - write operations are disabled")
+		return i18n.Errorf("server is in read-only mode - write operations are disabled")
 	}
 	return nil
 }
 
 // RegisterTools registers all MCP tools on the server
 func (h *ToolHandlers) RegisterTools(s McpServer) {
+	s = policyServer{McpServer: s, allowed: h.allowedWrites, audit: h.auditWrite}
 	ref := h.fetchReferenceData()
 	// Account
 	s.AddTool(mcp.NewTool("me",
 		mcp.WithDescription("Get current user information"),
 	), h.handleMe)
 
+	s.AddTool(mcp.NewTool("server_info",
+		mcp.WithDescription("Which Redmine server and user this server operates as: the Redmine URL, the API key's user, whether it is an administrator, "+
+			"and the server's version, read-only mode and language. Reports what to fix when Redmine is unreachable or rejects the key"),
+		mcp.WithBoolean("refresh",
+			mcp.Description("Check Redmine again instead of reporting the startup check (default: false)"),
+		),
+	), h.handleServerInfo)
+
+	s.AddTool(mcp.NewTool("permissions_check",
+		mcp.WithDescription("Check what the API key can do on a project or issue before a multi-step plan: view, add and edit issues, add notes, log time, "+
+			"manage versions and custom fields. Reads are probed; Redmine has no permissions API, so writes are inferred from your roles, the project's "+
+			"modules and status. Each capability is marked confirmed, likely or unknown"),
+		mcp.WithString("project",
+			mcp.Description("Project name or ID (optional with issue_id, whose project is checked)"),
+		),
+		mcp.WithNumber("issue_id",
+			mcp.Description("Issue ID, to also check that the issue is visible and whether you can edit it as its author"),
+		),
+	), h.handlePermissionsCheck)
+
 	// Projects
 	s.AddTool(mcp.NewTool("projects_list",
 		mcp.WithDescription("List all projects"),
@@ -181,8 +306,8 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 	// Issues
 	searchStatuses := append([]string{"open", "closed", "*"}, ref.statuses...)
 
-	s.AddTool(mcp.NewTool("issues_search",
-		mcp.WithDescription("Search issues"),
+	// Filters shared by issues_search and issues_stats
+	issueFilters := []mcp.ToolOption{
 		mcp.WithString("project",
 			mcp.Description("Project name or ID"),
 		),
@@ -199,6 +324,9 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		mcp.WithString("assigned_to",
 			mcp.Description("Assignee name or 'me' for current user"),
 		),
+		mcp.WithString("watched_by",
+			mcp.Description("Only issues watched by this user (name, ID or 'me'). Other users' watch lists need an admin key"),
+		),
 		mcp.WithString("subject",
 			mcp.Description("Search keyword in issue subject (partial match)"),
 		),
@@ -206,39 +334,165 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 			mcp.Description("Parent issue ID (find subtasks)"),
 		),
 		mcp.WithString("updated_after",
-			mcp.Description("Only issues updated on or after this date (YYYY-MM-DD)"),
+			mcp.Description("Only issues updated on or after this date (YYYY-MM-DD, or relative: today, yesterday, '7 days ago', start_of_month, 2024-W12, ...)"),
 		),
 		mcp.WithString("updated_before",
-			mcp.Description("Only issues updated on or before this date (YYYY-MM-DD)"),
+			mcp.Description("Only issues updated on or before this date (YYYY-MM-DD, or relative like updated_after)"),
 		),
 		mcp.WithString("created_after",
-			mcp.Description("Only issues created on or after this date (YYYY-MM-DD)"),
+			mcp.Description("Only issues created on or after this date (YYYY-MM-DD, or relative like updated_after)"),
 		),
 		mcp.WithString("created_before",
-			mcp.Description("Only issues created on or before this date (YYYY-MM-DD)"),
-		),
-		mcp.WithString("sort",
-			mcp.Description("Sort order (e.g., 'updated_on:desc', 'priority:desc', 'created_on:asc')"),
+			mcp.Description("Only issues created on or before this date (YYYY-MM-DD, or relative like updated_after)"),
 		),
 		mcp.WithObject("custom_fields",
-			mcp.Description("Filter by custom field values (field name or ID -> value, e.g., {\"SW_Category\": \"SW Tool\"})"),
+			mcp.Description("Filter by custom field values (field name or ID -> value). A string matches exactly, an array matches any of its values, "+
+				"and a leading operator on a string changes the match: \"!value\" (not equal), \"~text\" (contains), \"!~text\" (doesn't contain). "+
+				"E.g., {\"SW_Category\": \"SW Tool\", \"Affected Component\": [\"UI\", \"API\"]}"),
+		),
+	}
+
+	s.AddTool(mcp.NewTool("issues_search", slices.Concat(
+		[]mcp.ToolOption{mcp.WithDescription("Search issues")},
+		issueFilters,
+		[]mcp.ToolOption{
+			mcp.WithString("query",
+				mcp.Description("Saved query name or ID to run (see queries_list). The query's own filters replace the other filters; only project, sort, limit and offset still apply"),
+			),
+			mcp.WithNumber("query_id",
+				mcp.Description("Saved query ID, as an alternative to query"),
+			),
+			mcp.WithString("text",
+				mcp.Description(fmt.Sprintf("Free-text search in subject, description and notes. Redmine can't combine it with the other filters in one query, "+
+					"so the first %d text matches are filtered; results are ordered most recent first and sort is ignored", maxTextSearchMatches)),
+			),
+			mcp.WithString("sort",
+				mcp.Description(sortDescription),
+			),
+			mcp.WithNumber("limit",
+				mcp.Description("Number of issues to return (default: 25, max: 1000; pages are fetched automatically above 100)"),
+			),
+			mcp.WithNumber("offset",
+				mcp.Description("Offset for pagination (default: 0)"),
+			),
+			mcp.WithBoolean("include_counts",
+				mcp.Description(fmt.Sprintf("Add journals_count, attachments_count, watchers_count and last_note_on to each issue (default: false). "+
+					"Needs one request per issue, so it fails if more than %d issues are returned", maxCountsIssues)),
+			),
+		},
+	)...), h.handleIssuesSearch)
+
+	s.AddTool(mcp.NewTool("issues_stats", slices.Concat(
+		[]mcp.ToolOption{
+			mcp.WithDescription("Count issues matching the issues_search filters, grouped by a field. Up to 1000 matching issues are counted, not just the first page; a note says when more matched"),
+			mcp.WithString("group_by",
+				mcp.Required(),
+				mcp.Description("Field to group by: status, tracker, priority, assignee, category, version, or a custom field name"),
+			),
+		},
+		issueFilters,
+	)...), h.handleIssuesStats)
+
+	s.AddTool(mcp.NewTool("issues_dueSoon",
+		mcp.WithDescription("List overdue issues and issues due within the next few days. Issues without a due date are excluded"),
+		mcp.WithString("project",
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("assigned_to",
+			mcp.Description("Assignee name or 'me' for current user"),
+		),
+		mcp.WithNumber("within_days",
+			mcp.Description("Include issues due within this many days from today (default: 7, 0 = only overdue)"),
+		),
+		mcp.WithBoolean("include_closed",
+			mcp.Description("Include closed issues (default: false)"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDescription),
+		),
+	), h.handleIssuesDueSoon)
+
+	s.AddTool(mcp.NewTool("issues_myWatched",
+		mcp.WithDescription("List open issues the current user watches, most recently updated first"),
+		mcp.WithString("project",
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("updated_after",
+			mcp.Description("Only issues updated on or after this date (YYYY-MM-DD, or relative: today, yesterday, '7 days ago', ...)"),
 		),
 		mcp.WithNumber("limit",
-			mcp.Description("Number of issues to return (default: 25)"),
+			mcp.Description("Number of issues to return (default: 25, max: 1000)"),
 		),
 		mcp.WithNumber("offset",
 			mcp.Description("Offset for pagination (default: 0)"),
 		),
-	), h.handleIssuesSearch)
+	), h.handleIssuesMyWatched)
 
 	s.AddTool(mcp.NewTool("issues_getById",
-		mcp.WithDescription("Get issue details including journals, watchers, and relations"),
+		mcp.WithDescription("Get issue details including journals, watchers, and relations. Journals flagged private_notes are internal-only and must not be quoted to customers."),
 		mcp.WithNumber("issue_id",
 			mcp.Required(),
 			mcp.Description("Issue ID"),
 		),
+		mcp.WithBoolean("include_private_notes",
+			mcp.Description("Include journals with private notes (default: true). Set false when preparing customer-facing output."),
+		),
+		mcp.WithBoolean("include_spent_hours",
+			mcp.Description("Add spent_time: total hours logged on the issue and a per-user breakdown (default: false)"),
+		),
+		mcp.WithBoolean("include_children",
+			mcp.Description("Add children: id, subject, status, assignee and done_ratio of each direct subtask (default: false)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("json (default) or markdown: a compact document with a linked title, metadata table, description, custom fields, "+
+				"the latest notes and attachments, ready to show in chat"),
+			mcp.Enum("json", "markdown"),
+		),
+		mcp.WithNumber("notes",
+			mcp.Description(fmt.Sprintf("Number of latest notes in markdown format (default: %d)", defaultMarkdownNotes)),
+		),
+		mcp.WithNumber("journals_offset",
+			mcp.Description("Skip this many of the most recent journals, to page back through a long history when a result says older journals were left out (default: 0)"),
+		),
 	), h.handleIssuesGetById)
 
+	s.AddTool(mcp.NewTool("issues_getTree",
+		mcp.WithDescription("Get an issue with its nested subtasks (work breakdown structure), including aggregate done ratio per level"),
+		mcp.WithNumber("issue_id",
+			mcp.Required(),
+			mcp.Description("Root issue ID"),
+		),
+		mcp.WithNumber("max_depth",
+			mcp.Description("Maximum subtask depth to fetch (default: 3)"),
+		),
+	), h.handleIssuesGetTree)
+
+	s.AddTool(mcp.NewTool("issues_schedule",
+		mcp.WithDescription("Export open issues with dates, progress, parent and precedes/blocks relations for building a Gantt chart"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("version",
+			mcp.Description("Only issues targeted at this version (name or ID)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: json (default) or csv"),
+			mcp.Enum("json", "csv"),
+		),
+	), h.handleIssuesSchedule)
+
+	s.AddTool(mcp.NewTool("issues_history",
+		mcp.WithDescription("Get the chronological field change history of an issue (who changed what and when)"),
+		mcp.WithNumber("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID"),
+		),
+		mcp.WithString("field",
+			mcp.Description("Only show changes to this field: status, assignee, priority, done_ratio, tracker, version, or a custom field name"),
+		),
+	), h.handleIssuesHistory)
+
 	s.AddTool(mcp.NewTool("issues_create",
 		mcp.WithDescription("Create a new issue"),
 		mcp.WithString("project",
@@ -259,7 +513,29 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 			mcp.Description("Issue description"),
 		),
 		mcp.WithString("assigned_to",
-			mcp.Description("Assignee name or ID"),
+			mcp.Description("Assignee user name or ID; a group name when no user matches"),
+		),
+		mcp.WithString("assigned_to_group",
+			mcp.Description("Group name or ID to assign to, for names that match both a user and a group (Redmine must allow issue assignment to groups)"),
+		),
+		mcp.WithString("status",
+			append([]mcp.PropertyOption{
+				mcp.Description("Initial status name or ID (default: the tracker's default status). Redmine ignores statuses the workflow doesn't allow for new issues; the result then has a warning"),
+			}, enumOpt(ref.statuses)...)...,
+		),
+		mcp.WithString("priority",
+			append([]mcp.PropertyOption{
+				mcp.Description("Priority name or ID"),
+			}, enumOpt(ref.priorities)...)...,
+		),
+		mcp.WithNumber("done_ratio",
+			mcp.Description("Initial progress percentage (0-100)"),
+		),
+		mcp.WithString("category",
+			mcp.Description("Issue category name or ID within the project"),
+		),
+		mcp.WithString("version",
+			mcp.Description("Target version name or ID within the project"),
 		),
 		mcp.WithNumber("parent_issue_id",
 			mcp.Description("Parent issue ID"),
@@ -270,8 +546,11 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		mcp.WithString("due_date",
 			mcp.Description("Due date (YYYY-MM-DD)"),
 		),
+		mcp.WithNumber("estimated_hours",
+			mcp.Description("Estimated time in hours"),
+		),
 		mcp.WithObject("custom_fields",
-			mcp.Description("Custom fields as key-value pairs (field name -> value)"),
+			mcp.Description(h.customFieldsDescription(customFieldHintTools["issues_create"])),
 		),
 		mcp.WithBoolean("is_private",
 			mcp.Description("Whether the issue is private"),
@@ -280,8 +559,53 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 			mcp.Description("Upload tokens from attachments_upload to attach files (array of {token, filename, content_type, description})"),
 			mcp.Items(map[string]any{"type": "object"}),
 		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("Validate and resolve everything, then return the payload that would be sent and any problems without creating the issue"),
+		),
+		h.asUserParam(),
 	), h.handleIssuesCreate)
 
+	s.AddTool(mcp.NewTool("issues_bulkCreate",
+		mcp.WithDescription(fmt.Sprintf("Create up to %d issues at once, e.g. a sprint plan. Every item is validated before any issue is created; "+
+			"creation then continues on individual failures (partial success)", redmine.MaxBulkCreateIssues)),
+		mcp.WithArray("issues",
+			mcp.Required(),
+			mcp.Description("Issues to create, each with the fields of issues_create; project and tracker default to the top-level values"),
+			mcp.Items(bulkCreateItemSchema),
+		),
+		mcp.WithString("project",
+			mcp.Description("Default project name or ID for items without one"),
+		),
+		mcp.WithString("tracker",
+			append([]mcp.PropertyOption{
+				mcp.Description("Default tracker name or ID for items without one"),
+			}, enumOpt(ref.trackers)...)...,
+		),
+		mcp.WithBoolean("link_sequentially",
+			mcp.Description("Add a precedes relation from each created issue to the next one (default: false)"),
+		),
+	), h.handleIssuesBulkCreate)
+
+	s.AddTool(mcp.NewTool("templates_list",
+		mcp.WithDescription("List the issue templates of issues_createFromTemplate with their variables, defaults and number of subtasks"),
+	), h.handleTemplatesList)
+
+	s.AddTool(mcp.NewTool("issues_createFromTemplate",
+		mcp.WithDescription("Create an issue, and its subtasks, from a named template (see templates_list). "+
+			"The template's {{.placeholders}} are filled from variables; the issue and every subtask are validated before any is created"),
+		mcp.WithString("template",
+			mcp.Required(),
+			mcp.Description("Template name"),
+		),
+		mcp.WithObject("variables",
+			mcp.Description("Values of the template's placeholders, e.g. {\"service\": \"checkout\"}; variables with a template default are optional"),
+		),
+		mcp.WithObject("overrides",
+			mcp.Description("issues_create fields replacing the template's for the issue (not its subtasks), e.g. {\"assigned_to\": \"me\"}; "+
+				"custom_fields are merged with the template's"),
+		),
+	), h.handleIssuesCreateFromTemplate)
+
 	s.AddTool(mcp.NewTool("issues_update",
 		mcp.WithDescription("Update an issue"),
 		mcp.WithNumber("issue_id",
@@ -310,7 +634,10 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 			}, enumOpt(ref.trackers)...)...,
 		),
 		mcp.WithString("assigned_to",
-			mcp.Description("New assignee name or ID"),
+			mcp.Description("New assignee user name or ID; a group name when no user matches"),
+		),
+		mcp.WithString("assigned_to_group",
+			mcp.Description("Group name or ID to assign to, for names that match both a user and a group (Redmine must allow issue assignment to groups)"),
 		),
 		mcp.WithString("start_date",
 			mcp.Description("Start date (YYYY-MM-DD)"),
@@ -324,8 +651,17 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		mcp.WithNumber("done_ratio",
 			mcp.Description("Progress percentage (0-100)"),
 		),
+		mcp.WithString("category",
+			mcp.Description("New issue category name or ID within the issue's project"),
+		),
+		mcp.WithString("version",
+			mcp.Description("New target version name or ID within the issue's project"),
+		),
+		mcp.WithNumber("estimated_hours",
+			mcp.Description("Estimated time in hours"),
+		),
 		mcp.WithObject("custom_fields",
-			mcp.Description("Custom fields to update as key-value pairs"),
+			mcp.Description(h.customFieldsDescription(customFieldHintTools["issues_update"])),
 		),
 		mcp.WithBoolean("is_private",
 			mcp.Description("Whether the issue is private"),
@@ -334,6 +670,25 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 			mcp.Description("Upload tokens from attachments_upload to attach files (array of {token, filename, content_type, description})"),
 			mcp.Items(map[string]any{"type": "object"}),
 		),
+		mcp.WithBoolean("clear_assigned_to",
+			mcp.Description("Set to true to unassign the issue"),
+		),
+		mcp.WithBoolean("clear_due_date",
+			mcp.Description("Set to true to remove the due date"),
+		),
+		mcp.WithBoolean("clear_description",
+			mcp.Description("Set to true to empty the description"),
+		),
+		mcp.WithBoolean("clear_estimated_hours",
+			mcp.Description("Set to true to remove the estimated time"),
+		),
+		mcp.WithBoolean("clear_category",
+			mcp.Description("Set to true to remove the issue category"),
+		),
+		mcp.WithBoolean("clear_version",
+			mcp.Description("Set to true to remove the target version"),
+		),
+		h.asUserParam(),
 	), h.handleIssuesUpdate)
 
 	s.AddTool(mcp.NewTool("issues_createSubtask",
@@ -355,25 +710,48 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 			mcp.Description("Subtask description"),
 		),
 		mcp.WithString("assigned_to",
-			mcp.Description("Assignee name or ID"),
+			mcp.Description("Assignee user name or ID; a group name when no user matches"),
+		),
+		mcp.WithString("assigned_to_group",
+			mcp.Description("Group name or ID to assign to, for names that match both a user and a group (Redmine must allow issue assignment to groups)"),
+		),
+		mcp.WithString("status",
+			append([]mcp.PropertyOption{
+				mcp.Description("Initial status name or ID (default: the tracker's default status). Redmine ignores statuses the workflow doesn't allow for new issues; the result then has a warning"),
+			}, enumOpt(ref.statuses)...)...,
 		),
 		mcp.WithString("priority",
 			append([]mcp.PropertyOption{
 				mcp.Description("Priority name or ID"),
 			}, enumOpt(ref.priorities)...)...,
 		),
+		mcp.WithNumber("done_ratio",
+			mcp.Description("Initial progress percentage (0-100)"),
+		),
+		mcp.WithString("category",
+			mcp.Description("Issue category name or ID within the project"),
+		),
+		mcp.WithString("version",
+			mcp.Description("Target version name or ID within the project"),
+		),
 		mcp.WithString("start_date",
 			mcp.Description("Start date (YYYY-MM-DD)"),
 		),
 		mcp.WithString("due_date",
 			mcp.Description("Due date (YYYY-MM-DD)"),
 		),
+		mcp.WithNumber("estimated_hours",
+			mcp.Description("Estimated time in hours"),
+		),
 		mcp.WithObject("custom_fields",
 			mcp.Description("Custom fields as key-value pairs"),
 		),
 		mcp.WithBoolean("is_private",
 			mcp.Description("Whether the subtask is private"),
 		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("Validate and resolve everything, then return the payload that would be sent and any problems without creating the issue"),
+		),
 	), h.handleIssuesCreateSubtask)
 
 	s.AddTool(mcp.NewTool("issues_addWatcher",
@@ -388,6 +766,21 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		),
 	), h.handleIssuesAddWatcher)
 
+	s.AddTool(mcp.NewTool("issues_addComment",
+		mcp.WithDescription("Add a comment (journal note) to an issue without changing any other field"),
+		mcp.WithNumber("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID"),
+		),
+		mcp.WithString("notes",
+			mcp.Required(),
+			mcp.Description("Comment text"),
+		),
+		mcp.WithBoolean("private_notes",
+			mcp.Description("Make the comment private, visible only to users allowed to see private notes"),
+		),
+	), h.handleIssuesAddComment)
+
 	s.AddTool(mcp.NewTool("issues_addRelation",
 		mcp.WithDescription("Create a relation between two issues"),
 		mcp.WithNumber("issue_id",
@@ -400,8 +793,11 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		),
 		mcp.WithString("relation_type",
 			mcp.Required(),
-			mcp.Description("Relation type: relates, duplicates, blocks, precedes, copied_to"),
-			mcp.Enum("relates", "duplicates", "blocks", "precedes", "copied_to"),
+			mcp.Description("How issue_id relates to issue_to_id: relates, duplicates, duplicated_by, blocks, blocked_by, precedes, follows, copied_to, copied_from (case-insensitive). "+
+				"Inverse types are stored as the forward type with the issues swapped: 10 blocked_by 11 creates 11 blocks 10"),
+		),
+		mcp.WithNumber("delay",
+			mcp.Description("Days between the end of the preceding issue and the start of the following one (precedes and follows only), e.g. 2 to start two days after QA signoff"),
 		),
 	), h.handleIssuesAddRelation)
 
@@ -443,6 +839,18 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		),
 	), h.handleProjectsGetDetail)
 
+	s.AddTool(mcp.NewTool("projects_summary",
+		mcp.WithDescription("Project status in one call: open and closed issue counts, open issues by tracker and priority, overdue and unassigned open issues, "+
+			"issues updated in the last 14 days, versions with their open/closed counts and due dates, and hours logged in the last 30 days"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDescription),
+		),
+	), h.handleProjectsSummary)
+
 	s.AddTool(mcp.NewTool("projects_update",
 		mcp.WithDescription("Update project settings (trackers, custom fields, name, description). Requires admin or project manager privileges."),
 		mcp.WithString("project",
@@ -465,13 +873,31 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		),
 	), h.handleProjectsUpdate)
 
+	s.AddTool(mcp.NewTool("projects_setStatus",
+		mcp.WithDescription("Change a project's lifecycle: archive/unarchive (admin, Redmine 5.0+), close/reopen (Redmine 5.1+), or permanently delete (admin)"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name, identifier or ID"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Description("Lifecycle action"),
+			mcp.Enum(redmine.ProjectActions...),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Description("Must be true to delete: deletion removes the project with all its issues, wiki pages and files and cannot be undone"),
+		),
+	), h.handleProjectsSetStatus)
+
 	// Time Entries
 	s.AddTool(mcp.NewTool("timeEntries_create",
-		mcp.WithDescription("Create a time entry for an issue"),
+		mcp.WithDescription("Create a time entry for an issue, or for a project without an issue (e.g. meetings). Give exactly one of issue_id and project."),
 		mcp.WithNumber("issue_id",
-			mcp.Required(),
 			mcp.Description("Issue ID"),
 		),
+		mcp.WithString("project",
+			mcp.Description("Project name or ID, to log time on the project itself instead of an issue"),
+		),
 		mcp.WithNumber("hours",
 			mcp.Required(),
 			mcp.Description("Hours spent"),
@@ -487,27 +913,53 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		mcp.WithString("spent_on",
 			mcp.Description("Date the time was spent (YYYY-MM-DD format, defaults to today)"),
 		),
+		h.asUserParam(),
 	), h.handleTimeEntriesCreate)
 
+	s.AddTool(mcp.NewTool("timeEntries_createBatch",
+		mcp.WithDescription("Create multiple time entries at once (e.g., a week of timesheets). Continues on individual failures (partial success)."),
+		mcp.WithArray("entries",
+			mcp.Required(),
+			mcp.Description("Array of time entries, each with issue_id, hours, and optional spent_on (YYYY-MM-DD), activity, comments"),
+			mcp.Items(map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"issue_id": map[string]any{"type": "number", "description": "Issue ID"},
+					"hours":    map[string]any{"type": "number", "description": "Hours spent"},
+					"spent_on": map[string]any{"type": "string", "description": "Date (YYYY-MM-DD, defaults to today)"},
+					"activity": map[string]any{"type": "string", "description": "Activity name or ID"},
+					"comments": map[string]any{"type": "string", "description": "Comments"},
+				},
+				"required": []string{"issue_id", "hours"},
+			}),
+		),
+	), h.handleTimeEntriesCreateBatch)
+
 	s.AddTool(mcp.NewTool("timeEntries_list",
 		mcp.WithDescription("List time entries with filters"),
 		mcp.WithString("project", mcp.Description("Project name or ID")),
 		mcp.WithString("user", mcp.Description("User name or ID, use 'me' for current user")),
 		mcp.WithNumber("issue_id", mcp.Description("Filter by issue ID")),
-		mcp.WithString("from", mcp.Description("Start date (YYYY-MM-DD)")),
-		mcp.WithString("to", mcp.Description("End date (YYYY-MM-DD)")),
-		mcp.WithString("period", mcp.Description("Date shortcut: this_week, last_week, this_month, last_month")),
+		mcp.WithString("from", mcp.Description("Start date (YYYY-MM-DD, or relative: today, yesterday, '7 days ago', start_of_month, 2024-W12, ...)")),
+		mcp.WithString("to", mcp.Description("End date (YYYY-MM-DD, or relative like from)")),
+		mcp.WithString("period", mcp.Description("Date shortcut: this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, "+
+			"last_7_days, last_30_days (both ending today), or a relative date like from")),
 		mcp.WithNumber("limit", mcp.Description("Results limit (default 25)")),
+		mcp.WithNumber("offset", mcp.Description("Offset for pagination (default: 0)")),
+		mcp.WithBoolean("fetch_all", mcp.Description("Fetch all pages instead of a single page (up to 1000 entries; limit is ignored)")),
 	), h.handleTimeEntriesList)
 
 	s.AddTool(mcp.NewTool("timeEntries_report",
 		mcp.WithDescription("Generate time entry report with aggregation"),
 		mcp.WithString("project", mcp.Description("Project name or ID")),
 		mcp.WithString("user", mcp.Description("User name or ID")),
-		mcp.WithString("from", mcp.Description("Start date (YYYY-MM-DD)")),
-		mcp.WithString("to", mcp.Description("End date (YYYY-MM-DD)")),
-		mcp.WithString("period", mcp.Description("Date shortcut: this_week, last_week, this_month, last_month")),
-		mcp.WithString("group_by", mcp.Required(), mcp.Description("Grouping: project, user, activity, or comma-separated combination")),
+		mcp.WithString("from", mcp.Description("Start date (YYYY-MM-DD, or relative: today, yesterday, '7 days ago', start_of_month, 2024-W12, ...)")),
+		mcp.WithString("to", mcp.Description("End date (YYYY-MM-DD, or relative like from)")),
+		mcp.WithString("period", mcp.Description("Date shortcut: this_week, last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, "+
+			"last_7_days, last_30_days (both ending today), or a relative date like from")),
+		mcp.WithString("group_by", mcp.Required(), mcp.Description("Grouping: project, user, activity, issue, date, or comma-separated combination (date groups are sorted chronologically)")),
+		mcp.WithString("format", mcp.Description("Output format: json (default) or csv (one row per group plus a total row)"), mcp.Enum("json", "csv")),
+		mcp.WithString("attach_to", mcp.Description("Upload the CSV report as an attachment: issue:ID")),
 	), h.handleTimeEntriesReport)
 
 	// Attachments
@@ -519,7 +971,7 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		),
 		mcp.WithString("content",
 			mcp.Required(),
-			mcp.Description("File content as base64-encoded string (max 3MB decoded)"),
+			mcp.Description(fmt.Sprintf("File content as base64-encoded string (max %d MB decoded)", h.maxAttachmentSize/(1024*1024))),
 		),
 		mcp.WithString("content_type",
 			mcp.Description("MIME type (e.g., 'application/pdf'). Auto-detected if omitted."),
@@ -527,7 +979,7 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 	), h.handleAttachmentsUpload)
 
 	s.AddTool(mcp.NewTool("attachments_download",
-		mcp.WithDescription("Download an attachment by ID. Returns base64-encoded content."),
+		mcp.WithDescription("Download an attachment by ID. Returns base64-encoded content (max 10MB; use the REST API for larger files)."),
 		mcp.WithNumber("attachment_id",
 			mcp.Required(),
 			mcp.Description("Attachment ID"),
@@ -542,6 +994,36 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		),
 	), h.handleAttachmentsList)
 
+	s.AddTool(mcp.NewTool("attachments_delete",
+		mcp.WithDescription("Permanently delete an attachment. Only its author or an administrator can delete it. "+
+			"Returns the deleted file's name and issue for the record"),
+		mcp.WithNumber("attachment_id",
+			mcp.Required(),
+			mcp.Description("Attachment ID"),
+		),
+		mcp.WithNumber("issue_id",
+			mcp.Description("Issue the attachment belongs to. Redmine doesn't report an attachment's container, so pass it to have it checked and recorded"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Required(),
+			mcp.Description("Must be true to confirm the permanent deletion"),
+		),
+	), h.handleAttachmentsDelete)
+
+	s.AddTool(mcp.NewTool("attachments_update",
+		mcp.WithDescription("Rename an attachment or change its description. Returns the updated attachment"),
+		mcp.WithNumber("attachment_id",
+			mcp.Required(),
+			mcp.Description("Attachment ID"),
+		),
+		mcp.WithString("filename",
+			mcp.Description("New filename"),
+		),
+		mcp.WithString("description",
+			mcp.Description("New description (empty string clears it)"),
+		),
+	), h.handleAttachmentsUpdate)
+
 	s.AddTool(mcp.NewTool("attachments_uploadAndAttach",
 		mcp.WithDescription("Upload a file and attach it to an issue in one step. Most common use case for adding attachments."),
 		mcp.WithNumber("issue_id",
@@ -554,7 +1036,7 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		),
 		mcp.WithString("content",
 			mcp.Required(),
-			mcp.Description("File content as base64-encoded string (max 3MB decoded)"),
+			mcp.Description(fmt.Sprintf("File content as base64-encoded string (max %d MB decoded)", h.maxAttachmentSize/(1024*1024))),
 		),
 		mcp.WithString("content_type",
 			mcp.Description("MIME type (e.g., 'application/pdf'). Auto-detected if omitted."),
@@ -570,22 +1052,27 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 	// Reference
 	s.AddTool(mcp.NewTool("trackers_list",
 		mcp.WithDescription("List all trackers"),
+		mcp.WithBoolean("force_refresh", mcp.Description("Bypass the cache and fetch fresh data from Redmine")),
 	), h.handleTrackersList)
 
 	s.AddTool(mcp.NewTool("statuses_list",
 		mcp.WithDescription("List all issue statuses"),
+		mcp.WithBoolean("force_refresh", mcp.Description("Bypass the cache and fetch fresh data from Redmine")),
 	), h.handleStatusesList)
 
 	s.AddTool(mcp.NewTool("priorities_list",
 		mcp.WithDescription("List all issue priorities"),
+		mcp.WithBoolean("force_refresh", mcp.Description("Bypass the cache and fetch fresh data from Redmine")),
 	), h.handlePrioritiesList)
 
 	s.AddTool(mcp.NewTool("activities_list",
 		mcp.WithDescription("List all time entry activities"),
+		mcp.WithBoolean("force_refresh", mcp.Description("Bypass the cache and fetch fresh data from Redmine")),
 	), h.handleActivitiesList)
 
 	s.AddTool(mcp.NewTool("roles_list",
 		mcp.WithDescription("List all roles available in the Redmine instance"),
+		mcp.WithBoolean("force_refresh", mcp.Description("Bypass the cache and fetch fresh data from Redmine")),
 	), h.handleRolesList)
 
 	s.AddTool(mcp.NewTool("reference_workflow",
@@ -641,6 +1128,14 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		),
 	), h.handleIssuesRemoveWatcher)
 
+	s.AddTool(mcp.NewTool("issues_listRelations",
+		mcp.WithDescription("List an issue's relations in both directions (e.g. 'blocks #456', 'blocked by #789') with the related issue's subject and status"),
+		mcp.WithNumber("issue_id",
+			mcp.Required(),
+			mcp.Description("Issue ID"),
+		),
+	), h.handleIssuesListRelations)
+
 	s.AddTool(mcp.NewTool("issues_removeRelation",
 		mcp.WithDescription("Remove a relation between issues"),
 		mcp.WithNumber("relation_id",
@@ -667,6 +1162,31 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		),
 	), h.handleUsersSearch)
 
+	s.AddTool(mcp.NewTool("users_get",
+		mcp.WithDescription("Get a user's details with group memberships and roles per project. Login, status and last login are only returned to admins"),
+		mcp.WithString("user",
+			mcp.Required(),
+			mcp.Description("User name, login, ID or 'me'"),
+		),
+		mcp.WithString("project",
+			mcp.Description("Project name or ID to look the user name up in, for keys that can't list users"),
+		),
+	), h.handleUsersGet)
+
+	s.AddTool(mcp.NewTool("groups_list",
+		mcp.WithDescription("List user groups (requires admin privileges)"),
+		mcp.WithBoolean("include_users",
+			mcp.Description("Include the users in each group (default: false)"),
+		),
+	), h.handleGroupsList)
+
+	s.AddTool(mcp.NewTool("queries_list",
+		mcp.WithDescription("List saved issue queries visible to the current user. Run one with issues_search's query parameter"),
+		mcp.WithString("project",
+			mcp.Description("Only queries usable in this project (name or ID); queries for all projects are included"),
+		),
+	), h.handleQueriesList)
+
 	// --- Global Search ---
 
 	s.AddTool(mcp.NewTool("search_global",
@@ -725,7 +1245,7 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 			}, enumOpt(ref.statuses)...)...,
 		),
 		mcp.WithString("assigned_to",
-			mcp.Description("New assignee name or ID"),
+			mcp.Description("New assignee user name or ID; a group name when no user matches"),
 		),
 		mcp.WithString("priority",
 			append([]mcp.PropertyOption{
@@ -735,6 +1255,12 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		mcp.WithString("notes",
 			mcp.Description("Notes/comment to add to each issue"),
 		),
+		mcp.WithNumber("parallelism",
+			mcp.Description(fmt.Sprintf("Number of issues updated concurrently (default: %d, max: %d)", defaultBatchParallelism, maxBatchParallelism)),
+		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("Resolve and validate everything, then return the status, assignee and priority changes each issue would get without updating any issue"),
+		),
 	), h.handleIssuesBatchUpdate)
 
 	s.AddTool(mcp.NewTool("issues_copy",
@@ -749,8 +1275,40 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		mcp.WithString("subject",
 			mcp.Description("Override subject for the copy (defaults to source subject)"),
 		),
+		mcp.WithBoolean("copy_watchers",
+			mcp.Description("Add the source issue's watchers to the copy (default: false)"),
+		),
+		mcp.WithBoolean("copy_attachments",
+			mcp.Description("Re-upload the source issue's attachments to the copy, up to 20 MB in total (default: false)"),
+		),
+		mcp.WithBoolean("link_to_source",
+			mcp.Description("Create a copied_to relation from the source issue to the copy (default: true)"),
+		),
 	), h.handleIssuesCopy)
 
+	s.AddTool(mcp.NewTool("issues_copyTree",
+		mcp.WithDescription("Copy an issue and all its subtasks, preserving the parent/child structure. Useful for recurring checklists kept as a template issue."),
+		mcp.WithNumber("issue_id",
+			mcp.Required(),
+			mcp.Description("Root issue ID of the subtree to copy"),
+		),
+		mcp.WithString("project",
+			mcp.Description("Target project name or ID (defaults to each issue's own project)"),
+		),
+		mcp.WithString("subject_prefix",
+			mcp.Description("Text prepended to every copied subject"),
+		),
+		mcp.WithString("subject_suffix",
+			mcp.Description("Text appended to every copied subject, e.g. ' (v2.1)'"),
+		),
+		mcp.WithNumber("date_offset_days",
+			mcp.Description("Shift start and due dates by this many days (negative moves them earlier)"),
+		),
+		mcp.WithNumber("max_depth",
+			mcp.Description(fmt.Sprintf("Maximum subtask depth to copy (default: 5). At most %d issues are copied.", maxCopyTreeIssues)),
+		),
+	), h.handleIssuesCopyTree)
+
 	// --- Group C: Versions ---
 
 	s.AddTool(mcp.NewTool("versions_list",
@@ -761,6 +1319,25 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		),
 	), h.handleVersionsList)
 
+	s.AddTool(mcp.NewTool("versions_progress",
+		mcp.WithDescription("Release readiness of a version: open/closed counts, completion by done ratio, open issues by priority, overdue issues, "+
+			"estimated vs spent hours, due date and days remaining, and at_risk when high priority issues are open close to the due date"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("version",
+			mcp.Required(),
+			mcp.Description("Version name or ID"),
+		),
+		mcp.WithNumber("at_risk_days",
+			mcp.Description(fmt.Sprintf("At risk when fewer days than this remain and issues above the default priority are open (default: %d)", redmine.DefaultAtRiskDays)),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDescription),
+		),
+	), h.handleVersionsProgress)
+
 	s.AddTool(mcp.NewTool("versions_create",
 		mcp.WithDescription("Create a new version/milestone in a project"),
 		mcp.WithString("project",
@@ -862,11 +1439,16 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 	// --- Project Memberships ---
 
 	s.AddTool(mcp.NewTool("memberships_list",
-		mcp.WithDescription("List all memberships (users and groups) for a project"),
+		mcp.WithDescription("List memberships (users and groups) for a project, up to 100 per page. Roles a user gets from a group are marked inherited. With user, shows that user's effective roles on the project, direct and through groups."),
 		mcp.WithString("project",
 			mcp.Required(),
 			mcp.Description("Project name or ID"),
 		),
+		mcp.WithString("user",
+			mcp.Description("Only this user (name, ID or 'me'): their membership, their groups' memberships and one effective role per entry"),
+		),
+		mcp.WithNumber("limit", mcp.Description("Results limit (default and max 100)")),
+		mcp.WithNumber("offset", mcp.Description("Offset for pagination (default: 0)")),
 	), h.handleMembershipsList)
 
 	s.AddTool(mcp.NewTool("memberships_add",
@@ -929,8 +1511,8 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		),
 	), h.handleWikiGet)
 
-	s.AddTool(mcp.NewTool("wiki_createOrUpdate",
-		mcp.WithDescription("Create or update a wiki page"),
+	s.AddTool(mcp.NewTool("wiki_getVersion",
+		mcp.WithDescription("Get an old revision of a wiki page with its content"),
 		mcp.WithString("project",
 			mcp.Required(),
 			mcp.Description("Project name or ID"),
@@ -939,24 +1521,165 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 			mcp.Required(),
 			mcp.Description("Wiki page title"),
 		),
-		mcp.WithString("text",
+		mcp.WithNumber("version",
 			mcp.Required(),
-			mcp.Description("Wiki page content (Textile or Markdown depending on Redmine config)"),
-		),
-		mcp.WithString("comments",
-			mcp.Description("Edit comment / version note"),
+			mcp.Description("Version number"),
 		),
-	), h.handleWikiCreateOrUpdate)
-
-	// --- Group F: Export ---
+	), h.handleWikiGetVersion)
 
-	s.AddTool(mcp.NewTool("issues_exportCSV",
-		mcp.WithDescription("Export issues as CSV text. Same filters as issues_search."),
+	s.AddTool(mcp.NewTool("wiki_history",
+		mcp.WithDescription("List the revisions of a wiki page (newest first) with author, date and edit comment"),
 		mcp.WithString("project",
+			mcp.Required(),
 			mcp.Description("Project name or ID"),
 		),
-		mcp.WithString("tracker",
-			append([]mcp.PropertyOption{
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Wiki page title"),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description(fmt.Sprintf("Number of most recent versions to list (default: %d, max: %d)", defaultWikiHistoryVersions, maxWikiHistoryVersions)),
+		),
+	), h.handleWikiHistory)
+
+	s.AddTool(mcp.NewTool("wiki_diff",
+		mcp.WithDescription("Show a unified diff between two versions of a wiki page"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Wiki page title"),
+		),
+		mcp.WithNumber("from_version",
+			mcp.Description("Older version number (default: the version before to_version)"),
+		),
+		mcp.WithNumber("to_version",
+			mcp.Description("Newer version number (default: current version)"),
+		),
+	), h.handleWikiDiff)
+
+	s.AddTool(mcp.NewTool("wiki_createOrUpdate",
+		mcp.WithDescription("Create or update a wiki page"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Wiki page title"),
+		),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("Wiki page content (Textile or Markdown depending on Redmine config)"),
+		),
+		mcp.WithString("comments",
+			mcp.Description("Edit comment / version note"),
+		),
+		mcp.WithString("parent_title",
+			mcp.Description("Title of the parent page to file this page under"),
+		),
+		mcp.WithArray("upload_tokens",
+			mcp.Description("Upload tokens from attachments_upload to attach files (array of {token, filename, content_type, description})"),
+			mcp.Items(map[string]any{"type": "object"}),
+		),
+	), h.handleWikiCreateOrUpdate)
+
+	s.AddTool(mcp.NewTool("wiki_uploadAndAttach",
+		mcp.WithDescription("Upload a file and attach it to an existing wiki page in one step (e.g. a screenshot referenced in the page)"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Wiki page title"),
+		),
+		mcp.WithString("filename",
+			mcp.Required(),
+			mcp.Description("Filename (e.g., 'diagram.png')"),
+		),
+		mcp.WithString("content",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("File content as base64-encoded string (max %d MB decoded)", h.maxAttachmentSize/(1024*1024))),
+		),
+		mcp.WithString("content_type",
+			mcp.Description("MIME type (e.g., 'image/png'). Auto-detected if omitted."),
+		),
+		mcp.WithString("description",
+			mcp.Description("File description"),
+		),
+		mcp.WithString("comments",
+			mcp.Description("Edit comment / version note"),
+		),
+	), h.handleWikiUploadAndAttach)
+
+	s.AddTool(mcp.NewTool("wiki_delete",
+		mcp.WithDescription("Delete a wiki page and its history. Child pages are kept and moved to the top level"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Wiki page title"),
+		),
+		mcp.WithBoolean("confirm",
+			mcp.Required(),
+			mcp.Description("Must be true to confirm the permanent deletion"),
+		),
+	), h.handleWikiDelete)
+
+	// --- Documents ---
+
+	s.AddTool(mcp.NewTool("documents_list",
+		mcp.WithDescription("List documents of a project (requires the Documents module)"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID"),
+		),
+	), h.handleDocumentsList)
+
+	s.AddTool(mcp.NewTool("documents_get",
+		mcp.WithDescription("Get a document with its description and attachments"),
+		mcp.WithNumber("document_id",
+			mcp.Required(),
+			mcp.Description("Document ID"),
+		),
+	), h.handleDocumentsGet)
+
+	s.AddTool(mcp.NewTool("documents_create",
+		mcp.WithDescription("Create a document in a project (requires the Documents module). Attach files with upload_tokens from attachments_upload"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Document title"),
+		),
+		mcp.WithString("description",
+			mcp.Description("Document description"),
+		),
+		mcp.WithString("category",
+			mcp.Description("Document category name or ID. Defaults to the default document category; required when none is marked default"),
+		),
+		mcp.WithArray("upload_tokens",
+			mcp.Description("Upload tokens from attachments_upload to attach files (array of {token, filename, content_type, description})"),
+			mcp.Items(map[string]any{"type": "object"}),
+		),
+	), h.handleDocumentsCreate)
+
+	// --- Group F: Export ---
+
+	s.AddTool(mcp.NewTool("issues_exportCSV",
+		mcp.WithDescription("Export issues as CSV, JSON Lines or a Markdown table (for pasting into chat or wiki pages). Same filters as issues_search."),
+		mcp.WithString("project",
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("tracker",
+			append([]mcp.PropertyOption{
 				mcp.Description("Tracker name or ID"),
 			}, enumOpt(ref.trackers)...)...,
 		),
@@ -972,7 +1695,7 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 			mcp.Description("Search keyword in issue subject (partial match)"),
 		),
 		mcp.WithString("sort",
-			mcp.Description("Sort order (e.g., 'updated_on:desc')"),
+			mcp.Description(sortDescription),
 		),
 		mcp.WithNumber("limit",
 			mcp.Description("Number of issues to return (default: 25)"),
@@ -980,30 +1703,100 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 		mcp.WithNumber("offset",
 			mcp.Description("Offset for pagination (default: 0)"),
 		),
+		mcp.WithString("format",
+			mcp.Description("Output format: csv (default), jsonl (one issue object per line, with custom fields) or markdown"),
+			mcp.Enum(redmine.ExportFormats...),
+		),
+		mcp.WithString("columns",
+			mcp.Description("Comma-separated columns for csv and markdown: id, subject, project, tracker, status, priority, assignee, author, start, due, "+
+				"done_ratio, estimated_hours, spent_hours, created, updated, description, or custom field names/IDs (multiple values are joined with ';'). "+
+				"Defaults: csv id,subject,project,tracker,status,priority,assignee,created,updated; markdown id,subject,status,assignee,due"),
+		),
+		mcp.WithBoolean("include_description",
+			mcp.Description("Add the description as a last column, with line breaks written as \\n (default: false)"),
+		),
 	), h.handleIssuesExportCSV)
 
+	s.AddTool(mcp.NewTool("issues_importCSV",
+		mcp.WithDescription(fmt.Sprintf("Create or update issues from a CSV file with a header row, the reverse of issues_exportCSV. "+
+			"Names are resolved and custom fields validated like issues_create and issues_update; rows are imported in order and "+
+			"each row is reported as created, updated or failed. At most %d rows.", redmine.MaxImportRows)),
+		mcp.WithString("content",
+			mcp.Required(),
+			mcp.Description("CSV file content as base64-encoded string"),
+		),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID for created issues; upsert_by_subject matches subjects within it"),
+		),
+		mcp.WithString("tracker",
+			append([]mcp.PropertyOption{
+				mcp.Description("Tracker name or ID for created issues without a tracker column value"),
+			}, enumOpt(ref.trackers)...)...,
+		),
+		mcp.WithString("mode",
+			mcp.Description("create_only (default) creates every row; update_by_id updates the issue in the id column; "+
+				"upsert_by_subject updates the project's issue with the row's subject, or creates one if there is none"),
+			mcp.Enum(redmine.ImportModes...),
+		),
+		mcp.WithObject("mapping",
+			mcp.Description("Column header -> field (subject, tracker, status, priority, assigned_to, start_date, due_date, done_ratio, "+
+				"estimated_hours, description, parent_issue_id, is_private, notes, id, project) or custom field name. Only mapped columns are imported. "+
+				"Default: headers naming a field (including issues_exportCSV headers) map to it and other headers are custom field names"),
+		),
+		mcp.WithBoolean("dry_run",
+			mcp.Description("Resolve and validate every row and report the request each would send, without changing anything (default: false)"),
+		),
+	), h.handleIssuesImportCSV)
+
 	// --- Group G: Reports ---
 
 	s.AddTool(mcp.NewTool("reports_weekly",
-		mcp.WithDescription("Generate a weekly time report aggregated by day, issue, and activity"),
+		mcp.WithDescription("Generate a weekly time report over the week's work days, aggregated by day, project, issue, and activity"),
 		mcp.WithString("user",
 			mcp.Description("User name or ID (default: 'me')"),
 		),
 		mcp.WithString("week_of",
-			mcp.Description("Any date within the target week (YYYY-MM-DD, defaults to current week)"),
+			mcp.Description("Any date within the target week (YYYY-MM-DD, 'last_week', '14 days ago' or 2024-W12; defaults to current week)"),
+		),
+		mcp.WithBoolean("include_previous_week",
+			mcp.Description("Add previous_week: the week before's total and per-project hours with the change to this week (default: false)"),
+		),
+		mcp.WithBoolean("include_weekend",
+			mcp.Description("Cover every day of the week, not only the work days, so weekend hours count (default: false)"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDescription),
 		),
 	), h.handleReportsWeekly)
 
 	s.AddTool(mcp.NewTool("reports_standup",
-		mcp.WithDescription("Generate a standup report: yesterday's time entries + today's open issues"),
+		mcp.WithDescription("Generate a standup report: the previous work day's time entries and activity (issues assigned to or opened by the user that were updated, with the user's own notes) + today's open issues"),
 		mcp.WithString("user",
 			mcp.Description("User name or ID (default: 'me')"),
 		),
 		mcp.WithString("date",
-			mcp.Description("Date for the standup (YYYY-MM-DD, defaults to today)"),
+			mcp.Description("Date for the standup (YYYY-MM-DD, today, yesterday or 'N days ago'; defaults to today)"),
+		),
+		mcp.WithBoolean("include_time_entries",
+			mcp.Description("Include the previous work day's time entries (default: true). Turn off for teams that don't log time"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDescription),
 		),
 	), h.handleReportsStandup)
 
+	s.AddTool(mcp.NewTool("me_workload",
+		mcp.WithDescription("Summarize a user's current workload in one call: open issues by status and priority, overdue and due-soon issues, "+
+			"hours logged this week vs last week, and the most recently updated assigned issues"),
+		mcp.WithString("user",
+			mcp.Description("User name or ID (default: 'me')"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDescription),
+		),
+	), h.handleMeWorkload)
+
 	s.AddTool(mcp.NewTool("reports_project_analysis",
 		mcp.WithDescription("Generate comprehensive project analysis report with time tracking, issue statistics, and custom field breakdowns. Useful for project retrospectives and resource planning."),
 		mcp.WithString("project",
@@ -1032,6 +1825,86 @@ func (h *ToolHandlers) RegisterTools(s McpServer) {
 			mcp.Description("Where to save the report: 'dmsf' (DMSF plugin, recommended), 'dmsf:FolderID', 'files', 'files:VersionName', 'issue:ID'. If omitted, returns data directly"),
 		),
 	), h.handleReportsProjectAnalysis)
+
+	s.AddTool(mcp.NewTool("reports_capacity",
+		mcp.WithDescription("Per-user capacity report: logged hours vs expected working hours (work days × hours per day). Sorted by largest under-logging first."),
+		mcp.WithString("project",
+			mcp.Description("Project name or ID. Project members without time entries are included with 0 hours"),
+		),
+		mcp.WithString("from",
+			mcp.Description("Start date (YYYY-MM-DD, or relative: today, yesterday, '7 days ago', start_of_month, 2024-W12, ...)"),
+		),
+		mcp.WithString("to",
+			mcp.Description("End date (YYYY-MM-DD, or relative like from)"),
+		),
+		mcp.WithString("period",
+			mcp.Description("Date shortcut: this_week (default), last_week, this_month, last_month, this_quarter, last_quarter, this_year, last_year, last_7_days, last_30_days"),
+		),
+		mcp.WithNumber("expected_hours_per_day",
+			mcp.Description("Expected working hours per day (default: 8)"),
+		),
+		mcp.WithArray("exclude_dates",
+			mcp.Description("Dates to exclude from working days, e.g. public holidays (YYYY-MM-DD)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDescription),
+		),
+	), h.handleReportsCapacity)
+
+	s.AddTool(mcp.NewTool("reports_estimateVsActual",
+		mcp.WithDescription("Compare estimated hours with spent hours per issue, aggregated per version and assignee. Flags issues that are over estimate."),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("version",
+			mcp.Description("Only include issues in this version/milestone (name or ID)"),
+		),
+		mcp.WithString("status",
+			mcp.Description("Issue status filter: 'all' (default), 'open', 'closed'"),
+			mcp.Enum("all", "open", "closed"),
+		),
+		mcp.WithString("from",
+			mcp.Description("Only count time logged on or after this date (YYYY-MM-DD)"),
+		),
+		mcp.WithString("to",
+			mcp.Description("Only count time logged on or before this date (YYYY-MM-DD)"),
+		),
+		mcp.WithNumber("over_threshold_percent",
+			mcp.Description("Flag issues whose spent hours exceed the estimate by more than this percentage (default: 20)"),
+		),
+	), h.handleReportsEstimateVsActual)
+
+	s.AddTool(mcp.NewTool("reports_burndown",
+		mcp.WithDescription(fmt.Sprintf("Burndown: remaining open issues or estimated hours at the end of each day, with the ideal line. "+
+			"Redmine keeps no daily snapshots, so the series is rebuilt from each issue's created_on and closed_on: reopened issues count as never closed, "+
+			"issues count from their creation even if they joined the version later, and days are UTC. precise replays the status changes "+
+			"in the journals of up to %d closed issues instead", redmine.MaxBurndownJournalIssues)),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name or ID"),
+		),
+		mcp.WithString("version",
+			mcp.Description("Only issues in this version (name or ID). from then defaults to the version's creation and to to its due date, if already past"),
+		),
+		mcp.WithString("from",
+			mcp.Description("First day (YYYY-MM-DD or relative like '14 days ago'; default: 30 days before to)"),
+		),
+		mcp.WithString("to",
+			mcp.Description("Last day (YYYY-MM-DD or relative; default: today)"),
+		),
+		mcp.WithString("metric",
+			mcp.Description("What remains: issue_count (default) or estimated_hours"),
+			mcp.Enum(redmine.BurndownIssueCount, redmine.BurndownEstimatedHours),
+		),
+		mcp.WithBoolean("precise",
+			mcp.Description("Replay status changes from the journals of closed issues, catching reopened issues (default: false; one request per closed issue)"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description(timezoneDescription),
+		),
+	), h.handleReportsBurndown)
 }
 
 // McpServer interface for registering tools
@@ -1044,7 +1917,7 @@ type McpServer interface {
 func (h *ToolHandlers) handleMe(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	user, err := h.client.GetCurrentUser()
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get current user: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get current user: %v", err)), nil
 	}
 
 	result := map[string]any{
@@ -1062,9 +1935,9 @@ func (h *ToolHandlers) handleMe(ctx context.Context, req mcp.CallToolRequest) (*
 func (h *ToolHandlers) handleProjectsList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	limit := req.GetInt("limit", 100)
 
-	projects, err := h.client.ListProjects(limit)
+	projects, total, err := h.client.ListProjects(limit)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list projects: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list projects: %v", err)), nil
 	}
 
 	result := make([]map[string]any, len(projects))
@@ -1073,6 +1946,7 @@ func (h *ToolHandlers) handleProjectsList(ctx context.Context, req mcp.CallToolR
 			"id":          p.ID,
 			"name":        p.Name,
 			"identifier":  p.Identifier,
+			"url":         h.client.Links().Project(p.Identifier),
 			"description": p.Description,
 		}
 		if p.Parent != nil {
@@ -1083,14 +1957,13 @@ func (h *ToolHandlers) handleProjectsList(ctx context.Context, req mcp.CallToolR
 		}
 	}
 
-	return jsonResult(map[string]any{
+	return jsonResult(paginate(map[string]any{
 		"projects": result,
-		"count":    len(projects),
-	})
+	}, len(projects), 0, limit, total))
 }
 
 func (h *ToolHandlers) handleProjectsCreate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+	if err := h.checkWrite("projects_create"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
@@ -1110,13 +1983,13 @@ func (h *ToolHandlers) handleProjectsCreate(ctx context.Context, req mcp.CallToo
 	if parent := req.GetString("parent", ""); parent != "" {
 		parentID, err = h.resolver.ResolveProject(parent)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve parent project: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve parent project: %v", err)), nil
 		}
 	}
 
 	project, err := h.client.CreateProject(name, identifier, description, parentID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create project: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create project: %v", err)), nil
 	}
 
 	return jsonResult(map[string]any{
@@ -1126,13 +1999,14 @@ func (h *ToolHandlers) handleProjectsCreate(ctx context.Context, req mcp.CallToo
 	})
 }
 
-func (h *ToolHandlers) handleIssuesSearch(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// issueSearchParams builds search parameters from the issues_search filter arguments
+func (h *ToolHandlers) issueSearchParams(req mcp.CallToolRequest) (redmine.SearchIssuesParams, error) {
 	params := redmine.SearchIssuesParams{}
 
 	if project := req.GetString("project", ""); project != "" {
 		projectID, err := h.resolver.ResolveProject(project)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+			return params, i18n.Errorf("Failed to resolve project: %v", err)
 		}
 		params.ProjectID = strconv.Itoa(projectID)
 	}
@@ -1140,15 +2014,16 @@ func (h *ToolHandlers) handleIssuesSearch(ctx context.Context, req mcp.CallToolR
 	if tracker := req.GetString("tracker", ""); tracker != "" {
 		trackerID, err := h.resolver.ResolveTracker(tracker)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve tracker: %v", err)), nil
+			return params, i18n.Errorf("Failed to resolve tracker: %v", err)
 		}
 		params.TrackerID = trackerID
 	}
 
-	if status := req.GetString("status", ""); status != "" {
+	defaults := h.toolDefault(req)
+	if status := req.GetString("status", defaults.Status); status != "" {
 		statusID, err := h.resolver.ResolveStatus(status)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve status: %v", err)), nil
+			return params, i18n.Errorf("Failed to resolve status: %v", err)
 		}
 		params.StatusID = statusID
 	} else {
@@ -1165,1745 +2040,3588 @@ func (h *ToolHandlers) handleIssuesSearch(ctx context.Context, req mcp.CallToolR
 			}
 			userID, err := h.resolver.ResolveUser(assignedTo, projectID)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve user: %v", err)), nil
+				return params, i18n.Errorf("Failed to resolve user: %v", err)
 			}
 			params.AssignedToID = strconv.Itoa(userID)
 		}
 	}
 
+	if watchedBy := req.GetString("watched_by", ""); watchedBy != "" {
+		if watchedBy == "me" {
+			params.WatcherID = "me"
+		} else {
+			var projectID int
+			if params.ProjectID != "" {
+				projectID, _ = strconv.Atoi(params.ProjectID)
+			}
+			userID, err := h.resolver.ResolveUser(watchedBy, projectID)
+			if err != nil {
+				return params, i18n.Errorf("Failed to resolve watcher: %v", err)
+			}
+			params.WatcherID = strconv.Itoa(userID)
+		}
+	}
+
 	// Subject keyword search
 	params.Subject = req.GetString("subject", "")
 
 	params.ParentID = req.GetInt("parent_id", 0)
 
 	// Date filters: convert user-friendly params to Redmine filter syntax
-	if after := req.GetString("updated_after", ""); after != "" {
-		params.UpdatedOn = ">=" + after
-	}
-	if before := req.GetString("updated_before", ""); before != "" {
-		if params.UpdatedOn != "" {
-			// Both after and before: use range syntax "><start|end"
-			after := req.GetString("updated_after", "")
-			params.UpdatedOn = "><" + after + "|" + before
-		} else {
-			params.UpdatedOn = "<=" + before
-		}
+	var err error
+	if params.UpdatedOn, err = h.dateFilterArg(req, "updated_after", "updated_before"); err != nil {
+		return params, err
 	}
-	if after := req.GetString("created_after", ""); after != "" {
-		params.CreatedOn = ">=" + after
-	}
-	if before := req.GetString("created_before", ""); before != "" {
-		if params.CreatedOn != "" {
-			after := req.GetString("created_after", "")
-			params.CreatedOn = "><" + after + "|" + before
-		} else {
-			params.CreatedOn = "<=" + before
-		}
+	if params.CreatedOn, err = h.dateFilterArg(req, "created_after", "created_before"); err != nil {
+		return params, err
 	}
 
-	params.Sort = req.GetString("sort", "")
+	projectID, _ := strconv.Atoi(params.ProjectID)
+	if params.Sort, err = h.sortArg(req, projectID, params.TrackerID); err != nil {
+		return params, err
+	}
 
 	// Custom field filter: resolve field names to IDs
 	if cfFilter := getMapArg(req, "custom_fields"); cfFilter != nil {
-		params.CustomFieldFilter = make(map[string]string)
+		params.CustomFieldFilter = make(map[string]redmine.CustomFieldFilter)
 		for nameOrID, value := range cfFilter {
 			cfID, err := h.resolver.ResolveCustomFieldByName(nameOrID, 0, 0)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve custom field '%s': %v", nameOrID, err)), nil
+				return params, i18n.Errorf("Failed to resolve custom field '%s': %v", nameOrID, err)
+			}
+			filter, err := parseCustomFieldFilter(value)
+			if err != nil {
+				return params, i18n.Errorf("Invalid filter for custom field '%s': %v", nameOrID, err)
 			}
-			params.CustomFieldFilter[strconv.Itoa(cfID)] = fmt.Sprintf("%v", value)
+			params.CustomFieldFilter[strconv.Itoa(cfID)] = filter
 		}
 	}
 
-	params.Limit = req.GetInt("limit", 25)
-	params.Offset = req.GetInt("offset", 0)
+	return params, nil
+}
 
-	issues, total, err := h.client.SearchIssues(params)
+// sortArg validates the sort argument, or the tool's configured default sort,
+// resolving custom field names in the project and tracker
+func (h *ToolHandlers) sortArg(req mcp.CallToolRequest, projectID, trackerID int) (string, error) {
+	sort, err := redmine.ParseIssueSort(req.GetString("sort", h.toolDefault(req).Sort), func(name string) (int, error) {
+		return h.resolver.ResolveCustomFieldByName(name, projectID, trackerID)
+	})
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to search issues: %v", err)), nil
-	}
-
-	result := make([]map[string]any, len(issues))
-	for i, issue := range issues {
-		result[i] = formatIssue(issue)
+		return "", i18n.Errorf("Invalid sort: %v", err)
 	}
-
-	return jsonResult(map[string]any{
-		"issues":      result,
-		"count":       len(issues),
-		"total_count": total,
-	})
+	return sort, nil
 }
 
-func (h *ToolHandlers) handleIssuesGetById(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	issueIDFloat, err := req.RequireFloat("issue_id")
+// dateFilterArg converts an after/before argument pair to a Redmine date
+// filter: ">=after", "<=before" or "><after|before" when both are given
+func (h *ToolHandlers) dateFilterArg(req mcp.CallToolRequest, afterKey, beforeKey string) (string, error) {
+	after, err := h.dateArg(req, afterKey, false)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return "", err
 	}
-	issueID := int(issueIDFloat)
-
-	issue, err := h.client.GetIssue(issueID)
+	before, err := h.dateArg(req, beforeKey, true)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get issue: %v", err)), nil
+		return "", err
 	}
-
-	result := formatIssueDetail(*issue)
-
-	// Add allowed_statuses from workflow rules (Redmine pre-5.0 doesn't provide this)
-	if h.workflow != nil && len(issue.AllowedStatuses) == 0 {
-		if allowed := h.workflow.GetAllowedStatuses(issue.Tracker.ID, issue.Status.ID); len(allowed) > 0 {
-			statuses := make([]map[string]any, len(allowed))
-			for i, s := range allowed {
-				statuses[i] = map[string]any{"id": s.ID, "name": s.Name}
-			}
-			result["allowed_statuses"] = statuses
-		}
+	switch {
+	case after != "" && before != "":
+		return "><" + after + "|" + before, nil
+	case after != "":
+		return ">=" + after, nil
+	case before != "":
+		return "<=" + before, nil
 	}
-
-	return jsonResult(result)
+	return "", nil
 }
 
-func (h *ToolHandlers) handleIssuesCreate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+func (h *ToolHandlers) handleIssuesSearch(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	includeCounts := req.GetBool("include_counts", false)
+	if req.GetString("query", "") != "" || req.GetInt("query_id", 0) > 0 {
+		return h.searchIssuesByQuery(ctx, req, includeCounts)
 	}
 
-	project, err := req.RequireString("project")
+	params, err := h.issueSearchParams(req)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	tracker, err := req.RequireString("tracker")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
+	params.Limit = h.limitArg(req, 25)
+	params.Offset = req.GetInt("offset", 0)
 
-	subject, err := req.RequireString("subject")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	if text := strings.TrimSpace(req.GetString("text", "")); text != "" {
+		return h.searchIssuesText(ctx, text, params, includeCounts)
 	}
 
-	projectID, err := h.resolver.ResolveProject(project)
+	issues, total, err := h.client.SearchIssuesAll(params)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to search issues: %v", err)), nil
 	}
 
-	trackerID, err := h.resolver.ResolveTracker(tracker)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve tracker: %v", err)), nil
+	result := make([]map[string]any, len(issues))
+	for i, issue := range issues {
+		result[i] = formatIssue(issue, h.client.Links())
 	}
-
-	params := redmine.CreateIssueParams{
-		ProjectID: projectID,
-		TrackerID: trackerID,
-		Subject:   subject,
+	if includeCounts {
+		if err := h.addIssueCounts(ctx, issues, result); err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to add counts: %v", err)), nil
+		}
 	}
 
-	params.Description = req.GetString("description", "")
+	return jsonResult(paginate(map[string]any{
+		"issues":          result,
+		"applied_filters": appliedFilters(params),
+	}, len(issues), params.Offset, params.Limit, total))
+}
 
-	if assignedTo := req.GetString("assigned_to", ""); assignedTo != "" {
-		userID, err := h.resolver.ResolveUser(assignedTo, projectID)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve assignee: %v", err)), nil
-		}
-		params.AssignedToID = userID
-	}
+// sortDescription documents the sort argument of the issue search tools
+var sortDescription = "Comma-separated sort keys, each a field with optional :desc or :asc (e.g., 'priority:desc,updated_on:desc'). " +
+	"Fields: " + strings.Join(redmine.IssueSortFields, ", ") + ", or a custom field name or cf_<id>"
 
-	params.ParentIssueID = req.GetInt("parent_issue_id", 0)
-	params.StartDate = req.GetString("start_date", "")
-	params.DueDate = req.GetString("due_date", "")
+// savedQueryIgnoredFilters are the issues_search arguments Redmine ignores
+// when a saved query is given
+var savedQueryIgnoredFilters = []string{
+	"tracker", "status", "assigned_to", "watched_by", "subject", "parent_id", "updated_after", "updated_before",
+	"created_after", "created_before", "custom_fields", "text",
+}
 
-	if args := req.GetArguments(); args != nil {
-		if v, ok := args["is_private"]; ok {
-			if b, ok := v.(bool); ok {
-				params.IsPrivate = &b
-			}
+// searchIssuesByQuery answers issues_search for a saved query. Redmine applies
+// the query's filters instead of the request's, so filters given alongside it
+// are dropped and reported back.
+func (h *ToolHandlers) searchIssuesByQuery(ctx context.Context, req mcp.CallToolRequest, includeCounts bool) (*mcp.CallToolResult, error) {
+	params := redmine.SearchIssuesParams{
+		Limit:  h.limitArg(req, 25),
+		Offset: req.GetInt("offset", 0),
+	}
+
+	var projectID int
+	if project := req.GetString("project", ""); project != "" {
+		var err error
+		projectID, err = h.resolver.ResolveProject(project)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 		}
+		params.ProjectID = strconv.Itoa(projectID)
 	}
 
-	customFields := getMapArg(req, "custom_fields")
-	if customFields == nil {
-		customFields = map[string]any{}
+	params.QueryID = req.GetInt("query_id", 0)
+	if params.QueryID == 0 {
+		queryID, err := h.resolver.ResolveQuery(req.GetString("query", ""), projectID)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve query: %v", err)), nil
+		}
+		params.QueryID = queryID
 	}
-	resolved, err := h.resolveCustomFields(customFields, projectID, trackerID)
-	if err != nil {
+
+	var err error
+	if params.Sort, err = h.sortArg(req, projectID, 0); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	params.CustomFields = resolved
 
-	if tokens := getArrayArg(req, "upload_tokens"); tokens != nil {
-		uploads, err := parseUploadTokens(tokens)
-		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
+	args := req.GetArguments()
+	var ignored []string
+	for _, name := range savedQueryIgnoredFilters {
+		if v, ok := args[name]; ok && v != nil && v != "" {
+			ignored = append(ignored, name)
 		}
-		params.Uploads = uploads
 	}
 
-	issue, err := h.client.CreateIssue(params)
+	issues, total, err := h.client.SearchIssuesAll(params)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create issue: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to search issues: %v", err)), nil
 	}
 
-	return jsonResult(formatIssue(*issue))
-}
-
-func (h *ToolHandlers) handleIssuesUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	result := make([]map[string]any, len(issues))
+	for i, issue := range issues {
+		result[i] = formatIssue(issue, h.client.Links())
 	}
-
-	issueIDFloat, err := req.RequireFloat("issue_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	if includeCounts {
+		if err := h.addIssueCounts(ctx, issues, result); err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to add counts: %v", err)), nil
+		}
 	}
-	issueID := int(issueIDFloat)
 
-	params := redmine.UpdateIssueParams{
-		IssueID: issueID,
+	response := paginate(map[string]any{
+		"issues":          result,
+		"query_id":        params.QueryID,
+		"applied_filters": appliedFilters(params),
+	}, len(issues), params.Offset, params.Limit, total)
+	if len(ignored) > 0 {
+		response["ignored_filters"] = ignored
+		response["note"] = i18n.Sprintf("Redmine applies the saved query's own filters, so these filters were not used: %s", strings.Join(ignored, ", "))
 	}
+	return jsonResult(response)
+}
 
-	// Get issue to resolve project context for user lookup
-	issue, err := h.client.GetIssue(issueID)
+// searchIssuesText answers issues_search when text is given, paginating the
+// filtered matches locally
+func (h *ToolHandlers) searchIssuesText(ctx context.Context, text string, params redmine.SearchIssuesParams, includeCounts bool) (*mcp.CallToolResult, error) {
+	matches, capped, err := h.searchIssuesByText(text, params)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get issue: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to search issues: %v", err)), nil
 	}
 
-	params.Subject = req.GetString("subject", "")
-	params.Description = req.GetString("description", "")
-	params.StartDate = req.GetString("start_date", "")
-	params.DueDate = req.GetString("due_date", "")
+	total := len(matches)
+	start := min(max(params.Offset, 0), total)
+	end := min(start+max(params.Limit, 0), total)
+	issues := matches[start:end]
 
-	if priority := req.GetString("priority", ""); priority != "" {
-		priorityID, err := h.resolver.ResolvePriority(priority)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve priority: %v", err)), nil
-		}
-		params.PriorityID = priorityID
+	result := make([]map[string]any, len(issues))
+	for i, issue := range issues {
+		result[i] = formatIssue(issue, h.client.Links())
 	}
-
-	if tracker := req.GetString("tracker", ""); tracker != "" {
-		trackerID, err := h.resolver.ResolveTracker(tracker)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve tracker: %v", err)), nil
+	if includeCounts {
+		if err := h.addIssueCounts(ctx, issues, result); err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to add counts: %v", err)), nil
 		}
-		params.TrackerID = trackerID
 	}
 
-	if status := req.GetString("status", ""); status != "" {
-		statusID, err := h.resolver.ResolveStatusID(status)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve status: %v", err)), nil
-		}
-		if err := h.workflow.ValidateTransition(issue.Tracker.ID, issue.Status.ID, statusID); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Invalid status transition: %v", err)), nil
-		}
-		params.StatusID = statusID
+	response := paginate(map[string]any{
+		"issues":          result,
+		"applied_filters": appliedFilters(params),
+	}, len(issues), start, params.Limit, total)
+	if capped {
+		response["note"] = i18n.Sprintf("Only the first %d text matches were filtered; narrow the text or add a project to see the rest", maxTextSearchMatches)
 	}
+	return jsonResult(response)
+}
 
-	if assignedTo := req.GetString("assigned_to", ""); assignedTo != "" {
-		userID, err := h.resolver.ResolveUser(assignedTo, issue.Project.ID)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve assignee: %v", err)), nil
-		}
-		params.AssignedToID = userID
+func (h *ToolHandlers) handleIssuesStats(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groupBy, err := req.RequireString("group_by")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	groupBy = strings.TrimSpace(groupBy)
+	if lower := strings.ToLower(groupBy); slices.Contains(issueStatsFields, lower) {
+		groupBy = lower
 	}
 
-	params.Notes = req.GetString("notes", "")
-
-	// done_ratio and is_private: check if explicitly provided
-	if args := req.GetArguments(); args != nil {
-		if v, ok := args["done_ratio"]; ok {
-			if f, ok := v.(float64); ok {
-				ratio := int(f)
-				params.DoneRatio = &ratio
-			}
-		}
-		if v, ok := args["is_private"]; ok {
-			if b, ok := v.(bool); ok {
-				params.IsPrivate = &b
-			}
-		}
+	params, err := h.issueSearchParams(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	if customFields := getMapArg(req, "custom_fields"); customFields != nil {
-		resolved, err := h.resolveCustomFields(customFields, issue.Project.ID, issue.Tracker.ID)
-		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
-		}
-		params.CustomFields = resolved
+	issues, total, err := h.fetchAllIssues(params)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to search issues: %v", err)), nil
 	}
 
-	if tokens := getArrayArg(req, "upload_tokens"); tokens != nil {
-		uploads, err := parseUploadTokens(tokens)
-		if err != nil {
-			return mcp.NewToolResultError(err.Error()), nil
-		}
-		params.Uploads = uploads
+	groups, err := buildIssueStats(issues, groupBy)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
+	// Every matching issue is counted, page by page
+	applied := appliedFilters(params)
+	delete(applied, "limit")
 
-	if err := h.client.UpdateIssue(params); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update issue: %v", err)), nil
+	result := map[string]any{
+		"group_by":        groupBy,
+		"groups":          groups,
+		"group_count":     len(groups),
+		"total":           len(issues),
+		"applied_filters": applied,
 	}
+	if note := cappedIssuesNote(len(issues), total); note != "" {
+		result["note"] = note
+	}
+	return jsonResult(result)
+}
 
-	return jsonResult(map[string]any{
-		"success":  true,
-		"issue_id": issueID,
-		"message":  "Issue updated successfully",
-	})
+// fetchAllIssues fetches the issues matching params, up to
+// redmine.MaxSearchIssuesLimit, and how many match in all
+func (h *ToolHandlers) fetchAllIssues(params redmine.SearchIssuesParams) ([]redmine.Issue, int, error) {
+	params.Limit = redmine.MaxSearchIssuesLimit
+	params.Offset = 0
+	return h.client.SearchIssuesAll(params)
 }
 
-func (h *ToolHandlers) handleIssuesCreateSubtask(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+// cappedIssuesNote explains a result computed from the first fetched of total
+// matching issues, or returns "" when every issue was fetched
+func cappedIssuesNote(fetched, total int) string {
+	if fetched >= total {
+		return ""
 	}
+	return i18n.Sprintf("Only the first %d of %d matching issues were fetched; narrow the filters to cover the rest", fetched, total)
+}
 
-	parentIDFloat, err := req.RequireFloat("parent_issue_id")
+func (h *ToolHandlers) handleIssuesGetTree(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	issueIDFloat, err := req.RequireFloat("issue_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	parentID := int(parentIDFloat)
+	maxDepth := req.GetInt("max_depth", 3)
+	if maxDepth < 0 {
+		return mcp.NewToolResultError(i18n.T("max_depth must be 0 or greater")), nil
+	}
 
-	subject, err := req.RequireString("subject")
+	root, err := h.client.GetIssue(int(issueIDFloat))
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get issue: %v", err)), nil
 	}
 
-	// Get parent issue to get project and default tracker
-	parent, err := h.client.GetIssue(parentID)
+	tree, fetched, truncated, err := h.buildIssueTree(*root, maxDepth, maxTreeIssues)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get parent issue: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to fetch subtasks: %v", err)), nil
 	}
 
-	params := redmine.CreateIssueParams{
-		ProjectID:     parent.Project.ID,
-		TrackerID:     parent.Tracker.ID,
-		Subject:       subject,
-		ParentIssueID: parentID,
+	result, _, _ := formatIssueTree(tree)
+	response := map[string]any{
+		"tree":          result,
+		"max_depth":     maxDepth,
+		"fetched_count": fetched,
+		"is_truncated":  truncated,
+	}
+	if truncated {
+		response["note"] = i18n.Sprintf("Stopped after fetching %d issues; reduce max_depth or query a deeper subtask directly", maxTreeIssues)
 	}
+	return jsonResult(response)
+}
 
-	if tracker := req.GetString("tracker", ""); tracker != "" {
-		trackerID, err := h.resolver.ResolveTracker(tracker)
+func (h *ToolHandlers) handleIssuesSchedule(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	format := req.GetString("format", "json")
+	if format != "json" && format != "csv" {
+		return mcp.NewToolResultError(i18n.Sprintf("Invalid format '%s' (valid: json, csv)", format)), nil
+	}
+
+	// Relations come back with the issue list, so one paginated pass is enough
+	params := redmine.SearchIssuesParams{
+		ProjectID: strconv.Itoa(projectID),
+		StatusID:  "open",
+		Sort:      "start_date,id",
+		Include:   "relations",
+	}
+	if version := req.GetString("version", ""); version != "" {
+		versionID, err := h.resolver.ResolveVersion(version, projectID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve tracker: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve version: %v", err)), nil
 		}
-		params.TrackerID = trackerID
+		params.VersionID = strconv.Itoa(versionID)
 	}
 
-	params.Description = req.GetString("description", "")
-	params.StartDate = req.GetString("start_date", "")
-	params.DueDate = req.GetString("due_date", "")
+	issues, total, err := h.fetchAllIssues(params)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to search issues: %v", err)), nil
+	}
 
-	if assignedTo := req.GetString("assigned_to", ""); assignedTo != "" {
-		userID, err := h.resolver.ResolveUser(assignedTo, parent.Project.ID)
+	if format == "csv" {
+		csvData, err := scheduleCSV(issues)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve assignee: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to generate CSV: %v", err)), nil
 		}
-		params.AssignedToID = userID
+		return mcp.NewToolResultText(string(csvData)), nil
 	}
 
-	if priority := req.GetString("priority", ""); priority != "" {
-		priorityID, err := h.resolver.ResolvePriority(priority)
+	result := make([]map[string]any, len(issues))
+	for i, issue := range issues {
+		result[i] = formatScheduleIssue(issue)
+	}
+
+	response := paginate(map[string]any{
+		"issues": result,
+	}, len(issues), 0, redmine.MaxSearchIssuesLimit, total)
+	if note := cappedIssuesNote(len(issues), total); note != "" {
+		response["note"] = note
+	}
+	return jsonResult(response)
+}
+
+func (h *ToolHandlers) handleIssuesDueSoon(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	withinDays := req.GetInt("within_days", 7)
+	if withinDays < 0 {
+		return mcp.NewToolResultError(i18n.T("within_days must be 0 or greater")), nil
+	}
+
+	params := redmine.SearchIssuesParams{StatusID: "open"}
+	if req.GetBool("include_closed", false) {
+		params.StatusID = "*"
+	}
+
+	var projectID int
+	if project := req.GetString("project", ""); project != "" {
+		id, err := h.resolver.ResolveProject(project)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve priority: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 		}
-		params.PriorityID = priorityID
+		projectID = id
+		params.ProjectID = strconv.Itoa(projectID)
 	}
 
-	if args := req.GetArguments(); args != nil {
-		if v, ok := args["is_private"]; ok {
-			if b, ok := v.(bool); ok {
-				params.IsPrivate = &b
+	if assignedTo := req.GetString("assigned_to", ""); assignedTo != "" {
+		if assignedTo == "me" {
+			params.AssignedToID = "me"
+		} else {
+			userID, err := h.resolver.ResolveUser(assignedTo, projectID)
+			if err != nil {
+				return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve user: %v", err)), nil
 			}
+			params.AssignedToID = strconv.Itoa(userID)
 		}
 	}
 
-	customFields := getMapArg(req, "custom_fields")
-	if customFields == nil {
-		customFields = map[string]any{}
-	}
-	resolved, err := h.resolveCustomFields(customFields, parent.Project.ID, params.TrackerID)
+	now, err := h.now(req)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	params.CustomFields = resolved
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	overdueFilter, dueSoonFilter := dueDateFilters(today, withinDays)
 
-	issue, err := h.client.CreateIssue(params)
+	params.DueDate = overdueFilter
+	overdueIssues, overdueTotal, err := h.fetchAllIssues(params)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create subtask: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to search overdue issues: %v", err)), nil
+	}
+	overdue := formatDueIssues(overdueIssues, today, true, h.client.Links())
+
+	note := cappedIssuesNote(len(overdueIssues), overdueTotal)
+
+	dueSoon := []map[string]any{}
+	if dueSoonFilter != "" {
+		params.DueDate = dueSoonFilter
+		dueSoonIssues, dueSoonTotal, err := h.fetchAllIssues(params)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to search issues due soon: %v", err)), nil
+		}
+		dueSoon = formatDueIssues(dueSoonIssues, today, false, h.client.Links())
+		if note == "" {
+			note = cappedIssuesNote(len(dueSoonIssues), dueSoonTotal)
+		}
 	}
 
-	return jsonResult(formatIssue(*issue))
+	result := map[string]any{
+		"today":          today.Format("2006-01-02"),
+		"within_days":    withinDays,
+		"overdue":        overdue,
+		"overdue_count":  len(overdue),
+		"due_soon":       dueSoon,
+		"due_soon_count": len(dueSoon),
+	}
+	if note != "" {
+		result["note"] = note
+	}
+	return jsonResult(result)
 }
 
-func (h *ToolHandlers) handleIssuesAddWatcher(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+func (h *ToolHandlers) handleIssuesMyWatched(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params := redmine.SearchIssuesParams{
+		StatusID:  "open",
+		WatcherID: "me",
+		Sort:      "updated_on:desc",
+		Offset:    req.GetInt("offset", 0),
+	}
+	// Report the page SearchIssuesAll fetches, whose limit is capped
+	params.Limit = min(req.GetInt("limit", 25), redmine.MaxSearchIssuesLimit)
+	if params.Limit <= 0 {
+		params.Limit = 25
 	}
 
-	issueIDFloat, err := req.RequireFloat("issue_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	if project := req.GetString("project", ""); project != "" {
+		projectID, err := h.resolver.ResolveProject(project)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+		}
+		params.ProjectID = strconv.Itoa(projectID)
 	}
-	issueID := int(issueIDFloat)
 
-	user, err := req.RequireString("user")
+	after, err := h.dateArg(req, "updated_after", false)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-
-	// Get issue to resolve project context
-	issue, err := h.client.GetIssue(issueID)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get issue: %v", err)), nil
+	if after != "" {
+		params.UpdatedOn = ">=" + after
 	}
 
-	userID, err := h.resolver.ResolveUser(user, issue.Project.ID)
+	issues, total, err := h.client.SearchIssuesAll(params)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve user: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to search watched issues: %v", err)), nil
 	}
 
-	if err := h.client.AddWatcher(issueID, userID); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to add watcher: %v", err)), nil
+	result := make([]map[string]any, len(issues))
+	for i, issue := range issues {
+		result[i] = formatIssue(issue, h.client.Links())
 	}
 
-	return jsonResult(map[string]any{
-		"success":  true,
-		"issue_id": issueID,
-		"user_id":  userID,
-		"message":  "Watcher added successfully",
-	})
+	return jsonResult(paginate(map[string]any{
+		"issues": result,
+	}, len(issues), params.Offset, params.Limit, total))
 }
 
-func (h *ToolHandlers) handleIssuesAddRelation(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
+func (h *ToolHandlers) handleIssuesGetById(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	issueIDFloat, err := req.RequireFloat("issue_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 	issueID := int(issueIDFloat)
 
-	issueToIDFloat, err := req.RequireFloat("issue_to_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	format := req.GetString("format", "json")
+	if format != "json" && format != "markdown" {
+		return mcp.NewToolResultError(i18n.Sprintf("invalid format: %s (use json or markdown)", format)), nil
 	}
-	issueToID := int(issueToIDFloat)
 
-	relationType, err := req.RequireString("relation_type")
+	issue, err := h.client.GetIssue(issueID)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get issue: %v", err)), nil
 	}
 
-	relation, err := h.client.CreateRelation(issueID, issueToID, relationType)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create relation: %v", err)), nil
+	h.resolver.RecordAllowedStatuses(issue)
+
+	var hiddenNotes int
+	if !req.GetBool("include_private_notes", true) {
+		issue.Journals, hiddenNotes = withoutPrivateNotes(issue.Journals)
 	}
+	totalJournals := len(issue.Journals)
+	journalsOffset := max(req.GetInt("journals_offset", 0), 0)
+	issue.Journals = issue.Journals[:max(totalJournals-journalsOffset, 0)]
 
-	return jsonResult(map[string]any{
-		"id":            relation.ID,
-		"issue_id":      relation.IssueID,
-		"issue_to_id":   relation.IssueToID,
-		"relation_type": relation.RelationType,
-	})
-}
+	var spent *redmine.IssueSpentTime
+	if req.GetBool("include_spent_hours", false) {
+		if spent, err = h.client.SpentTimeOnIssue(issueID); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+	var children []redmine.IssueChild
+	if req.GetBool("include_children", false) {
+		if children, err = h.client.ChildIssues(issueID); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	if format == "markdown" {
+		return mcp.NewToolResultText(RenderIssueMarkdown(*issue, h.client.Links(), IssueMarkdownOptions{
+			Notes:     req.GetInt("notes", defaultMarkdownNotes),
+			SpentTime: spent,
+			Children:  children,
+		})), nil
+	}
+
+	result := formatIssueDetail(*issue, h.client.Links())
+	h.markAssigneeType(result)
+	if hiddenNotes > 0 {
+		result["private_notes_hidden"] = hiddenNotes
+	}
+	if journalsOffset > 0 {
+		result["journals_offset"] = journalsOffset
+		result["total_journals"] = totalJournals
+	}
+	if spent != nil {
+		result["spent_time"] = spent
+	}
+	if req.GetBool("include_children", false) {
+		result["children"] = children
+	}
+
+	// Add allowed_statuses from workflow rules (Redmine pre-5.0 doesn't provide this)
+	if h.workflow != nil && len(issue.AllowedStatuses) == 0 {
+		if allowed := h.workflow.GetAllowedStatuses(issue.Tracker.ID, issue.Status.ID); len(allowed) > 0 {
+			statuses := make([]map[string]any, len(allowed))
+			for i, s := range allowed {
+				statuses[i] = map[string]any{"id": s.ID, "name": s.Name}
+			}
+			result["allowed_statuses"] = statuses
+		}
+	}
+
+	return jsonResult(result)
+}
+
+func (h *ToolHandlers) handleIssuesHistory(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	issueIDFloat, err := req.RequireFloat("issue_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueID := int(issueIDFloat)
+
+	issue, err := h.client.GetIssue(issueID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get issue: %v", err)), nil
+	}
+
+	field := req.GetString("field", "")
+	history := buildIssueHistory(issue.Journals, h.buildHistoryLookups(issue), field)
+
+	result := map[string]any{
+		"issue_id": issue.ID,
+		"subject":  issue.Subject,
+		"history":  history,
+		"count":    len(history),
+	}
+	if field != "" {
+		result["field"] = field
+	}
+
+	return jsonResult(result)
+}
+
+func (h *ToolHandlers) handleIssuesCreate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dryRun := req.GetBool("dry_run", false)
+	if !dryRun {
+		if err := h.checkWrite("issues_create"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	project, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	tracker, err := req.RequireString("tracker")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	subject, err := req.RequireString("subject")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	projectID, err := h.resolver.ResolveProject(project)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	trackerID, err := h.resolver.ResolveTracker(tracker)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve tracker: %v", err)), nil
+	}
+
+	params := redmine.CreateIssueParams{
+		ProjectID:     projectID,
+		TrackerID:     trackerID,
+		Subject:       subject,
+		ParentIssueID: req.GetInt("parent_issue_id", 0),
+	}
+
+	problems := h.buildCreateIssueParams(req, &params)
+	if dryRun {
+		return h.createIssueDryRun(params, problems)
+	}
+	if len(problems) > 0 {
+		return mcp.NewToolResultError(strings.Join(problems, "\n")), nil
+	}
+
+	client, asUser, err := h.actingClient(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	issue, err := client.CreateIssue(params)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create issue: %v", err)), nil
+	}
+
+	result := h.createdIssueResult(params, issue)
+	if asUser != "" {
+		result["as_user"] = asUser
+	}
+	return jsonResult(result)
+}
+
+// createdIssueResult formats a newly created issue, warning about requested
+// values Redmine ignored
+func (h *ToolHandlers) createdIssueResult(params redmine.CreateIssueParams, issue *redmine.Issue) map[string]any {
+	result := formatIssue(*issue, h.client.Links())
+	h.markAssigneeType(result)
+	if warning := params.CreateWarning(issue); warning != "" {
+		result["warning"] = warning
+	}
+	return result
+}
+
+// buildCreateIssueParams resolves the optional create arguments onto params, whose
+// project and tracker must already be set. issues_create and issues_createSubtask
+// share it for both real and dry runs so validation can't diverge from what is
+// sent. It keeps going after a failure and returns every problem found.
+func (h *ToolHandlers) buildCreateIssueParams(req mcp.CallToolRequest, params *redmine.CreateIssueParams) []string {
+	var problems []string
+
+	params.Description = req.GetString("description", "")
+	params.StartDate = req.GetString("start_date", "")
+	params.DueDate = req.GetString("due_date", "")
+
+	if hours, err := estimatedHoursArg(req); err != nil {
+		problems = append(problems, err.Error())
+	} else {
+		params.EstimatedHours = hours
+	}
+
+	if assignedTo := req.GetString("assigned_to", ""); assignedTo != "" {
+		userID, err := h.resolver.ResolveAssignee(assignedTo, params.ProjectID)
+		if err != nil {
+			problems = append(problems, i18n.Sprintf("Failed to resolve assignee: %v", err))
+		}
+		params.AssignedToID = userID
+	}
+
+	if group := req.GetString("assigned_to_group", ""); group != "" {
+		if params.AssignedToID > 0 {
+			problems = append(problems, "assigned_to and assigned_to_group cannot both be set")
+		}
+		groupID, err := h.resolver.ResolveAssigneeGroup(group, params.ProjectID)
+		if err != nil {
+			problems = append(problems, i18n.Sprintf("Failed to resolve assignee group: %v", err))
+		}
+		params.AssignedToID = groupID
+	}
+
+	if priority := req.GetString("priority", ""); priority != "" {
+		priorityID, err := h.resolver.ResolvePriority(priority)
+		if err != nil {
+			problems = append(problems, i18n.Sprintf("Failed to resolve priority: %v", err))
+		}
+		params.PriorityID = priorityID
+	}
+
+	if status := req.GetString("status", ""); status != "" {
+		statusID, err := h.resolver.ResolveStatusID(status)
+		if err != nil {
+			problems = append(problems, i18n.Sprintf("Failed to resolve status: %v", err))
+		}
+		params.StatusID = statusID
+	}
+
+	if category := req.GetString("category", ""); category != "" {
+		categoryID, err := h.resolver.ResolveCategory(category, params.ProjectID)
+		if err != nil {
+			problems = append(problems, i18n.Sprintf("Failed to resolve category: %v", err))
+		}
+		params.CategoryID = categoryID
+	}
+
+	if version := req.GetString("version", ""); version != "" {
+		versionID, err := h.resolver.ResolveVersion(version, params.ProjectID)
+		if err != nil {
+			problems = append(problems, i18n.Sprintf("Failed to resolve version: %v", err))
+		}
+		params.FixedVersionID = versionID
+	}
+
+	if args := req.GetArguments(); args != nil {
+		if v, ok := args["done_ratio"]; ok {
+			if f, ok := v.(float64); ok {
+				if f < 0 || f > 100 {
+					problems = append(problems, "done_ratio must be between 0 and 100")
+				}
+				ratio := int(f)
+				params.DoneRatio = &ratio
+			}
+		}
+		if v, ok := args["is_private"]; ok {
+			if b, ok := v.(bool); ok {
+				params.IsPrivate = &b
+			}
+		}
+	}
+
+	customFields := getMapArg(req, "custom_fields")
+	if customFields == nil {
+		customFields = map[string]any{}
+	}
+	if resolved, err := h.resolveCustomFields(customFields, params.ProjectID, params.TrackerID); err != nil {
+		problems = append(problems, err.Error())
+	} else {
+		params.CustomFields = resolved
+	}
+
+	if tokens := getArrayArg(req, "upload_tokens"); tokens != nil {
+		uploads, err := parseUploadTokens(tokens)
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+		params.Uploads = uploads
+	}
+
+	return problems
+}
+
+// createIssueDryRun reports the payload a create would send, the status the new
+// issue would start in, and any problems, without calling Redmine's create API
+func (h *ToolHandlers) createIssueDryRun(params redmine.CreateIssueParams, problems []string) (*mcp.CallToolResult, error) {
+	result := map[string]any{
+		"dry_run":  true,
+		"valid":    len(problems) == 0,
+		"payload":  params.Payload(),
+		"problems": append([]string{}, problems...),
+	}
+
+	// Without an explicit status Redmine uses the tracker's default status
+	if trackers, err := h.resolver.GetTrackers(); err == nil && params.StatusID == 0 {
+		for _, t := range trackers {
+			if t.ID == params.TrackerID && t.DefaultStatus != nil {
+				result["default_status"] = map[string]any{"id": t.DefaultStatus.ID, "name": t.DefaultStatus.Name}
+			}
+		}
+	}
+
+	return jsonResult(result)
+}
+
+func (h *ToolHandlers) handleIssuesUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("issues_update"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	issueIDFloat, err := req.RequireFloat("issue_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueID := int(issueIDFloat)
+
+	params := redmine.UpdateIssueParams{
+		IssueID: issueID,
+	}
+
+	// Get issue to resolve project context for user lookup
+	issue, err := h.client.GetIssue(issueID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get issue: %v", err)), nil
+	}
+
+	if err := h.buildUpdateIssueParams(req, issue, &params); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, asUser, err := h.actingClient(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if err := client.UpdateIssue(params); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to update issue: %v", err)), nil
+	}
+
+	result := map[string]any{
+		"success":  true,
+		"issue_id": issueID,
+		"message":  i18n.T("Issue updated successfully"),
+	}
+	if params.AssignedToID > 0 {
+		result["assigned_to"] = map[string]any{"id": params.AssignedToID}
+		h.markAssigneeType(result)
+	}
+	if asUser != "" {
+		result["as_user"] = asUser
+	}
+	return jsonResult(result)
+}
+
+// markAssigneeType adds whether the assignee of a formatted issue is a user
+// or a group, since Redmine reports both the same way. The type is left out
+// when Redmine won't tell.
+func (h *ToolHandlers) markAssigneeType(result map[string]any) {
+	assignee, ok := result["assigned_to"].(map[string]any)
+	if !ok {
+		return
+	}
+	id, _ := assignee["id"].(int)
+	if typ := h.resolver.AssigneeType(id); typ != "" {
+		assignee["type"] = typ
+	}
+}
+
+// buildUpdateIssueParams resolves issues_update's optional arguments into
+// params, validating the status transition and custom fields against issue
+func (h *ToolHandlers) buildUpdateIssueParams(req mcp.CallToolRequest, issue *redmine.Issue, params *redmine.UpdateIssueParams) error {
+	params.Subject = req.GetString("subject", "")
+	params.Description = req.GetString("description", "")
+	params.StartDate = req.GetString("start_date", "")
+	params.DueDate = req.GetString("due_date", "")
+
+	params.ClearAssignee = req.GetBool("clear_assigned_to", false)
+	params.ClearDueDate = req.GetBool("clear_due_date", false)
+	params.ClearDescription = req.GetBool("clear_description", false)
+	params.ClearEstimatedHours = req.GetBool("clear_estimated_hours", false)
+	params.ClearCategory = req.GetBool("clear_category", false)
+	params.ClearFixedVersion = req.GetBool("clear_version", false)
+
+	var err error
+	if params.EstimatedHours, err = estimatedHoursArg(req); err != nil {
+		return err
+	}
+
+	if priority := req.GetString("priority", ""); priority != "" {
+		priorityID, err := h.resolver.ResolvePriority(priority)
+		if err != nil {
+			return i18n.Errorf("Failed to resolve priority: %v", err)
+		}
+		params.PriorityID = priorityID
+	}
+
+	if tracker := req.GetString("tracker", ""); tracker != "" {
+		trackerID, err := h.resolver.ResolveTracker(tracker)
+		if err != nil {
+			return i18n.Errorf("Failed to resolve tracker: %v", err)
+		}
+		params.TrackerID = trackerID
+	}
+
+	if status := req.GetString("status", ""); status != "" {
+		statusID, err := h.resolver.ResolveStatusID(status)
+		if err != nil {
+			return i18n.Errorf("Failed to resolve status: %v", err)
+		}
+		if err := h.validateTransition(issue, statusID); err != nil {
+			return i18n.Errorf("Invalid status transition: %v", err)
+		}
+		params.StatusID = statusID
+	}
+
+	if assignedTo := req.GetString("assigned_to", ""); assignedTo != "" {
+		userID, err := h.resolver.ResolveAssignee(assignedTo, issue.Project.ID)
+		if err != nil {
+			return i18n.Errorf("Failed to resolve assignee: %v", err)
+		}
+		params.AssignedToID = userID
+	}
+
+	if group := req.GetString("assigned_to_group", ""); group != "" {
+		if params.AssignedToID > 0 {
+			return i18n.Errorf("assigned_to and assigned_to_group cannot both be set")
+		}
+		groupID, err := h.resolver.ResolveAssigneeGroup(group, issue.Project.ID)
+		if err != nil {
+			return i18n.Errorf("Failed to resolve assignee group: %v", err)
+		}
+		params.AssignedToID = groupID
+	}
+
+	if category := req.GetString("category", ""); category != "" {
+		categoryID, err := h.resolver.ResolveCategory(category, issue.Project.ID)
+		if err != nil {
+			return i18n.Errorf("Failed to resolve category: %v", err)
+		}
+		params.CategoryID = categoryID
+	}
+
+	if version := req.GetString("version", ""); version != "" {
+		versionID, err := h.resolver.ResolveVersion(version, issue.Project.ID)
+		if err != nil {
+			return i18n.Errorf("Failed to resolve version: %v", err)
+		}
+		params.FixedVersionID = versionID
+	}
+
+	params.Notes = req.GetString("notes", "")
+
+	// done_ratio and is_private: check if explicitly provided
+	if args := req.GetArguments(); args != nil {
+		if v, ok := args["done_ratio"]; ok {
+			if f, ok := v.(float64); ok {
+				ratio := int(f)
+				params.DoneRatio = &ratio
+			}
+		}
+		if v, ok := args["is_private"]; ok {
+			if b, ok := v.(bool); ok {
+				params.IsPrivate = &b
+			}
+		}
+	}
+
+	if customFields := getMapArg(req, "custom_fields"); customFields != nil {
+		resolved, err := h.resolveCustomFields(customFields, issue.Project.ID, issue.Tracker.ID)
+		if err != nil {
+			return err
+		}
+		params.CustomFields = resolved
+	}
+
+	if tokens := getArrayArg(req, "upload_tokens"); tokens != nil {
+		uploads, err := parseUploadTokens(tokens)
+		if err != nil {
+			return err
+		}
+		params.Uploads = uploads
+	}
+
+	return nil
+}
+
+func (h *ToolHandlers) handleIssuesCreateSubtask(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dryRun := req.GetBool("dry_run", false)
+	if !dryRun {
+		if err := h.checkWrite("issues_createSubtask"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	parentIDFloat, err := req.RequireFloat("parent_issue_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	parentID := int(parentIDFloat)
+
+	subject, err := req.RequireString("subject")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Get parent issue to get project and default tracker
+	parent, err := h.client.GetIssue(parentID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get parent issue: %v", err)), nil
+	}
+
+	params := redmine.CreateIssueParams{
+		ProjectID:     parent.Project.ID,
+		TrackerID:     parent.Tracker.ID,
+		Subject:       subject,
+		ParentIssueID: parentID,
+	}
+
+	if tracker := req.GetString("tracker", ""); tracker != "" {
+		trackerID, err := h.resolver.ResolveTracker(tracker)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve tracker: %v", err)), nil
+		}
+		params.TrackerID = trackerID
+	}
+
+	problems := h.buildCreateIssueParams(req, &params)
+	if dryRun {
+		return h.createIssueDryRun(params, problems)
+	}
+	if len(problems) > 0 {
+		return mcp.NewToolResultError(strings.Join(problems, "\n")), nil
+	}
+
+	issue, err := h.client.CreateIssue(params)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create subtask: %v", err)), nil
+	}
+
+	return jsonResult(h.createdIssueResult(params, issue))
+}
+
+func (h *ToolHandlers) handleIssuesAddWatcher(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("issues_addWatcher"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	issueIDFloat, err := req.RequireFloat("issue_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueID := int(issueIDFloat)
+
+	user, err := req.RequireString("user")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Get issue to resolve project context
+	issue, err := h.client.GetIssue(issueID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get issue: %v", err)), nil
+	}
+
+	userID, err := h.resolver.ResolveUser(user, issue.Project.ID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve user: %v", err)), nil
+	}
+
+	if err := h.client.AddWatcher(issueID, userID); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to add watcher: %v", err)), nil
+	}
+
+	return jsonResult(map[string]any{
+		"success":  true,
+		"issue_id": issueID,
+		"user_id":  userID,
+		"message":  i18n.T("Watcher added successfully"),
+	})
+}
+
+func (h *ToolHandlers) handleIssuesAddComment(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("issues_addComment"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	issueIDFloat, err := req.RequireFloat("issue_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueID := int(issueIDFloat)
+
+	notes, err := req.RequireString("notes")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if strings.TrimSpace(notes) == "" {
+		return mcp.NewToolResultError(i18n.T("notes must not be empty")), nil
+	}
+
+	params := redmine.UpdateIssueParams{IssueID: issueID, Notes: notes}
+	private := req.GetBool("private_notes", false)
+	if private {
+		params.PrivateNotes = &private
+	}
+
+	if err := h.client.UpdateIssue(params); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to add comment: %v", err)), nil
+	}
+
+	return jsonResult(map[string]any{
+		"success":       true,
+		"issue_id":      issueID,
+		"private_notes": private,
+		"notes":         redmine.NotePreview(notes),
+		"message":       i18n.T("Comment added successfully"),
+	})
+}
+
+func (h *ToolHandlers) handleIssuesAddRelation(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("issues_addRelation"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	issueIDFloat, err := req.RequireFloat("issue_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueID := int(issueIDFloat)
+
+	issueToIDFloat, err := req.RequireFloat("issue_to_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueToID := int(issueToIDFloat)
+
+	requestedType, err := req.RequireString("relation_type")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	fromID, toID, relationType, err := redmine.NormalizeRelation(issueID, issueToID, requestedType)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var delay *int
+	if v, ok := req.GetArguments()["delay"].(float64); ok {
+		if err := redmine.ValidateRelationDelay(relationType); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		d := int(v)
+		delay = &d
+	}
+
+	relation, err := h.client.CreateRelation(fromID, toID, relationType, delay)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create relation: %v", err)), nil
+	}
+
+	_, label := relationDirection(*relation, relation.IssueID)
+	result := map[string]any{
+		"id":            relation.ID,
+		"issue_id":      relation.IssueID,
+		"issue_to_id":   relation.IssueToID,
+		"relation_type": relation.RelationType,
+		"summary":       fmt.Sprintf("#%d %s #%d", relation.IssueID, label, relation.IssueToID),
+	}
+	if relation.Delay != nil {
+		result["delay"] = *relation.Delay
+	}
+	if fromID != issueID {
+		result["swapped"] = true
+		result["requested"] = fmt.Sprintf("#%d %s #%d", issueID, requestedType, issueToID)
+		result["note"] = i18n.Sprintf("%s is stored as the inverse %s relation, so the issues were swapped", requestedType, relationType)
+	}
+	return jsonResult(result)
+}
 
 func (h *ToolHandlers) handleIssuesGetRequiredFields(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	projectStr, err := req.RequireString("project")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	trackerStr, err := req.RequireString("tracker")
+	trackerStr, err := req.RequireString("tracker")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	trackerID, err := h.resolver.ResolveTracker(trackerStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve tracker: %v", err)), nil
+	}
+
+	// Get project and tracker names for response
+	projects, _, _ := h.client.ListProjects(100)
+	trackers, _ := h.resolver.GetTrackers()
+
+	var projectName, trackerName string
+	for _, p := range projects {
+		if p.ID == projectID {
+			projectName = p.Name
+			break
+		}
+	}
+	for _, t := range trackers {
+		if t.ID == trackerID {
+			trackerName = t.Name
+			break
+		}
+	}
+
+	// Get custom fields
+	customFields, err := h.resolver.ProjectCustomFields(projectID, trackerID, h.rules)
+	if err != nil {
+		// Return partial result even if custom fields unavailable
+		return jsonResult(map[string]any{
+			"project": projectName,
+			"tracker": trackerName,
+			"required": map[string]any{
+				"standard": []string{"subject"},
+				"custom":   []any{},
+			},
+			"note": i18n.Sprintf("Could not retrieve custom fields: %v", err),
+		})
+	}
+
+	// Format custom fields
+	customFieldsList := make([]map[string]any, 0)
+	for _, cf := range customFields {
+		field := map[string]any{
+			"id":   cf.ID,
+			"name": cf.Name,
+		}
+		if cf.FieldFormat != "" && cf.FieldFormat != "unknown" {
+			field["type"] = cf.FieldFormat
+		}
+		if len(cf.PossibleValues) > 0 {
+			field["possible_values"] = cf.PossibleValues
+		}
+		customFieldsList = append(customFieldsList, field)
+	}
+
+	return jsonResult(map[string]any{
+		"project": projectName,
+		"tracker": trackerName,
+		"required": map[string]any{
+			"standard": []string{"subject"},
+			"custom":   customFieldsList,
+		},
+		"note": i18n.T("Custom fields shown are available for this project/tracker. Required status cannot be determined without admin access - try creating the issue and check error messages."),
+	})
+}
+
+func (h *ToolHandlers) handleCustomFieldsList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	var trackerID int
+	if tracker := req.GetString("tracker", ""); tracker != "" {
+		trackerID, err = h.resolver.ResolveTracker(tracker)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve tracker: %v", err)), nil
+		}
+	}
+
+	fields, err := h.resolver.ProjectCustomFields(projectID, trackerID, h.rules)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get custom fields: %v", err)), nil
+	}
+
+	// Format results
+	results := make([]map[string]any, len(fields))
+	for i, f := range fields {
+		results[i] = map[string]any{
+			"id":   f.ID,
+			"name": f.Name,
+		}
+		if f.FieldFormat != "" && f.FieldFormat != "unknown" {
+			results[i]["type"] = f.FieldFormat
+		}
+		if f.Required {
+			results[i]["required"] = true
+		}
+		if len(f.PossibleValues) > 0 {
+			results[i]["possible_values"] = f.PossibleValues
+		}
+	}
+
+	return jsonResult(wholeList(map[string]any{
+		"project_id":    projectID,
+		"tracker_id":    trackerID,
+		"custom_fields": results,
+	}, len(results)))
+}
+
+func (h *ToolHandlers) handleCustomFieldsListAll(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	fields, err := h.client.ListAllCustomFields()
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf(
+			"Requires admin privileges. Use customFields_list with a project/tracker to see fields available in a specific project (no admin required). Error: %v", err)), nil
+	}
+
+	// Optional type filter
+	typeFilter := req.GetString("type", "")
+
+	results := make([]map[string]any, 0)
+	for _, f := range fields {
+		if typeFilter != "" && f.CustomizedType != typeFilter {
+			continue
+		}
+		field := map[string]any{
+			"id":              f.ID,
+			"name":            f.Name,
+			"customized_type": f.CustomizedType,
+			"field_format":    f.FieldFormat,
+			"is_required":     f.IsRequired,
+			"visible":         f.Visible,
+		}
+		if f.Multiple {
+			field["multiple"] = true
+		}
+		if f.DefaultValue != "" {
+			field["default_value"] = f.DefaultValue
+		}
+		if len(f.PossibleValues) > 0 {
+			vals := make([]string, len(f.PossibleValues))
+			for i, v := range f.PossibleValues {
+				vals[i] = v.Value
+			}
+			field["possible_values"] = vals
+		}
+		if len(f.Trackers) > 0 {
+			trackers := make([]map[string]any, len(f.Trackers))
+			for i, t := range f.Trackers {
+				trackers[i] = map[string]any{"id": t.ID, "name": t.Name}
+			}
+			field["trackers"] = trackers
+		}
+		results = append(results, field)
+	}
+
+	return jsonResult(wholeList(map[string]any{
+		"custom_fields": results,
+	}, len(results)))
+}
+
+func (h *ToolHandlers) handleProjectsGetDetail(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	project, err := h.client.GetProjectDetail(projectID, []string{"trackers", "issue_custom_fields"})
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get project detail: %v", err)), nil
+	}
+
+	result := map[string]any{
+		"id":          project.ID,
+		"name":        project.Name,
+		"identifier":  project.Identifier,
+		"url":         h.client.Links().Project(project.Identifier),
+		"description": project.Description,
+		"status":      project.Status,
+	}
+
+	if project.Parent != nil {
+		result["parent"] = map[string]any{
+			"id":   project.Parent.ID,
+			"name": project.Parent.Name,
+		}
+	}
+
+	trackers := make([]map[string]any, len(project.Trackers))
+	for i, t := range project.Trackers {
+		trackers[i] = map[string]any{"id": t.ID, "name": t.Name}
+	}
+	result["trackers"] = trackers
+
+	customFields := make([]map[string]any, len(project.IssueCustomFields))
+	for i, cf := range project.IssueCustomFields {
+		customFields[i] = map[string]any{"id": cf.ID, "name": cf.Name}
+	}
+	result["issue_custom_fields"] = customFields
+
+	return jsonResult(result)
+}
+
+func (h *ToolHandlers) handleProjectsSummary(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	today, err := h.now(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	summary, err := redmine.BuildProjectSummary(h.client.WithContext(ctx), projectID, today)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to build project summary: %v", err)), nil
+	}
+
+	return jsonResult(summary)
+}
+
+func (h *ToolHandlers) handleProjectsUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("projects_update"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	projectStr, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	params := redmine.UpdateProjectParams{
+		ProjectID: projectID,
+	}
+
+	params.Name = req.GetString("name", "")
+	params.Description = req.GetString("description", "")
+
+	// Resolve tracker_ids (names or IDs)
+	if trackerArgs := getArrayArg(req, "tracker_ids"); trackerArgs != nil {
+		trackerIDs := make([]int, 0, len(trackerArgs))
+		for _, arg := range trackerArgs {
+			s := fmt.Sprintf("%v", arg)
+			id, err := h.resolver.ResolveTracker(s)
+			if err != nil {
+				return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve tracker '%s': %v", s, err)), nil
+			}
+			trackerIDs = append(trackerIDs, id)
+		}
+		params.TrackerIDs = trackerIDs
+	}
+
+	// Resolve issue_custom_field_ids (names or IDs)
+	if cfArgs := getArrayArg(req, "issue_custom_field_ids"); cfArgs != nil {
+		cfIDs := make([]int, 0, len(cfArgs))
+		for _, arg := range cfArgs {
+			s := fmt.Sprintf("%v", arg)
+			id, err := h.resolver.ResolveCustomFieldByName(s, projectID, 0)
+			if err != nil {
+				return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve custom field '%s': %v", s, err)), nil
+			}
+			cfIDs = append(cfIDs, id)
+		}
+		params.IssueCustomFieldIDs = cfIDs
+	}
+
+	if err := h.client.UpdateProject(params); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to update project: %v", err)), nil
+	}
+
+	// Return updated project detail
+	project, err := h.client.GetProjectDetail(projectID, []string{"trackers", "issue_custom_fields"})
+	if err != nil {
+		return jsonResult(map[string]any{
+			"success":    true,
+			"project_id": projectID,
+			"message":    i18n.T("Project updated successfully (could not fetch updated details)"),
+		})
+	}
+
+	result := map[string]any{
+		"success":    true,
+		"project_id": project.ID,
+		"name":       project.Name,
+		"message":    i18n.T("Project updated successfully"),
+	}
+
+	trackers := make([]map[string]any, len(project.Trackers))
+	for i, t := range project.Trackers {
+		trackers[i] = map[string]any{"id": t.ID, "name": t.Name}
+	}
+	result["trackers"] = trackers
+
+	customFields := make([]map[string]any, len(project.IssueCustomFields))
+	for i, cf := range project.IssueCustomFields {
+		customFields[i] = map[string]any{"id": cf.ID, "name": cf.Name}
+	}
+	result["issue_custom_fields"] = customFields
+
+	return jsonResult(result)
+}
+
+// projectActionDone words each lifecycle action in the past tense
+var projectActionDone = map[string]string{
+	"archive":   "archived",
+	"unarchive": "unarchived",
+	"close":     "closed",
+	"reopen":    "reopened",
+	"delete":    "deleted",
+}
+
+func (h *ToolHandlers) handleProjectsSetStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("projects_setStatus"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	projectStr, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	action, err := req.RequireString("action")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !slices.Contains(redmine.ProjectActions, action) {
+		return mcp.NewToolResultError(i18n.Sprintf("Invalid action %q. Valid actions: %s", action, strings.Join(redmine.ProjectActions, ", "))), nil
+	}
+	if action == "delete" && !req.GetBool("confirm", false) {
+		return mcp.NewToolResultError(i18n.T("Deleting a project permanently removes all its issues, wiki pages and files. Set confirm to true to proceed.")), nil
+	}
+
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	if err := h.client.SetProjectStatus(projectID, action); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return jsonResult(map[string]any{
+		"success":    true,
+		"project_id": projectID,
+		"action":     action,
+		"message":    i18n.Sprintf("Project %s successfully", i18n.T(projectActionDone[action])),
+	})
+}
+
+func (h *ToolHandlers) handleTimeEntriesCreate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("timeEntries_create"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	issueID := int(req.GetFloat("issue_id", 0))
+	project := req.GetString("project", "")
+	if issueID > 0 && project != "" {
+		return mcp.NewToolResultError(i18n.T("Give either issue_id or project, not both")), nil
+	}
+	if issueID <= 0 && project == "" {
+		return mcp.NewToolResultError(i18n.T("issue_id or project is required")), nil
+	}
+
+	hours, err := req.RequireFloat("hours")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	params := redmine.CreateTimeEntryParams{
+		IssueID: issueID,
+		Hours:   hours,
+	}
+	if project != "" {
+		projectID, err := h.resolver.ResolveProject(project)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+		}
+		params.ProjectID = projectID
+	}
+
+	if activity := req.GetString("activity", ""); activity != "" {
+		activityID, err := h.resolver.ResolveActivity(activity)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve activity: %v", err)), nil
+		}
+		params.ActivityID = activityID
+	}
+
+	params.Comments = req.GetString("comments", "")
+	params.SpentOn = req.GetString("spent_on", "")
+
+	client, asUser, err := h.actingClient(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	entry, err := client.CreateTimeEntry(params)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create time entry: %v", err)), nil
+	}
+
+	result := map[string]any{
+		"id":       entry.ID,
+		"project":  entry.Project.Name,
+		"hours":    entry.Hours,
+		"activity": entry.Activity.Name,
+		"comments": entry.Comments,
+		"spent_on": entry.SpentOn,
+	}
+	if issueID > 0 {
+		result["issue_id"] = issueID
+	}
+	if asUser != "" {
+		result["as_user"] = asUser
+	}
+	return jsonResult(result)
+}
+
+func (h *ToolHandlers) handleTimeEntriesCreateBatch(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("timeEntries_createBatch"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	entriesRaw := getArrayArg(req, "entries")
+	if len(entriesRaw) == 0 {
+		return mcp.NewToolResultError(i18n.T("entries is required and must be a non-empty array")), nil
+	}
+
+	// Resolve each activity name once for the whole batch
+	activityCache := make(map[string]int)
+
+	var created []map[string]any
+	var failures []map[string]any
+
+	for i, raw := range entriesRaw {
+		item, ok := raw.(map[string]any)
+		if !ok {
+			failures = append(failures, map[string]any{
+				"index": i,
+				"error": "entry must be an object",
+			})
+			continue
+		}
+
+		issueIDFloat, _ := item["issue_id"].(float64)
+		hours, _ := item["hours"].(float64)
+		if issueIDFloat <= 0 || hours <= 0 {
+			failures = append(failures, map[string]any{
+				"index": i,
+				"error": "issue_id and hours are required",
+			})
+			continue
+		}
+		issueID := int(issueIDFloat)
+
+		params := redmine.CreateTimeEntryParams{
+			IssueID: issueID,
+			Hours:   hours,
+		}
+		params.Comments, _ = item["comments"].(string)
+		params.SpentOn, _ = item["spent_on"].(string)
+
+		if activity, _ := item["activity"].(string); activity != "" {
+			activityID, ok := activityCache[activity]
+			if !ok {
+				var err error
+				activityID, err = h.resolver.ResolveActivity(activity)
+				if err != nil {
+					failures = append(failures, map[string]any{
+						"index":    i,
+						"issue_id": issueID,
+						"error":    i18n.Sprintf("Failed to resolve activity: %v", err),
+					})
+					continue
+				}
+				activityCache[activity] = activityID
+			}
+			params.ActivityID = activityID
+		}
+
+		entry, err := h.client.CreateTimeEntry(params)
+		if err != nil {
+			failures = append(failures, map[string]any{
+				"index":    i,
+				"issue_id": issueID,
+				"error":    i18n.Sprintf("Failed to create time entry: %v", err),
+			})
+			continue
+		}
+
+		created = append(created, map[string]any{
+			"index":    i,
+			"id":       entry.ID,
+			"issue_id": issueID,
+			"hours":    entry.Hours,
+			"activity": entry.Activity.Name,
+			"spent_on": entry.SpentOn,
+		})
+	}
+
+	return jsonResult(map[string]any{
+		"success": created,
+		"failed":  failures,
+	})
+}
+
+func (h *ToolHandlers) handleTimeEntriesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params := redmine.ListTimeEntriesParams{}
+
+	// Handle project
+	var projectID int
+	if project := req.GetString("project", ""); project != "" {
+		var err error
+		projectID, err = h.resolver.ResolveProject(project)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+		}
+		params.ProjectID = strconv.Itoa(projectID)
+	}
+
+	// Handle user
+	if user := req.GetString("user", ""); user != "" {
+		if user == "me" {
+			params.UserID = "me"
+		} else {
+			userID, err := h.resolver.ResolveUser(user, projectID)
+			if err != nil {
+				return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve user: %v", err)), nil
+			}
+			params.UserID = strconv.Itoa(userID)
+		}
+	}
+
+	// Handle issue_id
+	if issueID := req.GetInt("issue_id", 0); issueID > 0 {
+		params.IssueID = issueID
+	}
+
+	// Handle the date period shortcut; explicit from/to override its ends
+	var err error
+	params.From, params.To, err = h.dateRangeArgs(req, "")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Handle pagination
+	params.Limit = req.GetInt("limit", 25)
+	params.Offset = req.GetInt("offset", 0)
+
+	var entries []redmine.TimeEntry
+	var totalCount int
+	limit := params.Limit
+	if req.GetBool("fetch_all", false) {
+		limit = maxTimeEntriesFetch
+		entries, totalCount, err = h.client.FetchTimeEntries(params, maxTimeEntriesFetch)
+	} else {
+		entries, totalCount, err = h.client.ListTimeEntries(params)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list time entries: %v", err)), nil
+	}
+
+	// Format results
+	results := make([]map[string]any, len(entries))
+	for i, entry := range entries {
+		result := map[string]any{
+			"id":       entry.ID,
+			"url":      h.client.Links().TimeEntry(entry.ID),
+			"project":  entry.Project.Name,
+			"user":     entry.User.Name,
+			"activity": entry.Activity.Name,
+			"hours":    entry.Hours,
+			"spent_on": entry.SpentOn,
+			"comments": entry.Comments,
+		}
+		if entry.Issue != nil {
+			result["issue_id"] = entry.Issue.ID
+		}
+		results[i] = result
+	}
+
+	return jsonResult(paginate(map[string]any{
+		"time_entries": results,
+	}, len(entries), params.Offset, limit, totalCount))
+}
+
+// maxTimeEntriesFetch caps how many time entries timeEntries_list fetches with fetch_all
+const maxTimeEntriesFetch = 1000
+
+func (h *ToolHandlers) handleTimeEntriesReport(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Build params for fetching all matching entries
+	params := redmine.ListTimeEntriesParams{}
+
+	// Handle project filter
+	if project := req.GetString("project", ""); project != "" {
+		projectID, err := h.resolver.ResolveProject(project)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+		}
+		params.ProjectID = strconv.Itoa(projectID)
+	}
+
+	// Handle user filter
+	if user := req.GetString("user", ""); user != "" {
+		if user == "me" {
+			params.UserID = "me"
+		} else {
+			userID, err := h.resolver.ResolveUser(user, 0)
+			if err != nil {
+				return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve user: %v", err)), nil
+			}
+			params.UserID = strconv.Itoa(userID)
+		}
+	}
+
+	// Handle date period
+	var err error
+	params.From, params.To, err = h.dateRangeArgs(req, "")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Get grouping
+	groupByStr, err := req.RequireString("group_by")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	groupBy, err := redmine.ParseTimeEntryGroupBy(groupByStr)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	format := req.GetString("format", "json")
+	if format != "json" && format != "csv" {
+		return mcp.NewToolResultError(i18n.Sprintf("invalid format: %s (valid: json, csv)", format)), nil
+	}
+
+	// Validate attach_to before doing any work
+	var attachIssueID int
+	attachTo := req.GetString("attach_to", "")
+	if attachTo != "" {
+		if err := h.checkWrite("timeEntries_report"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		attachIssueID, err = parseAttachToIssue(attachTo)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	report, err := redmine.BuildTimeEntryReport(h.client, params, groupBy)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := map[string]any{
+		"total_hours": report.TotalHours,
+		"entry_count": report.EntryCount,
+		"group_by":    report.GroupBy,
+		"groups":      report.Groups,
+	}
+	if report.Period != "" {
+		result["period"] = report.Period
+	}
+
+	if format == "json" && attachTo == "" {
+		return jsonResult(result)
+	}
+
+	csvData, err := report.CSV()
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to generate CSV: %v", err)), nil
+	}
+
+	if attachTo == "" {
+		return mcp.NewToolResultText(string(csvData)), nil
+	}
+
+	filename := fmt.Sprintf("time_report_%s.csv", time.Now().Format("20060102"))
+	notes := fmt.Sprintf("Time entry report attached (generated on %s)", time.Now().Format("2006-01-02 15:04:05"))
+	attachment, err := h.attachFileToIssue(attachIssueID, filename, "text/csv", csvData, notes)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to attach report: %v", err)), nil
+	}
+	result["attachment"] = attachment
+
+	return jsonResult(result)
+}
+
+// parseAttachToIssue parses an attach_to value of the form "issue:ID"
+func parseAttachToIssue(attachTo string) (int, error) {
+	idStr, ok := strings.CutPrefix(attachTo, "issue:")
+	if !ok {
+		return 0, i18n.Errorf("invalid attach_to value: %s (valid: issue:ID)", attachTo)
+	}
+	issueID, err := strconv.Atoi(idStr)
+	if err != nil || issueID <= 0 {
+		return 0, i18n.Errorf("invalid issue ID: %s", idStr)
+	}
+	return issueID, nil
+}
+
+// attachFileToIssue uploads content and attaches it to an issue, returning the attachment info
+func (h *ToolHandlers) attachFileToIssue(issueID int, filename, contentType string, content []byte, notes string) (map[string]any, error) {
+	token, err := h.client.UploadFile(filename, contentType, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	params := redmine.UpdateIssueParams{
+		IssueID: issueID,
+		Notes:   notes,
+		Uploads: []redmine.UploadToken{*token},
+	}
+	if err := h.client.UpdateIssue(params); err != nil {
+		return nil, i18n.Errorf("file uploaded (token: %s) but failed to attach to issue: %w", token.Token, err)
+	}
+
+	attachment := map[string]any{
+		"issue_id": issueID,
+		"filename": filename,
+		"size":     len(content),
+	}
+
+	// Look up the new attachment ID (best effort)
+	if issue, err := h.client.GetIssue(issueID); err == nil {
+		for i := len(issue.Attachments) - 1; i >= 0; i-- {
+			if issue.Attachments[i].Filename == filename {
+				attachment["id"] = issue.Attachments[i].ID
+				break
+			}
+		}
+	}
+
+	return attachment, nil
+}
+
+// maxMCPDownloadSize caps attachments_download, whose base64 content lands in
+// the tool result; larger files are for the REST API's streamed download
+const maxMCPDownloadSize = 10 * 1024 * 1024
+
+// mcpDownloadTimeout bounds attachments_download. OpenAttachment leaves large
+// transfers to the context, and a capped download needs no more than this.
+const mcpDownloadTimeout = 2 * time.Minute
+
+func (h *ToolHandlers) handleAttachmentsUpload(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("attachments_upload"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	filename, err := req.RequireString("filename")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	projectID, err := h.resolver.ResolveProject(projectStr)
+	contentB64, err := req.RequireString("content")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	trackerID, err := h.resolver.ResolveTracker(trackerStr)
+	decoded, err := base64.StdEncoding.DecodeString(contentB64)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve tracker: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Invalid base64 content: %v", err)), nil
 	}
 
-	// Get project and tracker names for response
-	projects, _ := h.client.ListProjects(100)
-	trackers, _ := h.client.ListTrackers()
+	if err := redmine.CheckAttachmentSize(filename, int64(len(decoded)), h.maxAttachmentSize); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("File too large: %v", err)), nil
+	}
 
-	var projectName, trackerName string
-	for _, p := range projects {
-		if p.ID == projectID {
-			projectName = p.Name
-			break
+	token, err := h.client.UploadFile(filename, req.GetString("content_type", ""), bytes.NewReader(decoded))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to upload file: %v", err)), nil
+	}
+
+	return jsonResult(map[string]any{
+		"token":        token.Token,
+		"filename":     token.Filename,
+		"content_type": token.ContentType,
+		"size":         len(decoded),
+		"message":      i18n.T("File uploaded. Use the token with issues_create or issues_update to attach it."),
+	})
+}
+
+func (h *ToolHandlers) handleAttachmentsDownload(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	idFloat, err := req.RequireFloat("attachment_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	attachmentID := int(idFloat)
+
+	attachment, err := h.client.GetAttachment(attachmentID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get attachment: %v", err)), nil
+	}
+	filename, contentType := attachment.Filename, attachment.ContentType
+
+	tooLarge := func(size int) *mcp.CallToolResult {
+		return mcp.NewToolResultError(i18n.Sprintf("Attachment %s is %.1f MB, over the %d MB limit of attachments_download. "+
+			"Download it from the REST API at /api/v1/attachments/%d/download or from Redmine at %s/attachments/download/%d",
+			filename, float64(size)/(1024*1024), maxMCPDownloadSize/(1024*1024), attachmentID, h.client.BaseURL(), attachmentID))
+	}
+	if attachment.Filesize > maxMCPDownloadSize {
+		return tooLarge(attachment.Filesize), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, mcpDownloadTimeout)
+	defer cancel()
+	body, err := h.client.WithContext(ctx).OpenAttachment(attachmentID, filename)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to download attachment: %v", err)), nil
+	}
+	defer func() { _ = body.Close() }()
+
+	// The limit also holds when the metadata understates the size
+	data, err := io.ReadAll(io.LimitReader(body, maxMCPDownloadSize+1))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to download attachment: %v", err)), nil
+	}
+	if len(data) > maxMCPDownloadSize {
+		return tooLarge(len(data)), nil
+	}
+
+	return jsonResult(map[string]any{
+		"attachment_id": attachmentID,
+		"filename":      filename,
+		"content_type":  contentType,
+		"size":          len(data),
+		"content":       base64.StdEncoding.EncodeToString(data),
+	})
+}
+
+// formatAttachments returns the attachment fields shown by attachments_list and wiki_get
+func formatAttachments(attachments []redmine.Attachment, links redmine.Links) []map[string]any {
+	result := make([]map[string]any, len(attachments))
+	for i, a := range attachments {
+		result[i] = map[string]any{
+			"id":           a.ID,
+			"url":          links.Attachment(a.ID, a.Filename),
+			"filename":     a.Filename,
+			"filesize":     a.Filesize,
+			"content_type": a.ContentType,
+			"description":  a.Description,
+			"created_on":   a.CreatedOn,
+			"author":       map[string]any{"id": a.Author.ID, "name": a.Author.Name},
 		}
 	}
-	for _, t := range trackers {
-		if t.ID == trackerID {
-			trackerName = t.Name
-			break
+	return result
+}
+
+func (h *ToolHandlers) handleAttachmentsList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	idFloat, err := req.RequireFloat("issue_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueID := int(idFloat)
+
+	issue, err := h.client.GetIssue(issueID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get issue: %v", err)), nil
+	}
+
+	attachments := formatAttachments(issue.Attachments, h.client.Links())
+
+	return jsonResult(wholeList(map[string]any{
+		"issue_id":    issueID,
+		"attachments": attachments,
+	}, len(attachments)))
+}
+
+func (h *ToolHandlers) handleAttachmentsDelete(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("attachments_delete"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	idFloat, err := req.RequireFloat("attachment_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	attachmentID := int(idFloat)
+
+	if !req.GetBool("confirm", false) {
+		return mcp.NewToolResultError(i18n.T("Deleting an attachment permanently removes the file; set confirm to true to proceed")), nil
+	}
+
+	attachment, err := h.client.GetAttachment(attachmentID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get attachment: %v", err)), nil
+	}
+
+	result := map[string]any{
+		"success":       true,
+		"attachment_id": attachmentID,
+		"filename":      attachment.Filename,
+		"filesize":      attachment.Filesize,
+		"author":        map[string]any{"id": attachment.Author.ID, "name": attachment.Author.Name},
+		"created_on":    attachment.CreatedOn,
+	}
+
+	// The attachment API has no container, so the issue is only known when given
+	if issueID := req.GetInt("issue_id", 0); issueID > 0 {
+		issue, err := h.client.GetIssue(issueID)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to get issue: %v", err)), nil
+		}
+		if !slices.ContainsFunc(issue.Attachments, func(a redmine.Attachment) bool { return a.ID == attachmentID }) {
+			return mcp.NewToolResultError(i18n.Sprintf("Attachment #%d (%s) is not attached to issue #%d; nothing was deleted", attachmentID, attachment.Filename, issueID)), nil
 		}
+		result["issue"] = map[string]any{"id": issue.ID, "subject": issue.Subject}
 	}
 
-	// Get custom fields
-	customFields, err := h.client.GetProjectCustomFields(projectID, trackerID)
+	if err := h.client.DeleteAttachment(attachmentID); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to delete attachment %s: %v", attachment.Filename, err)), nil
+	}
+
+	result["message"] = "Attachment deleted successfully"
+	return jsonResult(result)
+}
+
+func (h *ToolHandlers) handleAttachmentsUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("attachments_update"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	idFloat, err := req.RequireFloat("attachment_id")
 	if err != nil {
-		// Return partial result even if custom fields unavailable
-		return jsonResult(map[string]any{
-			"project": projectName,
-			"tracker": trackerName,
-			"required": map[string]any{
-				"standard": []string{"subject"},
-				"custom":   []any{},
-			},
-			"note": "Could not retrieve custom fields: " + err.Error(),
-		})
+		return mcp.NewToolResultError(err.Error()), nil
 	}
+	attachmentID := int(idFloat)
 
-	// Format custom fields
-	customFieldsList := make([]map[string]any, 0)
-	for _, cf := range customFields {
-		field := map[string]any{
-			"id":   cf.ID,
-			"name": cf.Name,
+	// Only send fields that were explicitly provided, so "" clears the description
+	var filename, description *string
+	args := req.GetArguments()
+	if _, ok := args["filename"]; ok {
+		v := req.GetString("filename", "")
+		filename = &v
+	}
+	if _, ok := args["description"]; ok {
+		v := req.GetString("description", "")
+		description = &v
+	}
+	if filename == nil && description == nil {
+		return mcp.NewToolResultError(i18n.T("Nothing to update: give a filename or a description")), nil
+	}
+
+	if err := h.client.UpdateAttachment(attachmentID, filename, description); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to update attachment: %v", err)), nil
+	}
+
+	attachment, err := h.client.GetAttachment(attachmentID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Attachment updated, but failed to fetch it: %v", err)), nil
+	}
+
+	return jsonResult(map[string]any{
+		"success":    true,
+		"attachment": formatAttachments([]redmine.Attachment{*attachment}, h.client.Links())[0],
+		"message":    i18n.T("Attachment updated successfully"),
+	})
+}
+
+func (h *ToolHandlers) handleAttachmentsUploadAndAttach(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("attachments_uploadAndAttach"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	issueIDFloat, err := req.RequireFloat("issue_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	issueID := int(issueIDFloat)
+
+	filename, err := req.RequireString("filename")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	contentB64, err := req.RequireString("content")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(contentB64)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Invalid base64 content: %v", err)), nil
+	}
+
+	if err := redmine.CheckAttachmentSize(filename, int64(len(decoded)), h.maxAttachmentSize); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("File too large: %v", err)), nil
+	}
+
+	// Step 1: Upload
+	token, err := h.client.UploadFile(filename, req.GetString("content_type", ""), bytes.NewReader(decoded))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to upload file: %v", err)), nil
+	}
+	token.Description = req.GetString("description", "")
+
+	// Step 2: Attach to issue
+	params := redmine.UpdateIssueParams{
+		IssueID: issueID,
+		Notes:   req.GetString("notes", ""),
+		Uploads: []redmine.UploadToken{*token},
+	}
+
+	if err := h.client.UpdateIssue(params); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("File uploaded (token: %s) but failed to attach to issue: %v", token.Token, err)), nil
+	}
+
+	return jsonResult(map[string]any{
+		"success":  true,
+		"issue_id": issueID,
+		"filename": filename,
+		"size":     len(decoded),
+		"message":  i18n.T("File uploaded and attached to issue successfully"),
+	})
+}
+
+func (h *ToolHandlers) handleTrackersList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	get := h.resolver.GetTrackers
+	if req.GetBool("force_refresh", false) {
+		get = h.resolver.RefreshTrackers
+	}
+	trackers, err := get()
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list trackers: %v", err)), nil
+	}
+
+	result := make([]map[string]any, len(trackers))
+	for i, t := range trackers {
+		result[i] = map[string]any{
+			"id":   t.ID,
+			"name": t.Name,
 		}
-		if cf.FieldFormat != "" && cf.FieldFormat != "unknown" {
-			field["type"] = cf.FieldFormat
+	}
+
+	return jsonResult(wholeList(map[string]any{
+		"trackers": result,
+	}, len(trackers)))
+}
+
+func (h *ToolHandlers) handleStatusesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	get := h.resolver.GetStatuses
+	if req.GetBool("force_refresh", false) {
+		get = h.resolver.RefreshStatuses
+	}
+	statuses, err := get()
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list statuses: %v", err)), nil
+	}
+
+	result := make([]map[string]any, len(statuses))
+	for i, s := range statuses {
+		result[i] = map[string]any{
+			"id":        s.ID,
+			"name":      s.Name,
+			"is_closed": s.IsClosed,
 		}
-		if len(cf.PossibleValues) > 0 {
-			field["possible_values"] = cf.PossibleValues
+	}
+
+	return jsonResult(wholeList(map[string]any{
+		"statuses": result,
+	}, len(statuses)))
+}
+
+func (h *ToolHandlers) handlePrioritiesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	get := h.resolver.GetPriorities
+	if req.GetBool("force_refresh", false) {
+		get = h.resolver.RefreshPriorities
+	}
+	priorities, err := get()
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list priorities: %v", err)), nil
+	}
+
+	result := make([]map[string]any, len(priorities))
+	for i, p := range priorities {
+		result[i] = map[string]any{
+			"id":         p.ID,
+			"name":       p.Name,
+			"is_default": p.IsDefault,
 		}
-		customFieldsList = append(customFieldsList, field)
 	}
 
-	return jsonResult(map[string]any{
-		"project": projectName,
-		"tracker": trackerName,
-		"required": map[string]any{
-			"standard": []string{"subject"},
-			"custom":   customFieldsList,
-		},
-		"note": "Custom fields shown are available for this project/tracker. Required status cannot be determined without admin access - try creating the issue and check error messages.",
-	})
+	return jsonResult(wholeList(map[string]any{
+		"priorities": result,
+	}, len(priorities)))
 }
 
-func (h *ToolHandlers) handleCustomFieldsList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectStr, err := req.RequireString("project")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+func (h *ToolHandlers) handleActivitiesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	get := h.resolver.GetActivities
+	if req.GetBool("force_refresh", false) {
+		get = h.resolver.RefreshActivities
 	}
-
-	projectID, err := h.resolver.ResolveProject(projectStr)
+	activities, err := get()
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list activities: %v", err)), nil
 	}
 
-	var trackerID int
-	if tracker := req.GetString("tracker", ""); tracker != "" {
-		trackerID, err = h.resolver.ResolveTracker(tracker)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve tracker: %v", err)), nil
+	result := make([]map[string]any, len(activities))
+	for i, a := range activities {
+		result[i] = map[string]any{
+			"id":         a.ID,
+			"name":       a.Name,
+			"is_default": a.IsDefault,
+			"active":     a.Active,
 		}
 	}
 
-	fields, err := h.client.GetProjectCustomFields(projectID, trackerID)
+	return jsonResult(wholeList(map[string]any{
+		"activities": result,
+	}, len(activities)))
+}
+
+func (h *ToolHandlers) handleRolesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	get := h.resolver.GetRoles
+	if req.GetBool("force_refresh", false) {
+		get = h.resolver.RefreshRoles
+	}
+	roles, err := get()
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get custom fields: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list roles: %v", err)), nil
 	}
 
-	// Format results
-	results := make([]map[string]any, len(fields))
-	for i, f := range fields {
-		results[i] = map[string]any{
-			"id":   f.ID,
-			"name": f.Name,
-		}
-		if f.FieldFormat != "" && f.FieldFormat != "unknown" {
-			results[i]["type"] = f.FieldFormat
-		}
-		if f.Required {
-			results[i]["required"] = true
-		}
-		if len(f.PossibleValues) > 0 {
-			results[i]["possible_values"] = f.PossibleValues
+	result := make([]map[string]any, len(roles))
+	for i, r := range roles {
+		result[i] = map[string]any{
+			"id":   r.ID,
+			"name": r.Name,
 		}
 	}
 
-	return jsonResult(map[string]any{
-		"project_id":    projectID,
-		"tracker_id":    trackerID,
-		"custom_fields": results,
-	})
+	return jsonResult(wholeList(map[string]any{
+		"roles": result,
+	}, len(roles)))
 }
 
-func (h *ToolHandlers) handleCustomFieldsListAll(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	fields, err := h.client.ListAllCustomFields()
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf(
-			"Requires admin privileges. Use customFields_list with a project/tracker to see fields available in a specific project (no admin required). Error: %v", err)), nil
+func (h *ToolHandlers) handleReferenceWorkflow(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if h.workflow == nil {
+		return mcp.NewToolResultError(i18n.T("Workflow rules not configured. Set WORKFLOW_RULES_FILE or --workflow-rules flag.")), nil
 	}
 
-	// Optional type filter
-	typeFilter := req.GetString("type", "")
+	trackerStr := req.GetString("tracker", "")
 
-	results := make([]map[string]any, 0)
-	for _, f := range fields {
-		if typeFilter != "" && f.CustomizedType != typeFilter {
-			continue
-		}
-		field := map[string]any{
-			"id":              f.ID,
-			"name":            f.Name,
-			"customized_type": f.CustomizedType,
-			"field_format":    f.FieldFormat,
-			"is_required":     f.IsRequired,
-			"visible":         f.Visible,
-		}
-		if f.Multiple {
-			field["multiple"] = true
-		}
-		if f.DefaultValue != "" {
-			field["default_value"] = f.DefaultValue
-		}
-		if len(f.PossibleValues) > 0 {
-			vals := make([]string, len(f.PossibleValues))
-			for i, v := range f.PossibleValues {
-				vals[i] = v.Value
-			}
-			field["possible_values"] = vals
+	if trackerStr != "" {
+		// Show specific tracker
+		trackerID, err := h.resolver.ResolveTracker(trackerStr)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve tracker: %v", err)), nil
 		}
-		if len(f.Trackers) > 0 {
-			trackers := make([]map[string]any, len(f.Trackers))
-			for i, t := range f.Trackers {
-				trackers[i] = map[string]any{"id": t.ID, "name": t.Name}
-			}
-			field["trackers"] = trackers
+
+		trackerKey := strconv.Itoa(trackerID)
+		tracker, ok := h.workflow.Trackers[trackerKey]
+		if !ok {
+			return mcp.NewToolResultError(i18n.Sprintf("No workflow rules defined for tracker %s (ID: %d)", trackerStr, trackerID)), nil
 		}
-		results = append(results, field)
+
+		return jsonResult(formatTrackerWorkflow(trackerID, tracker))
+	}
+
+	// Show all trackers summary
+	trackers := make([]map[string]any, 0, len(h.workflow.Trackers))
+	for idStr, tracker := range h.workflow.Trackers {
+		id, _ := strconv.Atoi(idStr)
+		trackers = append(trackers, formatTrackerWorkflow(id, tracker))
 	}
 
 	return jsonResult(map[string]any{
-		"custom_fields": results,
-		"count":         len(results),
+		"trackers": trackers,
+		"count":    len(trackers),
 	})
 }
 
-func (h *ToolHandlers) handleProjectsGetDetail(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectStr, err := req.RequireString("project")
-	if err != nil {
+// --- Group A: CRUD Gaps ---
+
+func (h *ToolHandlers) handleTimeEntriesUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("timeEntries_update"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	projectID, err := h.resolver.ResolveProject(projectStr)
+	idFloat, err := req.RequireFloat("time_entry_id")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
+	timeEntryID := int(idFloat)
 
-	project, err := h.client.GetProjectDetail(projectID, []string{"trackers", "issue_custom_fields"})
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project detail: %v", err)), nil
+	params := redmine.UpdateTimeEntryParams{
+		TimeEntryID: timeEntryID,
 	}
 
-	result := map[string]any{
-		"id":          project.ID,
-		"name":        project.Name,
-		"identifier":  project.Identifier,
-		"description": project.Description,
-		"status":      project.Status,
+	// Only set fields that were explicitly provided, so "" and 0 are sent as values
+	args := req.GetArguments()
+	if v, ok := args["hours"]; ok {
+		hours, ok := v.(float64)
+		if !ok {
+			return mcp.NewToolResultError(i18n.Sprintf("invalid hours: %v", v)), nil
+		}
+		params.Hours = &hours
 	}
 
-	if project.Parent != nil {
-		result["parent"] = map[string]any{
-			"id":   project.Parent.ID,
-			"name": project.Parent.Name,
+	if activity := req.GetString("activity", ""); activity != "" {
+		activityID, err := h.resolver.ResolveActivity(activity)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve activity: %v", err)), nil
 		}
+		params.ActivityID = &activityID
 	}
 
-	trackers := make([]map[string]any, len(project.Trackers))
-	for i, t := range project.Trackers {
-		trackers[i] = map[string]any{"id": t.ID, "name": t.Name}
+	if _, ok := args["comments"]; ok {
+		comments := req.GetString("comments", "")
+		params.Comments = &comments
+	}
+	if _, ok := args["spent_on"]; ok {
+		spentOn := req.GetString("spent_on", "")
+		params.SpentOn = &spentOn
 	}
-	result["trackers"] = trackers
 
-	customFields := make([]map[string]any, len(project.IssueCustomFields))
-	for i, cf := range project.IssueCustomFields {
-		customFields[i] = map[string]any{"id": cf.ID, "name": cf.Name}
+	if err := h.client.UpdateTimeEntry(params); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to update time entry: %v", err)), nil
 	}
-	result["issue_custom_fields"] = customFields
 
-	return jsonResult(result)
+	return jsonResult(map[string]any{
+		"success":       true,
+		"time_entry_id": timeEntryID,
+		"message":       i18n.T("Time entry updated successfully"),
+	})
 }
 
-func (h *ToolHandlers) handleProjectsUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleTimeEntriesDelete(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("timeEntries_delete"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	projectStr, err := req.RequireString("project")
+	idFloat, err := req.RequireFloat("time_entry_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	timeEntryID := int(idFloat)
 
-	projectID, err := h.resolver.ResolveProject(projectStr)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
-	}
-
-	params := redmine.UpdateProjectParams{
-		ProjectID: projectID,
+	if err := h.client.DeleteTimeEntry(timeEntryID); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to delete time entry: %v", err)), nil
 	}
 
-	params.Name = req.GetString("name", "")
-	params.Description = req.GetString("description", "")
-
-	// Resolve tracker_ids (names or IDs)
-	if trackerArgs := getArrayArg(req, "tracker_ids"); trackerArgs != nil {
-		trackerIDs := make([]int, 0, len(trackerArgs))
-		for _, arg := range trackerArgs {
-			s := fmt.Sprintf("%v", arg)
-			id, err := h.resolver.ResolveTracker(s)
-			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve tracker '%s': %v", s, err)), nil
-			}
-			trackerIDs = append(trackerIDs, id)
-		}
-		params.TrackerIDs = trackerIDs
-	}
+	return jsonResult(map[string]any{
+		"success":       true,
+		"time_entry_id": timeEntryID,
+		"message":       i18n.T("Time entry deleted successfully"),
+	})
+}
 
-	// Resolve issue_custom_field_ids (names or IDs)
-	if cfArgs := getArrayArg(req, "issue_custom_field_ids"); cfArgs != nil {
-		cfIDs := make([]int, 0, len(cfArgs))
-		for _, arg := range cfArgs {
-			s := fmt.Sprintf("%v", arg)
-			id, err := h.resolver.ResolveCustomFieldByName(s, projectID, 0)
-			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve custom field '%s': %v", s, err)), nil
-			}
-			cfIDs = append(cfIDs, id)
-		}
-		params.IssueCustomFieldIDs = cfIDs
+func (h *ToolHandlers) handleIssuesRemoveWatcher(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("issues_removeWatcher"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	if err := h.client.UpdateProject(params); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update project: %v", err)), nil
+	issueIDFloat, err := req.RequireFloat("issue_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
+	issueID := int(issueIDFloat)
 
-	// Return updated project detail
-	project, err := h.client.GetProjectDetail(projectID, []string{"trackers", "issue_custom_fields"})
+	user, err := req.RequireString("user")
 	if err != nil {
-		return jsonResult(map[string]any{
-			"success":    true,
-			"project_id": projectID,
-			"message":    "Project updated successfully (could not fetch updated details)",
-		})
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	result := map[string]any{
-		"success":    true,
-		"project_id": project.ID,
-		"name":       project.Name,
-		"message":    "Project updated successfully",
+	// Get issue to resolve project context
+	issue, err := h.client.GetIssue(issueID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get issue: %v", err)), nil
 	}
 
-	trackers := make([]map[string]any, len(project.Trackers))
-	for i, t := range project.Trackers {
-		trackers[i] = map[string]any{"id": t.ID, "name": t.Name}
+	userID, err := h.resolver.ResolveUser(user, issue.Project.ID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve user: %v", err)), nil
 	}
-	result["trackers"] = trackers
 
-	customFields := make([]map[string]any, len(project.IssueCustomFields))
-	for i, cf := range project.IssueCustomFields {
-		customFields[i] = map[string]any{"id": cf.ID, "name": cf.Name}
+	if err := h.client.RemoveWatcher(issueID, userID); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to remove watcher: %v", err)), nil
 	}
-	result["issue_custom_fields"] = customFields
 
-	return jsonResult(result)
+	return jsonResult(map[string]any{
+		"success":  true,
+		"issue_id": issueID,
+		"user_id":  userID,
+		"message":  i18n.T("Watcher removed successfully"),
+	})
 }
 
-func (h *ToolHandlers) handleTimeEntriesCreate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
+func (h *ToolHandlers) handleIssuesListRelations(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	issueIDFloat, err := req.RequireFloat("issue_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 	issueID := int(issueIDFloat)
 
-	hours, err := req.RequireFloat("hours")
+	relations, err := h.client.GetRelations(issueID)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get relations: %v", err)), nil
 	}
 
-	params := redmine.CreateTimeEntryParams{
-		IssueID: issueID,
-		Hours:   hours,
+	// Look up the related issues in a single search, capped to avoid fan-out
+	var otherIDs []int
+	for _, r := range relations {
+		otherID, _ := relationDirection(r, issueID)
+		if !slices.Contains(otherIDs, otherID) {
+			otherIDs = append(otherIDs, otherID)
+		}
 	}
+	enriched := otherIDs[:min(len(otherIDs), maxRelationEnrichment)]
 
-	if activity := req.GetString("activity", ""); activity != "" {
-		activityID, err := h.resolver.ResolveActivity(activity)
+	related := make(map[int]redmine.Issue)
+	if len(enriched) > 0 {
+		issues, _, err := h.client.SearchIssues(redmine.SearchIssuesParams{
+			IssueIDs: enriched,
+			StatusID: "*",
+			Limit:    len(enriched),
+		})
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve activity: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to get related issues: %v", err)), nil
+		}
+		for _, issue := range issues {
+			related[issue.ID] = issue
 		}
-		params.ActivityID = activityID
 	}
 
-	params.Comments = req.GetString("comments", "")
-	params.SpentOn = req.GetString("spent_on", "")
+	result := wholeList(map[string]any{
+		"issue_id":  issueID,
+		"relations": formatRelations(relations, issueID, related),
+	}, len(relations))
+	if len(otherIDs) > len(enriched) {
+		result["note"] = i18n.Sprintf("Subjects and statuses were looked up for the first %d related issues only", maxRelationEnrichment)
+	}
+	return jsonResult(result)
+}
+
+func (h *ToolHandlers) handleIssuesRemoveRelation(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("issues_removeRelation"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	entry, err := h.client.CreateTimeEntry(params)
+	idFloat, err := req.RequireFloat("relation_id")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create time entry: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	relationID := int(idFloat)
+
+	if err := h.client.DeleteRelation(relationID); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to remove relation: %v", err)), nil
 	}
 
 	return jsonResult(map[string]any{
-		"id":       entry.ID,
-		"issue_id": issueID,
-		"hours":    entry.Hours,
-		"activity": entry.Activity.Name,
-		"comments": entry.Comments,
-		"spent_on": entry.SpentOn,
+		"success":     true,
+		"relation_id": relationID,
+		"message":     i18n.T("Relation removed successfully"),
 	})
 }
 
-func (h *ToolHandlers) handleTimeEntriesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	params := redmine.ListTimeEntriesParams{}
+// --- Group E: User Search ---
+
+func (h *ToolHandlers) handleUsersSearch(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params := redmine.SearchUsersParams{}
+
+	params.Name = req.GetString("name", "")
 
-	// Handle project
-	var projectID int
 	if project := req.GetString("project", ""); project != "" {
-		var err error
-		projectID, err = h.resolver.ResolveProject(project)
+		projectID, err := h.resolver.ResolveProject(project)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
-		}
-		params.ProjectID = strconv.Itoa(projectID)
-	}
-
-	// Handle user
-	if user := req.GetString("user", ""); user != "" {
-		if user == "me" {
-			params.UserID = "me"
-		} else {
-			userID, err := h.resolver.ResolveUser(user, projectID)
-			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve user: %v", err)), nil
-			}
-			params.UserID = strconv.Itoa(userID)
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 		}
+		params.ProjectID = projectID
 	}
 
-	// Handle issue_id
-	if issueID := req.GetInt("issue_id", 0); issueID > 0 {
-		params.IssueID = issueID
-	}
-
-	// Handle date period shortcut
-	if period := req.GetString("period", ""); period != "" {
-		params.From, params.To = resolveDatePeriod(period)
-	}
-
-	// Handle explicit from/to (override period if both specified)
-	if from := req.GetString("from", ""); from != "" {
-		params.From = from
-	}
-	if to := req.GetString("to", ""); to != "" {
-		params.To = to
-	}
-
-	// Handle limit
+	params.Status = req.GetInt("status", 0)
 	params.Limit = req.GetInt("limit", 25)
 
-	entries, totalCount, err := h.client.ListTimeEntries(params)
+	users, total, err := h.client.SearchUsers(params)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list time entries: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to search users: %v", err)), nil
 	}
 
-	// Format results
-	results := make([]map[string]any, len(entries))
-	for i, entry := range entries {
+	results := make([]map[string]any, len(users))
+	for i, u := range users {
 		result := map[string]any{
-			"id":       entry.ID,
-			"project":  entry.Project.Name,
-			"user":     entry.User.Name,
-			"activity": entry.Activity.Name,
-			"hours":    entry.Hours,
-			"spent_on": entry.SpentOn,
-			"comments": entry.Comments,
+			"id":   u.ID,
+			"name": u.Name,
 		}
-		if entry.Issue != nil {
-			result["issue_id"] = entry.Issue.ID
+		if u.Login != "" {
+			result["login"] = u.Login
+		}
+		if u.Mail != "" {
+			result["email"] = u.Mail
 		}
 		results[i] = result
 	}
 
-	return jsonResult(map[string]any{
-		"total_count":  totalCount,
-		"count":        len(entries),
-		"time_entries": results,
-	})
+	return jsonResult(paginate(map[string]any{
+		"users": results,
+	}, len(users), params.Offset, params.Limit, total))
 }
 
-func (h *ToolHandlers) handleTimeEntriesReport(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// Build params for fetching all matching entries
-	params := redmine.ListTimeEntriesParams{
-		Limit: 100, // Fetch in batches
+func (h *ToolHandlers) handleUsersGet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	user, err := req.RequireString("user")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// Handle project filter
+	var projectID int
 	if project := req.GetString("project", ""); project != "" {
-		projectID, err := h.resolver.ResolveProject(project)
+		projectID, err = h.resolver.ResolveProject(project)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 		}
-		params.ProjectID = strconv.Itoa(projectID)
 	}
 
-	// Handle user filter
-	if user := req.GetString("user", ""); user != "" {
-		if user == "me" {
-			params.UserID = "me"
-		} else {
-			userID, err := h.resolver.ResolveUser(user, 0)
-			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve user: %v", err)), nil
-			}
-			params.UserID = strconv.Itoa(userID)
-		}
+	userID, err := h.resolver.ResolveUser(user, projectID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve user: %v", err)), nil
 	}
 
-	// Handle date period
-	if period := req.GetString("period", ""); period != "" {
-		params.From, params.To = resolveDatePeriod(period)
-	}
-	if from := req.GetString("from", ""); from != "" {
-		params.From = from
-	}
-	if to := req.GetString("to", ""); to != "" {
-		params.To = to
+	detail, err := h.client.GetUser(userID, []string{"memberships", "groups"})
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get user: %v", err)), nil
 	}
 
-	// Get grouping
-	groupByStr, err := req.RequireString("group_by")
+	return jsonResult(detail.Profile())
+}
+
+func (h *ToolHandlers) handleGroupsList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groups, err := h.client.ListGroups()
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	groupBy := strings.Split(groupByStr, ",")
-	for i := range groupBy {
-		groupBy[i] = strings.TrimSpace(groupBy[i])
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list groups: %v. Listing groups requires admin privileges; use memberships_list to see groups in a specific project", err)), nil
 	}
 
-	// Fetch all entries (paginated)
-	var allEntries []redmine.TimeEntry
-	for {
-		entries, _, err := h.client.ListTimeEntries(params)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch time entries: %v", err)), nil
+	includeUsers := req.GetBool("include_users", false)
+	results := make([]map[string]any, len(groups))
+	for i, g := range groups {
+		result := map[string]any{
+			"id":   g.ID,
+			"name": g.Name,
 		}
-		allEntries = append(allEntries, entries...)
-		if len(entries) < params.Limit {
-			break
+		if includeUsers {
+			detail, err := h.client.GetGroup(g.ID, true)
+			if err != nil {
+				return mcp.NewToolResultError(i18n.Sprintf("Failed to get users of group '%s': %v", g.Name, err)), nil
+			}
+			users := detail.Users
+			if users == nil {
+				users = []redmine.IDName{}
+			}
+			result["users"] = users
 		}
-		params.Offset += params.Limit
-	}
-
-	// Aggregate by group_by
-	type GroupKey struct {
-		Project  string
-		User     string
-		Activity string
+		results[i] = result
 	}
 
-	aggregated := make(map[GroupKey]float64)
-	var totalHours float64
+	return jsonResult(wholeList(map[string]any{
+		"groups": results,
+	}, len(groups)))
+}
 
-	for _, entry := range allEntries {
-		key := GroupKey{}
-		for _, g := range groupBy {
-			switch g {
-			case "project":
-				key.Project = entry.Project.Name
-			case "user":
-				key.User = entry.User.Name
-			case "activity":
-				key.Activity = entry.Activity.Name
-			}
+func (h *ToolHandlers) handleQueriesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var projectID int
+	if project := req.GetString("project", ""); project != "" {
+		var err error
+		projectID, err = h.resolver.ResolveProject(project)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 		}
-		aggregated[key] += entry.Hours
-		totalHours += entry.Hours
 	}
 
-	// Build result
-	groups := make([]map[string]any, 0, len(aggregated))
-	for key, hours := range aggregated {
-		group := map[string]any{
-			"hours": hours,
-		}
-		if key.Project != "" {
-			group["project"] = key.Project
+	queries, err := h.client.ListQueries()
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list queries: %v", err)), nil
+	}
+
+	results := []map[string]any{}
+	for _, q := range queries {
+		if projectID > 0 && q.ProjectID != nil && *q.ProjectID != projectID {
+			continue
 		}
-		if key.User != "" {
-			group["user"] = key.User
+		result := map[string]any{
+			"id":        q.ID,
+			"name":      q.Name,
+			"is_public": q.IsPublic,
+			"scope":     "all projects",
 		}
-		if key.Activity != "" {
-			group["activity"] = key.Activity
+		if q.ProjectID != nil {
+			result["scope"] = "project"
+			result["project_id"] = *q.ProjectID
 		}
-		groups = append(groups, group)
+		results = append(results, result)
 	}
 
-	// Sort by hours descending
-	sort.Slice(groups, func(i, j int) bool {
-		return groups[i]["hours"].(float64) > groups[j]["hours"].(float64)
-	})
+	return jsonResult(wholeList(map[string]any{
+		"queries": results,
+	}, len(results)))
+}
 
-	result := map[string]any{
-		"total_hours": totalHours,
-		"entry_count": len(allEntries),
-		"group_by":    groupBy,
-		"groups":      groups,
+// --- Global Search ---
+
+func (h *ToolHandlers) handleSearchGlobal(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	q := req.GetString("q", "")
+	if q == "" {
+		return mcp.NewToolResultError(i18n.T("q (search query) is required")), nil
+	}
+
+	params := redmine.GlobalSearchParams{
+		Query:      q,
+		Scope:      req.GetString("scope", ""),
+		TitlesOnly: req.GetBool("titles_only", false),
+		Issues:     req.GetBool("issues", false),
+		WikiPages:  req.GetBool("wiki_pages", false),
+		News:       req.GetBool("news", false),
+		Documents:  req.GetBool("documents", false),
+		Changesets: req.GetBool("changesets", false),
+		Messages:   req.GetBool("messages", false),
+		Projects:   req.GetBool("projects", false),
+		Offset:     req.GetInt("offset", 0),
+		Limit:      req.GetInt("limit", 25),
 	}
 
-	if params.From != "" || params.To != "" {
-		result["period"] = fmt.Sprintf("%s ~ %s", params.From, params.To)
+	results, total, err := h.client.GlobalSearch(params)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to search: %v", err)), nil
 	}
 
-	return jsonResult(result)
+	items := make([]map[string]any, len(results))
+	for i, r := range results {
+		item := map[string]any{
+			"id":    r.ID,
+			"title": r.Title,
+			"type":  r.Type,
+			"url":   r.URL,
+		}
+		if r.Description != "" {
+			item["description"] = r.Description
+		}
+		if r.Datetime != "" {
+			item["datetime"] = r.Datetime
+		}
+		items[i] = item
+	}
+
+	return jsonResult(paginate(map[string]any{
+		"results": items,
+	}, len(results), params.Offset, params.Limit, total))
 }
 
-const maxMCPAttachmentSize = 3 * 1024 * 1024 // 3MB decoded
+// --- Group B: Batch & Copy ---
 
-func (h *ToolHandlers) handleAttachmentsUpload(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
+// Batch updates run concurrently; the cap keeps the load on Redmine reasonable
+const (
+	defaultBatchParallelism = 4
+	maxBatchParallelism     = 10
+)
 
-	filename, err := req.RequireString("filename")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+func (h *ToolHandlers) handleIssuesBatchUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dryRun := req.GetBool("dry_run", false)
+	if !dryRun {
+		if err := h.checkWrite("issues_batchUpdate"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 	}
 
-	contentB64, err := req.RequireString("content")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	issueIDsRaw := getArrayArg(req, "issue_ids")
+	if len(issueIDsRaw) == 0 {
+		return mcp.NewToolResultError(i18n.T("issue_ids is required and must be a non-empty array")), nil
 	}
 
-	decoded, err := base64.StdEncoding.DecodeString(contentB64)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid base64 content: %v", err)), nil
+	// Parse issue IDs
+	issueIDs := make([]int, 0, len(issueIDsRaw))
+	for _, raw := range issueIDsRaw {
+		if f, ok := raw.(float64); ok {
+			issueIDs = append(issueIDs, int(f))
+		} else {
+			return mcp.NewToolResultError(i18n.Sprintf("Invalid issue ID: %v", raw)), nil
+		}
 	}
 
-	if len(decoded) > maxMCPAttachmentSize {
-		return mcp.NewToolResultError(fmt.Sprintf("File too large: %d bytes (max %d bytes / 3MB)", len(decoded), maxMCPAttachmentSize)), nil
+	// Pre-resolve common fields once
+	var statusID int
+	if status := req.GetString("status", ""); status != "" {
+		var err error
+		statusID, err = h.resolver.ResolveStatusID(status)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve status: %v", err)), nil
+		}
 	}
 
-	token, err := h.client.UploadFile(filename, bytes.NewReader(decoded))
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to upload file: %v", err)), nil
+	var priorityID int
+	if priority := req.GetString("priority", ""); priority != "" {
+		var err error
+		priorityID, err = h.resolver.ResolvePriority(priority)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve priority: %v", err)), nil
+		}
 	}
 
-	contentType := req.GetString("content_type", "")
-	if contentType != "" {
-		token.ContentType = contentType
+	parallelism := req.GetInt("parallelism", defaultBatchParallelism)
+	if parallelism < 1 {
+		return mcp.NewToolResultError(i18n.T("parallelism must be at least 1")), nil
 	}
+	parallelism = min(parallelism, maxBatchParallelism, len(issueIDs))
 
-	return jsonResult(map[string]any{
-		"token":    token.Token,
-		"filename": token.Filename,
-		"size":     len(decoded),
-		"message":  "File uploaded. Use the token with issues_create or issues_update to attach it.",
-	})
-}
+	base := redmine.UpdateIssueParams{
+		StatusID:   statusID,
+		PriorityID: priorityID,
+		Notes:      req.GetString("notes", ""),
+	}
 
-func (h *ToolHandlers) handleAttachmentsDownload(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	idFloat, err := req.RequireFloat("attachment_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	// "me" is the same for every issue, so resolve it once up front
+	assignee := newBatchAssignee(h.resolver, req.GetString("assigned_to", ""))
+	if strings.EqualFold(assignee.query, "me") {
+		user, err := h.client.GetCurrentUser()
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to get current user: %v", err)), nil
+		}
+		assignee.meID = user.ID
+		assignee.name = user.Firstname + " " + user.Lastname
 	}
-	attachmentID := int(idFloat)
 
-	data, contentType, filename, err := h.client.DownloadAttachment(attachmentID)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to download attachment: %v", err)), nil
+	var names redmine.BatchNames
+	if dryRun {
+		if statusID > 0 {
+			names.Status = h.resolver.StatusName(statusID)
+		}
+		if priorityID > 0 {
+			names.Priority = h.resolver.PriorityName(priorityID)
+		}
 	}
 
-	return jsonResult(map[string]any{
-		"attachment_id": attachmentID,
-		"filename":      filename,
-		"content_type":  contentType,
-		"size":          len(data),
-		"content":       base64.StdEncoding.EncodeToString(data),
-	})
-}
+	// Update issues with a bounded worker pool; failures[i] stays nil on
+	// success, and previews[i] holds a dry run's changes
+	failures := make([]map[string]any, len(issueIDs))
+	previews := make([]map[string]any, len(issueIDs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range parallelism {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if dryRun {
+					previews[i], failures[i] = h.batchPreviewIssue(issueIDs[i], base, assignee, names)
+				} else {
+					failures[i] = h.batchUpdateIssue(issueIDs[i], base, assignee)
+				}
+			}
+		}()
+	}
+	for i := range issueIDs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
-func (h *ToolHandlers) handleAttachmentsList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	idFloat, err := req.RequireFloat("issue_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	var successIDs []int
+	var failed []map[string]any
+	for i, issueID := range issueIDs {
+		if failures[i] != nil {
+			failed = append(failed, failures[i])
+		} else {
+			successIDs = append(successIDs, issueID)
+		}
 	}
-	issueID := int(idFloat)
+	sort.Ints(successIDs)
+	sort.SliceStable(failed, func(i, j int) bool {
+		return failed[i]["id"].(int) < failed[j]["id"].(int)
+	})
 
-	issue, err := h.client.GetIssue(issueID)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get issue: %v", err)), nil
+	if !dryRun {
+		return jsonResult(map[string]any{
+			"success": successIDs,
+			"failed":  failed,
+		})
 	}
 
-	attachments := make([]map[string]any, len(issue.Attachments))
-	for i, a := range issue.Attachments {
-		attachments[i] = map[string]any{
-			"id":           a.ID,
-			"filename":     a.Filename,
-			"filesize":     a.Filesize,
-			"content_type": a.ContentType,
-			"description":  a.Description,
-			"created_on":   a.CreatedOn,
-			"author":       map[string]any{"id": a.Author.ID, "name": a.Author.Name},
+	changes := make([]map[string]any, 0, len(successIDs))
+	for _, p := range previews {
+		if p != nil {
+			changes = append(changes, p)
 		}
 	}
-
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i]["id"].(int) < changes[j]["id"].(int)
+	})
 	return jsonResult(map[string]any{
-		"issue_id":    issueID,
-		"attachments": attachments,
-		"count":       len(attachments),
+		"dry_run": true,
+		"success": successIDs,
+		"failed":  failed,
+		"changes": changes,
 	})
 }
 
-func (h *ToolHandlers) handleAttachmentsUploadAndAttach(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
+// batchAssignee resolves a batch update's assignee once per project, shared by workers
+type batchAssignee struct {
+	resolver *redmine.Resolver
+	query    string
+	meID     int
+	name     string // Display name for previews when meID is set
 
-	issueIDFloat, err := req.RequireFloat("issue_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-	issueID := int(issueIDFloat)
+	mu        sync.Mutex
+	byProject map[int]int
+}
 
-	filename, err := req.RequireString("filename")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+func newBatchAssignee(resolver *redmine.Resolver, query string) *batchAssignee {
+	return &batchAssignee{resolver: resolver, query: query, byProject: make(map[int]int)}
+}
+
+// resolve returns the assignee's user ID in the context of projectID
+func (a *batchAssignee) resolve(projectID int) (int, error) {
+	if a.meID > 0 {
+		return a.meID, nil
 	}
 
-	contentB64, err := req.RequireString("content")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+	a.mu.Lock()
+	id, ok := a.byProject[projectID]
+	a.mu.Unlock()
+	if ok {
+		return id, nil
 	}
 
-	decoded, err := base64.StdEncoding.DecodeString(contentB64)
+	id, err := a.resolver.ResolveAssignee(a.query, projectID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid base64 content: %v", err)), nil
+		return 0, err
 	}
 
-	if len(decoded) > maxMCPAttachmentSize {
-		return mcp.NewToolResultError(fmt.Sprintf("File too large: %d bytes (max %d bytes / 3MB)", len(decoded), maxMCPAttachmentSize)), nil
-	}
+	a.mu.Lock()
+	a.byProject[projectID] = id
+	a.mu.Unlock()
+	return id, nil
+}
 
-	// Step 1: Upload
-	token, err := h.client.UploadFile(filename, bytes.NewReader(decoded))
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to upload file: %v", err)), nil
-	}
+// batchUpdateIssue applies a batch update to one issue and returns a failure entry, or nil on success
+func (h *ToolHandlers) batchUpdateIssue(issueID int, base redmine.UpdateIssueParams, assignee *batchAssignee) map[string]any {
+	params := base
+	params.IssueID = issueID
 
-	contentType := req.GetString("content_type", "")
-	if contentType != "" {
-		token.ContentType = contentType
-	}
-	token.Description = req.GetString("description", "")
+	// The issue is only needed for project context (assignee) or transition validation
+	validate := params.StatusID > 0 && h.workflowEnabled()
+	if assignee.query != "" || validate {
+		issue, err := h.client.GetIssue(issueID)
+		if err != nil {
+			return map[string]any{
+				"id":    issueID,
+				"error": i18n.Sprintf("Failed to get issue: %v", err),
+			}
+		}
 
-	// Step 2: Attach to issue
-	params := redmine.UpdateIssueParams{
-		IssueID: issueID,
-		Notes:   req.GetString("notes", ""),
-		Uploads: []redmine.UploadToken{*token},
+		if validate {
+			if err := h.validateTransition(issue, params.StatusID); err != nil {
+				return transitionFailure(issueID, err)
+			}
+		}
+
+		if assignee.query != "" {
+			userID, err := assignee.resolve(issue.Project.ID)
+			if err != nil {
+				return map[string]any{
+					"id":    issueID,
+					"error": i18n.Sprintf("Failed to resolve assignee: %v", err),
+				}
+			}
+			params.AssignedToID = userID
+		}
 	}
 
 	if err := h.client.UpdateIssue(params); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("File uploaded (token: %s) but failed to attach to issue: %v", token.Token, err)), nil
+		return map[string]any{
+			"id":    issueID,
+			"error": i18n.Sprintf("Failed to update: %v", err),
+		}
 	}
 
-	return jsonResult(map[string]any{
-		"success":  true,
-		"issue_id": issueID,
-		"filename": filename,
-		"size":     len(decoded),
-		"message":  "File uploaded and attached to issue successfully",
-	})
+	return nil
 }
 
-func (h *ToolHandlers) handleTrackersList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	trackers, err := h.resolver.GetTrackers()
+// batchPreviewIssue runs a batch update's resolution and validation for one
+// issue without updating it. It returns the changes the update would make, or
+// a failure entry shaped like batchUpdateIssue's.
+func (h *ToolHandlers) batchPreviewIssue(issueID int, base redmine.UpdateIssueParams, assignee *batchAssignee, names redmine.BatchNames) (preview, failure map[string]any) {
+	params := base
+	params.IssueID = issueID
+
+	issue, err := h.client.GetIssue(issueID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list trackers: %v", err)), nil
+		return nil, map[string]any{
+			"id":    issueID,
+			"error": i18n.Sprintf("Failed to get issue: %v", err),
+		}
 	}
 
-	result := make([]map[string]any, len(trackers))
-	for i, t := range trackers {
-		result[i] = map[string]any{
-			"id":   t.ID,
-			"name": t.Name,
+	if params.StatusID > 0 && h.workflowEnabled() {
+		if err := h.validateTransition(issue, params.StatusID); err != nil {
+			return nil, transitionFailure(issueID, err)
 		}
 	}
 
-	return jsonResult(map[string]any{
-		"trackers": result,
-		"count":    len(trackers),
-	})
-}
-
-func (h *ToolHandlers) handleStatusesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	statuses, err := h.resolver.GetStatuses()
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list statuses: %v", err)), nil
+	if assignee.query != "" {
+		userID, err := assignee.resolve(issue.Project.ID)
+		if err != nil {
+			return nil, map[string]any{
+				"id":    issueID,
+				"error": i18n.Sprintf("Failed to resolve assignee: %v", err),
+			}
+		}
+		params.AssignedToID = userID
+		names.Assignee = assignee.query
+		if assignee.meID > 0 {
+			names.Assignee = strings.TrimSpace(assignee.name)
+		}
 	}
 
-	result := make([]map[string]any, len(statuses))
-	for i, s := range statuses {
-		result[i] = map[string]any{
-			"id":        s.ID,
-			"name":      s.Name,
-			"is_closed": s.IsClosed,
-		}
+	preview = map[string]any{
+		"id":      issueID,
+		"subject": issue.Subject,
+		"changes": redmine.PreviewBatchUpdate(issue, params, names),
 	}
+	if params.Notes != "" {
+		preview["notes"] = redmine.NotePreview(params.Notes)
+	}
+	return preview, nil
+}
 
-	return jsonResult(map[string]any{
-		"statuses": result,
-		"count":    len(statuses),
-	})
+// workflowEnabled reports whether status transitions should be validated
+func (h *ToolHandlers) workflowEnabled() bool {
+	return h.workflow != nil || h.workflowSource == redmine.WorkflowSourceServer || h.workflowSource == redmine.WorkflowSourceHybrid
 }
 
-func (h *ToolHandlers) handlePrioritiesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	priorities, err := h.resolver.GetPriorities()
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list priorities: %v", err)), nil
+// validateTransition checks a status change using the configured workflow source
+func (h *ToolHandlers) validateTransition(issue *redmine.Issue, statusID int) error {
+	return h.resolver.ValidateTransition(issue, statusID, h.workflow, h.workflowSource)
+}
+
+// transitionFailure builds a batch failure entry for a rejected status transition,
+// including the current status and allowed targets when available
+func transitionFailure(issueID int, err error) map[string]any {
+	failure := map[string]any{
+		"id":    issueID,
+		"error": i18n.Sprintf("Invalid status transition: %v", err),
 	}
+	var transitionErr *redmine.TransitionError
+	if errors.As(err, &transitionErr) {
+		failure["current_status"] = transitionErr.From.Name
+		failure["allowed_statuses"] = transitionErr.AllowedNames()
+	}
+	return failure
+}
 
-	result := make([]map[string]any, len(priorities))
-	for i, p := range priorities {
-		result[i] = map[string]any{
-			"id":         p.ID,
-			"name":       p.Name,
-			"is_default": p.IsDefault,
-		}
+func (h *ToolHandlers) handleIssuesCopy(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("issues_copy"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return jsonResult(map[string]any{
-		"priorities": result,
-		"count":      len(priorities),
-	})
-}
+	issueIDFloat, err := req.RequireFloat("issue_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	sourceID := int(issueIDFloat)
 
-func (h *ToolHandlers) handleActivitiesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	activities, err := h.resolver.GetActivities()
+	// Get source issue
+	source, err := h.client.GetIssue(sourceID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list activities: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get source issue: %v", err)), nil
 	}
 
-	result := make([]map[string]any, len(activities))
-	for i, a := range activities {
-		result[i] = map[string]any{
-			"id":         a.ID,
-			"name":       a.Name,
-			"is_default": a.IsDefault,
-			"active":     a.Active,
+	// Determine target project
+	targetProjectID := source.Project.ID
+	if project := req.GetString("project", ""); project != "" {
+		targetProjectID, err = h.resolver.ResolveProject(project)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve target project: %v", err)), nil
 		}
 	}
 
-	return jsonResult(map[string]any{
-		"activities": result,
-		"count":      len(activities),
+	newIssue, report, err := h.copyIssue(source, copyOptions{
+		ProjectID:       targetProjectID,
+		Subject:         req.GetString("subject", ""),
+		CopyWatchers:    req.GetBool("copy_watchers", false),
+		CopyAttachments: req.GetBool("copy_attachments", false),
+		LinkToSource:    req.GetBool("link_to_source", true),
 	})
-}
-
-func (h *ToolHandlers) handleRolesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	roles, err := h.resolver.GetRoles()
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list roles: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create copy: %v", err)), nil
 	}
 
-	result := make([]map[string]any, len(roles))
-	for i, r := range roles {
-		result[i] = map[string]any{
-			"id":   r.ID,
-			"name": r.Name,
-		}
+	result := formatIssue(*newIssue, h.client.Links())
+	for aspect, outcome := range report {
+		result[aspect] = outcome
 	}
-
-	return jsonResult(map[string]any{
-		"roles": result,
-		"count": len(roles),
-	})
+	return jsonResult(result)
 }
 
-func (h *ToolHandlers) handleReferenceWorkflow(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if h.workflow == nil {
-		return mcp.NewToolResultError("Workflow rules not configured. Set WORKFLOW_RULES_FILE or --workflow-rules flag."), nil
+func (h *ToolHandlers) handleIssuesCopyTree(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("issues_copyTree"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	trackerStr := req.GetString("tracker", "")
+	issueIDFloat, err := req.RequireFloat("issue_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	maxDepth := req.GetInt("max_depth", 5)
+	if maxDepth < 0 {
+		return mcp.NewToolResultError(i18n.T("max_depth must be 0 or greater")), nil
+	}
 
-	if trackerStr != "" {
-		// Show specific tracker
-		trackerID, err := h.resolver.ResolveTracker(trackerStr)
+	opts := copyOptions{DateOffsetDays: req.GetInt("date_offset_days", 0)}
+	if project := req.GetString("project", ""); project != "" {
+		opts.ProjectID, err = h.resolver.ResolveProject(project)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve tracker: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve target project: %v", err)), nil
 		}
+	}
 
-		trackerKey := strconv.Itoa(trackerID)
-		tracker, ok := h.workflow.Trackers[trackerKey]
-		if !ok {
-			return mcp.NewToolResultError(fmt.Sprintf("No workflow rules defined for tracker %s (ID: %d)", trackerStr, trackerID)), nil
-		}
+	root, err := h.client.GetIssue(int(issueIDFloat))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get issue: %v", err)), nil
+	}
 
-		return jsonResult(formatTrackerWorkflow(trackerID, tracker))
+	tree, fetched, truncated, err := h.buildIssueTree(*root, maxDepth, maxCopyTreeIssues)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to fetch subtasks: %v", err)), nil
+	}
+	if truncated {
+		// Copying part of a tree would silently drop subtasks
+		return mcp.NewToolResultError(i18n.Sprintf("The subtree has more than %d issues; reduce max_depth or copy a deeper subtask separately", maxCopyTreeIssues)), nil
 	}
 
-	// Show all trackers summary
-	trackers := make([]map[string]any, 0, len(h.workflow.Trackers))
-	for idStr, tracker := range h.workflow.Trackers {
-		id, _ := strconv.Atoi(idStr)
-		trackers = append(trackers, formatTrackerWorkflow(id, tracker))
+	out := &treeCopy{idMap: make(map[string]int)}
+	h.copyTree(tree, 0, opts, req.GetString("subject_prefix", ""), req.GetString("subject_suffix", ""), out)
+
+	newRootID, ok := out.idMap[strconv.Itoa(root.ID)]
+	if !ok {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to copy root issue #%d: %v", root.ID, out.failed[0]["error"])), nil
 	}
 
-	return jsonResult(map[string]any{
-		"trackers": trackers,
-		"count":    len(trackers),
-	})
+	response := map[string]any{
+		"success":      len(out.failed) == 0,
+		"source_id":    root.ID,
+		"new_root_id":  newRootID,
+		"id_map":       out.idMap,
+		"copied_count": len(out.idMap),
+		"source_count": fetched,
+	}
+	if len(out.failed) > 0 {
+		response["failed"] = out.failed
+	}
+	if len(out.skipped) > 0 {
+		response["skipped"] = out.skipped
+	}
+	return jsonResult(response)
 }
 
-// --- Group A: CRUD Gaps ---
+// --- Group C: Versions ---
 
-func (h *ToolHandlers) handleTimeEntriesUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleVersionsProgress(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-
-	idFloat, err := req.RequireFloat("time_entry_id")
+	versionStr, err := req.RequireString("version")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	timeEntryID := int(idFloat)
 
-	params := redmine.UpdateTimeEntryParams{
-		TimeEntryID: timeEntryID,
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
-
-	if args := req.GetArguments(); args != nil {
-		if v, ok := args["hours"]; ok {
-			if f, ok := v.(float64); ok {
-				params.Hours = f
-			}
-		}
+	versionID, err := h.resolver.ResolveVersion(versionStr, projectID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve version: %v", err)), nil
 	}
 
-	if activity := req.GetString("activity", ""); activity != "" {
-		activityID, err := h.resolver.ResolveActivity(activity)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve activity: %v", err)), nil
-		}
-		params.ActivityID = activityID
+	today, err := h.now(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	params.Comments = req.GetString("comments", "")
-	params.SpentOn = req.GetString("spent_on", "")
+	version, err := h.client.GetVersion(versionID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get version: %v", err)), nil
+	}
+	priorities, err := h.resolver.GetPriorities()
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to load priorities: %v", err)), nil
+	}
 
-	if err := h.client.UpdateTimeEntry(params); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update time entry: %v", err)), nil
+	atRiskDays := req.GetInt("at_risk_days", redmine.DefaultAtRiskDays)
+	progress, err := redmine.BuildVersionProgress(h.client.WithContext(ctx), projectID, *version, today, atRiskDays, redmine.HighPriorityIDs(priorities))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to build version progress: %v", err)), nil
 	}
 
-	return jsonResult(map[string]any{
-		"success":       true,
-		"time_entry_id": timeEntryID,
-		"message":       "Time entry updated successfully",
-	})
+	return jsonResult(progress)
 }
 
-func (h *ToolHandlers) handleTimeEntriesDelete(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleVersionsList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	idFloat, err := req.RequireFloat("time_entry_id")
+	projectID, err := h.resolver.ResolveProject(projectStr)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
-	timeEntryID := int(idFloat)
 
-	if err := h.client.DeleteTimeEntry(timeEntryID); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete time entry: %v", err)), nil
+	versions, err := h.client.ListVersions(projectID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list versions: %v", err)), nil
 	}
 
-	return jsonResult(map[string]any{
-		"success":       true,
-		"time_entry_id": timeEntryID,
-		"message":       "Time entry deleted successfully",
-	})
+	results := make([]map[string]any, len(versions))
+	for i, v := range versions {
+		results[i] = map[string]any{
+			"id":          v.ID,
+			"url":         h.client.Links().Version(v.ID),
+			"name":        v.Name,
+			"description": v.Description,
+			"status":      v.Status,
+			"due_date":    v.DueDate,
+			"sharing":     v.Sharing,
+			"created_on":  v.CreatedOn,
+			"updated_on":  v.UpdatedOn,
+		}
+	}
+
+	return jsonResult(wholeList(map[string]any{
+		"versions": results,
+	}, len(versions)))
 }
 
-func (h *ToolHandlers) handleIssuesRemoveWatcher(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleVersionsCreate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("versions_create"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	issueIDFloat, err := req.RequireFloat("issue_id")
+	projectStr, err := req.RequireString("project")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	issueID := int(issueIDFloat)
 
-	user, err := req.RequireString("user")
+	name, err := req.RequireString("name")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// Get issue to resolve project context
-	issue, err := h.client.GetIssue(issueID)
+	projectID, err := h.resolver.ResolveProject(projectStr)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get issue: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
-	userID, err := h.resolver.ResolveUser(user, issue.Project.ID)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve user: %v", err)), nil
+	params := redmine.CreateVersionParams{
+		ProjectID:   projectID,
+		Name:        name,
+		Description: req.GetString("description", ""),
+		Status:      req.GetString("status", ""),
+		DueDate:     req.GetString("due_date", ""),
+		Sharing:     req.GetString("sharing", ""),
 	}
 
-	if err := h.client.RemoveWatcher(issueID, userID); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove watcher: %v", err)), nil
+	version, err := h.client.CreateVersion(params)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create version: %v", err)), nil
 	}
 
 	return jsonResult(map[string]any{
-		"success":  true,
-		"issue_id": issueID,
-		"user_id":  userID,
-		"message":  "Watcher removed successfully",
+		"id":          version.ID,
+		"name":        version.Name,
+		"description": version.Description,
+		"status":      version.Status,
+		"due_date":    version.DueDate,
+		"sharing":     version.Sharing,
+		"created_on":  version.CreatedOn,
 	})
 }
 
-func (h *ToolHandlers) handleIssuesRemoveRelation(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleVersionsUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("versions_update"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	idFloat, err := req.RequireFloat("relation_id")
+	idFloat, err := req.RequireFloat("version_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	relationID := int(idFloat)
+	versionID := int(idFloat)
 
-	if err := h.client.DeleteRelation(relationID); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove relation: %v", err)), nil
+	params := redmine.UpdateVersionParams{
+		VersionID:   versionID,
+		Name:        req.GetString("name", ""),
+		Description: req.GetString("description", ""),
+		Status:      req.GetString("status", ""),
+		DueDate:     req.GetString("due_date", ""),
+		Sharing:     req.GetString("sharing", ""),
+	}
+
+	if err := h.client.UpdateVersion(params); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to update version: %v", err)), nil
 	}
 
 	return jsonResult(map[string]any{
-		"success":     true,
-		"relation_id": relationID,
-		"message":     "Relation removed successfully",
+		"success":    true,
+		"version_id": versionID,
+		"message":    i18n.T("Version updated successfully"),
 	})
 }
 
-// --- Group E: User Search ---
-
-func (h *ToolHandlers) handleUsersSearch(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	params := redmine.SearchUsersParams{}
-
-	params.Name = req.GetString("name", "")
+// --- Issue Categories ---
 
-	if project := req.GetString("project", ""); project != "" {
-		projectID, err := h.resolver.ResolveProject(project)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
-		}
-		params.ProjectID = projectID
+func (h *ToolHandlers) handleCategoriesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	params.Status = req.GetInt("status", 0)
-	params.Limit = req.GetInt("limit", 25)
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
 
-	users, total, err := h.client.SearchUsers(params)
+	categories, err := h.client.ListIssueCategories(projectID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to search users: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list issue categories: %v", err)), nil
 	}
 
-	results := make([]map[string]any, len(users))
-	for i, u := range users {
+	results := make([]map[string]any, len(categories))
+	for i, c := range categories {
 		result := map[string]any{
-			"id":   u.ID,
-			"name": u.Name,
-		}
-		if u.Login != "" {
-			result["login"] = u.Login
+			"id":   c.ID,
+			"name": c.Name,
 		}
-		if u.Mail != "" {
-			result["email"] = u.Mail
+		if c.AssignedTo.ID > 0 {
+			result["assigned_to"] = map[string]any{
+				"id":   c.AssignedTo.ID,
+				"name": c.AssignedTo.Name,
+			}
 		}
 		results[i] = result
 	}
 
-	return jsonResult(map[string]any{
-		"users":       results,
-		"count":       len(users),
-		"total_count": total,
-	})
+	return jsonResult(wholeList(map[string]any{
+		"categories": results,
+	}, len(categories)))
 }
 
-// --- Global Search ---
-
-func (h *ToolHandlers) handleSearchGlobal(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	q := req.GetString("q", "")
-	if q == "" {
-		return mcp.NewToolResultError("q (search query) is required"), nil
-	}
-
-	params := redmine.GlobalSearchParams{
-		Query:      q,
-		Scope:      req.GetString("scope", ""),
-		TitlesOnly: req.GetBool("titles_only", false),
-		Issues:     req.GetBool("issues", false),
-		WikiPages:  req.GetBool("wiki_pages", false),
-		News:       req.GetBool("news", false),
-		Documents:  req.GetBool("documents", false),
-		Changesets: req.GetBool("changesets", false),
-		Messages:   req.GetBool("messages", false),
-		Projects:   req.GetBool("projects", false),
-		Offset:     req.GetInt("offset", 0),
-		Limit:      req.GetInt("limit", 25),
+func (h *ToolHandlers) handleCategoriesCreate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("categories_create"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	results, total, err := h.client.GlobalSearch(params)
+	projectStr, err := req.RequireString("project")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to search: %v", err)), nil
-	}
-
-	items := make([]map[string]any, len(results))
-	for i, r := range results {
-		item := map[string]any{
-			"id":    r.ID,
-			"title": r.Title,
-			"type":  r.Type,
-			"url":   r.URL,
-		}
-		if r.Description != "" {
-			item["description"] = r.Description
-		}
-		if r.Datetime != "" {
-			item["datetime"] = r.Datetime
-		}
-		items[i] = item
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return jsonResult(map[string]any{
-		"results":     items,
-		"count":       len(results),
-		"total_count": total,
-	})
-}
-
-// --- Group B: Batch & Copy ---
-
-func (h *ToolHandlers) handleIssuesBatchUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+	name, err := req.RequireString("name")
+	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	issueIDsRaw := getArrayArg(req, "issue_ids")
-	if len(issueIDsRaw) == 0 {
-		return mcp.NewToolResultError("issue_ids is required and must be a non-empty array"), nil
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
-	// Parse issue IDs
-	issueIDs := make([]int, 0, len(issueIDsRaw))
-	for _, raw := range issueIDsRaw {
-		if f, ok := raw.(float64); ok {
-			issueIDs = append(issueIDs, int(f))
-		} else {
-			return mcp.NewToolResultError(fmt.Sprintf("Invalid issue ID: %v", raw)), nil
-		}
+	params := redmine.CreateIssueCategoryParams{
+		ProjectID: projectID,
+		Name:      name,
 	}
 
-	// Pre-resolve common fields once
-	var statusID int
-	if status := req.GetString("status", ""); status != "" {
-		var err error
-		statusID, err = h.resolver.ResolveStatusID(status)
+	// Resolve assigned_to if provided
+	if assignedTo := req.GetString("assigned_to", ""); assignedTo != "" {
+		userID, err := h.resolver.ResolveUser(assignedTo, projectID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve status: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve assigned_to: %v", err)), nil
 		}
+		params.AssignedToID = userID
 	}
 
-	var priorityID int
-	if priority := req.GetString("priority", ""); priority != "" {
-		var err error
-		priorityID, err = h.resolver.ResolvePriority(priority)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve priority: %v", err)), nil
-		}
+	category, err := h.client.CreateIssueCategory(params)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create issue category: %v", err)), nil
 	}
 
-	notes := req.GetString("notes", "")
-	assignedToStr := req.GetString("assigned_to", "")
-
-	// Process each issue
-	var successIDs []int
-	var failures []map[string]any
-
-	for _, issueID := range issueIDs {
-		params := redmine.UpdateIssueParams{
-			IssueID:    issueID,
-			StatusID:   statusID,
-			PriorityID: priorityID,
-			Notes:      notes,
+	result := map[string]any{
+		"id":   category.ID,
+		"name": category.Name,
+	}
+	if category.AssignedTo.ID > 0 {
+		result["assigned_to"] = map[string]any{
+			"id":   category.AssignedTo.ID,
+			"name": category.AssignedTo.Name,
 		}
+	}
 
-		// Resolve assigned_to per issue (needs project context)
-		if assignedToStr != "" {
-			issue, err := h.client.GetIssue(issueID)
-			if err != nil {
-				failures = append(failures, map[string]any{
-					"id":    issueID,
-					"error": fmt.Sprintf("Failed to get issue: %v", err),
-				})
-				continue
-			}
-
-			// Validate status transition if status is being changed
-			if statusID > 0 && h.workflow != nil {
-				if err := h.workflow.ValidateTransition(issue.Tracker.ID, issue.Status.ID, statusID); err != nil {
-					failures = append(failures, map[string]any{
-						"id":    issueID,
-						"error": fmt.Sprintf("Invalid status transition: %v", err),
-					})
-					continue
-				}
-			}
+	return jsonResult(result)
+}
 
-			if assignedToStr == "me" {
-				user, err := h.client.GetCurrentUser()
-				if err != nil {
-					failures = append(failures, map[string]any{
-						"id":    issueID,
-						"error": fmt.Sprintf("Failed to get current user: %v", err),
-					})
-					continue
-				}
-				params.AssignedToID = user.ID
-			} else {
-				userID, err := h.resolver.ResolveUser(assignedToStr, issue.Project.ID)
-				if err != nil {
-					failures = append(failures, map[string]any{
-						"id":    issueID,
-						"error": fmt.Sprintf("Failed to resolve assignee: %v", err),
-					})
-					continue
-				}
-				params.AssignedToID = userID
-			}
-		} else if statusID > 0 && h.workflow != nil {
-			// Still need to validate status transition
-			issue, err := h.client.GetIssue(issueID)
-			if err != nil {
-				failures = append(failures, map[string]any{
-					"id":    issueID,
-					"error": fmt.Sprintf("Failed to get issue: %v", err),
-				})
-				continue
-			}
-			if err := h.workflow.ValidateTransition(issue.Tracker.ID, issue.Status.ID, statusID); err != nil {
-				failures = append(failures, map[string]any{
-					"id":    issueID,
-					"error": fmt.Sprintf("Invalid status transition: %v", err),
-				})
-				continue
-			}
-		}
+func (h *ToolHandlers) handleCategoriesUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("categories_update"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-		if err := h.client.UpdateIssue(params); err != nil {
-			failures = append(failures, map[string]any{
-				"id":    issueID,
-				"error": fmt.Sprintf("Failed to update: %v", err),
-			})
-			continue
+	idFloat, err := req.RequireFloat("category_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	categoryID := int(idFloat)
+
+	params := redmine.UpdateIssueCategoryParams{
+		CategoryID: categoryID,
+		Name:       req.GetString("name", ""),
+	}
+
+	// Resolve assigned_to if provided
+	if assignedTo := req.GetString("assigned_to", ""); assignedTo != "" {
+		// We don't have project context here, so pass 0 (resolver will search all projects)
+		userID, err := h.resolver.ResolveUser(assignedTo, 0)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve assigned_to: %v", err)), nil
 		}
+		params.AssignedToID = userID
+	}
 
-		successIDs = append(successIDs, issueID)
+	if err := h.client.UpdateIssueCategory(params); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to update issue category: %v", err)), nil
 	}
 
 	return jsonResult(map[string]any{
-		"success": successIDs,
-		"failed":  failures,
+		"success":     true,
+		"category_id": categoryID,
+		"message":     i18n.T("Category updated successfully"),
 	})
 }
 
-func (h *ToolHandlers) handleIssuesCopy(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleCategoriesDelete(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("categories_delete"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	issueIDFloat, err := req.RequireFloat("issue_id")
+	idFloat, err := req.RequireFloat("category_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	sourceID := int(issueIDFloat)
+	categoryID := int(idFloat)
 
-	// Get source issue
-	source, err := h.client.GetIssue(sourceID)
+	if err := h.client.DeleteIssueCategory(categoryID); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to delete issue category: %v", err)), nil
+	}
+
+	return jsonResult(map[string]any{
+		"success":     true,
+		"category_id": categoryID,
+		"message":     i18n.T("Category deleted successfully"),
+	})
+}
+
+// --- Project Memberships ---
+
+func (h *ToolHandlers) handleMembershipsList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get source issue: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// Determine target project
-	targetProjectID := source.Project.ID
-	if project := req.GetString("project", ""); project != "" {
-		targetProjectID, err = h.resolver.ResolveProject(project)
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	// A user's roles may come from any membership, so they need every page
+	if userStr := req.GetString("user", ""); userStr != "" {
+		memberships, err := h.client.ListProjectMemberships(projectID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve target project: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to list memberships: %v", err)), nil
 		}
+		return h.userEffectiveRoles(userStr, projectID, memberships)
 	}
 
-	// Build create params from source issue
-	subject := source.Subject
-	if overrideSubject := req.GetString("subject", ""); overrideSubject != "" {
-		subject = overrideSubject
+	offset := max(req.GetInt("offset", 0), 0)
+	limit := req.GetInt("limit", redmine.MaxMembershipsLimit)
+	if limit <= 0 || limit > redmine.MaxMembershipsLimit {
+		limit = redmine.MaxMembershipsLimit
 	}
-
-	params := redmine.CreateIssueParams{
-		ProjectID:   targetProjectID,
-		TrackerID:   source.Tracker.ID,
-		Subject:     subject,
-		Description: source.Description,
-		PriorityID:  source.Priority.ID,
-		StartDate:   source.StartDate,
-		DueDate:     source.DueDate,
+	memberships, total, err := h.client.GetProjectMemberships(projectID, offset, limit)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list memberships: %v", err)), nil
 	}
 
-	if source.AssignedTo != nil {
-		params.AssignedToID = source.AssignedTo.ID
+	return jsonResult(paginate(map[string]any{
+		"memberships": membershipEntries(memberships),
+	}, len(memberships), offset, limit, total))
+}
+
+// userEffectiveRoles answers memberships_list for one user, combining their
+// own membership with the memberships of their groups
+func (h *ToolHandlers) userEffectiveRoles(userStr string, projectID int, memberships []redmine.ProjectMembership) (*mcp.CallToolResult, error) {
+	userID, err := h.resolver.ResolveUser(userStr, projectID)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve user: %v", err)), nil
 	}
 
-	// Copy custom fields
-	if len(source.CustomFields) > 0 {
-		cfMap := make(map[string]any)
-		for _, cf := range source.CustomFields {
-			if cf.Value != nil && cf.Value != "" {
-				cfMap[strconv.Itoa(cf.ID)] = cf.Value
-			}
-		}
-		if len(cfMap) > 0 {
-			params.CustomFields = cfMap
-		}
+	result := map[string]any{}
+	user := map[string]any{"id": userID}
+	var groups []redmine.IDName
+	if detail, err := h.client.GetUser(userID, []string{"groups"}); err != nil {
+		result["note"] = i18n.T("Could not read the user's groups, so inherited roles don't name their group")
+	} else {
+		user["name"] = strings.TrimSpace(detail.Firstname + " " + detail.Lastname)
+		groups = detail.Groups
 	}
+	result["user"] = user
 
-	newIssue, err := h.client.CreateIssue(params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create copy: %v", err)), nil
+	roles, used := redmine.EffectiveRoles(userID, groups, memberships)
+	if roles == nil {
+		roles = []redmine.EffectiveRole{}
+		result["note"] = i18n.T("The user has no roles on this project")
 	}
+	result["effective_roles"] = roles
+	result["memberships"] = membershipEntries(used)
+	result["count"] = len(used)
+	return jsonResult(result)
+}
 
-	return jsonResult(formatIssue(*newIssue))
+// membershipEntries formats memberships for memberships_list
+func membershipEntries(memberships []redmine.ProjectMembership) []map[string]any {
+	result := make([]map[string]any, len(memberships))
+	for i, m := range memberships {
+		entry := map[string]any{
+			"id":      m.ID,
+			"project": map[string]any{"id": m.Project.ID, "name": m.Project.Name},
+			"roles":   m.Roles,
+		}
+		if m.User != nil {
+			entry["user"] = map[string]any{"id": m.User.ID, "name": m.User.Name}
+		}
+		if m.Group != nil {
+			entry["group"] = map[string]any{"id": m.Group.ID, "name": m.Group.Name}
+		}
+		result[i] = entry
+	}
+	return result
 }
 
-// --- Group C: Versions ---
+func (h *ToolHandlers) handleMembershipsAdd(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("memberships_add"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-func (h *ToolHandlers) handleVersionsList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	projectStr, err := req.RequireString("project")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
@@ -2911,113 +5629,127 @@ func (h *ToolHandlers) handleVersionsList(ctx context.Context, req mcp.CallToolR
 
 	projectID, err := h.resolver.ResolveProject(projectStr)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
-	versions, err := h.client.ListVersions(projectID)
+	userStr := req.GetString("user", "")
+	groupStr := req.GetString("group", "")
+
+	if userStr == "" && groupStr == "" {
+		return mcp.NewToolResultError(i18n.T("Must specify either user or group")), nil
+	}
+	if userStr != "" && groupStr != "" {
+		return mcp.NewToolResultError(i18n.T("Cannot specify both user and group")), nil
+	}
+
+	roleIDs, err := h.roleIDsArg(req)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list versions: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	results := make([]map[string]any, len(versions))
-	for i, v := range versions {
-		results[i] = map[string]any{
-			"id":          v.ID,
-			"name":        v.Name,
-			"description": v.Description,
-			"status":      v.Status,
-			"due_date":    v.DueDate,
-			"sharing":     v.Sharing,
-			"created_on":  v.CreatedOn,
-			"updated_on":  v.UpdatedOn,
+	var userID *int
+	var groupID *int
+
+	if userStr != "" {
+		uid, err := h.resolver.ResolveUser(userStr, projectID)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve user: %v", err)), nil
+		}
+		userID = &uid
+	}
+
+	if groupStr != "" {
+		gid, err := h.resolver.ResolveGroup(groupStr)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve group: %v", err)), nil
 		}
+		groupID = &gid
+	}
+
+	membership, err := h.client.CreateProjectMembership(projectID, userID, groupID, roleIDs)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create membership: %v", err)), nil
 	}
 
 	return jsonResult(map[string]any{
-		"versions": results,
-		"count":    len(versions),
+		"success":       true,
+		"membership_id": membership.ID,
+		"membership":    membership,
+		"message":       i18n.T("Membership added successfully"),
 	})
 }
 
-func (h *ToolHandlers) handleVersionsCreate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleMembershipsUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("memberships_update"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	projectStr, err := req.RequireString("project")
+	idFloat, err := req.RequireFloat("membership_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	membershipID := int(idFloat)
 
-	name, err := req.RequireString("name")
+	roleIDs, err := h.roleIDsArg(req)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	projectID, err := h.resolver.ResolveProject(projectStr)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+	if err := h.client.UpdateProjectMembership(membershipID, roleIDs); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to update membership: %v", err)), nil
 	}
 
-	params := redmine.CreateVersionParams{
-		ProjectID:   projectID,
-		Name:        name,
-		Description: req.GetString("description", ""),
-		Status:      req.GetString("status", ""),
-		DueDate:     req.GetString("due_date", ""),
-		Sharing:     req.GetString("sharing", ""),
+	return jsonResult(map[string]any{
+		"success":       true,
+		"membership_id": membershipID,
+		"message":       i18n.T("Membership updated successfully"),
+	})
+}
+
+// roleIDsArg resolves the roles argument of the membership tools
+func (h *ToolHandlers) roleIDsArg(req mcp.CallToolRequest) ([]int, error) {
+	rolesArray := getArrayArg(req, "roles")
+	if len(rolesArray) == 0 {
+		return nil, i18n.Errorf("must specify at least one role")
 	}
 
-	version, err := h.client.CreateVersion(params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create version: %v", err)), nil
+	roles := make([]string, 0, len(rolesArray))
+	for _, roleItem := range rolesArray {
+		roleStr, ok := roleItem.(string)
+		if !ok {
+			return nil, i18n.Errorf("role must be a string (name or ID)")
+		}
+		roles = append(roles, roleStr)
 	}
 
-	return jsonResult(map[string]any{
-		"id":          version.ID,
-		"name":        version.Name,
-		"description": version.Description,
-		"status":      version.Status,
-		"due_date":    version.DueDate,
-		"sharing":     version.Sharing,
-		"created_on":  version.CreatedOn,
-	})
+	return h.resolver.ResolveRoles(roles)
 }
 
-func (h *ToolHandlers) handleVersionsUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleMembershipsRemove(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("memberships_remove"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	idFloat, err := req.RequireFloat("version_id")
+	idFloat, err := req.RequireFloat("membership_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	versionID := int(idFloat)
-
-	params := redmine.UpdateVersionParams{
-		VersionID:   versionID,
-		Name:        req.GetString("name", ""),
-		Description: req.GetString("description", ""),
-		Status:      req.GetString("status", ""),
-		DueDate:     req.GetString("due_date", ""),
-		Sharing:     req.GetString("sharing", ""),
-	}
+	membershipID := int(idFloat)
 
-	if err := h.client.UpdateVersion(params); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update version: %v", err)), nil
+	if err := h.client.DeleteProjectMembership(membershipID); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to remove membership: %v", err)), nil
 	}
 
 	return jsonResult(map[string]any{
-		"success":    true,
-		"version_id": versionID,
-		"message":    "Version updated successfully",
+		"success":       true,
+		"membership_id": membershipID,
+		"message":       i18n.T("Membership removed successfully"),
 	})
 }
 
-// --- Issue Categories ---
+// --- Group D: Wiki ---
 
-func (h *ToolHandlers) handleCategoriesList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (h *ToolHandlers) handleWikiList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	projectStr, err := req.RequireString("project")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
@@ -3025,189 +5757,268 @@ func (h *ToolHandlers) handleCategoriesList(ctx context.Context, req mcp.CallToo
 
 	projectID, err := h.resolver.ResolveProject(projectStr)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
-	categories, err := h.client.ListIssueCategories(projectID)
+	pages, err := h.client.ListWikiPages(projectID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list issue categories: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list wiki pages: %v", err)), nil
 	}
 
-	results := make([]map[string]any, len(categories))
-	for i, c := range categories {
-		result := map[string]any{
-			"id":   c.ID,
-			"name": c.Name,
+	links := h.client.Links()
+	results := make([]map[string]any, len(pages))
+	for i, p := range pages {
+		results[i] = map[string]any{
+			"title":      p.Title,
+			"url":        links.WikiPage(strconv.Itoa(projectID), p.Title),
+			"version":    p.Version,
+			"created_on": p.CreatedOn,
+			"updated_on": p.UpdatedOn,
 		}
-		if c.AssignedTo.ID > 0 {
-			result["assigned_to"] = map[string]any{
-				"id":   c.AssignedTo.ID,
-				"name": c.AssignedTo.Name,
-			}
+		if p.Parent != nil {
+			results[i]["parent_title"] = p.Parent.Title
 		}
-		results[i] = result
 	}
 
-	return jsonResult(map[string]any{
-		"categories": results,
-		"count":      len(categories),
-	})
+	return jsonResult(wholeList(map[string]any{
+		"wiki_pages": results,
+	}, len(pages)))
+}
+
+func (h *ToolHandlers) handleWikiGet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	title, err := req.RequireString("title")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	page, err := h.client.GetWikiPage(projectID, title)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get wiki page: %v", err)), nil
+	}
+
+	return jsonResult(formatWikiPage(page, projectID, h.client.Links()))
+}
+
+// Wiki history fetches one request per version, so the number listed is capped
+const (
+	defaultWikiHistoryVersions = 20
+	maxWikiHistoryVersions     = 100
+)
+
+// formatWikiPage returns the fields shared by wiki_get and wiki_getVersion
+func formatWikiPage(page *redmine.WikiPageDetail, projectID int, links redmine.Links) map[string]any {
+	result := map[string]any{
+		"title":      page.Title,
+		"url":        links.WikiPage(strconv.Itoa(projectID), page.Title),
+		"text":       page.Text,
+		"version":    page.Version,
+		"author":     map[string]any{"id": page.Author.ID, "name": page.Author.Name},
+		"comments":   page.Comments,
+		"created_on": page.CreatedOn,
+		"updated_on": page.UpdatedOn,
+	}
+	if page.Parent != nil {
+		result["parent_title"] = page.Parent.Title
+	}
+	if len(page.Attachments) > 0 {
+		result["attachments"] = formatAttachments(page.Attachments, links)
+	}
+	return result
 }
 
-func (h *ToolHandlers) handleCategoriesCreate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleWikiGetVersion(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	projectStr, err := req.RequireString("project")
+	title, err := req.RequireString("title")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	name, err := req.RequireString("name")
+	version, err := req.RequireFloat("version")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	projectID, err := h.resolver.ResolveProject(projectStr)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
-	params := redmine.CreateIssueCategoryParams{
-		ProjectID: projectID,
-		Name:      name,
+	page, err := h.client.GetWikiPageVersion(projectID, title, int(version))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get wiki page version: %v", err)), nil
 	}
 
-	// Resolve assigned_to if provided
-	if assignedTo := req.GetString("assigned_to", ""); assignedTo != "" {
-		userID, err := h.resolver.ResolveUser(assignedTo, projectID)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve assigned_to: %v", err)), nil
-		}
-		params.AssignedToID = userID
+	return jsonResult(formatWikiPage(page, projectID, h.client.Links()))
+}
+
+func (h *ToolHandlers) handleWikiHistory(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	category, err := h.client.CreateIssueCategory(params)
+	title, err := req.RequireString("title")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create issue category: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	result := map[string]any{
-		"id":   category.ID,
-		"name": category.Name,
+	limit := min(max(req.GetInt("limit", defaultWikiHistoryVersions), 1), maxWikiHistoryVersions)
+
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
-	if category.AssignedTo.ID > 0 {
-		result["assigned_to"] = map[string]any{
-			"id":   category.AssignedTo.ID,
-			"name": category.AssignedTo.Name,
+
+	current, err := h.client.GetWikiPage(projectID, title)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get wiki page: %v", err)), nil
+	}
+
+	oldest := max(1, current.Version-limit+1)
+	versions := make([]map[string]any, 0, current.Version-oldest+1)
+	for v := current.Version; v >= oldest; v-- {
+		page := current
+		if v != current.Version {
+			page, err = h.client.GetWikiPageVersion(projectID, title, v)
+			if err != nil {
+				return mcp.NewToolResultError(i18n.Sprintf("Failed to get wiki page version %d: %v", v, err)), nil
+			}
 		}
+		versions = append(versions, map[string]any{
+			"version":    v,
+			"author":     map[string]any{"id": page.Author.ID, "name": page.Author.Name},
+			"comments":   page.Comments,
+			"updated_on": page.UpdatedOn,
+		})
 	}
 
-	return jsonResult(result)
+	return jsonResult(paginate(map[string]any{
+		"title":           current.Title,
+		"current_version": current.Version,
+		"versions":        versions,
+	}, len(versions), 0, limit, current.Version))
 }
 
-func (h *ToolHandlers) handleCategoriesUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleWikiDiff(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	idFloat, err := req.RequireFloat("category_id")
+	title, err := req.RequireString("title")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	categoryID := int(idFloat)
 
-	params := redmine.UpdateIssueCategoryParams{
-		CategoryID: categoryID,
-		Name:       req.GetString("name", ""),
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
-	// Resolve assigned_to if provided
-	if assignedTo := req.GetString("assigned_to", ""); assignedTo != "" {
-		// We don't have project context here, so pass 0 (resolver will search all projects)
-		userID, err := h.resolver.ResolveUser(assignedTo, 0)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve assigned_to: %v", err)), nil
-		}
-		params.AssignedToID = userID
+	toVersion := req.GetInt("to_version", 0)
+	var newer *redmine.WikiPageDetail
+	if toVersion > 0 {
+		newer, err = h.client.GetWikiPageVersion(projectID, title, toVersion)
+	} else {
+		newer, err = h.client.GetWikiPage(projectID, title)
+	}
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get wiki page: %v", err)), nil
 	}
 
-	if err := h.client.UpdateIssueCategory(params); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update issue category: %v", err)), nil
+	fromVersion := req.GetInt("from_version", newer.Version-1)
+	if fromVersion < 1 {
+		return mcp.NewToolResultError(i18n.Sprintf("Version %d is the first version; there is nothing to compare it with", newer.Version)), nil
+	}
+	older, err := h.client.GetWikiPageVersion(projectID, title, fromVersion)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get wiki page version %d: %v", fromVersion, err)), nil
+	}
+
+	diff, err := unifiedDiff(older.Text, newer.Text,
+		fmt.Sprintf("%s (version %d)", title, older.Version),
+		fmt.Sprintf("%s (version %d)", title, newer.Version), 3)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to compute diff: %v", err)), nil
 	}
 
 	return jsonResult(map[string]any{
-		"success":     true,
-		"category_id": categoryID,
-		"message":     "Category updated successfully",
+		"title":        title,
+		"from_version": older.Version,
+		"to_version":   newer.Version,
+		"changed":      diff != "",
+		"diff":         diff,
 	})
 }
 
-func (h *ToolHandlers) handleCategoriesDelete(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleWikiCreateOrUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("wiki_createOrUpdate"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	idFloat, err := req.RequireFloat("category_id")
+	projectStr, err := req.RequireString("project")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	categoryID := int(idFloat)
 
-	if err := h.client.DeleteIssueCategory(categoryID); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete issue category: %v", err)), nil
+	title, err := req.RequireString("title")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return jsonResult(map[string]any{
-		"success":     true,
-		"category_id": categoryID,
-		"message":     "Category deleted successfully",
-	})
-}
-
-// --- Project Memberships ---
-
-func (h *ToolHandlers) handleMembershipsList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectStr, err := req.RequireString("project")
+	text, err := req.RequireString("text")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	projectID, err := h.resolver.ResolveProject(projectStr)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
-	memberships, err := h.client.GetProjectMemberships(projectID, 1000)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list memberships: %v", err)), nil
+	params := redmine.WikiPageParams{
+		ProjectID:   projectID,
+		Title:       title,
+		Text:        text,
+		Comments:    req.GetString("comments", ""),
+		ParentTitle: req.GetString("parent_title", ""),
 	}
 
-	result := make([]map[string]any, len(memberships))
-	for i, m := range memberships {
-		entry := map[string]any{
-			"id":      m.ID,
-			"project": map[string]any{"id": m.Project.ID, "name": m.Project.Name},
-			"roles":   m.Roles,
-		}
-		if m.User != nil {
-			entry["user"] = map[string]any{"id": m.User.ID, "name": m.User.Name}
-		}
-		if m.Group != nil {
-			entry["group"] = map[string]any{"id": m.Group.ID, "name": m.Group.Name}
+	if tokens := getArrayArg(req, "upload_tokens"); tokens != nil {
+		uploads, err := parseUploadTokens(tokens)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		result[i] = entry
+		params.Uploads = uploads
+	}
+
+	if err := h.client.CreateOrUpdateWikiPage(params); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create/update wiki page: %v", err)), nil
 	}
 
 	return jsonResult(map[string]any{
-		"memberships": result,
-		"count":       len(memberships),
+		"success": true,
+		"title":   title,
+		"message": i18n.T("Wiki page created/updated successfully"),
 	})
 }
 
-func (h *ToolHandlers) handleMembershipsAdd(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleWikiUploadAndAttach(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("wiki_uploadAndAttach"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
@@ -3216,139 +6027,125 @@ func (h *ToolHandlers) handleMembershipsAdd(ctx context.Context, req mcp.CallToo
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	projectID, err := h.resolver.ResolveProject(projectStr)
+	title, err := req.RequireString("title")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	userStr := req.GetString("user", "")
-	groupStr := req.GetString("group", "")
+	filename, err := req.RequireString("filename")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	if userStr == "" && groupStr == "" {
-		return mcp.NewToolResultError("Must specify either user or group"), nil
+	contentB64, err := req.RequireString("content")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-	if userStr != "" && groupStr != "" {
-		return mcp.NewToolResultError("Cannot specify both user and group"), nil
+
+	decoded, err := base64.StdEncoding.DecodeString(contentB64)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Invalid base64 content: %v", err)), nil
 	}
 
-	// Resolve roles
-	rolesArray := getArrayArg(req, "roles")
-	if len(rolesArray) == 0 {
-		return mcp.NewToolResultError("Must specify at least one role"), nil
+	if err := redmine.CheckAttachmentSize(filename, int64(len(decoded)), h.maxAttachmentSize); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("File too large: %v", err)), nil
 	}
 
-	roleIDs := make([]int, 0, len(rolesArray))
-	for _, roleItem := range rolesArray {
-		roleStr, ok := roleItem.(string)
-		if !ok {
-			return mcp.NewToolResultError("Role must be a string (name or ID)"), nil
-		}
-		roleID, err := h.resolver.ResolveRole(roleStr)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve role '%s': %v", roleStr, err)), nil
-		}
-		roleIDs = append(roleIDs, roleID)
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
-	var userID *int
-	var groupID *int
+	// Redmine only accepts attachments alongside the page text, so resend the current text
+	page, err := h.client.GetWikiPage(projectID, title)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get wiki page: %v", err)), nil
+	}
 
-	if userStr != "" {
-		uid, err := h.resolver.ResolveUser(userStr, projectID)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve user: %v", err)), nil
-		}
-		userID = &uid
+	// Step 1: Upload
+	token, err := h.client.UploadFile(filename, req.GetString("content_type", ""), bytes.NewReader(decoded))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to upload file: %v", err)), nil
 	}
+	token.Description = req.GetString("description", "")
 
-	if groupStr != "" {
-		// Groups are not resolved by name in current implementation - must be ID
-		gid, err := strconv.Atoi(groupStr)
-		if err != nil {
-			return mcp.NewToolResultError("Group must be specified as numeric ID"), nil
-		}
-		groupID = &gid
+	// Step 2: Attach to wiki page
+	params := redmine.WikiPageParams{
+		ProjectID: projectID,
+		Title:     page.Title,
+		Text:      page.Text,
+		Comments:  req.GetString("comments", ""),
+		Uploads:   []redmine.UploadToken{*token},
 	}
 
-	membership, err := h.client.CreateProjectMembership(projectID, userID, groupID, roleIDs)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create membership: %v", err)), nil
+	if err := h.client.CreateOrUpdateWikiPage(params); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("File uploaded (token: %s) but failed to attach to wiki page: %v", token.Token, err)), nil
 	}
 
 	return jsonResult(map[string]any{
-		"success":       true,
-		"membership_id": membership.ID,
-		"membership":    membership,
-		"message":       "Membership added successfully",
+		"success":  true,
+		"title":    page.Title,
+		"filename": filename,
+		"size":     len(decoded),
+		"message":  i18n.T("File uploaded and attached to wiki page successfully"),
 	})
 }
 
-func (h *ToolHandlers) handleMembershipsUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleWikiDelete(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("wiki_delete"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	idFloat, err := req.RequireFloat("membership_id")
+	projectStr, err := req.RequireString("project")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
-	membershipID := int(idFloat)
 
-	// Resolve roles
-	rolesArray := getArrayArg(req, "roles")
-	if len(rolesArray) == 0 {
-		return mcp.NewToolResultError("Must specify at least one role"), nil
+	title, err := req.RequireString("title")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	roleIDs := make([]int, 0, len(rolesArray))
-	for _, roleItem := range rolesArray {
-		roleStr, ok := roleItem.(string)
-		if !ok {
-			return mcp.NewToolResultError("Role must be a string (name or ID)"), nil
-		}
-		roleID, err := h.resolver.ResolveRole(roleStr)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve role '%s': %v", roleStr, err)), nil
-		}
-		roleIDs = append(roleIDs, roleID)
+	if !req.GetBool("confirm", false) {
+		return mcp.NewToolResultError(i18n.T("Deleting a wiki page permanently removes its content and history; set confirm to true to proceed")), nil
 	}
 
-	if err := h.client.UpdateProjectMembership(membershipID, roleIDs); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to update membership: %v", err)), nil
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	if err := h.client.DeleteWikiPage(projectID, title); err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to delete wiki page: %v", err)), nil
 	}
 
 	return jsonResult(map[string]any{
-		"success":       true,
-		"membership_id": membershipID,
-		"message":       "Membership updated successfully",
+		"success": true,
+		"title":   title,
+		"message": i18n.T("Wiki page deleted successfully"),
 	})
 }
 
-func (h *ToolHandlers) handleMembershipsRemove(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
+// --- Documents ---
 
-	idFloat, err := req.RequireFloat("membership_id")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+func formatDocument(doc redmine.Document, links redmine.Links) map[string]any {
+	result := map[string]any{
+		"id":          doc.ID,
+		"title":       doc.Title,
+		"description": doc.Description,
+		"category":    map[string]any{"id": doc.Category.ID, "name": doc.Category.Name},
+		"created_on":  doc.CreatedOn,
 	}
-	membershipID := int(idFloat)
-
-	if err := h.client.DeleteProjectMembership(membershipID); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to remove membership: %v", err)), nil
+	if doc.Project.ID > 0 {
+		result["project"] = map[string]any{"id": doc.Project.ID, "name": doc.Project.Name}
 	}
-
-	return jsonResult(map[string]any{
-		"success":       true,
-		"membership_id": membershipID,
-		"message":       "Membership removed successfully",
-	})
+	if len(doc.Attachments) > 0 {
+		result["attachments"] = formatAttachments(doc.Attachments, links)
+	}
+	return result
 }
 
-// --- Group D: Wiki ---
-
-func (h *ToolHandlers) handleWikiList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (h *ToolHandlers) handleDocumentsList(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	projectStr, err := req.RequireString("project")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
@@ -3356,64 +6153,40 @@ func (h *ToolHandlers) handleWikiList(ctx context.Context, req mcp.CallToolReque
 
 	projectID, err := h.resolver.ResolveProject(projectStr)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
-	pages, err := h.client.ListWikiPages(projectID)
+	docs, err := h.client.ListDocuments(projectID)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list wiki pages: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to list documents: %v", err)), nil
 	}
 
-	results := make([]map[string]any, len(pages))
-	for i, p := range pages {
-		results[i] = map[string]any{
-			"title":      p.Title,
-			"version":    p.Version,
-			"created_on": p.CreatedOn,
-			"updated_on": p.UpdatedOn,
-		}
+	result := make([]map[string]any, len(docs))
+	for i, doc := range docs {
+		result[i] = formatDocument(doc, h.client.Links())
 	}
 
-	return jsonResult(map[string]any{
-		"wiki_pages": results,
-		"count":      len(pages),
-	})
+	return jsonResult(wholeList(map[string]any{
+		"documents": result,
+	}, len(result)))
 }
 
-func (h *ToolHandlers) handleWikiGet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	projectStr, err := req.RequireString("project")
-	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
-	}
-
-	title, err := req.RequireString("title")
+func (h *ToolHandlers) handleDocumentsGet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	idFloat, err := req.RequireFloat("document_id")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	projectID, err := h.resolver.ResolveProject(projectStr)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
-	}
-
-	page, err := h.client.GetWikiPage(projectID, title)
+	doc, err := h.client.GetDocument(int(idFloat))
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get wiki page: %v", err)), nil
-	}
-
-	return jsonResult(map[string]any{
-		"title":      page.Title,
-		"text":       page.Text,
-		"version":    page.Version,
-		"author":     map[string]any{"id": page.Author.ID, "name": page.Author.Name},
-		"comments":   page.Comments,
-		"created_on": page.CreatedOn,
-		"updated_on": page.UpdatedOn,
-	})
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get document: %v", err)), nil
+	}
+
+	return jsonResult(formatDocument(*doc, h.client.Links()))
 }
 
-func (h *ToolHandlers) handleWikiCreateOrUpdate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	if err := h.checkReadOnly(); err != nil {
+func (h *ToolHandlers) handleDocumentsCreate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := h.checkWrite("documents_create"); err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
@@ -3427,120 +6200,99 @@ func (h *ToolHandlers) handleWikiCreateOrUpdate(ctx context.Context, req mcp.Cal
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	text, err := req.RequireString("text")
+	projectID, err := h.resolver.ResolveProject(projectStr)
 	if err != nil {
-		return mcp.NewToolResultError(err.Error()), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
-	projectID, err := h.resolver.ResolveProject(projectStr)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+	params := redmine.CreateDocumentParams{
+		ProjectID:   projectID,
+		Title:       title,
+		Description: req.GetString("description", ""),
 	}
 
-	params := redmine.WikiPageParams{
-		ProjectID: projectID,
-		Title:     title,
-		Text:      text,
-		Comments:  req.GetString("comments", ""),
+	// Redmine requires a category, so fall back to the default one
+	if category := req.GetString("category", ""); category != "" {
+		categoryID, err := h.resolver.ResolveDocumentCategory(category)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve document category: %v", err)), nil
+		}
+		params.CategoryID = categoryID
+	} else {
+		categoryID, err := h.resolver.DefaultDocumentCategory()
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve document category: %v", err)), nil
+		}
+		params.CategoryID = categoryID
 	}
 
-	if err := h.client.CreateOrUpdateWikiPage(params); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create/update wiki page: %v", err)), nil
+	if tokens := getArrayArg(req, "upload_tokens"); tokens != nil {
+		uploads, err := parseUploadTokens(tokens)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		params.Uploads = uploads
+	}
+
+	doc, err := h.client.CreateDocument(params)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to create document: %v", err)), nil
 	}
 
 	return jsonResult(map[string]any{
-		"success": true,
-		"title":   title,
-		"message": "Wiki page created/updated successfully",
+		"success":  true,
+		"document": formatDocument(*doc, h.client.Links()),
+		"message":  i18n.T("Document created successfully"),
 	})
 }
 
 // --- Group F: Export ---
 
 func (h *ToolHandlers) handleIssuesExportCSV(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// Build search params (same logic as handleIssuesSearch)
-	params := redmine.SearchIssuesParams{}
-
-	if project := req.GetString("project", ""); project != "" {
-		projectID, err := h.resolver.ResolveProject(project)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
-		}
-		params.ProjectID = strconv.Itoa(projectID)
+	params, err := h.issueSearchParams(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
+	params.Limit = h.limitArg(req, 25)
+	params.Offset = req.GetInt("offset", 0)
 
-	if tracker := req.GetString("tracker", ""); tracker != "" {
-		trackerID, err := h.resolver.ResolveTracker(tracker)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve tracker: %v", err)), nil
-		}
-		params.TrackerID = trackerID
+	format, err := redmine.ParseExportFormat(req.GetString("format", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-
-	if status := req.GetString("status", ""); status != "" {
-		statusID, err := h.resolver.ResolveStatus(status)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve status: %v", err)), nil
-		}
-		params.StatusID = statusID
-	} else {
-		params.StatusID = "open"
+	defaults := redmine.DefaultCSVColumns
+	if format == redmine.ExportMarkdown {
+		defaults = redmine.DefaultMarkdownColumns
 	}
-
-	if assignedTo := req.GetString("assigned_to", ""); assignedTo != "" {
-		if assignedTo == "me" {
-			params.AssignedToID = "me"
-		} else {
-			var projectID int
-			if params.ProjectID != "" {
-				projectID, _ = strconv.Atoi(params.ProjectID)
-			}
-			userID, err := h.resolver.ResolveUser(assignedTo, projectID)
-			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve user: %v", err)), nil
-			}
-			params.AssignedToID = strconv.Itoa(userID)
-		}
+	projectID, _ := strconv.Atoi(params.ProjectID)
+	columns, err := redmine.ParseExportColumns(req.GetString("columns", ""), defaults, func(name string) (int, error) {
+		return h.resolver.ResolveCustomFieldByName(name, projectID, params.TrackerID)
+	})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if req.GetBool("include_description", false) {
+		columns = redmine.WithDescriptionColumn(columns)
 	}
-
-	params.Subject = req.GetString("subject", "")
-	params.Sort = req.GetString("sort", "")
-	params.Limit = req.GetInt("limit", 25)
-	params.Offset = req.GetInt("offset", 0)
 
 	issues, _, err := h.client.SearchIssues(params)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to search issues: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to search issues: %v", err)), nil
 	}
 
-	// Write CSV
 	var buf bytes.Buffer
-	writer := csv.NewWriter(&buf)
-
-	// Header
-	_ = writer.Write([]string{"ID", "Subject", "Project", "Tracker", "Status", "Priority", "Assignee", "Created", "Updated"})
-
-	for _, issue := range issues {
-		assignee := ""
-		if issue.AssignedTo != nil {
-			assignee = issue.AssignedTo.Name
-		}
-		_ = writer.Write([]string{
-			strconv.Itoa(issue.ID),
-			issue.Subject,
-			issue.Project.Name,
-			issue.Tracker.Name,
-			issue.Status.Name,
-			issue.Priority.Name,
-			assignee,
-			issue.CreatedOn,
-			issue.UpdatedOn,
+	switch format {
+	case redmine.ExportJSONL:
+		err = redmine.WriteIssuesJSONL(&buf, issues, func(issue redmine.Issue) map[string]any {
+			return formatIssue(issue, h.client.Links())
 		})
+	case redmine.ExportMarkdown:
+		err = redmine.WriteIssuesMarkdown(&buf, issues, columns)
+	default:
+		err = redmine.WriteIssuesCSV(&buf, issues, columns)
 	}
-
-	writer.Flush()
-	if err := writer.Error(); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to write CSV: %v", err)), nil
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to write %s: %v", format, err)), nil
 	}
 
 	return mcp.NewToolResultText(buf.String()), nil
@@ -3549,263 +6301,300 @@ func (h *ToolHandlers) handleIssuesExportCSV(ctx context.Context, req mcp.CallTo
 // --- Group G: Reports ---
 
 func (h *ToolHandlers) handleReportsWeekly(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// Resolve user
-	userStr := req.GetString("user", "me")
-	userID := "me"
-	var userName string
+	user, err := h.resolver.ResolveReportUser(req.GetString("user", "me"))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve user: %v", err)), nil
+	}
 
-	if userStr == "me" {
-		user, err := h.client.GetCurrentUser()
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to get current user: %v", err)), nil
-		}
-		userName = user.Firstname + " " + user.Lastname
-	} else {
-		resolvedID, err := h.resolver.ResolveUser(userStr, 0)
+	weekOf, err := h.now(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if s := req.GetString("week_of", ""); s != "" {
+		parsed, err := redmine.ParseDate(s, weekOf, h.week)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve user: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Invalid week_of: %v", err)), nil
 		}
-		userID = strconv.Itoa(resolvedID)
-		userName = userStr
+		weekOf = parsed
 	}
 
-	// Calculate Monday-Friday of the target week
-	now := time.Now()
-	if weekOf := req.GetString("week_of", ""); weekOf != "" {
-		parsed, err := time.Parse("2006-01-02", weekOf)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Invalid date format for week_of: %v", err)), nil
-		}
-		now = parsed
+	opts := redmine.WeeklyOptions{
+		IncludePreviousWeek: req.GetBool("include_previous_week", false),
+		IncludeWeekend:      req.GetBool("include_weekend", false),
+	}
+	report, err := redmine.BuildWeeklyReport(h.client, user, weekOf, h.week, opts)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to build weekly report: %v", err)), nil
+	}
+
+	return jsonResult(report)
+}
+
+func (h *ToolHandlers) handleMeWorkload(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	user, err := h.resolver.ResolveReportUser(req.GetString("user", "me"))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve user: %v", err)), nil
 	}
 
-	weekday := int(now.Weekday())
-	if weekday == 0 {
-		weekday = 7
+	today, err := h.now(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-	monday := now.AddDate(0, 0, -weekday+1)
-	friday := monday.AddDate(0, 0, 4)
 
-	fromDate := monday.Format("2006-01-02")
-	toDate := friday.Format("2006-01-02")
+	workload, err := redmine.BuildWorkload(h.client, user, today, h.week)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to build workload: %v", err)), nil
+	}
 
-	// Fetch time entries for the week
-	teParams := redmine.ListTimeEntriesParams{
-		UserID: userID,
-		From:   fromDate,
-		To:     toDate,
-		Limit:  100,
+	return jsonResult(workload)
+}
+
+func (h *ToolHandlers) handleReportsStandup(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	user, err := h.resolver.ResolveReportUser(req.GetString("user", "me"))
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve user: %v", err)), nil
 	}
 
-	var allEntries []redmine.TimeEntry
-	for {
-		entries, _, err := h.client.ListTimeEntries(teParams)
+	today, err := h.now(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if dateStr := req.GetString("date", ""); dateStr != "" {
+		parsed, err := redmine.ParseDate(dateStr, today, h.week)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch time entries: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Invalid date: %v", err)), nil
 		}
-		allEntries = append(allEntries, entries...)
-		if len(entries) < teParams.Limit {
-			break
-		}
-		teParams.Offset += teParams.Limit
+		today = parsed
 	}
 
-	// Aggregate by day
-	byDay := make(map[string]float64)
-	// Initialize all weekdays
-	for i := 0; i < 5; i++ {
-		day := monday.AddDate(0, 0, i).Format("2006-01-02")
-		byDay[day] = 0
+	opts := redmine.StandupOptions{IncludeTimeEntries: req.GetBool("include_time_entries", true)}
+	report, err := redmine.BuildStandupReport(h.client, user, today, h.week, opts)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to build standup report: %v", err)), nil
 	}
 
-	// Aggregate by issue
-	type issueAgg struct {
-		Subject string
-		Hours   float64
-	}
-	byIssue := make(map[int]*issueAgg)
+	return jsonResult(report)
+}
 
-	// Aggregate by activity
-	byActivity := make(map[string]float64)
+func (h *ToolHandlers) handleReportsCapacity(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params := redmine.ListTimeEntriesParams{}
 
-	var totalHours float64
-	for _, entry := range allEntries {
-		byDay[entry.SpentOn] += entry.Hours
-		totalHours += entry.Hours
+	var members []redmine.IDName
+	if project := req.GetString("project", ""); project != "" {
+		projectID, err := h.resolver.ResolveProject(project)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+		}
+		params.ProjectID = strconv.Itoa(projectID)
 
-		if entry.Issue != nil {
-			if agg, ok := byIssue[entry.Issue.ID]; ok {
-				agg.Hours += entry.Hours
-			} else {
-				byIssue[entry.Issue.ID] = &issueAgg{
-					Subject: entry.Issue.Name,
-					Hours:   entry.Hours,
+		// Members are best effort: without them only users with entries are listed
+		if memberships, _, err := h.client.GetProjectMemberships(projectID, 0, redmine.MaxMembershipsLimit); err == nil {
+			for _, m := range memberships {
+				if m.User != nil {
+					members = append(members, *m.User)
 				}
 			}
 		}
+	}
 
-		byActivity[entry.Activity.Name] += entry.Hours
+	// Determine date range (defaults to this week)
+	var err error
+	params.From, params.To, err = h.dateRangeArgs(req, "this_week")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if params.From == "" || params.To == "" {
+		return mcp.NewToolResultError(i18n.T("a date range is required: use period, or both from and to")), nil
 	}
 
-	// Build by_day result (sorted)
-	dayKeys := make([]string, 0, len(byDay))
-	for k := range byDay {
-		dayKeys = append(dayKeys, k)
+	hoursPerDay := req.GetFloat("expected_hours_per_day", 8)
+	if hoursPerDay < 0 {
+		return mcp.NewToolResultError(i18n.T("expected_hours_per_day must not be negative")), nil
 	}
-	sort.Strings(dayKeys)
 
-	byDayResult := make([]map[string]any, len(dayKeys))
-	for i, date := range dayKeys {
-		byDayResult[i] = map[string]any{
-			"date":  date,
-			"hours": byDay[date],
+	exclude := make(map[string]bool)
+	for _, d := range getArrayArg(req, "exclude_dates") {
+		dateStr, ok := d.(string)
+		if !ok {
+			return mcp.NewToolResultError(i18n.Sprintf("invalid exclude_dates value: %v", d)), nil
+		}
+		if _, err := time.Parse("2006-01-02", dateStr); err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("invalid exclude_dates value: %s (use YYYY-MM-DD)", dateStr)), nil
 		}
+		exclude[dateStr] = true
 	}
 
-	// Build by_issue result
-	byIssueResult := make([]map[string]any, 0, len(byIssue))
-	for id, agg := range byIssue {
-		byIssueResult = append(byIssueResult, map[string]any{
-			"issue_id": id,
-			"subject":  agg.Subject,
-			"hours":    agg.Hours,
-		})
+	workingDays, err := countWorkingDays(params.From, params.To, exclude, h.week)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-	sort.Slice(byIssueResult, func(i, j int) bool {
-		return byIssueResult[i]["hours"].(float64) > byIssueResult[j]["hours"].(float64)
-	})
+	expectedHours := float64(workingDays) * hoursPerDay
 
-	// Build by_activity result
-	byActivityResult := make([]map[string]any, 0, len(byActivity))
-	for name, hours := range byActivity {
-		byActivityResult = append(byActivityResult, map[string]any{
-			"activity": name,
-			"hours":    hours,
-		})
+	entries, _, err := h.client.FetchTimeEntries(params, 0)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to fetch time entries: %v", err)), nil
+	}
+
+	users := buildCapacityReport(entries, members, expectedHours)
+
+	var totalLogged float64
+	for _, e := range entries {
+		totalLogged += e.Hours
 	}
-	sort.Slice(byActivityResult, func(i, j int) bool {
-		return byActivityResult[i]["hours"].(float64) > byActivityResult[j]["hours"].(float64)
-	})
 
 	return jsonResult(map[string]any{
-		"user":        userName,
-		"period":      fmt.Sprintf("%s ~ %s", fromDate, toDate),
-		"total_hours": totalHours,
-		"by_day":      byDayResult,
-		"by_issue":    byIssueResult,
-		"by_activity": byActivityResult,
+		"from":                   params.From,
+		"to":                     params.To,
+		"working_days":           workingDays,
+		"expected_hours_per_day": hoursPerDay,
+		"expected_hours":         roundHours(expectedHours),
+		"total_logged_hours":     roundHours(totalLogged),
+		"users":                  users,
+		"user_count":             len(users),
 	})
 }
 
-func (h *ToolHandlers) handleReportsStandup(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	// Resolve user
-	userStr := req.GetString("user", "me")
-	var userID string
-	var userName string
-	var assignedToID string
+func (h *ToolHandlers) handleReportsEstimateVsActual(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	if userStr == "me" {
-		user, err := h.client.GetCurrentUser()
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to get current user: %v", err)), nil
-		}
-		userID = "me"
-		assignedToID = "me"
-		userName = user.Firstname + " " + user.Lastname
-	} else {
-		resolvedID, err := h.resolver.ResolveUser(userStr, 0)
-		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve user: %v", err)), nil
-		}
-		userID = strconv.Itoa(resolvedID)
-		assignedToID = userID
-		userName = userStr
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
-	// Determine dates
-	today := time.Now()
-	if dateStr := req.GetString("date", ""); dateStr != "" {
-		parsed, err := time.Parse("2006-01-02", dateStr)
+	overPercent := req.GetFloat("over_threshold_percent", defaultOverEstimatePercent)
+	if overPercent < 0 {
+		return mcp.NewToolResultError(i18n.T("over_threshold_percent must not be negative")), nil
+	}
+
+	issueParams := redmine.SearchIssuesParams{
+		ProjectID: strconv.Itoa(projectID),
+		StatusID:  "*",
+	}
+	switch status := req.GetString("status", "all"); status {
+	case "open", "closed":
+		issueParams.StatusID = status
+	case "all":
+	default:
+		return mcp.NewToolResultError(i18n.Sprintf("invalid status: %s (use all, open or closed)", status)), nil
+	}
+
+	if version := req.GetString("version", ""); version != "" {
+		versionID, err := h.resolver.ResolveVersion(version, projectID)
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Invalid date format: %v", err)), nil
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve version: %v", err)), nil
 		}
-		today = parsed
+		issueParams.VersionID = strconv.Itoa(versionID)
 	}
 
-	todayStr := today.Format("2006-01-02")
-
-	// Calculate yesterday (skip weekends)
-	yesterday := today.AddDate(0, 0, -1)
-	if yesterday.Weekday() == time.Sunday {
-		yesterday = yesterday.AddDate(0, 0, -2) // Friday
-	} else if yesterday.Weekday() == time.Saturday {
-		yesterday = yesterday.AddDate(0, 0, -1) // Friday
+	issues, total, err := h.fetchAllIssues(issueParams)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to fetch issues: %v", err)), nil
 	}
-	yesterdayStr := yesterday.Format("2006-01-02")
 
-	// Fetch yesterday's time entries
+	// One paginated pass over the project's time entries, joined to issues locally,
+	// instead of a time entry query per issue
 	teParams := redmine.ListTimeEntriesParams{
-		UserID: userID,
-		From:   yesterdayStr,
-		To:     yesterdayStr,
-		Limit:  100,
+		ProjectID: strconv.Itoa(projectID),
+		From:      req.GetString("from", ""),
+		To:        req.GetString("to", ""),
+	}
+	entries, _, err := h.client.FetchTimeEntries(teParams, 0)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to fetch time entries: %v", err)), nil
+	}
+
+	report := buildEstimateReport(issues, entries, overPercent)
+	report["project_id"] = projectID
+	if note := cappedIssuesNote(len(issues), total); note != "" {
+		report["note"] = note
+	}
+	report["over_threshold_percent"] = overPercent
+	if teParams.From != "" {
+		report["from"] = teParams.From
 	}
+	if teParams.To != "" {
+		report["to"] = teParams.To
+	}
+
+	return jsonResult(report)
+}
 
-	entries, _, err := h.client.ListTimeEntries(teParams)
+func (h *ToolHandlers) handleReportsBurndown(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	projectStr, err := req.RequireString("project")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch time entries: %v", err)), nil
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	var yesterdayTotal float64
-	yesterdayEntries := make([]map[string]any, len(entries))
-	for i, entry := range entries {
-		yesterdayTotal += entry.Hours
-		e := map[string]any{
-			"hours":    entry.Hours,
-			"activity": entry.Activity.Name,
+	projectID, err := h.resolver.ResolveProject(projectStr)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
+	}
+
+	now, err := h.now(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := redmine.BurndownParams{
+		ProjectID: projectID,
+		To:        now.Format(redmine.DateLayout),
+		Metric:    req.GetString("metric", redmine.BurndownIssueCount),
+		Precise:   req.GetBool("precise", false),
+	}
+
+	if versionStr := req.GetString("version", ""); versionStr != "" {
+		versionID, err := h.resolver.ResolveVersion(versionStr, projectID)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve version: %v", err)), nil
 		}
-		if entry.Issue != nil {
-			e["issue_id"] = entry.Issue.ID
-			e["subject"] = entry.Issue.Name
+		version, err := h.client.GetVersion(versionID)
+		if err != nil {
+			return mcp.NewToolResultError(i18n.Sprintf("Failed to get version: %v", err)), nil
+		}
+		params.VersionID = versionID
+		if version.DueDate != "" && version.DueDate < params.To {
+			params.To = version.DueDate
+		}
+		if len(version.CreatedOn) >= len(redmine.DateLayout) {
+			params.From = version.CreatedOn[:len(redmine.DateLayout)]
 		}
-		yesterdayEntries[i] = e
 	}
 
-	// Fetch today's open issues assigned to user
-	searchParams := redmine.SearchIssuesParams{
-		AssignedToID: assignedToID,
-		StatusID:     "open",
-		Limit:        50,
-		Sort:         "priority:desc",
+	if to, err := h.dateArg(req, "to", true); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	} else if to != "" {
+		params.To = to
+	}
+	if from, err := h.dateArg(req, "from", false); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	} else if from != "" {
+		params.From = from
+	}
+	if params.From == "" {
+		to, _ := time.Parse(redmine.DateLayout, params.To)
+		params.From = to.AddDate(0, 0, -30).Format(redmine.DateLayout)
 	}
 
-	issues, _, err := h.client.SearchIssues(searchParams)
+	statuses, err := h.resolver.GetStatuses()
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch open issues: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to load statuses: %v", err)), nil
 	}
-
-	openIssues := make([]map[string]any, len(issues))
-	for i, issue := range issues {
-		openIssues[i] = map[string]any{
-			"id":       issue.ID,
-			"subject":  issue.Subject,
-			"status":   issue.Status.Name,
-			"priority": issue.Priority.Name,
+	closedStatuses := make(map[int]bool)
+	for _, st := range statuses {
+		if st.IsClosed {
+			closedStatuses[st.ID] = true
 		}
 	}
 
-	return jsonResult(map[string]any{
-		"user": userName,
-		"date": todayStr,
-		"yesterday": map[string]any{
-			"date":        yesterdayStr,
-			"total_hours": yesterdayTotal,
-			"entries":     yesterdayEntries,
-		},
-		"today": map[string]any{
-			"open_issues": openIssues,
-		},
-	})
+	burndown, err := redmine.BuildBurndown(h.client.WithContext(ctx), params, closedStatuses)
+	if err != nil {
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to build burndown: %v", err)), nil
+	}
+
+	return jsonResult(burndown)
 }
 
 func formatTrackerWorkflow(trackerID int, tracker redmine.WorkflowTracker) map[string]any {
@@ -3860,11 +6649,13 @@ func (h *ToolHandlers) resolveCustomFields(fields map[string]any, projectID int,
 	var unknownFields []string
 
 	// Try to get custom field definitions for name resolution
-	definitions, defErr := h.client.GetProjectCustomFields(projectID, trackerID)
+	definitions, defErr := h.resolver.ProjectCustomFields(projectID, trackerID, h.rules)
 	nameToID := make(map[string]int)
+	defsByID := make(map[int]redmine.CustomFieldDefinition)
 	if defErr == nil {
 		for _, def := range definitions {
 			nameToID[strings.ToLower(def.Name)] = def.ID
+			defsByID[def.ID] = def
 		}
 	}
 
@@ -3892,25 +6683,42 @@ func (h *ToolHandlers) resolveCustomFields(fields map[string]any, projectID int,
 			continue
 		}
 
-		// Validate value against rules
+		// Validate value against rules, then the field's format and possible values
 		validated, err := h.validateCustomFieldValue(fieldID, value)
 		if err != nil {
 			return nil, err
 		}
+		if def, ok := defsByID[fieldID]; ok {
+			if validated, err = redmine.NormalizeCustomFieldValue(def, validated); err != nil {
+				return nil, err
+			}
+		}
 		result[strconv.Itoa(fieldID)] = validated
 	}
 
 	if len(unknownFields) > 0 {
-		// Build helpful error message
-		availableFields := make([]string, 0, len(nameToID))
-		for name := range nameToID {
-			availableFields = append(availableFields, name)
+		// Suggest close names per unknown field instead of listing every field
+		fieldNames := make([]string, 0, len(definitions))
+		for _, def := range definitions {
+			fieldNames = append(fieldNames, def.Name)
+		}
+		if h.rules != nil {
+			for _, field := range h.rules.Fields {
+				fieldNames = append(fieldNames, field.Name)
+			}
+		}
+
+		sort.Strings(unknownFields)
+		descriptions := make([]string, len(unknownFields))
+		for i, name := range unknownFields {
+			descriptions[i] = name
+			if suggestions := redmine.SuggestNames(name, fieldNames, redmine.MaxSuggestions); len(suggestions) > 0 {
+				descriptions[i] = fmt.Sprintf("%s (did you mean: %s?)", name, strings.Join(suggestions, ", "))
+			}
 		}
-		sort.Strings(availableFields)
 
-		return nil, fmt.Errorf("custom field(s) not found: %s\nAvailable fields: %s",
-			strings.Join(unknownFields, ", "),
-			strings.Join(availableFields, ", "))
+		return nil, i18n.Errorf("custom field(s) not found: %s. Use customFields_list to see available fields",
+			strings.Join(descriptions, "; "))
 	}
 
 	// Check for missing required fields (per-tracker)
@@ -3927,7 +6735,7 @@ func (h *ToolHandlers) resolveCustomFields(fields map[string]any, projectID int,
 		}
 		if len(missing) > 0 {
 			sort.Strings(missing)
-			return nil, fmt.Errorf("required custom field(s) missing: %s", strings.Join(missing, "; "))
+			return nil, i18n.Errorf("required custom field(s) missing: %s", strings.Join(missing, "; "))
 		}
 	}
 
@@ -3963,9 +6771,26 @@ func (h *ToolHandlers) validateCustomFieldValue(fieldID int, value any) (any, er
 	}
 }
 
-func formatIssue(issue redmine.Issue) map[string]any {
+// estimatedHoursArg returns the estimated_hours argument, or nil when it was not provided
+func estimatedHoursArg(req mcp.CallToolRequest) (*float64, error) {
+	v, ok := req.GetArguments()["estimated_hours"]
+	if !ok || v == nil {
+		return nil, nil
+	}
+	hours, ok := v.(float64)
+	if !ok {
+		return nil, i18n.Errorf("estimated_hours must be a number")
+	}
+	if hours < 0 {
+		return nil, i18n.Errorf("estimated_hours must not be negative, got %v", hours)
+	}
+	return &hours, nil
+}
+
+func formatIssue(issue redmine.Issue, links redmine.Links) map[string]any {
 	result := map[string]any{
 		"id":      issue.ID,
+		"url":     links.Issue(issue.ID),
 		"subject": issue.Subject,
 		"project": map[string]any{
 			"id":   issue.Project.ID,
@@ -3998,6 +6823,19 @@ func formatIssue(issue redmine.Issue) map[string]any {
 		}
 	}
 
+	if issue.Category != nil {
+		result["category"] = map[string]any{
+			"id":   issue.Category.ID,
+			"name": issue.Category.Name,
+		}
+	}
+	if issue.FixedVersion != nil {
+		result["fixed_version"] = map[string]any{
+			"id":   issue.FixedVersion.ID,
+			"name": issue.FixedVersion.Name,
+		}
+	}
+
 	if issue.StartDate != "" {
 		result["start_date"] = issue.StartDate
 	}
@@ -4007,12 +6845,18 @@ func formatIssue(issue redmine.Issue) map[string]any {
 	if issue.ClosedOn != "" {
 		result["closed_on"] = issue.ClosedOn
 	}
+	if issue.EstimatedHours != nil {
+		result["estimated_hours"] = *issue.EstimatedHours
+	}
+	if issue.SpentHours != nil {
+		result["spent_hours"] = *issue.SpentHours
+	}
 
 	return result
 }
 
-func formatIssueDetail(issue redmine.Issue) map[string]any {
-	result := formatIssue(issue)
+func formatIssueDetail(issue redmine.Issue, links redmine.Links) map[string]any {
+	result := formatIssue(issue, links)
 	result["description"] = issue.Description
 	result["done_ratio"] = issue.DoneRatio
 
@@ -4034,10 +6878,11 @@ func formatIssueDetail(issue redmine.Issue) map[string]any {
 		journals := make([]map[string]any, len(issue.Journals))
 		for i, j := range issue.Journals {
 			journals[i] = map[string]any{
-				"id":         j.ID,
-				"user":       j.User.Name,
-				"notes":      j.Notes,
-				"created_on": j.CreatedOn,
+				"id":            j.ID,
+				"user":          j.User.Name,
+				"notes":         j.Notes,
+				"private_notes": j.PrivateNotes,
+				"created_on":    j.CreatedOn,
 			}
 		}
 		result["journals"] = journals
@@ -4065,6 +6910,9 @@ func formatIssueDetail(issue redmine.Issue) map[string]any {
 				"issue_to_id":   r.IssueToID,
 				"relation_type": r.RelationType,
 			}
+			if r.Delay != nil {
+				relations[i]["delay"] = *r.Delay
+			}
 		}
 		result["relations"] = relations
 	}
@@ -4083,29 +6931,32 @@ func formatIssueDetail(issue redmine.Issue) map[string]any {
 
 	// Attachments
 	if len(issue.Attachments) > 0 {
-		attachments := make([]map[string]any, len(issue.Attachments))
-		for i, a := range issue.Attachments {
-			attachments[i] = map[string]any{
-				"id":           a.ID,
-				"filename":     a.Filename,
-				"filesize":     a.Filesize,
-				"content_type": a.ContentType,
-				"description":  a.Description,
-				"created_on":   a.CreatedOn,
-				"author":       map[string]any{"id": a.Author.ID, "name": a.Author.Name},
-			}
-		}
-		result["attachments"] = attachments
+		result["attachments"] = formatAttachments(issue.Attachments, links)
 	}
 
 	return result
 }
 
+// withoutPrivateNotes drops journals with private notes and reports how many
+// were removed. Redmine stores field changes made alongside a private note in a
+// separate public journal, so no change history is lost.
+func withoutPrivateNotes(journals []redmine.Journal) ([]redmine.Journal, int) {
+	public := make([]redmine.Journal, 0, len(journals))
+	for _, j := range journals {
+		if !j.PrivateNotes {
+			public = append(public, j)
+		}
+	}
+	return public, len(journals) - len(public)
+}
+
 func jsonResult(data any) (*mcp.CallToolResult, error) {
 	jsonBytes, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal result: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to marshal result: %v", err)), nil
 	}
+	// Clients cut oversized results mid-JSON, so trim them to a valid whole
+	jsonBytes, _ = fitBudget(jsonBytes, responseBudgetFromEnv())
 	return mcp.NewToolResultText(string(jsonBytes)), nil
 }
 
@@ -4150,12 +7001,12 @@ func parseUploadTokens(tokens []any) ([]redmine.UploadToken, error) {
 	for _, t := range tokens {
 		m, ok := t.(map[string]any)
 		if !ok {
-			return nil, fmt.Errorf("upload_tokens items must be objects with 'token' and 'filename' fields")
+			return nil, i18n.Errorf("upload_tokens items must be objects with 'token' and 'filename' fields")
 		}
 		token, _ := m["token"].(string)
 		filename, _ := m["filename"].(string)
 		if token == "" || filename == "" {
-			return nil, fmt.Errorf("upload_tokens items require 'token' and 'filename' fields")
+			return nil, i18n.Errorf("upload_tokens items require 'token' and 'filename' fields")
 		}
 		u := redmine.UploadToken{
 			Token:    token,
@@ -4172,21 +7023,67 @@ func parseUploadTokens(tokens []any) ([]redmine.UploadToken, error) {
 	return uploads, nil
 }
 
+// parseCustomFieldFilter converts an issues_search custom_fields value into a filter.
+// Arrays match any of their values; strings may start with "!", "~" or "!~".
+func parseCustomFieldFilter(value any) (redmine.CustomFieldFilter, error) {
+	var filter redmine.CustomFieldFilter
+
+	switch v := value.(type) {
+	case []any:
+		if len(v) == 0 {
+			return filter, i18n.Errorf("array must contain at least one value")
+		}
+		filter.Operator = "="
+		for _, item := range v {
+			filter.Values = append(filter.Values, fmt.Sprintf("%v", item))
+		}
+	case string:
+		filter.Operator = "="
+		for _, op := range []string{"!~", "!", "~"} {
+			if rest, ok := strings.CutPrefix(v, op); ok {
+				filter.Operator, v = op, rest
+				break
+			}
+		}
+		if v == "" {
+			return filter, i18n.Errorf("missing value after operator %q", filter.Operator)
+		}
+		filter.Values = []string{v}
+	default:
+		filter.Operator = "="
+		filter.Values = []string{fmt.Sprintf("%v", v)}
+	}
+
+	// Redmine separates multiple values with "|", so it can't appear inside one
+	for _, val := range filter.Values {
+		if strings.Contains(val, "|") {
+			return filter, i18n.Errorf("value %q must not contain '|'; pass multiple values as an array", val)
+		}
+	}
+
+	return filter, nil
+}
+
 func (h *ToolHandlers) handleReportsProjectAnalysis(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	projectStr, err := req.RequireString("project")
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	if req.GetString("attach_to", "") != "" {
+		if err := h.checkWrite("reports_project_analysis"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
 
 	projectID, err := h.resolver.ResolveProject(projectStr)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to resolve project: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to resolve project: %v", err)), nil
 	}
 
 	// Get project details
 	project, err := h.client.GetProjectDetail(projectID, nil)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get project: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to get project: %v", err)), nil
 	}
 
 	// Parse custom_fields parameter
@@ -4213,7 +7110,7 @@ func (h *ToolHandlers) handleReportsProjectAnalysis(ctx context.Context, req mcp
 	rg := NewReportGenerator(h.client, h.resolver)
 	result, err := rg.GenerateProjectAnalysis(params)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to generate analysis: %v", err)), nil
+		return mcp.NewToolResultError(i18n.Sprintf("Failed to generate analysis: %v", err)), nil
 	}
 
 	// Handle output format
@@ -4224,7 +7121,7 @@ func (h *ToolHandlers) handleReportsProjectAnalysis(ctx context.Context, req mcp
 			filename := fmt.Sprintf("%s_analysis_%s.csv", project.Identifier, time.Now().Format("20060102"))
 			url, err := rg.AttachResult([]byte(csv), filename, params)
 			if err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to attach report: %v", err)), nil
+				return mcp.NewToolResultError(i18n.Sprintf("Failed to attach report: %v", err)), nil
 			}
 			result.DownloadURL = url
 			return jsonResult(result)
@@ -2,23 +2,34 @@ package mcp
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
 	"github.com/ycho/redmine-mcp-server/internal/redmine"
 )
 
 // --- TestResolveDatePeriod ---
 
 func TestResolveDatePeriod(t *testing.T) {
+	h := &ToolHandlers{week: redmine.DefaultWorkWeek}
+
 	t.Run("this_week returns Monday to Sunday of current week", func(t *testing.T) {
-		from, to := resolveDatePeriod("this_week")
-		if from == "" || to == "" {
+		from, to, err := h.resolveDatePeriod("this_week", time.Now())
+		if err != nil || from == "" || to == "" {
 			t.Fatal("expected non-empty from/to for this_week")
 		}
 
@@ -58,8 +69,8 @@ func TestResolveDatePeriod(t *testing.T) {
 	})
 
 	t.Run("last_week returns Monday to Sunday of previous week", func(t *testing.T) {
-		from, to := resolveDatePeriod("last_week")
-		if from == "" || to == "" {
+		from, to, err := h.resolveDatePeriod("last_week", time.Now())
+		if err != nil || from == "" || to == "" {
 			t.Fatal("expected non-empty from/to for last_week")
 		}
 
@@ -86,7 +97,7 @@ func TestResolveDatePeriod(t *testing.T) {
 			t.Errorf("expected 6-day span, got %v", diff)
 		}
 		// The last_week Sunday must be before this_week Monday
-		thisFrom, _ := resolveDatePeriod("this_week")
+		thisFrom, _, _ := h.resolveDatePeriod("this_week", time.Now())
 		thisFromDate, _ := time.Parse("2006-01-02", thisFrom)
 		if !toDate.Before(thisFromDate) {
 			t.Errorf("last_week end (%s) should be before this_week start (%s)", to, thisFrom)
@@ -99,8 +110,8 @@ func TestResolveDatePeriod(t *testing.T) {
 	})
 
 	t.Run("this_month returns first to last day of current month", func(t *testing.T) {
-		from, to := resolveDatePeriod("this_month")
-		if from == "" || to == "" {
+		from, to, err := h.resolveDatePeriod("this_month", time.Now())
+		if err != nil || from == "" || to == "" {
 			t.Fatal("expected non-empty from/to for this_month")
 		}
 
@@ -136,8 +147,8 @@ func TestResolveDatePeriod(t *testing.T) {
 	})
 
 	t.Run("last_month returns first to last day of previous month", func(t *testing.T) {
-		from, to := resolveDatePeriod("last_month")
-		if from == "" || to == "" {
+		from, to, err := h.resolveDatePeriod("last_month", time.Now())
+		if err != nil || from == "" || to == "" {
 			t.Fatal("expected non-empty from/to for last_month")
 		}
 
@@ -184,14 +195,17 @@ func TestResolveDatePeriod(t *testing.T) {
 	})
 
 	t.Run("empty string returns empty strings", func(t *testing.T) {
-		from, to := resolveDatePeriod("")
-		if from != "" || to != "" {
+		from, to, err := h.resolveDatePeriod("", time.Now())
+		if err != nil || from != "" || to != "" {
 			t.Errorf("expected empty strings for empty period, got from=%q to=%q", from, to)
 		}
 	})
 
-	t.Run("invalid period returns empty strings", func(t *testing.T) {
-		from, to := resolveDatePeriod("invalid")
+	t.Run("invalid period returns an error", func(t *testing.T) {
+		from, to, err := h.resolveDatePeriod("invalid", time.Now())
+		if err == nil || !strings.Contains(err.Error(), "this_week") {
+			t.Errorf("expected an error listing the accepted periods, got %v", err)
+		}
 		if from != "" || to != "" {
 			t.Errorf("expected empty strings for invalid period, got from=%q to=%q", from, to)
 		}
@@ -217,12 +231,15 @@ func TestFormatIssue(t *testing.T) {
 		UpdatedOn: "2025-01-15T14:30:00Z",
 	}
 
-	result := formatIssue(issue)
+	result := formatIssue(issue, redmine.NewClient("https://redmine.example.com/", "").Links())
 
 	// Check top-level fields
 	if result["id"] != 42 {
 		t.Errorf("expected id=42, got %v", result["id"])
 	}
+	if result["url"] != "https://redmine.example.com/issues/42" {
+		t.Errorf("expected the issue page url, got %v", result["url"])
+	}
 	if result["subject"] != "Fix login bug" {
 		t.Errorf("expected subject='Fix login bug', got %v", result["subject"])
 	}
@@ -306,7 +323,7 @@ func TestFormatIssue_NilAssignedTo(t *testing.T) {
 		Subject: "Test",
 	}
 
-	result := formatIssue(issue)
+	result := formatIssue(issue, redmine.Links{})
 
 	if _, exists := result["assigned_to"]; exists {
 		t.Error("expected assigned_to to be absent when nil")
@@ -377,7 +394,7 @@ func TestFormatIssueDetail(t *testing.T) {
 		},
 	}
 
-	result := formatIssueDetail(issue)
+	result := formatIssueDetail(issue, redmine.NewClient("https://redmine.example.com", "").Links())
 
 	// Check fields inherited from formatIssue
 	if result["id"] != 100 {
@@ -481,6 +498,9 @@ func TestFormatIssueDetail(t *testing.T) {
 	if len(attachments) != 1 {
 		t.Fatalf("expected 1 attachment, got %d", len(attachments))
 	}
+	if attachments[0]["url"] != "https://redmine.example.com/attachments/download/200/spec.pdf" {
+		t.Errorf("expected the attachment download url, got %v", attachments[0]["url"])
+	}
 	if attachments[0]["filename"] != "spec.pdf" {
 		t.Errorf("expected attachment filename='spec.pdf', got %v", attachments[0]["filename"])
 	}
@@ -510,7 +530,7 @@ func TestFormatIssueDetail_EmptyOptionalFields(t *testing.T) {
 		Subject: "Minimal issue",
 	}
 
-	result := formatIssueDetail(issue)
+	result := formatIssueDetail(issue, redmine.Links{})
 
 	// description and done_ratio should always be present in detail
 	if result["description"] != "" {
@@ -1144,6 +1164,20 @@ func TestResolveCustomFieldsRequiredValidation(t *testing.T) {
 		}
 	})
 
+	t.Run("unknown field name suggests close matches", func(t *testing.T) {
+		fields := map[string]any{"Componet": "HW"}
+		_, err := h.resolveCustomFields(fields, 1, 0)
+		if err == nil {
+			t.Fatal("expected error for unknown field")
+		}
+		if !strings.Contains(err.Error(), "Componet (did you mean: Component?)") {
+			t.Fatalf("expected suggestion in error, got: %v", err)
+		}
+		if strings.Contains(err.Error(), "SW_Category") {
+			t.Fatalf("expected only close matches, got: %v", err)
+		}
+	})
+
 	t.Run("no required fields with nil rules passes", func(t *testing.T) {
 		hNoRules := NewToolHandlers(client, nil, nil)
 		fields := map[string]any{}
@@ -1156,3 +1190,2138 @@ func TestResolveCustomFieldsRequiredValidation(t *testing.T) {
 		}
 	})
 }
+
+func TestHandleIssuesSearch_Pagination(t *testing.T) {
+	const totalIssues = 800
+	requests := 0
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/issues.json" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if limit > 100 {
+			limit = 100
+		}
+		issues := []map[string]any{}
+		for id := offset + 1; id <= offset+limit && id <= totalIssues; id++ {
+			issues = append(issues, map[string]any{"id": id, "subject": "Issue"})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issues":      issues,
+			"total_count": totalIssues,
+		})
+	}))
+	defer mockServer.Close()
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, nil)
+
+	search := func(t *testing.T, args map[string]any) map[string]any {
+		t.Helper()
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleIssuesSearch(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("expected success, got error: %v", result.Content)
+		}
+		var data map[string]any
+		if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &data); err != nil {
+			t.Fatalf("failed to parse result JSON: %v", err)
+		}
+		return data
+	}
+
+	t.Run("limit above 100 fetches multiple pages", func(t *testing.T) {
+		requests = 0
+		data := search(t, map[string]any{"limit": float64(500)})
+		// The response budget trims the list again, keeping count in step
+		if !strings.Contains(fmt.Sprint(data["truncation_notes"]), "of 500 issues") || data["count"] != float64(len(data["issues"].([]any))) {
+			t.Errorf("expected 500 fetched issues and a matching count, got %v %v", data["count"], data["truncation_notes"])
+		}
+		if data["total_count"].(float64) != totalIssues {
+			t.Errorf("expected total_count=%d, got %v", totalIssues, data["total_count"])
+		}
+		if data["has_more"] != true {
+			t.Errorf("expected has_more=true, got %v", data["has_more"])
+		}
+		if requests != 5 {
+			t.Errorf("expected 5 page requests, got %d", requests)
+		}
+	})
+
+	t.Run("fetching the tail is not truncated", func(t *testing.T) {
+		data := search(t, map[string]any{"limit": float64(200), "offset": float64(700)})
+		if data["count"].(float64) != 100 {
+			t.Errorf("expected count=100, got %v", data["count"])
+		}
+		if data["has_more"] != false {
+			t.Errorf("expected has_more=false, got %v", data["has_more"])
+		}
+	})
+}
+
+// --- TestHandleIssuesUpdate_ClearFlags ---
+
+func TestHandleIssuesUpdate_ClearFlags(t *testing.T) {
+	var got map[string]map[string]any
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &got)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`{"issue":{"id":7,"project":{"id":1,"name":"P"},"tracker":{"id":1,"name":"Bug"},"status":{"id":1,"name":"New"}}}`))
+	}))
+	defer mockServer.Close()
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"issue_id":          float64(7),
+		"clear_assigned_to": true,
+		"clear_due_date":    true,
+		"clear_description": true,
+	}
+	result, err := h.handleIssuesUpdate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+
+	for _, key := range []string{"assigned_to_id", "due_date", "description"} {
+		if v, ok := got["issue"][key]; !ok || v != "" {
+			t.Errorf("expected %q to be sent as empty string, got %v (present=%v)", key, v, ok)
+		}
+	}
+}
+
+// --- TestHandleIssuesUpdate_CategoryAndVersion ---
+
+func TestHandleIssuesUpdate_CategoryAndVersion(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /issues/7.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issue":{"id":7,"project":{"id":3,"name":"P"},"tracker":{"id":1,"name":"Bug"},"status":{"id":1,"name":"New"}}}`))
+	})
+	mux.HandleFunc("GET /projects/3/issue_categories.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issue_categories":[{"id":4,"name":"Backend"},{"id":5,"name":"Frontend"}]}`))
+	})
+	mux.HandleFunc("PUT /issues/7.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"issue_id":      float64(7),
+		"category":      "frontend",
+		"clear_version": true,
+	}
+	result, err := h.handleIssuesUpdate(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if got["issue"]["category_id"] != float64(5) {
+		t.Errorf("expected category_id 5, got %v", got["issue"]["category_id"])
+	}
+	if v, ok := got["issue"]["fixed_version_id"]; !ok || v != "" {
+		t.Errorf("expected fixed_version_id to be sent as empty string, got %v (present=%v)", v, ok)
+	}
+
+	req.Params.Arguments = map[string]any{"issue_id": float64(7), "category": "Docs"}
+	result, _ = h.handleIssuesUpdate(context.Background(), req)
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "issue category not found: Docs") {
+		t.Errorf("expected an unknown category to be rejected, got %v", result.Content)
+	}
+}
+
+// --- TestHandleIssuesUpdate_TransitionError ---
+
+func TestHandleIssuesUpdate_TransitionError(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/issue_statuses.json":
+			_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":2,"name":"In Progress"},{"id":5,"name":"Closed"}]}`))
+		case r.URL.Path == "/issues/7.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":7,"project":{"id":1,"name":"P"},"tracker":{"id":4,"name":"Bug"},"status":{"id":1,"name":"New"}}}`))
+		case r.URL.Path == "/issues/8.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":8,"project":{"id":1,"name":"P"},"tracker":{"id":9,"name":"Task"},"status":{"id":1,"name":"New"}}}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	workflow := &redmine.WorkflowRules{
+		Trackers: map[string]redmine.WorkflowTracker{
+			"4": {
+				Name: "Bug",
+				Statuses: map[string]redmine.WorkflowStatus{
+					"1": {Name: "New"},
+					"2": {Name: "In Progress"},
+					"5": {Name: "Closed", IsClosed: true},
+				},
+				Transitions: map[string][]int{"1": {2}},
+			},
+		},
+	}
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, workflow)
+
+	t.Run("rejected transition lists allowed statuses", func(t *testing.T) {
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"issue_id": float64(7), "status": "Closed"}
+		result, err := h.handleIssuesUpdate(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.IsError {
+			t.Fatal("expected error result")
+		}
+		text := result.Content[0].(gomcp.TextContent).Text
+		if !strings.Contains(text, "from 'New' to 'Closed'") || !strings.Contains(text, "allowed: In Progress") {
+			t.Errorf("expected current and allowed statuses in error, got %q", text)
+		}
+	})
+
+	t.Run("tracker without rules is not validated", func(t *testing.T) {
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"issue_id": float64(8), "status": "Closed"}
+		result, err := h.handleIssuesUpdate(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.IsError {
+			t.Errorf("expected success for tracker without rules, got %v", result.Content)
+		}
+	})
+}
+
+// --- TestHandleIssuesUpdate_ServerWorkflowSource ---
+
+func TestHandleIssuesUpdate_ServerWorkflowSource(t *testing.T) {
+	var updated bool
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPut:
+			updated = true
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/issue_statuses.json":
+			_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":2,"name":"In Progress"},{"id":5,"name":"Closed"}]}`))
+		case r.URL.Path == "/issues/7.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":7,"project":{"id":1,"name":"P"},"tracker":{"id":4,"name":"Bug"},"status":{"id":1,"name":"New"},
+				"allowed_statuses":[{"id":1,"name":"New"},{"id":2,"name":"In Progress"}]}}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	client := redmine.NewClient(mockServer.URL, "test-api-key")
+	h := NewToolHandlers(client, nil, nil)
+	h.workflowSource = redmine.WorkflowSourceServer
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"issue_id": float64(7), "status": "Closed"}
+	result, err := h.handleIssuesUpdate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected transition to be rejected using server allowed_statuses")
+	}
+	if text := result.Content[0].(gomcp.TextContent).Text; !strings.Contains(text, "allowed: In Progress") {
+		t.Errorf("expected allowed statuses in error, got %q", text)
+	}
+	if updated {
+		t.Error("issue should not be updated after a rejected transition")
+	}
+
+	req.Params.Arguments = map[string]any{"issue_id": float64(7), "status": "In Progress"}
+	result, err = h.handleIssuesUpdate(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected allowed transition to succeed, got %v %v", err, result.Content)
+	}
+}
+
+// --- TestHandleMembershipsAdd_GroupByName ---
+
+func TestHandleMembershipsAdd_GroupByName(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /groups.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"groups":[{"id":5,"name":"Firmware Team"},{"id":6,"name":"QA Team"}]}`))
+	})
+	mux.HandleFunc("GET /roles.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"roles":[{"id":3,"name":"Developer"}]}`))
+	})
+	mux.HandleFunc("POST /projects/1/memberships.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"membership":{"id":40,"project":{"id":1,"name":"P"},"group":{"id":6,"name":"QA Team"},"roles":[{"id":3,"name":"Developer"}]}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"project": "1",
+		"group":   "qa team",
+		"roles":   []any{"Developer"},
+	}
+	result, err := h.handleMembershipsAdd(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+	if got["membership"]["group_id"] != float64(6) {
+		t.Errorf("expected group_id 6 to be sent, got %v", got["membership"])
+	}
+}
+
+// --- TestHandleGroupsList ---
+
+func TestHandleGroupsList(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /groups.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"groups":[{"id":5,"name":"Firmware Team"}]}`))
+	})
+	mux.HandleFunc("GET /groups/5.json", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include") != "users" {
+			t.Errorf("expected include=users, got %q", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"group":{"id":5,"name":"Firmware Team","users":[{"id":10,"name":"John Smith"}]}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"include_users": true}
+	result, err := h.handleGroupsList(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+
+	var out struct {
+		Groups []struct {
+			ID    int              `json:"id"`
+			Users []redmine.IDName `json:"users"`
+		} `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if len(out.Groups) != 1 || len(out.Groups[0].Users) != 1 || out.Groups[0].Users[0].ID != 10 {
+		t.Errorf("unexpected groups: %+v", out.Groups)
+	}
+
+	forbidden := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer forbidden.Close()
+
+	h = NewToolHandlers(redmine.NewClient(forbidden.URL, "test-api-key"), nil, nil)
+	result, err = h.handleGroupsList(context.Background(), gomcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "admin privileges") {
+		t.Errorf("expected admin privileges error, got %v", result.Content)
+	}
+}
+
+// --- TestHandleUsersGet ---
+
+func TestHandleUsersGet(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/current.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"user":{"id":5,"login":"bob","firstname":"Bob","lastname":"Lin"}}`))
+	})
+	mux.HandleFunc("GET /users/5.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"user":{"id":5,"login":"bob","firstname":"Bob","lastname":"Lin","status":1,
+			"groups":[{"id":20,"name":"Frontend Team"}],
+			"memberships":[{"id":1,"project":{"id":3,"name":"Web"},"roles":[{"id":4,"name":"Developer"}]}]}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"user": "me"}
+	result, err := h.handleUsersGet(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+
+	var out struct {
+		Login       string `json:"login"`
+		Status      string `json:"status"`
+		Groups      []redmine.IDName
+		Memberships []struct {
+			Project redmine.IDName `json:"project"`
+			Roles   []string       `json:"roles"`
+		} `json:"memberships"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if out.Login != "bob" || out.Status != "active" || len(out.Groups) != 1 ||
+		len(out.Memberships) != 1 || out.Memberships[0].Project.Name != "Web" || out.Memberships[0].Roles[0] != "Developer" {
+		t.Errorf("unexpected user: %+v", out)
+	}
+}
+
+// --- TestHandleWikiDelete ---
+
+func TestHandleWikiDelete(t *testing.T) {
+	deleted := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /projects/1/wiki/Obsolete.json", func(w http.ResponseWriter, r *http.Request) {
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1", "title": "Obsolete", "confirm": false}
+	result, err := h.handleWikiDelete(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || deleted {
+		t.Fatalf("expected deletion to be refused without confirm")
+	}
+
+	req.Params.Arguments = map[string]any{"project": "1", "title": "Obsolete", "confirm": true}
+	result, err = h.handleWikiDelete(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError || !deleted {
+		t.Fatalf("expected page to be deleted, got %v", result.Content)
+	}
+}
+
+// --- TestHandleWikiHistoryAndDiff ---
+
+func TestHandleWikiHistoryAndDiff(t *testing.T) {
+	versions := map[string]string{
+		"1": `{"wiki_page":{"title":"Guide","text":"Install\n","version":1,"author":{"id":1,"name":"Alice"},"comments":"first"}}`,
+		"2": `{"wiki_page":{"title":"Guide","text":"Install\nConfigure\n","version":2,"author":{"id":2,"name":"Bob"},"comments":"add config"}}`,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects/1/wiki/Guide.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"wiki_page":{"title":"Guide","text":"Install\nConfigure\nRun\n","version":3,"author":{"id":1,"name":"Alice"},"comments":"add run"}}`))
+	})
+	mux.HandleFunc("GET /projects/1/wiki/Guide/{version}", func(w http.ResponseWriter, r *http.Request) {
+		body, ok := versions[strings.TrimSuffix(r.PathValue("version"), ".json")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1", "title": "Guide", "limit": float64(2)}
+	result, err := h.handleWikiHistory(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	var history struct {
+		Versions []struct {
+			Version  int    `json:"version"`
+			Comments string `json:"comments"`
+		} `json:"versions"`
+		HasMore bool `json:"has_more"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &history); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if len(history.Versions) != 2 || history.Versions[0].Version != 3 || history.Versions[1].Comments != "add config" || !history.HasMore {
+		t.Errorf("unexpected history: %+v", history)
+	}
+
+	req.Params.Arguments = map[string]any{"project": "1", "title": "Guide", "from_version": float64(1)}
+	result, err = h.handleWikiDiff(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	var diff struct {
+		FromVersion int    `json:"from_version"`
+		ToVersion   int    `json:"to_version"`
+		Diff        string `json:"diff"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &diff); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if diff.FromVersion != 1 || diff.ToVersion != 3 || !strings.Contains(diff.Diff, "+Configure\n+Run\n") {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+}
+
+// --- TestHandleWikiUploadAndAttach ---
+
+func TestHandleWikiUploadAndAttach(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects/1/wiki/Guide.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"wiki_page":{"title":"Guide","text":"See !shot.png!","version":2}}`))
+	})
+	mux.HandleFunc("POST /uploads.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"upload":{"token":"9.xyz"}}`))
+	})
+	mux.HandleFunc("PUT /projects/1/wiki/Guide.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"project":  "1",
+		"title":    "Guide",
+		"filename": "shot.png",
+		"content":  base64.StdEncoding.EncodeToString([]byte("png-bytes")),
+	}
+	result, err := h.handleWikiUploadAndAttach(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+
+	if got["wiki_page"]["text"] != "See !shot.png!" {
+		t.Errorf("expected current text to be resent, got %v", got["wiki_page"]["text"])
+	}
+	uploads, _ := got["wiki_page"]["uploads"].([]any)
+	if len(uploads) != 1 || uploads[0].(map[string]any)["token"] != "9.xyz" {
+		t.Errorf("expected uploaded token to be attached, got %v", got["wiki_page"]["uploads"])
+	}
+}
+
+// --- TestHandleDocumentsCreate ---
+
+func TestHandleDocumentsCreate(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /enumerations/document_categories.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"document_categories":[{"id":1,"name":"User documentation"},{"id":2,"name":"Technical documentation","is_default":true}]}`))
+	})
+	mux.HandleFunc("POST /projects/1/documents.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"document":{"id":5,"title":"Manual","category":{"id":2,"name":"Technical documentation"}}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"project":       "1",
+		"title":         "Manual",
+		"upload_tokens": []any{map[string]any{"token": "3.abc", "filename": "manual.pdf"}},
+	}
+
+	h.readOnly = true
+	result, err := h.handleDocumentsCreate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || got != nil {
+		t.Fatalf("expected creation to be refused in read-only mode")
+	}
+	h.readOnly = false
+
+	result, err = h.handleDocumentsCreate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+	if got["document"]["category_id"] != float64(2) {
+		t.Errorf("expected default category 2, got %v", got["document"]["category_id"])
+	}
+	uploads, _ := got["document"]["uploads"].([]any)
+	if len(uploads) != 1 || uploads[0].(map[string]any)["token"] != "3.abc" {
+		t.Errorf("expected upload token to be sent, got %v", got["document"]["uploads"])
+	}
+
+	req.Params.Arguments = map[string]any{"project": "1", "title": "Guide", "category": "user"}
+	result, err = h.handleDocumentsCreate(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if got["document"]["category_id"] != float64(1) {
+		t.Errorf("expected category 1, got %v", got["document"]["category_id"])
+	}
+}
+
+func TestHandleDocumentsCreate_NoDefaultCategory(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /enumerations/document_categories.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"document_categories":[{"id":1,"name":"User documentation"},{"id":2,"name":"Technical documentation"}]}`))
+	})
+	mux.HandleFunc("POST /projects/1/documents.json", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("expected no document to be created without a category")
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1", "title": "Manual"}
+	result, err := h.handleDocumentsCreate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := result.Content[0].(gomcp.TextContent).Text
+	if !result.IsError || !strings.Contains(text, "pass category, one of: User documentation, Technical documentation") {
+		t.Errorf("expected an error listing the categories, got %s", text)
+	}
+}
+
+// --- TestHandleIssuesCreate_EstimatedHours ---
+
+func TestHandleIssuesCreate_EstimatedHours(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Task"}]}`))
+	})
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"issue":{"id":42,"subject":"Estimate me","estimated_hours":6.5,"spent_hours":2}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1", "tracker": "Task", "subject": "Estimate me", "estimated_hours": float64(-1)}
+	result, err := h.handleIssuesCreate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || got != nil {
+		t.Fatalf("expected negative estimate to be rejected")
+	}
+
+	req.Params.Arguments = map[string]any{"project": "1", "tracker": "Task", "subject": "Estimate me", "estimated_hours": 6.5}
+	result, err = h.handleIssuesCreate(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if got["issue"]["estimated_hours"] != 6.5 {
+		t.Errorf("expected estimated_hours=6.5 in request, got %v", got["issue"])
+	}
+
+	var issue map[string]any
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &issue); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if issue["estimated_hours"] != 6.5 || issue["spent_hours"] != float64(2) {
+		t.Errorf("expected estimated and spent hours in output, got %v", issue)
+	}
+}
+
+// --- TestHandleIssuesCreate_InitialState ---
+
+func TestHandleIssuesCreate_InitialState(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Task"}]}`))
+	})
+	mux.HandleFunc("GET /issue_statuses.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":2,"name":"In Progress"}]}`))
+	})
+	mux.HandleFunc("GET /enumerations/issue_priorities.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issue_priorities":[{"id":2,"name":"Normal"},{"id":3,"name":"High"}]}`))
+	})
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		// The workflow doesn't allow In Progress for new issues, so Redmine falls back to New
+		_, _ = w.Write([]byte(`{"issue":{"id":42,"subject":"Started","status":{"id":1,"name":"New"},"priority":{"id":3,"name":"High"},"done_ratio":30}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1", "tracker": "Task", "subject": "Started", "done_ratio": float64(130)}
+	result, _ := h.handleIssuesCreate(context.Background(), req)
+	if !result.IsError || got != nil {
+		t.Fatal("expected an out of range done_ratio to be rejected")
+	}
+
+	req.Params.Arguments = map[string]any{"project": "1", "tracker": "Task", "subject": "Started", "status": "in progress", "priority": "High", "done_ratio": float64(30)}
+	result, err := h.handleIssuesCreate(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if got["issue"]["status_id"] != float64(2) || got["issue"]["priority_id"] != float64(3) || got["issue"]["done_ratio"] != float64(30) {
+		t.Errorf("expected resolved status, priority and done_ratio in the request, got %v", got["issue"])
+	}
+
+	var issue map[string]any
+	_ = json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &issue)
+	warning, _ := issue["warning"].(string)
+	if !strings.Contains(warning, "status (created as New") || strings.Contains(warning, "done_ratio") {
+		t.Errorf("expected a warning about the ignored status only, got %q", warning)
+	}
+}
+
+// --- TestHandleIssuesCreate_GroupAssignee ---
+
+func TestHandleIssuesCreate_GroupAssignee(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+	})
+	mux.HandleFunc("GET /users.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"users":[{"id":5,"login":"alice","firstname":"Alice","lastname":"Chen"}],"total_count":1}`))
+	})
+	mux.HandleFunc("GET /groups.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"groups":[{"id":20,"name":"Frontend Team"}]}`))
+	})
+	mux.HandleFunc("GET /projects/1/memberships.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"memberships":[{"id":1,"user":{"id":5,"name":"Alice Chen"}}]}`))
+	})
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"issue":{"id":42,"subject":"Layout broken","assigned_to":{"id":20,"name":"Frontend Team"}}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1", "tracker": "Bug", "subject": "Layout broken", "assigned_to": "Frontend Team"}
+	result, err := h.handleIssuesCreate(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if got["issue"]["assigned_to_id"] != float64(20) {
+		t.Errorf("expected the group to be assigned, got %v", got["issue"]["assigned_to_id"])
+	}
+	var issue map[string]any
+	_ = json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &issue)
+	if assignee, _ := issue["assigned_to"].(map[string]any); assignee["type"] != "group" {
+		t.Errorf("expected the assignee to be marked as a group, got %v", issue["assigned_to"])
+	}
+
+	req.Params.Arguments = map[string]any{"project": "1", "tracker": "Bug", "subject": "Layout broken", "assigned_to": "alice", "assigned_to_group": "Frontend Team"}
+	result, _ = h.handleIssuesCreate(context.Background(), req)
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "cannot both be set") {
+		t.Errorf("expected assigned_to and assigned_to_group together to be rejected, got %v", result.Content)
+	}
+}
+
+// --- TestHandleIssuesCreate_ValidationErrors ---
+
+func TestHandleIssuesCreate_ValidationErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+	})
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"errors":["Severity cannot be blank","Due date must be greater than start date"]}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1", "tracker": "Bug", "subject": "Crash"}
+	result, err := h.handleIssuesCreate(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error result")
+	}
+	text := result.Content[0].(gomcp.TextContent).Text
+	if !strings.Contains(text, "\n- Severity cannot be blank\n- Due date must be greater than start date") {
+		t.Errorf("expected validation messages as a bullet list, got %q", text)
+	}
+}
+
+func TestHandleIssuesCreate_Localized(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+	}))
+	defer mockServer.Close()
+
+	zh, err := i18n.NewPrinter("zh-TW")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i18n.SetDefault(zh)
+	t.Cleanup(func() { i18n.SetDefault(nil) })
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "1", "tracker": "Feature", "subject": "Crash"}
+	result, _ := h.handleIssuesCreate(context.Background(), req)
+	if !result.IsError {
+		t.Fatal("expected error result")
+	}
+	// Both the tool's message and the resolver's are translated; the query is not
+	text := result.Content[0].(gomcp.TextContent).Text
+	if !strings.HasPrefix(text, "無法解析追蹤標籤：找不到追蹤標籤：Feature") {
+		t.Errorf("expected a translated error, got %q", text)
+	}
+}
+
+// --- TestHandleIssuesBatchUpdate_Parallel ---
+
+func TestHandleIssuesBatchUpdate_Parallel(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/current.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"user":{"id":9,"login":"me","firstname":"Me","lastname":"Self"}}`))
+	})
+	mux.HandleFunc("GET /issues/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(r.PathValue("id"), ".json")
+		_, _ = w.Write([]byte(`{"issue":{"id":` + id + `,"project":{"id":1,"name":"P"}}}`))
+	})
+	mux.HandleFunc("PUT /issues/{id}", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		var body map[string]map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["issue"]["assigned_to_id"] != float64(9) {
+			t.Errorf("expected assignee 9, got %v", body["issue"])
+		}
+		if id := r.PathValue("id"); id == "3.json" || id == "7.json" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors":["Issue is locked"]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"issue_ids":   []any{float64(8), float64(7), float64(6), float64(5), float64(4), float64(3), float64(2), float64(1)},
+		"assigned_to": "me",
+		"parallelism": float64(4),
+	}
+	result, err := h.handleIssuesBatchUpdate(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+
+	var resp struct {
+		Success []int `json:"success"`
+		Failed  []struct {
+			ID int `json:"id"`
+		} `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &resp); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if !slices.Equal(resp.Success, []int{1, 2, 4, 5, 6, 8}) {
+		t.Errorf("expected sorted successes, got %v", resp.Success)
+	}
+	if len(resp.Failed) != 2 || resp.Failed[0].ID != 3 || resp.Failed[1].ID != 7 {
+		t.Errorf("expected sorted failures 3 and 7, got %+v", resp.Failed)
+	}
+	if maxInFlight < 2 || maxInFlight > 4 {
+		t.Errorf("expected between 2 and 4 concurrent updates, got %d", maxInFlight)
+	}
+}
+
+// --- TestHandleIssuesBatchUpdate_DryRun ---
+
+func TestHandleIssuesBatchUpdate_DryRun(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /issue_statuses.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":2,"name":"In Progress"},{"id":5,"name":"Closed"}]}`))
+	})
+	mux.HandleFunc("GET /users.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"users":[{"id":12,"login":"bob","firstname":"Bob","lastname":"Lee","name":"Bob Lee"}],"total_count":1}`))
+	})
+	mux.HandleFunc("GET /issues/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("id") {
+		case "1.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":1,"subject":"Login","project":{"id":1,"name":"P"},"tracker":{"id":4,"name":"Bug"},` +
+				`"status":{"id":1,"name":"New"},"assigned_to":{"id":3,"name":"Alice"}}}`))
+		case "2.json":
+			// Closed issues may only be reopened as New
+			_, _ = w.Write([]byte(`{"issue":{"id":2,"subject":"Sync","project":{"id":1,"name":"P"},"tracker":{"id":4,"name":"Bug"},` +
+				`"status":{"id":5,"name":"Closed"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("PUT /issues/{id}", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("dry run must not update issue %s", r.PathValue("id"))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	workflow := &redmine.WorkflowRules{
+		Trackers: map[string]redmine.WorkflowTracker{
+			"4": {
+				Name: "Bug",
+				Statuses: map[string]redmine.WorkflowStatus{
+					"1": {Name: "New"},
+					"2": {Name: "In Progress"},
+					"5": {Name: "Closed", IsClosed: true},
+				},
+				Transitions: map[string][]int{"1": {2}, "5": {1}},
+			},
+		},
+	}
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, workflow)
+	// A dry run changes nothing, so read-only mode allows it
+	h.readOnly = true
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"issue_ids":   []any{float64(3), float64(2), float64(1)},
+		"status":      "In Progress",
+		"assigned_to": "bob",
+		"notes":       "Triaged",
+		"dry_run":     true,
+	}
+	result, err := h.handleIssuesBatchUpdate(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+
+	var resp struct {
+		DryRun  bool  `json:"dry_run"`
+		Success []int `json:"success"`
+		Failed  []struct {
+			ID    int    `json:"id"`
+			Error string `json:"error"`
+		} `json:"failed"`
+		Changes []struct {
+			ID      int                            `json:"id"`
+			Subject string                         `json:"subject"`
+			Changes map[string]redmine.FieldChange `json:"changes"`
+			Notes   string                         `json:"notes"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &resp); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if !resp.DryRun || !slices.Equal(resp.Success, []int{1}) {
+		t.Errorf("expected a dry run with only issue 1 passing, got %+v", resp)
+	}
+	if len(resp.Failed) != 2 || resp.Failed[0].ID != 2 || !strings.Contains(resp.Failed[0].Error, "Invalid status transition") ||
+		resp.Failed[1].ID != 3 || !strings.Contains(resp.Failed[1].Error, "Failed to get issue") {
+		t.Errorf("expected the transition and missing issue failures, got %+v", resp.Failed)
+	}
+	if len(resp.Changes) != 1 {
+		t.Fatalf("expected one preview, got %+v", resp.Changes)
+	}
+	preview := resp.Changes[0]
+	if preview.ID != 1 || preview.Subject != "Login" || preview.Notes != "Triaged" ||
+		preview.Changes["status"] != (redmine.FieldChange{Old: "New", New: "In Progress"}) ||
+		preview.Changes["assigned_to"] != (redmine.FieldChange{Old: "Alice", New: "bob"}) {
+		t.Errorf("unexpected preview: %+v", preview)
+	}
+	if _, ok := preview.Changes["priority"]; ok {
+		t.Errorf("expected no priority change, got %+v", preview.Changes)
+	}
+}
+
+// --- TestHandleTrackersList_ForceRefresh ---
+
+func TestHandleTrackersList_ForceRefresh(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	for range 2 {
+		if result, _ := h.handleTrackersList(context.Background(), req); result.IsError {
+			t.Fatalf("expected success, got %v", result.Content)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected cached second call, got %d requests", calls)
+	}
+
+	req.Params.Arguments = map[string]any{"force_refresh": true}
+	if result, _ := h.handleTrackersList(context.Background(), req); result.IsError {
+		t.Fatalf("expected success, got %v", result.Content)
+	}
+	if calls != 2 {
+		t.Errorf("expected force_refresh to refetch, got %d requests", calls)
+	}
+}
+
+// --- TestHandleIssuesCreate_DryRun ---
+
+func TestHandleIssuesCreate_DryRun(t *testing.T) {
+	created := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug","default_status":{"id":1,"name":"New"}}]}`))
+	})
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	})
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		created = true
+		w.WriteHeader(http.StatusCreated)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	rules := &redmine.CustomFieldRules{Fields: map[string]redmine.CustomFieldRule{
+		"7": {Name: "Severity", Values: []string{"Low", "High"}, RequiredByTrackers: []int{1}},
+	}}
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), rules, nil)
+	h.readOnly = true // dry runs don't write, so read-only mode allows them
+
+	call := func(args map[string]any) map[string]any {
+		t.Helper()
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleIssuesCreate(context.Background(), req)
+		if err != nil || result.IsError {
+			t.Fatalf("expected dry run report, got %v %v", err, result.Content)
+		}
+		var report map[string]any
+		if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &report); err != nil {
+			t.Fatalf("failed to parse result: %v", err)
+		}
+		return report
+	}
+
+	report := call(map[string]any{"project": "1", "tracker": "Bug", "subject": "Crash", "estimated_hours": float64(-2), "dry_run": true})
+	problems, _ := report["problems"].([]any)
+	if report["valid"] != false || len(problems) != 2 {
+		t.Fatalf("expected estimate and required field problems, got %v", report)
+	}
+	if !strings.Contains(fmt.Sprint(problems), "Severity") {
+		t.Errorf("expected missing Severity to be reported, got %v", problems)
+	}
+
+	report = call(map[string]any{"project": "1", "tracker": "Bug", "subject": "Crash", "custom_fields": map[string]any{"Severity": "high"}, "dry_run": true})
+	if report["valid"] != true {
+		t.Fatalf("expected valid dry run, got %v", report)
+	}
+	issue := report["payload"].(map[string]any)["issue"].(map[string]any)
+	if issue["tracker_id"] != float64(1) || issue["subject"] != "Crash" {
+		t.Errorf("unexpected payload: %v", issue)
+	}
+	if cfs, _ := issue["custom_fields"].([]any); len(cfs) != 1 {
+		t.Errorf("expected resolved custom field in payload, got %v", issue["custom_fields"])
+	}
+	if status, _ := report["default_status"].(map[string]any); status["name"] != "New" {
+		t.Errorf("expected tracker default status, got %v", report["default_status"])
+	}
+
+	if created {
+		t.Error("expected dry run not to create an issue")
+	}
+}
+
+// --- TestHandleCustomFieldsList_ProjectWithoutIssues ---
+
+func TestHandleCustomFieldsList_ProjectWithoutIssues(t *testing.T) {
+	var created map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects/5.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"project":{"id":5,"name":"Fresh","issue_custom_fields":[{"id":3,"name":"Component"}]}}`))
+	})
+	mux.HandleFunc("GET /custom_fields.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	})
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+	})
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &created)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"issue":{"id":1,"subject":"First"}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "5"}
+	result, err := h.handleCustomFieldsList(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "Component") {
+		t.Errorf("expected project custom field, got %s", result.Content[0].(gomcp.TextContent).Text)
+	}
+
+	req.Params.Arguments = map[string]any{"project": "5", "tracker": "Bug", "subject": "First", "custom_fields": map[string]any{"component": "UI"}}
+	result, err = h.handleIssuesCreate(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected field name to resolve without issues, got %v %v", err, result.Content)
+	}
+	if cfs, _ := created["issue"]["custom_fields"].([]any); len(cfs) != 1 {
+		t.Errorf("expected resolved custom field in request, got %v", created["issue"])
+	}
+}
+
+// --- TestParseCustomFieldFilter ---
+
+func TestParseCustomFieldFilter(t *testing.T) {
+	tests := []struct {
+		value   any
+		wantOp  string
+		want    []string
+		wantErr bool
+	}{
+		{"SW Tool", "=", []string{"SW Tool"}, false},
+		{[]any{"UI", "API"}, "=", []string{"UI", "API"}, false},
+		{"!Deprecated", "!", []string{"Deprecated"}, false},
+		{"~login", "~", []string{"login"}, false},
+		{"!~draft", "!~", []string{"draft"}, false},
+		{float64(3), "=", []string{"3"}, false},
+		{"a|b", "", nil, true},
+		{[]any{}, "", nil, true},
+		{"!", "", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseCustomFieldFilter(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseCustomFieldFilter(%v): expected error", tt.value)
+			}
+			continue
+		}
+		if err != nil || got.Operator != tt.wantOp || !slices.Equal(got.Values, tt.want) {
+			t.Errorf("parseCustomFieldFilter(%v) = %+v, %v; want %s %v", tt.value, got, err, tt.wantOp, tt.want)
+		}
+	}
+}
+
+// --- TestHandleIssuesSearch_MultiValueCustomField ---
+
+func TestHandleIssuesSearch_MultiValueCustomField(t *testing.T) {
+	var cfQuery string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /custom_fields.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"custom_fields":[{"id":12,"name":"Affected Component","customized_type":"issue","field_format":"list","multiple":true}]}`))
+	})
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		cfQuery = r.URL.Query().Get("cf_12")
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{
+		"custom_fields": map[string]any{"Affected Component": []any{"UI", "API"}},
+	}
+	result, err := h.handleIssuesSearch(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if cfQuery != "=UI|API" {
+		t.Errorf("expected any-of filter, got %q", cfQuery)
+	}
+}
+
+// --- TestHandleIssuesCreate_CustomFieldFormats ---
+
+func TestHandleIssuesCreate_CustomFieldFormats(t *testing.T) {
+	var created map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects/1.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"project":{"id":1,"issue_custom_fields":[{"id":1,"name":"Regression"},{"id":2,"name":"Found on"},{"id":3,"name":"Component"}]}}`))
+	})
+	mux.HandleFunc("GET /custom_fields.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"custom_fields":[
+			{"id":1,"name":"Regression","customized_type":"issue","field_format":"bool"},
+			{"id":2,"name":"Found on","customized_type":"issue","field_format":"date"},
+			{"id":3,"name":"Component","customized_type":"issue","field_format":"list","possible_values":[{"value":"UI"},{"value":"Backend"}]}]}`))
+	})
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"}]}`))
+	})
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &created)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"issue":{"id":9,"subject":"Crash"}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	create := func(fields map[string]any) *gomcp.CallToolResult {
+		t.Helper()
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"project": "1", "tracker": "Bug", "subject": "Crash", "custom_fields": fields}
+		result, err := h.handleIssuesCreate(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	result := create(map[string]any{"Component": "Database"})
+	if text := result.Content[0].(gomcp.TextContent).Text; !result.IsError || !strings.Contains(text, "Valid values: UI, Backend") {
+		t.Errorf("expected possible values in error, got %s", text)
+	}
+
+	result = create(map[string]any{"Found on": "next week"})
+	if text := result.Content[0].(gomcp.TextContent).Text; !result.IsError || !strings.Contains(text, "YYYY-MM-DD") {
+		t.Errorf("expected date format error, got %s", text)
+	}
+
+	result = create(map[string]any{"Regression": "Yes", "Found on": "2026-05-01", "Component": "ui"})
+	if result.IsError {
+		t.Fatalf("expected success, got %v", result.Content)
+	}
+	values := map[float64]any{}
+	for _, cf := range created["issue"]["custom_fields"].([]any) {
+		m := cf.(map[string]any)
+		values[m["id"].(float64)] = m["value"]
+	}
+	if values[1] != "1" || values[2] != "2026-05-01" || values[3] != "UI" {
+		t.Errorf("expected normalized values, got %v", values)
+	}
+}
+
+// --- TestHandleIssuesAddComment ---
+
+func TestHandleIssuesAddComment(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /issues/42.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"issue_id": float64(42), "notes": strings.Repeat("x", 250), "private_notes": true}
+	result, err := h.handleIssuesAddComment(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got %v", result.Content)
+	}
+
+	if len(got["issue"]) != 2 || got["issue"]["private_notes"] != true || len(got["issue"]["notes"].(string)) != 250 {
+		t.Errorf("expected only notes and private_notes in payload, got %v", got["issue"])
+	}
+
+	var resp map[string]any
+	_ = json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &resp)
+	if resp["issue_id"] != float64(42) || resp["notes"] != strings.Repeat("x", 200)+"..." {
+		t.Errorf("expected issue ID and truncated note, got %v", resp)
+	}
+
+	req.Params.Arguments = map[string]any{"issue_id": float64(42), "notes": "  "}
+	result, _ = h.handleIssuesAddComment(context.Background(), req)
+	if !result.IsError {
+		t.Error("expected error for empty notes")
+	}
+}
+
+// --- TestHandleIssuesGetById_PrivateNotes ---
+
+func TestHandleIssuesGetById_PrivateNotes(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/issue_private_notes.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /issues/77.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(fixture)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	get := func(args map[string]any) map[string]any {
+		t.Helper()
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleIssuesGetById(context.Background(), req)
+		if err != nil || result.IsError {
+			t.Fatalf("unexpected failure: %v %v", err, result.Content)
+		}
+		var resp map[string]any
+		if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &resp); err != nil {
+			t.Fatalf("failed to parse result: %v", err)
+		}
+		return resp
+	}
+
+	resp := get(map[string]any{"issue_id": float64(77)})
+	journals := resp["journals"].([]any)
+	if len(journals) != 3 {
+		t.Fatalf("expected all journals by default, got %d", len(journals))
+	}
+	if journals[0].(map[string]any)["private_notes"] != false || journals[1].(map[string]any)["private_notes"] != true {
+		t.Errorf("expected private_notes flags on journals, got %v", journals)
+	}
+
+	resp = get(map[string]any{"issue_id": float64(77), "include_private_notes": false})
+	journals = resp["journals"].([]any)
+	if len(journals) != 2 || resp["private_notes_hidden"] != float64(1) {
+		t.Fatalf("expected the private journal to be hidden, got %v (hidden: %v)", journals, resp["private_notes_hidden"])
+	}
+	for _, j := range journals {
+		if strings.Contains(j.(map[string]any)["notes"].(string), "ReportExporter") {
+			t.Errorf("private note leaked: %v", j)
+		}
+	}
+}
+
+// --- TestHandleProjectsSetStatus ---
+
+func TestHandleProjectsSetStatus(t *testing.T) {
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /projects/{id}/{action}", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+		if r.PathValue("id") == "2" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /projects/{path}", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "DELETE "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	call := func(args map[string]any) (*gomcp.CallToolResult, string) {
+		t.Helper()
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleProjectsSetStatus(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result, result.Content[0].(gomcp.TextContent).Text
+	}
+
+	result, text := call(map[string]any{"project": "1", "action": "delete"})
+	if !result.IsError || !strings.Contains(text, "confirm") || len(calls) != 0 {
+		t.Errorf("expected delete without confirm to be refused before any request, got %s (calls %v)", text, calls)
+	}
+
+	result, text = call(map[string]any{"project": "1", "action": "delete", "confirm": true})
+	if result.IsError || len(calls) != 1 || calls[0] != "DELETE /projects/1.json" {
+		t.Errorf("expected confirmed delete, got %s (calls %v)", text, calls)
+	}
+
+	result, text = call(map[string]any{"project": "1", "action": "close"})
+	if result.IsError || !strings.Contains(text, "Project closed successfully") {
+		t.Errorf("expected close to succeed, got %s", text)
+	}
+
+	result, text = call(map[string]any{"project": "2", "action": "archive"})
+	if !result.IsError || !strings.Contains(text, "403") || !strings.Contains(text, "administrator") {
+		t.Errorf("expected permission error with hint, got %s", text)
+	}
+
+	result, _ = call(map[string]any{"project": "1", "action": "freeze"})
+	if !result.IsError {
+		t.Error("expected error for invalid action")
+	}
+}
+
+// --- TestHandleIssuesCopy_Extras ---
+
+func TestHandleIssuesCopy_Extras(t *testing.T) {
+	var created map[string]map[string]any
+	var relation map[string]map[string]any
+	var watchersAdded []float64
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /issues/10.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issue":{"id":10,"subject":"Crash","project":{"id":1,"name":"Web"},"tracker":{"id":1,"name":"Bug"},"priority":{"id":2,"name":"Normal"},
+			"watchers":[{"id":5,"name":"Alice"},{"id":6,"name":"Locked User"}],
+			"attachments":[
+				{"id":100,"filename":"log.txt","filesize":5,"content_type":"text/plain","description":"server log"},
+				{"id":101,"filename":"dump.bin","filesize":30000000,"content_type":"application/octet-stream"}]}}`))
+	})
+	mux.HandleFunc("GET /attachments/100.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"attachment":{"id":100,"filename":"log.txt","filesize":5,"content_type":"text/plain"}}`))
+	})
+	mux.HandleFunc("GET /attachments/download/100/log.txt", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello"))
+	})
+	mux.HandleFunc("POST /uploads.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"upload":{"token":"1.abc"}}`))
+	})
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&created)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"issue":{"id":11,"subject":"Crash","project":{"id":1,"name":"Web"}}}`))
+	})
+	mux.HandleFunc("POST /issues/11/watchers.json", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]float64
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["user_id"] == 6 {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors":["User is invalid"]}`))
+			return
+		}
+		watchersAdded = append(watchersAdded, body["user_id"])
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /issues/10/relations.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&relation)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"relation":{"id":50,"issue_id":10,"issue_to_id":11,"relation_type":"copied_to"}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"issue_id": float64(10), "copy_watchers": true, "copy_attachments": true}
+	result, err := h.handleIssuesCopy(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := result.Content[0].(gomcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("expected success, got %s", text)
+	}
+
+	uploads, _ := created["issue"]["uploads"].([]any)
+	if len(uploads) != 1 || uploads[0].(map[string]any)["filename"] != "log.txt" || uploads[0].(map[string]any)["token"] != "1.abc" {
+		t.Errorf("expected the small attachment to be re-uploaded, got %v", created["issue"]["uploads"])
+	}
+	if len(watchersAdded) != 1 || watchersAdded[0] != 5 {
+		t.Errorf("expected watcher 5 to be added, got %v", watchersAdded)
+	}
+	if relation["relation"]["issue_to_id"] != float64(11) || relation["relation"]["relation_type"] != "copied_to" {
+		t.Errorf("expected copied_to relation to the copy, got %v", relation)
+	}
+
+	var resp map[string]any
+	_ = json.Unmarshal([]byte(text), &resp)
+	if resp["id"] != float64(11) {
+		t.Errorf("expected the new issue in the result, got %v", resp)
+	}
+	attachments := resp["attachments"].(map[string]any)
+	if len(attachments["copied"].([]any)) != 1 || len(attachments["skipped"].([]any)) != 1 {
+		t.Errorf("expected one copied and one skipped attachment, got %v", attachments)
+	}
+	watchers := resp["watchers"].(map[string]any)
+	if len(watchers["failed"].([]any)) != 1 {
+		t.Errorf("expected the failed watcher to be reported, got %v", watchers)
+	}
+	if resp["relation"].(map[string]any)["created"] != true {
+		t.Errorf("expected relation outcome, got %v", resp["relation"])
+	}
+}
+
+// --- TestHandleIssuesSearch_SavedQuery ---
+
+func TestHandleIssuesSearch_SavedQuery(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/queries.json":
+			_, _ = w.Write([]byte(`{"queries":[{"id":11,"name":"Triage needed","is_public":true,"project_id":null}],"total_count":1}`))
+		case "/issues.json":
+			// The saved query replaces the filters, including the default open status
+			if q.Get("query_id") != "11" || q.Get("project_id") != "3" || q.Has("status_id") || q.Has("tracker_id") {
+				t.Errorf("unexpected issue query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"issues":[{"id":42,"subject":"Crash on start"}],"total_count":1}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"query": "triage", "project": "3", "tracker": "Bug", "status": ""}
+	result, err := h.handleIssuesSearch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &data); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	if data["query_id"] != float64(11) || data["total_count"] != float64(1) {
+		t.Errorf("unexpected result: %v", data)
+	}
+	ignored, _ := data["ignored_filters"].([]any)
+	if len(ignored) != 1 || ignored[0] != "tracker" {
+		t.Errorf("expected only tracker to be reported as ignored, got %v", data["ignored_filters"])
+	}
+}
+
+// --- TestHandleQueriesList ---
+
+func TestHandleQueriesList(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		offset := r.URL.Query().Get("offset")
+		// Two pages to check ListQueries follows total_count
+		if offset == "0" {
+			_, _ = w.Write([]byte(`{"queries":[{"id":1,"name":"Sprint board","is_public":true,"project_id":3},{"id":2,"name":"Other team","is_public":true,"project_id":4}],"total_count":3}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"queries":[{"id":3,"name":"My bugs","is_public":false,"project_id":null}],"total_count":3}`))
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"project": "3"}
+	result, err := h.handleQueriesList(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &data); err != nil {
+		t.Fatalf("failed to parse result JSON: %v", err)
+	}
+	queries := data["queries"].([]any)
+	if data["count"] != float64(2) || len(queries) != 2 {
+		t.Fatalf("expected the project query and the global one, got %v", data)
+	}
+	first, second := queries[0].(map[string]any), queries[1].(map[string]any)
+	if first["id"] != float64(1) || first["scope"] != "project" || first["project_id"] != float64(3) {
+		t.Errorf("unexpected project query: %v", first)
+	}
+	if second["id"] != float64(3) || second["scope"] != "all projects" || second["is_public"] != false {
+		t.Errorf("unexpected global query: %v", second)
+	}
+}
+
+// --- TestHandleIssuesSearch_RelativeDates ---
+
+func TestHandleIssuesSearch_RelativeDates(t *testing.T) {
+	var query url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"updated_after": "2024-W12", "updated_before": "2024-W12", "created_after": "today"}
+	result, err := h.handleIssuesSearch(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if got := query.Get("updated_on"); got != "><2024-03-18|2024-03-24" {
+		t.Errorf("expected the week as a range, got %q", got)
+	}
+	if got := query.Get("created_on"); got != ">="+time.Now().Format("2006-01-02") {
+		t.Errorf("expected today's date, got %q", got)
+	}
+
+	req.Params.Arguments = map[string]any{"created_before": "last Tuesday"}
+	result, err = h.handleIssuesSearch(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "invalid created_before") {
+		t.Errorf("expected an error naming the argument, got %v", result.Content)
+	}
+}
+
+func TestHandleIssuesWatched(t *testing.T) {
+	var query url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issues":[{"id":7,"subject":"Watched","status":{"id":1,"name":"New"}}],"total_count":1}`))
+	}))
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"watched_by": "12"}
+	result, err := h.handleIssuesSearch(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if got := query.Get("watcher_id"); got != "12" {
+		t.Errorf("expected watcher_id=12, got %q", got)
+	}
+
+	req.Params.Arguments = map[string]any{"updated_after": "2024-03-18"}
+	result, err = h.handleIssuesMyWatched(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if query.Get("watcher_id") != "me" || query.Get("status_id") != "open" ||
+		query.Get("sort") != "updated_on:desc" || query.Get("updated_on") != ">=2024-03-18" {
+		t.Errorf("unexpected issues_myWatched query: %v", query)
+	}
+	if text := result.Content[0].(gomcp.TextContent).Text; !strings.Contains(text, `"subject": "Watched"`) {
+		t.Errorf("expected the watched issue, got %s", text)
+	}
+
+	// The page reported is the one fetched, with the limit capped
+	req.Params.Arguments = map[string]any{"offset": float64(40), "limit": float64(5000)}
+	result, err = h.handleIssuesMyWatched(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	var page map[string]any
+	_ = json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &page)
+	if query.Get("offset") != "40" || page["offset"] != float64(40) || page["limit"] != float64(redmine.MaxSearchIssuesLimit) {
+		t.Errorf("expected offset 40 and the capped limit, got query %v and %v/%v", query, page["offset"], page["limit"])
+	}
+}
+
+func TestHandleIssuesSearch_Sort(t *testing.T) {
+	var sort string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /custom_fields.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"custom_fields":[{"id":4,"name":"Severity","customized_type":"issue"}]}`))
+	})
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		sort = r.URL.Query().Get("sort")
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"sort": "severity desc, updated_on"}
+	result, err := h.handleIssuesSearch(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if sort != "cf_4:desc,updated_on" {
+		t.Errorf("expected the normalized sort, got %q", sort)
+	}
+
+	req.Params.Arguments = map[string]any{"sort": "bogus"}
+	result, _ = h.handleIssuesSearch(context.Background(), req)
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "updated_on") {
+		t.Errorf("expected an error listing the sortable fields, got %v", result.Content)
+	}
+}
+
+// --- TestHandleReportsStandup_Timezone ---
+
+func TestHandleReportsStandup_Timezone(t *testing.T) {
+	var spentOn string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/current.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"user":{"id":1,"firstname":"Alice","lastname":"Smith"}}`))
+	})
+	mux.HandleFunc("GET /time_entries.json", func(w http.ResponseWriter, r *http.Request) {
+		spentOn = r.URL.Query().Get("from")
+		_, _ = w.Write([]byte(`{"time_entries":[],"total_count":0}`))
+	})
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	h.location = time.FixedZone("UTC+14", 14*60*60)
+
+	result, err := h.handleReportsStandup(context.Background(), gomcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	var report redmine.StandupReport
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &report); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+
+	today := time.Now().In(h.location)
+	if report.Date != today.Format("2006-01-02") {
+		t.Errorf("expected today in the configured timezone, %s, got %s", today.Format("2006-01-02"), report.Date)
+	}
+	if want := h.week.PreviousWorkday(today).Format("2006-01-02"); spentOn != want {
+		t.Errorf("expected yesterday's entries from %s, got %s", want, spentOn)
+	}
+}
+
+// --- TestHandleAttachmentsDelete ---
+
+func TestHandleAttachmentsDelete(t *testing.T) {
+	var deleted []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /attachments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(r.PathValue("id"), ".json")
+		_, _ = fmt.Fprintf(w, `{"attachment":{"id":%s,"filename":"wrong-%s.png","filesize":120,"author":{"id":3,"name":"Alice"}}}`, id, id)
+	})
+	mux.HandleFunc("GET /issues/42.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issue":{"id":42,"subject":"Login page","attachments":[{"id":7,"filename":"wrong-7.png"}]}}`))
+	})
+	mux.HandleFunc("DELETE /attachments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(r.PathValue("id"), ".json")
+		if id == "8" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		deleted = append(deleted, id)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	call := func(args map[string]any) *gomcp.CallToolResult {
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleAttachmentsDelete(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	result := call(map[string]any{"attachment_id": float64(7), "issue_id": float64(42), "confirm": true})
+	if result.IsError {
+		t.Fatalf("expected success, got %v", result.Content)
+	}
+	var out map[string]any
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	issue, _ := out["issue"].(map[string]any)
+	if out["filename"] != "wrong-7.png" || issue["id"] != float64(42) || issue["subject"] != "Login page" {
+		t.Errorf("expected the filename and issue to be recorded, got %v", out)
+	}
+
+	if result := call(map[string]any{"attachment_id": float64(7)}); !result.IsError {
+		t.Error("expected deletion without confirm to be refused")
+	}
+	result = call(map[string]any{"attachment_id": float64(9), "issue_id": float64(42), "confirm": true})
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "not attached to issue #42") {
+		t.Errorf("expected an attachment of another issue to be refused, got %v", result.Content)
+	}
+	result = call(map[string]any{"attachment_id": float64(8), "confirm": true})
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "only the attachment's author or an administrator") {
+		t.Errorf("expected a 403 to explain who can delete, got %v", result.Content)
+	}
+
+	if len(deleted) != 1 || deleted[0] != "7" {
+		t.Errorf("expected only attachment 7 to be deleted, got %v", deleted)
+	}
+}
+
+// --- TestHandleAttachmentsUpdate ---
+
+func TestHandleAttachmentsUpdate(t *testing.T) {
+	var sent map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("PATCH /attachments/7.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /attachments/7.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"attachment":{"id":7,"filename":"report.pdf","description":"","author":{"id":3,"name":"Alice"}}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	call := func(args map[string]any) *gomcp.CallToolResult {
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleAttachmentsUpdate(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	result := call(map[string]any{"attachment_id": float64(7), "filename": "report.pdf", "description": ""})
+	if result.IsError {
+		t.Fatalf("expected success, got %v", result.Content)
+	}
+	if sent["attachment"]["filename"] != "report.pdf" || sent["attachment"]["description"] != "" {
+		t.Errorf("expected the filename and a cleared description to be sent, got %v", sent)
+	}
+	var out struct {
+		Attachment map[string]any `json:"attachment"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if out.Attachment["filename"] != "report.pdf" {
+		t.Errorf("expected the refreshed attachment, got %v", out.Attachment)
+	}
+
+	sent = nil
+	call(map[string]any{"attachment_id": float64(7), "description": "Q3 numbers"})
+	if _, ok := sent["attachment"]["filename"]; ok || sent["attachment"]["description"] != "Q3 numbers" {
+		t.Errorf("expected only the description to be sent, got %v", sent)
+	}
+
+	sent = nil
+	if result := call(map[string]any{"attachment_id": float64(7)}); !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "Nothing to update") {
+		t.Errorf("expected an empty update to be rejected, got %v", result.Content)
+	}
+	if result := call(map[string]any{"attachment_id": float64(7), "filename": " "}); !result.IsError {
+		t.Error("expected an empty filename to be rejected")
+	}
+	if sent != nil {
+		t.Errorf("expected rejected updates not to reach Redmine, got %v", sent)
+	}
+}
+
+// --- TestHandleAttachmentsDownload_SizeCap ---
+
+func TestHandleAttachmentsDownload_SizeCap(t *testing.T) {
+	big := strings.Repeat("x", maxMCPDownloadSize+1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /attachments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("id") {
+		case "1.json":
+			_, _ = w.Write([]byte(`{"attachment":{"id":1,"filename":"small.txt","filesize":2}}`))
+		case "2.json":
+			_, _ = w.Write([]byte(`{"attachment":{"id":2,"filename":"build.log","filesize":314572800}}`))
+		case "3.json":
+			// Metadata that understates the real size
+			_, _ = w.Write([]byte(`{"attachment":{"id":3,"filename":"liar.bin","filesize":1}}`))
+		}
+	})
+	mux.HandleFunc("GET /attachments/download/{id}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("id") {
+		case "1":
+			_, _ = w.Write([]byte("ok"))
+		case "2":
+			t.Error("expected a file over the cap not to be downloaded")
+		case "3":
+			_, _ = w.Write([]byte(big))
+		}
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	call := func(id int) *gomcp.CallToolResult {
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{"attachment_id": float64(id)}
+		result, err := h.handleAttachmentsDownload(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	if result := call(1); result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, base64.StdEncoding.EncodeToString([]byte("ok"))) {
+		t.Errorf("expected a small file to be returned, got %v", result.Content)
+	}
+	result := call(2)
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "300.0 MB") ||
+		!strings.Contains(result.Content[0].(gomcp.TextContent).Text, "/api/v1/attachments/2/download") {
+		t.Errorf("expected the size and REST download URL, got %v", result.Content)
+	}
+	if result := call(3); !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "over the 10 MB limit") {
+		t.Errorf("expected the cap to hold when metadata is wrong, got %v", result.Content)
+	}
+}
+
+func TestHandleAttachmentsDownload_Stalled(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /attachments/1.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"attachment":{"id":1,"filename":"slow.txt","filesize":2}}`))
+	})
+	mux.HandleFunc("GET /attachments/download/1/slow.txt", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"attachment_id": float64(1)}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The call's context aborts a download Redmine never finishes
+	done := make(chan *gomcp.CallToolResult)
+	go func() {
+		result, _ := h.handleAttachmentsDownload(ctx, req)
+		done <- result
+	}()
+	select {
+	case result := <-done:
+		if !result.IsError {
+			t.Errorf("expected the stalled download to fail, got %v", result.Content)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the download to stop with the call's context")
+	}
+}
+
+// --- TestHandleAttachmentsUpload_ContentType ---
+
+func TestHandleAttachmentsUpload_ContentType(t *testing.T) {
+	var queries []url.Values
+	var attached map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /uploads.json", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"upload":{"token":"1.abc"}}`))
+	})
+	mux.HandleFunc("PUT /issues/7.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&attached)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	content := base64.StdEncoding.EncodeToString([]byte("<svg/>"))
+
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"filename": "notes.txt", "content": content, "content_type": "text/markdown"}
+	result, err := h.handleAttachmentsUpload(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+	if !strings.Contains(result.Content[0].(gomcp.TextContent).Text, `"content_type": "text/markdown"`) {
+		t.Errorf("expected the content type in the result, got %v", result.Content)
+	}
+
+	// Without content_type the type is guessed from the extension
+	req.Params.Arguments = map[string]any{"issue_id": float64(7), "filename": "diagram.svg", "content": content}
+	result, err = h.handleAttachmentsUploadAndAttach(context.Background(), req)
+	if err != nil || result.IsError {
+		t.Fatalf("expected success, got %v %v", err, result.Content)
+	}
+
+	if len(queries) != 2 || queries[0].Get("filename") != "notes.txt" || queries[0].Get("content_type") != "text/markdown" ||
+		queries[1].Get("filename") != "diagram.svg" || queries[1].Get("content_type") != "image/svg+xml" {
+		t.Errorf("unexpected upload queries: %v", queries)
+	}
+	uploads, _ := attached["issue"]["uploads"].([]any)
+	if len(uploads) != 1 || uploads[0].(map[string]any)["content_type"] != "image/svg+xml" {
+		t.Errorf("expected the guessed content type on the attachment, got %v", attached["issue"]["uploads"])
+	}
+}
+
+// --- TestHandleAttachmentsUpload_SizeLimit ---
+
+func TestHandleAttachmentsUpload_SizeLimit(t *testing.T) {
+	uploads := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /uploads.json", func(w http.ResponseWriter, r *http.Request) {
+		uploads++
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"upload":{"token":"1.abc"}}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	if h.maxAttachmentSize != redmine.DefaultMaxAttachmentMB*1024*1024 {
+		t.Fatalf("expected the default limit, got %d", h.maxAttachmentSize)
+	}
+	h.maxAttachmentSize = 1024 * 1024
+	upload := func(size int) *gomcp.CallToolResult {
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = map[string]any{
+			"filename": "dump.bin",
+			"content":  base64.StdEncoding.EncodeToString(make([]byte, size)),
+		}
+		result, err := h.handleAttachmentsUpload(context.Background(), req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	if result := upload(1024 * 1024); result.IsError {
+		t.Errorf("expected a file at the limit to upload, got %v", result.Content)
+	}
+	result := upload(1536 * 1024)
+	if !result.IsError || !strings.Contains(result.Content[0].(gomcp.TextContent).Text, "dump.bin is 1.5 MB, over the 1 MB attachment limit") {
+		t.Errorf("expected both sizes in the error, got %v", result.Content)
+	}
+	if uploads != 1 {
+		t.Errorf("expected only the file within the limit to be uploaded, got %d uploads", uploads)
+	}
+}
+
+func TestHandleIssuesGetById_SpentHoursAndChildren(t *testing.T) {
+	var timeEntryCalls, childCalls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /issues/10.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"issue":{"id":10,"subject":"Parent","status":{"id":1,"name":"New"}}}`))
+	})
+	mux.HandleFunc("GET /time_entries.json", func(w http.ResponseWriter, r *http.Request) {
+		timeEntryCalls++
+		if r.URL.Query().Get("issue_id") != "10" {
+			t.Errorf("expected time entries filtered by issue, got %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"time_entries":[{"id":1,"hours":2,"user":{"id":7,"name":"Alice"}},{"id":2,"hours":1.5,"user":{"id":8,"name":"Bob"}},{"id":3,"hours":1,"user":{"id":7,"name":"Alice"}}],"total_count":3}`))
+	})
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		childCalls++
+		if r.URL.Query().Get("parent_id") != "10" || r.URL.Query().Get("status_id") != "*" {
+			t.Errorf("expected open and closed children of 10, got %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"issues":[{"id":11,"subject":"Child","status":{"id":5,"name":"Closed"},"assigned_to":{"id":7,"name":"Alice"},"done_ratio":100}],"total_count":1}`))
+	})
+	mockServer := httptest.NewServer(mux)
+	defer mockServer.Close()
+
+	h := NewToolHandlers(redmine.NewClient(mockServer.URL, "test-api-key"), nil, nil)
+	get := func(args map[string]any) map[string]any {
+		t.Helper()
+		req := gomcp.CallToolRequest{}
+		req.Params.Arguments = args
+		result, err := h.handleIssuesGetById(context.Background(), req)
+		if err != nil || result.IsError {
+			t.Fatalf("unexpected failure: %v %v", err, result.Content)
+		}
+		var resp map[string]any
+		if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &resp); err != nil {
+			t.Fatalf("failed to parse result: %v", err)
+		}
+		return resp
+	}
+
+	resp := get(map[string]any{"issue_id": float64(10)})
+	if _, ok := resp["spent_time"]; ok || timeEntryCalls != 0 || childCalls != 0 {
+		t.Fatalf("expected no extra lookups by default, got %v", resp)
+	}
+
+	resp = get(map[string]any{"issue_id": float64(10), "include_spent_hours": true, "include_children": true})
+	spent := resp["spent_time"].(map[string]any)
+	if spent["total_hours"] != 4.5 || spent["entry_count"] != float64(3) {
+		t.Errorf("expected 4.5 hours over 3 entries, got %v", spent)
+	}
+	byUser := spent["by_user"].([]any)
+	if len(byUser) != 2 || byUser[0].(map[string]any)["user"] != "Alice" || byUser[0].(map[string]any)["hours"] != float64(3) {
+		t.Errorf("expected Alice first with 3 hours, got %v", byUser)
+	}
+	children := resp["children"].([]any)
+	if len(children) != 1 {
+		t.Fatalf("expected one child, got %v", children)
+	}
+	if child := children[0].(map[string]any); child["status"] != "Closed" || child["assigned_to"] != "Alice" || child["done_ratio"] != float64(100) {
+		t.Errorf("unexpected child summary: %v", child)
+	}
+}
@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"math"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// maxTreeIssues caps how many issues issues_getTree fetches in total
+const maxTreeIssues = 500
+
+// issueTreeNode is an issue with its fetched subtasks
+type issueTreeNode struct {
+	issue    redmine.Issue
+	children []*issueTreeNode
+}
+
+// buildIssueTree fetches subtasks of root level by level down to maxDepth,
+// stopping once maxIssues issues (root included) have been fetched. It returns
+// the number of fetched issues and whether the tree was truncated by the cap.
+func (h *ToolHandlers) buildIssueTree(root redmine.Issue, maxDepth, maxIssues int) (*issueTreeNode, int, bool, error) {
+	rootNode := &issueTreeNode{issue: root}
+	fetched := 1
+	level := []*issueTreeNode{rootNode}
+
+	for depth := 0; depth < maxDepth && len(level) > 0; depth++ {
+		var next []*issueTreeNode
+		for _, node := range level {
+			params := redmine.SearchIssuesParams{
+				ParentID: node.issue.ID,
+				StatusID: "*",
+				Sort:     "id:asc",
+			}
+			for {
+				params.Limit = min(100, maxIssues-fetched)
+				if params.Limit <= 0 {
+					return rootNode, fetched, true, nil
+				}
+
+				issues, total, err := h.client.SearchIssues(params)
+				if err != nil {
+					return nil, fetched, false, err
+				}
+				issues = issues[:min(len(issues), params.Limit)]
+				for _, issue := range issues {
+					child := &issueTreeNode{issue: issue}
+					node.children = append(node.children, child)
+					next = append(next, child)
+				}
+				fetched += len(issues)
+				params.Offset += len(issues)
+
+				if len(issues) == 0 || params.Offset >= total {
+					break
+				}
+			}
+		}
+		level = next
+	}
+
+	return rootNode, fetched, false, nil
+}
+
+// formatIssueTree renders a tree node with its children. Each node reports
+// its direct children_count, total descendant_count and an aggregate
+// done_ratio computed like Redmine: the average of the children weighted by
+// estimated hours when every child has an estimate, unweighted otherwise.
+func formatIssueTree(node *issueTreeNode) (map[string]any, int, float64) {
+	issue := node.issue
+	result := map[string]any{
+		"id":         issue.ID,
+		"subject":    issue.Subject,
+		"status":     issue.Status.Name,
+		"done_ratio": issue.DoneRatio,
+	}
+	if issue.AssignedTo != nil {
+		result["assigned_to"] = issue.AssignedTo.Name
+	}
+	if issue.EstimatedHours != nil {
+		result["estimated_hours"] = *issue.EstimatedHours
+	}
+	if issue.SpentHours != nil {
+		result["spent_hours"] = *issue.SpentHours
+	}
+
+	if len(node.children) == 0 {
+		result["children"] = []map[string]any{}
+		result["children_count"] = 0
+		result["descendant_count"] = 0
+		result["aggregate_done_ratio"] = issue.DoneRatio
+		return result, 0, float64(issue.DoneRatio)
+	}
+
+	children := make([]map[string]any, len(node.children))
+	ratios := make([]float64, len(node.children))
+	descendants := 0
+	weighted := true
+	for i, child := range node.children {
+		var count int
+		children[i], count, ratios[i] = formatIssueTree(child)
+		descendants += 1 + count
+		if child.issue.EstimatedHours == nil || *child.issue.EstimatedHours <= 0 {
+			weighted = false
+		}
+	}
+
+	var sum, weights float64
+	for i, child := range node.children {
+		weight := 1.0
+		if weighted {
+			weight = *child.issue.EstimatedHours
+		}
+		sum += ratios[i] * weight
+		weights += weight
+	}
+	aggregate := sum / weights
+
+	result["children"] = children
+	result["children_count"] = len(children)
+	result["descendant_count"] = descendants
+	result["aggregate_done_ratio"] = int(math.Round(aggregate))
+	return result, descendants, aggregate
+}
@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// newIssueTreeServer serves issue 1 with children 2 and 3, and 3 with child 4
+func newIssueTreeServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	children := map[string]string{
+		"1": `{"id":2,"subject":"Design","status":{"id":5,"name":"Closed"},"done_ratio":100,"estimated_hours":10},
+			{"id":3,"subject":"Build","status":{"id":2,"name":"In Progress"},"done_ratio":20,"estimated_hours":30,"assigned_to":{"id":7,"name":"Alice"}}`,
+		"3": `{"id":4,"subject":"Backend","status":{"id":1,"name":"New"},"done_ratio":0,"spent_hours":1.5}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/issues/1.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":1,"subject":"Release","status":{"id":2,"name":"In Progress"},"done_ratio":40}}`))
+		case "/issues.json":
+			parent := r.URL.Query().Get("parent_id")
+			if r.URL.Query().Get("status_id") != "*" {
+				t.Errorf("expected subtasks of all statuses, got %q", r.URL.Query().Get("status_id"))
+			}
+			items := children[parent]
+			count := strings.Count(items, `"subject"`)
+			fmt.Fprintf(w, `{"issues":[%s],"total_count":%d}`, items, count)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func callIssuesGetTree(t *testing.T, h *ToolHandlers, args map[string]any) map[string]any {
+	t.Helper()
+	req := gomcp.CallToolRequest{}
+	req.Params.Arguments = args
+	result, err := h.handleIssuesGetTree(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got error: %v", result.Content)
+	}
+	var out map[string]any
+	if err := json.Unmarshal([]byte(result.Content[0].(gomcp.TextContent).Text), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	return out
+}
+
+func TestHandleIssuesGetTree(t *testing.T) {
+	server := newIssueTreeServer(t)
+	defer server.Close()
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	out := callIssuesGetTree(t, h, map[string]any{"issue_id": float64(1)})
+	if out["fetched_count"] != float64(4) || out["is_truncated"] != false {
+		t.Errorf("expected 4 fetched issues without truncation, got %v", out)
+	}
+
+	root := out["tree"].(map[string]any)
+	if root["children_count"] != float64(2) || root["descendant_count"] != float64(3) {
+		t.Errorf("unexpected root counts: %v", root)
+	}
+	// Weighted by estimates: (100*10 + build) / 40 where build aggregates its only child at 0%
+	if root["aggregate_done_ratio"] != float64(25) {
+		t.Errorf("expected aggregate done ratio 25, got %v", root["aggregate_done_ratio"])
+	}
+
+	build := root["children"].([]any)[1].(map[string]any)
+	if build["assigned_to"] != "Alice" || build["estimated_hours"] != float64(30) {
+		t.Errorf("unexpected node fields: %v", build)
+	}
+	backend := build["children"].([]any)[0].(map[string]any)
+	if backend["spent_hours"] != 1.5 || backend["children_count"] != float64(0) {
+		t.Errorf("unexpected leaf: %v", backend)
+	}
+}
+
+func TestHandleIssuesGetTree_Limits(t *testing.T) {
+	server := newIssueTreeServer(t)
+	defer server.Close()
+	h := NewToolHandlers(redmine.NewClient(server.URL, "test-key"), nil, nil)
+
+	out := callIssuesGetTree(t, h, map[string]any{"issue_id": float64(1), "max_depth": float64(1)})
+	root := out["tree"].(map[string]any)
+	build := root["children"].([]any)[1].(map[string]any)
+	if build["children_count"] != float64(0) || out["fetched_count"] != float64(3) {
+		t.Errorf("expected depth 1 to stop before grandchildren, got %v", out)
+	}
+	// Weighted by estimates: (100*10 + 20*30) / 40
+	if root["aggregate_done_ratio"] != float64(40) {
+		t.Errorf("expected aggregate done ratio 40, got %v", root["aggregate_done_ratio"])
+	}
+
+	issue, err := h.client.GetIssue(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, fetched, truncated, err := h.buildIssueTree(*issue, 3, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !truncated || fetched != 2 {
+		t.Errorf("expected truncation at 2 issues, got fetched=%d truncated=%v", fetched, truncated)
+	}
+}
@@ -0,0 +1,66 @@
+package redmine
+
+// MaxBulkCreateIssues caps how many issues one bulk create may contain
+const MaxBulkCreateIssues = 50
+
+// BulkCreated is an issue created by BulkCreateIssues
+type BulkCreated struct {
+	Index   int    `json:"index"`
+	ID      int    `json:"id"`
+	Subject string `json:"subject"`
+	Project string `json:"project"`
+	Tracker string `json:"tracker"`
+}
+
+// BulkFailure is an item BulkCreateIssues couldn't create
+type BulkFailure struct {
+	Index   int    `json:"index"`
+	Subject string `json:"subject"`
+	Error   string `json:"error"`
+}
+
+// BulkRelation is a precedes relation added between consecutive created issues
+type BulkRelation struct {
+	IssueID   int    `json:"issue_id"`
+	IssueToID int    `json:"issue_to_id"`
+	Error     string `json:"error,omitempty"`
+}
+
+// BulkCreateResult reports a bulk create's outcome per item
+type BulkCreateResult struct {
+	Success   []BulkCreated  `json:"success"`
+	Failed    []BulkFailure  `json:"failed"`
+	Relations []BulkRelation `json:"relations,omitempty"`
+}
+
+// BulkCreateIssues creates already validated issues in order, continuing
+// after failures. With linkSequentially each created issue precedes the next
+// created one; items that failed are skipped, so the chain stays unbroken.
+func BulkCreateIssues(c *Client, items []CreateIssueParams, linkSequentially bool) BulkCreateResult {
+	result := BulkCreateResult{Success: []BulkCreated{}, Failed: []BulkFailure{}}
+	for i, params := range items {
+		issue, err := c.CreateIssue(params)
+		if err != nil {
+			result.Failed = append(result.Failed, BulkFailure{Index: i, Subject: params.Subject, Error: err.Error()})
+			continue
+		}
+		result.Success = append(result.Success, BulkCreated{
+			Index:   i,
+			ID:      issue.ID,
+			Subject: issue.Subject,
+			Project: issue.Project.Name,
+			Tracker: issue.Tracker.Name,
+		})
+	}
+
+	if linkSequentially {
+		for i := 1; i < len(result.Success); i++ {
+			rel := BulkRelation{IssueID: result.Success[i-1].ID, IssueToID: result.Success[i].ID}
+			if _, err := c.CreateRelation(rel.IssueID, rel.IssueToID, "precedes", nil); err != nil {
+				rel.Error = err.Error()
+			}
+			result.Relations = append(result.Relations, rel)
+		}
+	}
+	return result
+}
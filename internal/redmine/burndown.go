@@ -0,0 +1,230 @@
+package redmine
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// MaxBurndownJournalIssues caps how many closed issues a precise burndown
+	// fetches journals for; the others fall back to their closed_on date
+	MaxBurndownJournalIssues = 100
+
+	// maxBurndownDays caps the length of a burndown series
+	maxBurndownDays = 366
+
+	// burndownParallelism bounds the concurrent journal requests of a precise burndown
+	burndownParallelism = 4
+)
+
+// Burndown metrics
+const (
+	BurndownIssueCount     = "issue_count"
+	BurndownEstimatedHours = "estimated_hours"
+)
+
+// BurndownPoint is the work remaining at the end of a day, with the ideal
+// straight line from the first day's remaining work down to zero
+type BurndownPoint struct {
+	Date      string  `json:"date"`
+	Remaining float64 `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
+
+// Burndown is a reconstructed burndown series
+type Burndown struct {
+	Metric          string          `json:"metric"`
+	From            string          `json:"from"`
+	To              string          `json:"to"`
+	IssueCount      int             `json:"issue_count"`
+	Precise         bool            `json:"precise"`
+	JournalsChecked int             `json:"journals_checked,omitempty"`
+	Points          []BurndownPoint `json:"points"`
+	Notes           []string        `json:"notes,omitempty"`
+}
+
+// BurndownParams selects the issues and days of a burndown. Dates are
+// YYYY-MM-DD; VersionID 0 means every issue of the project.
+type BurndownParams struct {
+	ProjectID int
+	VersionID int
+	From, To  string
+	Metric    string
+	Precise   bool
+}
+
+// statusChange is an issue entering or leaving a closed status
+type statusChange struct {
+	date   string
+	closed bool
+}
+
+// BuildBurndown reconstructs the remaining work per day from the issues'
+// creation and closing dates, since Redmine keeps no daily snapshots. An
+// issue counts from the day it was created until the day it was closed. By
+// default closed_on is taken as the closing day of issues in a closed status
+// and reopened issues count as never closed; with Precise the status changes
+// in the journals of up to MaxBurndownJournalIssues closed issues are
+// replayed instead. Days are UTC days.
+func BuildBurndown(c *Client, params BurndownParams, closedStatuses map[int]bool) (*Burndown, error) {
+	from, err := time.Parse(DateLayout, params.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from date: %s", params.From)
+	}
+	to, err := time.Parse(DateLayout, params.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to date: %s", params.To)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("from (%s) is after to (%s)", params.From, params.To)
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	if days > maxBurndownDays {
+		return nil, fmt.Errorf("the range covers %d days; burndowns cover at most %d", days, maxBurndownDays)
+	}
+	switch params.Metric {
+	case "":
+		params.Metric = BurndownIssueCount
+	case BurndownIssueCount, BurndownEstimatedHours:
+	default:
+		return nil, fmt.Errorf("invalid metric: %s (use %s or %s)", params.Metric, BurndownIssueCount, BurndownEstimatedHours)
+	}
+
+	search := SearchIssuesParams{
+		ProjectID: strconv.Itoa(params.ProjectID),
+		StatusID:  "*",
+		// Issues created after the range never count
+		CreatedOn: "<=" + params.To,
+	}
+	if params.VersionID > 0 {
+		search.VersionID = strconv.Itoa(params.VersionID)
+	}
+	issues, total, err := listAllIssues(c, search)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	b := &Burndown{
+		Metric:     params.Metric,
+		From:       params.From,
+		To:         params.To,
+		IssueCount: len(issues),
+		Precise:    params.Precise,
+		Points:     make([]BurndownPoint, days),
+	}
+	if params.VersionID > 0 {
+		b.Notes = append(b.Notes, "Issues count from their creation, even if they were added to the version later")
+	}
+	if note := cappedIssuesNote(len(issues), total); note != "" {
+		b.Notes = append(b.Notes, note)
+	}
+
+	changes := make([][]statusChange, len(issues))
+	var closed []int
+	for i, issue := range issues {
+		if closedStatuses[issue.Status.ID] {
+			closed = append(closed, i)
+			// Issues closed before Redmine recorded closed_on have only updated_on
+			closedOn := issue.ClosedOn
+			if closedOn == "" {
+				closedOn = issue.UpdatedOn
+			}
+			changes[i] = []statusChange{{date: dayOf(closedOn), closed: true}}
+		}
+	}
+	if params.Precise {
+		checked := closed[:min(len(closed), MaxBurndownJournalIssues)]
+		if err := replayStatusChanges(c, issues, checked, changes, closedStatuses); err != nil {
+			return nil, err
+		}
+		b.JournalsChecked = len(checked)
+		if len(closed) > len(checked) {
+			b.Notes = append(b.Notes, fmt.Sprintf("Journals were checked for %d of %d closed issues; the others use their closed_on date", len(checked), len(closed)))
+		}
+	}
+
+	for d := range days {
+		day := from.AddDate(0, 0, d).Format(DateLayout)
+		var remaining float64
+		for i, issue := range issues {
+			if dayOf(issue.CreatedOn) > day || closedAt(changes[i], day) {
+				continue
+			}
+			remaining += burndownWeight(issue, params.Metric)
+		}
+		b.Points[d] = BurndownPoint{Date: day, Remaining: roundHours(remaining)}
+	}
+	start := b.Points[0].Remaining
+	for d := range b.Points {
+		if days > 1 {
+			b.Points[d].Ideal = roundHours(start * (1 - float64(d)/float64(days-1)))
+		}
+	}
+	return b, nil
+}
+
+// replayStatusChanges replaces the changes of the checked issues with the
+// closing and reopening days their journals record
+func replayStatusChanges(c *Client, issues []Issue, checked []int, changes [][]statusChange, closedStatuses map[int]bool) error {
+	g := new(errgroup.Group)
+	g.SetLimit(burndownParallelism)
+	for _, i := range checked {
+		g.Go(func() error {
+			journals, err := issueJournals(c, issues[i].ID)
+			if err != nil {
+				return fmt.Errorf("failed to fetch journals of issue #%d: %w", issues[i].ID, err)
+			}
+			var replayed []statusChange
+			for _, j := range journals {
+				for _, d := range j.Details {
+					if d.Property != "attr" || d.Name != "status_id" {
+						continue
+					}
+					id, _ := strconv.Atoi(d.NewValue)
+					replayed = append(replayed, statusChange{date: dayOf(j.CreatedOn), closed: closedStatuses[id]})
+				}
+			}
+			if len(replayed) > 0 {
+				sort.SliceStable(replayed, func(a, b int) bool { return replayed[a].date < replayed[b].date })
+				changes[i] = replayed
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// closedAt reports whether the last status change on or before day closed
+// the issue
+func closedAt(changes []statusChange, day string) bool {
+	closed := false
+	for _, ch := range changes {
+		if ch.date > day {
+			break
+		}
+		closed = ch.closed
+	}
+	return closed
+}
+
+func burndownWeight(issue Issue, metric string) float64 {
+	if metric == BurndownEstimatedHours {
+		if issue.EstimatedHours == nil {
+			return 0
+		}
+		return *issue.EstimatedHours
+	}
+	return 1
+}
+
+// dayOf returns the date part of a Redmine timestamp
+func dayOf(timestamp string) string {
+	if len(timestamp) < len(DateLayout) {
+		return timestamp
+	}
+	return timestamp[:len(DateLayout)]
+}
@@ -0,0 +1,99 @@
+package redmine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBuildBurndown(t *testing.T) {
+	var journalCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/issues.json":
+			if q.Get("status_id") != "*" || q.Get("created_on") != "<=2025-03-05" || q.Get("fixed_version_id") != "10" {
+				t.Errorf("unexpected issue query: %s", r.URL.RawQuery)
+			}
+			// #3 was closed on the 2nd, reopened on the 3rd and closed again on the 4th
+			fmt.Fprint(w, `{"issues":[
+				{"id":1,"status":{"id":1},"created_on":"2025-02-20T09:00:00Z","estimated_hours":5},
+				{"id":2,"status":{"id":5},"created_on":"2025-02-20T09:00:00Z","closed_on":"2025-03-02T10:00:00Z","estimated_hours":3},
+				{"id":3,"status":{"id":5},"created_on":"2025-02-21T09:00:00Z","closed_on":"2025-03-04T10:00:00Z"},
+				{"id":4,"status":{"id":1},"created_on":"2025-03-03T09:00:00Z","estimated_hours":2}
+			],"total_count":4}`)
+		case "/issues/2.json":
+			journalCalls.Add(1)
+			fmt.Fprint(w, `{"issue":{"id":2,"journals":[
+				{"id":1,"created_on":"2025-03-02T10:00:00Z","details":[{"property":"attr","name":"status_id","old_value":"1","new_value":"5"}]}
+			]}}`)
+		case "/issues/3.json":
+			journalCalls.Add(1)
+			fmt.Fprint(w, `{"issue":{"id":3,"journals":[
+				{"id":2,"created_on":"2025-03-02T10:00:00Z","details":[{"property":"attr","name":"status_id","old_value":"1","new_value":"5"}]},
+				{"id":3,"created_on":"2025-03-03T10:00:00Z","details":[{"property":"attr","name":"status_id","old_value":"5","new_value":"2"}]},
+				{"id":4,"created_on":"2025-03-04T10:00:00Z","details":[{"property":"attr","name":"status_id","old_value":"2","new_value":"5"}]}
+			]}}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	c := NewClient(server.URL, "key")
+	closed := map[int]bool{5: true}
+
+	params := BurndownParams{ProjectID: 3, VersionID: 10, From: "2025-03-01", To: "2025-03-05"}
+	b, err := BuildBurndown(c, params, closed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	remaining := func(b *Burndown) []float64 {
+		var r []float64
+		for _, p := range b.Points {
+			r = append(r, p.Remaining)
+		}
+		return r
+	}
+	// closed_on only knows #3's last closing
+	if got := fmt.Sprint(remaining(b)); got != "[3 2 3 2 2]" {
+		t.Errorf("unexpected remaining issues: %s", got)
+	}
+	if b.Points[0].Ideal != 3 || b.Points[2].Ideal != 1.5 || b.Points[4].Ideal != 0 {
+		t.Errorf("unexpected ideal line: %+v", b.Points)
+	}
+	if journalCalls.Load() != 0 {
+		t.Errorf("expected no journal requests without precise")
+	}
+
+	params.Precise = true
+	b, err = BuildBurndown(c, params, closed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fmt.Sprint(remaining(b)); got != "[3 1 3 2 2]" {
+		t.Errorf("expected #3's reopening to be replayed, got %s", got)
+	}
+	if b.JournalsChecked != 2 || journalCalls.Load() != 2 {
+		t.Errorf("expected the journals of the 2 closed issues, got %d checked and %d requests", b.JournalsChecked, journalCalls.Load())
+	}
+
+	params.Precise = false
+	params.Metric = BurndownEstimatedHours
+	b, _ = BuildBurndown(c, params, closed)
+	if got := fmt.Sprint(remaining(b)); got != "[8 5 7 7 7]" {
+		t.Errorf("unexpected remaining hours: %s", got)
+	}
+
+	for _, bad := range []BurndownParams{
+		{From: "2025-03-05", To: "2025-03-01"},
+		{From: "2024-01-01", To: "2025-03-01"},
+		{From: "2025-03-01", To: "2025-03-05", Metric: "points"},
+	} {
+		if _, err := BuildBurndown(c, bad, closed); err == nil {
+			t.Errorf("expected an error for %+v", bad)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package redmine
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultCacheTTL is how long the Resolver keeps reference data (trackers,
+// statuses, priorities, ...) before fetching it again
+const DefaultCacheTTL = time.Hour
+
+// cachedList memoizes a list fetched from Redmine for a TTL. It is safe for
+// concurrent use; concurrent misses share a single upstream request.
+type cachedList[T any] struct {
+	mu        sync.RWMutex
+	items     []T
+	fetchedAt time.Time
+	loaded    bool
+
+	group singleflight.Group
+}
+
+// get returns the cached list, calling fetch when it is missing, older than
+// ttl, or force is set. Fetch errors are not cached.
+func (c *cachedList[T]) get(ttl time.Duration, force bool, fetch func() ([]T, error)) ([]T, error) {
+	if !force {
+		c.mu.RLock()
+		items, fresh := c.items, c.loaded && time.Since(c.fetchedAt) < ttl
+		c.mu.RUnlock()
+		if fresh {
+			return items, nil
+		}
+	}
+
+	v, err, _ := c.group.Do("", func() (any, error) {
+		items, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.items, c.fetchedAt, c.loaded = items, time.Now(), true
+		c.mu.Unlock()
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]T), nil
+}
+
+// peek returns the cached list without fetching, even if it has expired
+func (c *cachedList[T]) peek() []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.items
+}
+
+// projectCache keeps one cachedList per project, for reference data Redmine
+// scopes to a project such as versions and issue categories
+type projectCache[T any] struct {
+	mu    sync.Mutex
+	lists map[int]*cachedList[T]
+}
+
+// get returns the project's list, fetching it as cachedList.get does
+func (c *projectCache[T]) get(projectID int, ttl time.Duration, force bool, fetch func(int) ([]T, error)) ([]T, error) {
+	c.mu.Lock()
+	if c.lists == nil {
+		c.lists = make(map[int]*cachedList[T])
+	}
+	list, ok := c.lists[projectID]
+	if !ok {
+		list = &cachedList[T]{}
+		c.lists[projectID] = list
+	}
+	c.mu.Unlock()
+
+	return list.get(ttl, force, func() ([]T, error) { return fetch(projectID) })
+}
@@ -0,0 +1,133 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCountingServer serves /trackers.json and /issue_statuses.json, counting
+// upstream requests. Responses are delayed so concurrent callers overlap.
+func newCountingServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"},{"id":2,"name":"Feature"}]}`))
+	})
+	mux.HandleFunc("GET /issue_statuses.json", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New","is_closed":false}]}`))
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts, &calls
+}
+
+func TestResolver_ConcurrentMissesShareOneRequest(t *testing.T) {
+	ts, calls := newCountingServer(t)
+	resolver := NewResolver(NewClient(ts.URL, "test-key"))
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			trackers, err := resolver.GetTrackers()
+			if err != nil || len(trackers) != 2 {
+				t.Errorf("unexpected result: %v, %v", trackers, err)
+			}
+			if _, err := resolver.ResolveTracker("bug"); err != nil {
+				t.Errorf("ResolveTracker: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 upstream request, got %d", got)
+	}
+}
+
+func TestResolver_HammerCachedGetters(t *testing.T) {
+	ts, calls := newCountingServer(t)
+	resolver := NewResolver(NewClient(ts.URL, "test-key"))
+
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch i % 4 {
+			case 0:
+				_, _ = resolver.GetTrackers()
+			case 1:
+				_, _ = resolver.GetStatuses()
+			case 2:
+				_, _ = resolver.ResolveStatus("new")
+			case 3:
+				_, _ = resolver.RefreshTrackers()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Refreshes may each trigger a request, but never more than one per caller
+	if got := calls.Load(); got < 2 || got > 27 {
+		t.Errorf("unexpected upstream request count %d", got)
+	}
+}
+
+func TestResolver_CacheTTLAndForceRefresh(t *testing.T) {
+	ts, calls := newCountingServer(t)
+	resolver := NewResolver(NewClient(ts.URL, "test-key"))
+
+	_, _ = resolver.GetStatuses()
+	_, _ = resolver.GetStatuses()
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected cached second call, got %d requests", got)
+	}
+
+	if _, err := resolver.RefreshStatuses(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected refresh to bypass the cache, got %d requests", got)
+	}
+
+	resolver.SetCacheTTL(time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	_, _ = resolver.GetStatuses()
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected expired entry to be refetched, got %d requests", got)
+	}
+}
+
+func TestResolver_FetchErrorsAreNotCached(t *testing.T) {
+	fail := true
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /roles.json", func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"roles":[{"id":3,"name":"Manager"}]}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	resolver := NewResolver(NewClient(ts.URL, "test-key"))
+
+	if _, err := resolver.GetRoles(); err == nil {
+		t.Fatal("expected error")
+	}
+	fail = false
+	roles, err := resolver.GetRoles()
+	if err != nil || len(roles) != 1 {
+		t.Errorf("expected roles after recovery, got %v, %v", roles, err)
+	}
+}
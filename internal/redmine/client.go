@@ -2,14 +2,16 @@ package redmine
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 )
 
 // Client is a Redmine API client
@@ -17,17 +19,38 @@ type Client struct {
 	baseURL    string
 	apiKey     string
 	httpClient *http.Client
+	retry      RetryPolicy
+	limiter    *RateLimiter
+	ctx        context.Context
+	switchUser string // login sent as X-Redmine-Switch-User; empty acts as the key's owner
+	username   string // basic auth user, sent instead of apiKey when set
+	password   string
 }
 
 // NewClient creates a new Redmine client
-func NewClient(baseURL, apiKey string) *Client {
-	return &Client{
+func NewClient(baseURL, apiKey string, opts ...ClientOption) *Client {
+	c := &Client{
 		baseURL: strings.TrimSuffix(baseURL, "/"),
 		apiKey:  apiKey,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
+		retry:   DefaultRetryPolicy(),
+		limiter: defaultRateLimiter(),
+		ctx:     context.Background(),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithContext returns a copy of the client whose requests are bound to ctx,
+// so cancelling ctx aborts in-flight requests and rate limit waits
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.ctx = ctx
+	return &clone
 }
 
 // BaseURL returns the Redmine base URL
@@ -35,41 +58,29 @@ func (c *Client) BaseURL() string {
 	return c.baseURL
 }
 
+// HasCredentials reports whether the client was created with an API key or
+// a basic auth username
+func (c *Client) HasCredentials() bool {
+	return c.apiKey != "" || c.username != ""
+}
+
 // doRequest performs an HTTP request to the Redmine API
 func (c *Client) doRequest(method, path string, body any) ([]byte, error) {
-	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(jsonBody)
-	}
-
-	req, err := http.NewRequest(method, c.baseURL+path, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("X-Redmine-API-Key", c.apiKey)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
-	}
-
-	return respBody, nil
+	return c.send(method, path, func() io.Reader {
+		if jsonBody == nil {
+			return nil
+		}
+		return bytes.NewReader(jsonBody)
+	}, "application/json")
 }
 
 // User represents a Redmine user
@@ -127,29 +138,31 @@ type ProjectsResponse struct {
 	Limit      int       `json:"limit"`
 }
 
-// ListProjects returns all projects with pagination support
-func (c *Client) ListProjects(limit int) ([]Project, error) {
+// ListProjects returns up to limit projects, fetching them page by page, and
+// the total number of projects
+func (c *Client) ListProjects(limit int) ([]Project, int, error) {
 	if limit <= 0 {
 		limit = 100
 	}
 
 	var allProjects []Project
-	offset := 0
+	offset, total := 0, 0
 	batchSize := 100 // Redmine typically limits to 100 per request
 
 	for {
 		path := fmt.Sprintf("/projects.json?limit=%d&offset=%d", batchSize, offset)
 		data, err := c.doRequest("GET", path, nil)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
 		var resp ProjectsResponse
 		if err := json.Unmarshal(data, &resp); err != nil {
-			return nil, fmt.Errorf("failed to parse response: %w", err)
+			return nil, 0, fmt.Errorf("failed to parse response: %w", err)
 		}
 
 		allProjects = append(allProjects, resp.Projects...)
+		total = resp.TotalCount
 
 		// Check if we've fetched all projects or reached the requested limit
 		// Use total_count for accurate pagination detection
@@ -165,7 +178,7 @@ func (c *Client) ListProjects(limit int) ([]Project, error) {
 		allProjects = allProjects[:limit]
 	}
 
-	return allProjects, nil
+	return allProjects, total, nil
 }
 
 // CreateProjectRequest is the request body for creating a project
@@ -205,8 +218,9 @@ func (c *Client) CreateProject(name, identifier, description string, parentID in
 
 // Tracker represents a Redmine tracker
 type Tracker struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	DefaultStatus *IDName `json:"default_status,omitempty"`
 }
 
 // ListTrackers returns all trackers
@@ -306,6 +320,31 @@ type Role struct {
 	Name string `json:"name"`
 }
 
+// RoleDetail is a role with the permissions it grants, as GET
+// /roles/{id}.json returns it
+type RoleDetail struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// GetRole returns a role with its permissions
+func (c *Client) GetRole(roleID int) (*RoleDetail, error) {
+	data, err := c.doRequest("GET", fmt.Sprintf("/roles/%d.json", roleID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Role RoleDetail `json:"role"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Role, nil
+}
+
 // ListRoles returns all roles
 func (c *Client) ListRoles() ([]Role, error) {
 	data, err := c.doRequest("GET", "/roles.json", nil)
@@ -368,6 +407,7 @@ type ProjectDetail struct {
 	} `json:"parent,omitempty"`
 	Trackers          []IDName `json:"trackers,omitempty"`
 	IssueCustomFields []IDName `json:"issue_custom_fields,omitempty"`
+	EnabledModules    []IDName `json:"enabled_modules,omitempty"`
 }
 
 // UpdateProjectParams are parameters for updating a project
@@ -390,7 +430,7 @@ type Attachment struct {
 	Author      IDName `json:"author"`
 }
 
-// UploadToken represents an uploaded file token for attaching to issues
+// UploadToken represents an uploaded file token for attaching to issues or wiki pages
 type UploadToken struct {
 	Token       string `json:"token"`
 	Filename    string `json:"filename"`
@@ -398,25 +438,47 @@ type UploadToken struct {
 	Description string `json:"description,omitempty"`
 }
 
+// uploadsPayload converts upload tokens to the "uploads" request field
+func uploadsPayload(tokens []UploadToken) []map[string]any {
+	uploads := make([]map[string]any, len(tokens))
+	for i, u := range tokens {
+		upload := map[string]any{
+			"token":    u.Token,
+			"filename": u.Filename,
+		}
+		if u.ContentType != "" {
+			upload["content_type"] = u.ContentType
+		}
+		if u.Description != "" {
+			upload["description"] = u.Description
+		}
+		uploads[i] = upload
+	}
+	return uploads
+}
+
 // Issue represents a Redmine issue
 type Issue struct {
-	ID           int     `json:"id"`
-	Project      IDName  `json:"project"`
-	Tracker      IDName  `json:"tracker"`
-	Status       IDName  `json:"status"`
-	Priority     IDName  `json:"priority"`
-	Author       IDName  `json:"author"`
-	AssignedTo   *IDName `json:"assigned_to,omitempty"`
-	FixedVersion *IDName `json:"fixed_version,omitempty"`
-	Subject      string  `json:"subject"`
-	Description  string  `json:"description"`
-	StartDate    string  `json:"start_date,omitempty"`
-	DueDate      string  `json:"due_date,omitempty"`
-	DoneRatio    int     `json:"done_ratio"`
-	CreatedOn    string  `json:"created_on"`
-	UpdatedOn    string  `json:"updated_on"`
-	ClosedOn     string  `json:"closed_on,omitempty"`
-	Parent       *struct {
+	ID             int      `json:"id"`
+	Project        IDName   `json:"project"`
+	Tracker        IDName   `json:"tracker"`
+	Status         IDName   `json:"status"`
+	Priority       IDName   `json:"priority"`
+	Author         IDName   `json:"author"`
+	AssignedTo     *IDName  `json:"assigned_to,omitempty"`
+	FixedVersion   *IDName  `json:"fixed_version,omitempty"`
+	Category       *IDName  `json:"category,omitempty"`
+	Subject        string   `json:"subject"`
+	Description    string   `json:"description"`
+	StartDate      string   `json:"start_date,omitempty"`
+	DueDate        string   `json:"due_date,omitempty"`
+	DoneRatio      int      `json:"done_ratio"`
+	EstimatedHours *float64 `json:"estimated_hours,omitempty"`
+	SpentHours     *float64 `json:"spent_hours,omitempty"`
+	CreatedOn      string   `json:"created_on"`
+	UpdatedOn      string   `json:"updated_on"`
+	ClosedOn       string   `json:"closed_on,omitempty"`
+	Parent         *struct {
 		ID int `json:"id"`
 	} `json:"parent,omitempty"`
 	CustomFields    []CustomField `json:"custom_fields,omitempty"`
@@ -435,11 +497,12 @@ type IDName struct {
 
 // Journal represents an issue journal entry (comment/change)
 type Journal struct {
-	ID        int      `json:"id"`
-	User      IDName   `json:"user"`
-	Notes     string   `json:"notes"`
-	CreatedOn string   `json:"created_on"`
-	Details   []Detail `json:"details,omitempty"`
+	ID           int      `json:"id"`
+	User         IDName   `json:"user"`
+	Notes        string   `json:"notes"`
+	PrivateNotes bool     `json:"private_notes"`
+	CreatedOn    string   `json:"created_on"`
+	Details      []Detail `json:"details,omitempty"`
 }
 
 // Detail represents a journal detail (field change)
@@ -456,7 +519,7 @@ type Relation struct {
 	IssueID      int    `json:"issue_id"`
 	IssueToID    int    `json:"issue_to_id"`
 	RelationType string `json:"relation_type"`
-	Delay        int    `json:"delay,omitempty"`
+	Delay        *int   `json:"delay,omitempty"` // days between precedes/follows issues; nil for other types
 }
 
 // IssuesResponse is the response from /issues.json
@@ -469,23 +532,47 @@ type IssuesResponse struct {
 
 // SearchIssuesParams are parameters for searching issues
 type SearchIssuesParams struct {
-	ProjectID    string
-	TrackerID    int
-	StatusID     string // "open", "closed", "*", or specific status ID
-	AssignedToID string // "me" or user ID
-	VersionID    string // Version/milestone ID or name
-	Subject      string // Search keyword for subject (partial match)
+	ProjectID         string
+	TrackerID         int
+	StatusID          string // "open", "closed", "*", or specific status ID
+	AssignedToID      string // "me" or user ID
+	WatcherID         string // "me" or user ID: only issues this user watches
+	AuthorID          string // "me" or user ID
+	VersionID         string // Version/milestone ID or name
+	Subject           string // Search keyword for subject (partial match)
 	ParentID          int
-	CreatedOn         string // Redmine date filter, e.g., ">=2024-01-01"
-	UpdatedOn         string // Redmine date filter, e.g., ">=2024-01-01"
-	Sort              string // Sort order, e.g., "updated_on:desc"
-	CustomFieldFilter map[string]string // cf_ID -> value
+	IssueIDs          []int                        // Restrict to these issue IDs
+	CreatedOn         string                       // Redmine date filter, e.g., ">=2024-01-01"
+	UpdatedOn         string                       // Redmine date filter, e.g., ">=2024-01-01"
+	DueDate           string                       // Redmine date filter, e.g., "<=2024-01-01"
+	Include           string                       // Comma-separated associations, e.g., "relations"
+	Sort              string                       // Sort order, e.g., "updated_on:desc"
+	QueryID           int                          // Saved query; Redmine then ignores the other filters
+	CustomFieldFilter map[string]CustomFieldFilter // cf_ID -> filter
 	Limit             int
 	Offset            int
 }
 
-// SearchIssues searches for issues
-func (c *Client) SearchIssues(params SearchIssuesParams) ([]Issue, int, error) {
+// CustomFieldFilter matches issues on a custom field value
+type CustomFieldFilter struct {
+	// Operator is "=" (any of Values, the default), "!" (none of Values),
+	// "~" (contains) or "!~" (doesn't contain)
+	Operator string
+	Values   []string
+}
+
+// queryValue renders the filter in Redmine's short filter syntax, e.g. "=a|b" or "!~text".
+// The operator is always explicit so values starting with "!" or "~" aren't misread.
+func (f CustomFieldFilter) queryValue() string {
+	op := f.Operator
+	if op == "" {
+		op = "="
+	}
+	return op + strings.Join(f.Values, "|")
+}
+
+// Values returns the query parameters SearchIssues sends for params
+func (params SearchIssuesParams) Values() url.Values {
 	query := url.Values{}
 
 	if params.ProjectID != "" {
@@ -500,6 +587,12 @@ func (c *Client) SearchIssues(params SearchIssuesParams) ([]Issue, int, error) {
 	if params.AssignedToID != "" {
 		query.Set("assigned_to_id", params.AssignedToID)
 	}
+	if params.WatcherID != "" {
+		query.Set("watcher_id", params.WatcherID)
+	}
+	if params.AuthorID != "" {
+		query.Set("author_id", params.AuthorID)
+	}
 	if params.VersionID != "" {
 		query.Set("fixed_version_id", params.VersionID)
 	}
@@ -510,17 +603,33 @@ func (c *Client) SearchIssues(params SearchIssuesParams) ([]Issue, int, error) {
 	if params.ParentID > 0 {
 		query.Set("parent_id", strconv.Itoa(params.ParentID))
 	}
+	if len(params.IssueIDs) > 0 {
+		ids := make([]string, len(params.IssueIDs))
+		for i, id := range params.IssueIDs {
+			ids[i] = strconv.Itoa(id)
+		}
+		query.Set("issue_id", strings.Join(ids, ","))
+	}
 	if params.CreatedOn != "" {
 		query.Set("created_on", params.CreatedOn)
 	}
 	if params.UpdatedOn != "" {
 		query.Set("updated_on", params.UpdatedOn)
 	}
+	if params.DueDate != "" {
+		query.Set("due_date", params.DueDate)
+	}
+	if params.Include != "" {
+		query.Set("include", params.Include)
+	}
 	if params.Sort != "" {
 		query.Set("sort", params.Sort)
 	}
-	for cfID, value := range params.CustomFieldFilter {
-		query.Set("cf_"+cfID, value)
+	if params.QueryID > 0 {
+		query.Set("query_id", strconv.Itoa(params.QueryID))
+	}
+	for cfID, filter := range params.CustomFieldFilter {
+		query.Set("cf_"+cfID, filter.queryValue())
 	}
 	if params.Limit > 0 {
 		query.Set("limit", strconv.Itoa(params.Limit))
@@ -530,8 +639,12 @@ func (c *Client) SearchIssues(params SearchIssuesParams) ([]Issue, int, error) {
 	if params.Offset > 0 {
 		query.Set("offset", strconv.Itoa(params.Offset))
 	}
+	return query
+}
 
-	path := "/issues.json?" + query.Encode()
+// SearchIssues searches for issues
+func (c *Client) SearchIssues(params SearchIssuesParams) ([]Issue, int, error) {
+	path := "/issues.json?" + params.Values().Encode()
 	data, err := c.doRequest("GET", path, nil)
 	if err != nil {
 		return nil, 0, err
@@ -545,6 +658,49 @@ func (c *Client) SearchIssues(params SearchIssuesParams) ([]Issue, int, error) {
 	return resp.Issues, resp.TotalCount, nil
 }
 
+// MaxSearchIssuesLimit is the safety ceiling for SearchIssuesAll so a runaway
+// query cannot fetch tens of thousands of issues
+const MaxSearchIssuesLimit = 1000
+
+// SearchIssuesAll searches for issues, transparently paginating when params.Limit
+// exceeds the Redmine per-request cap of 100. The limit is capped at MaxSearchIssuesLimit.
+func (c *Client) SearchIssuesAll(params SearchIssuesParams) ([]Issue, int, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 25
+	}
+	if limit > MaxSearchIssuesLimit {
+		limit = MaxSearchIssuesLimit
+	}
+
+	var allIssues []Issue
+	total := 0
+	batchSize := 100 // Redmine typically limits to 100 per request
+	pageParams := params
+
+	for len(allIssues) < limit {
+		pageParams.Limit = min(limit-len(allIssues), batchSize)
+		pageParams.Offset = params.Offset + len(allIssues)
+
+		issues, pageTotal, err := c.SearchIssues(pageParams)
+		if err != nil {
+			return nil, 0, err
+		}
+		total = pageTotal
+		allIssues = append(allIssues, issues...)
+
+		if len(issues) == 0 || params.Offset+len(allIssues) >= total {
+			break
+		}
+	}
+
+	if len(allIssues) > limit {
+		allIssues = allIssues[:limit]
+	}
+
+	return allIssues, total, nil
+}
+
 // GetIssue returns an issue by ID with optional includes
 func (c *Client) GetIssue(issueID int) (*Issue, error) {
 	path := fmt.Sprintf("/issues/%d.json?include=journals,watchers,relations,allowed_statuses,attachments", issueID)
@@ -565,61 +721,77 @@ func (c *Client) GetIssue(issueID int) (*Issue, error) {
 
 // CreateIssueParams are parameters for creating an issue
 type CreateIssueParams struct {
-	ProjectID     int
-	TrackerID     int
-	Subject       string
-	Description   string
-	StatusID      int
-	PriorityID    int
-	AssignedToID  int
-	ParentIssueID int
-	StartDate     string
-	DueDate       string
-	IsPrivate     *bool
-	CustomFields  map[string]any
-	Uploads       []UploadToken
-}
-
-// CreateIssue creates a new issue
-func (c *Client) CreateIssue(params CreateIssueParams) (*Issue, error) {
+	ProjectID      int
+	TrackerID      int
+	Subject        string
+	Description    string
+	StatusID       int
+	PriorityID     int
+	AssignedToID   int
+	ParentIssueID  int
+	CategoryID     int
+	FixedVersionID int
+	StartDate      string
+	DueDate        string
+	DoneRatio      *int // nil = Redmine's default
+	IsPrivate      *bool
+	EstimatedHours *float64
+	CustomFields   map[string]any
+	Uploads        []UploadToken
+}
+
+// Payload returns the request body CreateIssue sends to Redmine
+func (p CreateIssueParams) Payload() map[string]any {
 	reqBody := map[string]any{
 		"issue": map[string]any{
-			"project_id": params.ProjectID,
-			"tracker_id": params.TrackerID,
-			"subject":    params.Subject,
+			"project_id": p.ProjectID,
+			"tracker_id": p.TrackerID,
+			"subject":    p.Subject,
 		},
 	}
 
 	issueData := reqBody["issue"].(map[string]any)
 
-	if params.Description != "" {
-		issueData["description"] = params.Description
+	if p.Description != "" {
+		issueData["description"] = p.Description
 	}
-	if params.StatusID > 0 {
-		issueData["status_id"] = params.StatusID
+	if p.StatusID > 0 {
+		issueData["status_id"] = p.StatusID
 	}
-	if params.PriorityID > 0 {
-		issueData["priority_id"] = params.PriorityID
+	if p.PriorityID > 0 {
+		issueData["priority_id"] = p.PriorityID
 	}
-	if params.AssignedToID > 0 {
-		issueData["assigned_to_id"] = params.AssignedToID
+	if p.AssignedToID > 0 {
+		issueData["assigned_to_id"] = p.AssignedToID
 	}
-	if params.ParentIssueID > 0 {
-		issueData["parent_issue_id"] = params.ParentIssueID
+	if p.ParentIssueID > 0 {
+		issueData["parent_issue_id"] = p.ParentIssueID
 	}
-	if params.StartDate != "" {
-		issueData["start_date"] = params.StartDate
+	if p.CategoryID > 0 {
+		issueData["category_id"] = p.CategoryID
 	}
-	if params.DueDate != "" {
-		issueData["due_date"] = params.DueDate
+	if p.FixedVersionID > 0 {
+		issueData["fixed_version_id"] = p.FixedVersionID
+	}
+	if p.StartDate != "" {
+		issueData["start_date"] = p.StartDate
+	}
+	if p.DueDate != "" {
+		issueData["due_date"] = p.DueDate
+	}
+	if p.DoneRatio != nil {
+		issueData["done_ratio"] = *p.DoneRatio
+	}
+	if p.IsPrivate != nil {
+		issueData["is_private"] = *p.IsPrivate
 	}
-	if params.IsPrivate != nil {
-		issueData["is_private"] = *params.IsPrivate
+	if p.EstimatedHours != nil {
+		issueData["estimated_hours"] = *p.EstimatedHours
 	}
 
-	if len(params.CustomFields) > 0 {
+	if len(p.CustomFields) > 0 {
 		customFields := make([]map[string]any, 0)
-		for id, value := range params.CustomFields {
+		for id, value := range p.CustomFields {
 			cfID, _ := strconv.Atoi(id)
 			customFields = append(customFields, map[string]any{
 				"id":    cfID,
@@ -629,25 +801,34 @@ func (c *Client) CreateIssue(params CreateIssueParams) (*Issue, error) {
 		issueData["custom_fields"] = customFields
 	}
 
-	if len(params.Uploads) > 0 {
-		uploads := make([]map[string]any, len(params.Uploads))
-		for i, u := range params.Uploads {
-			upload := map[string]any{
-				"token":    u.Token,
-				"filename": u.Filename,
-			}
-			if u.ContentType != "" {
-				upload["content_type"] = u.ContentType
-			}
-			if u.Description != "" {
-				upload["description"] = u.Description
-			}
-			uploads[i] = upload
-		}
-		issueData["uploads"] = uploads
+	if len(p.Uploads) > 0 {
+		issueData["uploads"] = uploadsPayload(p.Uploads)
 	}
 
-	data, err := c.doRequest("POST", "/issues.json", reqBody)
+	return reqBody
+}
+
+// CreateWarning describes requested values Redmine silently replaced when
+// creating issue: it drops an initial status the workflow doesn't allow, and
+// a done ratio when progress is derived from the status. It returns "" when
+// the issue came back as requested.
+func (p CreateIssueParams) CreateWarning(issue *Issue) string {
+	var ignored []string
+	if p.StatusID > 0 && issue.Status.ID != p.StatusID {
+		ignored = append(ignored, fmt.Sprintf("status (created as %s; the workflow may not allow the requested status for new issues, update the issue to change it)", issue.Status.Name))
+	}
+	if p.DoneRatio != nil && issue.DoneRatio != *p.DoneRatio {
+		ignored = append(ignored, fmt.Sprintf("done_ratio (created with %d%%; Redmine may derive progress from the status)", issue.DoneRatio))
+	}
+	if len(ignored) == 0 {
+		return ""
+	}
+	return "Redmine ignored " + strings.Join(ignored, " and ")
+}
+
+// CreateIssue creates a new issue
+func (c *Client) CreateIssue(params CreateIssueParams) (*Issue, error) {
+	data, err := c.doRequest("POST", "/issues.json", params.Payload())
 	if err != nil {
 		return nil, err
 	}
@@ -674,53 +855,145 @@ type UpdateIssueParams struct {
 	StartDate    string
 	DueDate      string
 	DoneRatio    *int // nil = don't change, 0-100 = set value
-	IsPrivate    *bool
-	Notes        string
-	CustomFields map[string]any
-	Uploads      []UploadToken
+
+	CategoryID     int
+	FixedVersionID int
+	IsPrivate      *bool
+	Notes          string
+	CustomFields   map[string]any
+	Uploads        []UploadToken
+
+	EstimatedHours *float64 // nil = don't change
+	PrivateNotes   *bool    // Mark Notes as private (visible only to users allowed to see private notes)
+
+	// Clear flags send an empty value so Redmine blanks the field.
+	// Setting a clear flag together with its value field is an error.
+	ClearDescription    bool
+	ClearAssignee       bool
+	ClearDueDate        bool
+	ClearEstimatedHours bool
+	ClearCategory       bool
+	ClearFixedVersion   bool
+}
+
+// NotePreviewLength is how much of a note NotePreview keeps
+const NotePreviewLength = 200
+
+// NotePreview returns the first NotePreviewLength characters of a note, for
+// echoing a comment back without repeating all of it
+func NotePreview(notes string) string {
+	runes := []rune(notes)
+	if len(runes) <= NotePreviewLength {
+		return notes
+	}
+	return string(runes[:NotePreviewLength]) + "..."
 }
 
 // UpdateIssue updates an existing issue
 func (c *Client) UpdateIssue(params UpdateIssueParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/issues/%d.json", params.IssueID)
+	_, err := c.doRequest("PUT", path, params.Payload())
+	return err
+}
+
+// Validate rejects a field that is both set and cleared
+func (p UpdateIssueParams) Validate() error {
+	if p.ClearAssignee && p.AssignedToID > 0 {
+		return fmt.Errorf("cannot both set and clear the assignee")
+	}
+	if p.ClearDueDate && p.DueDate != "" {
+		return fmt.Errorf("cannot both set and clear the due date")
+	}
+	if p.ClearDescription && p.Description != "" {
+		return fmt.Errorf("cannot both set and clear the description")
+	}
+	if p.ClearEstimatedHours && p.EstimatedHours != nil {
+		return fmt.Errorf("cannot both set and clear the estimated hours")
+	}
+	if p.ClearCategory && p.CategoryID > 0 {
+		return fmt.Errorf("cannot both set and clear the category")
+	}
+	if p.ClearFixedVersion && p.FixedVersionID > 0 {
+		return fmt.Errorf("cannot both set and clear the target version")
+	}
+	return nil
+}
+
+// Payload returns the request body UpdateIssue sends to Redmine
+func (p UpdateIssueParams) Payload() map[string]any {
 	issueData := make(map[string]any)
 
-	if params.Subject != "" {
-		issueData["subject"] = params.Subject
+	if p.Subject != "" {
+		issueData["subject"] = p.Subject
 	}
-	if params.Description != "" {
-		issueData["description"] = params.Description
+	if p.Description != "" {
+		issueData["description"] = p.Description
 	}
-	if params.StatusID > 0 {
-		issueData["status_id"] = params.StatusID
+	if p.StatusID > 0 {
+		issueData["status_id"] = p.StatusID
 	}
-	if params.PriorityID > 0 {
-		issueData["priority_id"] = params.PriorityID
+	if p.PriorityID > 0 {
+		issueData["priority_id"] = p.PriorityID
 	}
-	if params.TrackerID > 0 {
-		issueData["tracker_id"] = params.TrackerID
+	if p.TrackerID > 0 {
+		issueData["tracker_id"] = p.TrackerID
 	}
-	if params.AssignedToID > 0 {
-		issueData["assigned_to_id"] = params.AssignedToID
+	if p.AssignedToID > 0 {
+		issueData["assigned_to_id"] = p.AssignedToID
 	}
-	if params.StartDate != "" {
-		issueData["start_date"] = params.StartDate
+	if p.CategoryID > 0 {
+		issueData["category_id"] = p.CategoryID
 	}
-	if params.DueDate != "" {
-		issueData["due_date"] = params.DueDate
+	if p.FixedVersionID > 0 {
+		issueData["fixed_version_id"] = p.FixedVersionID
 	}
-	if params.DoneRatio != nil {
-		issueData["done_ratio"] = *params.DoneRatio
+	if p.StartDate != "" {
+		issueData["start_date"] = p.StartDate
 	}
-	if params.IsPrivate != nil {
-		issueData["is_private"] = *params.IsPrivate
+	if p.DueDate != "" {
+		issueData["due_date"] = p.DueDate
 	}
-	if params.Notes != "" {
-		issueData["notes"] = params.Notes
+	if p.DoneRatio != nil {
+		issueData["done_ratio"] = *p.DoneRatio
+	}
+	if p.IsPrivate != nil {
+		issueData["is_private"] = *p.IsPrivate
+	}
+	if p.EstimatedHours != nil {
+		issueData["estimated_hours"] = *p.EstimatedHours
+	}
+	if p.Notes != "" {
+		issueData["notes"] = p.Notes
+	}
+	if p.PrivateNotes != nil {
+		issueData["private_notes"] = *p.PrivateNotes
+	}
+	if p.ClearDescription {
+		issueData["description"] = ""
+	}
+	if p.ClearAssignee {
+		issueData["assigned_to_id"] = ""
+	}
+	if p.ClearDueDate {
+		issueData["due_date"] = ""
+	}
+	if p.ClearEstimatedHours {
+		issueData["estimated_hours"] = ""
+	}
+	if p.ClearCategory {
+		issueData["category_id"] = ""
+	}
+	if p.ClearFixedVersion {
+		issueData["fixed_version_id"] = ""
 	}
 
-	if len(params.CustomFields) > 0 {
+	if len(p.CustomFields) > 0 {
 		customFields := make([]map[string]any, 0)
-		for id, value := range params.CustomFields {
+		for id, value := range p.CustomFields {
 			cfID, _ := strconv.Atoi(id)
 			customFields = append(customFields, map[string]any{
 				"id":    cfID,
@@ -730,31 +1003,13 @@ func (c *Client) UpdateIssue(params UpdateIssueParams) error {
 		issueData["custom_fields"] = customFields
 	}
 
-	if len(params.Uploads) > 0 {
-		uploads := make([]map[string]any, len(params.Uploads))
-		for i, u := range params.Uploads {
-			upload := map[string]any{
-				"token":    u.Token,
-				"filename": u.Filename,
-			}
-			if u.ContentType != "" {
-				upload["content_type"] = u.ContentType
-			}
-			if u.Description != "" {
-				upload["description"] = u.Description
-			}
-			uploads[i] = upload
-		}
-		issueData["uploads"] = uploads
+	if len(p.Uploads) > 0 {
+		issueData["uploads"] = uploadsPayload(p.Uploads)
 	}
 
-	reqBody := map[string]any{
+	return map[string]any{
 		"issue": issueData,
 	}
-
-	path := fmt.Sprintf("/issues/%d.json", params.IssueID)
-	_, err := c.doRequest("PUT", path, reqBody)
-	return err
 }
 
 // AddWatcher adds a watcher to an issue
@@ -768,14 +1023,17 @@ func (c *Client) AddWatcher(issueID, userID int) error {
 	return err
 }
 
-// CreateRelation creates a relation between issues
-func (c *Client) CreateRelation(issueID, issueToID int, relationType string) (*Relation, error) {
-	reqBody := map[string]any{
-		"relation": map[string]any{
-			"issue_to_id":   issueToID,
-			"relation_type": relationType,
-		},
+// CreateRelation creates a relation between issues. delay, the days between
+// precedes/follows issues, is left to Redmine when nil.
+func (c *Client) CreateRelation(issueID, issueToID int, relationType string, delay *int) (*Relation, error) {
+	relation := map[string]any{
+		"issue_to_id":   issueToID,
+		"relation_type": relationType,
+	}
+	if delay != nil {
+		relation["delay"] = *delay
 	}
+	reqBody := map[string]any{"relation": relation}
 
 	path := fmt.Sprintf("/issues/%d/relations.json", issueID)
 	data, err := c.doRequest("POST", path, reqBody)
@@ -814,6 +1072,7 @@ func (c *Client) GetRelations(issueID int) ([]Relation, error) {
 // CreateTimeEntryParams are parameters for creating a time entry
 type CreateTimeEntryParams struct {
 	IssueID    int
+	ProjectID  int // logs time on the project itself when IssueID is 0
 	Hours      float64
 	ActivityID int
 	Comments   string
@@ -839,6 +1098,7 @@ type ListTimeEntriesParams struct {
 	ProjectID string
 	UserID    string
 	IssueID   int
+	VersionID int    // Only entries on issues of this version
 	From      string // YYYY-MM-DD
 	To        string // YYYY-MM-DD
 	Limit     int
@@ -857,13 +1117,18 @@ type TimeEntriesResponse struct {
 func (c *Client) CreateTimeEntry(params CreateTimeEntryParams) (*TimeEntry, error) {
 	reqBody := map[string]any{
 		"time_entry": map[string]any{
-			"issue_id": params.IssueID,
-			"hours":    params.Hours,
+			"hours": params.Hours,
 		},
 	}
 
 	timeEntryData := reqBody["time_entry"].(map[string]any)
 
+	if params.IssueID > 0 {
+		timeEntryData["issue_id"] = params.IssueID
+	} else {
+		timeEntryData["project_id"] = params.ProjectID
+	}
+
 	if params.ActivityID > 0 {
 		timeEntryData["activity_id"] = params.ActivityID
 	}
@@ -902,6 +1167,9 @@ func (c *Client) ListTimeEntries(params ListTimeEntriesParams) ([]TimeEntry, int
 	if params.IssueID > 0 {
 		query.Set("issue_id", strconv.Itoa(params.IssueID))
 	}
+	if params.VersionID > 0 {
+		query.Set("issue.fixed_version_id", strconv.Itoa(params.VersionID))
+	}
 	if params.From != "" {
 		query.Set("from", params.From)
 	}
@@ -938,26 +1206,35 @@ func (c *Client) ListTimeEntries(params ListTimeEntriesParams) ([]TimeEntry, int
 
 // ProjectMembership represents a project membership
 type ProjectMembership struct {
-	ID      int     `json:"id"`
-	Project IDName  `json:"project"`
-	User    *IDName `json:"user,omitempty"`
-	Group   *IDName `json:"group,omitempty"`
-	Roles   []struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-	} `json:"roles"`
+	ID      int              `json:"id"`
+	Project IDName           `json:"project"`
+	User    *IDName          `json:"user,omitempty"`
+	Group   *IDName          `json:"group,omitempty"`
+	Roles   []MembershipRole `json:"roles"`
 }
 
-// GetProjectMemberships returns memberships for a project
-func (c *Client) GetProjectMemberships(projectID int, limit int) ([]ProjectMembership, error) {
-	if limit <= 0 {
-		limit = 100
+// MembershipRole is a role of a membership. Inherited roles come from a group
+// membership in the same project, or from the parent project.
+type MembershipRole struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Inherited bool   `json:"inherited,omitempty"`
+}
+
+// MaxMembershipsLimit is the most memberships Redmine returns in one request
+const MaxMembershipsLimit = 100
+
+// GetProjectMemberships returns a page of a project's memberships and the
+// total number of them. limit is clamped to MaxMembershipsLimit.
+func (c *Client) GetProjectMemberships(projectID, offset, limit int) ([]ProjectMembership, int, error) {
+	if limit <= 0 || limit > MaxMembershipsLimit {
+		limit = MaxMembershipsLimit
 	}
 
-	path := fmt.Sprintf("/projects/%d/memberships.json?limit=%d", projectID, limit)
+	path := fmt.Sprintf("/projects/%d/memberships.json?offset=%d&limit=%d", projectID, offset, limit)
 	data, err := c.doRequest("GET", path, nil)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var resp struct {
@@ -965,10 +1242,28 @@ func (c *Client) GetProjectMemberships(projectID int, limit int) ([]ProjectMembe
 		TotalCount  int                 `json:"total_count"`
 	}
 	if err := json.Unmarshal(data, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, 0, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return resp.Memberships, nil
+	return resp.Memberships, resp.TotalCount, nil
+}
+
+// ListProjectMemberships returns all of a project's memberships, paging
+// through them MaxMembershipsLimit at a time
+func (c *Client) ListProjectMemberships(projectID int) ([]ProjectMembership, error) {
+	var all []ProjectMembership
+	for offset := 0; ; {
+		memberships, total, err := c.GetProjectMemberships(projectID, offset, MaxMembershipsLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, memberships...)
+		offset += len(memberships)
+		if len(memberships) == 0 || offset >= total {
+			return all, nil
+		}
+	}
 }
 
 // CreateProjectMembership adds a user or group to a project with specified roles
@@ -1120,37 +1415,35 @@ func (c *Client) GetProjectDetail(projectID int, includes []string) (*ProjectDet
 
 // doRequestRaw performs an HTTP request with a raw body (non-JSON)
 func (c *Client) doRequestRaw(method, path string, body io.Reader, contentType string) ([]byte, error) {
-	req, err := http.NewRequest(method, c.baseURL+path, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("X-Redmine-API-Key", c.apiKey)
-	if contentType != "" {
-		req.Header.Set("Content-Type", contentType)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+	// A retried request needs to resend the body, so buffer it up front
+	if body != nil && c.canRetry(method) {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		return c.send(method, path, func() io.Reader { return bytes.NewReader(data) }, contentType)
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
+	return c.send(method, path, func() io.Reader { return body }, contentType)
+}
 
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(respBody))
+// UploadFile uploads a file to Redmine and returns an upload token. An empty
+// contentType is guessed from the filename's extension, then from the first
+// 512 bytes of content. Redmine only accepts application/octet-stream bodies
+// on /uploads.json, so the filename and content type travel as query
+// parameters and Redmine stores them on the attachment.
+func (c *Client) UploadFile(filename, contentType string, content io.Reader) (*UploadToken, error) {
+	if contentType == "" {
+		var err error
+		if contentType, content, err = detectContentType(filename, content); err != nil {
+			return nil, fmt.Errorf("failed to upload file: %w", err)
+		}
 	}
 
-	return respBody, nil
-}
-
-// UploadFile uploads a file to Redmine and returns an upload token
-func (c *Client) UploadFile(filename string, content io.Reader) (*UploadToken, error) {
-	data, err := c.doRequestRaw("POST", "/uploads.json", content, "application/octet-stream")
+	params := url.Values{}
+	params.Set("filename", filename)
+	params.Set("content_type", contentType)
+	data, err := c.doRequestRaw("POST", "/uploads.json?"+params.Encode(), content, "application/octet-stream")
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload file: %w", err)
 	}
@@ -1165,11 +1458,40 @@ func (c *Client) UploadFile(filename string, content io.Reader) (*UploadToken, e
 	}
 
 	return &UploadToken{
-		Token:    resp.Upload.Token,
-		Filename: filename,
+		Token:       resp.Upload.Token,
+		Filename:    filename,
+		ContentType: contentType,
 	}, nil
 }
 
+// DefaultMaxAttachmentMB is the attachment size limit when none is
+// configured, the same as Redmine's default attachment_max_size
+const DefaultMaxAttachmentMB = 5
+
+// CheckAttachmentSize returns an error stating both sizes in MB when size
+// bytes exceed the limit
+func CheckAttachmentSize(filename string, size, limit int64) error {
+	if size <= limit {
+		return nil
+	}
+	return fmt.Errorf("%s is %.1f MB, over the %d MB attachment limit", filename, float64(size)/(1024*1024), limit/(1024*1024))
+}
+
+// detectContentType guesses the content type of an upload and returns a
+// reader that still yields the whole content
+func detectContentType(filename string, content io.Reader) (string, io.Reader, error) {
+	if contentType := mime.TypeByExtension(filepath.Ext(filename)); contentType != "" {
+		return contentType, content, nil
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	head = head[:n]
+	return http.DetectContentType(head), io.MultiReader(bytes.NewReader(head), content), nil
+}
+
 // GetAttachment returns attachment metadata by ID
 func (c *Client) GetAttachment(id int) (*Attachment, error) {
 	path := fmt.Sprintf("/attachments/%d.json", id)
@@ -1197,9 +1519,7 @@ func (c *Client) DownloadAttachment(id int) ([]byte, string, string, error) {
 		return nil, "", "", fmt.Errorf("failed to get attachment info: %w", err)
 	}
 
-	// Download via /attachments/download/{id}/{filename}
-	path := fmt.Sprintf("/attachments/download/%d/%s", id, attachment.Filename)
-	data, err := c.doRequestRaw("GET", path, nil, "")
+	data, err := c.doRequestRaw("GET", attachmentDownloadPath(id, attachment.Filename), nil, "")
 	if err != nil {
 		return nil, "", "", fmt.Errorf("failed to download attachment: %w", err)
 	}
@@ -1207,30 +1527,99 @@ func (c *Client) DownloadAttachment(id int) ([]byte, string, string, error) {
 	return data, attachment.ContentType, attachment.Filename, nil
 }
 
-// UpdateTimeEntryParams are parameters for updating a time entry
+// DownloadAttachmentStream opens an attachment's content by ID without
+// reading it into memory. Returns the content, which the caller must close,
+// content type, filename and size from the attachment metadata.
+func (c *Client) DownloadAttachmentStream(id int) (io.ReadCloser, string, string, int64, error) {
+	attachment, err := c.GetAttachment(id)
+	if err != nil {
+		return nil, "", "", 0, fmt.Errorf("failed to get attachment info: %w", err)
+	}
+
+	body, err := c.OpenAttachment(id, attachment.Filename)
+	if err != nil {
+		return nil, "", "", 0, err
+	}
+
+	return body, attachment.ContentType, attachment.Filename, int64(attachment.Filesize), nil
+}
+
+// OpenAttachment opens the content of an attachment whose filename is already
+// known, for callers that check its metadata first. The caller must close it.
+func (c *Client) OpenAttachment(id int, filename string) (io.ReadCloser, error) {
+	// Large files outlast the client timeout, so only the context bounds the transfer
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	resp, err := c.open(&httpClient, "GET", attachmentDownloadPath(id, filename), func() io.Reader { return nil }, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	return resp.Body, nil
+}
+
+// attachmentDownloadPath returns the /attachments/download/{id}/{filename} path
+func attachmentDownloadPath(id int, filename string) string {
+	return fmt.Sprintf("/attachments/download/%d/%s", id, url.PathEscape(filename))
+}
+
+// UpdateAttachment renames an attachment and/or changes its description. Nil
+// fields are left unchanged; at least one must be given.
+func (c *Client) UpdateAttachment(id int, filename, description *string) error {
+	attachment := make(map[string]any)
+	if filename != nil {
+		if strings.TrimSpace(*filename) == "" {
+			return fmt.Errorf("filename must not be empty")
+		}
+		attachment["filename"] = *filename
+	}
+	if description != nil {
+		attachment["description"] = *description
+	}
+	if len(attachment) == 0 {
+		return fmt.Errorf("nothing to update: give a filename or a description")
+	}
+
+	path := fmt.Sprintf("/attachments/%d.json", id)
+	_, err := c.doRequest("PATCH", path, map[string]any{"attachment": attachment})
+	return err
+}
+
+// DeleteAttachment deletes an attachment by ID. Redmine only lets the
+// attachment's author or an administrator delete it, so a 403 says so.
+func (c *Client) DeleteAttachment(id int) error {
+	path := fmt.Sprintf("/attachments/%d.json", id)
+	_, err := c.doRequest("DELETE", path, nil)
+	if apiErr, ok := AsAPIError(err); ok && apiErr.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%w (only the attachment's author or an administrator can delete it)", err)
+	}
+	return err
+}
+
+// UpdateTimeEntryParams are parameters for updating a time entry.
+// Nil fields are left unchanged; non-nil fields are sent even when zero or empty.
 type UpdateTimeEntryParams struct {
 	TimeEntryID int
-	Hours       float64
-	ActivityID  int
-	Comments    string
-	SpentOn     string // Date in YYYY-MM-DD format
+	Hours       *float64
+	ActivityID  *int
+	Comments    *string
+	SpentOn     *string // Date in YYYY-MM-DD format
 }
 
 // UpdateTimeEntry updates an existing time entry
 func (c *Client) UpdateTimeEntry(params UpdateTimeEntryParams) error {
 	timeEntryData := make(map[string]any)
 
-	if params.Hours > 0 {
-		timeEntryData["hours"] = params.Hours
+	if params.Hours != nil {
+		timeEntryData["hours"] = *params.Hours
 	}
-	if params.ActivityID > 0 {
-		timeEntryData["activity_id"] = params.ActivityID
+	if params.ActivityID != nil {
+		timeEntryData["activity_id"] = *params.ActivityID
 	}
-	if params.Comments != "" {
-		timeEntryData["comments"] = params.Comments
+	if params.Comments != nil {
+		timeEntryData["comments"] = *params.Comments
 	}
-	if params.SpentOn != "" {
-		timeEntryData["spent_on"] = params.SpentOn
+	if params.SpentOn != nil {
+		timeEntryData["spent_on"] = *params.SpentOn
 	}
 
 	reqBody := map[string]any{
@@ -1300,7 +1689,8 @@ func (c *Client) SearchUsers(params SearchUsersParams) ([]User, int, error) {
 			Users      []User `json:"users"`
 			TotalCount int    `json:"total_count"`
 		}
-		if parseErr := json.Unmarshal(data, &resp); parseErr == nil {
+		err = json.Unmarshal(data, &resp)
+		if err == nil {
 			// Fill in Name if empty
 			for i := range resp.Users {
 				if resp.Users[i].Name == "" {
@@ -1313,10 +1703,10 @@ func (c *Client) SearchUsers(params SearchUsersParams) ([]User, int, error) {
 
 	// Fallback: use project memberships if admin API fails (403)
 	if params.ProjectID == 0 {
-		return nil, 0, fmt.Errorf("user search requires admin privileges or a project context (provide project parameter)")
+		return nil, 0, fmt.Errorf("user search requires admin privileges or a project context (provide project parameter): %w", err)
 	}
 
-	memberships, mErr := c.GetProjectMemberships(params.ProjectID, 100)
+	memberships, _, mErr := c.GetProjectMemberships(params.ProjectID, 0, MaxMembershipsLimit)
 	if mErr != nil {
 		return nil, 0, fmt.Errorf("failed to search users (admin API unavailable, membership fallback failed): %w", mErr)
 	}
@@ -1339,6 +1729,85 @@ func (c *Client) SearchUsers(params SearchUsersParams) ([]User, int, error) {
 	return users, len(users), nil
 }
 
+// Group represents a Redmine user group
+type Group struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Users []IDName `json:"users,omitempty"`
+}
+
+// ListGroups returns all groups (requires admin privileges)
+func (c *Client) ListGroups() ([]Group, error) {
+	data, err := c.doRequest("GET", "/groups.json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups (requires admin privileges): %w", err)
+	}
+
+	var resp struct {
+		Groups []Group `json:"groups"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.Groups, nil
+}
+
+// Query represents a saved issue query
+type Query struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	IsPublic  bool   `json:"is_public"`
+	ProjectID *int   `json:"project_id"` // nil for queries available in all projects
+}
+
+// ListQueries returns the saved issue queries visible to the current user
+func (c *Client) ListQueries() ([]Query, error) {
+	var all []Query
+	for offset := 0; ; {
+		data, err := c.doRequest("GET", fmt.Sprintf("/queries.json?limit=100&offset=%d", offset), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp struct {
+			Queries    []Query `json:"queries"`
+			TotalCount int     `json:"total_count"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		all = append(all, resp.Queries...)
+		offset += len(resp.Queries)
+		if len(resp.Queries) == 0 || offset >= resp.TotalCount {
+			return all, nil
+		}
+	}
+}
+
+// GetGroup returns a single group, optionally including its member users
+// (requires admin privileges)
+func (c *Client) GetGroup(id int, includeUsers bool) (*Group, error) {
+	path := fmt.Sprintf("/groups/%d.json", id)
+	if includeUsers {
+		path += "?include=users"
+	}
+	data, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group (requires admin privileges): %w", err)
+	}
+
+	var resp struct {
+		Group Group `json:"group"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Group, nil
+}
+
 // Version represents a Redmine version/milestone
 type Version struct {
 	ID          int    `json:"id"`
@@ -1370,6 +1839,23 @@ func (c *Client) ListVersions(projectID int) ([]Version, error) {
 	return resp.Versions, nil
 }
 
+// GetVersion returns a version by ID
+func (c *Client) GetVersion(versionID int) (*Version, error) {
+	data, err := c.doRequest("GET", fmt.Sprintf("/versions/%d.json", versionID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Version Version `json:"version"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Version, nil
+}
+
 // CreateVersionParams are parameters for creating a version
 type CreateVersionParams struct {
 	ProjectID   int
@@ -1558,23 +2044,31 @@ func (c *Client) DeleteIssueCategory(categoryID int) error {
 	return err
 }
 
+// WikiPageRef references a wiki page by title
+type WikiPageRef struct {
+	Title string `json:"title"`
+}
+
 // WikiPage represents a wiki page in the index
 type WikiPage struct {
-	Title     string `json:"title"`
-	Version   int    `json:"version"`
-	CreatedOn string `json:"created_on"`
-	UpdatedOn string `json:"updated_on"`
+	Title     string       `json:"title"`
+	Parent    *WikiPageRef `json:"parent,omitempty"`
+	Version   int          `json:"version"`
+	CreatedOn string       `json:"created_on"`
+	UpdatedOn string       `json:"updated_on"`
 }
 
 // WikiPageDetail represents a wiki page with content
 type WikiPageDetail struct {
-	Title     string `json:"title"`
-	Text      string `json:"text"`
-	Version   int    `json:"version"`
-	Author    IDName `json:"author"`
-	Comments  string `json:"comments"`
-	CreatedOn string `json:"created_on"`
-	UpdatedOn string `json:"updated_on"`
+	Title       string       `json:"title"`
+	Parent      *WikiPageRef `json:"parent,omitempty"`
+	Text        string       `json:"text"`
+	Version     int          `json:"version"`
+	Author      IDName       `json:"author"`
+	Comments    string       `json:"comments"`
+	CreatedOn   string       `json:"created_on"`
+	UpdatedOn   string       `json:"updated_on"`
+	Attachments []Attachment `json:"attachments,omitempty"`
 }
 
 // ListWikiPages returns all wiki pages for a project
@@ -1595,9 +2089,27 @@ func (c *Client) ListWikiPages(projectID int) ([]WikiPage, error) {
 	return resp.WikiPages, nil
 }
 
-// GetWikiPage returns a wiki page by title
+// GetWikiPage returns a wiki page by title, including its attachments
 func (c *Client) GetWikiPage(projectID int, title string) (*WikiPageDetail, error) {
-	path := fmt.Sprintf("/projects/%d/wiki/%s.json", projectID, url.PathEscape(title))
+	path := fmt.Sprintf("/projects/%d/wiki/%s.json?include=attachments", projectID, url.PathEscape(title))
+	data, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		WikiPage WikiPageDetail `json:"wiki_page"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.WikiPage, nil
+}
+
+// GetWikiPageVersion returns an old revision of a wiki page
+func (c *Client) GetWikiPageVersion(projectID int, title string, version int) (*WikiPageDetail, error) {
+	path := fmt.Sprintf("/projects/%d/wiki/%s/%d.json", projectID, url.PathEscape(title), version)
 	data, err := c.doRequest("GET", path, nil)
 	if err != nil {
 		return nil, err
@@ -1615,10 +2127,12 @@ func (c *Client) GetWikiPage(projectID int, title string) (*WikiPageDetail, erro
 
 // WikiPageParams are parameters for creating or updating a wiki page
 type WikiPageParams struct {
-	ProjectID int
-	Title     string
-	Text      string
-	Comments  string // edit comment / version note
+	ProjectID   int
+	Title       string
+	Text        string
+	Comments    string // edit comment / version note
+	ParentTitle string // file the page under this parent page
+	Uploads     []UploadToken
 }
 
 // CreateOrUpdateWikiPage creates or updates a wiki page
@@ -1629,6 +2143,12 @@ func (c *Client) CreateOrUpdateWikiPage(params WikiPageParams) error {
 	if params.Comments != "" {
 		wikiData["comments"] = params.Comments
 	}
+	if params.ParentTitle != "" {
+		wikiData["parent_title"] = params.ParentTitle
+	}
+	if len(params.Uploads) > 0 {
+		wikiData["uploads"] = uploadsPayload(params.Uploads)
+	}
 
 	reqBody := map[string]any{
 		"wiki_page": wikiData,
@@ -1639,6 +2159,131 @@ func (c *Client) CreateOrUpdateWikiPage(params WikiPageParams) error {
 	return err
 }
 
+// DeleteWikiPage deletes a wiki page and its history
+func (c *Client) DeleteWikiPage(projectID int, title string) error {
+	path := fmt.Sprintf("/projects/%d/wiki/%s.json", projectID, url.PathEscape(title))
+	_, err := c.doRequest("DELETE", path, nil)
+	return err
+}
+
+// Document represents a document in a project's Documents module
+type Document struct {
+	ID          int          `json:"id"`
+	Project     IDName       `json:"project"`
+	Category    IDName       `json:"category"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	CreatedOn   string       `json:"created_on"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// DocumentCategory represents a document category enumeration
+type DocumentCategory struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	IsDefault bool   `json:"is_default"`
+}
+
+// documentsModuleHint explains the most common cause of documents API failures
+const documentsModuleHint = "is the Documents module enabled for this project?"
+
+// ListDocuments returns the documents of a project
+func (c *Client) ListDocuments(projectID int) ([]Document, error) {
+	path := fmt.Sprintf("/projects/%d/documents.json", projectID)
+	data, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list documents (%s): %w", documentsModuleHint, err)
+	}
+
+	var resp struct {
+		Documents []Document `json:"documents"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.Documents, nil
+}
+
+// GetDocument returns a document with its attachments
+func (c *Client) GetDocument(documentID int) (*Document, error) {
+	path := fmt.Sprintf("/documents/%d.json?include=attachments", documentID)
+	data, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Document Document `json:"document"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Document, nil
+}
+
+// CreateDocumentParams are parameters for creating a document
+type CreateDocumentParams struct {
+	ProjectID   int
+	Title       string
+	Description string
+	CategoryID  int
+	Uploads     []UploadToken
+}
+
+// CreateDocument creates a document in a project
+func (c *Client) CreateDocument(params CreateDocumentParams) (*Document, error) {
+	documentData := map[string]any{
+		"title": params.Title,
+	}
+	if params.Description != "" {
+		documentData["description"] = params.Description
+	}
+	if params.CategoryID > 0 {
+		documentData["category_id"] = params.CategoryID
+	}
+	if len(params.Uploads) > 0 {
+		documentData["uploads"] = uploadsPayload(params.Uploads)
+	}
+
+	reqBody := map[string]any{
+		"document": documentData,
+	}
+
+	path := fmt.Sprintf("/projects/%d/documents.json", params.ProjectID)
+	data, err := c.doRequest("POST", path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document (%s): %w", documentsModuleHint, err)
+	}
+
+	var resp struct {
+		Document Document `json:"document"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Document, nil
+}
+
+// ListDocumentCategories returns all document categories
+func (c *Client) ListDocumentCategories() ([]DocumentCategory, error) {
+	data, err := c.doRequest("GET", "/enumerations/document_categories.json", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		DocumentCategories []DocumentCategory `json:"document_categories"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.DocumentCategories, nil
+}
+
 // UpdateProject updates a project's settings
 func (c *Client) UpdateProject(params UpdateProjectParams) error {
 	projectData := make(map[string]any)
@@ -1668,6 +2313,84 @@ func (c *Client) UpdateProject(params UpdateProjectParams) error {
 	return nil
 }
 
+// ProjectActions are the lifecycle actions accepted by SetProjectStatus
+var ProjectActions = []string{"archive", "unarchive", "close", "reopen", "delete"}
+
+// ArchiveProject archives a project, hiding it from everyone but
+// administrators (Redmine 5.0+, admin only)
+func (c *Client) ArchiveProject(projectID int) error {
+	return c.projectLifecycle(projectID, "archive")
+}
+
+// UnarchiveProject restores an archived project (Redmine 5.0+, admin only)
+func (c *Client) UnarchiveProject(projectID int) error {
+	return c.projectLifecycle(projectID, "unarchive")
+}
+
+// CloseProject makes a project read-only (Redmine 5.1+)
+func (c *Client) CloseProject(projectID int) error {
+	return c.projectLifecycle(projectID, "close")
+}
+
+// ReopenProject reopens a closed project (Redmine 5.1+)
+func (c *Client) ReopenProject(projectID int) error {
+	return c.projectLifecycle(projectID, "reopen")
+}
+
+// DeleteProject permanently deletes a project with its issues, wiki and
+// files (admin only)
+func (c *Client) DeleteProject(projectID int) error {
+	path := fmt.Sprintf("/projects/%d.json", projectID)
+	_, err := c.doRequest("DELETE", path, nil)
+	return err
+}
+
+func (c *Client) projectLifecycle(projectID int, action string) error {
+	path := fmt.Sprintf("/projects/%d/%s.json", projectID, action)
+	_, err := c.doRequest("PUT", path, nil)
+	return err
+}
+
+// SetProjectStatus applies one of ProjectActions to a project. Permission and
+// not-found errors are annotated with what the action needs; the *APIError
+// stays wrapped so callers can still map its status code.
+func (c *Client) SetProjectStatus(projectID int, action string) error {
+	var err error
+	switch action {
+	case "archive":
+		err = c.ArchiveProject(projectID)
+	case "unarchive":
+		err = c.UnarchiveProject(projectID)
+	case "close":
+		err = c.CloseProject(projectID)
+	case "reopen":
+		err = c.ReopenProject(projectID)
+	case "delete":
+		err = c.DeleteProject(projectID)
+	default:
+		return fmt.Errorf("unknown project action %q (valid: %s)", action, strings.Join(ProjectActions, ", "))
+	}
+	if err == nil {
+		return nil
+	}
+
+	if apiErr, ok := AsAPIError(err); ok {
+		switch apiErr.StatusCode {
+		case http.StatusForbidden:
+			need := "an administrator API key"
+			if action == "close" || action == "reopen" {
+				need = "an administrator or a role with the close project permission"
+			}
+			return fmt.Errorf("failed to %s project: %w (this needs %s)", action, err, need)
+		case http.StatusNotFound:
+			if action != "delete" {
+				return fmt.Errorf("failed to %s project: %w (the project doesn't exist, or this Redmine version has no %s API: archive/unarchive need Redmine 5.0+ and close/reopen Redmine 5.1+)", action, err, action)
+			}
+		}
+	}
+	return fmt.Errorf("failed to %s project: %w", action, err)
+}
+
 // ProjectFile represents a file in the project Files section
 type ProjectFile struct {
 	ID          int    `json:"id"`
@@ -1758,6 +2481,7 @@ type SearchResult struct {
 // GlobalSearchParams are parameters for global search across all Redmine resources
 type GlobalSearchParams struct {
 	Query      string
+	ProjectID  string // Search within this project (see Scope for its subprojects)
 	Scope      string // "all", "my_projects", "subprojects"
 	AllWords   bool
 	TitlesOnly bool
@@ -1820,6 +2544,9 @@ func (c *Client) GlobalSearch(params GlobalSearchParams) ([]SearchResult, int, e
 	}
 
 	path := "/search.json?" + query.Encode()
+	if params.ProjectID != "" {
+		path = fmt.Sprintf("/projects/%s/search.json?%s", url.PathEscape(params.ProjectID), query.Encode())
+	}
 	data, err := c.doRequest("GET", path, nil)
 	if err != nil {
 		return nil, 0, err
@@ -1871,7 +2598,7 @@ func (c *Client) DMSFUploadFile(projectID int, filename string, content io.Reade
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("X-Redmine-API-Key", c.apiKey)
+	c.authenticate(req)
 	req.Header.Set("Content-Type", "application/octet-stream")
 
 	resp, err := c.httpClient.Do(req)
@@ -2,9 +2,12 @@ package redmine
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -45,7 +48,8 @@ func TestUpdateTimeEntry_Success(t *testing.T) {
 	defer ts.Close()
 	client := NewClient(ts.URL, "test-key")
 
-	err := client.UpdateTimeEntry(UpdateTimeEntryParams{TimeEntryID: 1, Hours: 2.5})
+	hours := 2.5
+	err := client.UpdateTimeEntry(UpdateTimeEntryParams{TimeEntryID: 1, Hours: &hours})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -62,7 +66,8 @@ func TestUpdateTimeEntry_NotFound(t *testing.T) {
 	defer ts.Close()
 	client := NewClient(ts.URL, "test-key")
 
-	err := client.UpdateTimeEntry(UpdateTimeEntryParams{TimeEntryID: 999, Hours: 1.0})
+	hours := 1.0
+	err := client.UpdateTimeEntry(UpdateTimeEntryParams{TimeEntryID: 999, Hours: &hours})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -82,7 +87,8 @@ func TestUpdateTimeEntry_InvalidParams(t *testing.T) {
 	defer ts.Close()
 	client := NewClient(ts.URL, "test-key")
 
-	err := client.UpdateTimeEntry(UpdateTimeEntryParams{TimeEntryID: 1, Hours: -1})
+	hours := -1.0
+	err := client.UpdateTimeEntry(UpdateTimeEntryParams{TimeEntryID: 1, Hours: &hours})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -91,6 +97,64 @@ func TestUpdateTimeEntry_InvalidParams(t *testing.T) {
 	}
 }
 
+func TestUpdateTimeEntry_ExplicitZeroValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     func() UpdateTimeEntryParams
+		wantFields map[string]any
+	}{
+		{
+			name: "empty comments and zero hours are sent",
+			params: func() UpdateTimeEntryParams {
+				comments := ""
+				hours := 0.0
+				return UpdateTimeEntryParams{TimeEntryID: 1, Comments: &comments, Hours: &hours}
+			},
+			wantFields: map[string]any{"comments": "", "hours": 0.0},
+		},
+		{
+			name: "omitted fields are not sent",
+			params: func() UpdateTimeEntryParams {
+				spentOn := "2025-01-06"
+				return UpdateTimeEntryParams{TimeEntryID: 1, SpentOn: &spentOn}
+			},
+			wantFields: map[string]any{"spent_on": "2025-01-06"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]map[string]any
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /time_entries/1.json", func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Fatalf("failed to parse request body: %v", err)
+				}
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+			client := NewClient(ts.URL, "test-key")
+
+			if err := client.UpdateTimeEntry(tt.params()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			te := got["time_entry"]
+			if len(te) != len(tt.wantFields) {
+				t.Errorf("expected fields %v, got %v", tt.wantFields, te)
+			}
+			for k, want := range tt.wantFields {
+				if v, ok := te[k]; !ok || v != want {
+					t.Errorf("expected %s=%v, got %v (present=%v)", k, want, v, ok)
+				}
+			}
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // DeleteTimeEntry
 // ---------------------------------------------------------------------------
@@ -851,6 +915,148 @@ func TestCreateOrUpdateWikiPage_Update(t *testing.T) {
 	}
 }
 
+func TestCreateOrUpdateWikiPage_ParentTitle(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /projects/1/wiki/Setup.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /projects/1/wiki/index.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"wiki_pages":[{"title":"Guide","version":1},{"title":"Setup","parent":{"title":"Guide"},"version":1}]}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	if err := client.CreateOrUpdateWikiPage(WikiPageParams{ProjectID: 1, Title: "Setup", Text: "Steps", ParentTitle: "Guide"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["wiki_page"]["parent_title"] != "Guide" {
+		t.Errorf("expected parent_title=Guide, got %v", got["wiki_page"])
+	}
+
+	pages, err := client.ListWikiPages(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pages[0].Parent != nil || pages[1].Parent == nil || pages[1].Parent.Title != "Guide" {
+		t.Errorf("expected only Setup to have parent Guide, got %+v", pages)
+	}
+}
+
+func TestCreateOrUpdateWikiPage_Uploads(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /projects/1/wiki/Guide.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	err := client.CreateOrUpdateWikiPage(WikiPageParams{
+		ProjectID: 1,
+		Title:     "Guide",
+		Text:      "!screen.png!",
+		Uploads:   []UploadToken{{Token: "7.abc", Filename: "screen.png", ContentType: "image/png"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	uploads, ok := got["wiki_page"]["uploads"].([]any)
+	if !ok || len(uploads) != 1 {
+		t.Fatalf("expected one upload in request, got %v", got["wiki_page"])
+	}
+	upload := uploads[0].(map[string]any)
+	if upload["token"] != "7.abc" || upload["filename"] != "screen.png" || upload["content_type"] != "image/png" {
+		t.Errorf("unexpected upload payload: %v", upload)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// DeleteWikiPage
+// ---------------------------------------------------------------------------
+
+func TestDeleteWikiPage(t *testing.T) {
+	deleted := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /projects/1/wiki/Old Notes.json", func(w http.ResponseWriter, r *http.Request) {
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	if err := client.DeleteWikiPage(1, "Old Notes"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !deleted {
+		t.Error("expected DELETE request for the escaped page title")
+	}
+}
+
+func TestCreateDocument(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /projects/1/documents.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"document":{"id":7,"title":"Spec","category":{"id":2,"name":"Technical"}}}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	doc, err := client.CreateDocument(CreateDocumentParams{
+		ProjectID:  1,
+		Title:      "Spec",
+		CategoryID: 2,
+		Uploads:    []UploadToken{{Token: "1.abc", Filename: "spec.pdf"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.ID != 7 || doc.Category.Name != "Technical" {
+		t.Errorf("unexpected document: %+v", doc)
+	}
+	if got["document"]["title"] != "Spec" || got["document"]["category_id"] != float64(2) {
+		t.Errorf("unexpected request body: %v", got)
+	}
+	uploads, _ := got["document"]["uploads"].([]any)
+	if len(uploads) != 1 {
+		t.Errorf("expected 1 upload, got %v", got["document"]["uploads"])
+	}
+}
+
+func TestListDocuments_ModuleDisabled(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects/1/documents.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	_, err := client.ListDocuments(1)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "Documents module") {
+		t.Errorf("expected hint about the Documents module, got %v", err)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Additional tests for broader coverage
 // ---------------------------------------------------------------------------
@@ -919,12 +1125,16 @@ func TestUpdateTimeEntry_VerifiesAllFields(t *testing.T) {
 	defer ts.Close()
 	client := NewClient(ts.URL, "test-key")
 
+	hours := 3.5
+	activityID := 9
+	comments := "Code review"
+	spentOn := "2025-06-15"
 	err := client.UpdateTimeEntry(UpdateTimeEntryParams{
 		TimeEntryID: 5,
-		Hours:       3.5,
-		ActivityID:  9,
-		Comments:    "Code review",
-		SpentOn:     "2025-06-15",
+		Hours:       &hours,
+		ActivityID:  &activityID,
+		Comments:    &comments,
+		SpentOn:     &spentOn,
 	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -1255,3 +1465,376 @@ func TestListWikiPages_ServerError(t *testing.T) {
 		t.Errorf("expected error to contain '403', got: %v", err)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// SearchIssuesAll
+// ---------------------------------------------------------------------------
+
+// newPagedIssuesServer serves /issues.json from a fixed set of total issues,
+// honouring limit/offset but capping each page at 100 like Redmine does.
+func newPagedIssuesServer(t *testing.T, total int, requests *[]string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if requests != nil {
+			*requests = append(*requests, q.Get("limit")+"@"+q.Get("offset"))
+		}
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		if limit > 100 {
+			limit = 100
+		}
+
+		issues := []map[string]any{}
+		for id := offset + 1; id <= offset+limit && id <= total; id++ {
+			issues = append(issues, map[string]any{"id": id, "subject": fmt.Sprintf("Issue %d", id)})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issues":      issues,
+			"total_count": total,
+			"offset":      offset,
+			"limit":       limit,
+		})
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestSearchIssuesAll_PaginatesAboveServerCap(t *testing.T) {
+	var requests []string
+	ts := newPagedIssuesServer(t, 800, &requests)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	issues, total, err := client.SearchIssuesAll(SearchIssuesParams{Limit: 250})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 800 {
+		t.Errorf("expected total=800, got %d", total)
+	}
+	if len(issues) != 250 {
+		t.Fatalf("expected 250 issues, got %d", len(issues))
+	}
+	if issues[0].ID != 1 || issues[249].ID != 250 {
+		t.Errorf("unexpected issue range: first=%d last=%d", issues[0].ID, issues[249].ID)
+	}
+
+	want := []string{"100@", "100@100", "50@200"}
+	if strings.Join(requests, ",") != strings.Join(want, ",") {
+		t.Errorf("expected requests %v, got %v", want, requests)
+	}
+}
+
+func TestSearchIssuesAll_StopsAtTotal(t *testing.T) {
+	var requests []string
+	ts := newPagedIssuesServer(t, 130, &requests)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	issues, total, err := client.SearchIssuesAll(SearchIssuesParams{Limit: 500})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 130 || len(issues) != 130 {
+		t.Errorf("expected 130/130, got %d/%d", len(issues), total)
+	}
+	if len(requests) != 2 {
+		t.Errorf("expected 2 requests, got %d: %v", len(requests), requests)
+	}
+}
+
+func TestSearchIssuesAll_RespectsOffset(t *testing.T) {
+	ts := newPagedIssuesServer(t, 300, nil)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	issues, _, err := client.SearchIssuesAll(SearchIssuesParams{Limit: 150, Offset: 200})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) != 100 {
+		t.Fatalf("expected 100 issues, got %d", len(issues))
+	}
+	if issues[0].ID != 201 {
+		t.Errorf("expected first issue 201, got %d", issues[0].ID)
+	}
+}
+
+func TestSearchIssuesAll_SafetyCeiling(t *testing.T) {
+	ts := newPagedIssuesServer(t, 5000, nil)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	issues, total, err := client.SearchIssuesAll(SearchIssuesParams{Limit: 20000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) != MaxSearchIssuesLimit {
+		t.Errorf("expected %d issues, got %d", MaxSearchIssuesLimit, len(issues))
+	}
+	if total != 5000 {
+		t.Errorf("expected total=5000, got %d", total)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// UpdateIssue
+// ---------------------------------------------------------------------------
+
+func TestUpdateIssue_ClearFields(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /issues/7.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("failed to parse request body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	err := client.UpdateIssue(UpdateIssueParams{
+		IssueID:             7,
+		ClearAssignee:       true,
+		ClearDueDate:        true,
+		ClearDescription:    true,
+		ClearEstimatedHours: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	issue := got["issue"]
+	for _, key := range []string{"assigned_to_id", "due_date", "description", "estimated_hours"} {
+		v, ok := issue[key]
+		if !ok {
+			t.Errorf("expected %q in payload, got %v", key, issue)
+			continue
+		}
+		if v != "" {
+			t.Errorf("expected %q to be empty string, got %v", key, v)
+		}
+	}
+}
+
+func TestUpdateIssue_OmitsUnsetFields(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /issues/7.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	if err := client.UpdateIssue(UpdateIssueParams{IssueID: 7, Notes: "note"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"assigned_to_id", "due_date", "description", "estimated_hours"} {
+		if _, ok := got["issue"][key]; ok {
+			t.Errorf("expected %q to be omitted, got %v", key, got["issue"])
+		}
+	}
+}
+
+func TestUpdateIssue_PrivateNotes(t *testing.T) {
+	var got map[string]map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /issues/7.json", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	private := true
+	if err := client.UpdateIssue(UpdateIssueParams{IssueID: 7, Notes: "internal", PrivateNotes: &private}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["issue"]["private_notes"] != true || got["issue"]["notes"] != "internal" {
+		t.Errorf("expected private note in payload, got %v", got["issue"])
+	}
+
+	if err := client.UpdateIssue(UpdateIssueParams{IssueID: 7, Notes: "public"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := got["issue"]["private_notes"]; ok {
+		t.Errorf("expected private_notes to be omitted, got %v", got["issue"])
+	}
+}
+
+func TestNotePreview(t *testing.T) {
+	if got := NotePreview("short"); got != "short" {
+		t.Errorf("expected short note unchanged, got %q", got)
+	}
+	long := strings.Repeat("é", NotePreviewLength+10)
+	if got := NotePreview(long); got != strings.Repeat("é", NotePreviewLength)+"..." {
+		t.Errorf("expected note cut at %d characters, got %d", NotePreviewLength, len([]rune(got)))
+	}
+}
+
+func TestUpdateIssue_SetAndClearConflict(t *testing.T) {
+	client := NewClient("http://localhost", "test-key")
+
+	tests := []struct {
+		name   string
+		params UpdateIssueParams
+	}{
+		{"assignee", UpdateIssueParams{IssueID: 1, AssignedToID: 5, ClearAssignee: true}},
+		{"due date", UpdateIssueParams{IssueID: 1, DueDate: "2025-01-01", ClearDueDate: true}},
+		{"description", UpdateIssueParams{IssueID: 1, Description: "x", ClearDescription: true}},
+		{"estimated hours", UpdateIssueParams{IssueID: 1, EstimatedHours: new(float64), ClearEstimatedHours: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := client.UpdateIssue(tt.params); err == nil {
+				t.Error("expected error when setting and clearing the same field")
+			}
+		})
+	}
+}
+
+func TestSearchIssues_CustomFieldFilters(t *testing.T) {
+	var rawQuery string
+	var query url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawQuery, query = r.URL.RawQuery, r.URL.Query()
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	}))
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	_, _, err := client.SearchIssues(SearchIssuesParams{CustomFieldFilter: map[string]CustomFieldFilter{
+		"5": {Values: []string{"R&D", "UI / UX"}},
+		"6": {Operator: "!", Values: []string{"legacy", "old"}},
+		"7": {Operator: "~", Values: []string{"100% done"}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]string{"cf_5": "=R&D|UI / UX", "cf_6": "!legacy|old", "cf_7": "~100% done"}
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+	if !strings.Contains(rawQuery, "cf_5=%3DR%26D%7CUI+%2F+UX") {
+		t.Errorf("expected special characters to be escaped, got %s", rawQuery)
+	}
+}
+
+func TestSetProjectStatus(t *testing.T) {
+	var calls []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/projects/2/archive.json":
+			w.WriteHeader(http.StatusForbidden)
+		case "/projects/3/close.json":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	for _, action := range ProjectActions {
+		if err := client.SetProjectStatus(1, action); err != nil {
+			t.Errorf("%s: unexpected error: %v", action, err)
+		}
+	}
+	want := []string{
+		"PUT /projects/1/archive.json",
+		"PUT /projects/1/unarchive.json",
+		"PUT /projects/1/close.json",
+		"PUT /projects/1/reopen.json",
+		"DELETE /projects/1.json",
+	}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("unexpected requests: %v", calls)
+	}
+
+	err := client.SetProjectStatus(2, "archive")
+	if apiErr, ok := AsAPIError(err); !ok || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("expected wrapped 403, got %v", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "administrator") {
+		t.Errorf("expected admin hint, got %v", err)
+	}
+
+	if err := client.SetProjectStatus(3, "close"); err == nil || !strings.Contains(err.Error(), "Redmine 5.1+") {
+		t.Errorf("expected version hint for 404, got %v", err)
+	}
+
+	if err := client.SetProjectStatus(1, "freeze"); err == nil {
+		t.Error("expected error for unknown action")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// UploadFile
+// ---------------------------------------------------------------------------
+
+func TestUploadFile_ContentType(t *testing.T) {
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 600)
+	var got url.Values
+	var header, body string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /uploads.json", func(w http.ResponseWriter, r *http.Request) {
+		got, header = r.URL.Query(), r.Header.Get("Content-Type")
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"upload":{"token":"1.abc"}}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	tests := []struct {
+		filename, contentType, content string
+		want                           string
+	}{
+		{"notes.txt", "text/markdown", "# hi", "text/markdown"},
+		{"screen shot.png", "", "not really a png", "image/png"},
+		{"screenshot", "", png, "image/png"},
+		{"README", "", "", "text/plain; charset=utf-8"},
+	}
+	for _, tt := range tests {
+		token, err := client.UploadFile(tt.filename, tt.contentType, strings.NewReader(tt.content))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.filename, err)
+		}
+		if token.Token != "1.abc" || token.Filename != tt.filename || token.ContentType != tt.want {
+			t.Errorf("%s: unexpected token %+v", tt.filename, token)
+		}
+		// Redmine rejects any other body type with 406 Not Acceptable
+		if header != "application/octet-stream" {
+			t.Errorf("%s: expected an octet-stream body, got %q", tt.filename, header)
+		}
+		if got.Get("filename") != tt.filename || got.Get("content_type") != tt.want {
+			t.Errorf("%s: unexpected query %v", tt.filename, got)
+		}
+		if body != tt.content {
+			t.Errorf("%s: expected the whole content to be sent after sniffing, got %d bytes", tt.filename, len(body))
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package redmine
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// MaxImportRows caps how many data rows one CSV import may contain
+const MaxImportRows = 200
+
+// CSV import modes
+const (
+	ImportCreateOnly      = "create_only"
+	ImportUpdateByID      = "update_by_id"
+	ImportUpsertBySubject = "upsert_by_subject"
+)
+
+// ImportModes lists the supported CSV import modes
+var ImportModes = []string{ImportCreateOnly, ImportUpdateByID, ImportUpsertBySubject}
+
+// ParseImportMode validates a mode name; empty means create_only
+func ParseImportMode(mode string) (string, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode == "" {
+		return ImportCreateOnly, nil
+	}
+	if !slices.Contains(ImportModes, mode) {
+		return "", fmt.Errorf("invalid mode: %s (valid: %s)", mode, strings.Join(ImportModes, ", "))
+	}
+	return mode, nil
+}
+
+// importFields maps header names, lowercased, to the issue field they fill.
+// It accepts the tool argument names as well as the headers issues_exportCSV
+// writes, so an export can be edited and imported again.
+var importFields = map[string]string{
+	"id":              "id",
+	"#":               "id",
+	"subject":         "subject",
+	"project":         "project",
+	"tracker":         "tracker",
+	"status":          "status",
+	"priority":        "priority",
+	"assignee":        "assigned_to",
+	"assigned_to":     "assigned_to",
+	"assigned to":     "assigned_to",
+	"start":           "start_date",
+	"start_date":      "start_date",
+	"start date":      "start_date",
+	"due":             "due_date",
+	"due_date":        "due_date",
+	"due date":        "due_date",
+	"done %":          "done_ratio",
+	"done_ratio":      "done_ratio",
+	"estimated":       "estimated_hours",
+	"estimated_hours": "estimated_hours",
+	"estimated time":  "estimated_hours",
+	"description":     "description",
+	"parent":          "parent_issue_id",
+	"parent_issue_id": "parent_issue_id",
+	"parent task":     "parent_issue_id",
+	"private":         "is_private",
+	"is_private":      "is_private",
+	"notes":           "notes",
+}
+
+// readOnlyImportHeaders are exported columns Redmine computes, which an
+// auto-detected import skips instead of taking them for custom fields
+var readOnlyImportHeaders = map[string]bool{
+	"author":      true,
+	"created":     true,
+	"updated":     true,
+	"spent":       true,
+	"spent_hours": true,
+}
+
+// ImportColumn is a CSV column mapped to an issue field, or to a custom field
+// when Field is empty
+type ImportColumn struct {
+	Header      string `json:"header"`
+	Field       string `json:"field,omitempty"`
+	CustomField string `json:"custom_field,omitempty"`
+}
+
+// ImportRow is a data row of an import CSV. Blank cells are left out, so
+// they never change a field.
+type ImportRow struct {
+	Line         int
+	Fields       map[string]string
+	CustomFields map[string]string
+}
+
+// ImportCSV is a parsed import file
+type ImportCSV struct {
+	Columns []ImportColumn
+	Ignored []string // Headers that aren't imported
+	Rows    []ImportRow
+}
+
+// HasField reports whether a column fills field
+func (f *ImportCSV) HasField(field string) bool {
+	return slices.ContainsFunc(f.Columns, func(c ImportColumn) bool { return c.Field == field })
+}
+
+// ParseImportCSV reads a CSV file whose first row is a header. mapping maps
+// headers to issue fields or custom field names; headers it leaves out are
+// ignored. Without a mapping, headers naming an issue field are mapped to it
+// and every other header is taken for a custom field name.
+func ParseImportCSV(data []byte, mapping map[string]string) (*ImportCSV, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+
+	headers, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("CSV is empty; the first row must be a header")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	file := &ImportCSV{}
+	columns, err := importColumns(headers, mapping, &file.Ignored)
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range columns {
+		if col != nil {
+			file.Columns = append(file.Columns, *col)
+		}
+	}
+	if len(file.Columns) == 0 {
+		return nil, fmt.Errorf("no columns to import; headers: %s", strings.Join(headers, ", "))
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := ImportRow{Line: line, Fields: map[string]string{}, CustomFields: map[string]string{}}
+		for i, cell := range record {
+			cell = strings.TrimSpace(cell)
+			if i >= len(columns) || columns[i] == nil || cell == "" {
+				continue
+			}
+			switch col := columns[i]; {
+			case col.Field == "description":
+				row.Fields[col.Field] = unescapeNewlines(cell)
+			case col.Field != "":
+				row.Fields[col.Field] = cell
+			default:
+				row.CustomFields[col.CustomField] = cell
+			}
+		}
+		if len(row.Fields) == 0 && len(row.CustomFields) == 0 {
+			continue
+		}
+
+		if len(file.Rows) == MaxImportRows {
+			return nil, fmt.Errorf("CSV has more than %d data rows; split it into smaller files", MaxImportRows)
+		}
+		file.Rows = append(file.Rows, row)
+	}
+
+	if len(file.Rows) == 0 {
+		return nil, fmt.Errorf("CSV has no data rows")
+	}
+	return file, nil
+}
+
+// importColumns maps each header to a column, or nil for ignored headers,
+// which are added to ignored
+func importColumns(headers []string, mapping map[string]string, ignored *[]string) ([]*ImportColumn, error) {
+	// Mapping keys match headers case-insensitively
+	targets := make(map[string]string, len(mapping))
+	for header, target := range mapping {
+		targets[normalizeHeader(header)] = strings.TrimSpace(target)
+	}
+
+	columns := make([]*ImportColumn, len(headers))
+	seen := make(map[string]string)
+	for i, header := range headers {
+		header = strings.TrimSpace(header)
+		key := normalizeHeader(header)
+		target := key
+		if mapping != nil {
+			var ok bool
+			if target, ok = targets[key]; !ok {
+				*ignored = append(*ignored, header)
+				continue
+			}
+			delete(targets, key)
+		}
+		if target == "" || (mapping == nil && readOnlyImportHeaders[target]) {
+			*ignored = append(*ignored, header)
+			continue
+		}
+
+		col := &ImportColumn{Header: header}
+		if field, ok := importFields[normalizeHeader(target)]; ok {
+			col.Field = field
+		} else if mapping != nil {
+			col.CustomField = target
+		} else {
+			col.CustomField = header
+		}
+
+		name := col.Field
+		if name == "" {
+			name = "custom field " + col.CustomField
+		}
+		if prev, ok := seen[strings.ToLower(name)]; ok {
+			return nil, fmt.Errorf("columns %q and %q both map to %s", prev, header, name)
+		}
+		seen[strings.ToLower(name)] = header
+		columns[i] = col
+	}
+
+	if len(targets) > 0 {
+		unknown := make([]string, 0, len(targets))
+		for header := range targets {
+			unknown = append(unknown, header)
+		}
+		slices.Sort(unknown)
+		return nil, fmt.Errorf("mapping names columns missing from the header: %s", strings.Join(unknown, ", "))
+	}
+	return columns, nil
+}
+
+func normalizeHeader(header string) string {
+	return strings.ToLower(strings.TrimSpace(header))
+}
+
+// unescapeNewlines reverses escapeNewlines, which exports use to keep a
+// description on one line
+func unescapeNewlines(s string) string {
+	return strings.ReplaceAll(s, `\n`, "\n")
+}
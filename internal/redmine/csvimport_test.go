@@ -0,0 +1,94 @@
+package redmine
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseImportCSV_AutoDetect(t *testing.T) {
+	// The headers issues_exportCSV writes, plus a custom field and a BOM
+	data := "\ufeffID,Subject,Assignee,Done %,Created,Severity,Description\n" +
+		`7,Fix login,Alice,50,2025-01-01,High,First\nSecond` + "\n" +
+		",,,,,,\n" +
+		"8,Typo,,,,,\n"
+	file, err := ParseImportCSV([]byte(data), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(file.Ignored) != 1 || file.Ignored[0] != "Created" {
+		t.Errorf("expected the read-only Created column to be ignored, got %v", file.Ignored)
+	}
+	if !file.HasField("id") || !file.HasField("assigned_to") || !file.HasField("done_ratio") || file.HasField("status") {
+		t.Errorf("unexpected columns: %+v", file.Columns)
+	}
+
+	if len(file.Rows) != 2 {
+		t.Fatalf("expected the blank row to be skipped, got %d rows", len(file.Rows))
+	}
+	first := file.Rows[0]
+	if first.Line != 2 || first.Fields["subject"] != "Fix login" || first.Fields["assigned_to"] != "Alice" ||
+		first.CustomFields["Severity"] != "High" || first.Fields["description"] != "First\nSecond" {
+		t.Errorf("unexpected first row: %+v", first)
+	}
+	if second := file.Rows[1]; second.Line != 4 || len(second.Fields) != 2 || len(second.CustomFields) != 0 {
+		t.Errorf("expected blank cells to be left out, got %+v", second)
+	}
+}
+
+func TestParseImportCSV_Mapping(t *testing.T) {
+	data := "Task,Owner,Sprint,Comment\nWrite docs,Bob,S1,ignored\n"
+	file, err := ParseImportCSV([]byte(data), map[string]string{"task": "subject", "Owner": "assigned_to", "Sprint": "Iteration"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	row := file.Rows[0]
+	if row.Fields["subject"] != "Write docs" || row.Fields["assigned_to"] != "Bob" || row.CustomFields["Iteration"] != "S1" {
+		t.Errorf("unexpected row: %+v", row)
+	}
+	if len(file.Ignored) != 1 || file.Ignored[0] != "Comment" {
+		t.Errorf("expected unmapped columns to be ignored, got %v", file.Ignored)
+	}
+
+	if _, err := ParseImportCSV([]byte(data), map[string]string{"Title": "subject"}); err == nil || !strings.Contains(err.Error(), "title") {
+		t.Errorf("expected an error for a mapped column missing from the header, got %v", err)
+	}
+	if _, err := ParseImportCSV([]byte(data), map[string]string{"Task": "subject", "Comment": "Subject"}); err == nil || !strings.Contains(err.Error(), "both map to subject") {
+		t.Errorf("expected an error for two columns mapped to one field, got %v", err)
+	}
+}
+
+func TestParseImportCSV_Errors(t *testing.T) {
+	if _, err := ParseImportCSV(nil, nil); err == nil || !strings.Contains(err.Error(), "empty") {
+		t.Errorf("expected an error for an empty file, got %v", err)
+	}
+	if _, err := ParseImportCSV([]byte("Subject\n"), nil); err == nil || !strings.Contains(err.Error(), "no data rows") {
+		t.Errorf("expected an error for a file without rows, got %v", err)
+	}
+	if _, err := ParseImportCSV([]byte("Subject\n\"unterminated\n"), nil); err == nil || !strings.Contains(err.Error(), "invalid CSV") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("Subject\n")
+	for i := range MaxImportRows + 1 {
+		fmt.Fprintf(&b, "Issue %d\n", i)
+	}
+	if _, err := ParseImportCSV(b.Bytes(), nil); err == nil || !strings.Contains(err.Error(), "more than 200") {
+		t.Errorf("expected the row cap to be enforced, got %v", err)
+	}
+}
+
+func TestParseImportMode(t *testing.T) {
+	if mode, err := ParseImportMode(""); err != nil || mode != ImportCreateOnly {
+		t.Errorf("expected create_only by default, got %q %v", mode, err)
+	}
+	if mode, err := ParseImportMode(" Upsert_By_Subject "); err != nil || mode != ImportUpsertBySubject {
+		t.Errorf("expected upsert_by_subject, got %q %v", mode, err)
+	}
+	if _, err := ParseImportMode("replace"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
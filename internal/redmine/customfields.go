@@ -0,0 +1,177 @@
+package redmine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProjectCustomFields returns the issue custom fields available in a project,
+// merged from every source that answers so the result doesn't depend on the
+// project already having issues:
+//
+//  1. the project detail (include=issue_custom_fields) gives the fields enabled
+//     in the project, without admin rights
+//  2. the admin custom field list adds formats, possible values, required flags
+//     and tracker scoping
+//  3. the static rules file fills in values and per-tracker required fields,
+//     and is the only source when neither API is available
+//
+// trackerID 0 skips tracker filtering. rules may be nil.
+func (r *Resolver) ProjectCustomFields(projectID, trackerID int, rules *CustomFieldRules) ([]CustomFieldDefinition, error) {
+	byID := make(map[int]*CustomFieldDefinition)
+	var errs []error
+
+	// Tier 1: fields enabled in the project
+	var enabled map[int]bool
+	if project, err := r.client.GetProjectDetail(projectID, []string{"issue_custom_fields"}); err != nil {
+		errs = append(errs, fmt.Errorf("project detail: %w", err))
+	} else {
+		enabled = make(map[int]bool, len(project.IssueCustomFields))
+		for _, cf := range project.IssueCustomFields {
+			enabled[cf.ID] = true
+			byID[cf.ID] = &CustomFieldDefinition{ID: cf.ID, Name: cf.Name, FieldFormat: "unknown"}
+		}
+	}
+
+	// Tier 2: admin definitions, restricted to the project's fields when known
+	admin, adminErr := r.GetCustomFields()
+	if adminErr != nil {
+		errs = append(errs, adminErr)
+	}
+	for _, cf := range admin {
+		if cf.CustomizedType != "issue" || (enabled != nil && !enabled[cf.ID]) {
+			continue
+		}
+		if trackerID > 0 && len(cf.Trackers) > 0 && !slices.ContainsFunc(cf.Trackers, func(t IDName) bool { return t.ID == trackerID }) {
+			// Not used by this tracker
+			delete(byID, cf.ID)
+			continue
+		}
+		def := byID[cf.ID]
+		if def == nil {
+			def = &CustomFieldDefinition{ID: cf.ID}
+			byID[cf.ID] = def
+		}
+		def.Name = cf.Name
+		def.FieldFormat = cf.FieldFormat
+		def.Required = cf.IsRequired
+		def.PossibleValues = nil
+		for _, v := range cf.PossibleValues {
+			def.PossibleValues = append(def.PossibleValues, v.Value)
+		}
+	}
+
+	// Tier 3: rules file. Enrich what the API reported; only add fields of its
+	// own when no API source was available.
+	apiAvailable := enabled != nil || adminErr == nil
+	if rules != nil {
+		for idStr, rule := range rules.Fields {
+			id, convErr := strconv.Atoi(idStr)
+			if convErr != nil {
+				continue
+			}
+			def := byID[id]
+			if def == nil {
+				if apiAvailable {
+					continue
+				}
+				def = &CustomFieldDefinition{ID: id, Name: rule.Name, FieldFormat: "unknown"}
+				byID[id] = def
+			}
+			if len(def.PossibleValues) == 0 {
+				def.PossibleValues = rule.Values
+			}
+			if trackerID > 0 && slices.Contains(rule.RequiredByTrackers, trackerID) {
+				def.Required = true
+			}
+		}
+	}
+
+	if len(byID) == 0 && !apiAvailable && (rules == nil || len(rules.Fields) == 0) {
+		return nil, fmt.Errorf("could not determine custom fields: %w", errors.Join(errs...))
+	}
+
+	definitions := make([]CustomFieldDefinition, 0, len(byID))
+	for _, def := range byID {
+		definitions = append(definitions, *def)
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].ID < definitions[j].ID })
+	return definitions, nil
+}
+
+// NormalizeCustomFieldValue validates a value against a field definition, accepting
+// common spellings for boolean fields ("yes", "true", "1" become "1"), requiring
+// YYYY-MM-DD for date fields, and matching list fields against their possible
+// values. Arrays (multi-value fields) are checked item by item. Empty strings pass
+// through so fields can be cleared.
+func NormalizeCustomFieldValue(def CustomFieldDefinition, value any) (any, error) {
+	if items, ok := value.([]any); ok {
+		result := make([]any, len(items))
+		for i, item := range items {
+			normalized, err := NormalizeCustomFieldValue(def, item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = normalized
+		}
+		return result, nil
+	}
+
+	switch def.FieldFormat {
+	case "bool":
+		return normalizeBool(def, value)
+	case "date":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid value %v for %s (ID: %d). Expected a date as YYYY-MM-DD string", value, def.Name, def.ID)
+		}
+		if s != "" {
+			if _, err := time.Parse("2006-01-02", s); err != nil {
+				return nil, fmt.Errorf("invalid value %q for %s (ID: %d). Expected a date as YYYY-MM-DD", s, def.Name, def.ID)
+			}
+		}
+		return s, nil
+	case "list", "unknown":
+		// Enumeration, user and version fields list IDs rather than labels, so only
+		// plain lists (and fields known only from the rules file) are matched
+		s, ok := value.(string)
+		if !ok || s == "" || len(def.PossibleValues) == 0 {
+			return value, nil
+		}
+		return matchAllowedValue(def.Name, def.ID, def.PossibleValues, s)
+	}
+	return value, nil
+}
+
+func normalizeBool(def CustomFieldDefinition, value any) (any, error) {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case float64:
+		if v == 1 || v == 0 {
+			return strconv.Itoa(int(v)), nil
+		}
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "1", "true", "yes", "y", "on":
+			return "1", nil
+		case "0", "false", "no", "n", "off":
+			return "0", nil
+		case "":
+			return "", nil
+		}
+	}
+	shown := fmt.Sprintf("%v", value)
+	if s, ok := value.(string); ok {
+		shown = strconv.Quote(s)
+	}
+	return nil, fmt.Errorf("invalid value %s for %s (ID: %d). Expected a boolean: yes/no, true/false or 1/0", shown, def.Name, def.ID)
+}
@@ -0,0 +1,193 @@
+package redmine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const adminCustomFieldsJSON = `{"custom_fields":[
+	{"id":1,"name":"Severity","customized_type":"issue","field_format":"list","is_required":true,
+	 "possible_values":[{"value":"Low"},{"value":"High"}],"trackers":[{"id":1,"name":"Bug"}]},
+	{"id":2,"name":"Customer","customized_type":"issue","field_format":"string"},
+	{"id":3,"name":"Other project field","customized_type":"issue","field_format":"string"},
+	{"id":4,"name":"Department","customized_type":"user","field_format":"string"}
+]}`
+
+// newCustomFieldServer mocks the project detail and admin custom field endpoints;
+// a false flag makes that endpoint fail with 403
+func newCustomFieldServer(t *testing.T, projectOK, adminOK bool) *Resolver {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /projects/1.json", func(w http.ResponseWriter, r *http.Request) {
+		if !projectOK {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Query().Get("include") != "issue_custom_fields" {
+			t.Errorf("expected include=issue_custom_fields, got %q", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"project":{"id":1,"name":"New project","issue_custom_fields":[{"id":1,"name":"Severity"},{"id":2,"name":"Customer"}]}}`))
+	})
+	mux.HandleFunc("GET /custom_fields.json", func(w http.ResponseWriter, r *http.Request) {
+		if !adminOK {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(adminCustomFieldsJSON))
+	})
+	mux.HandleFunc("GET /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		// The project has no issues to inspect
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return NewResolver(NewClient(ts.URL, "test-key"))
+}
+
+var testRules = &CustomFieldRules{Fields: map[string]CustomFieldRule{
+	"2":  {Name: "Customer", Values: []string{"ACME", "Globex"}, RequiredByTrackers: []int{2}},
+	"10": {Name: "Rules only", Values: []string{"A"}},
+}}
+
+func fieldsByID(defs []CustomFieldDefinition) map[int]CustomFieldDefinition {
+	m := make(map[int]CustomFieldDefinition, len(defs))
+	for _, d := range defs {
+		m[d.ID] = d
+	}
+	return m
+}
+
+func TestProjectCustomFields_ProjectDetailOnly(t *testing.T) {
+	resolver := newCustomFieldServer(t, true, false)
+
+	defs, err := resolver.ProjectCustomFields(1, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(defs) != 2 || defs[0].Name != "Severity" || defs[1].Name != "Customer" {
+		t.Errorf("expected project fields without issues or admin rights, got %+v", defs)
+	}
+}
+
+func TestProjectCustomFields_AdminEnrichesProjectFields(t *testing.T) {
+	resolver := newCustomFieldServer(t, true, true)
+
+	defs, err := resolver.ProjectCustomFields(1, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := fieldsByID(defs)
+	if len(byID) != 2 {
+		t.Fatalf("expected only the project's issue fields, got %+v", defs)
+	}
+	if sev := byID[1]; sev.FieldFormat != "list" || !sev.Required || len(sev.PossibleValues) != 2 {
+		t.Errorf("expected admin details for Severity, got %+v", sev)
+	}
+
+	// Severity is scoped to the Bug tracker
+	defs, _ = resolver.ProjectCustomFields(1, 2, nil)
+	if _, ok := fieldsByID(defs)[1]; ok || len(defs) != 1 {
+		t.Errorf("expected tracker filtering to drop Severity, got %+v", defs)
+	}
+}
+
+func TestProjectCustomFields_AdminOnly(t *testing.T) {
+	resolver := newCustomFieldServer(t, false, true)
+
+	defs, err := resolver.ProjectCustomFields(1, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := fieldsByID(defs)
+	if len(byID) != 3 {
+		t.Errorf("expected all issue fields from the admin API, got %+v", defs)
+	}
+	if _, ok := byID[4]; ok {
+		t.Error("expected non-issue fields to be excluded")
+	}
+}
+
+func TestProjectCustomFields_RulesOnly(t *testing.T) {
+	resolver := newCustomFieldServer(t, false, false)
+
+	if _, err := resolver.ProjectCustomFields(1, 0, nil); err == nil {
+		t.Fatal("expected error when no source is available")
+	}
+
+	defs, err := resolver.ProjectCustomFields(1, 2, testRules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := fieldsByID(defs)
+	if len(byID) != 2 || byID[10].Name != "Rules only" {
+		t.Errorf("expected fields from the rules file, got %+v", defs)
+	}
+	if !byID[2].Required {
+		t.Errorf("expected Customer required for tracker 2, got %+v", byID[2])
+	}
+}
+
+func TestProjectCustomFields_RulesEnrichAPIFields(t *testing.T) {
+	resolver := newCustomFieldServer(t, true, false)
+
+	defs, err := resolver.ProjectCustomFields(1, 2, testRules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := fieldsByID(defs)
+	if _, ok := byID[10]; ok {
+		t.Error("expected rules-only fields to be skipped when the project lists its fields")
+	}
+	if c := byID[2]; len(c.PossibleValues) != 2 || !c.Required {
+		t.Errorf("expected rules values and required flag on Customer, got %+v", c)
+	}
+}
+
+func TestNormalizeCustomFieldValue(t *testing.T) {
+	flag := CustomFieldDefinition{ID: 1, Name: "Regression", FieldFormat: "bool"}
+	due := CustomFieldDefinition{ID: 2, Name: "Release date", FieldFormat: "date"}
+	list := CustomFieldDefinition{ID: 3, Name: "Component", FieldFormat: "list", PossibleValues: []string{"UI", "Backend"}}
+	enum := CustomFieldDefinition{ID: 4, Name: "Team", FieldFormat: "enumeration", PossibleValues: []string{"7", "8"}}
+
+	tests := []struct {
+		def     CustomFieldDefinition
+		value   any
+		want    any
+		wantErr string
+	}{
+		{flag, "Yes", "1", ""},
+		{flag, "false", "0", ""},
+		{flag, true, "1", ""},
+		{flag, float64(0), "0", ""},
+		{flag, "maybe", nil, `invalid value "maybe" for Regression (ID: 1). Expected a boolean`},
+		{due, "2026-03-01", "2026-03-01", ""},
+		{due, "", "", ""},
+		{due, "03/01/2026", nil, "Expected a date as YYYY-MM-DD"},
+		{list, "backend", "Backend", ""},
+		{list, "Database", nil, "Valid values: UI, Backend"},
+		{enum, "Platform", "Platform", ""},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeCustomFieldValue(tt.def, tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s %v: expected error containing %q, got %v", tt.def.Name, tt.value, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s %v: got %v, %v; want %v", tt.def.Name, tt.value, got, err, tt.want)
+		}
+	}
+
+	multi, err := NormalizeCustomFieldValue(list, []any{"ui", "Backend"})
+	if err != nil || fmt.Sprint(multi) != "[UI Backend]" {
+		t.Errorf("expected each item to be matched, got %v, %v", multi, err)
+	}
+	if _, err := NormalizeCustomFieldValue(list, []any{"UI", "Nope"}); err == nil {
+		t.Error("expected an invalid item to be rejected")
+	}
+}
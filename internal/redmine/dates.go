@@ -0,0 +1,154 @@
+package redmine
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DateLayout is the date format of Redmine's API
+const DateLayout = "2006-01-02"
+
+// DatePeriods are the period names ParseDateRange accepts
+var DatePeriods = []string{
+	"this_week", "last_week", "this_month", "last_month", "this_quarter", "last_quarter",
+	"this_year", "last_year", "last_7_days", "last_30_days",
+}
+
+// DateExpressions describes what ParseDateRange accepts, for errors and tool
+// descriptions
+var DateExpressions = "YYYY-MM-DD, today, yesterday, tomorrow, 'N days ago', 'N weeks ago', YYYY-Www (ISO week), " +
+	"start_of_week, end_of_week, start_of_month, end_of_month, start_of_last_month, end_of_last_month, " +
+	"or a period: " + strings.Join(DatePeriods, ", ")
+
+// TimezoneFromEnv loads REDMINE_MCP_TIMEZONE once per process, the zone that
+// decides what "today" is. Unset or invalid values fall back to the server's
+// local time.
+var TimezoneFromEnv = sync.OnceValue(func() *time.Location {
+	name := os.Getenv("REDMINE_MCP_TIMEZONE")
+	loc, err := LoadTimezone(name)
+	if err != nil {
+		slog.Warn("ignoring invalid REDMINE_MCP_TIMEZONE", "value", name, "error", err)
+		return time.Local
+	}
+	if name != "" {
+		slog.Info("date math uses timezone", "timezone", loc.String())
+	}
+	return loc
+})
+
+// LoadTimezone loads an IANA timezone such as "Asia/Taipei". An empty name or
+// "local" is the server's local time.
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q (use an IANA name such as Asia/Taipei or UTC)", name)
+	}
+	return loc, nil
+}
+
+var (
+	agoPattern     = regexp.MustCompile(`^(\d+)\s+(day|week)s?\s+ago$`)
+	isoWeekPattern = regexp.MustCompile(`^(\d{4})-w(\d{1,2})$`)
+)
+
+// ParseDateRange resolves a date expression relative to now to the days it
+// covers: a single day for dates such as "yesterday", several for ISO weeks
+// and periods such as "last_month". Days are counted in now's location. Week
+// periods start on the configured week start; ISO weeks always start on
+// Monday.
+func ParseDateRange(expr string, now time.Time, week WorkWeek) (from, to time.Time, err error) {
+	s := strings.ToLower(strings.TrimSpace(expr))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	weekStart := week.StartOf(today)
+	firstOfMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+	firstOfLastMonth := firstOfMonth.AddDate(0, -1, 0)
+	firstOfQuarter := time.Date(today.Year(), today.Month()-(today.Month()-1)%3, 1, 0, 0, 0, 0, today.Location())
+	firstOfYear := time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, today.Location())
+
+	switch s {
+	case "today":
+		return singleDay(today)
+	case "yesterday":
+		return singleDay(today.AddDate(0, 0, -1))
+	case "tomorrow":
+		return singleDay(today.AddDate(0, 0, 1))
+	case "start_of_week":
+		return singleDay(weekStart)
+	case "end_of_week":
+		return singleDay(weekStart.AddDate(0, 0, 6))
+	case "start_of_month":
+		return singleDay(firstOfMonth)
+	case "end_of_month":
+		return singleDay(firstOfMonth.AddDate(0, 1, -1))
+	case "start_of_last_month":
+		return singleDay(firstOfLastMonth)
+	case "end_of_last_month":
+		return singleDay(firstOfMonth.AddDate(0, 0, -1))
+	case "this_week":
+		return weekStart, weekStart.AddDate(0, 0, 6), nil
+	case "last_week":
+		return weekStart.AddDate(0, 0, -7), weekStart.AddDate(0, 0, -1), nil
+	case "this_month":
+		return firstOfMonth, firstOfMonth.AddDate(0, 1, -1), nil
+	case "last_month":
+		return firstOfLastMonth, firstOfMonth.AddDate(0, 0, -1), nil
+	case "this_quarter":
+		return firstOfQuarter, firstOfQuarter.AddDate(0, 3, -1), nil
+	case "last_quarter":
+		return firstOfQuarter.AddDate(0, -3, 0), firstOfQuarter.AddDate(0, 0, -1), nil
+	case "this_year":
+		return firstOfYear, firstOfYear.AddDate(1, 0, -1), nil
+	case "last_year":
+		return firstOfYear.AddDate(-1, 0, 0), firstOfYear.AddDate(0, 0, -1), nil
+	case "last_7_days":
+		// Rolling windows end today
+		return today.AddDate(0, 0, -6), today, nil
+	case "last_30_days":
+		return today.AddDate(0, 0, -29), today, nil
+	}
+
+	if t, err := time.ParseInLocation(DateLayout, s, now.Location()); err == nil {
+		return singleDay(t)
+	}
+	if m := agoPattern.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == "week" {
+			n *= 7
+		}
+		return singleDay(today.AddDate(0, 0, -n))
+	}
+	if m := isoWeekPattern.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		n, _ := strconv.Atoi(m[2])
+		// January 4th is always in ISO week 1
+		first := DefaultWorkWeek.StartOf(time.Date(year, time.January, 4, 0, 0, 0, 0, now.Location()))
+		start := first.AddDate(0, 0, 7*(n-1))
+		if y, w := start.ISOWeek(); n < 1 || y != year || w != n {
+			return time.Time{}, time.Time{}, fmt.Errorf("unrecognized date %q: %d has no week %d", expr, year, n)
+		}
+		return start, start.AddDate(0, 0, 6), nil
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("unrecognized date %q; accepted: %s", expr, DateExpressions)
+}
+
+// ParseDate resolves a date expression relative to now to a single day, the
+// first day of a range such as an ISO week
+func ParseDate(expr string, now time.Time, week WorkWeek) (time.Time, error) {
+	from, _, err := ParseDateRange(expr, now, week)
+	return from, err
+}
+
+func singleDay(t time.Time) (time.Time, time.Time, error) {
+	return t, t, nil
+}
@@ -0,0 +1,163 @@
+package redmine
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDateRange(t *testing.T) {
+	// A Sunday, so week math has to treat it as the end of the week
+	now := time.Date(2025, time.March, 2, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		from, to string
+	}{
+		{"2025-01-15", "2025-01-15", "2025-01-15"},
+		{" Today ", "2025-03-02", "2025-03-02"},
+		{"yesterday", "2025-03-01", "2025-03-01"},
+		{"tomorrow", "2025-03-03", "2025-03-03"},
+		{"7 days ago", "2025-02-23", "2025-02-23"},
+		{"1 day ago", "2025-03-01", "2025-03-01"},
+		{"2 weeks ago", "2025-02-16", "2025-02-16"},
+		{"start_of_week", "2025-02-24", "2025-02-24"},
+		{"end_of_week", "2025-03-02", "2025-03-02"},
+		{"start_of_month", "2025-03-01", "2025-03-01"},
+		{"end_of_month", "2025-03-31", "2025-03-31"},
+		{"start_of_last_month", "2025-02-01", "2025-02-01"},
+		{"end_of_last_month", "2025-02-28", "2025-02-28"},
+		{"this_week", "2025-02-24", "2025-03-02"},
+		{"last_week", "2025-02-17", "2025-02-23"},
+		{"this_month", "2025-03-01", "2025-03-31"},
+		{"last_month", "2025-02-01", "2025-02-28"},
+		{"2024-W12", "2024-03-18", "2024-03-24"},
+		{"2025-w1", "2024-12-30", "2025-01-05"},
+		{"2020-W53", "2020-12-28", "2021-01-03"},
+	}
+	for _, tt := range tests {
+		from, to, err := ParseDateRange(tt.expr, now, DefaultWorkWeek)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.expr, err)
+			continue
+		}
+		if got := from.Format(DateLayout); got != tt.from {
+			t.Errorf("%q: expected from %s, got %s", tt.expr, tt.from, got)
+		}
+		if got := to.Format(DateLayout); got != tt.to {
+			t.Errorf("%q: expected to %s, got %s", tt.expr, tt.to, got)
+		}
+	}
+}
+
+func TestParseDateRange_Invalid(t *testing.T) {
+	now := time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC)
+	for _, expr := range []string{"", "next_quarter", "2025-02-30", "03/02/2025", "days ago", "2025-W54", "2025-W53"} {
+		_, _, err := ParseDateRange(expr, now, DefaultWorkWeek)
+		if err == nil {
+			t.Errorf("%q: expected an error", expr)
+			continue
+		}
+		if !strings.Contains(err.Error(), "has no week") && !strings.Contains(err.Error(), "YYYY-MM-DD, today") {
+			t.Errorf("%q: expected the error to list accepted formats, got %v", expr, err)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 0, 0, 0, 0, time.UTC)
+	got, err := ParseDate("last_week", now, DefaultWorkWeek)
+	if err != nil || got.Format(DateLayout) != "2025-03-03" {
+		t.Errorf("expected a range to resolve to its first day, got %v %v", got, err)
+	}
+}
+
+func TestParseDateRange_PeriodBoundaries(t *testing.T) {
+	tests := []struct {
+		now      string
+		period   string
+		from, to string
+	}{
+		// Sunday belongs to the week that started the Monday before
+		{"2025-03-02", "this_week", "2025-02-24", "2025-03-02"},
+		{"2025-03-02", "last_week", "2025-02-17", "2025-02-23"},
+		{"2025-03-03", "this_week", "2025-03-03", "2025-03-09"},
+		{"2025-03-03", "last_week", "2025-02-24", "2025-03-02"},
+		// Weeks spanning a year boundary
+		{"2025-01-01", "this_week", "2024-12-30", "2025-01-05"},
+		{"2025-01-05", "last_week", "2024-12-23", "2024-12-29"},
+		// Months, including a leap February and January's previous month
+		{"2024-02-29", "this_month", "2024-02-01", "2024-02-29"},
+		{"2024-03-31", "last_month", "2024-02-01", "2024-02-29"},
+		{"2025-01-15", "last_month", "2024-12-01", "2024-12-31"},
+		// Quarters
+		{"2025-01-01", "this_quarter", "2025-01-01", "2025-03-31"},
+		{"2025-03-31", "this_quarter", "2025-01-01", "2025-03-31"},
+		{"2025-05-20", "this_quarter", "2025-04-01", "2025-06-30"},
+		{"2025-12-31", "this_quarter", "2025-10-01", "2025-12-31"},
+		{"2025-02-10", "last_quarter", "2024-10-01", "2024-12-31"},
+		{"2025-08-10", "last_quarter", "2025-04-01", "2025-06-30"},
+		// Years
+		{"2024-02-29", "this_year", "2024-01-01", "2024-12-31"},
+		{"2025-01-01", "last_year", "2024-01-01", "2024-12-31"},
+		// Rolling windows include today
+		{"2025-03-02", "last_7_days", "2025-02-24", "2025-03-02"},
+		{"2024-03-01", "last_30_days", "2024-02-01", "2024-03-01"},
+	}
+	for _, tt := range tests {
+		now, _ := time.Parse(DateLayout, tt.now)
+		from, to, err := ParseDateRange(tt.period, now.Add(23*time.Hour), DefaultWorkWeek)
+		if err != nil {
+			t.Errorf("%s on %s: unexpected error: %v", tt.period, tt.now, err)
+			continue
+		}
+		if got := from.Format(DateLayout) + ".." + to.Format(DateLayout); got != tt.from+".."+tt.to {
+			t.Errorf("%s on %s: expected %s..%s, got %s", tt.period, tt.now, tt.from, tt.to, got)
+		}
+	}
+}
+
+func TestParseDateRange_Location(t *testing.T) {
+	taipei, err := LoadTimezone("Asia/Taipei")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 23:30 on Sunday in UTC is already Monday morning in Taipei
+	now := time.Date(2025, time.March, 2, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		loc      *time.Location
+		expr     string
+		from, to string
+	}{
+		{time.UTC, "today", "2025-03-02", "2025-03-02"},
+		{taipei, "today", "2025-03-03", "2025-03-03"},
+		{taipei, "yesterday", "2025-03-02", "2025-03-02"},
+		{time.UTC, "this_week", "2025-02-24", "2025-03-02"},
+		{taipei, "this_week", "2025-03-03", "2025-03-09"},
+	}
+	for _, tt := range tests {
+		from, to, err := ParseDateRange(tt.expr, now.In(tt.loc), DefaultWorkWeek)
+		if err != nil {
+			t.Errorf("%s in %s: unexpected error: %v", tt.expr, tt.loc, err)
+			continue
+		}
+		if got := from.Format(DateLayout) + ".." + to.Format(DateLayout); got != tt.from+".."+tt.to {
+			t.Errorf("%s in %s: expected %s..%s, got %s", tt.expr, tt.loc, tt.from, tt.to, got)
+		}
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	for _, name := range []string{"", " local ", "Local"} {
+		if loc, err := LoadTimezone(name); err != nil || loc != time.Local {
+			t.Errorf("%q: expected local time, got %v %v", name, loc, err)
+		}
+	}
+	if loc, err := LoadTimezone("Asia/Taipei"); err != nil || loc.String() != "Asia/Taipei" {
+		t.Errorf("expected Asia/Taipei, got %v %v", loc, err)
+	}
+	if _, err := LoadTimezone("Mars/Olympus"); err == nil || !strings.Contains(err.Error(), "IANA") {
+		t.Errorf("expected an error naming the expected format, got %v", err)
+	}
+}
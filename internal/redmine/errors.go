@@ -0,0 +1,50 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// APIError is returned when Redmine responds with an error status.
+// Errors holds the validation messages from a {"errors": [...]} body, if any.
+type APIError struct {
+	StatusCode int
+	Errors     []string
+	Body       string
+}
+
+// newAPIError builds an APIError, parsing Redmine's validation messages from the body
+func newAPIError(status int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: status, Body: string(body)}
+
+	var resp struct {
+		Errors []string `json:"errors"`
+	}
+	if json.Unmarshal(body, &resp) == nil {
+		apiErr.Errors = resp.Errors
+	}
+
+	return apiErr
+}
+
+// Error renders validation messages as a bullet list, falling back to the raw body
+func (e *APIError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+	}
+	if len(e.Errors) == 1 {
+		return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Errors[0])
+	}
+	return fmt.Sprintf("API error (status %d):\n- %s", e.StatusCode, strings.Join(e.Errors, "\n- "))
+}
+
+// AsAPIError returns the APIError wrapped in err, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
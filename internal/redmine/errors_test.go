@@ -0,0 +1,50 @@
+package redmine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{"validation list", 422, `{"errors":["Subject cannot be blank","Tracker is not included in the list"]}`, "API error (status 422):\n- Subject cannot be blank\n- Tracker is not included in the list"},
+		{"single message", 422, `{"errors":["Subject cannot be blank"]}`, "API error (status 422): Subject cannot be blank"},
+		{"non-JSON body", 404, "Not Found", "API error (status 404): Not Found"},
+		{"empty body", 403, "", "API error (status 403): "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newAPIError(tt.status, []byte(tt.body)).Error(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCreateIssue_ValidationErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /issues.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"errors":["Subject cannot be blank","Severity cannot be blank"]}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := NewClient(ts.URL, "test-key")
+
+	_, err := client.CreateIssue(CreateIssueParams{ProjectID: 1, TrackerID: 1})
+	apiErr, ok := AsAPIError(fmt.Errorf("wrapped: %w", err))
+	if !ok {
+		t.Fatalf("expected APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity || len(apiErr.Errors) != 2 || apiErr.Errors[1] != "Severity cannot be blank" {
+		t.Errorf("unexpected APIError: %+v", apiErr)
+	}
+}
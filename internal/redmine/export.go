@@ -0,0 +1,295 @@
+package redmine
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Issue export formats
+const (
+	ExportCSV      = "csv"
+	ExportJSONL    = "jsonl"
+	ExportMarkdown = "markdown"
+)
+
+// ExportFormats lists the supported issue export formats
+var ExportFormats = []string{ExportCSV, ExportJSONL, ExportMarkdown}
+
+// ExportColumn is a column of a CSV or Markdown export: a built-in field or
+// a custom field
+type ExportColumn struct {
+	Key           string // Built-in column name, or cf_<ID> for a custom field
+	Header        string
+	CustomFieldID int
+}
+
+// builtinColumn is a column computed from the issue's standard fields
+type builtinColumn struct {
+	header string
+	value  func(Issue) string
+}
+
+// exportColumns maps column names, as accepted in a columns list, to columns
+var exportColumns = map[string]builtinColumn{
+	"id":              {"ID", func(i Issue) string { return strconv.Itoa(i.ID) }},
+	"subject":         {"Subject", func(i Issue) string { return i.Subject }},
+	"project":         {"Project", func(i Issue) string { return i.Project.Name }},
+	"tracker":         {"Tracker", func(i Issue) string { return i.Tracker.Name }},
+	"status":          {"Status", func(i Issue) string { return i.Status.Name }},
+	"priority":        {"Priority", func(i Issue) string { return i.Priority.Name }},
+	"assignee":        {"Assignee", assigneeName},
+	"author":          {"Author", func(i Issue) string { return i.Author.Name }},
+	"start":           {"Start", func(i Issue) string { return i.StartDate }},
+	"due":             {"Due", func(i Issue) string { return i.DueDate }},
+	"done_ratio":      {"Done %", func(i Issue) string { return strconv.Itoa(i.DoneRatio) }},
+	"estimated_hours": {"Estimated", func(i Issue) string { return formatHours(i.EstimatedHours) }},
+	"spent_hours":     {"Spent", func(i Issue) string { return formatHours(i.SpentHours) }},
+	"created":         {"Created", func(i Issue) string { return i.CreatedOn }},
+	"updated":         {"Updated", func(i Issue) string { return i.UpdatedOn }},
+	"description":     {"Description", func(i Issue) string { return escapeNewlines(i.Description) }},
+}
+
+// DefaultCSVColumns are the columns of a CSV export when none are given
+var DefaultCSVColumns = []string{"id", "subject", "project", "tracker", "status", "priority", "assignee", "created", "updated"}
+
+// DefaultMarkdownColumns are the columns of a Markdown export when none are given
+var DefaultMarkdownColumns = []string{"id", "subject", "status", "assignee", "due"}
+
+// ExportContentType returns the HTTP content type of an export format
+func ExportContentType(format string) string {
+	switch format {
+	case ExportJSONL:
+		return "application/x-ndjson"
+	case ExportMarkdown:
+		return "text/markdown; charset=utf-8"
+	default:
+		return "text/csv"
+	}
+}
+
+// ExportFileExtension returns the file extension of an export format
+func ExportFileExtension(format string) string {
+	if format == ExportMarkdown {
+		return "md"
+	}
+	return format
+}
+
+// ParseExportFormat validates a format name; empty means CSV
+func ParseExportFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		return ExportCSV, nil
+	}
+	if !slices.Contains(ExportFormats, format) {
+		return "", fmt.Errorf("invalid format: %s (valid: %s)", format, strings.Join(ExportFormats, ", "))
+	}
+	return format, nil
+}
+
+// ParseExportColumns parses a comma-separated column list. Names that aren't
+// built-in columns are looked up as custom fields with resolveCustomField,
+// which may be nil to allow built-in columns only. An empty list returns
+// defaults.
+func ParseExportColumns(value string, defaults []string, resolveCustomField func(name string) (int, error)) ([]ExportColumn, error) {
+	var columns []ExportColumn
+	for name := range strings.SplitSeq(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if col, ok := builtinExportColumn(strings.ToLower(name)); ok {
+			columns = append(columns, col)
+			continue
+		}
+		if resolveCustomField == nil {
+			return nil, fmt.Errorf("unknown column: %s (valid: %s)", name, strings.Join(builtinColumnNames(), ", "))
+		}
+		id, err := resolveCustomField(name)
+		if err != nil {
+			return nil, fmt.Errorf("unknown column: %s is not a built-in column (%s) or a custom field: %v", name, strings.Join(builtinColumnNames(), ", "), err)
+		}
+		columns = append(columns, ExportColumn{Key: "cf_" + strconv.Itoa(id), Header: name, CustomFieldID: id})
+	}
+	if len(columns) == 0 {
+		for _, name := range defaults {
+			col, _ := builtinExportColumn(name)
+			columns = append(columns, col)
+		}
+	}
+	return columns, nil
+}
+
+// WithDescriptionColumn appends the description column unless it is already there
+func WithDescriptionColumn(columns []ExportColumn) []ExportColumn {
+	if slices.ContainsFunc(columns, func(c ExportColumn) bool { return c.Key == "description" }) {
+		return columns
+	}
+	col, _ := builtinExportColumn("description")
+	return append(columns, col)
+}
+
+func builtinExportColumn(name string) (ExportColumn, bool) {
+	col, ok := exportColumns[name]
+	if !ok {
+		return ExportColumn{}, false
+	}
+	return ExportColumn{Key: name, Header: col.header}, true
+}
+
+func builtinColumnNames() []string {
+	names := make([]string, 0, len(exportColumns))
+	for k := range exportColumns {
+		names = append(names, k)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// WriteIssuesCSV writes issues as CSV with a header row
+func WriteIssuesCSV(w io.Writer, issues []Issue, columns []ExportColumn) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write(exportHeaders(columns))
+	for _, issue := range issues {
+		_ = writer.Write(exportRow(issue, columns))
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteIssuesMarkdown writes issues as a Markdown table with padded columns
+func WriteIssuesMarkdown(w io.Writer, issues []Issue, columns []ExportColumn) error {
+	rows := [][]string{exportHeaders(columns)}
+	for _, issue := range issues {
+		row := exportRow(issue, columns)
+		for i, cell := range row {
+			row[i] = markdownCell(cell)
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(columns))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell), 3)
+		}
+	}
+
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for i, cell := range cells {
+			b.WriteString(" " + cell + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)) + " |")
+		}
+		b.WriteString("\n")
+	}
+	writeRow(rows[0])
+	separator := make([]string, len(columns))
+	for i := range separator {
+		separator[i] = strings.Repeat("-", widths[i])
+	}
+	writeRow(separator)
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteIssuesJSONL writes one JSON object per issue and line. format builds
+// the object; the issue's custom fields are added to it by name.
+func WriteIssuesJSONL(w io.Writer, issues []Issue, format func(Issue) map[string]any) error {
+	enc := json.NewEncoder(w)
+	for _, issue := range issues {
+		obj := format(issue)
+		if len(issue.CustomFields) > 0 {
+			cf := make(map[string]any, len(issue.CustomFields))
+			for _, f := range issue.CustomFields {
+				cf[f.Name] = f.Value
+			}
+			obj["custom_fields"] = cf
+		}
+		if err := enc.Encode(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportHeaders(columns []ExportColumn) []string {
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.Header
+	}
+	return headers
+}
+
+func exportRow(issue Issue, columns []ExportColumn) []string {
+	row := make([]string, len(columns))
+	for i, col := range columns {
+		if col.CustomFieldID > 0 {
+			row[i] = customFieldText(issue, col.CustomFieldID)
+		} else {
+			row[i] = exportColumns[col.Key].value(issue)
+		}
+	}
+	return row
+}
+
+// customFieldText renders a custom field value for a cell, joining the
+// values of multi-value fields with ";". Redmine only returns fields that
+// apply to the issue, so a missing field is an empty cell.
+func customFieldText(issue Issue, id int) string {
+	for _, cf := range issue.CustomFields {
+		if cf.ID != id {
+			continue
+		}
+		switch v := cf.Value.(type) {
+		case nil:
+			return ""
+		case string:
+			return v
+		case []any:
+			parts := make([]string, len(v))
+			for i, item := range v {
+				parts[i] = fmt.Sprint(item)
+			}
+			return strings.Join(parts, ";")
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+// escapeNewlines writes line breaks as a literal \n so a value stays on one line
+func escapeNewlines(s string) string {
+	return strings.NewReplacer("\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// markdownCell keeps a value on one table row and escapes the cell separator
+func markdownCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func assigneeName(issue Issue) string {
+	if issue.AssignedTo == nil {
+		return ""
+	}
+	return issue.AssignedTo.Name
+}
+
+func formatHours(hours *float64) string {
+	if hours == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*hours, 'f', -1, 64)
+}
@@ -0,0 +1,156 @@
+package redmine
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func exportTestIssues() []Issue {
+	est := 4.5
+	return []Issue{
+		{ID: 1, Subject: "Fix login | SSO", Status: IDName{Name: "New"}, AssignedTo: &IDName{Name: "Alice"}, DueDate: "2025-03-10", EstimatedHours: &est,
+			CustomFields: []CustomField{{ID: 3, Name: "Component", Value: "UI"}}},
+		{ID: 12, Subject: "Multi\nline", Status: IDName{Name: "In Progress"}},
+	}
+}
+
+func columnKeys(cols []ExportColumn) string {
+	keys := make([]string, len(cols))
+	for i, c := range cols {
+		keys[i] = c.Key
+	}
+	return strings.Join(keys, ",")
+}
+
+func mustColumns(t *testing.T, value string) []ExportColumn {
+	t.Helper()
+	cols, err := ParseExportColumns(value, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return cols
+}
+
+func TestParseExportColumns(t *testing.T) {
+	cols, err := ParseExportColumns(" ID, due ,subject", DefaultCSVColumns, nil)
+	if err != nil || columnKeys(cols) != "id,due,subject" {
+		t.Errorf("unexpected columns %v (err %v)", cols, err)
+	}
+	if cols, _ := ParseExportColumns("", DefaultMarkdownColumns, nil); columnKeys(cols) != "id,subject,status,assignee,due" {
+		t.Errorf("expected defaults, got %v", cols)
+	}
+	if _, err := ParseExportColumns("id,bogus", nil, nil); err == nil || !strings.Contains(err.Error(), "valid: assignee") {
+		t.Errorf("expected an error listing valid columns, got %v", err)
+	}
+
+	resolve := func(name string) (int, error) {
+		if name == "Severity" {
+			return 7, nil
+		}
+		return 0, fmt.Errorf("custom field not found: %s", name)
+	}
+	cols, err = ParseExportColumns("id,Severity", nil, resolve)
+	if err != nil || columnKeys(cols) != "id,cf_7" || cols[1].Header != "Severity" {
+		t.Errorf("expected a custom field column, got %+v (err %v)", cols, err)
+	}
+	if _, err := ParseExportColumns("Root Cause", nil, resolve); err == nil || !strings.Contains(err.Error(), "custom field not found") {
+		t.Errorf("expected the lookup error, got %v", err)
+	}
+	if cols := WithDescriptionColumn(WithDescriptionColumn(mustColumns(t, "id"))); columnKeys(cols) != "id,description" {
+		t.Errorf("expected description once, got %v", cols)
+	}
+	if _, err := ParseExportFormat("xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestWriteIssuesCSV_Columns(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteIssuesCSV(&buf, exportTestIssues(), mustColumns(t, "id,assignee,estimated_hours")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "ID,Assignee,Estimated\n1,Alice,4.5\n12,,\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestWriteIssuesCSV_CustomFieldsAndDescription(t *testing.T) {
+	issues := []Issue{
+		{ID: 1, Description: "Steps:\r\n1. open, then close\n2. crash", CustomFields: []CustomField{
+			{ID: 7, Name: "Severity", Value: "High, blocking"},
+			{ID: 8, Name: "Root Cause", Value: []any{"Config", "Code"}},
+		}},
+		{ID: 2, Description: `He said "no"`, CustomFields: []CustomField{{ID: 8, Name: "Root Cause", Value: []any{}}}},
+	}
+	columns := []ExportColumn{
+		{Key: "id", Header: "ID"},
+		{Key: "cf_7", Header: "Severity", CustomFieldID: 7},
+		{Key: "cf_8", Header: "Root Cause", CustomFieldID: 8},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteIssuesCSV(&buf, issues, WithDescriptionColumn(columns)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Commas and quotes are quoted by encoding/csv; line breaks are escaped
+	// so every issue stays on one physical line
+	want := "ID,Severity,Root Cause,Description\n" +
+		"1,\"High, blocking\",Config;Code,\"Steps:\\n1. open, then close\\n2. crash\"\n" +
+		"2,,,\"He said \"\"no\"\"\"\n"
+	if buf.String() != want {
+		t.Errorf("expected %q, got %q", want, buf.String())
+	}
+
+	// Without escaping, a multi-line custom field value is still valid CSV
+	buf.Reset()
+	issues[0].CustomFields[0].Value = "line one\nline two"
+	if err := WriteIssuesCSV(&buf, issues[:1], columns[:2]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(records) != 2 || records[1][1] != "line one\nline two" {
+		t.Errorf("expected the value to round-trip, got %q (err %v)", records, err)
+	}
+}
+
+func TestWriteIssuesMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteIssuesMarkdown(&buf, exportTestIssues(), mustColumns(t, strings.Join(DefaultMarkdownColumns, ","))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "" +
+		"| ID  | Subject          | Status      | Assignee | Due        |\n" +
+		"| --- | ---------------- | ----------- | -------- | ---------- |\n" +
+		"| 1   | Fix login \\| SSO | New         | Alice    | 2025-03-10 |\n" +
+		"| 12  | Multi line       | In Progress |          |            |\n"
+	if buf.String() != want {
+		t.Errorf("unexpected table:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteIssuesJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	format := func(i Issue) map[string]any { return map[string]any{"id": i.ID} }
+	if err := WriteIssuesJSONL(&buf, exportTestIssues(), format); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per issue, got %q", buf.String())
+	}
+	var first map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("invalid JSON line: %v", err)
+	}
+	if first["custom_fields"].(map[string]any)["Component"] != "UI" {
+		t.Errorf("expected custom fields by name, got %v", first)
+	}
+	if strings.Contains(lines[1], "custom_fields") {
+		t.Errorf("expected no custom_fields for an issue without them, got %s", lines[1])
+	}
+}
@@ -0,0 +1,108 @@
+package redmine
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// DefaultTimeout bounds each request to Redmine when no timeout is configured
+const DefaultTimeout = 30 * time.Second
+
+// ClientOption configures a Client created by NewClient
+type ClientOption func(*Client)
+
+// WithHTTPClient sends the client's requests with hc, typically one built by
+// NewHTTPClient and shared by every client of a server so they share its
+// connections. A nil hc keeps the default.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		if hc != nil {
+			c.httpClient = hc
+		}
+	}
+}
+
+// WithTimeout bounds each request of the client by d. It applies to the
+// HTTP client set so far, so it goes after WithHTTPClient.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Timeout = d
+		c.httpClient = &hc
+	}
+}
+
+// HTTPConfig configures how clients connect to Redmine
+type HTTPConfig struct {
+	// Timeout bounds each request: one page of a paginated fetch, or one
+	// retry attempt, rather than a whole operation. 0 uses DefaultTimeout.
+	Timeout            time.Duration
+	CAFile             string // PEM file of root CAs trusted besides the system's, for a private CA
+	InsecureSkipVerify bool   // Skip TLS certificate verification; for testing only
+	ProxyURL           string // HTTP or HTTPS proxy; empty uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+}
+
+// NewHTTPClient builds the HTTP client of cfg
+func NewHTTPClient(cfg HTTPConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificate found in CA file %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.InsecureSkipVerify {
+		slog.Warn("!!! TLS certificate verification of Redmine is DISABLED: anyone on the network path can read and alter requests, including the API key. Use a CA file instead outside of testing !!!")
+		tlsConfig.InsecureSkipVerify = true
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil || (proxy.Scheme != "http" && proxy.Scheme != "https") || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: expected http://host:port or https://host:port", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
+
+// WithBasicAuth authenticates the client's requests with HTTP basic auth
+// instead of an API key, for Redmine instances with the REST API keys
+// disabled. The password is sent only in the Authorization header, never in
+// URLs or errors.
+func WithBasicAuth(username, password string) ClientOption {
+	return func(c *Client) {
+		c.username, c.password = username, password
+	}
+}
+
+// authenticate sets the credentials of the client on req
+func (c *Client) authenticate(req *http.Request) {
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+		return
+	}
+	req.Header.Set("X-Redmine-API-Key", c.apiKey)
+}
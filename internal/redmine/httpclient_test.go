@@ -0,0 +1,154 @@
+package redmine
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClient_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"user":{"id":1,"login":"jdoe"}}`))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  HTTPConfig
+		wantErr bool
+	}{
+		{"untrusted", HTTPConfig{}, true},
+		{"custom CA", HTTPConfig{CAFile: caFile}, false},
+		{"insecure", HTTPConfig{InsecureSkipVerify: true}, false},
+	}
+	for _, tt := range tests {
+		httpClient, err := NewHTTPClient(tt.config)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		client := NewClient(server.URL, "key", WithHTTPClient(httpClient))
+		client.retry.MaxRetries = 0
+		if _, err := client.GetCurrentUser(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNewHTTPClient_Invalid(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, config := range []HTTPConfig{
+		{CAFile: filepath.Join(t.TempDir(), "missing.pem")},
+		{CAFile: notPEM},
+		{ProxyURL: "proxy.example.com:3128"},
+		{ProxyURL: "ftp://proxy.example.com"},
+	} {
+		if _, err := NewHTTPClient(config); err == nil {
+			t.Errorf("expected an error for %+v", config)
+		}
+	}
+}
+
+func TestNewHTTPClient_Proxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		_, _ = w.Write([]byte(`{"user":{"id":1,"login":"jdoe"}}`))
+	}))
+	defer proxy.Close()
+
+	httpClient, err := NewHTTPClient(HTTPConfig{ProxyURL: proxy.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if httpClient.Timeout != DefaultTimeout {
+		t.Errorf("expected the default timeout, got %v", httpClient.Timeout)
+	}
+	client := NewClient("http://redmine.internal", "key", WithHTTPClient(httpClient))
+	if _, err := client.GetCurrentUser(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if proxied != "http://redmine.internal/users/current.json" {
+		t.Errorf("expected the request to go through the proxy, got %q", proxied)
+	}
+}
+
+func TestClientTimeout_PerRequest(t *testing.T) {
+	const pageDelay = 60 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/issues/1.json" {
+			time.Sleep(4 * pageDelay)
+		}
+		time.Sleep(pageDelay)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		issues := make([]string, limit)
+		for i := range issues {
+			issues[i] = fmt.Sprintf(`{"id":%d}`, offset+i+1)
+		}
+		_, _ = fmt.Fprintf(w, `{"issues":[%s],"total_count":400}`, strings.Join(issues, ","))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "key", WithTimeout(3*pageDelay))
+	client.retry.MaxRetries = 0
+
+	// Four pages take longer than the timeout, but each page is within it
+	issues, _, err := client.SearchIssuesAll(SearchIssuesParams{Limit: 400})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) != 400 {
+		t.Errorf("expected 400 issues, got %d", len(issues))
+	}
+
+	if _, err := client.GetIssue(1); err == nil {
+		t.Error("expected a request slower than the timeout to fail")
+	}
+}
+
+func TestWithBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if r.Header.Get("X-Redmine-API-Key") != "" || !ok || username != "jdoe" || password != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"user":{"id":3,"login":"jdoe"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", WithBasicAuth("jdoe", "s3cret"))
+	if !client.HasCredentials() {
+		t.Error("expected basic auth to count as credentials")
+	}
+	user, err := client.GetCurrentUser()
+	if err != nil || user.Login != "jdoe" {
+		t.Fatalf("expected jdoe, got %+v, %v", user, err)
+	}
+
+	// A wrong password is diagnosed without being echoed
+	client = NewClient(server.URL, "", WithBasicAuth("jdoe", "wrong-password"))
+	if _, err := client.GetCurrentUser(); err == nil || strings.Contains(err.Error(), "wrong-password") {
+		t.Errorf("expected an error without the password, got %v", err)
+	}
+	info, err := SelfTest(t.Context(), client)
+	if err == nil || !strings.Contains(err.Error(), "REDMINE_PASSWORD") || strings.Contains(info.Error, "wrong-password") {
+		t.Errorf("expected a username or password diagnosis, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package redmine
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+)
+
+// SwitchUserHeader makes Redmine run a request as another user when the API
+// key belongs to an administrator
+const SwitchUserHeader = "X-Redmine-Switch-User"
+
+// SwitchUserError is returned for a failed request made on behalf of
+// another user, explaining the statuses Redmine uses to refuse the switch
+type SwitchUserError struct {
+	Login string
+	Err   *APIError
+}
+
+func (e *SwitchUserError) Error() string {
+	switch e.Err.StatusCode {
+	case http.StatusPreconditionFailed:
+		return i18n.Sprintf("Redmine refused to act as %q (status 412): the login must belong to an active user and the API key to an administrator", e.Login)
+	case http.StatusForbidden:
+		return i18n.Sprintf("acting as %q was forbidden (status 403): %v", e.Login, e.Err)
+	}
+	return i18n.Sprintf("acting as %q: %v", e.Login, e.Err)
+}
+
+func (e *SwitchUserError) Unwrap() error {
+	return e.Err
+}
+
+// SwitchUser returns a copy of the client whose requests run as another
+// user, given by login or numeric ID, along with that user. Redmine silently
+// ignores the switch for non-admin keys, so the switch is confirmed with
+// /users/current.json before any write is made with the copy.
+func (c *Client) SwitchUser(loginOrID string) (*Client, *User, error) {
+	login := strings.TrimSpace(loginOrID)
+	if login == "" {
+		return nil, nil, i18n.Errorf("a login or user ID is required")
+	}
+	if id, err := strconv.Atoi(login); err == nil {
+		user, err := c.GetUser(id, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get user %d: %w", id, err)
+		}
+		// Redmine only shows logins to administrators
+		if user.Login == "" {
+			return nil, nil, i18n.Errorf("cannot act as user %d: only administrator API keys can act as another user", id)
+		}
+		login = user.Login
+	}
+
+	clone := *c
+	clone.switchUser = login
+	user, err := clone.GetCurrentUser()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.EqualFold(user.Login, login) {
+		return nil, nil, i18n.Errorf("Redmine ignored the request to act as %q: only administrator API keys can act as another user", login)
+	}
+	return &clone, user, nil
+}
@@ -0,0 +1,114 @@
+package redmine
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// switchUserServer emulates Redmine's handling of X-Redmine-Switch-User:
+// admin keys switch to an active login or get 412, other keys are ignored
+func switchUserServer(t *testing.T, gotSwitch *[]string) *httptest.Server {
+	t.Helper()
+	logins := map[string]int{"admin": 1, "jsmith": 2}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		login := "admin"
+		if r.Header.Get("X-Redmine-API-Key") == "user-key" {
+			login = "jsmith"
+		}
+		if sw := r.Header.Get(SwitchUserHeader); sw != "" {
+			*gotSwitch = append(*gotSwitch, r.Method+" "+r.URL.Path+" as "+sw)
+			if login == "admin" {
+				if _, ok := logins[sw]; !ok {
+					w.WriteHeader(http.StatusPreconditionFailed)
+					return
+				}
+				login = sw
+			}
+		}
+
+		switch {
+		case r.URL.Path == "/users/current.json":
+			_, _ = fmt.Fprintf(w, `{"user":{"id":%d,"login":%q,"firstname":"A","lastname":"B"}}`, logins[login], login)
+		case r.URL.Path == "/users/2.json":
+			if login == "admin" {
+				_, _ = w.Write([]byte(`{"user":{"id":2,"login":"jsmith"}}`))
+			} else {
+				_, _ = w.Write([]byte(`{"user":{"id":2}}`))
+			}
+		case r.URL.Path == "/time_entries.json" && login == "jsmith":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"time_entry":{"id":9}}`))
+		}
+	}))
+}
+
+func TestSwitchUser(t *testing.T) {
+	var switched []string
+	server := switchUserServer(t, &switched)
+	defer server.Close()
+
+	client := NewClient(server.URL, "admin-key")
+	for _, loginOrID := range []string{"jsmith", "2"} {
+		switched = nil
+		asUser, user, err := client.SwitchUser(loginOrID)
+		if err != nil {
+			t.Fatalf("SwitchUser(%q): %v", loginOrID, err)
+		}
+		if user.ID != 2 || user.Login != "jsmith" {
+			t.Errorf("SwitchUser(%q) returned user %+v, want jsmith", loginOrID, user)
+		}
+		if _, err := asUser.CreateTimeEntry(CreateTimeEntryParams{IssueID: 1, Hours: 1}); err == nil {
+			t.Error("expected Redmine's 403 for the switched user")
+		}
+		want := []string{"GET /users/current.json as jsmith", "POST /time_entries.json as jsmith"}
+		if strings.Join(switched, ", ") != strings.Join(want, ", ") {
+			t.Errorf("switched requests = %v, want %v", switched, want)
+		}
+	}
+
+	// The original client keeps acting as the key's owner
+	switched = nil
+	if _, err := client.CreateTimeEntry(CreateTimeEntryParams{IssueID: 1, Hours: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(switched) != 0 {
+		t.Errorf("expected no switch header on the original client, got %v", switched)
+	}
+}
+
+func TestSwitchUser_Errors(t *testing.T) {
+	var switched []string
+	server := switchUserServer(t, &switched)
+	defer server.Close()
+
+	// Unknown or locked logins get 412
+	_, _, err := NewClient(server.URL, "admin-key").SwitchUser("nobody")
+	var switchErr *SwitchUserError
+	if !errors.As(err, &switchErr) || switchErr.Err.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("expected a 412 SwitchUserError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `"nobody"`) || !strings.Contains(err.Error(), "administrator") {
+		t.Errorf("expected the error to explain the refusal, got %q", err)
+	}
+	if apiErr, ok := AsAPIError(err); !ok || apiErr.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("expected the APIError to stay reachable, got %v", err)
+	}
+
+	// Redmine ignores the header for non-admin keys
+	_, _, err = NewClient(server.URL, "user-key").SwitchUser("admin")
+	if err == nil || !strings.Contains(err.Error(), "administrator") {
+		t.Errorf("expected an error for a non-admin key, got %v", err)
+	}
+
+	// Non-admins can't see logins, so IDs can't be resolved
+	_, _, err = NewClient(server.URL, "user-key").SwitchUser("2")
+	if err == nil || !strings.Contains(err.Error(), "administrator") {
+		t.Errorf("expected an error resolving an ID with a non-admin key, got %v", err)
+	}
+}
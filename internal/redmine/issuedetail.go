@@ -0,0 +1,61 @@
+package redmine
+
+import "fmt"
+
+// IssueSpentTime is the time logged on an issue, in total and per user
+type IssueSpentTime struct {
+	TotalHours float64          `json:"total_hours"`
+	EntryCount int              `json:"entry_count"`
+	ByUser     []map[string]any `json:"by_user"`
+}
+
+// SpentTimeOnIssue fetches every time entry Redmine returns for an issue and
+// sums the hours, per user sorted by hours descending
+func (c *Client) SpentTimeOnIssue(issueID int) (*IssueSpentTime, error) {
+	entries, _, err := c.FetchTimeEntries(ListTimeEntriesParams{IssueID: issueID}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch time entries: %w", err)
+	}
+	byUser, total := AggregateTimeEntries(entries, []string{"user"}, nil)
+	return &IssueSpentTime{
+		TotalHours: total,
+		EntryCount: len(entries),
+		ByUser:     byUser,
+	}, nil
+}
+
+// IssueChild summarizes a subtask for issue detail views
+type IssueChild struct {
+	ID         int    `json:"id"`
+	Subject    string `json:"subject"`
+	Status     string `json:"status"`
+	AssignedTo string `json:"assigned_to,omitempty"`
+	DoneRatio  int    `json:"done_ratio"`
+}
+
+// ChildIssues fetches the direct subtasks of an issue, open and closed, up to
+// MaxSearchIssuesLimit
+func (c *Client) ChildIssues(issueID int) ([]IssueChild, error) {
+	issues, _, err := c.SearchIssuesAll(SearchIssuesParams{
+		ParentID: issueID,
+		StatusID: "*",
+		Sort:     "id:asc",
+		Limit:    MaxSearchIssuesLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subtasks: %w", err)
+	}
+	children := make([]IssueChild, len(issues))
+	for i, issue := range issues {
+		children[i] = IssueChild{
+			ID:        issue.ID,
+			Subject:   issue.Subject,
+			Status:    issue.Status.Name,
+			DoneRatio: issue.DoneRatio,
+		}
+		if issue.AssignedTo != nil {
+			children[i].AssignedTo = issue.AssignedTo.Name
+		}
+	}
+	return children, nil
+}
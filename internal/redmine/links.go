@@ -0,0 +1,48 @@
+package redmine
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Links builds the web UI URLs of Redmine objects, so output can carry
+// clickable links instead of bare IDs
+type Links struct {
+	baseURL string
+}
+
+// Links returns the link builder of the client's Redmine
+func (c *Client) Links() Links {
+	return Links{baseURL: c.baseURL}
+}
+
+// Issue returns the page of an issue
+func (l Links) Issue(id int) string {
+	return fmt.Sprintf("%s/issues/%d", l.baseURL, id)
+}
+
+// Project returns the overview page of a project; Redmine accepts the
+// identifier or the numeric ID
+func (l Links) Project(identifier string) string {
+	return l.baseURL + "/projects/" + url.PathEscape(identifier)
+}
+
+// Version returns the roadmap page of a version
+func (l Links) Version(id int) string {
+	return fmt.Sprintf("%s/versions/%d", l.baseURL, id)
+}
+
+// WikiPage returns a wiki page of a project
+func (l Links) WikiPage(project, title string) string {
+	return l.Project(project) + "/wiki/" + url.PathEscape(title)
+}
+
+// Attachment returns the direct download URL of an attachment
+func (l Links) Attachment(id int, filename string) string {
+	return fmt.Sprintf("%s/attachments/download/%d/%s", l.baseURL, id, url.PathEscape(filename))
+}
+
+// TimeEntry returns the edit page of a time entry, Redmine's only page for one
+func (l Links) TimeEntry(id int) string {
+	return fmt.Sprintf("%s/time_entries/%d/edit", l.baseURL, id)
+}
@@ -0,0 +1,20 @@
+package redmine
+
+import "testing"
+
+func TestLinks(t *testing.T) {
+	links := NewClient("https://redmine.example.com/", "key").Links()
+	tests := []struct{ got, want string }{
+		{links.Issue(42), "https://redmine.example.com/issues/42"},
+		{links.Project("web"), "https://redmine.example.com/projects/web"},
+		{links.Version(10), "https://redmine.example.com/versions/10"},
+		{links.WikiPage("3", "Release notes"), "https://redmine.example.com/projects/3/wiki/Release%20notes"},
+		{links.Attachment(7, "log #1.txt"), "https://redmine.example.com/attachments/download/7/log%20%231.txt"},
+		{links.TimeEntry(5), "https://redmine.example.com/time_entries/5/edit"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %s, want %s", tt.got, tt.want)
+		}
+	}
+}
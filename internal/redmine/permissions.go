@@ -0,0 +1,234 @@
+package redmine
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+)
+
+// Confidence of a capability in a permissions check
+const (
+	// ConfidenceConfirmed capabilities were shown by a request, or follow
+	// from a rule Redmine always applies (admin, closed project, module)
+	ConfidenceConfirmed = "confirmed"
+	// ConfidenceLikely capabilities are inferred from the roles' permissions;
+	// per-tracker limits and workflows can still refuse a change
+	ConfidenceLikely = "likely"
+	// ConfidenceUnknown capabilities could not be worked out
+	ConfidenceUnknown = "unknown"
+)
+
+// Capability is what a permissions check found about one action. Allowed
+// is nil when the confidence is unknown.
+type Capability struct {
+	Name       string `json:"name"`
+	Allowed    *bool  `json:"allowed"`
+	Confidence string `json:"confidence"`
+	Reason     string `json:"reason"`
+}
+
+// PermissionReport is what the API key can do on a project and, when one
+// was given, on one of its issues
+type PermissionReport struct {
+	User         IDName       `json:"user"`
+	Admin        bool         `json:"admin"`
+	Project      IDName       `json:"project"`
+	Issue        *IDName      `json:"issue,omitempty"`
+	Roles        []string     `json:"roles"`
+	Capabilities []Capability `json:"capabilities"`
+}
+
+// roleCapability is a write capability granted by a role permission, when
+// the project module holding the permission is enabled
+type roleCapability struct {
+	name       string
+	permission string
+	module     string // empty for permissions outside any module
+}
+
+// roleCapabilities are the write capabilities a permissions check infers from roles
+var roleCapabilities = []roleCapability{
+	{"add_issues", "add_issues", "issue_tracking"},
+	{"edit_issues", "edit_issues", "issue_tracking"},
+	{"add_notes", "add_issue_notes", "issue_tracking"},
+	{"log_time", "log_time", "time_tracking"},
+	{"manage_versions", "manage_versions", ""},
+}
+
+// projectStatusActive is the status of projects that are neither closed nor archived
+const projectStatusActive = 1
+
+// permissionCheck holds what CheckPermissions gathered to infer capabilities
+type permissionCheck struct {
+	user     *UserDetail
+	project  *ProjectDetail
+	issue    *Issue
+	member   bool
+	roles    []*RoleDetail // nil when rolesErr is set
+	rolesErr error
+}
+
+// CheckPermissions probes what the API key can do on a project, or on an
+// issue and its project when issueID is set. Redmine has no "my
+// permissions" API, so reads are confirmed with lightweight requests and
+// writes are inferred from the user's membership roles, the project's
+// enabled modules and its status, without changing anything.
+func CheckPermissions(c *Client, projectID, issueID int) (*PermissionReport, error) {
+	report := &PermissionReport{Roles: []string{}}
+	check := &permissionCheck{}
+
+	var issueCap *Capability
+	if issueID > 0 {
+		issue, err := c.GetIssue(issueID)
+		switch {
+		case err == nil:
+			check.issue = issue
+			projectID = issue.Project.ID
+			report.Issue = &IDName{ID: issue.ID, Name: issue.Subject}
+			issueCap = confirmedCapability("view_issue", true, i18n.Sprintf("issue #%d was read", issueID))
+		case isDenied(err):
+			issueCap = confirmedCapability("view_issue", false, i18n.Sprintf("issue #%d does not exist or is not visible: %v", issueID, err))
+		default:
+			issueCap = unknownCapability("view_issue", i18n.Sprintf("reading issue #%d failed: %v", issueID, err))
+		}
+		if check.issue == nil && projectID <= 0 {
+			return nil, fmt.Errorf("failed to get issue %d: %w", issueID, err)
+		}
+	}
+
+	user, err := c.GetCurrentUserDetail([]string{"memberships"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+	check.user = user
+	report.User = IDName{ID: user.ID, Name: strings.TrimSpace(user.Firstname + " " + user.Lastname)}
+	report.Admin = user.Admin
+
+	project, err := c.GetProjectDetail(projectID, []string{"enabled_modules"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project %d: %w", projectID, err)
+	}
+	check.project = project
+	report.Project = IDName{ID: project.ID, Name: project.Name}
+	report.Capabilities = append(report.Capabilities, *confirmedCapability("view_project", true, i18n.T("the project was read")))
+
+	_, _, err = c.SearchIssues(SearchIssuesParams{ProjectID: strconv.Itoa(projectID), Limit: 1})
+	switch {
+	case err == nil:
+		report.Capabilities = append(report.Capabilities, *confirmedCapability("view_issues", true, i18n.T("the project's issues were listed")))
+	case isDenied(err):
+		report.Capabilities = append(report.Capabilities, *confirmedCapability("view_issues", false, i18n.Sprintf("listing the project's issues was refused: %v", err)))
+	default:
+		report.Capabilities = append(report.Capabilities, *unknownCapability("view_issues", i18n.Sprintf("listing the project's issues failed: %v", err)))
+	}
+	if issueCap != nil {
+		report.Capabilities = append(report.Capabilities, *issueCap)
+	}
+
+	for _, m := range user.Memberships {
+		if m.Project.ID != projectID {
+			continue
+		}
+		check.member = true
+		for _, r := range m.Roles {
+			if !slices.Contains(report.Roles, r.Name) {
+				report.Roles = append(report.Roles, r.Name)
+			}
+			if check.rolesErr != nil || check.hasRole(r.ID) {
+				continue
+			}
+			role, err := c.GetRole(r.ID)
+			if err != nil {
+				check.rolesErr, check.roles = err, nil
+				continue
+			}
+			check.roles = append(check.roles, role)
+		}
+	}
+
+	for _, rc := range roleCapabilities {
+		report.Capabilities = append(report.Capabilities, *check.capability(rc))
+	}
+
+	_, err = c.ListAllCustomFields()
+	switch {
+	case err == nil:
+		report.Capabilities = append(report.Capabilities, *confirmedCapability("manage_custom_fields", true, i18n.T("the custom field definitions were listed, which needs an administrator")))
+	case isDenied(err):
+		report.Capabilities = append(report.Capabilities, *confirmedCapability("manage_custom_fields", false, i18n.T("only administrators can manage custom fields")))
+	default:
+		report.Capabilities = append(report.Capabilities, *unknownCapability("manage_custom_fields", i18n.Sprintf("listing custom fields failed: %v", err)))
+	}
+
+	return report, nil
+}
+
+// capability infers a write capability from the project and the user's roles
+func (p *permissionCheck) capability(rc roleCapability) *Capability {
+	if p.project.Status != 0 && p.project.Status != projectStatusActive {
+		return confirmedCapability(rc.name, false, i18n.T("the project is closed or archived, so it is read-only"))
+	}
+	if rc.module != "" && len(p.project.EnabledModules) > 0 && !p.moduleEnabled(rc.module) {
+		return confirmedCapability(rc.name, false, i18n.Sprintf("the %s module is disabled on the project", rc.module))
+	}
+	if p.user.Admin {
+		return confirmedCapability(rc.name, true, i18n.T("administrators have every permission on active projects"))
+	}
+	if !p.member {
+		return unknownCapability(rc.name, i18n.T("not a member of the project; a public project may still grant the Non member role's permissions, which the API doesn't show"))
+	}
+	if p.rolesErr != nil {
+		return unknownCapability(rc.name, i18n.Sprintf("the permissions of the roles could not be read: %v", p.rolesErr))
+	}
+
+	if granting := p.rolesGranting(rc.permission); len(granting) > 0 {
+		return likelyCapability(rc.name, true, i18n.Sprintf("%s granted by %s", rc.permission, strings.Join(granting, ", ")))
+	}
+	if rc.permission == "edit_issues" && p.issue != nil && p.issue.Author.ID == p.user.ID {
+		if granting := p.rolesGranting("edit_own_issues"); len(granting) > 0 {
+			return likelyCapability(rc.name, true, i18n.Sprintf("edit_own_issues granted by %s, and the issue is your own", strings.Join(granting, ", ")))
+		}
+	}
+	return likelyCapability(rc.name, false, i18n.Sprintf("none of the roles grants %s", rc.permission))
+}
+
+func (p *permissionCheck) hasRole(id int) bool {
+	return slices.ContainsFunc(p.roles, func(r *RoleDetail) bool { return r.ID == id })
+}
+
+// rolesGranting returns the names of the roles with permission
+func (p *permissionCheck) rolesGranting(permission string) []string {
+	var names []string
+	for _, r := range p.roles {
+		if slices.Contains(r.Permissions, permission) {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
+
+func (p *permissionCheck) moduleEnabled(module string) bool {
+	return slices.ContainsFunc(p.project.EnabledModules, func(m IDName) bool { return m.Name == module })
+}
+
+// isDenied reports whether err is Redmine refusing a request or hiding its target
+func isDenied(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && (apiErr.StatusCode == http.StatusForbidden || apiErr.StatusCode == http.StatusNotFound)
+}
+
+func confirmedCapability(name string, allowed bool, reason string) *Capability {
+	return &Capability{Name: name, Allowed: &allowed, Confidence: ConfidenceConfirmed, Reason: reason}
+}
+
+func likelyCapability(name string, allowed bool, reason string) *Capability {
+	return &Capability{Name: name, Allowed: &allowed, Confidence: ConfidenceLikely, Reason: reason}
+}
+
+func unknownCapability(name, reason string) *Capability {
+	return &Capability{Name: name, Confidence: ConfidenceUnknown, Reason: reason}
+}
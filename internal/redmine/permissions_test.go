@@ -0,0 +1,169 @@
+package redmine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// permissionsServer serves a user with the Developer role on project 1,
+// whose modules and status can be changed per test
+type permissionsServer struct {
+	admin   bool
+	member  bool
+	modules string
+	status  int
+}
+
+func (s *permissionsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/users/current.json":
+		memberships := `[{"id":1,"project":{"id":2,"name":"Other"},"roles":[{"id":3,"name":"Manager"}]}]`
+		if s.member {
+			memberships = `[{"id":1,"project":{"id":1,"name":"Web"},"roles":[{"id":4,"name":"Developer"},{"id":5,"name":"Reporter","inherited":true}]}]`
+		}
+		_, _ = fmt.Fprintf(w, `{"user":{"id":7,"firstname":"Jane","lastname":"Doe","admin":%t,"memberships":%s}}`, s.admin, memberships)
+	case "/projects/1.json":
+		_, _ = fmt.Fprintf(w, `{"project":{"id":1,"name":"Web","status":%d,"enabled_modules":%s}}`, s.status, s.modules)
+	case "/issues.json":
+		_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+	case "/issues/42.json":
+		_, _ = w.Write([]byte(`{"issue":{"id":42,"subject":"Login fails","project":{"id":1,"name":"Web"},"author":{"id":7,"name":"Jane Doe"}}}`))
+	case "/issues/43.json":
+		w.WriteHeader(http.StatusForbidden)
+	case "/roles/4.json":
+		_, _ = w.Write([]byte(`{"role":{"id":4,"name":"Developer","permissions":["view_issues","add_issues","edit_own_issues","log_time"]}}`))
+	case "/roles/5.json":
+		_, _ = w.Write([]byte(`{"role":{"id":5,"name":"Reporter","permissions":["view_issues","add_issue_notes"]}}`))
+	case "/custom_fields.json":
+		if s.admin {
+			_, _ = w.Write([]byte(`{"custom_fields":[]}`))
+		} else {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	default:
+		http.Error(w, "not found", http.StatusNotFound)
+	}
+}
+
+// capabilityState renders a capability as "allowed/confidence" for comparison
+func capabilityState(c Capability) string {
+	if c.Allowed == nil {
+		return "?/" + c.Confidence
+	}
+	return fmt.Sprintf("%t/%s", *c.Allowed, c.Confidence)
+}
+
+func checkCapabilities(t *testing.T, report *PermissionReport, want map[string]string) {
+	t.Helper()
+	got := make(map[string]string)
+	for _, c := range report.Capabilities {
+		got[c.Name] = capabilityState(c)
+		if c.Reason == "" {
+			t.Errorf("%s has no reason", c.Name)
+		}
+	}
+	for name, state := range want {
+		if got[name] != state {
+			t.Errorf("%s = %s, want %s", name, got[name], state)
+		}
+	}
+}
+
+func TestCheckPermissions_Member(t *testing.T) {
+	s := &permissionsServer{member: true, status: 1, modules: `[{"id":1,"name":"issue_tracking"},{"id":2,"name":"time_tracking"}]`}
+	server := httptest.NewServer(s)
+	defer server.Close()
+	client := NewClient(server.URL, "key")
+
+	report, err := CheckPermissions(client, 1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.User.Name != "Jane Doe" || report.Project.Name != "Web" || report.Issue != nil {
+		t.Errorf("unexpected report header: %+v", report)
+	}
+	if fmt.Sprint(report.Roles) != "[Developer Reporter]" {
+		t.Errorf("unexpected roles: %v", report.Roles)
+	}
+	checkCapabilities(t, report, map[string]string{
+		"view_project":         "true/confirmed",
+		"view_issues":          "true/confirmed",
+		"add_issues":           "true/likely",
+		"edit_issues":          "false/likely",
+		"add_notes":            "true/likely",
+		"log_time":             "true/likely",
+		"manage_versions":      "false/likely",
+		"manage_custom_fields": "false/confirmed",
+	})
+
+	// edit_own_issues covers the user's own issue
+	report, err = CheckPermissions(client, 0, 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Issue == nil || report.Issue.ID != 42 || report.Project.ID != 1 {
+		t.Errorf("expected the issue's project to be checked, got %+v", report)
+	}
+	checkCapabilities(t, report, map[string]string{
+		"view_issue":  "true/confirmed",
+		"edit_issues": "true/likely",
+	})
+
+	// An invisible issue is reported, the project is still checked
+	report, err = CheckPermissions(client, 1, 43)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkCapabilities(t, report, map[string]string{"view_issue": "false/confirmed", "add_issues": "true/likely"})
+
+	// Without a project there is nothing left to check
+	if _, err := CheckPermissions(client, 0, 43); err == nil {
+		t.Error("expected an error for an invisible issue without a project")
+	}
+
+	// Disabled modules and closed projects are certain refusals
+	s.modules = `[{"id":1,"name":"issue_tracking"}]`
+	report, _ = CheckPermissions(client, 1, 0)
+	checkCapabilities(t, report, map[string]string{"log_time": "false/confirmed", "add_issues": "true/likely"})
+	s.status = 5
+	report, _ = CheckPermissions(client, 1, 0)
+	checkCapabilities(t, report, map[string]string{"add_issues": "false/confirmed", "view_issues": "true/confirmed"})
+}
+
+func TestCheckPermissions_AdminAndNonMember(t *testing.T) {
+	s := &permissionsServer{admin: true, status: 1, modules: `[{"id":1,"name":"issue_tracking"}]`}
+	server := httptest.NewServer(s)
+	defer server.Close()
+	client := NewClient(server.URL, "key")
+
+	report, err := CheckPermissions(client, 1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.Admin {
+		t.Error("expected the admin flag")
+	}
+	checkCapabilities(t, report, map[string]string{
+		"edit_issues":          "true/confirmed",
+		"manage_versions":      "true/confirmed",
+		"log_time":             "false/confirmed",
+		"manage_custom_fields": "true/confirmed",
+	})
+
+	s.admin = false
+	report, err = CheckPermissions(client, 1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Roles) != 0 {
+		t.Errorf("expected no roles on the project, got %v", report.Roles)
+	}
+	checkCapabilities(t, report, map[string]string{
+		"view_issues": "true/confirmed",
+		"add_issues":  "?/unknown",
+		"log_time":    "false/confirmed",
+	})
+}
@@ -0,0 +1,64 @@
+package redmine
+
+import "strconv"
+
+// FieldChange is the old and new value of a field in an update preview
+type FieldChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// BatchNames holds the display names of a batch update's new values, which
+// are resolved once for the whole batch
+type BatchNames struct {
+	Status   string
+	Priority string
+	Assignee string
+}
+
+// PreviewBatchUpdate describes the status, priority and assignee changes
+// params would make to issue, keyed by field. Fields that params leaves alone
+// or that already have the new value are omitted.
+func PreviewBatchUpdate(issue *Issue, params UpdateIssueParams, names BatchNames) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+	if params.StatusID > 0 && params.StatusID != issue.Status.ID {
+		changes["status"] = FieldChange{Old: issue.Status.Name, New: names.Status}
+	}
+	if params.PriorityID > 0 && params.PriorityID != issue.Priority.ID {
+		changes["priority"] = FieldChange{Old: issue.Priority.Name, New: names.Priority}
+	}
+	if params.AssignedToID > 0 && (issue.AssignedTo == nil || issue.AssignedTo.ID != params.AssignedToID) {
+		old := ""
+		if issue.AssignedTo != nil {
+			old = issue.AssignedTo.Name
+		}
+		changes["assigned_to"] = FieldChange{Old: old, New: names.Assignee}
+	}
+	return changes
+}
+
+// StatusName returns the name of a status ID, or the ID itself when the
+// statuses can't be loaded
+func (r *Resolver) StatusName(id int) string {
+	if statuses, err := r.GetStatuses(); err == nil {
+		for _, s := range statuses {
+			if s.ID == id {
+				return s.Name
+			}
+		}
+	}
+	return strconv.Itoa(id)
+}
+
+// PriorityName returns the name of a priority ID, or the ID itself when the
+// priorities can't be loaded
+func (r *Resolver) PriorityName(id int) string {
+	if priorities, err := r.GetPriorities(); err == nil {
+		for _, p := range priorities {
+			if p.ID == id {
+				return p.Name
+			}
+		}
+	}
+	return strconv.Itoa(id)
+}
@@ -0,0 +1,200 @@
+package redmine
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// summaryRecentDays is the window of a project summary's recently updated count
+	summaryRecentDays = 14
+
+	// summaryHoursDays is the window of a project summary's logged hours
+	summaryHoursDays = 30
+
+	// summaryParallelism bounds the concurrent requests of a project summary
+	summaryParallelism = 4
+)
+
+// VersionSummary is a version with the counts of its issues in the project
+type VersionSummary struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	DueDate     string `json:"due_date,omitempty"`
+	OpenCount   int    `json:"open_count"`
+	ClosedCount int    `json:"closed_count"`
+}
+
+// ProjectSummary is the health of a project at a glance. The breakdowns by
+// tracker and priority, the overdue and the unassigned counts are of open
+// issues.
+type ProjectSummary struct {
+	Project         IDName    `json:"project"`
+	Date            string    `json:"date"`
+	OpenCount       int       `json:"open_count"`
+	ClosedCount     int       `json:"closed_count"`
+	ByTracker       []CountBy `json:"by_tracker"`
+	ByPriority      []CountBy `json:"by_priority"`
+	OverdueCount    int       `json:"overdue_count"`
+	UnassignedCount int       `json:"unassigned_count"`
+	RecentlyUpdated struct {
+		Days  int `json:"days"`
+		Count int `json:"count"`
+	} `json:"recently_updated"`
+	Versions []VersionSummary `json:"versions"`
+	Note     string           `json:"note,omitempty"`
+	Hours    struct {
+		Days   int     `json:"days"`
+		Period string  `json:"period"`
+		Total  float64 `json:"total"`
+	} `json:"hours"`
+}
+
+// BuildProjectSummary fetches what a project summary needs concurrently: the
+// open issues, which give most of the counts, totals of closed and recently
+// updated issues, the versions with their closed totals, and the time entries
+// of the last days, as of today
+func BuildProjectSummary(c *Client, projectID int, today time.Time) (*ProjectSummary, error) {
+	project := strconv.Itoa(projectID)
+	todayStr := today.Format("2006-01-02")
+	s := &ProjectSummary{Date: todayStr, Versions: []VersionSummary{}}
+	s.RecentlyUpdated.Days = summaryRecentDays
+	s.Hours.Days = summaryHoursDays
+	from := today.AddDate(0, 0, -(summaryHoursDays - 1)).Format("2006-01-02")
+	s.Hours.Period = fmt.Sprintf("%s ~ %s", from, todayStr)
+
+	var (
+		open      []Issue
+		openTotal int
+		versions  []Version
+	)
+	g := new(errgroup.Group)
+	g.SetLimit(summaryParallelism)
+	g.Go(func() error {
+		detail, err := c.GetProjectDetail(projectID, nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch project: %w", err)
+		}
+		s.Project = IDName{ID: detail.ID, Name: detail.Name}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if open, openTotal, err = listAllIssues(c, SearchIssuesParams{ProjectID: project, StatusID: "open"}); err != nil {
+			return fmt.Errorf("failed to fetch open issues: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if s.ClosedCount, err = countIssues(c, SearchIssuesParams{ProjectID: project, StatusID: "closed"}); err != nil {
+			return fmt.Errorf("failed to count closed issues: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		since := today.AddDate(0, 0, -summaryRecentDays).Format("2006-01-02")
+		if s.RecentlyUpdated.Count, err = countIssues(c, SearchIssuesParams{ProjectID: project, StatusID: "*", UpdatedOn: ">=" + since}); err != nil {
+			return fmt.Errorf("failed to count recently updated issues: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		entries, err := listAllTimeEntries(c, ListTimeEntriesParams{ProjectID: project, From: from, To: todayStr})
+		if err != nil {
+			return fmt.Errorf("failed to fetch time entries: %w", err)
+		}
+		for _, e := range entries {
+			s.Hours.Total += e.Hours
+		}
+		s.Hours.Total = roundHours(s.Hours.Total)
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if versions, err = c.ListVersions(projectID); err != nil {
+			return fmt.Errorf("failed to fetch versions: %w", err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	summarizeOpenIssues(s, open, todayStr)
+	s.OpenCount = max(s.OpenCount, openTotal)
+	s.Note = cappedIssuesNote(len(open), openTotal)
+
+	// Closed issues per version need one count each, so they wait for the list
+	openByVersion := make(map[int]int)
+	for _, issue := range open {
+		if issue.FixedVersion != nil {
+			openByVersion[issue.FixedVersion.ID]++
+		}
+	}
+	s.Versions = make([]VersionSummary, len(versions))
+	g = new(errgroup.Group)
+	g.SetLimit(summaryParallelism)
+	for i, v := range versions {
+		s.Versions[i] = VersionSummary{ID: v.ID, Name: v.Name, Status: v.Status, DueDate: v.DueDate, OpenCount: openByVersion[v.ID]}
+		g.Go(func() error {
+			closed, err := countIssues(c, SearchIssuesParams{ProjectID: project, StatusID: "closed", VersionID: strconv.Itoa(v.ID)})
+			if err != nil {
+				return fmt.Errorf("failed to count closed issues of version %s: %w", v.Name, err)
+			}
+			s.Versions[i].ClosedCount = closed
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	sortVersionSummaries(s.Versions)
+	return s, nil
+}
+
+// summarizeOpenIssues fills in the counts taken from the open issues
+func summarizeOpenIssues(s *ProjectSummary, open []Issue, today string) {
+	byTracker := make(map[string]int)
+	byPriority := make(map[string]int)
+	s.OpenCount = len(open)
+	for _, issue := range open {
+		byTracker[issue.Tracker.Name]++
+		byPriority[issue.Priority.Name]++
+		if issue.DueDate != "" && issue.DueDate < today {
+			s.OverdueCount++
+		}
+		if issue.AssignedTo == nil {
+			s.UnassignedCount++
+		}
+	}
+	s.ByTracker = sortedCounts(byTracker)
+	s.ByPriority = sortedCounts(byPriority)
+}
+
+// sortVersionSummaries orders versions by due date, versions without one last
+func sortVersionSummaries(versions []VersionSummary) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		a, b := versions[i], versions[j]
+		if (a.DueDate == "") != (b.DueDate == "") {
+			return b.DueDate == ""
+		}
+		if a.DueDate != b.DueDate {
+			return a.DueDate < b.DueDate
+		}
+		return a.Name < b.Name
+	})
+}
+
+// countIssues returns how many issues match params without fetching them
+func countIssues(c *Client, params SearchIssuesParams) (int, error) {
+	params.Limit = 1
+	_, total, err := c.SearchIssues(params)
+	return total, err
+}
@@ -0,0 +1,88 @@
+package redmine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBuildProjectSummary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/projects/3.json":
+			fmt.Fprint(w, `{"project":{"id":3,"name":"Web"}}`)
+		case "/projects/3/versions.json":
+			fmt.Fprint(w, `{"versions":[
+				{"id":11,"name":"2.0","status":"open"},
+				{"id":10,"name":"1.0","status":"closed","due_date":"2025-02-01"}
+			]}`)
+		case "/time_entries.json":
+			if q.Get("project_id") != "3" || q.Get("from") != "2025-02-04" || q.Get("to") != "2025-03-05" {
+				t.Errorf("unexpected time entry query: %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"time_entries":[{"id":1,"hours":2.1},{"id":2,"hours":1.2}],"total_count":2}`)
+		case "/issues.json":
+			if q.Get("project_id") != "3" {
+				t.Errorf("expected issues of project 3, got %s", r.URL.RawQuery)
+			}
+			switch {
+			case q.Get("status_id") == "open":
+				fmt.Fprint(w, `{"issues":[
+					{"id":1,"tracker":{"id":1,"name":"Bug"},"priority":{"id":2,"name":"High"},"due_date":"2025-03-01","fixed_version":{"id":11,"name":"2.0"}},
+					{"id":2,"tracker":{"id":1,"name":"Bug"},"priority":{"id":1,"name":"Normal"},"assigned_to":{"id":7,"name":"Alice"}},
+					{"id":3,"tracker":{"id":2,"name":"Feature"},"priority":{"id":1,"name":"Normal"},"due_date":"2025-03-05","fixed_version":{"id":11,"name":"2.0"}}
+				],"total_count":3}`)
+			case q.Get("status_id") == "closed" && q.Get("fixed_version_id") == "10":
+				fmt.Fprint(w, `{"issues":[],"total_count":4}`)
+			case q.Get("status_id") == "closed" && q.Get("fixed_version_id") == "11":
+				fmt.Fprint(w, `{"issues":[],"total_count":1}`)
+			case q.Get("status_id") == "closed":
+				fmt.Fprint(w, `{"issues":[],"total_count":9}`)
+			case q.Get("updated_on") == ">=2025-02-19":
+				fmt.Fprint(w, `{"issues":[],"total_count":5}`)
+			default:
+				t.Errorf("unexpected issue query: %s", r.URL.RawQuery)
+			}
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	today, _ := time.Parse(DateLayout, "2025-03-05")
+	s, err := BuildProjectSummary(NewClient(server.URL, "key"), 3, today)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s.Project.Name != "Web" || s.OpenCount != 3 || s.ClosedCount != 9 || s.RecentlyUpdated.Count != 5 {
+		t.Errorf("unexpected counts: %+v", s)
+	}
+	// Due today isn't overdue yet
+	if s.OverdueCount != 1 || s.UnassignedCount != 2 {
+		t.Errorf("expected 1 overdue and 2 unassigned, got %d and %d", s.OverdueCount, s.UnassignedCount)
+	}
+	if s.ByTracker[0] != (CountBy{Name: "Bug", Count: 2}) || s.ByPriority[0] != (CountBy{Name: "Normal", Count: 2}) {
+		t.Errorf("unexpected breakdowns: %+v %+v", s.ByTracker, s.ByPriority)
+	}
+	if s.Hours.Total != 3.3 || s.Hours.Period != "2025-02-04 ~ 2025-03-05" {
+		t.Errorf("unexpected hours: %+v", s.Hours)
+	}
+
+	want := []VersionSummary{
+		{ID: 10, Name: "1.0", Status: "closed", DueDate: "2025-02-01", ClosedCount: 4},
+		{ID: 11, Name: "2.0", Status: "open", OpenCount: 2, ClosedCount: 1},
+	}
+	if len(s.Versions) != len(want) {
+		t.Fatalf("expected %d versions, got %+v", len(want), s.Versions)
+	}
+	for i, v := range s.Versions {
+		if v != want[i] {
+			t.Errorf("version %d: got %+v, want %+v", i, v, want[i])
+		}
+	}
+}
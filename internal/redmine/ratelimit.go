@@ -0,0 +1,102 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimitLogThreshold is how long a request must wait for the limiter before it is logged
+const rateLimitLogThreshold = 100 * time.Millisecond
+
+// RateLimiter is a token bucket limiting how fast the client sends requests to Redmine.
+// It is safe for concurrent use and can be shared by several clients.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // bucket capacity
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter allowing rps requests per second with the given burst.
+// A burst below 1 is treated as 1.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	b := float64(max(burst, 1))
+	return &RateLimiter{rate: rps, burst: b, tokens: b, last: time.Now()}
+}
+
+// ParseRateLimit parses a rate limit given as "rps" or "rps:burst", e.g. "5" or "2.5:10".
+// The burst defaults to the rate rounded down (at least 1).
+func ParseRateLimit(value string) (float64, int, error) {
+	rateStr, burstStr, hasBurst := strings.Cut(strings.TrimSpace(value), ":")
+	rps, err := strconv.ParseFloat(rateStr, 64)
+	if err != nil || rps <= 0 {
+		return 0, 0, fmt.Errorf("invalid rate %q: must be a positive number of requests per second", rateStr)
+	}
+
+	burst := max(int(rps), 1)
+	if hasBurst {
+		burst, err = strconv.Atoi(burstStr)
+		if err != nil || burst < 1 {
+			return 0, 0, fmt.Errorf("invalid burst %q: must be a positive integer", burstStr)
+		}
+	}
+	return rps, burst, nil
+}
+
+// defaultRateLimiter is shared by every client created with NewClient, so
+// per-request clients (REST mode, SSE sessions) draw from the same budget.
+// It is nil (unlimited) unless REDMINE_RATE_LIMIT is set.
+var defaultRateLimiter = sync.OnceValue(func() *RateLimiter {
+	v := os.Getenv("REDMINE_RATE_LIMIT")
+	if v == "" {
+		return nil
+	}
+	rps, burst, err := ParseRateLimit(v)
+	if err != nil {
+		slog.Warn("ignoring invalid REDMINE_RATE_LIMIT", "value", v, "error", err)
+		return nil
+	}
+	slog.Info("Redmine rate limit enabled", "requests_per_second", rps, "burst", burst)
+	return NewRateLimiter(rps, burst)
+})
+
+// SetRateLimiter replaces the client's rate limiter; nil disables rate limiting
+func (c *Client) SetRateLimiter(limiter *RateLimiter) {
+	c.limiter = limiter
+}
+
+// Wait blocks until a request may be sent or ctx is done, returning how long it waited
+func (l *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	// Reserve a token now; a negative balance is the caller's place in the queue
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return delay, nil
+	case <-ctx.Done():
+		// Give the reservation back so cancelled calls don't delay others
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return 0, ctx.Err()
+	}
+}
@@ -0,0 +1,95 @@
+package redmine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_BackToBackCallsAreThrottled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"trackers":[]}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL, "test-key")
+	client.SetRateLimiter(NewRateLimiter(20, 1))
+
+	const calls = 6
+	start := time.Now()
+	for range calls {
+		if _, err := client.ListTrackers(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// The first call uses the burst token, each further call waits 1/20s
+	if elapsed, want := time.Since(start), (calls-1)*50*time.Millisecond; elapsed < want {
+		t.Errorf("expected %d calls to take at least %v, took %v", calls, want, elapsed)
+	}
+}
+
+func TestRateLimiter_Burst(t *testing.T) {
+	limiter := NewRateLimiter(1, 3)
+	for i := range 3 {
+		if waited, err := limiter.Wait(context.Background()); err != nil || waited != 0 {
+			t.Fatalf("call %d: expected no wait within burst, got %v, %v", i, waited, err)
+		}
+	}
+}
+
+func TestRateLimiter_WaitHonorsCancellation(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"trackers":[]}`))
+	}))
+	defer ts.Close()
+
+	limiter := NewRateLimiter(0.1, 1)
+	if _, err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	client := NewClient(ts.URL, "test-key").WithContext(ctx)
+	client.SetRateLimiter(limiter)
+
+	start := time.Now()
+	_, err := client.ListTrackers()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected cancellation to stop waiting, waited %v", elapsed)
+	}
+	if calls != 0 {
+		t.Errorf("expected no request to reach Redmine, got %d", calls)
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		value     string
+		wantRate  float64
+		wantBurst int
+		wantErr   bool
+	}{
+		{"5", 5, 5, false},
+		{"2.5:10", 2.5, 10, false},
+		{"0.5", 0.5, 1, false},
+		{"0", 0, 0, true},
+		{"fast", 0, 0, true},
+		{"5:0", 0, 0, true},
+	}
+	for _, tt := range tests {
+		rate, burst, err := ParseRateLimit(tt.value)
+		if (err != nil) != tt.wantErr || rate != tt.wantRate || burst != tt.wantBurst {
+			t.Errorf("ParseRateLimit(%q) = %v, %v, %v; want %v, %v, err=%v", tt.value, rate, burst, err, tt.wantRate, tt.wantBurst, tt.wantErr)
+		}
+	}
+}
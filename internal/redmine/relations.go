@@ -0,0 +1,75 @@
+package redmine
+
+import (
+	"strings"
+
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
+)
+
+// RelationTypes are the relation types Redmine stores. Each has an inverse
+// name (blocked_by, follows, ...) that Redmine stores as the same type from
+// the other issue.
+var RelationTypes = []string{"relates", "duplicates", "blocks", "precedes", "copied_to"}
+
+// relationAlias is the stored type of an accepted relation name, and whether
+// the name is its inverse, stored with the issues swapped
+type relationAlias struct {
+	typ     string
+	inverse bool
+}
+
+// relationAliases maps every accepted relation name to its stored type
+var relationAliases = map[string]relationAlias{
+	"relates":       {"relates", false},
+	"relates_to":    {"relates", false},
+	"related_to":    {"relates", false},
+	"duplicates":    {"duplicates", false},
+	"duplicated":    {"duplicates", true},
+	"duplicated_by": {"duplicates", true},
+	"blocks":        {"blocks", false},
+	"blocked":       {"blocks", true},
+	"blocked_by":    {"blocks", true},
+	"precedes":      {"precedes", false},
+	"follows":       {"precedes", true},
+	"copied_to":     {"copied_to", false},
+	"copied_from":   {"copied_to", true},
+}
+
+// relationTypeNames lists the accepted names in error messages
+const relationTypeNames = "relates, duplicates, duplicated_by, blocks, blocked_by, precedes, follows, copied_to, copied_from"
+
+// ParseRelationType returns the stored type of a relation name, ignoring case
+// and accepting spaces or dashes for underscores ("Blocked by"). inverse is
+// true for inverse names such as blocked_by, which are stored as the type
+// from the other issue.
+func ParseRelationType(name string) (typ string, inverse bool, err error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+	alias, ok := relationAliases[key]
+	if !ok {
+		return "", false, i18n.Errorf("unknown relation type %q (use %s)", name, relationTypeNames)
+	}
+	return alias.typ, alias.inverse, nil
+}
+
+// NormalizeRelation turns "issueID <name> issueToID" into the relation Redmine
+// stores: "A blocked_by B" becomes "B blocks A", with from and to swapped
+func NormalizeRelation(issueID, issueToID int, name string) (from, to int, typ string, err error) {
+	typ, inverse, err := ParseRelationType(name)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	if inverse {
+		return issueToID, issueID, typ, nil
+	}
+	return issueID, issueToID, typ, nil
+}
+
+// ValidateRelationDelay checks that a relation of relationType can have a
+// delay; Redmine only schedules precedes and follows relations
+func ValidateRelationDelay(relationType string) error {
+	if relationType != "precedes" && relationType != "follows" {
+		return i18n.Errorf("delay is only valid for precedes and follows relations, not %s", relationType)
+	}
+	return nil
+}
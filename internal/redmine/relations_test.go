@@ -0,0 +1,40 @@
+package redmine
+
+import "testing"
+
+func TestParseRelationType(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantType    string
+		wantInverse bool
+	}{
+		{"blocks", "blocks", false},
+		{"Blocked_By", "blocks", true},
+		{"blocked by", "blocks", true},
+		{"blocked", "blocks", true},
+		{"follows", "precedes", true},
+		{"copied-from", "copied_to", true},
+		{"duplicated_by", "duplicates", true},
+		{" relates ", "relates", false},
+	}
+	for _, tt := range tests {
+		typ, inverse, err := ParseRelationType(tt.name)
+		if err != nil || typ != tt.wantType || inverse != tt.wantInverse {
+			t.Errorf("ParseRelationType(%q) = %q, %v, %v; want %q, %v", tt.name, typ, inverse, err, tt.wantType, tt.wantInverse)
+		}
+	}
+	if _, _, err := ParseRelationType("parent_of"); err == nil {
+		t.Error("expected an error for an unknown relation type")
+	}
+}
+
+func TestNormalizeRelation(t *testing.T) {
+	from, to, typ, err := NormalizeRelation(10, 11, "blocked_by")
+	if err != nil || from != 11 || to != 10 || typ != "blocks" {
+		t.Errorf("expected 10 blocked_by 11 to become 11 blocks 10, got %d %s %d (%v)", from, typ, to, err)
+	}
+	from, to, typ, err = NormalizeRelation(10, 11, "precedes")
+	if err != nil || from != 10 || to != 11 || typ != "precedes" {
+		t.Errorf("expected precedes to keep its issues, got %d %s %d (%v)", from, typ, to, err)
+	}
+}
@@ -0,0 +1,519 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportUser is the user a personal report (weekly, standup) is about
+type ReportUser struct {
+	ID     string // "me" or a numeric user ID, usable as a user_id / assigned_to_id filter
+	Name   string
+	UserID int // the numeric ID, also for "me"; 0 when not known
+}
+
+// UserLookupError is returned when the user named in a report request can't be
+// resolved, either because no user matches or because the API key isn't allowed
+// to look users up by name
+type UserLookupError struct {
+	Query string
+	Err   error
+}
+
+func (e *UserLookupError) Error() string {
+	var resolveErr *ResolveError
+	if errors.As(e.Err, &resolveErr) {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("cannot look up user %q: %v. Looking users up by name needs an API key with permission to list users; pass a numeric user ID or 'me' instead", e.Query, e.Err)
+}
+
+func (e *UserLookupError) Unwrap() error {
+	return e.Err
+}
+
+// ResolveReportUser resolves "me", a numeric ID or a user name for a report.
+// Failures to resolve a name are returned as *UserLookupError; failing to load
+// the current user for "me" is returned as is.
+func (r *Resolver) ResolveReportUser(nameOrID string) (ReportUser, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+	if nameOrID == "" || strings.EqualFold(nameOrID, "me") {
+		user, err := r.client.GetCurrentUser()
+		if err != nil {
+			return ReportUser{}, err
+		}
+		return ReportUser{ID: "me", Name: user.Firstname + " " + user.Lastname, UserID: user.ID}, nil
+	}
+
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		return ReportUser{ID: strconv.Itoa(id), Name: nameOrID, UserID: id}, nil
+	}
+
+	id, err := r.ResolveUser(nameOrID, 0)
+	if err != nil {
+		return ReportUser{}, &UserLookupError{Query: nameOrID, Err: err}
+	}
+	return ReportUser{ID: strconv.Itoa(id), Name: nameOrID, UserID: id}, nil
+}
+
+// HoursBy is one row of an hours breakdown in a report
+type HoursBy struct {
+	Date     string  `json:"date,omitempty"`
+	Project  string  `json:"project,omitempty"`
+	IssueID  int     `json:"issue_id,omitempty"`
+	Subject  string  `json:"subject,omitempty"`
+	Activity string  `json:"activity,omitempty"`
+	Hours    float64 `json:"hours"`
+}
+
+// WeeklyReport summarizes a user's time entries over the work days of a week
+type WeeklyReport struct {
+	User       string    `json:"user"`
+	Period     string    `json:"period"`
+	TotalHours float64   `json:"total_hours"`
+	EntryCount int       `json:"entry_count"`
+	ByDay      []HoursBy `json:"by_day"`
+	ByProject  []HoursBy `json:"by_project"`
+	ByIssue    []HoursBy `json:"by_issue"`
+	ByActivity []HoursBy `json:"by_activity"`
+
+	PreviousWeek *WeekComparison `json:"previous_week,omitempty"`
+
+	// Entries are the week's time entries, for callers that list them
+	Entries []TimeEntry `json:"-"`
+}
+
+// WeekComparison compares a weekly report with the week before it
+type WeekComparison struct {
+	Period     string         `json:"period"`
+	TotalHours float64        `json:"total_hours"`
+	DeltaHours float64        `json:"delta_hours"`
+	ByProject  []ProjectDelta `json:"by_project"`
+}
+
+// ProjectDelta is a project's hours in a week and the week before it
+type ProjectDelta struct {
+	Project       string  `json:"project"`
+	Hours         float64 `json:"hours"`
+	PreviousHours float64 `json:"previous_hours"`
+	DeltaHours    float64 `json:"delta_hours"`
+}
+
+// WeeklyOptions selects the optional parts of a weekly report
+type WeeklyOptions struct {
+	IncludePreviousWeek bool // Compare with the week before, overall and per project
+	IncludeWeekend      bool // Cover every day of the week, not only the work days
+}
+
+// StandupEntry is a time entry logged on the previous workday
+type StandupEntry struct {
+	IssueID  int     `json:"issue_id,omitempty"`
+	Subject  string  `json:"subject,omitempty"`
+	Project  string  `json:"project"`
+	Activity string  `json:"activity"`
+	Hours    float64 `json:"hours"`
+	Comments string  `json:"comments,omitempty"`
+}
+
+// StandupActivity is an issue assigned to or opened by the user that was
+// updated on the previous workday. Commented and Changed say whether the user
+// wrote a note or changed fields that day, as far as its journals were checked.
+type StandupActivity struct {
+	IssueID   int      `json:"issue_id"`
+	Subject   string   `json:"subject"`
+	Project   string   `json:"project"`
+	Status    string   `json:"status"`
+	Role      string   `json:"role"` // "assignee" or "author"
+	Commented bool     `json:"commented,omitempty"`
+	Changed   bool     `json:"changed,omitempty"`
+	Notes     []string `json:"notes,omitempty"`
+}
+
+// StandupIssue is an open issue assigned to the user
+type StandupIssue struct {
+	ID       int    `json:"id"`
+	Subject  string `json:"subject"`
+	Project  string `json:"project"`
+	Tracker  string `json:"tracker"`
+	Status   string `json:"status"`
+	Priority string `json:"priority"`
+}
+
+// StandupDay is the previous workday of a standup report
+type StandupDay struct {
+	Date         string            `json:"date"`
+	TotalHours   float64           `json:"total_hours"`
+	EntryCount   int               `json:"entry_count"`
+	Entries      []StandupEntry    `json:"entries"`
+	Activity     []StandupActivity `json:"activity"`
+	ActivityNote string            `json:"activity_note,omitempty"`
+}
+
+// StandupReport lists the previous workday's time entries and issue activity
+// and today's open issues
+type StandupReport struct {
+	User      string     `json:"user"`
+	Date      string     `json:"date"`
+	Yesterday StandupDay `json:"yesterday"`
+	Today     struct {
+		OpenIssues []StandupIssue `json:"open_issues"`
+		IssueCount int            `json:"issue_count"`
+	} `json:"today"`
+}
+
+// BuildWeeklyReport fetches the user's time entries for the week containing
+// weekOf and aggregates them by day, project, issue and activity. The report
+// covers the first through the last work day of that week, or the whole week
+// with opts.IncludeWeekend, and the same days of the week before when
+// comparing.
+func BuildWeeklyReport(c *Client, user ReportUser, weekOf time.Time, week WorkWeek, opts WeeklyOptions) (*WeeklyReport, error) {
+	report, err := buildWeek(c, user, weekOf, week, opts.IncludeWeekend)
+	if err != nil {
+		return nil, err
+	}
+	report.User = user.Name
+
+	if opts.IncludePreviousWeek {
+		previous, err := buildWeek(c, user, weekOf.AddDate(0, 0, -7), week, opts.IncludeWeekend)
+		if err != nil {
+			return nil, err
+		}
+		report.PreviousWeek = compareWeeks(report, previous)
+	}
+	return report, nil
+}
+
+// buildWeek summarizes the user's entries of the days reported for the week
+// containing weekOf
+func buildWeek(c *Client, user ReportUser, weekOf time.Time, week WorkWeek, weekend bool) (*WeeklyReport, error) {
+	days := week.WorkDaysOf(weekOf)
+	if weekend {
+		start := week.StartOf(weekOf)
+		days = make([]time.Time, 7)
+		for i := range days {
+			days[i] = start.AddDate(0, 0, i)
+		}
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("the configured week has no work days")
+	}
+	from, to := days[0].Format("2006-01-02"), days[len(days)-1].Format("2006-01-02")
+
+	entries, err := listAllTimeEntries(c, ListTimeEntriesParams{UserID: user.ID, From: from, To: to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch time entries: %w", err)
+	}
+
+	report := summarizeWeek(entries, days)
+	report.Period = fmt.Sprintf("%s ~ %s", from, to)
+	report.Entries = entries
+	return report, nil
+}
+
+// compareWeeks reports the change from previous to current, overall and for
+// every project with hours in either week, largest current hours first
+func compareWeeks(current, previous *WeeklyReport) *WeekComparison {
+	byProject := make(map[string]*ProjectDelta)
+	project := func(name string) *ProjectDelta {
+		d, ok := byProject[name]
+		if !ok {
+			d = &ProjectDelta{Project: name}
+			byProject[name] = d
+		}
+		return d
+	}
+	for _, row := range current.ByProject {
+		project(row.Project).Hours = row.Hours
+	}
+	for _, row := range previous.ByProject {
+		project(row.Project).PreviousHours = row.Hours
+	}
+
+	comparison := &WeekComparison{
+		Period:     previous.Period,
+		TotalHours: previous.TotalHours,
+		DeltaHours: roundHours(current.TotalHours - previous.TotalHours),
+		ByProject:  make([]ProjectDelta, 0, len(byProject)),
+	}
+	for _, d := range byProject {
+		d.DeltaHours = roundHours(d.Hours - d.PreviousHours)
+		comparison.ByProject = append(comparison.ByProject, *d)
+	}
+	sort.Slice(comparison.ByProject, func(i, j int) bool {
+		a, b := comparison.ByProject[i], comparison.ByProject[j]
+		if a.Hours != b.Hours {
+			return a.Hours > b.Hours
+		}
+		if a.PreviousHours != b.PreviousHours {
+			return a.PreviousHours > b.PreviousHours
+		}
+		return a.Project < b.Project
+	})
+	return comparison
+}
+
+// roundHours rounds to two decimals, dropping float noise from subtractions
+func roundHours(h float64) float64 {
+	return math.Round(h*100) / 100
+}
+
+// summarizeWeek aggregates a week's entries. Every one of days is listed in
+// by_day, even without hours; the other breakdowns are sorted by hours
+// descending.
+func summarizeWeek(entries []TimeEntry, days []time.Time) *WeeklyReport {
+	byDay := make(map[string]float64)
+	for _, day := range days {
+		byDay[day.Format("2006-01-02")] = 0
+	}
+	byProject := make(map[string]float64)
+	byActivity := make(map[string]float64)
+	byIssue := make(map[int]*HoursBy)
+
+	report := &WeeklyReport{EntryCount: len(entries)}
+	for _, entry := range entries {
+		report.TotalHours += entry.Hours
+		byDay[entry.SpentOn] += entry.Hours
+		byProject[entry.Project.Name] += entry.Hours
+		byActivity[entry.Activity.Name] += entry.Hours
+		if entry.Issue != nil {
+			agg, ok := byIssue[entry.Issue.ID]
+			if !ok {
+				agg = &HoursBy{IssueID: entry.Issue.ID, Subject: entry.Issue.Name}
+				byIssue[entry.Issue.ID] = agg
+			}
+			agg.Hours += entry.Hours
+		}
+	}
+
+	for date, hours := range byDay {
+		report.ByDay = append(report.ByDay, HoursBy{Date: date, Hours: hours})
+	}
+	sort.Slice(report.ByDay, func(i, j int) bool { return report.ByDay[i].Date < report.ByDay[j].Date })
+
+	report.ByProject = make([]HoursBy, 0, len(byProject))
+	for name, hours := range byProject {
+		report.ByProject = append(report.ByProject, HoursBy{Project: name, Hours: hours})
+	}
+	report.ByIssue = make([]HoursBy, 0, len(byIssue))
+	for _, agg := range byIssue {
+		report.ByIssue = append(report.ByIssue, *agg)
+	}
+	report.ByActivity = make([]HoursBy, 0, len(byActivity))
+	for name, hours := range byActivity {
+		report.ByActivity = append(report.ByActivity, HoursBy{Activity: name, Hours: hours})
+	}
+	sortByHours(report.ByProject)
+	sortByHours(report.ByIssue)
+	sortByHours(report.ByActivity)
+
+	return report
+}
+
+// standupJournalChecks is how many of the previous workday's updated issues
+// the standup report fetches journals for, to find the user's own notes
+const standupJournalChecks = 10
+
+// StandupOptions selects the optional parts of a standup report
+type StandupOptions struct {
+	IncludeTimeEntries bool
+}
+
+// BuildStandupReport lists the user's time entries and the issues assigned to
+// or opened by them that were updated on the work day before date, skipping
+// the week's days off, and the open issues assigned to them
+func BuildStandupReport(c *Client, user ReportUser, date time.Time, week WorkWeek, opts StandupOptions) (*StandupReport, error) {
+	yesterday := week.PreviousWorkday(date).Format("2006-01-02")
+
+	var entries []TimeEntry
+	if opts.IncludeTimeEntries {
+		var err error
+		entries, err = listAllTimeEntries(c, ListTimeEntriesParams{UserID: user.ID, From: yesterday, To: yesterday})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch time entries: %w", err)
+		}
+	}
+
+	activity, err := standupActivity(c, user, yesterday, date.Location())
+	if err != nil {
+		return nil, err
+	}
+
+	issues, _, err := c.SearchIssues(SearchIssuesParams{
+		AssignedToID: user.ID,
+		StatusID:     "open",
+		Limit:        50,
+		Sort:         "priority:desc,updated_on:desc",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch open issues: %w", err)
+	}
+
+	report := &StandupReport{User: user.Name, Date: date.Format("2006-01-02")}
+	report.Yesterday.Date = yesterday
+	report.Yesterday.Activity = activity
+	if len(activity) > standupJournalChecks {
+		report.Yesterday.ActivityNote = fmt.Sprintf("Only the %d most recently updated issues were checked for the user's notes", standupJournalChecks)
+	}
+	report.Yesterday.EntryCount = len(entries)
+	report.Yesterday.Entries = make([]StandupEntry, len(entries))
+	for i, entry := range entries {
+		report.Yesterday.TotalHours += entry.Hours
+		e := StandupEntry{
+			Project:  entry.Project.Name,
+			Activity: entry.Activity.Name,
+			Hours:    entry.Hours,
+			Comments: entry.Comments,
+		}
+		if entry.Issue != nil {
+			e.IssueID = entry.Issue.ID
+			e.Subject = entry.Issue.Name
+		}
+		report.Yesterday.Entries[i] = e
+	}
+
+	report.Today.IssueCount = len(issues)
+	report.Today.OpenIssues = make([]StandupIssue, len(issues))
+	for i, issue := range issues {
+		report.Today.OpenIssues[i] = StandupIssue{
+			ID:       issue.ID,
+			Subject:  issue.Subject,
+			Project:  issue.Project.Name,
+			Tracker:  issue.Tracker.Name,
+			Status:   issue.Status.Name,
+			Priority: issue.Priority.Name,
+		}
+	}
+
+	return report, nil
+}
+
+// standupActivity finds the issues assigned to or opened by the user that were
+// updated on day, most recently updated first, and checks the journals of the
+// first few for the user's notes and changes that day in loc
+func standupActivity(c *Client, user ReportUser, day string, loc *time.Location) ([]StandupActivity, error) {
+	activity := []StandupActivity{}
+	seen := make(map[int]bool)
+	for _, role := range []string{"assignee", "author"} {
+		params := SearchIssuesParams{
+			StatusID:  "*",
+			UpdatedOn: "><" + day + "|" + day,
+			Sort:      "updated_on:desc",
+			Limit:     50,
+		}
+		if role == "assignee" {
+			params.AssignedToID = user.ID
+		} else {
+			params.AuthorID = user.ID
+		}
+		issues, _, err := c.SearchIssues(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch updated issues: %w", err)
+		}
+		for _, issue := range issues {
+			if seen[issue.ID] {
+				continue
+			}
+			seen[issue.ID] = true
+			activity = append(activity, StandupActivity{
+				IssueID: issue.ID,
+				Subject: issue.Subject,
+				Project: issue.Project.Name,
+				Status:  issue.Status.Name,
+				Role:    role,
+			})
+		}
+	}
+
+	userID := user.UserID
+	if userID == 0 {
+		userID, _ = strconv.Atoi(user.ID)
+	}
+	if userID == 0 {
+		return activity, nil
+	}
+	for i := range activity[:min(len(activity), standupJournalChecks)] {
+		journals, err := issueJournals(c, activity[i].IssueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch journals of issue #%d: %w", activity[i].IssueID, err)
+		}
+		for _, j := range journals {
+			if j.User.ID != userID || localDate(j.CreatedOn, loc) != day {
+				continue
+			}
+			if j.Notes != "" {
+				activity[i].Commented = true
+				activity[i].Notes = append(activity[i].Notes, j.Notes)
+			}
+			if len(j.Details) > 0 {
+				activity[i].Changed = true
+			}
+		}
+	}
+	return activity, nil
+}
+
+// localDate returns the YYYY-MM-DD date of an RFC 3339 timestamp in loc, or
+// the timestamp's own date when it doesn't parse
+func localDate(timestamp string, loc *time.Location) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return timestamp[:min(len(timestamp), len(DateLayout))]
+	}
+	return t.In(loc).Format(DateLayout)
+}
+
+// issueJournals fetches the journals of an issue without its other associations
+func issueJournals(c *Client, issueID int) ([]Journal, error) {
+	data, err := c.doRequest("GET", fmt.Sprintf("/issues/%d.json?include=journals", issueID), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Issue struct {
+			Journals []Journal `json:"journals"`
+		} `json:"issue"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return resp.Issue.Journals, nil
+}
+
+// maxReportTimeEntries bounds the time entries a report sums. Hours summed
+// over part of the entries would be wrong, so reports fail beyond it.
+const maxReportTimeEntries = 10000
+
+// listAllTimeEntries fetches every time entry matching params, up to
+// maxReportTimeEntries
+func listAllTimeEntries(c *Client, params ListTimeEntriesParams) ([]TimeEntry, error) {
+	params.Offset = 0
+	entries, total, err := c.FetchTimeEntries(params, maxReportTimeEntries)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) < total {
+		return nil, fmt.Errorf("%d time entries match, more than the %d a report sums; narrow the period or project", total, maxReportTimeEntries)
+	}
+	return entries, nil
+}
+
+// sortByHours orders rows by hours descending, breaking ties by label so the
+// output doesn't depend on map iteration order
+func sortByHours(rows []HoursBy) {
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.Hours != b.Hours {
+			return a.Hours > b.Hours
+		}
+		if a.IssueID != b.IssueID {
+			return a.IssueID < b.IssueID
+		}
+		return a.Project+a.Activity < b.Project+b.Activity
+	})
+}
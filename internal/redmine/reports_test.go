@@ -0,0 +1,282 @@
+package redmine
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWorkDaysOf(t *testing.T) {
+	tests := []struct {
+		day, monday, friday string
+	}{
+		{"2025-03-05", "2025-03-03", "2025-03-07"}, // Wednesday
+		{"2025-03-03", "2025-03-03", "2025-03-07"}, // Monday
+		{"2025-03-09", "2025-03-03", "2025-03-07"}, // Sunday belongs to the week before
+	}
+	for _, tt := range tests {
+		day, _ := time.Parse("2006-01-02", tt.day)
+		days := DefaultWorkWeek.WorkDaysOf(day)
+		if len(days) != 5 {
+			t.Fatalf("WorkDaysOf(%s) = %d days, want 5", tt.day, len(days))
+		}
+		if got := days[0].Format("2006-01-02"); got != tt.monday {
+			t.Errorf("WorkDaysOf(%s) monday = %s, want %s", tt.day, got, tt.monday)
+		}
+		if got := days[4].Format("2006-01-02"); got != tt.friday {
+			t.Errorf("WorkDaysOf(%s) friday = %s, want %s", tt.day, got, tt.friday)
+		}
+	}
+}
+
+func TestPreviousWorkday(t *testing.T) {
+	tests := map[string]string{
+		"2025-03-05": "2025-03-04", // Wednesday
+		"2025-03-03": "2025-02-28", // Monday skips the weekend
+		"2025-03-09": "2025-03-07", // Sunday
+	}
+	for day, want := range tests {
+		d, _ := time.Parse("2006-01-02", day)
+		if got := DefaultWorkWeek.PreviousWorkday(d).Format("2006-01-02"); got != want {
+			t.Errorf("PreviousWorkday(%s) = %s, want %s", day, got, want)
+		}
+	}
+}
+
+func TestSummarizeWeek(t *testing.T) {
+	monday, _ := time.Parse("2006-01-02", "2025-03-03")
+	entries := []TimeEntry{
+		{Project: IDName{Name: "Web"}, Issue: &IDName{ID: 1, Name: "Login"}, Activity: IDName{Name: "Dev"}, Hours: 2, SpentOn: "2025-03-03"},
+		{Project: IDName{Name: "Web"}, Issue: &IDName{ID: 1, Name: "Login"}, Activity: IDName{Name: "Review"}, Hours: 1, SpentOn: "2025-03-04"},
+		{Project: IDName{Name: "Ops"}, Activity: IDName{Name: "Dev"}, Hours: 4, SpentOn: "2025-03-04"},
+	}
+
+	report := summarizeWeek(entries, DefaultWorkWeek.WorkDaysOf(monday))
+	if report.TotalHours != 7 || report.EntryCount != 3 {
+		t.Errorf("expected 7h over 3 entries, got %vh over %d", report.TotalHours, report.EntryCount)
+	}
+	if len(report.ByDay) != 5 || report.ByDay[0].Date != "2025-03-03" || report.ByDay[1].Hours != 5 || report.ByDay[4].Hours != 0 {
+		t.Errorf("unexpected by_day: %+v", report.ByDay)
+	}
+	if len(report.ByProject) != 2 || report.ByProject[0].Project != "Ops" || report.ByProject[1].Hours != 3 {
+		t.Errorf("unexpected by_project: %+v", report.ByProject)
+	}
+	if len(report.ByIssue) != 1 || report.ByIssue[0].IssueID != 1 || report.ByIssue[0].Hours != 3 {
+		t.Errorf("unexpected by_issue: %+v", report.ByIssue)
+	}
+	if len(report.ByActivity) != 2 || report.ByActivity[0].Activity != "Dev" || report.ByActivity[0].Hours != 6 {
+		t.Errorf("unexpected by_activity: %+v", report.ByActivity)
+	}
+}
+
+func TestResolveReportUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":1,"firstname":"Alice","lastname":"Smith"}}`))
+		case "/users.json":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	resolver := NewResolver(NewClient(server.URL, "test-key"))
+
+	user, err := resolver.ResolveReportUser("me")
+	if err != nil || user.ID != "me" || user.Name != "Alice Smith" {
+		t.Errorf("expected current user, got %+v, %v", user, err)
+	}
+
+	user, err = resolver.ResolveReportUser("42")
+	if err != nil || user.ID != "42" {
+		t.Errorf("expected numeric ID to pass through, got %+v, %v", user, err)
+	}
+
+	_, err = resolver.ResolveReportUser("Bob")
+	var lookupErr *UserLookupError
+	if !errors.As(err, &lookupErr) {
+		t.Fatalf("expected UserLookupError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "numeric user ID") {
+		t.Errorf("expected hint about numeric IDs, got %q", err.Error())
+	}
+}
+
+func TestBuildStandupReport_Activity(t *testing.T) {
+	var timeEntryCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/time_entries.json":
+			timeEntryCalls++
+			_, _ = w.Write([]byte(`{"time_entries":[],"total_count":0}`))
+		case "/issues.json":
+			switch {
+			case q.Get("status_id") == "open":
+				_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+			case q.Get("updated_on") != "><2025-03-04|2025-03-04":
+				t.Errorf("unexpected updated_on filter %q", q.Get("updated_on"))
+			case q.Get("assigned_to_id") == "7":
+				_, _ = w.Write([]byte(`{"issues":[{"id":1,"subject":"Fix login","project":{"id":1,"name":"Web"},"status":{"id":2,"name":"In Progress"}}],"total_count":1}`))
+			case q.Get("author_id") == "7":
+				_, _ = w.Write([]byte(`{"issues":[{"id":1,"subject":"Fix login","project":{"id":1,"name":"Web"},"status":{"id":2,"name":"In Progress"}},{"id":2,"subject":"Report bug","project":{"id":1,"name":"Web"},"status":{"id":1,"name":"New"}}],"total_count":2}`))
+			default:
+				t.Errorf("unexpected issue query %s", r.URL.RawQuery)
+			}
+		case "/issues/1.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":1,"journals":[
+				{"id":1,"user":{"id":7,"name":"Alice"},"notes":"Found the cause","created_on":"2025-03-04T09:00:00Z"},
+				{"id":2,"user":{"id":8,"name":"Bob"},"notes":"Thanks","created_on":"2025-03-04T10:00:00Z"},
+				{"id":3,"user":{"id":7,"name":"Alice"},"notes":"Earlier note","created_on":"2025-03-03T10:00:00Z"}
+			]}}`))
+		case "/issues/2.json":
+			_, _ = w.Write([]byte(`{"issue":{"id":2,"journals":[
+				{"id":4,"user":{"id":8,"name":"Bob"},"notes":"","created_on":"2025-03-04T11:00:00Z","details":[{"property":"attr","name":"status_id"}]}
+			]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	date, _ := time.Parse(DateLayout, "2025-03-05")
+	report, err := BuildStandupReport(NewClient(server.URL, "key"), ReportUser{ID: "7", Name: "Alice"}, date, DefaultWorkWeek, StandupOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeEntryCalls != 0 {
+		t.Errorf("expected no time entry requests, got %d", timeEntryCalls)
+	}
+
+	activity := report.Yesterday.Activity
+	if len(activity) != 2 {
+		t.Fatalf("expected 2 issues without duplicates, got %+v", activity)
+	}
+	if a := activity[0]; a.IssueID != 1 || a.Role != "assignee" || !a.Commented || len(a.Notes) != 1 || a.Notes[0] != "Found the cause" {
+		t.Errorf("expected Alice's note of that day on #1, got %+v", a)
+	}
+	if a := activity[1]; a.IssueID != 2 || a.Role != "author" || a.Commented || a.Changed {
+		t.Errorf("expected #2 opened by Alice without her own updates, got %+v", a)
+	}
+}
+
+func TestBuildStandupReport_Timezone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/issues.json" && r.URL.Query().Get("assigned_to_id") == "7":
+			_, _ = w.Write([]byte(`{"issues":[{"id":1,"subject":"Fix login","project":{"id":1,"name":"Web"},"status":{"id":2,"name":"In Progress"}}],"total_count":1}`))
+		case r.URL.Path == "/issues.json":
+			_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+		case r.URL.Path == "/issues/1.json":
+			// Redmine reports journal times in UTC
+			_, _ = w.Write([]byte(`{"issue":{"id":1,"journals":[
+				{"id":1,"user":{"id":7,"name":"Alice"},"notes":"Early morning in Taipei","created_on":"2025-03-03T17:00:00Z"},
+				{"id":2,"user":{"id":7,"name":"Alice"},"notes":"Already the next day in Taipei","created_on":"2025-03-04T23:30:00Z"}
+			]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	taipei := time.FixedZone("CST", 8*60*60)
+	date := time.Date(2025, 3, 5, 9, 0, 0, 0, taipei)
+	report, err := BuildStandupReport(NewClient(server.URL, "key"), ReportUser{ID: "7", Name: "Alice", UserID: 7}, date, DefaultWorkWeek, StandupOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a := report.Yesterday.Activity; len(a) != 1 || len(a[0].Notes) != 1 || a[0].Notes[0] != "Early morning in Taipei" {
+		t.Errorf("expected only the note written on 2025-03-04 in the report's timezone, got %+v", a)
+	}
+
+	// The time entry fields are always present, even when not requested
+	data, _ := json.Marshal(report.Yesterday)
+	for _, key := range []string{`"total_hours":0`, `"entry_count":0`, `"entries":[]`} {
+		if !strings.Contains(string(data), key) {
+			t.Errorf("expected %s in %s", key, data)
+		}
+	}
+}
+
+func TestBuildWeeklyReport_PreviousWeekAndWeekend(t *testing.T) {
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		ranges = append(ranges, q.Get("from")+".."+q.Get("to"))
+		switch q.Get("from") {
+		case "2025-03-03":
+			_, _ = w.Write([]byte(`{"time_entries":[
+				{"id":1,"project":{"id":1,"name":"Web"},"activity":{"id":9,"name":"Dev"},"hours":6,"spent_on":"2025-03-04"},
+				{"id":2,"project":{"id":2,"name":"API"},"activity":{"id":9,"name":"Dev"},"hours":1.2,"spent_on":"2025-03-08"}
+			],"total_count":2}`))
+		case "2025-02-24":
+			_, _ = w.Write([]byte(`{"time_entries":[
+				{"id":3,"project":{"id":1,"name":"Web"},"activity":{"id":9,"name":"Dev"},"hours":2.5,"spent_on":"2025-02-25"},
+				{"id":4,"project":{"id":3,"name":"Docs"},"activity":{"id":9,"name":"Dev"},"hours":3,"spent_on":"2025-02-26"}
+			],"total_count":2}`))
+		default:
+			t.Errorf("unexpected time entry range %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	weekOf, _ := time.Parse(DateLayout, "2025-03-05")
+	opts := WeeklyOptions{IncludePreviousWeek: true, IncludeWeekend: true}
+	report, err := BuildWeeklyReport(NewClient(server.URL, "key"), ReportUser{ID: "7", Name: "Alice"}, weekOf, DefaultWorkWeek, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ranges) != 2 || ranges[0] != "2025-03-03..2025-03-09" || ranges[1] != "2025-02-24..2025-03-02" {
+		t.Errorf("expected both whole weeks to be fetched, got %v", ranges)
+	}
+	if report.TotalHours != 7.2 || len(report.ByDay) != 7 {
+		t.Errorf("expected Saturday's hours to count, got %+v", report)
+	}
+
+	prev := report.PreviousWeek
+	if prev == nil || prev.Period != "2025-02-24 ~ 2025-03-02" || prev.TotalHours != 5.5 || prev.DeltaHours != 1.7 {
+		t.Fatalf("unexpected previous week: %+v", prev)
+	}
+	want := []ProjectDelta{
+		{Project: "Web", Hours: 6, PreviousHours: 2.5, DeltaHours: 3.5},
+		{Project: "API", Hours: 1.2, DeltaHours: 1.2},
+		{Project: "Docs", PreviousHours: 3, DeltaHours: -3},
+	}
+	if len(prev.ByProject) != len(want) {
+		t.Fatalf("expected %d projects, got %+v", len(want), prev.ByProject)
+	}
+	for i, d := range prev.ByProject {
+		if d != want[i] {
+			t.Errorf("project %d: got %+v, want %+v", i, d, want[i])
+		}
+	}
+}
+
+func TestBuildWeeklyReport_TooManyEntries(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		entries := make([]map[string]any, limit)
+		for i := range entries {
+			entries[i] = map[string]any{"id": i + 1, "hours": 1, "spent_on": "2025-03-04"}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"time_entries": entries, "total_count": maxReportTimeEntries + 1})
+	}))
+	defer server.Close()
+
+	weekOf, _ := time.Parse(DateLayout, "2025-03-05")
+	_, err := BuildWeeklyReport(NewClient(server.URL, "key"), ReportUser{ID: "7", Name: "Alice"}, weekOf, DefaultWorkWeek, WeeklyOptions{})
+	if err == nil || !strings.Contains(err.Error(), "narrow the period") {
+		t.Errorf("expected the report to refuse summing part of the entries, got %v", err)
+	}
+	if requests != maxReportTimeEntries/100 {
+		t.Errorf("expected the fetch to stop at %d entries, got %d requests", maxReportTimeEntries, requests)
+	}
+}
@@ -1,85 +1,144 @@
 package redmine
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/ycho/redmine-mcp-server/internal/i18n"
 )
 
 // Resolver helps resolve names to IDs
 type Resolver struct {
 	client *Client
 
-	// Cached data
-	trackers     []Tracker
-	statuses     []IssueStatus
-	priorities   []IssuePriority
-	projects     []Project
-	activities   []TimeEntryActivity
-	customFields []CustomFieldDefinitionFull
-	roles        []Role
+	// Cached reference data, refetched after ttl
+	ttl          time.Duration
+	trackers     cachedList[Tracker]
+	statuses     cachedList[IssueStatus]
+	priorities   cachedList[IssuePriority]
+	projects     cachedList[Project]
+	activities   cachedList[TimeEntryActivity]
+	customFields cachedList[CustomFieldDefinitionFull]
+	roles        cachedList[Role]
+	groups       cachedList[Group]
+	docCats      cachedList[DocumentCategory]
+	queries      cachedList[Query]
+
+	// Per-project reference data, keyed by project ID
+	versions   projectCache[Version]
+	categories projectCache[IssueCategory]
+
+	// Allowed target statuses reported by Redmine, keyed by "trackerID:statusID".
+	// Guarded by transitionsMu since batch updates validate transitions concurrently.
+	transitionsMu sync.RWMutex
+	transitions   map[string][]IDName
+
+	// Principals seen while resolving or looking up assignees, true for
+	// groups, so responses can tell group assignees from users
+	groupIDsMu sync.RWMutex
+	groupIDs   map[int]bool
 }
 
 // NewResolver creates a new resolver
 func NewResolver(client *Client) *Resolver {
-	return &Resolver{client: client}
+	return &Resolver{client: client, ttl: DefaultCacheTTL}
+}
+
+// SetCacheTTL sets how long reference data is cached before it is fetched again
+func (r *Resolver) SetCacheTTL(ttl time.Duration) {
+	r.ttl = ttl
 }
 
 // ResolveError represents an error when resolving a name
 type ResolveError struct {
-	Type     string
-	Query    string
-	Matches  []IDName
-	NotFound bool
+	Type        string
+	Query       string
+	Matches     []IDName
+	NotFound    bool
+	Suggestions []string // close names for a not-found query
 }
 
+// Error is worded in the server's language; Type is translated too
 func (e *ResolveError) Error() string {
+	typ := i18n.T(e.Type)
 	if e.NotFound {
-		return fmt.Sprintf("%s not found: %s", e.Type, e.Query)
+		if len(e.Suggestions) > 0 {
+			return i18n.Sprintf("%s not found: %s (did you mean: %s?)", typ, e.Query, strings.Join(e.Suggestions, ", "))
+		}
+		return i18n.Sprintf("%s not found: %s", typ, e.Query)
 	}
 	names := make([]string, len(e.Matches))
 	for i, m := range e.Matches {
 		names[i] = fmt.Sprintf("%s (ID: %d)", m.Name, m.ID)
 	}
-	return fmt.Sprintf("multiple %s match '%s': %s", e.Type, e.Query, strings.Join(names, ", "))
+	return i18n.Sprintf("multiple %s match '%s': %s", typ, e.Query, strings.Join(names, ", "))
+}
+
+// notFoundError builds a not-found ResolveError with suggestions drawn from candidates
+func notFoundError(typ, query string, candidates []string) *ResolveError {
+	return &ResolveError{
+		Type:        typ,
+		Query:       query,
+		NotFound:    true,
+		Suggestions: SuggestNames(query, candidates, MaxSuggestions),
+	}
+}
+
+// namesOf extracts display names from cached reference data
+func namesOf[T any](items []T, name func(T) string) []string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = name(item)
+	}
+	return names
+}
+
+// normalizeName lowercases a name and collapses surrounding and repeated whitespace
+// so that names quoted loosely by users still match
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
 }
 
 // ResolveProject resolves a project name or ID to a project ID
 func (r *Resolver) ResolveProject(nameOrID string) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
 	// Try parsing as ID first
 	if id, err := strconv.Atoi(nameOrID); err == nil {
 		return id, nil
 	}
 
-	// Load projects if not cached
-	if r.projects == nil {
-		projects, err := r.client.ListProjects(1000)
-		if err != nil {
-			return 0, fmt.Errorf("failed to load projects: %w", err)
-		}
-		r.projects = projects
+	projects, err := r.getProjects()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load projects: %w", err)
 	}
 
 	// Search by name or identifier (case-insensitive)
-	query := strings.ToLower(nameOrID)
+	query := normalizeName(nameOrID)
 	var matches []IDName
-	for _, p := range r.projects {
-		if strings.ToLower(p.Name) == query || strings.ToLower(p.Identifier) == query {
+	for _, p := range projects {
+		if normalizeName(p.Name) == query || normalizeName(p.Identifier) == query {
 			matches = append(matches, IDName{ID: p.ID, Name: p.Name})
 		}
 	}
 
 	// If no exact match, try partial match
 	if len(matches) == 0 {
-		for _, p := range r.projects {
-			if strings.Contains(strings.ToLower(p.Name), query) {
+		for _, p := range projects {
+			if strings.Contains(normalizeName(p.Name), query) {
 				matches = append(matches, IDName{ID: p.ID, Name: p.Name})
 			}
 		}
 	}
 
 	if len(matches) == 0 {
-		return 0, &ResolveError{Type: "project", Query: nameOrID, NotFound: true}
+		return 0, notFoundError("project", nameOrID, namesOf(projects, func(v Project) string { return v.Name }))
 	}
 	if len(matches) > 1 {
 		return 0, &ResolveError{Type: "project", Query: nameOrID, Matches: matches}
@@ -90,40 +149,38 @@ func (r *Resolver) ResolveProject(nameOrID string) (int, error) {
 
 // ResolveTracker resolves a tracker name or ID to a tracker ID
 func (r *Resolver) ResolveTracker(nameOrID string) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
 	// Try parsing as ID first
 	if id, err := strconv.Atoi(nameOrID); err == nil {
 		return id, nil
 	}
 
-	// Load trackers if not cached
-	if r.trackers == nil {
-		trackers, err := r.client.ListTrackers()
-		if err != nil {
-			return 0, fmt.Errorf("failed to load trackers: %w", err)
-		}
-		r.trackers = trackers
+	trackers, err := r.GetTrackers()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load trackers: %w", err)
 	}
 
 	// Search by name (case-insensitive)
-	query := strings.ToLower(nameOrID)
+	query := normalizeName(nameOrID)
 	var matches []IDName
-	for _, t := range r.trackers {
-		if strings.ToLower(t.Name) == query {
-			matches = append(matches, IDName(t))
+	for _, t := range trackers {
+		if normalizeName(t.Name) == query {
+			matches = append(matches, IDName{ID: t.ID, Name: t.Name})
 		}
 	}
 
 	// If no exact match, try partial match
 	if len(matches) == 0 {
-		for _, t := range r.trackers {
-			if strings.Contains(strings.ToLower(t.Name), query) {
-				matches = append(matches, IDName(t))
+		for _, t := range trackers {
+			if strings.Contains(normalizeName(t.Name), query) {
+				matches = append(matches, IDName{ID: t.ID, Name: t.Name})
 			}
 		}
 	}
 
 	if len(matches) == 0 {
-		return 0, &ResolveError{Type: "tracker", Query: nameOrID, NotFound: true}
+		return 0, notFoundError("tracker", nameOrID, namesOf(trackers, func(v Tracker) string { return v.Name }))
 	}
 	if len(matches) > 1 {
 		return 0, &ResolveError{Type: "tracker", Query: nameOrID, Matches: matches}
@@ -135,13 +192,14 @@ func (r *Resolver) ResolveTracker(nameOrID string) (int, error) {
 // ResolveStatus resolves a status name or ID to a status ID
 // Special values: "open", "closed", "*" (all)
 func (r *Resolver) ResolveStatus(nameOrID string) (string, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
 	// Handle special values
-	switch strings.ToLower(nameOrID) {
-	case "open", "closed", "*", "all":
-		if nameOrID == "all" {
-			return "*", nil
-		}
-		return strings.ToLower(nameOrID), nil
+	switch special := strings.ToLower(nameOrID); special {
+	case "open", "closed", "*":
+		return special, nil
+	case "all":
+		return "*", nil
 	}
 
 	// Try parsing as ID first
@@ -149,35 +207,31 @@ func (r *Resolver) ResolveStatus(nameOrID string) (string, error) {
 		return nameOrID, nil
 	}
 
-	// Load statuses if not cached
-	if r.statuses == nil {
-		statuses, err := r.client.ListIssueStatuses()
-		if err != nil {
-			return "", fmt.Errorf("failed to load statuses: %w", err)
-		}
-		r.statuses = statuses
+	statuses, err := r.GetStatuses()
+	if err != nil {
+		return "", fmt.Errorf("failed to load statuses: %w", err)
 	}
 
 	// Search by name (case-insensitive)
-	query := strings.ToLower(nameOrID)
+	query := normalizeName(nameOrID)
 	var matches []IDName
-	for _, s := range r.statuses {
-		if strings.ToLower(s.Name) == query {
+	for _, s := range statuses {
+		if normalizeName(s.Name) == query {
 			matches = append(matches, IDName{ID: s.ID, Name: s.Name})
 		}
 	}
 
 	// If no exact match, try partial match
 	if len(matches) == 0 {
-		for _, s := range r.statuses {
-			if strings.Contains(strings.ToLower(s.Name), query) {
+		for _, s := range statuses {
+			if strings.Contains(normalizeName(s.Name), query) {
 				matches = append(matches, IDName{ID: s.ID, Name: s.Name})
 			}
 		}
 	}
 
 	if len(matches) == 0 {
-		return "", &ResolveError{Type: "status", Query: nameOrID, NotFound: true}
+		return "", notFoundError("status", nameOrID, namesOf(statuses, func(v IssueStatus) string { return v.Name }))
 	}
 	if len(matches) > 1 {
 		return "", &ResolveError{Type: "status", Query: nameOrID, Matches: matches}
@@ -188,39 +242,37 @@ func (r *Resolver) ResolveStatus(nameOrID string) (string, error) {
 
 // ResolveStatusID resolves a status name or ID to a status ID (int)
 func (r *Resolver) ResolveStatusID(nameOrID string) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
 	// Try parsing as ID first
 	if id, err := strconv.Atoi(nameOrID); err == nil {
 		return id, nil
 	}
 
-	// Load statuses if not cached
-	if r.statuses == nil {
-		statuses, err := r.client.ListIssueStatuses()
-		if err != nil {
-			return 0, fmt.Errorf("failed to load statuses: %w", err)
-		}
-		r.statuses = statuses
+	statuses, err := r.GetStatuses()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load statuses: %w", err)
 	}
 
 	// Search by name (case-insensitive)
-	query := strings.ToLower(nameOrID)
+	query := normalizeName(nameOrID)
 	var matches []IDName
-	for _, s := range r.statuses {
-		if strings.ToLower(s.Name) == query {
+	for _, s := range statuses {
+		if normalizeName(s.Name) == query {
 			matches = append(matches, IDName{ID: s.ID, Name: s.Name})
 		}
 	}
 
 	if len(matches) == 0 {
-		for _, s := range r.statuses {
-			if strings.Contains(strings.ToLower(s.Name), query) {
+		for _, s := range statuses {
+			if strings.Contains(normalizeName(s.Name), query) {
 				matches = append(matches, IDName{ID: s.ID, Name: s.Name})
 			}
 		}
 	}
 
 	if len(matches) == 0 {
-		return 0, &ResolveError{Type: "status", Query: nameOrID, NotFound: true}
+		return 0, notFoundError("status", nameOrID, namesOf(statuses, func(v IssueStatus) string { return v.Name }))
 	}
 	if len(matches) > 1 {
 		return 0, &ResolveError{Type: "status", Query: nameOrID, Matches: matches}
@@ -231,36 +283,35 @@ func (r *Resolver) ResolveStatusID(nameOrID string) (int, error) {
 
 // ResolvePriority resolves a priority name or ID to a priority ID
 func (r *Resolver) ResolvePriority(nameOrID string) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
 	if id, err := strconv.Atoi(nameOrID); err == nil {
 		return id, nil
 	}
 
-	if r.priorities == nil {
-		priorities, err := r.client.ListIssuePriorities()
-		if err != nil {
-			return 0, fmt.Errorf("failed to load priorities: %w", err)
-		}
-		r.priorities = priorities
+	priorities, err := r.GetPriorities()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load priorities: %w", err)
 	}
 
-	query := strings.ToLower(nameOrID)
+	query := normalizeName(nameOrID)
 	var matches []IDName
-	for _, p := range r.priorities {
-		if strings.ToLower(p.Name) == query {
+	for _, p := range priorities {
+		if normalizeName(p.Name) == query {
 			matches = append(matches, IDName{ID: p.ID, Name: p.Name})
 		}
 	}
 
 	if len(matches) == 0 {
-		for _, p := range r.priorities {
-			if strings.Contains(strings.ToLower(p.Name), query) {
+		for _, p := range priorities {
+			if strings.Contains(normalizeName(p.Name), query) {
 				matches = append(matches, IDName{ID: p.ID, Name: p.Name})
 			}
 		}
 	}
 
 	if len(matches) == 0 {
-		return 0, &ResolveError{Type: "priority", Query: nameOrID, NotFound: true}
+		return 0, notFoundError("priority", nameOrID, namesOf(priorities, func(v IssuePriority) string { return v.Name }))
 	}
 	if len(matches) > 1 {
 		return 0, &ResolveError{Type: "priority", Query: nameOrID, Matches: matches}
@@ -271,40 +322,38 @@ func (r *Resolver) ResolvePriority(nameOrID string) (int, error) {
 
 // ResolveRole resolves a role name or ID to a role ID
 func (r *Resolver) ResolveRole(nameOrID string) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
 	// Try parsing as ID first
 	if id, err := strconv.Atoi(nameOrID); err == nil {
 		return id, nil
 	}
 
-	// Load roles if not cached
-	if r.roles == nil {
-		roles, err := r.client.ListRoles()
-		if err != nil {
-			return 0, fmt.Errorf("failed to load roles: %w", err)
-		}
-		r.roles = roles
+	roles, err := r.GetRoles()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load roles: %w", err)
 	}
 
 	// Search by name (case-insensitive)
-	query := strings.ToLower(nameOrID)
+	query := normalizeName(nameOrID)
 	var matches []IDName
-	for _, role := range r.roles {
-		if strings.ToLower(role.Name) == query {
+	for _, role := range roles {
+		if normalizeName(role.Name) == query {
 			matches = append(matches, IDName{ID: role.ID, Name: role.Name})
 		}
 	}
 
 	// If no exact match, try partial match
 	if len(matches) == 0 {
-		for _, role := range r.roles {
-			if strings.Contains(strings.ToLower(role.Name), query) {
+		for _, role := range roles {
+			if strings.Contains(normalizeName(role.Name), query) {
 				matches = append(matches, IDName{ID: role.ID, Name: role.Name})
 			}
 		}
 	}
 
 	if len(matches) == 0 {
-		return 0, &ResolveError{Type: "role", Query: nameOrID, NotFound: true}
+		return 0, notFoundError("role", nameOrID, namesOf(roles, func(v Role) string { return v.Name }))
 	}
 	if len(matches) > 1 {
 		return 0, &ResolveError{Type: "role", Query: nameOrID, Matches: matches}
@@ -313,41 +362,210 @@ func (r *Resolver) ResolveRole(nameOrID string) (int, error) {
 	return matches[0].ID, nil
 }
 
+// ResolveRoles resolves role names or IDs to role IDs, failing on the first
+// role that can't be resolved
+func (r *Resolver) ResolveRoles(namesOrIDs []string) ([]int, error) {
+	if len(namesOrIDs) == 0 {
+		return nil, fmt.Errorf("must specify at least one role")
+	}
+	roleIDs := make([]int, 0, len(namesOrIDs))
+	for _, nameOrID := range namesOrIDs {
+		roleID, err := r.ResolveRole(nameOrID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve role '%s': %w", nameOrID, err)
+		}
+		roleIDs = append(roleIDs, roleID)
+	}
+	return roleIDs, nil
+}
+
+// ResolveGroup resolves a group name or ID to a group ID
+func (r *Resolver) ResolveGroup(nameOrID string) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
+	// Try parsing as ID first
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		return id, nil
+	}
+
+	groups, err := r.getGroups()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load groups: %w", err)
+	}
+
+	// Search by name (case-insensitive)
+	query := normalizeName(nameOrID)
+	var matches []IDName
+	for _, group := range groups {
+		if normalizeName(group.Name) == query {
+			matches = append(matches, IDName{ID: group.ID, Name: group.Name})
+		}
+	}
+
+	// If no exact match, try partial match
+	if len(matches) == 0 {
+		for _, group := range groups {
+			if strings.Contains(normalizeName(group.Name), query) {
+				matches = append(matches, IDName{ID: group.ID, Name: group.Name})
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return 0, notFoundError("group", nameOrID, namesOf(groups, func(v Group) string { return v.Name }))
+	}
+	if len(matches) > 1 {
+		return 0, &ResolveError{Type: "group", Query: nameOrID, Matches: matches}
+	}
+
+	return matches[0].ID, nil
+}
+
+// ResolveQuery resolves a saved query name or ID to a query ID. With a
+// projectID, queries of other projects are left out; queries available in
+// all projects always match.
+func (r *Resolver) ResolveQuery(nameOrID string, projectID int) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
+	// Try parsing as ID first
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		return id, nil
+	}
+
+	all, err := r.GetQueries()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load queries: %w", err)
+	}
+	var queries []Query
+	for _, q := range all {
+		if projectID == 0 || q.ProjectID == nil || *q.ProjectID == projectID {
+			queries = append(queries, q)
+		}
+	}
+
+	// Search by name (case-insensitive)
+	query := normalizeName(nameOrID)
+	var matches []IDName
+	for _, q := range queries {
+		if normalizeName(q.Name) == query {
+			matches = append(matches, IDName{ID: q.ID, Name: q.Name})
+		}
+	}
+
+	// If no exact match, try partial match
+	if len(matches) == 0 {
+		for _, q := range queries {
+			if strings.Contains(normalizeName(q.Name), query) {
+				matches = append(matches, IDName{ID: q.ID, Name: q.Name})
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return 0, notFoundError("query", nameOrID, namesOf(queries, func(v Query) string { return v.Name }))
+	}
+	if len(matches) > 1 {
+		return 0, &ResolveError{Type: "query", Query: nameOrID, Matches: matches}
+	}
+
+	return matches[0].ID, nil
+}
+
+// ResolveDocumentCategory resolves a document category name or ID to a category ID
+func (r *Resolver) ResolveDocumentCategory(nameOrID string) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
+	// Try parsing as ID first
+	if id, err := strconv.Atoi(nameOrID); err == nil {
+		return id, nil
+	}
+
+	categories, err := r.getDocumentCategories()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load document categories: %w", err)
+	}
+
+	// Search by name (case-insensitive)
+	query := normalizeName(nameOrID)
+	var matches []IDName
+	for _, c := range categories {
+		if normalizeName(c.Name) == query {
+			matches = append(matches, IDName{ID: c.ID, Name: c.Name})
+		}
+	}
+
+	// If no exact match, try partial match
+	if len(matches) == 0 {
+		for _, c := range categories {
+			if strings.Contains(normalizeName(c.Name), query) {
+				matches = append(matches, IDName{ID: c.ID, Name: c.Name})
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return 0, notFoundError("document category", nameOrID, namesOf(categories, func(v DocumentCategory) string { return v.Name }))
+	}
+	if len(matches) > 1 {
+		return 0, &ResolveError{Type: "document category", Query: nameOrID, Matches: matches}
+	}
+
+	return matches[0].ID, nil
+}
+
+// DefaultDocumentCategory returns the ID of the default document category.
+// Redmine requires a category, so when none is marked default the error
+// lists the categories to pick from.
+func (r *Resolver) DefaultDocumentCategory() (int, error) {
+	categories, err := r.getDocumentCategories()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load document categories: %w", err)
+	}
+	for _, c := range categories {
+		if c.IsDefault {
+			return c.ID, nil
+		}
+	}
+	if len(categories) == 0 {
+		return 0, fmt.Errorf("no document categories are defined in Redmine; an administrator must add one")
+	}
+	names := namesOf(categories, func(v DocumentCategory) string { return v.Name })
+	return 0, fmt.Errorf("no document category is marked default; pass category, one of: %s", strings.Join(names, ", "))
+}
+
 // ResolveActivity resolves an activity name or ID to an activity ID
 func (r *Resolver) ResolveActivity(nameOrID string) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
 	// Try parsing as ID first
 	if id, err := strconv.Atoi(nameOrID); err == nil {
 		return id, nil
 	}
 
-	// Load activities if not cached
-	if r.activities == nil {
-		activities, err := r.client.ListTimeEntryActivities()
-		if err != nil {
-			return 0, fmt.Errorf("failed to load activities: %w", err)
-		}
-		r.activities = activities
+	activities, err := r.GetActivities()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load activities: %w", err)
 	}
 
 	// Search by name (case-insensitive)
-	query := strings.ToLower(nameOrID)
+	query := normalizeName(nameOrID)
 	var matches []IDName
-	for _, a := range r.activities {
-		if strings.ToLower(a.Name) == query {
+	for _, a := range activities {
+		if normalizeName(a.Name) == query {
 			matches = append(matches, IDName{ID: a.ID, Name: a.Name})
 		}
 	}
 
 	if len(matches) == 0 {
-		for _, a := range r.activities {
-			if strings.Contains(strings.ToLower(a.Name), query) {
+		for _, a := range activities {
+			if strings.Contains(normalizeName(a.Name), query) {
 				matches = append(matches, IDName{ID: a.ID, Name: a.Name})
 			}
 		}
 	}
 
 	if len(matches) == 0 {
-		return 0, &ResolveError{Type: "activity", Query: nameOrID, NotFound: true}
+		return 0, notFoundError("activity", nameOrID, namesOf(activities, func(v TimeEntryActivity) string { return v.Name }))
 	}
 	if len(matches) > 1 {
 		return 0, &ResolveError{Type: "activity", Query: nameOrID, Matches: matches}
@@ -356,9 +574,12 @@ func (r *Resolver) ResolveActivity(nameOrID string) (int, error) {
 	return matches[0].ID, nil
 }
 
-// ResolveUser resolves a user name, email, or ID to a user ID
-// Uses project memberships since /users.json requires admin
+// ResolveUser resolves a user ID, login, email, or name to a user ID.
+// The admin users API is tried first; without admin rights it falls back to
+// project memberships, which only support name matching.
 func (r *Resolver) ResolveUser(nameOrID string, projectID int) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
 	// Handle special value
 	if strings.ToLower(nameOrID) == "me" {
 		user, err := r.client.GetCurrentUser()
@@ -373,50 +594,248 @@ func (r *Resolver) ResolveUser(nameOrID string, projectID int) (int, error) {
 		return id, nil
 	}
 
-	// If no project ID, we can't search users
+	// Prefer the admin users API, which exposes login and email
+	var candidates []string
+	users, _, err := r.client.SearchUsers(SearchUsersParams{Name: nameOrID, Limit: 100})
+	if err == nil {
+		if len(users) == 0 {
+			// The server-side filter is strict; widen the pool for contains
+			// matching and suggestions
+			users, _, _ = r.client.SearchUsers(SearchUsersParams{Limit: 100})
+		}
+		if matches := matchUsers(nameOrID, users); len(matches) > 0 {
+			return pickUser(nameOrID, matches)
+		}
+		candidates = namesOf(users, func(u User) string { return u.Name })
+	}
+
+	// Fall back to project memberships for non-admin callers
 	if projectID <= 0 {
-		return 0, fmt.Errorf("cannot search users without project context, please use user ID")
+		if err == nil {
+			return 0, notFoundError("user", nameOrID, candidates)
+		}
+		return 0, fmt.Errorf("cannot search users without project context, please use user ID: %w", err)
 	}
 
-	// Get project memberships
-	memberships, err := r.client.GetProjectMemberships(projectID, 1000)
-	if err != nil {
-		return 0, fmt.Errorf("failed to load project memberships: %w", err)
+	memberships, _, mErr := r.client.GetProjectMemberships(projectID, 0, MaxMembershipsLimit)
+	if mErr != nil {
+		return 0, fmt.Errorf("failed to load project memberships: %w", mErr)
 	}
 
-	// Search by name or email (case-insensitive)
-	query := strings.ToLower(nameOrID)
-	var matches []IDName
+	var members []User
 	for _, m := range memberships {
-		if m.User == nil {
-			continue
-		}
-		name := strings.ToLower(m.User.Name)
-		if name == query || strings.Contains(name, query) {
-			// Check for duplicates
-			found := false
-			for _, match := range matches {
-				if match.ID == m.User.ID {
-					found = true
-					break
-				}
+		if m.User != nil {
+			members = append(members, User{ID: m.User.ID, Name: m.User.Name})
+		}
+	}
+	if matches := matchUsers(nameOrID, members); len(matches) > 0 {
+		return pickUser(nameOrID, matches)
+	}
+	candidates = append(candidates, namesOf(members, func(u User) string { return u.Name })...)
+	return 0, notFoundError("user", nameOrID, candidates)
+}
+
+// matchUsers finds users matching query, trying in order exact login, exact
+// email, exact display name and finally display-name contains. The first
+// tier with any match wins.
+func matchUsers(query string, users []User) []IDName {
+	q := normalizeName(query)
+	tiers := []func(u User) bool{
+		func(u User) bool { return u.Login != "" && strings.EqualFold(u.Login, query) },
+		func(u User) bool { return u.Mail != "" && strings.EqualFold(u.Mail, query) },
+		func(u User) bool { return normalizeName(u.Name) == q },
+		func(u User) bool { return strings.Contains(normalizeName(u.Name), q) },
+	}
+
+	for _, match := range tiers {
+		seen := make(map[int]bool)
+		var matches []IDName
+		for _, u := range users {
+			if seen[u.ID] || !match(u) {
+				continue
 			}
-			if !found {
-				matches = append(matches, *m.User)
+			seen[u.ID] = true
+			matches = append(matches, IDName{ID: u.ID, Name: u.Name})
+		}
+		if len(matches) > 0 {
+			return matches
+		}
+	}
+	return nil
+}
+
+// pickUser returns the single matched user or an ambiguity error listing all
+// candidates with their IDs
+func pickUser(query string, matches []IDName) (int, error) {
+	if len(matches) > 1 {
+		return 0, &ResolveError{Type: "user", Query: query, Matches: matches}
+	}
+	return matches[0].ID, nil
+}
+
+// ResolveAssignee resolves an assignee name or ID to a user, or to a group
+// when no user matches. Redmine only accepts groups as assignees when
+// group assignment is enabled in its settings.
+func (r *Resolver) ResolveAssignee(nameOrID string, projectID int) (int, error) {
+	userID, err := r.ResolveUser(nameOrID, projectID)
+	if err == nil {
+		// A bare ID isn't looked up, so it may still be a group
+		if _, convErr := strconv.Atoi(strings.TrimSpace(nameOrID)); convErr != nil {
+			r.recordPrincipal(userID, false)
+		}
+		return userID, nil
+	}
+	var resolveErr *ResolveError
+	if !errors.As(err, &resolveErr) || !resolveErr.NotFound {
+		return 0, err
+	}
+	if groupID, groupErr := r.ResolveAssigneeGroup(nameOrID, projectID); groupErr == nil {
+		return groupID, nil
+	}
+	return 0, err
+}
+
+// ResolveAssigneeGroup resolves a group name or ID to a group ID, from the
+// group list when the caller is an admin and otherwise from the groups that
+// are members of the project. A numeric ID is checked the same way, so a
+// user ID isn't taken for a group.
+func (r *Resolver) ResolveAssigneeGroup(nameOrID string, projectID int) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
+	id, idErr := strconv.Atoi(nameOrID)
+	var err error
+	if idErr == nil {
+		_, err = r.client.GetGroup(id, false)
+		if apiErr, ok := AsAPIError(err); ok && apiErr.StatusCode == http.StatusNotFound {
+			return 0, notFoundError("group", nameOrID, nil)
+		}
+	} else {
+		id, err = r.ResolveGroup(nameOrID)
+	}
+	if err == nil {
+		r.recordGroups(IDName{ID: id})
+		return id, nil
+	}
+	var resolveErr *ResolveError
+	if errors.As(err, &resolveErr) || projectID <= 0 {
+		return 0, err
+	}
+
+	// Reading groups needs admin rights; fall back to project memberships
+	memberships, _, mErr := r.client.GetProjectMemberships(projectID, 0, MaxMembershipsLimit)
+	if mErr != nil {
+		return 0, fmt.Errorf("failed to load project memberships: %w", mErr)
+	}
+	var groups []IDName
+	for _, m := range memberships {
+		if m.Group != nil {
+			groups = append(groups, *m.Group)
+		}
+	}
+	r.recordGroups(groups...)
+
+	if idErr == nil {
+		if slices.ContainsFunc(groups, func(g IDName) bool { return g.ID == id }) {
+			return id, nil
+		}
+		return 0, notFoundError("group", nameOrID, namesOf(groups, func(g IDName) string { return g.Name }))
+	}
+
+	query := normalizeName(nameOrID)
+	var matches []IDName
+	for _, g := range groups {
+		if normalizeName(g.Name) == query {
+			matches = append(matches, g)
+		}
+	}
+	if len(matches) == 0 {
+		for _, g := range groups {
+			if strings.Contains(normalizeName(g.Name), query) {
+				matches = append(matches, g)
 			}
 		}
 	}
 
 	if len(matches) == 0 {
-		return 0, &ResolveError{Type: "user", Query: nameOrID, NotFound: true}
+		return 0, notFoundError("group", nameOrID, namesOf(groups, func(g IDName) string { return g.Name }))
 	}
 	if len(matches) > 1 {
-		return 0, &ResolveError{Type: "user", Query: nameOrID, Matches: matches}
+		return 0, &ResolveError{Type: "group", Query: nameOrID, Matches: matches}
 	}
-
 	return matches[0].ID, nil
 }
 
+// recordGroups remembers group IDs for IsGroup and AssigneeType
+func (r *Resolver) recordGroups(groups ...IDName) {
+	for _, g := range groups {
+		r.recordPrincipal(g.ID, true)
+	}
+}
+
+// recordPrincipal remembers whether the principal id is a group or a user
+func (r *Resolver) recordPrincipal(id int, group bool) {
+	r.groupIDsMu.Lock()
+	defer r.groupIDsMu.Unlock()
+	if r.groupIDs == nil {
+		r.groupIDs = make(map[int]bool)
+	}
+	r.groupIDs[id] = group
+}
+
+// knownPrincipal reports whether id is a group, and whether this resolver has
+// seen it at all, either in a cached group list or while resolving an assignee
+func (r *Resolver) knownPrincipal(id int) (group, known bool) {
+	r.groupIDsMu.RLock()
+	group, known = r.groupIDs[id]
+	r.groupIDsMu.RUnlock()
+	if known {
+		return group, true
+	}
+	for _, g := range r.groups.peek() {
+		if g.ID == id {
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// IsGroup reports whether id is a group this resolver has seen, either in a
+// cached group list or while resolving an assignee. It doesn't call Redmine.
+func (r *Resolver) IsGroup(id int) bool {
+	group, _ := r.knownPrincipal(id)
+	return group
+}
+
+// AssigneeType returns "group" or "user" for the assignee id, or "" when
+// Redmine won't say. Issue responses report both the same way, so an ID the
+// resolver hasn't seen is looked up as a group, which needs admin rights,
+// and then as a user.
+func (r *Resolver) AssigneeType(id int) string {
+	group, known := r.knownPrincipal(id)
+	if !known {
+		_, err := r.client.GetGroup(id, false)
+		apiErr, _ := AsAPIError(err)
+		switch {
+		case err == nil:
+			group, known = true, true
+		case apiErr != nil && apiErr.StatusCode == http.StatusNotFound:
+			group, known = false, true
+		default:
+			if _, err := r.client.GetUser(id, nil); err == nil {
+				group, known = false, true
+			}
+		}
+		if !known {
+			return ""
+		}
+		r.recordPrincipal(id, group)
+	}
+	if group {
+		return "group"
+	}
+	return "user"
+}
+
 // ResolveCustomFieldID resolves a custom field name to its ID
 // This requires fetching an issue to get the custom field mapping
 func (r *Resolver) ResolveCustomFieldID(name string, sampleIssueID int) (int, error) {
@@ -438,43 +857,7 @@ func (r *Resolver) ResolveCustomFieldID(name string, sampleIssueID int) (int, er
 		}
 	}
 
-	return 0, &ResolveError{Type: "custom field", Query: name, NotFound: true}
-}
-
-// GetTrackers returns all trackers
-func (r *Resolver) GetTrackers() ([]Tracker, error) {
-	if r.trackers == nil {
-		trackers, err := r.client.ListTrackers()
-		if err != nil {
-			return nil, err
-		}
-		r.trackers = trackers
-	}
-	return r.trackers, nil
-}
-
-// GetStatuses returns all statuses
-func (r *Resolver) GetStatuses() ([]IssueStatus, error) {
-	if r.statuses == nil {
-		statuses, err := r.client.ListIssueStatuses()
-		if err != nil {
-			return nil, err
-		}
-		r.statuses = statuses
-	}
-	return r.statuses, nil
-}
-
-// GetActivities returns all time entry activities
-func (r *Resolver) GetActivities() ([]TimeEntryActivity, error) {
-	if r.activities == nil {
-		activities, err := r.client.ListTimeEntryActivities()
-		if err != nil {
-			return nil, err
-		}
-		r.activities = activities
-	}
-	return r.activities, nil
+	return 0, notFoundError("custom field", name, namesOf(issue.CustomFields, func(v CustomField) string { return v.Name }))
 }
 
 // ResolveCustomFieldByName resolves a custom field name or ID to its ID.
@@ -485,27 +868,21 @@ func (r *Resolver) ResolveCustomFieldByName(nameOrID string, projectID int, trac
 		return id, nil
 	}
 
-	// Try admin API cache first
-	if r.customFields == nil {
-		fields, err := r.client.ListAllCustomFields()
-		if err == nil {
-			r.customFields = fields
-		}
-		// If admin API fails, customFields stays nil — we'll fall back below
-	}
+	// Try admin API cache first; if the admin API fails we fall back below
+	customFields, cfErr := r.GetCustomFields()
 
 	query := strings.ToLower(nameOrID)
 
 	// Search in admin API cache if available
-	if r.customFields != nil {
+	if cfErr == nil {
 		var matches []IDName
-		for _, cf := range r.customFields {
+		for _, cf := range customFields {
 			if strings.ToLower(cf.Name) == query {
 				matches = append(matches, IDName{ID: cf.ID, Name: cf.Name})
 			}
 		}
 		if len(matches) == 0 {
-			for _, cf := range r.customFields {
+			for _, cf := range customFields {
 				if strings.Contains(strings.ToLower(cf.Name), query) {
 					matches = append(matches, IDName{ID: cf.ID, Name: cf.Name})
 				}
@@ -518,92 +895,231 @@ func (r *Resolver) ResolveCustomFieldByName(nameOrID string, projectID int, trac
 			return 0, &ResolveError{Type: "custom field", Query: nameOrID, Matches: matches}
 		}
 		// Not found in admin cache — return not found
-		return 0, &ResolveError{Type: "custom field", Query: nameOrID, NotFound: true}
+		return 0, notFoundError("custom field", nameOrID, namesOf(customFields, func(v CustomFieldDefinitionFull) string { return v.Name }))
 	}
 
-	// Fallback: use project-specific custom fields (from issue inspection)
+	// Fallback: use the project's custom fields
+	var candidates []string
 	if projectID > 0 {
-		defs, err := r.client.GetProjectCustomFields(projectID, trackerID)
+		defs, err := r.ProjectCustomFields(projectID, trackerID, nil)
 		if err == nil {
 			for _, def := range defs {
 				if strings.ToLower(def.Name) == query {
 					return def.ID, nil
 				}
+				candidates = append(candidates, def.Name)
 			}
 		}
 	}
 
-	return 0, &ResolveError{Type: "custom field", Query: nameOrID, NotFound: true}
+	return 0, notFoundError("custom field", nameOrID, candidates)
+}
+
+// GetTrackers returns all trackers
+func (r *Resolver) GetTrackers() ([]Tracker, error) {
+	return r.trackers.get(r.ttl, false, r.client.ListTrackers)
+}
+
+// RefreshTrackers refetches trackers, bypassing the cache
+func (r *Resolver) RefreshTrackers() ([]Tracker, error) {
+	return r.trackers.get(r.ttl, true, r.client.ListTrackers)
+}
+
+// GetStatuses returns all statuses
+func (r *Resolver) GetStatuses() ([]IssueStatus, error) {
+	return r.statuses.get(r.ttl, false, r.client.ListIssueStatuses)
+}
+
+// RefreshStatuses refetches statuses, bypassing the cache
+func (r *Resolver) RefreshStatuses() ([]IssueStatus, error) {
+	return r.statuses.get(r.ttl, true, r.client.ListIssueStatuses)
 }
 
 // GetPriorities returns all issue priorities
 func (r *Resolver) GetPriorities() ([]IssuePriority, error) {
-	if r.priorities == nil {
-		priorities, err := r.client.ListIssuePriorities()
-		if err != nil {
-			return nil, err
-		}
-		r.priorities = priorities
-	}
-	return r.priorities, nil
+	return r.priorities.get(r.ttl, false, r.client.ListIssuePriorities)
 }
 
-// GetCustomFields returns all custom field definitions (requires admin)
-func (r *Resolver) GetCustomFields() ([]CustomFieldDefinitionFull, error) {
-	if r.customFields == nil {
-		fields, err := r.client.ListAllCustomFields()
-		if err != nil {
-			return nil, err
-		}
-		r.customFields = fields
-	}
-	return r.customFields, nil
+// RefreshPriorities refetches issue priorities, bypassing the cache
+func (r *Resolver) RefreshPriorities() ([]IssuePriority, error) {
+	return r.priorities.get(r.ttl, true, r.client.ListIssuePriorities)
+}
+
+// GetActivities returns all time entry activities
+func (r *Resolver) GetActivities() ([]TimeEntryActivity, error) {
+	return r.activities.get(r.ttl, false, r.client.ListTimeEntryActivities)
+}
+
+// RefreshActivities refetches time entry activities, bypassing the cache
+func (r *Resolver) RefreshActivities() ([]TimeEntryActivity, error) {
+	return r.activities.get(r.ttl, true, r.client.ListTimeEntryActivities)
 }
 
 // GetRoles returns all roles
 func (r *Resolver) GetRoles() ([]Role, error) {
-	if r.roles == nil {
-		roles, err := r.client.ListRoles()
-		if err != nil {
-			return nil, err
-		}
-		r.roles = roles
-	}
-	return r.roles, nil
+	return r.roles.get(r.ttl, false, r.client.ListRoles)
+}
+
+// RefreshRoles refetches roles, bypassing the cache
+func (r *Resolver) RefreshRoles() ([]Role, error) {
+	return r.roles.get(r.ttl, true, r.client.ListRoles)
+}
+
+// GetCustomFields returns all custom field definitions (requires admin)
+func (r *Resolver) GetCustomFields() ([]CustomFieldDefinitionFull, error) {
+	return r.customFields.get(r.ttl, false, r.client.ListAllCustomFields)
+}
+
+// GetQueries returns the saved issue queries visible to the current user
+func (r *Resolver) GetQueries() ([]Query, error) {
+	return r.queries.get(r.ttl, false, r.client.ListQueries)
+}
+
+func (r *Resolver) getProjects() ([]Project, error) {
+	return r.projects.get(r.ttl, false, func() ([]Project, error) {
+		projects, _, err := r.client.ListProjects(1000)
+		return projects, err
+	})
+}
+
+func (r *Resolver) getGroups() ([]Group, error) {
+	return r.groups.get(r.ttl, false, r.client.ListGroups)
+}
+
+func (r *Resolver) getDocumentCategories() ([]DocumentCategory, error) {
+	return r.docCats.get(r.ttl, false, r.client.ListDocumentCategories)
 }
 
 // ResolveVersion resolves a version name or ID to a version ID within a project
 func (r *Resolver) ResolveVersion(nameOrID string, projectID int) (int, error) {
+	return resolveInProject(nameOrID, "version", func(force bool) ([]Version, error) {
+		return r.versions.get(projectID, r.ttl, force, r.client.ListVersions)
+	}, func(v Version) IDName { return IDName{ID: v.ID, Name: v.Name} })
+}
+
+// ResolveCategory resolves an issue category name or ID to a category ID within a project
+func (r *Resolver) ResolveCategory(nameOrID string, projectID int) (int, error) {
+	return resolveInProject(nameOrID, "issue category", func(force bool) ([]IssueCategory, error) {
+		return r.categories.get(projectID, r.ttl, force, r.client.ListIssueCategories)
+	}, func(c IssueCategory) IDName { return IDName{ID: c.ID, Name: c.Name} })
+}
+
+// resolveInProject matches a name against a project's cached list, exactly
+// first and then partially. A name that matches nothing refetches the list
+// once, since versions and categories are often created just before use.
+func resolveInProject[T any](nameOrID, typ string, list func(force bool) ([]T, error), idName func(T) IDName) (int, error) {
+	nameOrID = strings.TrimSpace(nameOrID)
+
 	// Try parsing as ID first
 	if id, err := strconv.Atoi(nameOrID); err == nil {
 		return id, nil
 	}
 
-	// Fetch versions for the project
-	versions, err := r.client.ListVersions(projectID)
-	if err != nil {
-		return 0, fmt.Errorf("failed to list versions: %w", err)
-	}
-
-	query := strings.ToLower(nameOrID)
+	query := normalizeName(nameOrID)
+	var items []T
 	var matches []IDName
+	for _, force := range []bool{false, true} {
+		var err error
+		if items, err = list(force); err != nil {
+			return 0, fmt.Errorf("failed to list %s: %w", typ, err)
+		}
 
-	for _, v := range versions {
-		if strings.ToLower(v.Name) == query {
-			return v.ID, nil
+		for _, item := range items {
+			if v := idName(item); normalizeName(v.Name) == query {
+				return v.ID, nil
+			}
+		}
+		for _, item := range items {
+			if v := idName(item); strings.Contains(normalizeName(v.Name), query) {
+				matches = append(matches, v)
+			}
 		}
-		if strings.Contains(strings.ToLower(v.Name), query) {
-			matches = append(matches, IDName{ID: v.ID, Name: v.Name})
+		if len(matches) > 0 {
+			break
 		}
 	}
 
+	if len(matches) > 1 {
+		return 0, &ResolveError{Type: typ, Query: nameOrID, Matches: matches}
+	}
 	if len(matches) == 1 {
 		return matches[0].ID, nil
 	}
+	return 0, notFoundError(typ, nameOrID, namesOf(items, func(v T) string { return idName(v).Name }))
+}
 
-	if len(matches) > 1 {
-		return 0, &ResolveError{Type: "version", Query: nameOrID, Matches: matches}
+// RecordAllowedStatuses caches the allowed target statuses reported by Redmine
+// for the issue's tracker and current status. Issues without allowed_statuses
+// (Redmine < 5.0 or include not supported) are ignored.
+func (r *Resolver) RecordAllowedStatuses(issue *Issue) {
+	if issue == nil || len(issue.AllowedStatuses) == 0 {
+		return
+	}
+
+	// Redmine lists the current status as allowed; keep only real transitions
+	allowed := make([]IDName, 0, len(issue.AllowedStatuses))
+	for _, s := range issue.AllowedStatuses {
+		if s.ID != issue.Status.ID {
+			allowed = append(allowed, s)
+		}
+	}
+
+	r.transitionsMu.Lock()
+	defer r.transitionsMu.Unlock()
+	if r.transitions == nil {
+		r.transitions = make(map[string][]IDName)
+	}
+	r.transitions[transitionKey(issue.Tracker.ID, issue.Status.ID)] = allowed
+}
+
+// ObservedAllowedStatuses returns the cached server-reported targets for a tracker and status
+func (r *Resolver) ObservedAllowedStatuses(trackerID, fromStatusID int) ([]IDName, bool) {
+	r.transitionsMu.RLock()
+	defer r.transitionsMu.RUnlock()
+	allowed, ok := r.transitions[transitionKey(trackerID, fromStatusID)]
+	return allowed, ok
+}
+
+// ValidateTransition checks whether the issue may move to toStatusID.
+// Depending on source it consults statuses reported by Redmine (cached per
+// tracker and status), the static rules, or both with server data taking precedence.
+// Unknown transitions pass through, as with WorkflowRules.ValidateTransition.
+func (r *Resolver) ValidateTransition(issue *Issue, toStatusID int, rules *WorkflowRules, source WorkflowSource) error {
+	if source == WorkflowSourceServer || source == WorkflowSourceHybrid {
+		r.RecordAllowedStatuses(issue)
+		if allowed, ok := r.ObservedAllowedStatuses(issue.Tracker.ID, issue.Status.ID); ok {
+			if toStatusID == issue.Status.ID {
+				return nil
+			}
+			for _, s := range allowed {
+				if s.ID == toStatusID {
+					return nil
+				}
+			}
+			return &TransitionError{
+				Tracker: issue.Tracker.Name,
+				From:    IDName{ID: issue.Status.ID, Name: issue.Status.Name},
+				To:      IDName{ID: toStatusID, Name: r.cachedStatusName(toStatusID)},
+				Allowed: allowed,
+			}
+		}
+		if source == WorkflowSourceServer {
+			return nil
+		}
 	}
+	return rules.ValidateTransition(issue.Tracker.ID, issue.Status.ID, toStatusID)
+}
+
+// cachedStatusName returns a status name from the cache without fetching
+func (r *Resolver) cachedStatusName(id int) string {
+	for _, s := range r.statuses.peek() {
+		if s.ID == id {
+			return s.Name
+		}
+	}
+	return fmt.Sprintf("Unknown(%d)", id)
+}
 
-	return 0, &ResolveError{Type: "version", Query: nameOrID, NotFound: true}
+func transitionKey(trackerID, statusID int) string {
+	return strconv.Itoa(trackerID) + ":" + strconv.Itoa(statusID)
 }
@@ -1,8 +1,10 @@
 package redmine
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -159,6 +161,228 @@ func TestResolver_ResolveTracker(t *testing.T) {
 	}
 }
 
+func TestResolver_ResolveQuery(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/queries.json" {
+			requests++
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{
+				"queries": [
+					{"id": 1, "name": "Sprint board", "is_public": true, "project_id": 3},
+					{"id": 2, "name": "Sprint board", "is_public": true, "project_id": 4},
+					{"id": 3, "name": "Triage needed", "is_public": false, "project_id": null}
+				],
+				"total_count": 3
+			}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL, "test-key"))
+
+	tests := []struct {
+		name      string
+		input     string
+		projectID int
+		want      int
+		wantErr   bool
+	}{
+		{"by ID", "7", 0, 7, false},
+		{"scoped to project", "sprint board", 4, 2, false},
+		{"global query in any project", "Triage", 3, 3, false},
+		{"ambiguous without project", "Sprint board", 0, 0, true},
+		{"other project's query", "Sprint board", 9, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.ResolveQuery(tt.input, tt.projectID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ResolveQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ResolveQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if requests != 1 {
+		t.Errorf("expected queries to be fetched once and cached, got %d requests", requests)
+	}
+}
+
+func TestResolver_ResolveVersionAndCategory(t *testing.T) {
+	requests := map[string]int{}
+	versions := `{"versions":[{"id":1,"name":"v1.0"},{"id":2,"name":"v1.1"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/projects/3/versions.json":
+			_, _ = w.Write([]byte(versions))
+		case "/projects/4/versions.json":
+			_, _ = w.Write([]byte(`{"versions":[{"id":9,"name":"v1.0"}]}`))
+		case "/projects/3/issue_categories.json":
+			_, _ = w.Write([]byte(`{"issue_categories":[{"id":5,"name":"Backend"},{"id":6,"name":"Backend API"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL, "test-key"))
+
+	tests := []struct {
+		name    string
+		resolve func() (int, error)
+		want    int
+		wantErr bool
+	}{
+		{"version by name", func() (int, error) { return resolver.ResolveVersion("V1.1", 3) }, 2, false},
+		{"version scoped to project", func() (int, error) { return resolver.ResolveVersion("v1.0", 4) }, 9, false},
+		{"ambiguous version", func() (int, error) { return resolver.ResolveVersion("v1", 3) }, 0, true},
+		{"category exact match wins", func() (int, error) { return resolver.ResolveCategory("backend", 3) }, 5, false},
+		{"category partial match", func() (int, error) { return resolver.ResolveCategory("api", 3) }, 6, false},
+		{"category by ID", func() (int, error) { return resolver.ResolveCategory("12", 3) }, 12, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.resolve()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if requests["/projects/3/versions.json"] != 1 || requests["/projects/3/issue_categories.json"] != 1 {
+		t.Errorf("expected each project's lists to be fetched once and cached, got %v", requests)
+	}
+
+	// A version created after the list was cached is found by refetching
+	versions = `{"versions":[{"id":1,"name":"v1.0"},{"id":2,"name":"v1.1"},{"id":3,"name":"v2.0"}]}`
+	if got, err := resolver.ResolveVersion("v2.0", 3); err != nil || got != 3 {
+		t.Errorf("expected the new version to resolve to 3, got %v %v", got, err)
+	}
+	if _, err := resolver.ResolveVersion("v3.0", 3); err == nil || !strings.Contains(err.Error(), "version not found") {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestResolver_ResolveAssignee(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users.json", "/groups.json":
+			// Not an admin
+			w.WriteHeader(http.StatusForbidden)
+		case "/projects/3/memberships.json":
+			_, _ = w.Write([]byte(`{"memberships":[
+				{"id":1,"user":{"id":5,"name":"Alice Chen"}},
+				{"id":2,"user":{"id":6,"name":"Ops"}},
+				{"id":3,"group":{"id":20,"name":"Frontend Team"}},
+				{"id":4,"group":{"id":21,"name":"Ops"}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL, "test-key"))
+
+	tests := []struct {
+		name    string
+		resolve func() (int, error)
+		want    int
+		wantErr bool
+	}{
+		{"user", func() (int, error) { return resolver.ResolveAssignee("alice", 3) }, 5, false},
+		{"group when no user matches", func() (int, error) { return resolver.ResolveAssignee("Frontend Team", 3) }, 20, false},
+		{"user wins over a group of the same name", func() (int, error) { return resolver.ResolveAssignee("Ops", 3) }, 6, false},
+		{"explicit group", func() (int, error) { return resolver.ResolveAssigneeGroup("ops", 3) }, 21, false},
+		{"neither user nor group", func() (int, error) { return resolver.ResolveAssignee("Backend Team", 3) }, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.resolve()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := resolver.ResolveAssignee("Backend Team", 3); err == nil || !strings.Contains(err.Error(), "user not found") {
+		t.Errorf("expected the user lookup error when no group matches either, got %v", err)
+	}
+	if !resolver.IsGroup(20) || !resolver.IsGroup(21) || resolver.IsGroup(5) {
+		t.Error("expected groups seen in memberships to be recognized, and users not to be")
+	}
+}
+
+func TestResolver_AssigneeGroupIDs(t *testing.T) {
+	var lookups int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lookups++
+		switch r.URL.Path {
+		case "/groups/20.json":
+			_, _ = w.Write([]byte(`{"group":{"id":20,"name":"Frontend Team"}}`))
+		case "/groups/7.json", "/groups/8.json", "/groups/9.json":
+			// Not an admin
+			w.WriteHeader(http.StatusForbidden)
+		case "/users/7.json":
+			_, _ = w.Write([]byte(`{"user":{"id":7,"firstname":"Bob","lastname":"Lee"}}`))
+		case "/projects/3/memberships.json":
+			_, _ = w.Write([]byte(`{"memberships":[{"id":1,"user":{"id":7,"name":"Bob Lee"}},{"id":2,"group":{"id":9,"name":"Ops"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL, "test-key"))
+
+	// Numeric group IDs are checked with Redmine, or against the project's
+	// groups when the group API needs admin rights
+	if id, err := resolver.ResolveAssigneeGroup("20", 0); err != nil || id != 20 {
+		t.Errorf("expected group 20, got %d %v", id, err)
+	}
+	if id, err := resolver.ResolveAssigneeGroup("9", 3); err != nil || id != 9 {
+		t.Errorf("expected project group 9, got %d %v", id, err)
+	}
+	for _, query := range []string{"5", "7"} {
+		if _, err := resolver.ResolveAssigneeGroup(query, 3); err == nil || !strings.Contains(err.Error(), "group not found") {
+			t.Errorf("expected %s not to be taken for a group, got %v", query, err)
+		}
+	}
+
+	tests := []struct {
+		id   int
+		want string
+	}{
+		{20, "group"},
+		{9, "group"},
+		{5, "user"},
+		{7, "user"},
+		{8, ""},
+	}
+	for _, tt := range tests {
+		if got := resolver.AssigneeType(tt.id); got != tt.want {
+			t.Errorf("AssigneeType(%d) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+	before := lookups
+	resolver.AssigneeType(7)
+	if lookups != before {
+		t.Error("expected a principal's type to be remembered")
+	}
+}
+
 func TestResolver_ResolveStatus(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/issue_statuses.json" {
@@ -219,6 +443,11 @@ func TestResolveError_Error(t *testing.T) {
 			err:  ResolveError{Type: "project", Query: "foo", NotFound: true},
 			want: "project not found: foo",
 		},
+		{
+			name: "not found with suggestions",
+			err:  ResolveError{Type: "status", Query: "In Progess", NotFound: true, Suggestions: []string{"In Progress"}},
+			want: "status not found: In Progess (did you mean: In Progress?)",
+		},
 		{
 			name: "multiple matches",
 			err: ResolveError{
@@ -238,3 +467,457 @@ func TestResolveError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestResolver_ValidateTransition(t *testing.T) {
+	fileRules := &WorkflowRules{
+		Trackers: map[string]WorkflowTracker{
+			"4": {
+				Name: "Bug",
+				Statuses: map[string]WorkflowStatus{
+					"1": {Name: "New"},
+					"2": {Name: "In Progress"},
+					"5": {Name: "Closed", IsClosed: true},
+				},
+				Transitions: map[string][]int{"1": {2}},
+			},
+		},
+	}
+
+	// Redmine 5.0+ reports the current status too; New -> Closed is allowed on the server
+	withAllowed := &Issue{
+		ID:              1,
+		Tracker:         IDName{ID: 4, Name: "Bug"},
+		Status:          IDName{ID: 1, Name: "New"},
+		AllowedStatuses: []IDName{{ID: 1, Name: "New"}, {ID: 5, Name: "Closed"}},
+	}
+	withoutAllowed := &Issue{
+		ID:      2,
+		Tracker: IDName{ID: 4, Name: "Bug"},
+		Status:  IDName{ID: 1, Name: "New"},
+	}
+
+	tests := []struct {
+		name    string
+		source  WorkflowSource
+		issue   *Issue
+		to      int
+		wantErr bool
+	}{
+		{"file rejects", WorkflowSourceFile, withAllowed, 5, true},
+		{"server allows reported status", WorkflowSourceServer, withAllowed, 5, false},
+		{"server rejects unreported status", WorkflowSourceServer, withAllowed, 2, true},
+		{"server allows staying in current status", WorkflowSourceServer, withAllowed, 1, false},
+		{"hybrid prefers server", WorkflowSourceHybrid, withAllowed, 5, false},
+		{"empty source behaves as file", "", withAllowed, 5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewResolver(NewClient("http://localhost", "test-key"))
+			err := resolver.ValidateTransition(tt.issue, tt.to, fileRules, tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error=%v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("cached server data is reused for issues without allowed_statuses", func(t *testing.T) {
+		resolver := NewResolver(NewClient("http://localhost", "test-key"))
+		resolver.RecordAllowedStatuses(withAllowed)
+
+		if err := resolver.ValidateTransition(withoutAllowed, 5, fileRules, WorkflowSourceHybrid); err != nil {
+			t.Errorf("expected cached transition to be allowed, got %v", err)
+		}
+		err := resolver.ValidateTransition(withoutAllowed, 2, nil, WorkflowSourceServer)
+		if err == nil {
+			t.Fatal("expected cached transition to reject In Progress")
+		}
+		if !strings.Contains(err.Error(), "allowed: Closed") {
+			t.Errorf("expected allowed list without current status, got %q", err.Error())
+		}
+	})
+
+	t.Run("falls back when nothing is known", func(t *testing.T) {
+		resolver := NewResolver(NewClient("http://localhost", "test-key"))
+		if err := resolver.ValidateTransition(withoutAllowed, 5, nil, WorkflowSourceServer); err != nil {
+			t.Errorf("server source without data should pass through, got %v", err)
+		}
+		if err := resolver.ValidateTransition(withoutAllowed, 5, fileRules, WorkflowSourceHybrid); err == nil {
+			t.Error("hybrid source without server data should use the rules file")
+		}
+	})
+}
+
+func TestParseWorkflowSource(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    WorkflowSource
+		wantErr bool
+	}{
+		{"", WorkflowSourceFile, false},
+		{"file", WorkflowSourceFile, false},
+		{"Server", WorkflowSourceServer, false},
+		{" hybrid ", WorkflowSourceHybrid, false},
+		{"redmine", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseWorkflowSource(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseWorkflowSource(%q) = %q, %v; want %q, error=%v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestResolver_ResolveProject_Normalization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/projects.json" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{
+				"projects": [
+					{"id": 1306, "name": "SKY Rack Mgmt Software", "identifier": "sky-rack"},
+					{"id": 10, "name": "Alpha", "identifier": "alpha-hw"},
+					{"id": 11, "name": "Alpha Tools", "identifier": "alpha"},
+					{"id": 20, "name": "Beta Web", "identifier": "beta-web"},
+					{"id": 21, "name": "Beta App", "identifier": "beta-app"}
+				]
+			}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL, "test-key"))
+
+	tests := []struct {
+		name          string
+		input         string
+		want          int
+		wantAmbiguous bool
+	}{
+		{"exact name", "SKY Rack Mgmt Software", 1306, false},
+		{"case folded", "sky rack mgmt software", 1306, false},
+		{"surrounding whitespace", "  SKY Rack Mgmt Software ", 1306, false},
+		{"repeated inner whitespace", "sky  rack mgmt   software", 1306, false},
+		{"identifier", "SKY-RACK", 1306, false},
+		{"ID with whitespace", " 1306 ", 1306, false},
+		{"name and identifier of different projects", "alpha", 0, true},
+		{"ambiguous partial match", "beta", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.ResolveProject(tt.input)
+			if tt.wantAmbiguous {
+				var resolveErr *ResolveError
+				if !errors.As(err, &resolveErr) || resolveErr.NotFound || len(resolveErr.Matches) < 2 {
+					t.Fatalf("expected ambiguity error listing candidates, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveProject(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolver_ReferenceNormalization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/trackers.json":
+			_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"},{"id":2,"name":"Feature Request"}]}`))
+		case "/issue_statuses.json":
+			_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":2,"name":"In Progress"}]}`))
+		case "/enumerations/issue_priorities.json":
+			_, _ = w.Write([]byte(`{"issue_priorities":[{"id":2,"name":"Normal"},{"id":3,"name":"High"}]}`))
+		case "/enumerations/time_entry_activities.json":
+			_, _ = w.Write([]byte(`{"time_entry_activities":[{"id":9,"name":"Development"},{"id":10,"name":"Design"}]}`))
+		case "/roles.json":
+			_, _ = w.Write([]byte(`{"roles":[{"id":3,"name":"Manager"},{"id":4,"name":"Developer"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL, "test-key"))
+
+	tests := []struct {
+		name    string
+		resolve func(string) (int, error)
+		input   string
+		want    int
+	}{
+		{"tracker", resolver.ResolveTracker, " feature  request ", 2},
+		{"status", resolver.ResolveStatusID, "IN PROGRESS ", 2},
+		{"priority", resolver.ResolvePriority, " high", 3},
+		{"activity", resolver.ResolveActivity, "development\t", 9},
+		{"role", resolver.ResolveRole, "  manager  ", 3},
+		{"numeric ID", resolver.ResolveTracker, " 1 ", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.resolve(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolve(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("ambiguous activity lists candidates", func(t *testing.T) {
+		_, err := resolver.ResolveActivity("de")
+		var resolveErr *ResolveError
+		if !errors.As(err, &resolveErr) || len(resolveErr.Matches) != 2 {
+			t.Fatalf("expected ambiguity error with 2 candidates, got %v", err)
+		}
+	})
+
+	t.Run("status special values", func(t *testing.T) {
+		for input, want := range map[string]string{" Open ": "open", "ALL": "*", "closed": "closed"} {
+			got, err := resolver.ResolveStatus(input)
+			if err != nil || got != want {
+				t.Errorf("ResolveStatus(%q) = %q, %v; want %q", input, got, err, want)
+			}
+		}
+	})
+}
+
+func TestResolveRoles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/roles.json" {
+			_, _ = w.Write([]byte(`{"roles":[{"id":3,"name":"Manager"},{"id":4,"name":"Developer"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL, "test-key"))
+
+	ids, err := resolver.ResolveRoles([]string{"developer", " 3 "})
+	if err != nil || len(ids) != 2 || ids[0] != 4 || ids[1] != 3 {
+		t.Errorf("expected [4 3], got %v %v", ids, err)
+	}
+	if _, err := resolver.ResolveRoles(nil); err == nil {
+		t.Error("expected an error without roles")
+	}
+	if _, err := resolver.ResolveRoles([]string{"Manager", "Janitor"}); err == nil || !strings.Contains(err.Error(), "'Janitor'") {
+		t.Errorf("expected the error to name the unknown role, got %v", err)
+	}
+}
+
+func TestResolver_NotFoundSuggestions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/projects.json":
+			_, _ = w.Write([]byte(`{"projects":[{"id":1,"name":"SKY Rack Mgmt Software","identifier":"sky-rack"},{"id":2,"name":"Firmware","identifier":"fw"}]}`))
+		case "/trackers.json":
+			_, _ = w.Write([]byte(`{"trackers":[{"id":1,"name":"Bug"},{"id":2,"name":"Feature"},{"id":3,"name":"Support"}]}`))
+		case "/issue_statuses.json":
+			_, _ = w.Write([]byte(`{"issue_statuses":[{"id":1,"name":"New"},{"id":2,"name":"In Progress"},{"id":5,"name":"Closed"}]}`))
+		case "/enumerations/issue_priorities.json":
+			_, _ = w.Write([]byte(`{"issue_priorities":[{"id":2,"name":"Normal"},{"id":3,"name":"High"},{"id":4,"name":"Urgent"}]}`))
+		case "/projects/1/memberships.json":
+			_, _ = w.Write([]byte(`{"memberships":[{"id":1,"user":{"id":7,"name":"Alice Chen"}},{"id":2,"user":{"id":8,"name":"Bob Lin"}}]}`))
+		case "/custom_fields.json":
+			_, _ = w.Write([]byte(`{"custom_fields":[{"id":23,"name":"Component"},{"id":27,"name":"HW Version"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL, "test-key"))
+
+	tests := []struct {
+		name    string
+		resolve func() error
+		want    string
+	}{
+		{"status", func() error { _, err := resolver.ResolveStatusID("In Progess"); return err }, "In Progress"},
+		{"tracker", func() error { _, err := resolver.ResolveTracker("Bgu"); return err }, "Bug"},
+		{"project", func() error { _, err := resolver.ResolveProject("Firmwre"); return err }, "Firmware"},
+		{"priority", func() error { _, err := resolver.ResolvePriority("Urgnet"); return err }, "Urgent"},
+		{"user", func() error { _, err := resolver.ResolveUser("Alice Chne", 1); return err }, "Alice Chen"},
+		{"custom field", func() error { _, err := resolver.ResolveCustomFieldByName("Componnet", 1, 1); return err }, "Component"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.resolve()
+			var resolveErr *ResolveError
+			if !errors.As(err, &resolveErr) || !resolveErr.NotFound {
+				t.Fatalf("expected not-found ResolveError, got %v", err)
+			}
+			if len(resolveErr.Suggestions) == 0 || resolveErr.Suggestions[0] != tt.want {
+				t.Errorf("expected first suggestion %q, got %v", tt.want, resolveErr.Suggestions)
+			}
+			if !strings.Contains(err.Error(), "did you mean: "+tt.want) {
+				t.Errorf("expected suggestion in message, got %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestResolver_ResolveUser(t *testing.T) {
+	users := `{"users":[
+		{"id":10,"login":"jsmith","firstname":"John","lastname":"Smith","mail":"john.smith@example.com"},
+		{"id":11,"login":"jsmithers","firstname":"John","lastname":"Smithers","mail":"js@example.com"},
+		{"id":12,"login":"achen","firstname":"Alice","lastname":"Chen","mail":"alice@example.com"},
+		{"id":13,"login":"smith","firstname":"Robert","lastname":"Smith","mail":"rob@example.com"}
+	],"total_count":4}`
+	memberships := `{"memberships":[{"id":1,"user":{"id":10,"name":"John Smith"}},{"id":2,"user":{"id":11,"name":"John Smithers"}},{"id":3,"user":{"id":12,"name":"Alice Chen"}}]}`
+
+	newServer := func(admin bool) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/users.json":
+				if !admin {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				_, _ = w.Write([]byte(users))
+			case "/projects/1/memberships.json":
+				_, _ = w.Write([]byte(memberships))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	}
+
+	t.Run("admin", func(t *testing.T) {
+		server := newServer(true)
+		defer server.Close()
+
+		tests := []struct {
+			name      string
+			query     string
+			projectID int
+			want      int
+		}{
+			{"numeric ID", " 42 ", 0, 42},
+			{"exact login beats name contains", "smith", 0, 13},
+			{"login case-insensitive", "JSmithers", 0, 11},
+			{"exact email", "alice@example.com", 0, 12},
+			{"exact display name", "john  smith", 1, 10},
+			{"name contains", "chen", 0, 12},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resolver := NewResolver(NewClient(server.URL, "test-key"))
+				got, err := resolver.ResolveUser(tt.query, tt.projectID)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got != tt.want {
+					t.Errorf("expected %d, got %d", tt.want, got)
+				}
+			})
+		}
+
+		resolver := NewResolver(NewClient(server.URL, "test-key"))
+		_, err := resolver.ResolveUser("John S", 0)
+		var resolveErr *ResolveError
+		if !errors.As(err, &resolveErr) || len(resolveErr.Matches) != 2 {
+			t.Fatalf("expected ambiguity error with 2 matches, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "John Smith (ID: 10)") || !strings.Contains(err.Error(), "John Smithers (ID: 11)") {
+			t.Errorf("expected candidates with IDs, got %q", err.Error())
+		}
+	})
+
+	t.Run("memberships fallback", func(t *testing.T) {
+		server := newServer(false)
+		defer server.Close()
+		resolver := NewResolver(NewClient(server.URL, "test-key"))
+
+		got, err := resolver.ResolveUser("Alice Chen", 1)
+		if err != nil || got != 12 {
+			t.Fatalf("expected 12, got %d (err %v)", got, err)
+		}
+
+		_, err = resolver.ResolveUser("John S", 1)
+		var resolveErr *ResolveError
+		if !errors.As(err, &resolveErr) || len(resolveErr.Matches) != 2 {
+			t.Fatalf("expected ambiguity error with 2 matches, got %v", err)
+		}
+
+		if _, err := resolver.ResolveUser("alice@example.com", 0); err == nil || !strings.Contains(err.Error(), "project context") {
+			t.Errorf("expected project context error for global lookup, got %v", err)
+		}
+	})
+}
+
+func TestResolver_ResolveGroup(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/groups.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"groups":[{"id":5,"name":"Firmware Team"},{"id":6,"name":"QA Team"},{"id":7,"name":"Developers"}]}`))
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL, "test-key"))
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"9", 9},
+		{"qa team", 6},
+		{"  Developers ", 7},
+		{"firmware", 5},
+	}
+	for _, tt := range tests {
+		got, err := resolver.ResolveGroup(tt.query)
+		if err != nil {
+			t.Fatalf("ResolveGroup(%q): unexpected error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("ResolveGroup(%q) = %d, want %d", tt.query, got, tt.want)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected groups to be fetched once and cached, got %d requests", requests)
+	}
+
+	_, err := resolver.ResolveGroup("Team")
+	var resolveErr *ResolveError
+	if !errors.As(err, &resolveErr) || len(resolveErr.Matches) != 2 {
+		t.Errorf("expected ambiguity error with 2 matches, got %v", err)
+	}
+
+	_, err = resolver.ResolveGroup("Develpers")
+	if err == nil || !strings.Contains(err.Error(), "did you mean: Developers") {
+		t.Errorf("expected suggestion for misspelled group, got %v", err)
+	}
+}
+
+func TestResolver_ResolveGroup_RequiresAdmin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	resolver := NewResolver(NewClient(server.URL, "test-key"))
+	_, err := resolver.ResolveGroup("QA Team")
+	if err == nil || !strings.Contains(err.Error(), "requires admin privileges") {
+		t.Errorf("expected admin privileges error, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package redmine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultMaxRetries is used when REDMINE_MAX_RETRIES is not set
+	defaultMaxRetries = 3
+	// maxRetryAfter caps how long a Retry-After header can make a call wait
+	maxRetryAfter = time.Minute
+)
+
+// RetryPolicy controls how the client retries transient failures
+// (connection errors, 429, 502 and 503 responses).
+type RetryPolicy struct {
+	MaxRetries  int           // Retries after the first attempt; 0 disables retrying
+	BaseDelay   time.Duration // Backoff before the first retry, doubled on each further retry
+	MaxDelay    time.Duration // Upper bound for a single backoff delay
+	RetryWrites bool          // Also retry non-idempotent requests (POST, PUT, PATCH, DELETE)
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient.
+// REDMINE_MAX_RETRIES overrides the number of retries.
+func DefaultRetryPolicy() RetryPolicy {
+	policy := RetryPolicy{
+		MaxRetries: defaultMaxRetries,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   10 * time.Second,
+	}
+	if v := os.Getenv("REDMINE_MAX_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			policy.MaxRetries = n
+		} else {
+			slog.Warn("ignoring invalid REDMINE_MAX_RETRIES", "value", v)
+		}
+	}
+	return policy
+}
+
+// SetRetryPolicy replaces the client's retry policy
+func (c *Client) SetRetryPolicy(policy RetryPolicy) {
+	c.retry = policy
+}
+
+// canRetry reports whether requests with this method may be retried
+func (c *Client) canRetry(method string) bool {
+	if c.retry.MaxRetries <= 0 {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	default:
+		return c.retry.RetryWrites
+	}
+}
+
+// isRetryableStatus reports whether a response status indicates a transient failure
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before the given retry (1-based): exponential with jitter,
+// or the server's Retry-After when present.
+func (c *Client) backoff(retry int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+
+	delay := c.retry.BaseDelay << (retry - 1)
+	if c.retry.MaxDelay > 0 && (delay > c.retry.MaxDelay || delay <= 0) {
+		delay = c.retry.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// Jitter in [delay/2, delay] so concurrent callers don't retry in lockstep
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	var d time.Duration
+	if secs, err := strconv.Atoi(value); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(value); err == nil {
+		d = time.Until(t)
+	} else {
+		return 0, false
+	}
+	return min(max(d, 0), maxRetryAfter), true
+}
+
+// sleepContext waits for d, or returns ctx's error when it is done first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// send performs an HTTP request to the Redmine API, retrying transient failures
+// according to the client's retry policy. newBody is called once per attempt and
+// must return a fresh reader each time when the request can be retried.
+func (c *Client) send(method, path string, newBody func() io.Reader, contentType string) ([]byte, error) {
+	resp, err := c.open(c.httpClient, method, path, newBody, contentType)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return respBody, nil
+}
+
+// open performs a request like send, with httpClient, but returns the
+// successful response with its body unread. The caller must close the body.
+func (c *Client) open(httpClient *http.Client, method, path string, newBody func() io.Reader, contentType string) (*http.Response, error) {
+	attempts := 1
+	if c.canRetry(method) {
+		attempts += c.retry.MaxRetries
+	}
+
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			waited, err := c.limiter.Wait(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("rate limit wait cancelled: %w", err)
+			}
+			if waited > rateLimitLogThreshold {
+				slog.Debug("Redmine request delayed by rate limit", "method", method, "path", path, "waited", waited)
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, newBody())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		c.authenticate(req)
+		if c.switchUser != "" {
+			req.Header.Set(SwitchUserHeader, c.switchUser)
+		}
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			// A cancelled call or a shutdown isn't a network error worth retrying
+			if attempt < attempts && ctx.Err() == nil {
+				delay := c.backoff(attempt, nil)
+				slog.Debug("retrying Redmine request", "method", method, "path", path, "attempt", attempt, "max_attempts", attempts, "delay", delay, "error", err)
+				if err := sleepContext(ctx, delay); err != nil {
+					return nil, fmt.Errorf("retry wait cancelled: %w", err)
+				}
+				continue
+			}
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+
+		if isRetryableStatus(resp.StatusCode) && attempt < attempts {
+			// Drain the body so the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+			delay := c.backoff(attempt, resp)
+			slog.Debug("retrying Redmine request", "method", method, "path", path, "attempt", attempt, "max_attempts", attempts, "delay", delay, "status", resp.StatusCode)
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, fmt.Errorf("retry wait cancelled: %w", err)
+			}
+			continue
+		}
+
+		if resp.StatusCode >= 400 {
+			respBody, err := io.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read response: %w", err)
+			}
+			apiErr := newAPIError(resp.StatusCode, respBody)
+			if c.switchUser != "" {
+				return nil, &SwitchUserError{Login: c.switchUser, Err: apiErr}
+			}
+			return nil, apiErr
+		}
+
+		if attempt > 1 {
+			slog.Debug("Redmine request succeeded after retry", "method", method, "path", path, "attempts", attempt)
+		}
+
+		return resp, nil
+	}
+}
@@ -0,0 +1,277 @@
+package redmine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(url string, policy RetryPolicy) *Client {
+	client := NewClient(url, "test-key")
+	client.SetRetryPolicy(policy)
+	return client
+}
+
+func TestSend_RetriesTransientFailures(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/current.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"user":{"id":1,"login":"alice","firstname":"Alice","lastname":"Smith"}}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := newRetryTestClient(ts.URL, RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond})
+
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.ID != 1 || calls != 3 {
+		t.Errorf("expected success on 3rd attempt, got user %+v after %d calls", user, calls)
+	}
+}
+
+func TestSend_GivesUpAfterMaxRetries(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := newRetryTestClient(ts.URL, RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond})
+
+	if _, err := client.ListTrackers(); err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestSend_DoesNotRetryClientErrors(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /issues/1.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := newRetryTestClient(ts.URL, RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond})
+
+	if _, err := client.GetIssue(1); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("expected a single attempt for 404, got %d", calls)
+	}
+}
+
+func TestSend_RetriesWritesOnlyWhenEnabled(t *testing.T) {
+	calls := 0
+	var bodies []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /uploads.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"upload":{"token":"1.abc"}}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client := newRetryTestClient(ts.URL, RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond})
+	if _, err := client.UploadFile("a.txt", "text/plain", strings.NewReader("hello")); err == nil {
+		t.Fatal("expected POST to fail without retrying")
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 attempt, got %d", calls)
+	}
+
+	calls, bodies = 0, nil
+	client.SetRetryPolicy(RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, RetryWrites: true})
+	token, err := client.UploadFile("a.txt", "text/plain", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.Token != "1.abc" || calls != 2 {
+		t.Errorf("expected success on 2nd attempt, got %+v after %d calls", token, calls)
+	}
+	if len(bodies) != 2 || bodies[1] != "hello" {
+		t.Errorf("expected body to be resent on retry, got %q", bodies)
+	}
+}
+
+func TestSend_HonorsRetryAfter(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"trackers":[]}`))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := newRetryTestClient(ts.URL, RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond})
+
+	start := time.Now()
+	if _, err := client.ListTrackers(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected to wait for Retry-After, only waited %v", elapsed)
+	}
+}
+
+func TestSend_CancelledDuringBackoff(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := newRetryTestClient(ts.URL, RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.WithContext(ctx).ListTrackers()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the cancellation to end the wait, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second || calls != 1 {
+		t.Errorf("expected one attempt and no Retry-After wait, got %d calls after %v", calls, elapsed)
+	}
+}
+
+// countingTransport counts the requests it sends
+type countingTransport struct {
+	calls int
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.calls++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestSend_DoesNotRetryCancelledRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trackers.json", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := newRetryTestClient(ts.URL, RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond})
+	transport := &countingTransport{}
+	client.httpClient.Transport = transport
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.WithContext(ctx).ListTrackers(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the request to fail with its context, got %v", err)
+	}
+	if transport.calls != 1 {
+		t.Errorf("expected a cancelled request not to be retried, got %d attempts", transport.calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-5", 0, true},
+		{"3600", maxRetryAfter, true},
+		{"Mon, 01 Jan 2001 00:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestDefaultRetryPolicy_Env(t *testing.T) {
+	t.Setenv("REDMINE_MAX_RETRIES", "5")
+	if got := DefaultRetryPolicy().MaxRetries; got != 5 {
+		t.Errorf("expected 5 retries from env, got %d", got)
+	}
+
+	t.Setenv("REDMINE_MAX_RETRIES", "bogus")
+	if got := DefaultRetryPolicy().MaxRetries; got != defaultMaxRetries {
+		t.Errorf("expected default retries for invalid env, got %d", got)
+	}
+}
+
+func TestDownloadAttachmentStream(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /attachments/9.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"attachment":{"id":9,"filename":"build #2.log","filesize":11,"content_type":"text/plain"}}`))
+	})
+	mux.HandleFunc("GET /attachments/download/9/{name}", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.PathValue("name") != "build #2.log" {
+			t.Errorf("expected the filename to be escaped in the path, got %q", r.URL.RawPath)
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("hello world"))
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	client := newRetryTestClient(ts.URL, RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond})
+
+	body, contentType, filename, size, err := client.DownloadAttachmentStream(9)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = body.Close() }()
+	data, _ := io.ReadAll(body)
+	if string(data) != "hello world" || contentType != "text/plain" || filename != "build #2.log" || size != 11 || calls != 2 {
+		t.Errorf("unexpected download: %q %s %s %d after %d calls", data, contentType, filename, size, calls)
+	}
+
+	if _, _, _, _, err := client.DownloadAttachmentStream(10); err == nil {
+		t.Error("expected an error for a missing attachment")
+	}
+}
@@ -59,8 +59,14 @@ func (r *CustomFieldRules) ValidateValue(fieldID int, value string) (string, err
 		return value, nil
 	}
 
+	return matchAllowedValue(rule.Name, fieldID, rule.Values, value)
+}
+
+// matchAllowedValue returns the allowed value matching value, correcting its case,
+// or an error naming the field and listing the valid values (close matches first).
+func matchAllowedValue(name string, fieldID int, allowed []string, value string) (string, error) {
 	// Exact match
-	for _, v := range rule.Values {
+	for _, v := range allowed {
 		if v == value {
 			return value, nil
 		}
@@ -68,15 +74,19 @@ func (r *CustomFieldRules) ValidateValue(fieldID int, value string) (string, err
 
 	// Case-insensitive match
 	lower := strings.ToLower(value)
-	for _, v := range rule.Values {
+	for _, v := range allowed {
 		if strings.ToLower(v) == lower {
 			return v, nil // auto-correct case
 		}
 	}
 
-	// No match — return error with valid values
+	// No match — return error with valid values, leading with close matches
+	if suggestions := SuggestNames(value, allowed, MaxSuggestions); len(suggestions) > 0 {
+		return "", fmt.Errorf("invalid value %q for %s (ID: %d). Did you mean: %s? Valid values: %s",
+			value, name, fieldID, strings.Join(suggestions, ", "), strings.Join(allowed, ", "))
+	}
 	return "", fmt.Errorf("invalid value %q for %s (ID: %d). Valid values: %s",
-		value, rule.Name, fieldID, strings.Join(rule.Values, ", "))
+		value, name, fieldID, strings.Join(allowed, ", "))
 }
 
 // ValidateValues checks each value in a slice (for multi-select fields).
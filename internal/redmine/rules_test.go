@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -94,6 +95,27 @@ func TestValidateValue(t *testing.T) {
 	}
 }
 
+func TestValidateValueSuggestions(t *testing.T) {
+	rules := &CustomFieldRules{
+		Fields: map[string]CustomFieldRule{
+			"23": {Name: "Component", Values: []string{"SW Tool", "HW", "FPGA", "(none)"}},
+		},
+	}
+
+	_, err := rules.ValidateValue(23, "SW Tols")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "Did you mean: SW Tool?") {
+		t.Errorf("expected suggestion in error, got: %v", err)
+	}
+
+	_, err = rules.ValidateValue(23, "Mechanical")
+	if err == nil || strings.Contains(err.Error(), "Did you mean") {
+		t.Errorf("expected error without suggestions for unrelated value, got: %v", err)
+	}
+}
+
 func TestValidateValues(t *testing.T) {
 	rules := &CustomFieldRules{
 		Fields: map[string]CustomFieldRule{
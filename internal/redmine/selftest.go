@@ -0,0 +1,129 @@
+package redmine
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// SelfTestTimeout bounds the startup self-test, so a wrong REDMINE_URL
+// delays startup by seconds rather than the client timeout
+const SelfTestTimeout = 5 * time.Second
+
+// ServerInfo is what a self-test found out about Redmine and the API key.
+// Redmine reports its version on no API endpoint or header, so it isn't
+// included.
+type ServerInfo struct {
+	RedmineURL string `json:"redmine_url"`
+	Reachable  bool   `json:"reachable"`
+	User       *User  `json:"user,omitempty"`  // Owner of the API key; nil without a key
+	Admin      *bool  `json:"admin,omitempty"` // nil when the admin probe was inconclusive
+	LatencyMS  int64  `json:"latency_ms"`
+	CheckedAt  string `json:"checked_at"`
+	Error      string `json:"error,omitempty"`
+}
+
+// SelfTest checks that Redmine answers and accepts the client's credentials,
+// and whether they are an administrator's, which only admins can list
+// custom fields to show. A client without credentials only checks that
+// Redmine answers. The returned error says what to fix; info is set either way.
+func SelfTest(ctx context.Context, c *Client) (*ServerInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, SelfTestTimeout)
+	defer cancel()
+	c = c.WithContext(ctx)
+
+	info := &ServerInfo{RedmineURL: c.baseURL, CheckedAt: time.Now().Format(time.RFC3339)}
+	start := time.Now()
+	user, err := c.GetCurrentUser()
+	info.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		apiErr, answered := AsAPIError(err)
+		info.Reachable = answered
+		// Without a key Redmine refuses with 401, which still shows it's up
+		if answered && apiErr.StatusCode == http.StatusUnauthorized && !c.HasCredentials() {
+			return info, nil
+		}
+		if answered && apiErr.StatusCode == http.StatusUnauthorized && c.username != "" {
+			err = fmt.Errorf("Redmine rejected the username or password (401): check REDMINE_USERNAME and REDMINE_PASSWORD: %w", err)
+		} else {
+			err = diagnoseConnection(c.baseURL, err)
+		}
+		info.Error = err.Error()
+		return info, err
+	}
+	info.Reachable = true
+	info.User = user
+
+	if _, err := c.ListAllCustomFields(); err == nil {
+		admin := true
+		info.Admin = &admin
+	} else if apiErr, ok := AsAPIError(err); ok && apiErr.StatusCode == http.StatusForbidden {
+		admin := false
+		info.Admin = &admin
+	}
+	return info, nil
+}
+
+// diagnoseConnection explains a failed self-test request with what to fix
+func diagnoseConnection(baseURL string, err error) error {
+	apiErr, ok := AsAPIError(err)
+	var (
+		dnsErr    *net.DNSError
+		certErr   *tls.CertificateVerificationError
+		authErr   x509.UnknownAuthorityError
+		hostErr   x509.HostnameError
+		recordErr tls.RecordHeaderError
+		syntaxErr *json.SyntaxError
+		netErr    net.Error
+		hint      string
+	)
+	switch {
+	case ok && apiErr.StatusCode == http.StatusUnauthorized:
+		hint = "Redmine rejected the API key (401): check REDMINE_API_KEY"
+	case ok && apiErr.StatusCode == http.StatusForbidden:
+		hint = "Redmine refused the request (403): enable the REST web service in Administration > Settings > API, and check that the key's account is not locked"
+	case ok && apiErr.StatusCode == http.StatusNotFound:
+		hint = fmt.Sprintf("no Redmine API at %s (404): REDMINE_URL must be the Redmine root URL, including any sub-path", baseURL)
+	case ok:
+		hint = fmt.Sprintf("Redmine at %s answered with an error", baseURL)
+	case errors.As(err, &dnsErr):
+		hint = fmt.Sprintf("cannot resolve the host of %s: check the hostname in REDMINE_URL and DNS", baseURL)
+	case errors.As(err, &certErr), errors.As(err, &authErr), errors.As(err, &hostErr):
+		hint = fmt.Sprintf("the TLS certificate of %s is not trusted: set REDMINE_CA_FILE to its CA certificate, or fix the hostname in REDMINE_URL", baseURL)
+	case errors.As(err, &recordErr):
+		hint = fmt.Sprintf("%s does not speak TLS: use http:// in REDMINE_URL, or the HTTPS port", baseURL)
+	case errors.Is(err, syscall.ECONNREFUSED):
+		hint = fmt.Sprintf("connection to %s refused: check that Redmine is running and the port in REDMINE_URL", baseURL)
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		hint = fmt.Sprintf("no answer from %s within %s: check REDMINE_URL, proxies and firewalls", baseURL, SelfTestTimeout)
+	case errors.As(err, &syntaxErr):
+		hint = fmt.Sprintf("%s did not answer with Redmine's JSON: check that REDMINE_URL points at Redmine and the REST web service is enabled", baseURL)
+	default:
+		hint = fmt.Sprintf("cannot reach Redmine at %s", baseURL)
+	}
+	return fmt.Errorf("%s: %w", hint, err)
+}
+
+// LogSelfTest logs the outcome of a startup self-test
+func LogSelfTest(info *ServerInfo, err error) {
+	switch {
+	case err != nil:
+		slog.Error("Redmine self-test failed; tools will fail until this is fixed", "error", err)
+	case info.User == nil:
+		slog.Info("Redmine is reachable; no server API key to check", "redmine_url", info.RedmineURL, "latency_ms", info.LatencyMS)
+	default:
+		attrs := []any{"redmine_url", info.RedmineURL, "user", info.User.Login, "user_id", info.User.ID, "latency_ms", info.LatencyMS}
+		if info.Admin != nil {
+			attrs = append(attrs, "admin", *info.Admin)
+		}
+		slog.Info("Connected to Redmine", attrs...)
+	}
+}
@@ -0,0 +1,103 @@
+package redmine
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	admin := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Redmine-API-Key") == "" || r.Header.Get("X-Redmine-API-Key") == "revoked" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/users/current.json":
+			_, _ = w.Write([]byte(`{"user":{"id":3,"login":"jdoe","firstname":"Jane","lastname":"Doe"}}`))
+		case "/custom_fields.json":
+			if !admin {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"custom_fields":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	info, err := SelfTest(context.Background(), NewClient(server.URL, "key"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !info.Reachable || info.User == nil || info.User.Login != "jdoe" || info.Admin == nil || *info.Admin {
+		t.Errorf("expected jdoe without admin, got %+v", info)
+	}
+
+	admin = true
+	info, _ = SelfTest(context.Background(), NewClient(server.URL, "key"))
+	if info.Admin == nil || !*info.Admin {
+		t.Errorf("expected admin, got %+v", info)
+	}
+
+	// A revoked key is a 401 to fix
+	info, err = SelfTest(context.Background(), NewClient(server.URL, "revoked"))
+	if err == nil || !strings.Contains(err.Error(), "REDMINE_API_KEY") || !info.Reachable || info.Error == "" {
+		t.Errorf("expected a bad key diagnosis, got %v (%+v)", err, info)
+	}
+
+	// Without a key, a 401 only shows that Redmine is up
+	info, err = SelfTest(context.Background(), NewClient(server.URL, ""))
+	if err != nil || !info.Reachable || info.User != nil {
+		t.Errorf("expected a reachable keyless check, got %v (%+v)", err, info)
+	}
+}
+
+func TestSelfTest_NotRedmine(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html>Welcome to nginx</html>`))
+	}))
+	defer server.Close()
+
+	_, err := SelfTest(context.Background(), NewClient(server.URL, "key"))
+	if err == nil || !strings.Contains(err.Error(), "did not answer with Redmine's JSON") {
+		t.Errorf("expected a not-Redmine diagnosis, got %v", err)
+	}
+}
+
+func TestDiagnoseConnection(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{newAPIError(http.StatusUnauthorized, nil), "rejected the API key (401)"},
+		{newAPIError(http.StatusForbidden, nil), "REST web service"},
+		{newAPIError(http.StatusNotFound, nil), "root URL"},
+		{&net.DNSError{Err: "no such host", Name: "redmine.invalid"}, "cannot resolve the host"},
+		{context.DeadlineExceeded, "no answer from"},
+		{errors.New("boom"), "cannot reach Redmine"},
+	}
+	for _, tt := range tests {
+		err := diagnoseConnection("https://redmine.example.com", tt.err)
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("diagnoseConnection(%v) = %q, want it to mention %q", tt.err, err, tt.want)
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("expected %q to wrap %v", err, tt.err)
+		}
+	}
+
+	// A closed port refuses connections
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+	client := NewClient(url, "key")
+	client.retry.MaxRetries = 0
+	if _, err := SelfTest(context.Background(), client); err == nil || !strings.Contains(err.Error(), "refused") {
+		t.Errorf("expected a refused connection diagnosis, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package redmine
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// IssueSortFields are the issue columns Redmine can sort by; custom fields
+// sort as cf_<id>
+var IssueSortFields = []string{
+	"id", "project", "tracker", "parent", "subject", "status", "priority", "category", "fixed_version",
+	"assigned_to", "author", "created_on", "updated_on", "closed_on", "start_date", "due_date",
+	"done_ratio", "estimated_hours",
+}
+
+// ParseIssueSort validates a comma-separated issue sort and normalizes it to
+// Redmine's syntax: each key is a field optionally followed by ":desc" or
+// ":asc", and a space works as the separator too ("updated_on desc"). Names
+// that aren't sortable fields or cf_<id> are looked up as custom fields with
+// resolveCustomField, which may be nil to allow built-in fields only.
+func ParseIssueSort(value string, resolveCustomField func(name string) (int, error)) (string, error) {
+	var keys []string
+	for key := range strings.SplitSeq(value, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		field, order := splitSortKey(key)
+		if order != "" && order != "asc" && order != "desc" {
+			return "", fmt.Errorf("invalid sort order %q in %q: use asc or desc", order, key)
+		}
+		name, err := sortField(field, resolveCustomField)
+		if err != nil {
+			return "", err
+		}
+		if order == "desc" {
+			name += ":desc"
+		}
+		keys = append(keys, name)
+	}
+	return strings.Join(keys, ","), nil
+}
+
+// splitSortKey splits "field:order" or "field order" into its lowercased
+// order and the field; the order is empty when not given
+func splitSortKey(key string) (field, order string) {
+	if i := strings.LastIndex(key, ":"); i >= 0 {
+		return strings.TrimSpace(key[:i]), strings.ToLower(strings.TrimSpace(key[i+1:]))
+	}
+	if i := strings.LastIndex(key, " "); i >= 0 {
+		if last := strings.ToLower(key[i+1:]); last == "asc" || last == "desc" {
+			return strings.TrimSpace(key[:i]), last
+		}
+	}
+	return key, ""
+}
+
+func sortField(field string, resolveCustomField func(name string) (int, error)) (string, error) {
+	lower := strings.ToLower(field)
+	if slices.Contains(IssueSortFields, lower) {
+		return lower, nil
+	}
+	if id, ok := strings.CutPrefix(lower, "cf_"); ok {
+		if _, err := strconv.Atoi(id); err == nil {
+			return lower, nil
+		}
+	}
+	valid := strings.Join(IssueSortFields, ", ")
+	if resolveCustomField == nil {
+		return "", fmt.Errorf("invalid sort field %q (valid: %s, cf_<id>)", field, valid)
+	}
+	id, err := resolveCustomField(field)
+	if err != nil {
+		return "", fmt.Errorf("invalid sort field %q: not a sortable field (%s, cf_<id>) or a custom field: %v", field, valid, err)
+	}
+	return "cf_" + strconv.Itoa(id), nil
+}
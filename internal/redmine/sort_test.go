@@ -0,0 +1,49 @@
+package redmine
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseIssueSort(t *testing.T) {
+	resolve := func(name string) (int, error) {
+		if strings.EqualFold(name, "Due Week") {
+			return 7, nil
+		}
+		return 0, fmt.Errorf("custom field not found: %s", name)
+	}
+	tests := []struct {
+		value, want string
+	}{
+		{"", ""},
+		{"updated_on:desc", "updated_on:desc"},
+		{"updated desc", ""},
+		{"Updated_On DESC", "updated_on:desc"},
+		{"priority:desc, id", "priority:desc,id"},
+		{"due_date asc", "due_date"},
+		{"cf_12:desc", "cf_12:desc"},
+		{"Due Week desc", "cf_7:desc"},
+		{"Due Week", "cf_7"},
+	}
+	for _, tt := range tests {
+		got, err := ParseIssueSort(tt.value, resolve)
+		if tt.want == "" && tt.value != "" {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %q", tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%q: got %q, %v; want %q", tt.value, got, err, tt.want)
+		}
+	}
+
+	_, err := ParseIssueSort("id,bogus", nil)
+	if err == nil || !strings.Contains(err.Error(), "created_on") {
+		t.Errorf("expected an error listing the valid fields, got %v", err)
+	}
+	if _, err := ParseIssueSort("id:down", resolve); err == nil {
+		t.Error("expected an error for an invalid order")
+	}
+}
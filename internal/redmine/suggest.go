@@ -0,0 +1,88 @@
+package redmine
+
+import (
+	"sort"
+	"strings"
+)
+
+// MaxSuggestions is the number of "did you mean" candidates included in errors
+const MaxSuggestions = 3
+
+// SuggestNames returns up to limit names that are close to query, best first.
+// Names are compared case-insensitively with whitespace normalized; a name is
+// considered close when it shares a prefix with the query or is within a small
+// edit distance relative to the query length.
+func SuggestNames(query string, names []string, limit int) []string {
+	q := normalizeName(query)
+	if q == "" || limit <= 0 {
+		return nil
+	}
+
+	type candidate struct {
+		name  string
+		score int
+	}
+
+	threshold := max(1, len([]rune(q))/3)
+	seen := make(map[string]bool)
+	var candidates []candidate
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		n := normalizeName(name)
+		if n == "" {
+			continue
+		}
+		score := levenshtein(q, n)
+		if strings.HasPrefix(n, q) || strings.HasPrefix(q, n) {
+			// Prefix matches rank ahead of typos of similar size
+			score = min(score, 1)
+		}
+		if score <= threshold {
+			candidates = append(candidates, candidate{name: name, score: score})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score < candidates[j].score
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	suggestions := make([]string, 0, min(limit, len(candidates)))
+	for i := 0; i < len(candidates) && i < limit; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+// levenshtein returns the edit distance between two strings, counting an
+// adjacent transposition ("Bgu" vs "Bug") as a single edit
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
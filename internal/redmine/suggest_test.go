@@ -0,0 +1,54 @@
+package redmine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"in progess", "in progress", 1},
+		{"kitten", "sitting", 3},
+		{"bgu", "bug", 1},
+		{"測試", "測驗", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggestNames(t *testing.T) {
+	statuses := []string{"New", "In Progress", "Resolved", "Feedback", "Closed", "Rejected"}
+
+	tests := []struct {
+		name  string
+		query string
+		names []string
+		want  []string
+	}{
+		{"typo", "In Progess", statuses, []string{"In Progress"}},
+		{"case and typo", "closd", statuses, []string{"Closed"}},
+		{"prefix", "Res", statuses, []string{"Resolved"}},
+		{"transposition", "Bgu", []string{"Bug", "Bugfix", "Build"}, []string{"Bug"}},
+		{"ranked by distance", "Featre", []string{"Features", "Fixture", "Feature"}, []string{"Feature", "Features"}},
+		{"limited to max", "a", []string{"ab", "ac", "ad", "ae"}, []string{"ab", "ac", "ad"}},
+		{"nothing close", "Deployment", statuses, []string{}},
+		{"empty query", "  ", statuses, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SuggestNames(tt.query, tt.names, MaxSuggestions)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SuggestNames(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package redmine
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IssueTemplate is a named issue shape. Fields holds issues_create fields
+// (project, tracker, subject, description, priority, custom_fields, ...) and
+// Subtasks the fields of child issues, which default to the parent's project
+// and tracker. Every string in them is a text/template rendered with the
+// caller's variables, e.g. "Incident: {{.service}}".
+type IssueTemplate struct {
+	Name     string            `yaml:"name"`
+	Summary  string            `yaml:"summary"`
+	Defaults map[string]string `yaml:"defaults"` // values of optional variables
+	Subtasks []map[string]any  `yaml:"subtasks"`
+	Fields   map[string]any    `yaml:",inline"`
+
+	// variables are the placeholders used in Fields and Subtasks
+	variables []string
+}
+
+// IssueTemplates are the templates of a templates file, in file order
+type IssueTemplates []*IssueTemplate
+
+// LoadIssueTemplates reads issue templates from a YAML (or JSON) file with a
+// templates list and checks that every placeholder parses
+func LoadIssueTemplates(path string) (IssueTemplates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issue templates: %w", err)
+	}
+	var file struct {
+		Templates IssueTemplates `yaml:"templates"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse issue templates: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, t := range file.Templates {
+		if strings.TrimSpace(t.Name) == "" {
+			return nil, fmt.Errorf("issue template %d has no name", i+1)
+		}
+		key := strings.ToLower(t.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate issue template %q", t.Name)
+		}
+		seen[key] = true
+		if _, ok := t.Fields["subject"]; !ok {
+			return nil, fmt.Errorf("issue template %q has no subject", t.Name)
+		}
+
+		vars := make(map[string]bool)
+		if err := walkTemplateStrings(t.Fields, "", func(field, s string) (any, error) {
+			return s, collectVariables(field, s, vars)
+		}); err != nil {
+			return nil, fmt.Errorf("issue template %q: %w", t.Name, err)
+		}
+		for j, sub := range t.Subtasks {
+			if _, ok := sub["subject"]; !ok {
+				return nil, fmt.Errorf("issue template %q: subtask %d has no subject", t.Name, j+1)
+			}
+			if err := walkTemplateStrings(sub, fmt.Sprintf("subtasks[%d].", j), func(field, s string) (any, error) {
+				return s, collectVariables(field, s, vars)
+			}); err != nil {
+				return nil, fmt.Errorf("issue template %q: %w", t.Name, err)
+			}
+		}
+		t.variables = slices.Sorted(maps.Keys(vars))
+	}
+	return file.Templates, nil
+}
+
+// Find returns the template named name, ignoring case
+func (ts IssueTemplates) Find(name string) (*IssueTemplate, error) {
+	for _, t := range ts {
+		if strings.EqualFold(t.Name, name) {
+			return t, nil
+		}
+	}
+	names := make([]string, len(ts))
+	for i, t := range ts {
+		names[i] = t.Name
+	}
+	return nil, fmt.Errorf("issue template not found: %s (available: %s)", name, strings.Join(names, ", "))
+}
+
+// Variables returns the placeholders of the template, sorted
+func (t *IssueTemplate) Variables() []string {
+	return t.variables
+}
+
+// RequiredVariables returns the placeholders without a default value
+func (t *IssueTemplate) RequiredVariables() []string {
+	var required []string
+	for _, v := range t.variables {
+		if _, ok := t.Defaults[v]; !ok {
+			required = append(required, v)
+		}
+	}
+	return required
+}
+
+// Render fills the template's placeholders with vars, falling back to the
+// template's defaults, and returns the issue fields and the subtask fields.
+// A placeholder without a value is an error naming it.
+func (t *IssueTemplate) Render(vars map[string]any) (map[string]any, []map[string]any, error) {
+	data := make(map[string]any, len(t.Defaults)+len(vars))
+	for k, v := range t.Defaults {
+		data[k] = v
+	}
+	maps.Copy(data, vars)
+
+	var missing []string
+	for _, v := range t.variables {
+		if _, ok := data[v]; !ok {
+			missing = append(missing, fmt.Sprintf("%s ({{.%s}})", v, v))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("missing template variables: %s", strings.Join(missing, ", "))
+	}
+
+	render := func(field, s string) (any, error) {
+		tmpl, err := template.New(field).Option("missingkey=error").Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", field, err)
+		}
+		return buf.String(), nil
+	}
+
+	issue := cloneFields(t.Fields)
+	if err := walkTemplateStrings(issue, "", render); err != nil {
+		return nil, nil, err
+	}
+	subtasks := make([]map[string]any, len(t.Subtasks))
+	for i, sub := range t.Subtasks {
+		subtasks[i] = cloneFields(sub)
+		if err := walkTemplateStrings(subtasks[i], fmt.Sprintf("subtasks[%d].", i), render); err != nil {
+			return nil, nil, err
+		}
+	}
+	return issue, subtasks, nil
+}
+
+// walkTemplateStrings replaces every string in fields, including those in
+// nested maps and lists, with the result of fn; field is the string's path
+func walkTemplateStrings(fields map[string]any, prefix string, fn func(field, s string) (any, error)) error {
+	for k, v := range fields {
+		nv, err := walkTemplateValue(v, prefix+k, fn)
+		if err != nil {
+			return err
+		}
+		fields[k] = nv
+	}
+	return nil
+}
+
+func walkTemplateValue(v any, field string, fn func(field, s string) (any, error)) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return fn(field, v)
+	case map[string]any:
+		return v, walkTemplateStrings(v, field+".", fn)
+	case []any:
+		for i, item := range v {
+			nv, err := walkTemplateValue(item, fmt.Sprintf("%s[%d]", field, i), fn)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = nv
+		}
+	}
+	return v, nil
+}
+
+// cloneFields deep-copies template fields so rendering leaves the template intact
+func cloneFields(fields map[string]any) map[string]any {
+	clone := make(map[string]any, len(fields))
+	for k, v := range fields {
+		clone[k] = cloneValue(v)
+	}
+	return clone
+}
+
+func cloneValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return cloneFields(v)
+	case []any:
+		clone := make([]any, len(v))
+		for i, item := range v {
+			clone[i] = cloneValue(item)
+		}
+		return clone
+	}
+	return v
+}
+
+// collectVariables parses s and adds the top-level fields it reads ({{.name}})
+// to vars
+func collectVariables(field, s string, vars map[string]bool) error {
+	tmpl, err := template.New(field).Parse(s)
+	if err != nil {
+		return fmt.Errorf("invalid placeholder in %s: %w", field, err)
+	}
+	if tmpl.Tree != nil {
+		collectNodeVariables(tmpl.Tree.Root, vars)
+	}
+	return nil
+}
+
+func collectNodeVariables(node parse.Node, vars map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectNodeVariables(child, vars)
+		}
+	case *parse.ActionNode:
+		collectNodeVariables(n.Pipe, vars)
+	case *parse.IfNode:
+		collectNodeVariables(n.Pipe, vars)
+		collectNodeVariables(n.List, vars)
+		collectNodeVariables(n.ElseList, vars)
+	case *parse.RangeNode:
+		// The body's dot is the element, not the variables
+		collectNodeVariables(n.Pipe, vars)
+		collectNodeVariables(n.ElseList, vars)
+	case *parse.WithNode:
+		collectNodeVariables(n.Pipe, vars)
+		collectNodeVariables(n.ElseList, vars)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				collectNodeVariables(arg, vars)
+			}
+		}
+	case *parse.FieldNode:
+		vars[n.Ident[0]] = true
+	case *parse.ChainNode:
+		collectNodeVariables(n.Node, vars)
+	}
+}
@@ -0,0 +1,102 @@
+package redmine
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testTemplates = `templates:
+  - name: incident
+    summary: Production incident report
+    project: ops
+    tracker: 3
+    subject: "Incident: {{.service}}"
+    description: |
+      Service: {{.service}}
+      Severity: {{.severity}}
+      {{if .ticket}}Ticket: {{.ticket}}{{end}}
+    custom_fields:
+      Severity: "{{.severity}}"
+    defaults:
+      severity: 3
+      ticket: ""
+    subtasks:
+      - subject: "Postmortem for {{.service}}"
+        estimated_hours: 2
+`
+
+func writeTemplates(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "templates.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadIssueTemplates(t *testing.T) {
+	templates, err := LoadIssueTemplates(writeTemplates(t, testTemplates))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tmpl, err := templates.Find("Incident")
+	if err != nil {
+		t.Fatalf("expected the template to be found ignoring case: %v", err)
+	}
+	if got := strings.Join(tmpl.Variables(), ","); got != "service,severity,ticket" {
+		t.Errorf("unexpected variables: %s", got)
+	}
+	if got := strings.Join(tmpl.RequiredVariables(), ","); got != "service" {
+		t.Errorf("unexpected required variables: %s", got)
+	}
+	if _, err := templates.Find("change"); err == nil || !strings.Contains(err.Error(), "incident") {
+		t.Errorf("expected an error listing the templates, got %v", err)
+	}
+
+	for _, bad := range []string{
+		"templates:\n  - subject: x\n",
+		"templates:\n  - name: a\n    subject: x\n  - name: A\n    subject: y\n",
+		"templates:\n  - name: a\n    description: x\n",
+		"templates:\n  - name: a\n    subject: \"{{.x\"\n",
+	} {
+		if _, err := LoadIssueTemplates(writeTemplates(t, bad)); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestIssueTemplateRender(t *testing.T) {
+	templates, err := LoadIssueTemplates(writeTemplates(t, testTemplates))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := templates[0]
+
+	issue, subtasks, err := tmpl.Render(map[string]any{"service": "checkout", "ticket": "T-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issue["subject"] != "Incident: checkout" || issue["tracker"] != 3 {
+		t.Errorf("unexpected issue: %v", issue)
+	}
+	if desc := issue["description"].(string); !strings.Contains(desc, "Severity: 3") || !strings.Contains(desc, "Ticket: T-1") {
+		t.Errorf("unexpected description: %q", desc)
+	}
+	if cf := issue["custom_fields"].(map[string]any); cf["Severity"] != "3" {
+		t.Errorf("unexpected custom fields: %v", cf)
+	}
+	if len(subtasks) != 1 || subtasks[0]["subject"] != "Postmortem for checkout" {
+		t.Errorf("unexpected subtasks: %v", subtasks)
+	}
+	// Rendering leaves the template's placeholders in place
+	if tmpl.Fields["subject"] != "Incident: {{.service}}" || tmpl.Fields["custom_fields"].(map[string]any)["Severity"] != "{{.severity}}" {
+		t.Errorf("expected the template to be unchanged, got %v", tmpl.Fields)
+	}
+
+	_, _, err = tmpl.Render(map[string]any{"severity": "1"})
+	if err == nil || !strings.Contains(err.Error(), "service ({{.service}})") {
+		t.Errorf("expected an error naming the missing placeholder, got %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+package redmine
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TimeEntryReport sums time entries per group, shared by the
+// timeEntries_report tool and the REST time report
+type TimeEntryReport struct {
+	TotalHours float64          `json:"total_hours"`
+	EntryCount int              `json:"entry_count"`
+	GroupBy    []string         `json:"group_by"`
+	Groups     []map[string]any `json:"groups"`
+	Period     string           `json:"period,omitempty"`
+}
+
+// BuildTimeEntryReport fetches every time entry matching params and sums
+// hours by the groupBy dimensions, looking up issue subjects when grouping
+// by issue
+func BuildTimeEntryReport(c *Client, params ListTimeEntriesParams, groupBy []string) (*TimeEntryReport, error) {
+	entries, _, err := c.FetchTimeEntries(params, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch time entries: %w", err)
+	}
+
+	// Issue subjects are not included in time entries, look them up when grouping by issue
+	var subjects map[int]string
+	if slices.Contains(groupBy, "issue") {
+		subjects = c.LookupIssueSubjects(entries)
+	}
+
+	groups, totalHours := AggregateTimeEntries(entries, groupBy, subjects)
+	report := &TimeEntryReport{
+		TotalHours: totalHours,
+		EntryCount: len(entries),
+		GroupBy:    groupBy,
+		Groups:     groups,
+	}
+	if params.From != "" || params.To != "" {
+		report.Period = fmt.Sprintf("%s ~ %s", params.From, params.To)
+	}
+	return report, nil
+}
+
+// CSV renders the report with one column per group_by dimension plus hours,
+// followed by a total row
+func (r *TimeEntryReport) CSV() ([]byte, error) {
+	return TimeEntryReportCSV(r.Groups, r.GroupBy, r.TotalHours)
+}
+
+// FetchTimeEntries fetches time entries page by page starting at params.Offset.
+// maxEntries limits the number of entries returned (0 = no limit).
+func (c *Client) FetchTimeEntries(params ListTimeEntriesParams, maxEntries int) ([]TimeEntry, int, error) {
+	pageSize := 100 // Redmine typically limits to 100 per request
+	startOffset := params.Offset
+
+	var allEntries []TimeEntry
+	totalCount := 0
+	for {
+		params.Limit = pageSize
+		if maxEntries > 0 {
+			params.Limit = min(pageSize, maxEntries-len(allEntries))
+		}
+		params.Offset = startOffset + len(allEntries)
+
+		entries, total, err := c.ListTimeEntries(params)
+		if err != nil {
+			return nil, 0, err
+		}
+		totalCount = total
+		allEntries = append(allEntries, entries...)
+
+		if len(entries) < params.Limit || startOffset+len(allEntries) >= totalCount {
+			break
+		}
+		if maxEntries > 0 && len(allEntries) >= maxEntries {
+			break
+		}
+	}
+
+	return allEntries, totalCount, nil
+}
+
+// TimeEntryReportCSV renders report groups as CSV with one column per group_by
+// dimension plus hours, followed by a total row
+func TimeEntryReportCSV(groups []map[string]any, groupBy []string, totalHours float64) ([]byte, error) {
+	var header []string
+	var keys []string
+	for _, g := range groupBy {
+		if g == "issue" {
+			header = append(header, "Issue ID", "Subject")
+			keys = append(keys, "issue_id", "subject")
+			continue
+		}
+		header = append(header, strings.ToUpper(g[:1])+g[1:])
+		keys = append(keys, g)
+	}
+	header = append(header, "Hours")
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write(header)
+
+	for _, group := range groups {
+		row := make([]string, 0, len(header))
+		for _, key := range keys {
+			if v, ok := group[key]; ok && v != nil {
+				row = append(row, fmt.Sprintf("%v", v))
+			} else {
+				row = append(row, "")
+			}
+		}
+		row = append(row, strconv.FormatFloat(group["hours"].(float64), 'f', -1, 64))
+		_ = writer.Write(row)
+	}
+
+	total := make([]string, len(header))
+	total[0] = "Total"
+	total[len(total)-1] = strconv.FormatFloat(totalHours, 'f', -1, 64)
+	_ = writer.Write(total)
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// TimeEntryGroupDimensions lists the supported time report group_by values
+var TimeEntryGroupDimensions = []string{"project", "user", "activity", "issue", "date"}
+
+// ParseTimeEntryGroupBy splits a comma-separated group_by value and rejects unknown dimensions
+func ParseTimeEntryGroupBy(groupByStr string) ([]string, error) {
+	var groupBy []string
+	for _, g := range strings.Split(groupByStr, ",") {
+		g = strings.ToLower(strings.TrimSpace(g))
+		if g == "" {
+			continue
+		}
+		if !slices.Contains(TimeEntryGroupDimensions, g) {
+			return nil, fmt.Errorf("unsupported group_by '%s' (supported: %s)", g, strings.Join(TimeEntryGroupDimensions, ", "))
+		}
+		groupBy = append(groupBy, g)
+	}
+	if len(groupBy) == 0 {
+		return nil, fmt.Errorf("group_by is required (supported: %s)", strings.Join(TimeEntryGroupDimensions, ", "))
+	}
+	return groupBy, nil
+}
+
+// timeEntryGroupKey identifies one aggregation bucket of a time report
+type timeEntryGroupKey struct {
+	Project  string
+	User     string
+	Activity string
+	IssueID  int
+	Date     string
+}
+
+// AggregateTimeEntries sums hours per group. Groups are sorted chronologically when
+// grouping by date, otherwise by hours descending.
+func AggregateTimeEntries(entries []TimeEntry, groupBy []string, subjects map[int]string) ([]map[string]any, float64) {
+	aggregated := make(map[timeEntryGroupKey]float64)
+	var totalHours float64
+
+	for _, entry := range entries {
+		key := timeEntryGroupKey{}
+		for _, g := range groupBy {
+			switch g {
+			case "project":
+				key.Project = entry.Project.Name
+			case "user":
+				key.User = entry.User.Name
+			case "activity":
+				key.Activity = entry.Activity.Name
+			case "issue":
+				if entry.Issue != nil {
+					key.IssueID = entry.Issue.ID
+				}
+			case "date":
+				key.Date = entry.SpentOn
+			}
+		}
+		aggregated[key] += entry.Hours
+		totalHours += entry.Hours
+	}
+
+	byIssue := slices.Contains(groupBy, "issue")
+	byDate := slices.Contains(groupBy, "date")
+
+	groups := make([]map[string]any, 0, len(aggregated))
+	for key, hours := range aggregated {
+		group := map[string]any{
+			"hours": hours,
+		}
+		if key.Project != "" {
+			group["project"] = key.Project
+		}
+		if key.User != "" {
+			group["user"] = key.User
+		}
+		if key.Activity != "" {
+			group["activity"] = key.Activity
+		}
+		if byIssue {
+			if key.IssueID > 0 {
+				group["issue_id"] = key.IssueID
+				if subject := subjects[key.IssueID]; subject != "" {
+					group["subject"] = subject
+				}
+			} else {
+				group["issue_id"] = nil
+				group["subject"] = "(no issue)"
+			}
+		}
+		if byDate {
+			group["date"] = key.Date
+		}
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if byDate {
+			di, dj := groups[i]["date"].(string), groups[j]["date"].(string)
+			if di != dj {
+				return di < dj
+			}
+		}
+		return groups[i]["hours"].(float64) > groups[j]["hours"].(float64)
+	})
+
+	return groups, totalHours
+}
+
+// LookupIssueSubjects maps issue IDs referenced by time entries to their subjects.
+// Time entries usually only carry the issue ID, so missing subjects are fetched in
+// batches; lookup failures leave the subject out.
+func (c *Client) LookupIssueSubjects(entries []TimeEntry) map[int]string {
+	subjects := make(map[int]string)
+	var missing []int
+	for _, entry := range entries {
+		if entry.Issue == nil {
+			continue
+		}
+		if _, seen := subjects[entry.Issue.ID]; seen {
+			continue
+		}
+		subjects[entry.Issue.ID] = entry.Issue.Name
+		if entry.Issue.Name == "" {
+			missing = append(missing, entry.Issue.ID)
+		}
+	}
+
+	for start := 0; start < len(missing); start += 100 {
+		batch := missing[start:min(start+100, len(missing))]
+		issues, _, err := c.SearchIssues(SearchIssuesParams{
+			IssueIDs: batch,
+			StatusID: "*",
+			Limit:    len(batch),
+		})
+		if err != nil {
+			break
+		}
+		for _, issue := range issues {
+			subjects[issue.ID] = issue.Subject
+		}
+	}
+
+	return subjects
+}
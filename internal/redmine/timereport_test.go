@@ -0,0 +1,92 @@
+package redmine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTimeEntryGroupBy(t *testing.T) {
+	got, err := ParseTimeEntryGroupBy(" Issue , date")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "issue" || got[1] != "date" {
+		t.Errorf("expected [issue date], got %v", got)
+	}
+
+	_, err = ParseTimeEntryGroupBy("user,sprint")
+	if err == nil {
+		t.Fatal("expected error for unknown dimension")
+	}
+	if !strings.Contains(err.Error(), "sprint") || !strings.Contains(err.Error(), "project, user, activity, issue, date") {
+		t.Errorf("expected error to name the value and list supported dimensions, got: %v", err)
+	}
+
+	if _, err := ParseTimeEntryGroupBy(" , "); err == nil {
+		t.Fatal("expected error for empty group_by")
+	}
+}
+
+func TestAggregateTimeEntries(t *testing.T) {
+	entries := []TimeEntry{
+		{Issue: &IDName{ID: 1}, SpentOn: "2025-01-07", Hours: 2},
+		{Issue: &IDName{ID: 2}, SpentOn: "2025-01-06", Hours: 1},
+		{Issue: &IDName{ID: 1}, SpentOn: "2025-01-06", Hours: 3},
+		{SpentOn: "2025-01-08", Hours: 0.5},
+	}
+
+	t.Run("by issue includes subject", func(t *testing.T) {
+		groups, total := AggregateTimeEntries(entries, []string{"issue"}, map[int]string{1: "Login bug", 2: "Docs"})
+		if total != 6.5 {
+			t.Errorf("expected total=6.5, got %v", total)
+		}
+		if len(groups) != 3 {
+			t.Fatalf("expected 3 groups, got %d", len(groups))
+		}
+		if groups[0]["issue_id"] != 1 || groups[0]["subject"] != "Login bug" || groups[0]["hours"] != 5.0 {
+			t.Errorf("unexpected first group: %v", groups[0])
+		}
+		if groups[2]["subject"] != "(no issue)" {
+			t.Errorf("expected entries without issue to be grouped as '(no issue)', got %v", groups[2])
+		}
+	})
+
+	t.Run("by date is chronological", func(t *testing.T) {
+		groups, _ := AggregateTimeEntries(entries, []string{"date"}, nil)
+		var dates []string
+		for _, g := range groups {
+			dates = append(dates, g["date"].(string))
+		}
+		if strings.Join(dates, ",") != "2025-01-06,2025-01-07,2025-01-08" {
+			t.Errorf("expected chronological dates, got %v", dates)
+		}
+		if groups[0]["hours"] != 4.0 {
+			t.Errorf("expected 4 hours on 2025-01-06, got %v", groups[0]["hours"])
+		}
+	})
+}
+
+func TestTimeEntryReportCSV(t *testing.T) {
+	groups := []map[string]any{
+		{"issue_id": 10, "subject": "Fix, then test", "hours": 2.5},
+		{"issue_id": nil, "subject": "(no issue)", "hours": 1.0},
+	}
+
+	data, err := TimeEntryReportCSV(groups, []string{"issue"}, 3.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "Issue ID,Subject,Hours\n10,\"Fix, then test\",2.5\n,(no issue),1\nTotal,,3.5\n"
+	if string(data) != want {
+		t.Errorf("unexpected CSV:\n%s\nwant:\n%s", data, want)
+	}
+
+	data, err = TimeEntryReportCSV([]map[string]any{{"user": "Alice", "date": "2025-01-06", "hours": 8.0}}, []string{"user", "date"}, 8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "User,Date,Hours\nAlice,2025-01-06,8\n") {
+		t.Errorf("unexpected CSV:\n%s", data)
+	}
+}
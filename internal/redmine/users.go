@@ -0,0 +1,173 @@
+package redmine
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// UserDetail is a user as GET /users/{id}.json returns it. Redmine only
+// shows login, status and last login to admins and to the user themselves,
+// so those fields are empty for other callers.
+type UserDetail struct {
+	ID          int              `json:"id"`
+	Login       string           `json:"login"`
+	Firstname   string           `json:"firstname"`
+	Lastname    string           `json:"lastname"`
+	Mail        string           `json:"mail"`
+	Admin       bool             `json:"admin"`
+	Status      int              `json:"status"`
+	CreatedOn   string           `json:"created_on"`
+	LastLoginOn string           `json:"last_login_on"`
+	Groups      []IDName         `json:"groups"`
+	Memberships []UserMembership `json:"memberships"`
+}
+
+// UserMembership is a user's membership in one project
+type UserMembership struct {
+	ID      int              `json:"id"`
+	Project IDName           `json:"project"`
+	Roles   []MembershipRole `json:"roles"`
+}
+
+// userStatusNames maps Redmine user status codes to names
+var userStatusNames = map[int]string{1: "active", 2: "registered", 3: "locked"}
+
+// GetUser returns a user by ID, with includes such as "memberships" and "groups"
+func (c *Client) GetUser(userID int, includes []string) (*UserDetail, error) {
+	return c.getUserDetail(fmt.Sprintf("/users/%d.json", userID), includes)
+}
+
+// GetCurrentUserDetail returns the user of the API key, with includes such as
+// "memberships" and "groups"
+func (c *Client) GetCurrentUserDetail(includes []string) (*UserDetail, error) {
+	return c.getUserDetail("/users/current.json", includes)
+}
+
+func (c *Client) getUserDetail(path string, includes []string) (*UserDetail, error) {
+	if len(includes) > 0 {
+		path += "?include=" + strings.Join(includes, ",")
+	}
+	data, err := c.doRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		User UserDetail `json:"user"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.User, nil
+}
+
+// Profile formats the user for the users_get tool and the REST user route,
+// leaving out fields Redmine didn't return instead of showing them empty
+func (u *UserDetail) Profile() map[string]any {
+	result := map[string]any{
+		"id":   u.ID,
+		"name": strings.TrimSpace(u.Firstname + " " + u.Lastname),
+	}
+	if u.Login != "" {
+		result["login"] = u.Login
+	}
+	if u.Mail != "" {
+		result["mail"] = u.Mail
+	}
+	if u.CreatedOn != "" {
+		result["created_on"] = u.CreatedOn
+	}
+	if u.LastLoginOn != "" {
+		result["last_login_on"] = u.LastLoginOn
+	}
+	if name, ok := userStatusNames[u.Status]; ok {
+		result["status"] = name
+	}
+	if u.Admin {
+		result["admin"] = true
+	}
+	if u.Status == 0 {
+		result["note"] = "Redmine only returns login, status and last login to admins"
+	}
+
+	groups := make([]map[string]any, len(u.Groups))
+	for i, g := range u.Groups {
+		groups[i] = map[string]any{"id": g.ID, "name": g.Name}
+	}
+	result["groups"] = groups
+
+	projects := make([]map[string]any, len(u.Memberships))
+	for i, m := range u.Memberships {
+		roles := make([]string, len(m.Roles))
+		for j, r := range m.Roles {
+			roles[j] = r.Name
+		}
+		projects[i] = map[string]any{
+			"project": map[string]any{"id": m.Project.ID, "name": m.Project.Name},
+			"roles":   roles,
+		}
+	}
+	result["memberships"] = projects
+
+	return result
+}
+
+// EffectiveRole is a role a user holds on a project, directly, through
+// groups, or both
+type EffectiveRole struct {
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	Direct    bool     `json:"direct"`
+	Inherited bool     `json:"inherited"`
+	ViaGroups []IDName `json:"via_groups,omitempty"`
+}
+
+// EffectiveRoles combines the user's own membership among memberships with
+// those of the groups they belong to into one entry per role. Redmine copies
+// group roles into the user's membership marked inherited, so a role can show
+// up both ways; when groups is empty the inherited flag is all there is to go
+// on. The memberships the roles were taken from are returned too.
+func EffectiveRoles(userID int, groups []IDName, memberships []ProjectMembership) ([]EffectiveRole, []ProjectMembership) {
+	inGroup := make(map[int]bool, len(groups))
+	for _, g := range groups {
+		inGroup[g.ID] = true
+	}
+
+	var roles []EffectiveRole
+	index := make(map[int]int)
+	role := func(r MembershipRole) *EffectiveRole {
+		i, ok := index[r.ID]
+		if !ok {
+			i = len(roles)
+			index[r.ID] = i
+			roles = append(roles, EffectiveRole{ID: r.ID, Name: r.Name})
+		}
+		return &roles[i]
+	}
+
+	var used []ProjectMembership
+	for _, m := range memberships {
+		switch {
+		case m.User != nil && m.User.ID == userID:
+			for _, r := range m.Roles {
+				if r.Inherited {
+					role(r).Inherited = true
+				} else {
+					role(r).Direct = true
+				}
+			}
+		case m.Group != nil && inGroup[m.Group.ID]:
+			for _, r := range m.Roles {
+				e := role(r)
+				e.Inherited = true
+				e.ViaGroups = append(e.ViaGroups, *m.Group)
+			}
+		default:
+			continue
+		}
+		used = append(used, m)
+	}
+	return roles, used
+}
@@ -0,0 +1,64 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetUser_Profile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/5.json":
+			if got := r.URL.Query().Get("include"); got != "memberships,groups" {
+				t.Errorf("expected memberships and groups to be included, got %q", got)
+			}
+			_, _ = w.Write([]byte(`{"user":{"id":5,"login":"bob","firstname":"Bob","lastname":"Lin","mail":"bob@example.com",
+				"status":1,"created_on":"2024-01-02T00:00:00Z","last_login_on":"2025-03-01T08:00:00Z",
+				"groups":[{"id":20,"name":"Frontend Team"}],
+				"memberships":[{"id":1,"project":{"id":3,"name":"Web"},"roles":[{"id":4,"name":"Developer"},{"id":5,"name":"Reporter"}]}]}}`))
+		case "/users/6.json":
+			// What a non-admin key sees of another user
+			_, _ = w.Write([]byte(`{"user":{"id":6,"firstname":"Amy","lastname":"Wu","created_on":"2024-05-06T00:00:00Z"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	user, err := client.GetUser(5, []string{"memberships", "groups"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	profile := user.Profile()
+	if profile["name"] != "Bob Lin" || profile["login"] != "bob" || profile["status"] != "active" || profile["last_login_on"] != "2025-03-01T08:00:00Z" {
+		t.Errorf("unexpected profile: %v", profile)
+	}
+	memberships := profile["memberships"].([]map[string]any)
+	if len(memberships) != 1 || len(memberships[0]["roles"].([]string)) != 2 {
+		t.Errorf("expected one project with two roles, got %v", memberships)
+	}
+	if _, ok := profile["note"]; ok {
+		t.Errorf("expected no limited data note for a full profile, got %v", profile["note"])
+	}
+
+	user, err = client.GetUser(6, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	profile = user.Profile()
+	for _, key := range []string{"login", "mail", "status", "last_login_on"} {
+		if _, ok := profile[key]; ok {
+			t.Errorf("expected %s to be left out when Redmine didn't return it", key)
+		}
+	}
+	if profile["note"] == nil || len(profile["groups"].([]map[string]any)) != 0 {
+		t.Errorf("expected a note about limited data and empty groups, got %v", profile)
+	}
+
+	if _, err := client.GetUser(7, nil); err == nil {
+		t.Error("expected an error for an unknown user")
+	}
+}
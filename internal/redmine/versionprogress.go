@@ -0,0 +1,178 @@
+package redmine
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultAtRiskDays is how close to its due date a version with open high
+// priority issues is reported at risk
+const DefaultAtRiskDays = 7
+
+// VersionIssue is an open issue listed in a version progress report
+type VersionIssue struct {
+	ID         int    `json:"id"`
+	Subject    string `json:"subject"`
+	Status     string `json:"status"`
+	Priority   string `json:"priority"`
+	AssignedTo string `json:"assigned_to,omitempty"`
+	DueDate    string `json:"due_date,omitempty"`
+}
+
+// VersionProgress is how far a version is from release. Completion counts
+// closed issues as done and open ones by their done ratio. DaysRemaining is
+// negative once the due date has passed and left out without one.
+type VersionProgress struct {
+	Version           IDName         `json:"version"`
+	Status            string         `json:"status"`
+	DueDate           string         `json:"due_date,omitempty"`
+	DaysRemaining     *int           `json:"days_remaining,omitempty"`
+	TotalCount        int            `json:"total_count"`
+	OpenCount         int            `json:"open_count"`
+	ClosedCount       int            `json:"closed_count"`
+	CompletionPercent float64        `json:"completion_percent"`
+	OpenByPriority    []CountBy      `json:"open_by_priority"`
+	Overdue           []VersionIssue `json:"overdue"`
+	EstimatedHours    float64        `json:"estimated_hours"`
+	SpentHours        float64        `json:"spent_hours"`
+	AtRisk            bool           `json:"at_risk"`
+	AtRiskIssues      []VersionIssue `json:"at_risk_issues,omitempty"`
+	Note              string         `json:"note,omitempty"`
+}
+
+// HighPriorityIDs returns the priorities ranked above the default one, which
+// Redmine lists from lowest to highest. Without a default only the highest
+// priority counts.
+func HighPriorityIDs(priorities []IssuePriority) map[int]bool {
+	high := make(map[int]bool)
+	start := len(priorities) - 1
+	for i, p := range priorities {
+		if p.IsDefault {
+			start = i + 1
+		}
+	}
+	for _, p := range priorities[max(start, 0):] {
+		high[p.ID] = true
+	}
+	return high
+}
+
+// BuildVersionProgress fetches the version's open and closed issues in the
+// project and the time logged on them. The version is at risk when its due
+// date is less than atRiskDays away, or past, and open issues of a high
+// priority remain.
+func BuildVersionProgress(c *Client, projectID int, version Version, today time.Time, atRiskDays int, highPriorities map[int]bool) (*VersionProgress, error) {
+	params := SearchIssuesParams{ProjectID: strconv.Itoa(projectID), VersionID: strconv.Itoa(version.ID)}
+
+	var open, closed []Issue
+	var openTotal, closedTotal int
+	var entries []TimeEntry
+	g := new(errgroup.Group)
+	g.Go(func() error {
+		p := params
+		p.StatusID = "open"
+		var err error
+		if open, openTotal, err = listAllIssues(c, p); err != nil {
+			return fmt.Errorf("failed to fetch open issues: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		p := params
+		p.StatusID = "closed"
+		var err error
+		if closed, closedTotal, err = listAllIssues(c, p); err != nil {
+			return fmt.Errorf("failed to fetch closed issues: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if entries, err = listAllTimeEntries(c, ListTimeEntriesParams{ProjectID: params.ProjectID, VersionID: version.ID}); err != nil {
+			return fmt.Errorf("failed to fetch time entries: %w", err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	p := summarizeVersion(version, open, closed, today, atRiskDays, highPriorities)
+	p.Note = cappedIssuesNote(len(open)+len(closed), openTotal+closedTotal)
+	for _, e := range entries {
+		p.SpentHours += e.Hours
+	}
+	p.SpentHours = roundHours(p.SpentHours)
+	return p, nil
+}
+
+// summarizeVersion computes the progress of a version from its issues
+func summarizeVersion(version Version, open, closed []Issue, today time.Time, atRiskDays int, highPriorities map[int]bool) *VersionProgress {
+	todayStr := today.Format("2006-01-02")
+	p := &VersionProgress{
+		Version:     IDName{ID: version.ID, Name: version.Name},
+		Status:      version.Status,
+		DueDate:     version.DueDate,
+		TotalCount:  len(open) + len(closed),
+		OpenCount:   len(open),
+		ClosedCount: len(closed),
+		Overdue:     []VersionIssue{},
+	}
+
+	if due, err := time.Parse("2006-01-02", version.DueDate); err == nil {
+		todayDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+		days := int(math.Round(due.Sub(todayDate).Hours() / 24))
+		p.DaysRemaining = &days
+	}
+
+	done := 100 * len(closed)
+	byPriority := make(map[string]int)
+	var highOpen []VersionIssue
+	for _, issue := range open {
+		done += issue.DoneRatio
+		byPriority[issue.Priority.Name]++
+		if issue.DueDate != "" && issue.DueDate < todayStr {
+			p.Overdue = append(p.Overdue, newVersionIssue(issue))
+		}
+		if highPriorities[issue.Priority.ID] {
+			highOpen = append(highOpen, newVersionIssue(issue))
+		}
+	}
+	if p.TotalCount > 0 {
+		p.CompletionPercent = math.Round(float64(done)/float64(p.TotalCount)*10) / 10
+	}
+	p.OpenByPriority = sortedCounts(byPriority)
+	sort.SliceStable(p.Overdue, func(i, j int) bool { return p.Overdue[i].DueDate < p.Overdue[j].DueDate })
+
+	for _, issue := range append(open, closed...) {
+		if issue.EstimatedHours != nil {
+			p.EstimatedHours += *issue.EstimatedHours
+		}
+	}
+	p.EstimatedHours = roundHours(p.EstimatedHours)
+
+	if p.DaysRemaining != nil && *p.DaysRemaining < atRiskDays && len(highOpen) > 0 {
+		p.AtRisk = true
+		p.AtRiskIssues = highOpen
+	}
+	return p
+}
+
+func newVersionIssue(issue Issue) VersionIssue {
+	v := VersionIssue{
+		ID:       issue.ID,
+		Subject:  issue.Subject,
+		Status:   issue.Status.Name,
+		Priority: issue.Priority.Name,
+		DueDate:  issue.DueDate,
+	}
+	if issue.AssignedTo != nil {
+		v.AssignedTo = issue.AssignedTo.Name
+	}
+	return v
+}
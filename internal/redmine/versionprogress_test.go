@@ -0,0 +1,86 @@
+package redmine
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHighPriorityIDs(t *testing.T) {
+	priorities := []IssuePriority{{ID: 1, Name: "Low"}, {ID: 2, Name: "Normal", IsDefault: true}, {ID: 3, Name: "High"}, {ID: 4, Name: "Urgent"}}
+	if high := HighPriorityIDs(priorities); len(high) != 2 || !high[3] || !high[4] {
+		t.Errorf("expected the priorities above Normal, got %v", high)
+	}
+	priorities[1].IsDefault = false
+	if high := HighPriorityIDs(priorities); len(high) != 1 || !high[4] {
+		t.Errorf("expected only the highest priority without a default, got %v", high)
+	}
+	if high := HighPriorityIDs(nil); len(high) != 0 {
+		t.Errorf("expected no priorities, got %v", high)
+	}
+}
+
+func TestBuildVersionProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/issues.json":
+			if q.Get("project_id") != "3" || q.Get("fixed_version_id") != "10" {
+				t.Errorf("unexpected issue query: %s", r.URL.RawQuery)
+			}
+			if q.Get("status_id") == "closed" {
+				fmt.Fprint(w, `{"issues":[{"id":1,"priority":{"id":2,"name":"Normal"},"estimated_hours":4}],"total_count":1}`)
+				return
+			}
+			fmt.Fprint(w, `{"issues":[
+				{"id":2,"subject":"Crash on save","status":{"id":2,"name":"In Progress"},"priority":{"id":4,"name":"Urgent"},"done_ratio":50,"estimated_hours":6,"due_date":"2025-03-04","assigned_to":{"id":7,"name":"Alice"}},
+				{"id":3,"subject":"Typo","status":{"id":1,"name":"New"},"priority":{"id":2,"name":"Normal"},"done_ratio":0}
+			],"total_count":2}`)
+		case "/time_entries.json":
+			if q.Get("issue.fixed_version_id") != "10" {
+				t.Errorf("expected time entries of version 10, got %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"time_entries":[{"id":1,"hours":3.5},{"id":2,"hours":2}],"total_count":2}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	today, _ := time.Parse(DateLayout, "2025-03-05")
+	version := Version{ID: 10, Name: "1.0", Status: "open", DueDate: "2025-03-10"}
+	p, err := BuildVersionProgress(NewClient(server.URL, "key"), 3, version, today, DefaultAtRiskDays, map[int]bool{4: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.TotalCount != 3 || p.OpenCount != 2 || p.ClosedCount != 1 || p.CompletionPercent != 50 {
+		t.Errorf("unexpected counts: %+v", p)
+	}
+	if p.DaysRemaining == nil || *p.DaysRemaining != 5 {
+		t.Errorf("expected 5 days remaining, got %v", p.DaysRemaining)
+	}
+	if len(p.Overdue) != 1 || p.Overdue[0].ID != 2 || p.Overdue[0].AssignedTo != "Alice" {
+		t.Errorf("unexpected overdue issues: %+v", p.Overdue)
+	}
+	if p.EstimatedHours != 10 || p.SpentHours != 5.5 {
+		t.Errorf("expected 10 estimated and 5.5 spent hours, got %v and %v", p.EstimatedHours, p.SpentHours)
+	}
+	if !p.AtRisk || len(p.AtRiskIssues) != 1 || p.AtRiskIssues[0].ID != 2 {
+		t.Errorf("expected the open urgent issue to put the version at risk, got %+v", p)
+	}
+
+	// Far enough from the due date it isn't at risk, and without one never
+	p, _ = BuildVersionProgress(NewClient(server.URL, "key"), 3, version, today, 3, map[int]bool{4: true})
+	if p.AtRisk {
+		t.Errorf("expected no risk with 5 days left and at_risk_days 3")
+	}
+	version.DueDate = ""
+	p, _ = BuildVersionProgress(NewClient(server.URL, "key"), 3, version, today, DefaultAtRiskDays, map[int]bool{4: true})
+	if p.AtRisk || p.DaysRemaining != nil {
+		t.Errorf("expected no risk and no days remaining without a due date, got %+v", p)
+	}
+}
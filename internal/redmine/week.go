@@ -0,0 +1,136 @@
+package redmine
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WorkWeek is the week convention of periods and reports: the day weeks
+// start on and the days people work
+type WorkWeek struct {
+	Start    time.Weekday
+	WorkDays [7]bool // Indexed by time.Weekday
+}
+
+// DefaultWorkWeek starts on Monday and works Monday to Friday
+var DefaultWorkWeek = WorkWeek{
+	Start:    time.Monday,
+	WorkDays: [7]bool{time.Monday: true, time.Tuesday: true, time.Wednesday: true, time.Thursday: true, time.Friday: true},
+}
+
+// WorkWeekFromEnv reads REDMINE_MCP_WEEK_START and REDMINE_MCP_WORK_DAYS once
+// per process. Invalid values are logged and the default week is used.
+var WorkWeekFromEnv = sync.OnceValue(func() WorkWeek {
+	start, days := os.Getenv("REDMINE_MCP_WEEK_START"), os.Getenv("REDMINE_MCP_WORK_DAYS")
+	week, err := ParseWorkWeek(start, days)
+	if err != nil {
+		slog.Warn("ignoring invalid week configuration", "week_start", start, "work_days", days, "error", err)
+		return DefaultWorkWeek
+	}
+	if start != "" || days != "" {
+		slog.Info("week configuration", "week_start", week.Start, "work_days", week.String())
+	}
+	return week
+})
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseWorkWeek parses a week start, monday or sunday, and a comma-separated
+// list of work days and day ranges such as "mon-fri" or "sun-thu". Empty
+// values keep the default.
+func ParseWorkWeek(start, workDays string) (WorkWeek, error) {
+	week := DefaultWorkWeek
+
+	switch strings.ToLower(strings.TrimSpace(start)) {
+	case "", "monday", "mon":
+	case "sunday", "sun":
+		week.Start = time.Sunday
+	default:
+		return week, fmt.Errorf("invalid week start %q (valid: monday, sunday)", start)
+	}
+
+	if strings.TrimSpace(workDays) == "" {
+		return week, nil
+	}
+	week.WorkDays = [7]bool{}
+	for part := range strings.SplitSeq(strings.ToLower(workDays), ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		from, ok := weekdayNames[strings.TrimSpace(first)]
+		if !ok {
+			return week, fmt.Errorf("invalid work day %q (use mon, tue, ... or a range like mon-fri)", part)
+		}
+		to := from
+		if isRange {
+			if to, ok = weekdayNames[strings.TrimSpace(last)]; !ok {
+				return week, fmt.Errorf("invalid work day %q (use mon, tue, ... or a range like mon-fri)", part)
+			}
+		}
+		// Ranges may wrap around the weekend, e.g. sat-wed
+		for d := from; ; d = (d + 1) % 7 {
+			week.WorkDays[d] = true
+			if d == to {
+				break
+			}
+		}
+	}
+	return week, nil
+}
+
+// String lists the work days, e.g. "Sunday,Monday"
+func (w WorkWeek) String() string {
+	var names []string
+	for i := range 7 {
+		if d := (w.Start + time.Weekday(i)) % 7; w.WorkDays[d] {
+			names = append(names, d.String())
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// StartOf returns the first day of the week containing day
+func (w WorkWeek) StartOf(day time.Time) time.Time {
+	offset := (int(day.Weekday()) - int(w.Start) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// IsWorkDay reports whether day is a work day
+func (w WorkWeek) IsWorkDay(day time.Time) bool {
+	return w.WorkDays[day.Weekday()]
+}
+
+// WorkDaysOf returns the work days of the week containing day, in order
+func (w WorkWeek) WorkDaysOf(day time.Time) []time.Time {
+	start := w.StartOf(day)
+	var days []time.Time
+	for i := range 7 {
+		if d := start.AddDate(0, 0, i); w.IsWorkDay(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+// PreviousWorkday returns the work day before day, skipping days off
+func (w WorkWeek) PreviousWorkday(day time.Time) time.Time {
+	prev := day.AddDate(0, 0, -1)
+	// A week without work days would never end; fall back to the day before
+	for range 7 {
+		if w.IsWorkDay(prev) {
+			return prev
+		}
+		prev = prev.AddDate(0, 0, -1)
+	}
+	return day.AddDate(0, 0, -1)
+}
@@ -0,0 +1,142 @@
+package redmine
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// sundayWeek is a week starting on Sunday with Sunday to Thursday as work days
+var sundayWeek = WorkWeek{
+	Start:    time.Sunday,
+	WorkDays: [7]bool{time.Sunday: true, time.Monday: true, time.Tuesday: true, time.Wednesday: true, time.Thursday: true},
+}
+
+func TestParseWorkWeek(t *testing.T) {
+	if week, err := ParseWorkWeek("", ""); err != nil || week != DefaultWorkWeek {
+		t.Errorf("expected the default week for empty values, got %+v %v", week, err)
+	}
+	if week, err := ParseWorkWeek(" Sunday ", "sun-thu"); err != nil || week != sundayWeek {
+		t.Errorf("expected a Sunday to Thursday week, got %+v %v", week, err)
+	}
+	if week, err := ParseWorkWeek("sunday", "Sunday,mon, tue,wed,thu"); err != nil || week != sundayWeek {
+		t.Errorf("expected a list of days to match the range, got %+v %v", week, err)
+	}
+
+	week, err := ParseWorkWeek("monday", "sat-mon, wed")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := week.String(); got != "Monday,Wednesday,Saturday,Sunday" {
+		t.Errorf("expected a range wrapping around the week, got %s", got)
+	}
+
+	for _, tt := range [][2]string{{"saturday", ""}, {"", "mon-fry"}, {"", "mon-"}, {"", "mon,,fri"}} {
+		if _, err := ParseWorkWeek(tt[0], tt[1]); err == nil {
+			t.Errorf("ParseWorkWeek(%q, %q): expected an error", tt[0], tt[1])
+		}
+	}
+}
+
+func TestWorkWeek_MonthBoundaries(t *testing.T) {
+	tests := []struct {
+		name            string
+		week            WorkWeek
+		day             string
+		start           string
+		first, last     string
+		previousWorkday string
+	}{
+		// 2025-03-01 is a Saturday, 2025-06-01 a Sunday
+		{"monday week, saturday", DefaultWorkWeek, "2025-03-01", "2025-02-24", "2025-02-24", "2025-02-28", "2025-02-28"},
+		{"monday week, monday", DefaultWorkWeek, "2025-03-03", "2025-03-03", "2025-03-03", "2025-03-07", "2025-02-28"},
+		{"monday week, sunday", DefaultWorkWeek, "2025-06-01", "2025-05-26", "2025-05-26", "2025-05-30", "2025-05-30"},
+		{"sunday week, saturday", sundayWeek, "2025-03-01", "2025-02-23", "2025-02-23", "2025-02-27", "2025-02-27"},
+		{"sunday week, sunday", sundayWeek, "2025-06-01", "2025-06-01", "2025-06-01", "2025-06-05", "2025-05-29"},
+		{"sunday week, friday", sundayWeek, "2025-10-31", "2025-10-26", "2025-10-26", "2025-10-30", "2025-10-30"},
+	}
+	for _, tt := range tests {
+		day, _ := time.Parse(DateLayout, tt.day)
+		if got := tt.week.StartOf(day).Format(DateLayout); got != tt.start {
+			t.Errorf("%s: expected the week to start on %s, got %s", tt.name, tt.start, got)
+		}
+		days := tt.week.WorkDaysOf(day)
+		if len(days) != 5 || days[0].Format(DateLayout) != tt.first || days[4].Format(DateLayout) != tt.last {
+			t.Errorf("%s: expected work days %s..%s, got %v", tt.name, tt.first, tt.last, days)
+		}
+		if got := tt.week.PreviousWorkday(day).Format(DateLayout); got != tt.previousWorkday {
+			t.Errorf("%s: expected the previous work day to be %s, got %s", tt.name, tt.previousWorkday, got)
+		}
+	}
+}
+
+func TestParseDateRange_SundayWeek(t *testing.T) {
+	tests := []struct {
+		now      string
+		expr     string
+		from, to string
+	}{
+		{"2025-03-01", "this_week", "2025-02-23", "2025-03-01"},
+		{"2025-03-01", "last_week", "2025-02-16", "2025-02-22"},
+		{"2025-06-01", "this_week", "2025-06-01", "2025-06-07"},
+		{"2025-06-01", "last_week", "2025-05-25", "2025-05-31"},
+		{"2025-06-01", "start_of_week", "2025-06-01", "2025-06-01"},
+		{"2025-05-31", "end_of_week", "2025-05-31", "2025-05-31"},
+		// ISO weeks keep starting on Monday
+		{"2025-06-01", "2025-W22", "2025-05-26", "2025-06-01"},
+	}
+	for _, tt := range tests {
+		now, _ := time.Parse(DateLayout, tt.now)
+		from, to, err := ParseDateRange(tt.expr, now, sundayWeek)
+		if err != nil {
+			t.Errorf("%s on %s: unexpected error: %v", tt.expr, tt.now, err)
+			continue
+		}
+		if got := from.Format(DateLayout) + ".." + to.Format(DateLayout); got != tt.from+".."+tt.to {
+			t.Errorf("%s on %s: expected %s..%s, got %s", tt.expr, tt.now, tt.from, tt.to, got)
+		}
+	}
+}
+
+func TestBuildReports_SundayWeek(t *testing.T) {
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/time_entries.json":
+			ranges = append(ranges, r.URL.Query().Get("from")+".."+r.URL.Query().Get("to"))
+			_, _ = w.Write([]byte(`{"time_entries":[{"id":1,"hours":3,"spent_on":"2025-05-29"}],"total_count":1}`))
+		case "/issues.json":
+			_, _ = w.Write([]byte(`{"issues":[],"total_count":0}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := NewClient(server.URL, "test-key")
+	user := ReportUser{ID: "7", Name: "Alice"}
+
+	// Saturday 2025-05-31 belongs to the week of Sunday 2025-05-25
+	weekOf, _ := time.Parse(DateLayout, "2025-05-31")
+	weekly, err := BuildWeeklyReport(c, user, weekOf, sundayWeek, WeeklyOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if weekly.Period != "2025-05-25 ~ 2025-05-29" || len(weekly.ByDay) != 5 || weekly.ByDay[0].Date != "2025-05-25" || weekly.ByDay[4].Hours != 3 {
+		t.Errorf("unexpected weekly report: %+v", weekly)
+	}
+
+	// Sunday 2025-06-01 is a work day, so yesterday is the Thursday before
+	date, _ := time.Parse(DateLayout, "2025-06-01")
+	standup, err := BuildStandupReport(c, user, date, sundayWeek, StandupOptions{IncludeTimeEntries: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if standup.Yesterday.Date != "2025-05-29" || standup.Yesterday.TotalHours != 3 {
+		t.Errorf("unexpected standup yesterday: %+v", standup.Yesterday)
+	}
+
+	if len(ranges) != 2 || ranges[0] != "2025-05-25..2025-05-29" || ranges[1] != "2025-05-29..2025-05-29" {
+		t.Errorf("unexpected time entry ranges: %v", ranges)
+	}
+}
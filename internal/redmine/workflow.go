@@ -28,6 +28,32 @@ type WorkflowRules struct {
 	Trackers map[string]WorkflowTracker `json:"trackers"`
 }
 
+// WorkflowSource selects where transition rules come from
+type WorkflowSource string
+
+const (
+	// WorkflowSourceFile validates against the static rules file only
+	WorkflowSourceFile WorkflowSource = "file"
+	// WorkflowSourceServer validates against allowed_statuses reported by Redmine only
+	WorkflowSourceServer WorkflowSource = "server"
+	// WorkflowSourceHybrid prefers server-reported statuses and falls back to the rules file
+	WorkflowSourceHybrid WorkflowSource = "hybrid"
+)
+
+// ParseWorkflowSource parses a workflow source name. Empty defaults to file.
+func ParseWorkflowSource(s string) (WorkflowSource, error) {
+	switch WorkflowSource(strings.ToLower(strings.TrimSpace(s))) {
+	case "", WorkflowSourceFile:
+		return WorkflowSourceFile, nil
+	case WorkflowSourceServer:
+		return WorkflowSourceServer, nil
+	case WorkflowSourceHybrid:
+		return WorkflowSourceHybrid, nil
+	default:
+		return "", fmt.Errorf("invalid workflow source: %s (valid: file, server, hybrid)", s)
+	}
+}
+
 // LoadWorkflowRules loads workflow rules from a JSON file.
 // Returns nil (no rules) if the file doesn't exist.
 func LoadWorkflowRules(path string) (*WorkflowRules, error) {
@@ -47,8 +73,45 @@ func LoadWorkflowRules(path string) (*WorkflowRules, error) {
 	return &rules, nil
 }
 
+// TransitionError describes a status transition rejected by the workflow rules
+type TransitionError struct {
+	Tracker string
+	From    IDName
+	To      IDName
+	Allowed []IDName
+}
+
+func (e *TransitionError) Error() string {
+	if len(e.Allowed) == 0 {
+		return fmt.Sprintf("cannot move %s from '%s' to '%s'; no transitions allowed from '%s'",
+			e.Tracker, e.From.Name, e.To.Name, e.From.Name)
+	}
+	return fmt.Sprintf("cannot move %s from '%s' to '%s'; allowed: %s",
+		e.Tracker, e.From.Name, e.To.Name, strings.Join(e.AllowedNames(), ", "))
+}
+
+// AllowedNames returns the names of the allowed target statuses
+func (e *TransitionError) AllowedNames() []string {
+	names := make([]string, len(e.Allowed))
+	for i, s := range e.Allowed {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// Details returns the error as a map for JSON error payloads
+func (e *TransitionError) Details() map[string]any {
+	return map[string]any{
+		"error":            "Invalid status transition: " + e.Error(),
+		"tracker":          e.Tracker,
+		"current_status":   e.From,
+		"requested_status": e.To,
+		"allowed_statuses": e.Allowed,
+	}
+}
+
 // ValidateTransition checks whether a status transition is allowed.
-// Returns nil if allowed, or an error with allowed targets listed.
+// Returns nil if allowed, or a *TransitionError listing the allowed targets.
 // Passes through (returns nil) if rules are nil, tracker unknown, or from_status unknown.
 func (w *WorkflowRules) ValidateTransition(trackerID, fromStatusID, toStatusID int) error {
 	if w == nil {
@@ -72,22 +135,12 @@ func (w *WorkflowRules) ValidateTransition(trackerID, fromStatusID, toStatusID i
 		}
 	}
 
-	// Build helpful error message
-	fromName := statusName(tracker, fromStatusID)
-	toName := statusName(tracker, toStatusID)
-
-	allowedNames := make([]string, len(allowed))
-	for i, id := range allowed {
-		allowedNames[i] = statusName(tracker, id)
+	return &TransitionError{
+		Tracker: tracker.Name,
+		From:    IDName{ID: fromStatusID, Name: statusName(tracker, fromStatusID)},
+		To:      IDName{ID: toStatusID, Name: statusName(tracker, toStatusID)},
+		Allowed: w.GetAllowedStatuses(trackerID, fromStatusID),
 	}
-
-	if len(allowed) == 0 {
-		return fmt.Errorf("cannot change %s from '%s' to '%s': no transitions allowed from '%s'",
-			tracker.Name, fromName, toName, fromName)
-	}
-
-	return fmt.Errorf("cannot change %s from '%s' to '%s'. Allowed: %s",
-		tracker.Name, fromName, toName, strings.Join(allowedNames, ", "))
 }
 
 // GetAllowedStatuses returns the list of allowed target statuses for a given tracker and current status.
@@ -1,8 +1,10 @@
 package redmine
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -525,3 +527,46 @@ func containsSubstr(s, substr string) bool {
 	}
 	return false
 }
+
+func TestValidateTransitionStructuredError(t *testing.T) {
+	rules := &WorkflowRules{
+		Trackers: map[string]WorkflowTracker{
+			"4": {
+				Name: "Bug",
+				Statuses: map[string]WorkflowStatus{
+					"1": {Name: "New"},
+					"2": {Name: "In Progress"},
+					"5": {Name: "Closed", IsClosed: true},
+					"6": {Name: "Rejected", IsClosed: true},
+				},
+				Transitions: map[string][]int{
+					"1": {2, 6},
+				},
+			},
+		},
+	}
+
+	err := rules.ValidateTransition(4, 1, 5)
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("expected *TransitionError, got %T (%v)", err, err)
+	}
+	if transitionErr.From.Name != "New" || transitionErr.To.Name != "Closed" {
+		t.Errorf("unexpected from/to: %+v -> %+v", transitionErr.From, transitionErr.To)
+	}
+	if got := strings.Join(transitionErr.AllowedNames(), ","); got != "In Progress,Rejected" {
+		t.Errorf("expected allowed 'In Progress,Rejected', got %q", got)
+	}
+	want := "cannot move Bug from 'New' to 'Closed'; allowed: In Progress, Rejected"
+	if err.Error() != want {
+		t.Errorf("expected message %q, got %q", want, err.Error())
+	}
+
+	details := transitionErr.Details()
+	if details["current_status"].(IDName).Name != "New" {
+		t.Errorf("expected current_status New, got %v", details["current_status"])
+	}
+	if len(details["allowed_statuses"].([]IDName)) != 2 {
+		t.Errorf("expected 2 allowed statuses, got %v", details["allowed_statuses"])
+	}
+}
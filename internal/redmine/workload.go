@@ -0,0 +1,169 @@
+package redmine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// workloadDueSoonDays is how far ahead Workload looks for upcoming due dates
+const workloadDueSoonDays = 7
+
+// workloadRecentCount is how many recently updated issues Workload lists
+const workloadRecentCount = 5
+
+// CountBy is one row of an issue count breakdown
+type CountBy struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// WorkloadIssue is an open issue listed in a workload summary
+type WorkloadIssue struct {
+	ID        int    `json:"id"`
+	Subject   string `json:"subject"`
+	Project   string `json:"project"`
+	Status    string `json:"status"`
+	Priority  string `json:"priority"`
+	DueDate   string `json:"due_date,omitempty"`
+	UpdatedOn string `json:"updated_on,omitempty"`
+}
+
+// Workload summarizes what is on a user's plate: their open issues, what is
+// overdue or due soon, and the hours they logged this week and last week
+type Workload struct {
+	User            string          `json:"user"`
+	Date            string          `json:"date"`
+	OpenIssueCount  int             `json:"open_issue_count"`
+	ByStatus        []CountBy       `json:"by_status"`
+	ByPriority      []CountBy       `json:"by_priority"`
+	OverdueCount    int             `json:"overdue_count"`
+	DueSoon         []WorkloadIssue `json:"due_soon"`
+	RecentlyUpdated []WorkloadIssue `json:"recently_updated"`
+	Note            string          `json:"note,omitempty"`
+	Hours           struct {
+		ThisWeek       float64 `json:"this_week"`
+		LastWeek       float64 `json:"last_week"`
+		ThisWeekPeriod string  `json:"this_week_period"`
+		LastWeekPeriod string  `json:"last_week_period"`
+	} `json:"hours"`
+}
+
+// BuildWorkload fetches every open issue assigned to the user and their time
+// entries for the current and previous week, starting on the week's start
+// day, as of today
+func BuildWorkload(c *Client, user ReportUser, today time.Time, week WorkWeek) (*Workload, error) {
+	issues, total, err := listAllIssues(c, SearchIssuesParams{
+		AssignedToID: user.ID,
+		StatusID:     "open",
+		Sort:         "updated_on:desc",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch open issues: %w", err)
+	}
+
+	start := week.StartOf(today)
+	thisFrom, thisTo := start.Format("2006-01-02"), start.AddDate(0, 0, 6).Format("2006-01-02")
+	lastFrom, lastTo := start.AddDate(0, 0, -7).Format("2006-01-02"), start.AddDate(0, 0, -1).Format("2006-01-02")
+
+	entries, err := listAllTimeEntries(c, ListTimeEntriesParams{UserID: user.ID, From: lastFrom, To: thisTo})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch time entries: %w", err)
+	}
+
+	w := summarizeWorkload(issues, today)
+	w.User = user.Name
+	w.OpenIssueCount = max(w.OpenIssueCount, total)
+	w.Note = cappedIssuesNote(len(issues), total)
+	w.Hours.ThisWeekPeriod = fmt.Sprintf("%s ~ %s", thisFrom, thisTo)
+	w.Hours.LastWeekPeriod = fmt.Sprintf("%s ~ %s", lastFrom, lastTo)
+	for _, entry := range entries {
+		// Dates are YYYY-MM-DD, so string comparison orders them
+		if entry.SpentOn >= thisFrom {
+			w.Hours.ThisWeek += entry.Hours
+		} else {
+			w.Hours.LastWeek += entry.Hours
+		}
+	}
+	return w, nil
+}
+
+// summarizeWorkload aggregates open issues, which must be sorted by
+// updated_on descending so the first ones are the most recently updated
+func summarizeWorkload(issues []Issue, today time.Time) *Workload {
+	todayStr := today.Format("2006-01-02")
+	dueLimit := today.AddDate(0, 0, workloadDueSoonDays).Format("2006-01-02")
+
+	byStatus := make(map[string]int)
+	byPriority := make(map[string]int)
+	w := &Workload{
+		Date:            todayStr,
+		OpenIssueCount:  len(issues),
+		DueSoon:         []WorkloadIssue{},
+		RecentlyUpdated: []WorkloadIssue{},
+	}
+	for _, issue := range issues {
+		byStatus[issue.Status.Name]++
+		byPriority[issue.Priority.Name]++
+
+		switch {
+		case issue.DueDate == "":
+		case issue.DueDate < todayStr:
+			w.OverdueCount++
+		case issue.DueDate <= dueLimit:
+			w.DueSoon = append(w.DueSoon, newWorkloadIssue(issue))
+		}
+		if len(w.RecentlyUpdated) < workloadRecentCount {
+			w.RecentlyUpdated = append(w.RecentlyUpdated, newWorkloadIssue(issue))
+		}
+	}
+
+	sort.SliceStable(w.DueSoon, func(i, j int) bool { return w.DueSoon[i].DueDate < w.DueSoon[j].DueDate })
+	w.ByStatus = sortedCounts(byStatus)
+	w.ByPriority = sortedCounts(byPriority)
+	return w
+}
+
+func newWorkloadIssue(issue Issue) WorkloadIssue {
+	return WorkloadIssue{
+		ID:        issue.ID,
+		Subject:   issue.Subject,
+		Project:   issue.Project.Name,
+		Status:    issue.Status.Name,
+		Priority:  issue.Priority.Name,
+		DueDate:   issue.DueDate,
+		UpdatedOn: issue.UpdatedOn,
+	}
+}
+
+// sortedCounts orders counts descending, breaking ties by name
+func sortedCounts(counts map[string]int) []CountBy {
+	rows := make([]CountBy, 0, len(counts))
+	for name, count := range counts {
+		rows = append(rows, CountBy{Name: name, Count: count})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
+
+// listAllIssues fetches the issues matching params, up to
+// MaxSearchIssuesLimit, and how many match in all
+func listAllIssues(c *Client, params SearchIssuesParams) ([]Issue, int, error) {
+	params.Limit = MaxSearchIssuesLimit
+	params.Offset = 0
+	return c.SearchIssuesAll(params)
+}
+
+// cappedIssuesNote explains that a report covers only the fetched issues of
+// total, or returns "" when every issue was fetched
+func cappedIssuesNote(fetched, total int) string {
+	if fetched >= total {
+		return ""
+	}
+	return fmt.Sprintf("Only the first %d of %d issues were fetched; the breakdowns cover those", fetched, total)
+}
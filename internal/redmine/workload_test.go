@@ -0,0 +1,119 @@
+package redmine
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummarizeWorkload(t *testing.T) {
+	today, _ := time.Parse("2006-01-02", "2025-03-05")
+	issues := []Issue{
+		{ID: 1, Status: IDName{Name: "New"}, Priority: IDName{Name: "High"}, DueDate: "2025-03-04"},
+		{ID: 2, Status: IDName{Name: "In Progress"}, Priority: IDName{Name: "Normal"}, DueDate: "2025-03-12"},
+		{ID: 3, Status: IDName{Name: "New"}, Priority: IDName{Name: "Normal"}, DueDate: "2025-03-06"},
+		{ID: 4, Status: IDName{Name: "New"}, Priority: IDName{Name: "Normal"}, DueDate: "2025-03-13"},
+		{ID: 5, Status: IDName{Name: "In Progress"}, Priority: IDName{Name: "Low"}},
+		{ID: 6, Status: IDName{Name: "New"}, Priority: IDName{Name: "Normal"}, DueDate: "2025-03-05"},
+	}
+
+	w := summarizeWorkload(issues, today)
+	if w.OpenIssueCount != 6 || w.OverdueCount != 1 {
+		t.Errorf("expected 6 open and 1 overdue, got %d and %d", w.OpenIssueCount, w.OverdueCount)
+	}
+	// Due today counts as due soon; day 8 is out of range
+	if len(w.DueSoon) != 3 || w.DueSoon[0].ID != 6 || w.DueSoon[1].ID != 3 || w.DueSoon[2].ID != 2 {
+		t.Errorf("unexpected due_soon: %+v", w.DueSoon)
+	}
+	if w.ByStatus[0] != (CountBy{Name: "New", Count: 4}) || w.ByPriority[0] != (CountBy{Name: "Normal", Count: 4}) {
+		t.Errorf("unexpected breakdowns: %+v %+v", w.ByStatus, w.ByPriority)
+	}
+	if len(w.RecentlyUpdated) != 5 || w.RecentlyUpdated[0].ID != 1 {
+		t.Errorf("expected the first 5 issues as recently updated, got %+v", w.RecentlyUpdated)
+	}
+}
+
+func TestBuildWorkload_Paginates(t *testing.T) {
+	const openIssues = 150
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/issues.json":
+			if q.Get("assigned_to_id") != "7" || q.Get("status_id") != "open" {
+				t.Errorf("unexpected issue query: %s", r.URL.RawQuery)
+			}
+			offset, _ := strconv.Atoi(q.Get("offset"))
+			var issues []map[string]any
+			for id := offset + 1; id <= min(offset+100, openIssues); id++ {
+				issues = append(issues, map[string]any{"id": id, "status": map[string]any{"name": "New"}})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"issues": issues, "total_count": openIssues})
+		case "/time_entries.json":
+			if q.Get("from") != "2025-02-24" || q.Get("to") != "2025-03-09" {
+				t.Errorf("unexpected time entry range: %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"time_entries":[
+				{"id":1,"hours":3,"spent_on":"2025-02-28"},
+				{"id":2,"hours":2,"spent_on":"2025-03-03"},
+				{"id":3,"hours":1.5,"spent_on":"2025-03-05"}
+			],"total_count":3}`)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	today, _ := time.Parse("2006-01-02", "2025-03-05")
+	w, err := BuildWorkload(NewClient(server.URL, "test-key"), ReportUser{ID: "7", Name: "Alice"}, today, DefaultWorkWeek)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.OpenIssueCount != openIssues || w.ByStatus[0].Count != openIssues {
+		t.Errorf("expected all %d open issues across pages, got %d", openIssues, w.OpenIssueCount)
+	}
+	if w.Hours.ThisWeek != 3.5 || w.Hours.LastWeek != 3 {
+		t.Errorf("expected 3.5h this week and 3h last week, got %+v", w.Hours)
+	}
+	if w.Hours.ThisWeekPeriod != "2025-03-03 ~ 2025-03-09" {
+		t.Errorf("unexpected period: %s", w.Hours.ThisWeekPeriod)
+	}
+}
+
+func TestBuildWorkload_Capped(t *testing.T) {
+	const openIssues = 1200
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/issues.json" {
+			fmt.Fprint(w, `{"time_entries":[],"total_count":0}`)
+			return
+		}
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var issues []map[string]any
+		for id := offset + 1; id <= min(offset+limit, openIssues); id++ {
+			issues = append(issues, map[string]any{"id": id, "status": map[string]any{"name": "New"}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"issues": issues, "total_count": openIssues})
+	}))
+	defer server.Close()
+
+	today, _ := time.Parse("2006-01-02", "2025-03-05")
+	w, err := BuildWorkload(NewClient(server.URL, "test-key"), ReportUser{ID: "7", Name: "Alice"}, today, DefaultWorkWeek)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != MaxSearchIssuesLimit/100 {
+		t.Errorf("expected the fetch to stop at %d issues, got %d requests", MaxSearchIssuesLimit, requests)
+	}
+	if w.OpenIssueCount != openIssues || w.ByStatus[0].Count != MaxSearchIssuesLimit || !strings.Contains(w.Note, "1000 of 1200") {
+		t.Errorf("expected the full count with a note on the capped breakdown, got %d %v %q", w.OpenIssueCount, w.ByStatus, w.Note)
+	}
+}
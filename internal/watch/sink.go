@@ -0,0 +1,54 @@
+package watch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Sink receives change events
+type Sink interface {
+	Emit(event Event) error
+}
+
+// JSONLinesSink writes each event as one JSON line
+type JSONLinesSink struct {
+	W io.Writer
+}
+
+// Emit writes the event followed by a newline
+func (s JSONLinesSink) Emit(event Event) error {
+	return json.NewEncoder(s.W).Encode(event)
+}
+
+// WebhookSink POSTs each event as JSON to a URL
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookSink creates a webhook sink with a 10 second request timeout
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Emit posts the event; any non-2xx response is an error
+func (s *WebhookSink) Emit(event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	resp, err := s.Client.Post(s.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package watch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// IssueState is what the watcher remembers about an issue between polls
+type IssueState struct {
+	UpdatedOn  string `json:"updated_on"`
+	StatusID   int    `json:"status_id"`
+	Status     string `json:"status"`
+	AssignedTo string `json:"assigned_to,omitempty"`
+}
+
+// State is persisted between runs so a restart doesn't replay old changes.
+// Watermark is the latest updated_on seen, in Redmine's clock rather than the
+// local one, so clock skew between the hosts doesn't lose changes. Pruned is
+// when issues no longer watched were last dropped, in the local clock.
+type State struct {
+	Watermark string                `json:"watermark"`
+	Pruned    string                `json:"pruned,omitempty"`
+	Issues    map[string]IssueState `json:"issues"`
+}
+
+// LoadState reads the state file. A missing file returns an empty state,
+// which makes the next poll record a baseline instead of emitting events.
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &State{Issues: make(map[string]IssueState)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if state.Issues == nil {
+		state.Issues = make(map[string]IssueState)
+	}
+	return &state, nil
+}
+
+// Save writes the state to path through a temporary file so a crash never
+// leaves a truncated state behind
+func (s *State) Save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,346 @@
+// Package watch polls Redmine for issue changes and emits them as events.
+// Redmine core has no webhooks, so the watcher asks for issues updated since
+// the last poll and diffs them against a small state file.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+// Event types
+const (
+	EventIssueCreated    = "issue_created"
+	EventStatusChanged   = "status_changed"
+	EventAssigneeChanged = "assignee_changed"
+	EventCommentAdded    = "comment_added"
+)
+
+// DefaultOverlap is how far before the watermark each poll looks again, to
+// catch updates committed late with an earlier timestamp. Issues whose
+// updated_on didn't change since the last poll are skipped, so the overlap
+// never produces duplicate events.
+const DefaultOverlap = 2 * time.Minute
+
+// DefaultPruneInterval is how often the watcher lists every watched issue to
+// drop the deleted ones and those moved out of the watched projects from its
+// state, which polls for updated issues never see
+const DefaultPruneInterval = 24 * time.Hour
+
+// Event is a single change to an issue
+type Event struct {
+	Type      string `json:"type"`
+	IssueID   int    `json:"issue_id"`
+	Subject   string `json:"subject"`
+	Project   string `json:"project"`
+	UpdatedOn string `json:"updated_on"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+	User      string `json:"user,omitempty"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+// Config configures a Watcher
+type Config struct {
+	ProjectIDs    []string // Projects to watch; empty watches every visible project
+	StateFile     string
+	Overlap       time.Duration // Defaults to DefaultOverlap
+	PruneInterval time.Duration // Defaults to DefaultPruneInterval
+}
+
+// Watcher polls Redmine and emits change events to a sink
+type Watcher struct {
+	client *redmine.Client
+	config Config
+	sink   Sink
+	state  *State
+}
+
+// New creates a watcher, loading its state from config.StateFile
+func New(client *redmine.Client, config Config, sink Sink) (*Watcher, error) {
+	if config.Overlap <= 0 {
+		config.Overlap = DefaultOverlap
+	}
+	if config.PruneInterval <= 0 {
+		config.PruneInterval = DefaultPruneInterval
+	}
+	state, err := LoadState(config.StateFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Watcher{client: client, config: config, sink: sink, state: state}, nil
+}
+
+// Run polls every interval until ctx is cancelled. A failed poll is logged
+// and retried on the next tick.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := w.Poll(); err != nil {
+			slog.Warn("watch poll failed", "error", err)
+		} else if n > 0 {
+			slog.Debug("watch poll emitted events", "events", n)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches the issues updated since the last poll, emits their changes
+// and saves the new state. The first poll only records a baseline so the
+// existing backlog isn't reported as new. If an event can't be emitted the
+// state is left unchanged and the whole poll is retried next time, so events
+// are delivered at least once.
+func (w *Watcher) Poll() (int, error) {
+	if w.state.Watermark == "" {
+		return 0, w.baseline()
+	}
+
+	watermark, err := time.Parse(time.RFC3339, w.state.Watermark)
+	if err != nil {
+		return 0, fmt.Errorf("invalid watermark %q in state file: %w", w.state.Watermark, err)
+	}
+	since := watermark.Add(-w.config.Overlap).UTC().Format(time.RFC3339)
+
+	issues, err := w.listIssues(">=" + since)
+	if err != nil {
+		return 0, err
+	}
+
+	next := &State{Watermark: w.state.Watermark, Pruned: w.state.Pruned, Issues: make(map[string]IssueState, len(w.state.Issues))}
+	for id, s := range w.state.Issues {
+		next.Issues[id] = s
+	}
+
+	var events []Event
+	for _, issue := range issues {
+		key := strconv.Itoa(issue.ID)
+		prev, seen := w.state.Issues[key]
+		if seen && prev.UpdatedOn == issue.UpdatedOn {
+			continue
+		}
+		next.Issues[key] = issueState(issue)
+		next.Watermark = laterTime(next.Watermark, issue.UpdatedOn)
+
+		if !seen {
+			if after(issue.CreatedOn, watermark) {
+				events = append(events, newEvent(EventIssueCreated, issue))
+			} else {
+				// Moved into a watched project or became visible; there is
+				// nothing to diff against yet
+				slog.Debug("watch recorded previously unseen issue", "issue_id", issue.ID)
+			}
+			continue
+		}
+
+		changes, err := w.diff(prev, issue)
+		if err != nil {
+			return 0, err
+		}
+		events = append(events, changes...)
+	}
+
+	for _, event := range events {
+		if err := w.sink.Emit(event); err != nil {
+			return 0, fmt.Errorf("failed to emit %s event for issue #%d: %w", event.Type, event.IssueID, err)
+		}
+	}
+
+	if pruned, err := time.Parse(time.RFC3339, next.Pruned); err != nil || time.Since(pruned) >= w.config.PruneInterval {
+		if err := w.prune(next); err != nil {
+			// Stale entries only cost space; try again next poll
+			slog.Warn("watch failed to prune state", "error", err)
+		}
+	}
+
+	if err := next.Save(w.config.StateFile); err != nil {
+		return len(events), err
+	}
+	w.state = next
+	return len(events), nil
+}
+
+// baseline records every watched issue without emitting events
+func (w *Watcher) baseline() error {
+	issues, err := w.listIssues("")
+	if err != nil {
+		return err
+	}
+
+	state := &State{Pruned: time.Now().UTC().Format(time.RFC3339), Issues: make(map[string]IssueState, len(issues))}
+	for _, issue := range issues {
+		state.Issues[strconv.Itoa(issue.ID)] = issueState(issue)
+		state.Watermark = laterTime(state.Watermark, issue.UpdatedOn)
+	}
+	if state.Watermark == "" {
+		// Nothing to watch yet; only issues created from now on are new
+		state.Watermark = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	if err := state.Save(w.config.StateFile); err != nil {
+		return err
+	}
+	w.state = state
+	slog.Info("watch baseline recorded", "issues", len(issues), "watermark", state.Watermark)
+	return nil
+}
+
+// prune drops the issues that are no longer watched from state: deleted,
+// moved out of the watched projects or no longer visible
+func (w *Watcher) prune(state *State) error {
+	issues, err := w.listIssues("")
+	if err != nil {
+		return err
+	}
+	watched := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		watched[strconv.Itoa(issue.ID)] = true
+	}
+	dropped := 0
+	for key := range state.Issues {
+		if !watched[key] {
+			delete(state.Issues, key)
+			dropped++
+		}
+	}
+	state.Pruned = time.Now().UTC().Format(time.RFC3339)
+	if dropped > 0 {
+		slog.Debug("watch pruned issues no longer watched", "issues", dropped)
+	}
+	return nil
+}
+
+// diff compares an issue with its previous state. Comments are found in the
+// journals written after the previous updated_on; both timestamps come from
+// Redmine, so they compare without clock skew.
+func (w *Watcher) diff(prev IssueState, issue redmine.Issue) ([]Event, error) {
+	var events []Event
+	if issue.Status.ID != prev.StatusID {
+		e := newEvent(EventStatusChanged, issue)
+		e.Old, e.New = prev.Status, issue.Status.Name
+		events = append(events, e)
+	}
+	if assignee := assigneeName(issue); assignee != prev.AssignedTo {
+		e := newEvent(EventAssigneeChanged, issue)
+		e.Old, e.New = prev.AssignedTo, assignee
+		events = append(events, e)
+	}
+
+	detail, err := w.client.GetIssue(issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get journals of issue #%d: %w", issue.ID, err)
+	}
+	prevUpdated, err := time.Parse(time.RFC3339, prev.UpdatedOn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid updated_on %q in state of issue #%d: %w", prev.UpdatedOn, issue.ID, err)
+	}
+	for _, j := range detail.Journals {
+		if j.Notes == "" || !after(j.CreatedOn, prevUpdated) {
+			continue
+		}
+		e := newEvent(EventCommentAdded, issue)
+		e.User = j.User.Name
+		e.Notes = redmine.NotePreview(j.Notes)
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// listIssues fetches every issue in the watched projects, optionally limited
+// by an updated_on filter, across all statuses. Each issue is returned once:
+// an issue updated while the pages are read moves to a later page, and
+// watched projects share the issues of their subprojects.
+func (w *Watcher) listIssues(updatedOn string) ([]redmine.Issue, error) {
+	projects := w.config.ProjectIDs
+	if len(projects) == 0 {
+		projects = []string{""}
+	}
+
+	var all []redmine.Issue
+	index := make(map[int]int)
+	for _, projectID := range projects {
+		params := redmine.SearchIssuesParams{
+			ProjectID: projectID,
+			StatusID:  "*",
+			UpdatedOn: updatedOn,
+			Sort:      "updated_on:asc",
+			Limit:     100,
+		}
+		for {
+			issues, total, err := w.client.SearchIssues(params)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list issues: %w", err)
+			}
+			for _, issue := range issues {
+				if i, ok := index[issue.ID]; ok {
+					if laterTime(all[i].UpdatedOn, issue.UpdatedOn) == issue.UpdatedOn {
+						all[i] = issue
+					}
+					continue
+				}
+				index[issue.ID] = len(all)
+				all = append(all, issue)
+			}
+			params.Offset += len(issues)
+			if len(issues) == 0 || params.Offset >= total {
+				break
+			}
+		}
+	}
+	return all, nil
+}
+
+func issueState(issue redmine.Issue) IssueState {
+	return IssueState{
+		UpdatedOn:  issue.UpdatedOn,
+		StatusID:   issue.Status.ID,
+		Status:     issue.Status.Name,
+		AssignedTo: assigneeName(issue),
+	}
+}
+
+func newEvent(typ string, issue redmine.Issue) Event {
+	return Event{
+		Type:      typ,
+		IssueID:   issue.ID,
+		Subject:   issue.Subject,
+		Project:   issue.Project.Name,
+		UpdatedOn: issue.UpdatedOn,
+	}
+}
+
+func assigneeName(issue redmine.Issue) string {
+	if issue.AssignedTo == nil {
+		return ""
+	}
+	return issue.AssignedTo.Name
+}
+
+// after reports whether the Redmine timestamp ts is later than t; timestamps
+// that don't parse count as not later
+func after(ts string, t time.Time) bool {
+	parsed, err := time.Parse(time.RFC3339, ts)
+	return err == nil && parsed.After(t)
+}
+
+// laterTime returns the later of two Redmine timestamps, ignoring b if it
+// doesn't parse
+func laterTime(a, b string) string {
+	tb, err := time.Parse(time.RFC3339, b)
+	if err != nil {
+		return a
+	}
+	if ta, err := time.Parse(time.RFC3339, a); err == nil && !tb.After(ta) {
+		return a
+	}
+	return b
+}
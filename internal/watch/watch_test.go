@@ -0,0 +1,281 @@
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ycho/redmine-mcp-server/internal/redmine"
+)
+
+type recordingSink struct {
+	events []Event
+	fail   bool
+}
+
+func (s *recordingSink) Emit(event Event) error {
+	if s.fail {
+		return fmt.Errorf("sink down")
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+// fakeRedmine serves /issues.json honoring the updated_on >= filter and
+// /issues/{id}.json with the configured journals
+type fakeRedmine struct {
+	mu       sync.Mutex
+	issues   []map[string]any
+	journals map[int][]map[string]any
+	queries  []string
+}
+
+func (f *fakeRedmine) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.URL.Path == "/issues.json" {
+		f.queries = append(f.queries, r.URL.Query().Get("updated_on"))
+		since := strings.TrimPrefix(r.URL.Query().Get("updated_on"), ">=")
+		var matched []map[string]any
+		for _, issue := range f.issues {
+			if since == "" || issue["updated_on"].(string) >= since {
+				matched = append(matched, issue)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"issues": matched, "total_count": len(matched)})
+		return
+	}
+
+	var id int
+	if _, err := fmt.Sscanf(r.URL.Path, "/issues/%d.json", &id); err == nil {
+		for _, issue := range f.issues {
+			if issue["id"] == id {
+				detail := map[string]any{}
+				for k, v := range issue {
+					detail[k] = v
+				}
+				detail["journals"] = f.journals[id]
+				_ = json.NewEncoder(w).Encode(map[string]any{"issue": detail})
+				return
+			}
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func fakeIssue(id int, status string, statusID int, assignee string, created, updated string) map[string]any {
+	issue := map[string]any{
+		"id":         id,
+		"subject":    fmt.Sprintf("Issue %d", id),
+		"project":    map[string]any{"id": 1, "name": "Web"},
+		"status":     map[string]any{"id": statusID, "name": status},
+		"created_on": created,
+		"updated_on": updated,
+	}
+	if assignee != "" {
+		issue["assigned_to"] = map[string]any{"id": 7, "name": assignee}
+	}
+	return issue
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	fake := &fakeRedmine{
+		issues: []map[string]any{
+			fakeIssue(1, "New", 1, "", "2025-03-01T09:00:00Z", "2025-03-01T09:00:00Z"),
+			fakeIssue(2, "New", 1, "Alice", "2025-03-01T09:00:00Z", "2025-03-02T10:00:00Z"),
+		},
+		journals: map[int][]map[string]any{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	sink := &recordingSink{}
+	w, err := New(redmine.NewClient(server.URL, "test-key"), Config{StateFile: stateFile}, sink)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// First run records a baseline without reporting the backlog
+	if n, err := w.Poll(); err != nil || n != 0 || len(sink.events) != 0 {
+		t.Fatalf("expected a silent baseline, got %d events, err %v", n, err)
+	}
+	if w.state.Watermark != "2025-03-02T10:00:00Z" {
+		t.Errorf("expected watermark from the latest updated_on, got %s", w.state.Watermark)
+	}
+
+	// Issue 1 changes status and assignee and gets a comment; issue 3 is new
+	fake.mu.Lock()
+	fake.issues[0] = fakeIssue(1, "In Progress", 2, "Bob", "2025-03-01T09:00:00Z", "2025-03-03T08:00:00Z")
+	fake.issues = append(fake.issues, fakeIssue(3, "New", 1, "", "2025-03-03T07:00:00Z", "2025-03-03T07:00:00Z"))
+	fake.journals[1] = []map[string]any{
+		{"id": 10, "user": map[string]any{"name": "Old"}, "notes": "Before the baseline", "created_on": "2025-02-28T09:00:00Z"},
+		{"id": 11, "user": map[string]any{"name": "Bob"}, "notes": "", "created_on": "2025-03-03T07:59:00Z"},
+		{"id": 12, "user": map[string]any{"name": "Bob"}, "notes": "Taking this one", "created_on": "2025-03-03T08:00:00Z"},
+	}
+	fake.mu.Unlock()
+
+	n, err := w.Poll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	types := make([]string, len(sink.events))
+	for i, e := range sink.events {
+		types[i] = fmt.Sprintf("%s#%d", e.Type, e.IssueID)
+	}
+	want := "status_changed#1,assignee_changed#1,comment_added#1,issue_created#3"
+	if n != 4 || strings.Join(types, ",") != want {
+		t.Fatalf("expected %s, got %v", want, types)
+	}
+	if e := sink.events[0]; e.Old != "New" || e.New != "In Progress" {
+		t.Errorf("unexpected status change: %+v", e)
+	}
+	if e := sink.events[2]; e.User != "Bob" || e.Notes != "Taking this one" {
+		t.Errorf("unexpected comment: %+v", e)
+	}
+
+	// The query looks back by the overlap, but unchanged issues aren't reported twice
+	if got := fake.queries[len(fake.queries)-1]; got != ">=2025-03-02T09:58:00Z" {
+		t.Errorf("expected the query to start before the watermark by the overlap, got %s", got)
+	}
+	sink.events = nil
+	if n, err := w.Poll(); err != nil || n != 0 {
+		t.Errorf("expected no duplicate events, got %d (err %v)", n, err)
+	}
+
+	// State survives a restart
+	restarted, err := New(redmine.NewClient(server.URL, "test-key"), Config{StateFile: stateFile}, sink)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restarted.state.Watermark != "2025-03-03T08:00:00Z" || restarted.state.Issues["1"].Status != "In Progress" {
+		t.Errorf("unexpected reloaded state: %+v", restarted.state)
+	}
+}
+
+func TestWatcher_PollRetriesWhenSinkFails(t *testing.T) {
+	fake := &fakeRedmine{
+		issues:   []map[string]any{fakeIssue(1, "New", 1, "", "2025-03-01T09:00:00Z", "2025-03-01T09:00:00Z")},
+		journals: map[int][]map[string]any{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sink := &recordingSink{}
+	w, err := New(redmine.NewClient(server.URL, "test-key"), Config{StateFile: filepath.Join(t.TempDir(), "state.json")}, sink)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := w.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fake.issues = append(fake.issues, fakeIssue(2, "New", 1, "", "2025-03-02T09:00:00Z", "2025-03-02T09:00:00Z"))
+	sink.fail = true
+	if _, err := w.Poll(); err == nil {
+		t.Fatal("expected the sink error to be returned")
+	}
+
+	sink.fail = false
+	if n, err := w.Poll(); err != nil || n != 1 || sink.events[0].Type != EventIssueCreated {
+		t.Errorf("expected the event to be delivered on the next poll, got %d %v (err %v)", n, sink.events, err)
+	}
+}
+
+func TestWatcher_DuplicatesAndPrune(t *testing.T) {
+	fake := &fakeRedmine{
+		issues: []map[string]any{
+			fakeIssue(1, "New", 1, "", "2025-03-01T09:00:00Z", "2025-03-01T09:00:00Z"),
+			fakeIssue(2, "New", 1, "", "2025-03-01T09:00:00Z", "2025-03-01T10:00:00Z"),
+		},
+		journals: map[int][]map[string]any{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sink := &recordingSink{}
+	w, err := New(redmine.NewClient(server.URL, "test-key"), Config{StateFile: filepath.Join(t.TempDir(), "state.json")}, sink)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := w.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Issue 1 was updated while the pages were read and shows up twice
+	fake.mu.Lock()
+	fake.issues = []map[string]any{
+		fakeIssue(1, "In Progress", 2, "", "2025-03-01T09:00:00Z", "2025-03-02T09:00:00Z"),
+		fake.issues[1],
+		fakeIssue(1, "Resolved", 3, "", "2025-03-01T09:00:00Z", "2025-03-02T09:05:00Z"),
+	}
+	fake.mu.Unlock()
+	if n, err := w.Poll(); err != nil || n != 1 || sink.events[0].New != "Resolved" {
+		t.Fatalf("expected one status change to the latest copy, got %d %+v (err %v)", n, sink.events, err)
+	}
+
+	// A deleted issue stays in the state until the next prune
+	fake.mu.Lock()
+	fake.issues = fake.issues[:1]
+	fake.mu.Unlock()
+	if _, err := w.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := w.state.Issues["2"]; !ok {
+		t.Fatal("expected no prune before the interval")
+	}
+	w.state.Pruned = "2025-01-01T00:00:00Z"
+	if _, err := w.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := w.state.Issues["2"]; ok || len(w.state.Issues) != 1 {
+		t.Errorf("expected the deleted issue to be pruned, got %v", w.state.Issues)
+	}
+}
+
+func TestWatcher_EmptyBaselineUsesNow(t *testing.T) {
+	server := httptest.NewServer(&fakeRedmine{})
+	defer server.Close()
+
+	w, err := New(redmine.NewClient(server.URL, "test-key"), Config{StateFile: filepath.Join(t.TempDir(), "state.json")}, &recordingSink{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	before := time.Now().Add(-time.Second)
+	if _, err := w.Poll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !after(w.state.Watermark, before) {
+		t.Errorf("expected the watermark to default to now, got %s", w.state.Watermark)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var got Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected content type %q", r.Header.Get("Content-Type"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got.IssueID == 2 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL)
+	if err := sink.Emit(Event{Type: EventIssueCreated, IssueID: 1}); err != nil || got.IssueID != 1 {
+		t.Errorf("expected event to be posted, got %+v (err %v)", got, err)
+	}
+	if err := sink.Emit(Event{Type: EventIssueCreated, IssueID: 2}); err == nil {
+		t.Error("expected an error for a non-2xx response")
+	}
+}